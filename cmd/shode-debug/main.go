@@ -5,22 +5,31 @@ import (
 	"fmt"
 	"os"
 
+	"gitee.com/com_818cloud/shode/pkg/devtools/dap"
 	"gitee.com/com_818cloud/shode/pkg/environment"
 	"gitee.com/com_818cloud/shode/pkg/engine"
 	"gitee.com/com_818cloud/shode/pkg/module"
 	"gitee.com/com_818cloud/shode/pkg/parser"
 	"gitee.com/com_818cloud/shode/pkg/sandbox"
 	"gitee.com/com_818cloud/shode/pkg/stdlib"
+	"gitee.com/com_818cloud/shode/pkg/types"
 )
 
 func main() {
-	if len(os.Args) < 2 {
-		fmt.Fprintf(os.Stderr, "Usage: %s <script.sh>\n", os.Args[0])
+	dapMode := false
+	var scriptPath string
+	for _, arg := range os.Args[1:] {
+		if arg == "--dap" {
+			dapMode = true
+			continue
+		}
+		scriptPath = arg
+	}
+	if scriptPath == "" {
+		fmt.Fprintf(os.Stderr, "Usage: %s [--dap] <script.sh>\n", os.Args[0])
 		os.Exit(1)
 	}
 
-	scriptPath := os.Args[1]
-
 	// Setup components
 	em := environment.NewEnvironmentManager()
 	stdLib := stdlib.New()
@@ -36,6 +45,11 @@ func main() {
 		os.Exit(1)
 	}
 
+	if dapMode {
+		runDAP(ee, script)
+		return
+	}
+
 	fmt.Printf("=== Shode Debug Mode ===\n\n")
 	fmt.Printf("Script: %s\n", scriptPath)
 	fmt.Printf("Nodes: %d\n\n", len(script.Nodes))
@@ -63,3 +77,29 @@ func main() {
 
 	os.Exit(result.ExitCode)
 }
+
+// runDAP attaches a Debug Adapter Protocol server to ee over stdin/
+// stdout and runs script under its control, so a DAP client (e.g. an
+// editor) can set breakpoints and step through it as it executes.
+func runDAP(ee *engine.ExecutionEngine, script *types.ScriptNode) {
+	debugger := dap.NewDebugger()
+	ee.SetDebugHook(debugger)
+
+	served := make(chan struct{})
+	go func() {
+		defer close(served)
+		server := dap.NewServer(debugger)
+		if err := server.Serve(dap.Stdio()); err != nil {
+			fmt.Fprintf(os.Stderr, "DAP server stopped: %v\n", err)
+		}
+	}()
+
+	result, err := ee.Execute(context.Background(), script)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Execution error: %v\n", err)
+		os.Exit(1)
+	}
+
+	<-served
+	os.Exit(result.ExitCode)
+}
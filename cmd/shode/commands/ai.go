@@ -0,0 +1,249 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"gitee.com/com_818cloud/shode/pkg/agi"
+	"gitee.com/com_818cloud/shode/pkg/config"
+	"gitee.com/com_818cloud/shode/pkg/parser"
+	"gitee.com/com_818cloud/shode/pkg/sandbox"
+	"gitee.com/com_818cloud/shode/pkg/types"
+	"github.com/spf13/cobra"
+)
+
+// NewAICommand creates the 'ai' command for LLM-assisted script
+// generation and explanation, backed by the provider configured in
+// shode.toml's [agi] section (see pkg/agi).
+func NewAICommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "ai",
+		Short: "Generate or explain shode scripts using a configured LLM",
+	}
+
+	cmd.AddCommand(newAIGenerateCommand())
+	cmd.AddCommand(newAIExplainCommand())
+
+	return cmd
+}
+
+// newAIGenerateCommand creates the 'ai generate' subcommand.
+func newAIGenerateCommand() *cobra.Command {
+	var outputFile string
+
+	cmd := &cobra.Command{
+		Use:   "generate <prompt>",
+		Short: "Generate a shode script from a natural-language description",
+		Long: `Generate sends prompt, together with the project's configured entry
+scripts for context, to the LLM provider configured in shode.toml's
+[agi] section and prints (or saves) the resulting script.
+
+The generated script is parsed and run through the same
+SecurityChecker 'shode run' applies before execution - not executed
+automatically - and any parse or security findings are reported
+alongside it so they can be fixed first. This repo has no separate
+'shode check'/'shode scan' commands yet, so generate reuses the
+parser and SecurityChecker pipeline 'shode run' already performs
+rather than shelling out to commands that don't exist.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			projectCfg, err := loadProjectConfig()
+			if err != nil {
+				return fmt.Errorf("failed to load project config: %w", err)
+			}
+
+			provider, err := newAGIProvider(projectCfg)
+			if err != nil {
+				return fmt.Errorf("failed to build LLM provider: %w", err)
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 3*time.Minute)
+			defer cancel()
+
+			llm := agi.NewMultimodalLLM(provider)
+			script, err := llm.Generate(ctx, buildGeneratePrompt(projectCfg, args[0]), agi.Options{})
+			if err != nil {
+				return fmt.Errorf("failed to generate script: %w", err)
+			}
+			script = stripCodeFence(script)
+
+			if outputFile != "" {
+				if err := os.WriteFile(outputFile, []byte(script), 0644); err != nil {
+					return fmt.Errorf("failed to write %s: %w", outputFile, err)
+				}
+				fmt.Printf("wrote generated script to %s\n", outputFile)
+			} else {
+				fmt.Println(script)
+			}
+
+			for _, finding := range checkScript(script) {
+				fmt.Fprintf(os.Stderr, "warning: %s\n", finding)
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&outputFile, "output", "o", "", "write the generated script to this file instead of stdout")
+	return cmd
+}
+
+// newAIExplainCommand creates the 'ai explain' subcommand.
+func newAIExplainCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "explain <script-file>",
+		Short: "Explain an existing shode script line by line",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			source, err := os.ReadFile(args[0])
+			if err != nil {
+				return fmt.Errorf("failed to read %s: %w", args[0], err)
+			}
+
+			projectCfg, err := loadProjectConfig()
+			if err != nil {
+				return fmt.Errorf("failed to load project config: %w", err)
+			}
+
+			provider, err := newAGIProvider(projectCfg)
+			if err != nil {
+				return fmt.Errorf("failed to build LLM provider: %w", err)
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 3*time.Minute)
+			defer cancel()
+
+			prompt := fmt.Sprintf(
+				"Explain the following shode script line by line, in the order the lines appear:\n\n%s",
+				string(source),
+			)
+
+			llm := agi.NewMultimodalLLM(provider)
+			explanation, err := llm.Generate(ctx, prompt, agi.Options{})
+			if err != nil {
+				return fmt.Errorf("failed to explain script: %w", err)
+			}
+
+			fmt.Println(explanation)
+			return nil
+		},
+	}
+	return cmd
+}
+
+// buildGeneratePrompt wraps prompt with the project's configured entry
+// scripts so the model generates something that fits alongside the
+// rest of the project instead of a script written in a vacuum.
+func buildGeneratePrompt(projectCfg *config.ProjectConfig, prompt string) string {
+	var b strings.Builder
+	b.WriteString("Write a shell script for the shode runtime that accomplishes the following:\n")
+	b.WriteString(prompt)
+	b.WriteString("\n\nReturn only the script, with no surrounding explanation.")
+
+	for _, entry := range projectCfg.Entry {
+		source, err := os.ReadFile(entry)
+		if err != nil {
+			continue
+		}
+		b.WriteString(fmt.Sprintf("\n\nFor context, here is the project's existing entry script %s:\n%s", entry, string(source)))
+	}
+
+	return b.String()
+}
+
+// stripCodeFence removes a leading/trailing ``` fence some models wrap
+// generated code in, so the script written out is clean shell source
+// rather than Markdown.
+func stripCodeFence(script string) string {
+	trimmed := strings.TrimSpace(script)
+	if !strings.HasPrefix(trimmed, "```") {
+		return script
+	}
+
+	lines := strings.Split(trimmed, "\n")
+	if len(lines) < 2 {
+		return script
+	}
+	lines = lines[1:]
+	if last := len(lines) - 1; last >= 0 && strings.HasPrefix(strings.TrimSpace(lines[last]), "```") {
+		lines = lines[:last]
+	}
+	return strings.Join(lines, "\n")
+}
+
+// checkScript parses script and runs every command it contains through
+// a fresh SecurityChecker, returning one description per parse or
+// security failure. A generated script with no findings parses cleanly
+// and would pass the same checks 'shode run' performs before executing
+// it.
+func checkScript(script string) []string {
+	node, err := parser.NewParser().ParseString(script)
+	if err != nil {
+		return []string{fmt.Sprintf("failed to parse generated script: %v", err)}
+	}
+
+	var findings []string
+	security := sandbox.NewSecurityChecker()
+	walkAICommands(node.Nodes, func(cmd *types.CommandNode) {
+		if err := security.CheckCommand(cmd); err != nil {
+			findings = append(findings, fmt.Sprintf("line %d: %v", cmd.Pos.Line, err))
+		}
+	})
+	return findings
+}
+
+// walkAICommands calls fn on every CommandNode reachable from nodes,
+// including ones nested inside if/for/while bodies, pipes, and
+// &&/|| chains - mirroring pkg/optimizer's walkCommands closely enough
+// that a finding here lines up with what 'shode run' would check.
+func walkAICommands(nodes []types.Node, fn func(*types.CommandNode)) {
+	for _, n := range nodes {
+		walkAICommandsNode(n, fn)
+	}
+}
+
+func walkAICommandsNode(n types.Node, fn func(*types.CommandNode)) {
+	switch v := n.(type) {
+	case *types.CommandNode:
+		fn(v)
+	case *types.PipeNode:
+		walkAICommandsNode(v.Left, fn)
+		walkAICommandsNode(v.Right, fn)
+	case *types.AndNode:
+		walkAICommandsNode(v.Left, fn)
+		walkAICommandsNode(v.Right, fn)
+	case *types.OrNode:
+		walkAICommandsNode(v.Left, fn)
+		walkAICommandsNode(v.Right, fn)
+	case *types.BackgroundNode:
+		walkAICommandsNode(v.Command, fn)
+	case *types.HeredocNode:
+		walkAICommandsNode(v.Command, fn)
+	case *types.ScriptNode:
+		walkAICommands(v.Nodes, fn)
+	case *types.IfNode:
+		walkAICommandsNode(v.Condition, fn)
+		if v.Then != nil {
+			walkAICommands(v.Then.Nodes, fn)
+		}
+		if v.Else != nil {
+			walkAICommands(v.Else.Nodes, fn)
+		}
+	case *types.ForNode:
+		if v.Body != nil {
+			walkAICommands(v.Body.Nodes, fn)
+		}
+	case *types.WhileNode:
+		walkAICommandsNode(v.Condition, fn)
+		if v.Body != nil {
+			walkAICommands(v.Body.Nodes, fn)
+		}
+	case *types.FunctionNode:
+		if v.Body != nil {
+			walkAICommands(v.Body.Nodes, fn)
+		}
+	}
+}
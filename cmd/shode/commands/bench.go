@@ -0,0 +1,176 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gitee.com/com_818cloud/shode/pkg/engine"
+	"gitee.com/com_818cloud/shode/pkg/environment"
+	"gitee.com/com_818cloud/shode/pkg/module"
+	"gitee.com/com_818cloud/shode/pkg/output"
+	"gitee.com/com_818cloud/shode/pkg/parser"
+	"gitee.com/com_818cloud/shode/pkg/performance"
+	"gitee.com/com_818cloud/shode/pkg/sandbox"
+	"gitee.com/com_818cloud/shode/pkg/stdlib"
+	"github.com/spf13/cobra"
+)
+
+// benchResult is the --json payload for the 'bench' command.
+type benchResult struct {
+	Script     string                        `json:"script"`
+	Runs       int                           `json:"runs"`
+	Mean       string                        `json:"mean"`
+	P95        string                        `json:"p95"`
+	StdDev     string                        `json:"stdDev"`
+	Outliers   []int                         `json:"outliers"`
+	Regression *performance.RegressionReport `json:"regression,omitempty"`
+	Alloc      *performance.AllocDelta       `json:"alloc,omitempty"`
+}
+
+// NewBenchCommand creates the 'bench' command for statistically
+// benchmarking a script's execution time.
+func NewBenchCommand() *cobra.Command {
+	var runs int
+	var compare bool
+	var saveBaseline bool
+	var thresholdSigma float64
+	var jsonOutput bool
+	var memStats bool
+
+	cmd := &cobra.Command{
+		Use:   "bench [script-file]",
+		Short: "Run a script repeatedly and report timing statistics",
+		Long: `Bench executes a script file --runs times, reporting the mean, p95, and
+standard deviation of its duration and flagging statistical outliers. With
+--compare, the result is checked against a previously saved baseline and
+the command exits non-zero if the regression is statistically significant,
+so it can be wired into CI. Use --save-baseline to record the current
+result as the new baseline for future comparisons.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			scriptFile := args[0]
+			if runs < 1 {
+				return benchFail(jsonOutput, fmt.Errorf("--runs must be at least 1"))
+			}
+
+			if _, err := os.Stat(scriptFile); os.IsNotExist(err) {
+				return benchFail(jsonOutput, fmt.Errorf("script file not found: %s", scriptFile))
+			}
+
+			p := parser.NewSimpleParser()
+			script, err := p.ParseFile(scriptFile)
+			if err != nil {
+				return benchFail(jsonOutput, fmt.Errorf("failed to parse script: %v", err))
+			}
+
+			var before performance.AllocStats
+			if memStats {
+				before = performance.CaptureAllocStats()
+			}
+
+			durations := make([]time.Duration, 0, runs)
+			for i := 0; i < runs; i++ {
+				envManager := environment.NewEnvironmentManager()
+				stdLib := stdlib.New()
+				moduleMgr := module.NewModuleManager()
+				security := sandbox.NewSecurityChecker()
+				executionEngine := engine.NewExecutionEngine(envManager, stdLib, moduleMgr, security)
+
+				result, err := executionEngine.Execute(context.Background(), script)
+				if err != nil {
+					return benchFail(jsonOutput, fmt.Errorf("execution error on run %d: %v", i+1, err))
+				}
+				durations = append(durations, result.Duration)
+			}
+
+			var alloc *performance.AllocDelta
+			if memStats {
+				delta := performance.CaptureAllocStats().Since(before)
+				alloc = &delta
+			}
+
+			summary := performance.Summarize(durations)
+			scriptLabel := strings.TrimSuffix(filepath.Base(scriptFile), filepath.Ext(scriptFile))
+			store := performance.NewBenchmarkStore("")
+
+			var report *performance.RegressionReport
+			if compare {
+				baseline, ok, err := store.Load(scriptLabel)
+				if err != nil {
+					return benchFail(jsonOutput, fmt.Errorf("loading baseline: %v", err))
+				}
+				if !ok {
+					return benchFail(jsonOutput, fmt.Errorf("no saved baseline for %q; run with --save-baseline first", scriptLabel))
+				}
+				r := performance.CompareToBaseline(summary, baseline, thresholdSigma)
+				report = &r
+			}
+
+			if saveBaseline {
+				baseline := performance.NewBenchmarkBaseline(scriptLabel, summary, time.Now())
+				if err := store.Save(baseline); err != nil {
+					return benchFail(jsonOutput, fmt.Errorf("saving baseline: %v", err))
+				}
+			}
+
+			if jsonOutput {
+				if err := output.OK(benchResult{
+					Script:     scriptFile,
+					Runs:       runs,
+					Mean:       summary.Mean.String(),
+					P95:        summary.P95.String(),
+					StdDev:     summary.StdDev.String(),
+					Outliers:   summary.Outliers,
+					Regression: report,
+					Alloc:      alloc,
+				}).Write(os.Stdout); err != nil {
+					return err
+				}
+			} else {
+				fmt.Printf("Ran %s %d times\n", scriptFile, runs)
+				fmt.Printf("Mean:     %v\n", summary.Mean)
+				fmt.Printf("P95:      %v\n", summary.P95)
+				fmt.Printf("StdDev:   %v\n", summary.StdDev)
+				if len(summary.Outliers) > 0 {
+					fmt.Printf("Outliers: %v\n", summary.Outliers)
+				}
+				if report != nil {
+					fmt.Printf("\n%s (z=%.2f, %+.1f%% vs baseline)\n", report.Detail, report.ZScore, report.PercentOff)
+				}
+				if alloc != nil {
+					fmt.Printf("\nAllocated: %d bytes, %d mallocs, %d GC cycles over %d runs\n", alloc.AllocBytes, alloc.Mallocs, alloc.NumGC, runs)
+				}
+				if saveBaseline {
+					fmt.Println("\nSaved as new baseline.")
+				}
+			}
+
+			if report != nil && report.Regressed {
+				return fmt.Errorf("performance regression detected for %s", scriptLabel)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().IntVar(&runs, "runs", 10, "number of times to execute the script")
+	cmd.Flags().BoolVar(&compare, "compare", false, "compare the result against the saved baseline and fail on regression")
+	cmd.Flags().BoolVar(&saveBaseline, "save-baseline", false, "save this result as the new baseline")
+	cmd.Flags().Float64Var(&thresholdSigma, "threshold-sigma", 2.0, "number of standard errors beyond the baseline mean required to flag a regression")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "emit machine-readable JSON output")
+	cmd.Flags().BoolVar(&memStats, "mem-stats", false, "report allocation counters across all runs, useful for measuring the effect of allocation-reducing changes")
+
+	return cmd
+}
+
+// benchFail reports err either as a JSON envelope on stdout or as a
+// plain Go error, depending on whether --json was requested.
+func benchFail(jsonOutput bool, err error) error {
+	if jsonOutput {
+		return output.Err(err).Write(os.Stdout)
+	}
+	return err
+}
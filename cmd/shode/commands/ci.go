@@ -0,0 +1,173 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"gitee.com/com_818cloud/shode/pkg/ci"
+	"gitee.com/com_818cloud/shode/pkg/output"
+	"gitee.com/com_818cloud/shode/pkg/sandbox"
+	"github.com/spf13/cobra"
+)
+
+// jobResultPayload is the --json representation of a single ci.JobResult.
+type jobResultPayload struct {
+	Stage     string            `json:"stage"`
+	Job       string            `json:"job"`
+	Matrix    map[string]string `json:"matrix,omitempty"`
+	Success   bool              `json:"success"`
+	ExitCode  int               `json:"exitCode"`
+	Duration  string            `json:"duration"`
+	Output    string            `json:"output,omitempty"`
+	Error     string            `json:"error,omitempty"`
+	Artifacts []string          `json:"artifacts,omitempty"`
+}
+
+// ciRunResult is the --json payload for the 'ci run' command.
+type ciRunResult struct {
+	Pipeline string             `json:"pipeline"`
+	Success  bool               `json:"success"`
+	Jobs     []jobResultPayload `json:"jobs"`
+}
+
+// NewCICommand creates the 'ci' command and its subcommands for
+// running a shode-ci.toml pipeline.
+func NewCICommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "ci",
+		Short: "Run a shode-ci.toml pipeline",
+	}
+
+	cmd.AddCommand(newCIRunCommand())
+
+	return cmd
+}
+
+func newCIRunCommand() *cobra.Command {
+	var pipelinePath string
+	var concurrency int
+	var jsonOutput bool
+
+	cmd := &cobra.Command{
+		Use:   "run",
+		Short: "Run every stage and job in a pipeline file",
+		Long: `Run loads a shode-ci.toml pipeline and executes its stages in order. Jobs
+within a stage (after matrix expansion) run concurrently, bounded by
+--concurrency. A stage with any failed job stops the pipeline before the
+next stage starts. Each job runs through the same execution engine and
+[sandbox] policy "shode run" uses, so a pipeline behaves the same locally
+and in whatever CI provider invokes it.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			projectDir, err := os.Getwd()
+			if err != nil {
+				if jsonOutput {
+					return output.Err(err).Write(os.Stdout)
+				}
+				return err
+			}
+
+			pipeline, err := ci.LoadPipeline(pipelinePath)
+			if err != nil {
+				if jsonOutput {
+					return output.Err(err).Write(os.Stdout)
+				}
+				return err
+			}
+
+			security := sandbox.NewSecurityChecker()
+			if projectCfg, err := loadProjectConfig(); err == nil {
+				if len(projectCfg.Sandbox.DockerAllow) > 0 {
+					security.AllowDockerOperations(projectCfg.Sandbox.DockerAllow)
+				}
+				if len(projectCfg.Sandbox.KubernetesAllow) > 0 {
+					security.AllowKubernetesOperations(projectCfg.Sandbox.KubernetesAllow)
+				}
+				if len(projectCfg.Sandbox.GitAllow) > 0 {
+					security.AllowGitOperations(projectCfg.Sandbox.GitAllow)
+				}
+				if len(projectCfg.Sandbox.ObjectAllow) > 0 {
+					security.AllowObjectOperations(projectCfg.Sandbox.ObjectAllow)
+				}
+				if len(projectCfg.Sandbox.SSHAllow) > 0 {
+					security.AllowSSHOperations(projectCfg.Sandbox.SSHAllow)
+				}
+				if len(projectCfg.Sandbox.PowerShellAllow) > 0 {
+					security.AllowPowerShellOperations(projectCfg.Sandbox.PowerShellAllow)
+				}
+				if len(projectCfg.Sandbox.ResourceAllow) > 0 {
+					security.AllowResourceOperations(projectCfg.Sandbox.ResourceAllow)
+				}
+			}
+
+			runner := ci.NewRunner(projectDir, security, concurrency)
+			results, runErr := runner.Run(cmd.Context(), pipeline)
+
+			if jsonOutput {
+				payload := ciRunResult{Pipeline: pipelinePath, Success: runErr == nil}
+				for _, r := range results {
+					payload.Jobs = append(payload.Jobs, jobResultPayload{
+						Stage:     r.Stage,
+						Job:       r.Job,
+						Matrix:    r.Matrix,
+						Success:   r.Success,
+						ExitCode:  r.ExitCode,
+						Duration:  r.Duration.String(),
+						Output:    r.Output,
+						Error:     r.Error,
+						Artifacts: r.Artifacts,
+					})
+				}
+				envelope := output.OK(payload)
+				if runErr != nil {
+					envelope.Status = output.StatusError
+				}
+				if err := envelope.Write(os.Stdout); err != nil {
+					return err
+				}
+				return runErr
+			}
+
+			for _, r := range results {
+				status := "PASS"
+				if !r.Success {
+					status = "FAIL"
+				}
+				fmt.Printf("[%s] %s / %s%s - exit %d (%v)\n", status, r.Stage, r.Job, matrixLabel(r.Matrix), r.ExitCode, r.Duration)
+				if r.Output != "" {
+					fmt.Printf("%s\n", r.Output)
+				}
+				if r.Error != "" {
+					fmt.Printf("%s\n", r.Error)
+				}
+				for _, artifact := range r.Artifacts {
+					fmt.Printf("  artifact: %s\n", artifact)
+				}
+			}
+
+			return runErr
+		},
+	}
+
+	cmd.Flags().StringVar(&pipelinePath, "pipeline", ci.PipelineConfigFile, "path to the pipeline file")
+	cmd.Flags().IntVar(&concurrency, "concurrency", 1, "max jobs to run at once within a stage")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "emit machine-readable JSON output")
+
+	return cmd
+}
+
+// matrixLabel renders matrix as "[key=value, ...]", or "" when empty.
+func matrixLabel(matrix map[string]string) string {
+	if len(matrix) == 0 {
+		return ""
+	}
+	label := " ["
+	first := true
+	for k, v := range matrix {
+		if !first {
+			label += ", "
+		}
+		label += k + "=" + v
+		first = false
+	}
+	return label + "]"
+}
@@ -0,0 +1,128 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"gitee.com/com_818cloud/shode/pkg/daemon"
+	"github.com/spf13/cobra"
+)
+
+// NewDaemonCommand creates the 'daemon' command and its subcommands for
+// running and talking to a warm Shode runtime.
+func NewDaemonCommand() *cobra.Command {
+	var socketPath string
+
+	cmd := &cobra.Command{
+		Use:   "daemon",
+		Short: "Run or control a warm Shode runtime",
+		Long: `Daemon keeps a Shode runtime (standard library, module cache, execution
+engine) warm in the background and exposes a control API over a local Unix
+socket, so tools that invoke shode frequently can submit scripts without
+paying process-startup cost each time.`,
+	}
+
+	cmd.PersistentFlags().StringVar(&socketPath, "socket", "", "path to the daemon's control socket (default: ~/.shode.sock)")
+
+	cmd.AddCommand(newDaemonStartCommand(&socketPath))
+	cmd.AddCommand(newDaemonStatusCommand(&socketPath))
+	cmd.AddCommand(newDaemonSubmitCommand(&socketPath))
+
+	return cmd
+}
+
+func newDaemonStartCommand(socketPath *string) *cobra.Command {
+	return &cobra.Command{
+		Use:   "start",
+		Short: "Start the daemon in the foreground",
+		Long:  `Start runs the daemon in the foreground until interrupted with Ctrl+C.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			d := daemon.New(*socketPath)
+
+			sigChan := make(chan os.Signal, 1)
+			signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
+
+			errChan := make(chan error, 1)
+			go func() { errChan <- d.Serve() }()
+
+			addr := *socketPath
+			if addr == "" {
+				addr = daemon.DefaultSocketPath()
+			}
+			fmt.Printf("shode daemon listening on %s\n", addr)
+
+			select {
+			case err := <-errChan:
+				return err
+			case <-sigChan:
+				fmt.Println("\nShutting down daemon...")
+				ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+				defer cancel()
+				return d.Shutdown(ctx)
+			}
+		},
+	}
+}
+
+func newDaemonStatusCommand(socketPath *string) *cobra.Command {
+	return &cobra.Command{
+		Use:   "status",
+		Short: "Check whether the daemon is running",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client := daemon.NewClient(*socketPath)
+			status, err := client.Ping()
+			if err != nil {
+				return err
+			}
+			fmt.Printf("Daemon is up. Uptime: %s  Jobs tracked: %d\n", status.Uptime, status.JobCount)
+			return nil
+		},
+	}
+}
+
+func newDaemonSubmitCommand(socketPath *string) *cobra.Command {
+	var wait bool
+
+	cmd := &cobra.Command{
+		Use:   "submit [script-file]",
+		Short: "Submit a script to the running daemon",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client := daemon.NewClient(*socketPath)
+			job, err := client.Submit(args[0])
+			if err != nil {
+				return err
+			}
+
+			if !wait {
+				fmt.Printf("Submitted job %d\n", job.ID)
+				return nil
+			}
+
+			for job.Status == daemon.JobRunning {
+				time.Sleep(100 * time.Millisecond)
+				job, err = client.JobStatus(job.ID)
+				if err != nil {
+					return err
+				}
+			}
+
+			fmt.Printf("Job %d finished: %s (exit %d)\n", job.ID, job.Status, job.ExitCode)
+			if job.Output != "" {
+				fmt.Printf("\nOutput:\n%s\n", job.Output)
+			}
+			if job.Error != "" {
+				fmt.Printf("\nErrors:\n%s\n", job.Error)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&wait, "wait", false, "wait for the job to finish and print its result")
+
+	return cmd
+}
@@ -0,0 +1,315 @@
+package commands
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"strings"
+
+	"gitee.com/com_818cloud/shode/pkg/database"
+	dbmigrate "gitee.com/com_818cloud/shode/pkg/database/migrate"
+	"gitee.com/com_818cloud/shode/pkg/engine"
+	"gitee.com/com_818cloud/shode/pkg/environment"
+	"gitee.com/com_818cloud/shode/pkg/module"
+	"gitee.com/com_818cloud/shode/pkg/output"
+	"gitee.com/com_818cloud/shode/pkg/parser"
+	"gitee.com/com_818cloud/shode/pkg/sandbox"
+	"gitee.com/com_818cloud/shode/pkg/stdlib"
+	"github.com/spf13/cobra"
+)
+
+// dbCLIDrivers maps the --driver value accepted by 'shode db' to the
+// database/sql driver name it's registered under, matching the
+// aliases ConnectDB accepts from scripts.
+var dbCLIDrivers = map[string]string{
+	"sqlite":     "sqlite3",
+	"sqlite3":    "sqlite3",
+	"postgres":   "postgres",
+	"postgresql": "postgres",
+	"mysql":      "mysql",
+}
+
+// NewDBCommand creates the 'db' command and its subcommands for
+// managing a project's database schema.
+func NewDBCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "db",
+		Short: "Manage a project's database schema",
+	}
+
+	cmd.AddCommand(newDBMigrateCommand())
+
+	return cmd
+}
+
+func newDBMigrateCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "Apply, roll back, and inspect schema migrations",
+		Long: `Migrate manages versioned schema changes read from a migrations
+directory. Each migration is a pair of files named <version>_<name>.up.<ext>
+and <version>_<name>.down.<ext>, where <ext> is either "sql" (plain SQL,
+run directly against the connection) or "shode" (a Shode script, run
+through the engine - useful for migrations that need more than SQL, like
+backfilling data with the standard library's HTTP or file builtins).
+
+Applied versions are tracked in a schema_migrations table, created
+automatically on first use.`,
+	}
+
+	cmd.AddCommand(newDBMigrateUpCommand())
+	cmd.AddCommand(newDBMigrateDownCommand())
+	cmd.AddCommand(newDBMigrateStatusCommand())
+	cmd.AddCommand(newDBMigrateCreateCommand())
+
+	return cmd
+}
+
+// migrateConnFlags holds the flags shared by up/down/status for
+// connecting to the target database and locating the migrations
+// directory.
+type migrateConnFlags struct {
+	dir    string
+	driver string
+	dsn    string
+}
+
+func (f *migrateConnFlags) register(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&f.dir, "dir", "migrations", "migrations directory")
+	cmd.Flags().StringVar(&f.driver, "driver", "sqlite", "database driver (sqlite, postgres, mysql)")
+	cmd.Flags().StringVar(&f.dsn, "dsn", "", "data source name (required)")
+}
+
+// openMigrator opens the database connection named by f, loads every
+// migration in f.dir, and wires a script runner so "shode"-language
+// migrations run through a real engine against the same connection
+// SQL migrations use.
+func openMigrator(f *migrateConnFlags) (*dbmigrate.Migrator, *sql.DB, error) {
+	if f.dsn == "" {
+		return nil, nil, fmt.Errorf("--dsn is required")
+	}
+
+	driver, ok := dbCLIDrivers[strings.ToLower(f.driver)]
+	if !ok {
+		return nil, nil, fmt.Errorf("unsupported database driver: %s", f.driver)
+	}
+	dsn := database.NormalizeDSN(driver, f.dsn)
+
+	db, err := sql.Open(driver, dsn)
+	if err != nil {
+		return nil, nil, fmt.Errorf("opening database: %w", err)
+	}
+	if err := db.Ping(); err != nil {
+		db.Close()
+		return nil, nil, fmt.Errorf("connecting to database: %w", err)
+	}
+
+	m := dbmigrate.NewMigrator(&dbmigrate.Config{DB: db, Dialect: driver})
+	if err := m.Init(); err != nil {
+		db.Close()
+		return nil, nil, fmt.Errorf("initializing migrations table: %w", err)
+	}
+	if err := m.LoadMigrationsFromDir(f.dir); err != nil {
+		db.Close()
+		return nil, nil, fmt.Errorf("loading migrations: %w", err)
+	}
+	m.SetScriptRunner(newMigrationScriptRunner(driver, dsn, db))
+
+	return m, db, nil
+}
+
+// newMigrationScriptRunner returns a function that runs a shode
+// migration script through a real execution engine, sharing the
+// connection already open for SQL migrations so ExecDB/QueryDB in
+// the script see the same database and transaction-free state.
+func newMigrationScriptRunner(driver, dsn string, db *sql.DB) func(script string) error {
+	return func(script string) error {
+		treeParser := parser.NewParser()
+		scriptNode, err := treeParser.ParseString(script)
+		if err != nil {
+			return fmt.Errorf("parsing migration script: %w", err)
+		}
+
+		stdLib := stdlib.New()
+		if err := stdLib.DBManager().RegisterConnection("default", &database.Connection{
+			Name:   "default",
+			Driver: driver,
+			DSN:    dsn,
+			DB:     db,
+			Status: "connected",
+		}); err != nil {
+			return err
+		}
+
+		executionEngine := engine.NewExecutionEngine(environment.NewEnvironmentManager(), stdLib, module.NewModuleManager(), sandbox.NewSecurityChecker())
+		result, err := executionEngine.Execute(context.Background(), scriptNode)
+		if err != nil {
+			return err
+		}
+		if !result.Success {
+			return fmt.Errorf("migration script failed: %s", result.Output)
+		}
+		return nil
+	}
+}
+
+func newDBMigrateUpCommand() *cobra.Command {
+	var flags migrateConnFlags
+	var jsonOutput bool
+
+	cmd := &cobra.Command{
+		Use:   "up",
+		Short: "Apply all pending migrations",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			m, db, err := openMigrator(&flags)
+			if err != nil {
+				if jsonOutput {
+					return output.Err(err).Write(os.Stdout)
+				}
+				return err
+			}
+			defer db.Close()
+
+			if err := m.Up(); err != nil {
+				if jsonOutput {
+					return output.Err(err).Write(os.Stdout)
+				}
+				return err
+			}
+
+			if jsonOutput {
+				version, _ := m.CurrentVersion()
+				return output.OK(map[string]any{"currentVersion": version}).Write(os.Stdout)
+			}
+			fmt.Println("Migrations applied")
+			return nil
+		},
+	}
+
+	flags.register(cmd)
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "emit machine-readable JSON output")
+	return cmd
+}
+
+func newDBMigrateDownCommand() *cobra.Command {
+	var flags migrateConnFlags
+	var jsonOutput bool
+
+	cmd := &cobra.Command{
+		Use:   "down",
+		Short: "Roll back the most recently applied migration",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			m, db, err := openMigrator(&flags)
+			if err != nil {
+				if jsonOutput {
+					return output.Err(err).Write(os.Stdout)
+				}
+				return err
+			}
+			defer db.Close()
+
+			if err := m.Down(); err != nil {
+				if jsonOutput {
+					return output.Err(err).Write(os.Stdout)
+				}
+				return err
+			}
+
+			if jsonOutput {
+				version, _ := m.CurrentVersion()
+				return output.OK(map[string]any{"currentVersion": version}).Write(os.Stdout)
+			}
+			fmt.Println("Rolled back the most recent migration")
+			return nil
+		},
+	}
+
+	flags.register(cmd)
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "emit machine-readable JSON output")
+	return cmd
+}
+
+// migrateStatusResult is the --json payload for 'db migrate status'.
+type migrateStatusResult struct {
+	Current uint                  `json:"current"`
+	Applied []migrateStatusRecord `json:"applied"`
+	Pending []migrateStatusRecord `json:"pending"`
+}
+
+type migrateStatusRecord struct {
+	Version uint   `json:"version"`
+	Name    string `json:"name"`
+}
+
+func newDBMigrateStatusCommand() *cobra.Command {
+	var flags migrateConnFlags
+	var jsonOutput bool
+
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "Show applied and pending migrations",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			m, db, err := openMigrator(&flags)
+			if err != nil {
+				if jsonOutput {
+					return output.Err(err).Write(os.Stdout)
+				}
+				return err
+			}
+			defer db.Close()
+
+			status, err := m.Status()
+			if err != nil {
+				if jsonOutput {
+					return output.Err(err).Write(os.Stdout)
+				}
+				return err
+			}
+
+			if jsonOutput {
+				return output.OK(migrateStatusResult{
+					Current: status.Current,
+					Applied: toStatusRecords(status.Applied),
+					Pending: toStatusRecords(status.Pending),
+				}).Write(os.Stdout)
+			}
+
+			return m.PrintStatus()
+		},
+	}
+
+	flags.register(cmd)
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "emit machine-readable JSON output")
+	return cmd
+}
+
+func toStatusRecords(migrations []*dbmigrate.Migration) []migrateStatusRecord {
+	records := make([]migrateStatusRecord, len(migrations))
+	for i, migration := range migrations {
+		records[i] = migrateStatusRecord{Version: migration.Version, Name: migration.Name}
+	}
+	return records
+}
+
+func newDBMigrateCreateCommand() *cobra.Command {
+	var dir string
+	var lang string
+
+	cmd := &cobra.Command{
+		Use:   "create [name]",
+		Short: "Scaffold a new pair of migration files",
+		Long: `Create writes an empty up/down pair of migration files to --dir,
+numbered one past the highest version already there. Use --lang shode to
+scaffold a Shode script migration instead of plain SQL.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			m := dbmigrate.NewMigrator(&dbmigrate.Config{})
+			return m.Create(dir, args[0], lang)
+		},
+	}
+
+	cmd.Flags().StringVar(&dir, "dir", "migrations", "migrations directory")
+	cmd.Flags().StringVar(&lang, "lang", "sql", "migration language (sql, shode)")
+	return cmd
+}
@@ -0,0 +1,362 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"gitee.com/com_818cloud/shode/pkg/serverless"
+	"github.com/spf13/cobra"
+)
+
+// NewFnCommand creates the 'fn' command and its subcommands for
+// working with Shode serverless functions.
+func NewFnCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "fn",
+		Short: "Deploy and invoke Shode serverless functions",
+		Long: `Fn manages versioned Shode serverless functions: a script file becomes a
+Function that can be deployed under a version ID and invoked by name,
+with support for splitting traffic between versions for canary rollouts.`,
+	}
+
+	cmd.AddCommand(newFnDeployCommand())
+	cmd.AddCommand(newFnDevCommand())
+	cmd.AddCommand(newFnLogsCommand())
+
+	return cmd
+}
+
+func newFnDeployCommand() *cobra.Command {
+	var version string
+	var canary string
+	var rollbackErrorRate float64
+	var rollbackMinSamples int
+
+	cmd := &cobra.Command{
+		Use:   "deploy [script-file]",
+		Short: "Deploy a script as a version of a serverless function",
+		Long: `Deploy registers a script file as --version of the named function and
+invokes it once to demonstrate the result. Without --canary it becomes the
+sole stable version, receiving all traffic. With --canary N%, it's deployed
+alongside the current stable version, receiving N percent of invocations;
+if its error rate crosses --rollback-error-rate after --rollback-min-samples
+invocations, traffic is automatically reverted to stable.
+
+There's no persistent serverless platform behind this command yet, so
+deploy runs in-process for the duration of a single invocation: it builds a
+fresh VersionManager, deploys the function, runs one sample invocation
+through the configured traffic split, and prints the resulting routing
+table. It's meant to exercise and demonstrate the versioning behavior, not
+to stand up a long-lived service.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			scriptFile := args[0]
+			if version == "" {
+				return fmt.Errorf("--version is required")
+			}
+
+			code, err := os.ReadFile(scriptFile)
+			if err != nil {
+				return fmt.Errorf("reading script file: %w", err)
+			}
+
+			functionName := strings.TrimSuffix(scriptFile, ".sh")
+
+			scheduler := serverless.NewInvocationScheduler()
+			vm := serverless.NewVersionManager(scheduler, serverless.RollbackPolicy{
+				MinSamples:         rollbackMinSamples,
+				ErrorRateThreshold: rollbackErrorRate,
+			})
+
+			if canary == "" {
+				if err := vm.Deploy(functionName, version, string(code)); err != nil {
+					return fmt.Errorf("deploy: %w", err)
+				}
+			} else {
+				weight, err := parseCanaryWeight(canary)
+				if err != nil {
+					return err
+				}
+				if err := vm.Deploy(functionName, "stable", `true`); err != nil {
+					return fmt.Errorf("deploy: %w", err)
+				}
+				if err := vm.Canary(functionName, version, string(code), weight); err != nil {
+					return fmt.Errorf("canary deploy: %w", err)
+				}
+			}
+
+			inv, servedBy, err := vm.Invoke(functionName, nil)
+			if err != nil {
+				return fmt.Errorf("invoke: %w", err)
+			}
+
+			fmt.Printf("Deployed %s@%s\n", functionName, version)
+			fmt.Printf("Sample invocation served by: %s (status: %s)\n", servedBy, inv.Status)
+			if inv.Output != "" {
+				fmt.Printf("Output:\n%s", inv.Output)
+			}
+
+			routes, err := vm.Routes(functionName)
+			if err != nil {
+				return fmt.Errorf("routes: %w", err)
+			}
+			fmt.Println("Traffic split:")
+			for id, weight := range routes {
+				fmt.Printf("  %s: %d%%\n", id, weight)
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&version, "version", "", "version ID to deploy this script as (required)")
+	cmd.Flags().StringVar(&canary, "canary", "", "deploy as a canary receiving this percentage of traffic, e.g. 10%")
+	cmd.Flags().Float64Var(&rollbackErrorRate, "rollback-error-rate", 0.5, "error rate above which a canary is automatically rolled back")
+	cmd.Flags().IntVar(&rollbackMinSamples, "rollback-min-samples", 10, "minimum canary invocations before its error rate is evaluated for rollback")
+
+	return cmd
+}
+
+// newFnDevCommand creates the 'dev' subcommand, a local serverless
+// emulator for one function.
+func newFnDevCommand() *cobra.Command {
+	var interval time.Duration
+	var watch bool
+	var logDir string
+	var logRetention time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "dev [script-file]",
+		Short: "Run a function locally with hot reload and simulated triggers",
+		Long: `Dev runs a script file as a serverless function in-process, invoking it
+on a simulated trigger every --interval and re-loading the script from disk
+whenever it changes, so edits take effect without restarting. A refreshing
+terminal view shows recent invocations, their status, and output, giving
+the same inspection you'd get against the real platform.
+
+It uses the same InvocationScheduler, parser, and execution engine the
+platform uses to serve real invocations, so behavior here has parity with
+a real deployment. Press Ctrl+C to stop.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			scriptFile := args[0]
+			if _, err := os.Stat(scriptFile); err != nil {
+				return fmt.Errorf("script file not found: %s", scriptFile)
+			}
+			functionName := strings.TrimSuffix(scriptFile, ".sh")
+
+			scheduler := serverless.NewInvocationScheduler()
+			lastMod, err := reloadFnDevScript(scheduler, functionName, scriptFile)
+			if err != nil {
+				return err
+			}
+
+			lm, err := serverless.NewLogManager(logDir, logRetention)
+			if err != nil {
+				return fmt.Errorf("setting up log storage: %w", err)
+			}
+			scheduler.SetLogManager(lm)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			trigger := serverless.NewIntervalTrigger(scheduler, functionName, interval, nil)
+			go trigger.Run(ctx)
+
+			sigChan := make(chan os.Signal, 1)
+			signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
+
+			redraw := time.NewTicker(500 * time.Millisecond)
+			defer redraw.Stop()
+
+			for {
+				select {
+				case <-sigChan:
+					return nil
+				case <-redraw.C:
+					if watch {
+						if mod, changed := fnDevScriptChanged(scriptFile, lastMod); changed {
+							if _, err := reloadFnDevScript(scheduler, functionName, scriptFile); err != nil {
+								fmt.Fprintf(os.Stderr, "reload failed: %v\n", err)
+							} else {
+								lastMod = mod
+							}
+						}
+					}
+					fmt.Print("\033[H\033[2J")
+					fmt.Print(renderFnDevView(scheduler, functionName, lastMod))
+				}
+			}
+		},
+	}
+
+	cmd.Flags().DurationVar(&interval, "interval", 2*time.Second, "how often the simulated trigger invokes the function")
+	cmd.Flags().BoolVar(&watch, "watch", true, "reload the script from disk when it changes")
+	cmd.Flags().StringVar(&logDir, "log-dir", "", "directory to persist invocation logs to (default: "+serverless.DefaultLogDir()+")")
+	cmd.Flags().DurationVar(&logRetention, "log-retention", 24*time.Hour, "how long to keep invocation logs before they're pruned; 0 keeps them forever")
+
+	return cmd
+}
+
+// newFnLogsCommand creates the 'logs' subcommand, which reads the
+// invocation logs a running 'fn dev' (or deploy) has persisted for a
+// function, without needing to be attached to that process.
+func newFnLogsCommand() *cobra.Command {
+	var logDir string
+	var follow bool
+	var tail int
+
+	cmd := &cobra.Command{
+		Use:   "logs [function-name]",
+		Short: "Show or follow a function's persisted invocation logs",
+		Long: `Logs reads the invocation log entries a LogManager has persisted for
+function-name - written by a 'fn dev' or 'fn deploy' run configured with the
+same --log-dir - and prints them. With --follow, it keeps polling for new
+entries and prints them as they arrive, the way "shode daemon" jobs are
+polled rather than pushed, so it works against a log directory being
+written by a separate process.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			functionName := args[0]
+
+			lm, err := serverless.NewLogManager(logDir, 0)
+			if err != nil {
+				return fmt.Errorf("opening log storage: %w", err)
+			}
+
+			all, err := lm.Tail(functionName, 0)
+			if err != nil {
+				return fmt.Errorf("reading logs: %w", err)
+			}
+			shown := all
+			if tail > 0 && len(shown) > tail {
+				shown = shown[len(shown)-tail:]
+			}
+			for _, entry := range shown {
+				printFnLogEntry(entry)
+			}
+
+			if !follow {
+				return nil
+			}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			sigChan := make(chan os.Signal, 1)
+			signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
+			go func() {
+				<-sigChan
+				cancel()
+			}()
+
+			stream := lm.Follow(ctx, functionName)
+			for range all {
+				if _, ok := <-stream; !ok {
+					return nil // logs were pruned out from under us; nothing left to skip
+				}
+			}
+			for entry := range stream {
+				printFnLogEntry(entry)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&logDir, "log-dir", "", "directory invocation logs were persisted to (default: "+serverless.DefaultLogDir()+")")
+	cmd.Flags().BoolVar(&follow, "follow", false, "keep polling for and printing new log entries")
+	cmd.Flags().IntVar(&tail, "tail", 20, "number of most recent entries to show; 0 shows all retained entries")
+
+	return cmd
+}
+
+// printFnLogEntry writes one LogEntry in a single-line, human-readable
+// form.
+func printFnLogEntry(entry serverless.LogEntry) {
+	output := strings.TrimSpace(entry.Output)
+	fmt.Printf("[%s] #%d %s %s\n", entry.Timestamp.Format("15:04:05"), entry.InvocationID, entry.Status, output)
+	if entry.Error != "" {
+		fmt.Printf("  error: %s\n", entry.Error)
+	}
+}
+
+// reloadFnDevScript (re-)registers functionName from scriptFile and
+// returns the file's current modification time.
+func reloadFnDevScript(scheduler *serverless.InvocationScheduler, functionName, scriptFile string) (time.Time, error) {
+	info, err := os.Stat(scriptFile)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("reading script file: %w", err)
+	}
+	code, err := os.ReadFile(scriptFile)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("reading script file: %w", err)
+	}
+	scheduler.RegisterFunction(serverless.NewFunction(functionName, string(code)))
+	return info.ModTime(), nil
+}
+
+// fnDevScriptChanged reports whether scriptFile's modification time has
+// advanced past lastMod.
+func fnDevScriptChanged(scriptFile string, lastMod time.Time) (time.Time, bool) {
+	info, err := os.Stat(scriptFile)
+	if err != nil {
+		return lastMod, false
+	}
+	if info.ModTime().After(lastMod) {
+		return info.ModTime(), true
+	}
+	return lastMod, false
+}
+
+// renderFnDevView draws the dev emulator's terminal view: the function
+// being served and its most recent invocations.
+func renderFnDevView(scheduler *serverless.InvocationScheduler, functionName string, lastMod time.Time) string {
+	var b strings.Builder
+	fmt.Fprintln(&b, "=== Shode Fn Dev ===")
+	fmt.Fprintf(&b, "Function: %s  (reloaded %s)\n\n", functionName, lastMod.Format("15:04:05"))
+
+	fmt.Fprintln(&b, "-- Recent Invocations --")
+	var invocations []*serverless.Invocation
+	for id := 1; ; id++ {
+		inv, ok := scheduler.GetInvocation(id)
+		if !ok {
+			break
+		}
+		invocations = append(invocations, inv)
+	}
+	const maxShown = 20
+	if len(invocations) > maxShown {
+		invocations = invocations[len(invocations)-maxShown:]
+	}
+	if len(invocations) == 0 {
+		fmt.Fprintln(&b, "(none yet)")
+	}
+	for _, inv := range invocations {
+		output := strings.TrimSpace(inv.Output)
+		if len(output) > 60 {
+			output = output[:60] + "..."
+		}
+		fmt.Fprintf(&b, "  #%-4d %-9s %-8v %s\n", inv.ID, inv.Status, inv.Duration(), output)
+	}
+
+	return b.String()
+}
+
+// parseCanaryWeight parses a --canary value like "10%" or "10" into a
+// percentage in [0, 100].
+func parseCanaryWeight(s string) (int, error) {
+	weight, err := strconv.Atoi(strings.TrimSuffix(s, "%"))
+	if err != nil {
+		return 0, fmt.Errorf("--canary must be a percentage like 10%%, got %q", s)
+	}
+	if weight < 0 || weight > 100 {
+		return 0, fmt.Errorf("--canary must be between 0%% and 100%%, got %d%%", weight)
+	}
+	return weight, nil
+}
@@ -0,0 +1,48 @@
+package commands
+
+import (
+	"fmt"
+
+	"gitee.com/com_818cloud/shode/pkg/graph"
+	"github.com/spf13/cobra"
+)
+
+// NewGraphCommand creates the 'graph' command for visualizing script and
+// module dependencies.
+func NewGraphCommand() *cobra.Command {
+	var format string
+
+	cmd := &cobra.Command{
+		Use:   "graph [script-file]",
+		Short: "Visualize script and module dependencies",
+		Long: `Graph follows Source statements from the given script to build a
+dependency graph, rendered as DOT, JSON, or Mermaid.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			g, err := graph.BuildScriptGraph(args[0])
+			if err != nil {
+				return fmt.Errorf("failed to build dependency graph: %v", err)
+			}
+
+			switch format {
+			case "dot":
+				fmt.Print(g.ToDOT())
+			case "mermaid":
+				fmt.Print(g.ToMermaid())
+			case "json":
+				out, err := g.ToJSON()
+				if err != nil {
+					return fmt.Errorf("failed to render JSON: %v", err)
+				}
+				fmt.Println(out)
+			default:
+				return fmt.Errorf("unknown format %q (want dot, json, or mermaid)", format)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&format, "format", "dot", "output format: dot, json, or mermaid")
+
+	return cmd
+}
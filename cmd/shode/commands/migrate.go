@@ -0,0 +1,99 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"sort"
+
+	"gitee.com/com_818cloud/shode/pkg/migrate"
+	"gitee.com/com_818cloud/shode/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+// NewMigrateCommand creates the 'migrate' command for analyzing bash
+// scripts for Shode compatibility.
+func NewMigrateCommand() *cobra.Command {
+	var fix bool
+	var writeTo string
+	var jsonOutput bool
+
+	cmd := &cobra.Command{
+		Use:   "migrate [script-file]",
+		Short: "Analyze a bash script for Shode compatibility",
+		Long: `Migrate parses an existing bash script, reports constructs Shode doesn't
+support yet, and suggests safe builtin replacements (e.g. cp -> CopyFile).
+With --fix, trivially convertible patterns are rewritten automatically.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			scriptFile := args[0]
+			source, err := os.ReadFile(scriptFile)
+			if err != nil {
+				return fmt.Errorf("failed to read script: %v", err)
+			}
+
+			report, err := migrate.Analyze(scriptFile, string(source))
+			if err != nil {
+				return fmt.Errorf("failed to analyze script: %v", err)
+			}
+
+			var rewrittenTo string
+			if fix {
+				rewritten := migrate.RewriteScript(string(source))
+				rewrittenTo = writeTo
+				if rewrittenTo == "" {
+					rewrittenTo = scriptFile
+				}
+				if err := os.WriteFile(rewrittenTo, []byte(rewritten), 0644); err != nil {
+					return fmt.Errorf("failed to write rewritten script: %v", err)
+				}
+			}
+
+			if jsonOutput {
+				return output.OK(migrateResult{Report: report, RewrittenTo: rewrittenTo}).Write(os.Stdout)
+			}
+
+			printReport(report)
+			if rewrittenTo != "" {
+				fmt.Printf("\nRewrote trivially convertible patterns to %s\n", rewrittenTo)
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&fix, "fix", false, "rewrite trivially convertible patterns automatically")
+	cmd.Flags().StringVar(&writeTo, "write", "", "path to write the rewritten script (default: overwrite the input file)")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "emit machine-readable JSON output")
+
+	return cmd
+}
+
+// migrateResult is the --json payload for the 'migrate' command.
+type migrateResult struct {
+	Report      *migrate.Report `json:"report"`
+	RewrittenTo string          `json:"rewrittenTo,omitempty"`
+}
+
+func printReport(report *migrate.Report) {
+	fmt.Printf("Shode compatibility report for %s\n\n", report.ScriptPath)
+
+	if len(report.Findings) == 0 {
+		fmt.Println("No compatibility issues found.")
+		return
+	}
+
+	findings := make([]migrate.Finding, len(report.Findings))
+	copy(findings, report.Findings)
+	sort.SliceStable(findings, func(i, j int) bool { return findings[i].Line < findings[j].Line })
+
+	for _, f := range findings {
+		tag := "INFO "
+		if f.Severity == migrate.SeverityWarning {
+			tag = "WARN "
+		}
+		fmt.Printf("%s line %d: %s\n", tag, f.Line, f.Message)
+		if f.Suggestion != "" {
+			fmt.Printf("      suggestion: %s\n", f.Suggestion)
+		}
+	}
+}
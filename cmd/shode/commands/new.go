@@ -0,0 +1,101 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"gitee.com/com_818cloud/shode/pkg/cli/newproject"
+	"gitee.com/com_818cloud/shode/pkg/scaffold"
+	"github.com/spf13/cobra"
+)
+
+// NewNewCommand creates the 'new' command, which scaffolds a complete
+// shode project (shode.toml, directory layout, sample scripts and CI
+// config) from one of newproject.Templates().
+func NewNewCommand() *cobra.Command {
+	var templateType string
+	var listTemplates bool
+
+	cmd := &cobra.Command{
+		Use:   "new [project-name]",
+		Short: "Scaffold a new Shode project from a template",
+		Long: `New scaffolds a complete Shode project: a shode.toml, a
+directory layout, sample scripts and a CI workflow.
+
+Supported templates:
+  - cli-script:          A single-script command-line tool
+  - web-api:              An HTTP server with route handlers
+  - serverless-function: A function invoked with a single event payload
+  - package:              A library script meant to be sourced by others
+
+Examples:
+  shode new myapp --template web-api
+  shode new mytool --template cli-script
+  shode new --list-templates`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if listTemplates {
+				return listNewProjectTemplates()
+			}
+
+			if len(args) == 0 {
+				return fmt.Errorf("请提供项目名称\n\n使用 'shode new --list-templates' 查看可用模板")
+			}
+			projectName := args[0]
+
+			if err := scaffold.ValidateProjectName(projectName); err != nil {
+				return err
+			}
+			if templateType == "" {
+				templateType = string(newproject.TemplateCLIScript)
+			}
+			if !newproject.IsValidTemplate(templateType) {
+				return fmt.Errorf("unknown template %q; run 'shode new --list-templates' to see available templates", templateType)
+			}
+
+			if info, err := os.Stat(projectName); err == nil && info.IsDir() {
+				entries, err := os.ReadDir(projectName)
+				if err != nil {
+					return err
+				}
+				if len(entries) > 0 {
+					return fmt.Errorf("directory %q already exists and is not empty", projectName)
+				}
+			}
+
+			gen := newproject.NewGenerator(templateType, projectName)
+			if err := gen.Generate(projectName); err != nil {
+				return err
+			}
+
+			fmt.Printf("Created %q from template %q\n", projectName, templateType)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&templateType, "template", "t", string(newproject.TemplateCLIScript), "项目模板 ("+strings.Join(newproject.Templates(), ", ")+")")
+	cmd.Flags().BoolVarP(&listTemplates, "list-templates", "l", false, "列出所有可用模板")
+
+	return cmd
+}
+
+// listNewProjectTemplates 列出所有可用模板
+func listNewProjectTemplates() error {
+	fmt.Println("可用的项目模板:")
+	fmt.Println()
+
+	for _, tmpl := range newproject.Templates() {
+		fmt.Printf("  📦 %-20s\n", tmpl)
+	}
+
+	fmt.Println()
+	fmt.Println("使用方法:")
+	fmt.Println("  shode new <project-name> --template=<template-name>")
+	fmt.Println()
+	fmt.Println("示例:")
+	fmt.Println("  shode new myapp --template=web-api")
+	fmt.Println("  shode new mytool --template=cli-script")
+
+	return nil
+}
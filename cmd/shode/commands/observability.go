@@ -0,0 +1,66 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"gitee.com/com_818cloud/shode/pkg/observability"
+	"github.com/spf13/cobra"
+)
+
+// NewObservabilityCommand creates the 'observability' command and its
+// subcommands for generating monitoring assets from the metrics the
+// runtime exposes.
+func NewObservabilityCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "observability",
+		Short: "Generate monitoring assets for a running shode process",
+	}
+
+	cmd.AddCommand(newObservabilityExportDashboardsCommand())
+
+	return cmd
+}
+
+func newObservabilityExportDashboardsCommand() *cobra.Command {
+	var dashboardOut string
+	var alertsOut string
+
+	cmd := &cobra.Command{
+		Use:   "export-dashboards",
+		Short: "Generate a Grafana dashboard and Prometheus alert rules",
+		Long: `Export-dashboards writes a Grafana dashboard JSON document and a
+Prometheus alert rule file covering the metrics pkg/metrics/exporter serves
+on /metrics. Panel and rule metric names are read from that package's
+Catalog, so the generated assets stay in sync with the metrics the runtime
+actually emits rather than drifting out of an independently maintained
+list.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dashboard, err := observability.GenerateDashboard()
+			if err != nil {
+				return fmt.Errorf("failed to generate dashboard: %w", err)
+			}
+			if err := os.WriteFile(dashboardOut, dashboard, 0644); err != nil {
+				return fmt.Errorf("failed to write dashboard: %w", err)
+			}
+
+			rules, err := observability.GenerateAlertRules()
+			if err != nil {
+				return fmt.Errorf("failed to generate alert rules: %w", err)
+			}
+			if err := os.WriteFile(alertsOut, rules, 0644); err != nil {
+				return fmt.Errorf("failed to write alert rules: %w", err)
+			}
+
+			fmt.Printf("Wrote Grafana dashboard to %s\n", dashboardOut)
+			fmt.Printf("Wrote Prometheus alert rules to %s\n", alertsOut)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&dashboardOut, "dashboard-out", "shode-dashboard.json", "path to write the generated Grafana dashboard JSON")
+	cmd.Flags().StringVar(&alertsOut, "alerts-out", "shode-alerts.yaml", "path to write the generated Prometheus alert rules")
+
+	return cmd
+}
@@ -0,0 +1,25 @@
+package commands
+
+import (
+	"fmt"
+	"os/exec"
+	"runtime"
+)
+
+// openInBrowser opens path in the user's default browser, dispatching
+// to the platform's standard "open a file/URL" command.
+func openInBrowser(path string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", path)
+	case "windows":
+		cmd = exec.Command("cmd", "/c", "start", "", path)
+	default:
+		cmd = exec.Command("xdg-open", path)
+	}
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("no way to open a browser on %s: %w", runtime.GOOS, err)
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"gitee.com/com_818cloud/shode/pkg/docker"
+	"gitee.com/com_818cloud/shode/pkg/pack"
+	"github.com/spf13/cobra"
+)
+
+// NewPackCommand creates the 'pack' command, which bundles a script
+// into a deployable container image.
+func NewPackCommand() *cobra.Command {
+	var useDocker bool
+	var tag, baseImage, dockerHost, packagesDir, policyPath string
+
+	cmd := &cobra.Command{
+		Use:   "pack [script-file]",
+		Short: "Bundle a script and its dependencies into a container image",
+		Long: `Pack builds a minimal container image containing the shode runtime
+binary, the given script, its sh_models packages, and its shode.toml policy -
+generating the Dockerfile itself, so you never write one. --docker builds the
+image through the Docker Engine API, the same way "shode service install"
+talks to systemd/launchd without shelling out to their CLIs.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !useDocker {
+				return fmt.Errorf("pack currently requires --docker (a running Docker Engine)")
+			}
+			if tag == "" {
+				return fmt.Errorf("--tag is required")
+			}
+
+			scriptPath := args[0]
+			if policyPath == "" {
+				if projectCfg, err := loadProjectConfig(); err == nil && projectCfg.Policy != "" {
+					policyPath = projectCfg.Policy
+				} else {
+					policyPath = filepath.Join(filepath.Dir(scriptPath), "shode.toml")
+				}
+			}
+			if packagesDir == "" {
+				packagesDir = filepath.Join(filepath.Dir(scriptPath), "sh_models")
+			}
+
+			client, err := docker.NewClient(dockerHost)
+			if err != nil {
+				return fmt.Errorf("connecting to docker: %w", err)
+			}
+
+			spec := pack.Spec{
+				ScriptPath:  scriptPath,
+				PackagesDir: packagesDir,
+				PolicyPath:  policyPath,
+				BaseImage:   baseImage,
+			}
+
+			result, err := pack.Build(context.Background(), client, spec, tag)
+			if err != nil {
+				return fmt.Errorf("building image: %w", err)
+			}
+
+			fmt.Printf("Built image %s (%s)\n", tag, result.ImageID)
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&useDocker, "docker", false, "build the image through the Docker Engine API")
+	cmd.Flags().StringVar(&tag, "tag", "", "image tag, e.g. myscript:latest")
+	cmd.Flags().StringVar(&baseImage, "base-image", pack.DefaultBaseImage, "base image the bundled script runs on top of")
+	cmd.Flags().StringVar(&dockerHost, "docker-host", "", "Docker Engine API address (default: DOCKER_HOST or the local socket)")
+	cmd.Flags().StringVar(&packagesDir, "packages", "", "sh_models directory to bundle (default: alongside the script)")
+	cmd.Flags().StringVar(&policyPath, "policy", "", "policy file to bundle (default: the project's shode.toml)")
+
+	return cmd
+}
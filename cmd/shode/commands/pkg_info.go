@@ -1,12 +1,17 @@
 package commands
 
 import (
+	"os"
+
+	"gitee.com/com_818cloud/shode/pkg/output"
 	pkgmgr "gitee.com/com_818cloud/shode/pkg/pkgmgr"
 	"github.com/spf13/cobra"
 )
 
 // newPkgInfoCommand creates the 'info' subcommand
 func newPkgInfoCommand() *cobra.Command {
+	var jsonOutput bool
+
 	cmd := &cobra.Command{
 		Use:   "info <package>",
 		Short: "View detailed package information",
@@ -23,11 +28,21 @@ Example:
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			packageName := args[0]
-
 			pm := pkgmgr.NewPackageManager()
+
+			if jsonOutput {
+				info, err := pm.GetPackageDisplayInfo(packageName)
+				if err != nil {
+					return output.Err(err).Write(os.Stdout)
+				}
+				return output.OK(info).Write(os.Stdout)
+			}
+
 			return pm.ShowPackageInfo(packageName)
 		},
 	}
 
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "emit machine-readable JSON output")
+
 	return cmd
 }
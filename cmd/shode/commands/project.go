@@ -0,0 +1,125 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"gitee.com/com_818cloud/shode/pkg/agi"
+	"gitee.com/com_818cloud/shode/pkg/config"
+	"gitee.com/com_818cloud/shode/pkg/errorreport"
+	"gitee.com/com_818cloud/shode/pkg/security/audit"
+	"gitee.com/com_818cloud/shode/pkg/tracing"
+)
+
+// loadProjectConfig locates and loads the nearest shode.toml, searching
+// from the current working directory upward. Commands that need it call
+// this instead of repeating the lookup/parse logic themselves.
+func loadProjectConfig() (*config.ProjectConfig, error) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return nil, fmt.Errorf("failed to determine working directory: %w", err)
+	}
+
+	path, found := config.FindProjectConfig(cwd)
+	if !found {
+		return config.DefaultProjectConfig(), nil
+	}
+
+	return config.LoadProjectConfig(path)
+}
+
+// resolveScriptFile returns the script to run: the explicit argument if
+// given, otherwise the first entry script configured in shode.toml.
+func resolveScriptFile(args []string) (string, error) {
+	if len(args) > 0 {
+		return args[0], nil
+	}
+
+	projectCfg, err := loadProjectConfig()
+	if err != nil {
+		return "", fmt.Errorf("failed to load project config: %w", err)
+	}
+	if len(projectCfg.Entry) == 0 {
+		return "", fmt.Errorf("no script file given and no entry configured in %s", config.ProjectConfigFile)
+	}
+
+	return projectCfg.Entry[0], nil
+}
+
+// newTracer builds the tracer a command should attach to its execution
+// engine, module manager, and stdlib, per projectCfg's [tracing]
+// section. Tracing disabled (the default) gets a Tracer with a nil
+// exporter, which is a no-op, so callers can always attach one
+// unconditionally.
+func newTracer(projectCfg *config.ProjectConfig) *tracing.Tracer {
+	if !projectCfg.Tracing.Enabled {
+		return tracing.NewTracer(nil)
+	}
+	return tracing.NewTracer(tracing.NewOTLPSpanExporter(projectCfg.Tracing.OTLPEndpoint, tracingResource(projectCfg.Tracing)))
+}
+
+// newErrorReporter builds the reporter a command should attach to its
+// execution engine per projectCfg's [error_reporting] section, or nil
+// when error reporting is disabled (the default) or its DSN fails to
+// parse. Since a nil *errorreport.Reporter is itself a safe no-op,
+// callers may also attach the result unconditionally.
+func newErrorReporter(projectCfg *config.ProjectConfig) *errorreport.Reporter {
+	if !projectCfg.ErrorReporting.Enabled {
+		return nil
+	}
+	reporter, err := errorreport.NewReporter(projectCfg.ErrorReporting.DSN)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "warning: error_reporting enabled but DSN is invalid: %v\n", err)
+		return nil
+	}
+	return reporter
+}
+
+// newAuditor builds the auditor a command should attach to its
+// execution engine's event bus per projectCfg's [audit] section, or
+// nil when auditing is disabled (the default). Output entries are
+// passed straight through to audit.New, so SIEM destinations
+// ("splunk-hec://", "elastic://", "syslog+tls://") work the same way
+// here as a plain "stdout"/"stderr"/file path.
+func newAuditor(projectCfg *config.ProjectConfig) *audit.Auditor {
+	if !projectCfg.Audit.Enabled {
+		return nil
+	}
+	return audit.New(&audit.Config{
+		Output:        projectCfg.Audit.Output,
+		BufferSize:    projectCfg.Audit.BufferSize,
+		FlushInterval: time.Duration(projectCfg.Audit.FlushIntervalSeconds) * time.Second,
+		MaxRetries:    projectCfg.Audit.MaxRetries,
+	})
+}
+
+// newAGIProvider builds the LLM Provider a command should generate
+// text through per projectCfg's [agi] section.
+func newAGIProvider(projectCfg *config.ProjectConfig) (agi.Provider, error) {
+	return agi.NewProviderFromConfig(agi.Config{
+		Provider:      projectCfg.AGI.Provider,
+		Model:         projectCfg.AGI.Model,
+		BaseURL:       projectCfg.AGI.BaseURL,
+		APIKey:        projectCfg.AGI.APIKey,
+		GGUFBinary:    projectCfg.AGI.GGUFBinary,
+		GGUFModelPath: projectCfg.AGI.GGUFModelPath,
+	})
+}
+
+// tracingResource builds the resource attributes attached to every
+// span export, defaulting service.name to "shode" when unset.
+func tracingResource(cfg config.TracingConfig) map[string]string {
+	resource := make(map[string]string, len(cfg.Resource)+1)
+	for k, v := range cfg.Resource {
+		resource[k] = v
+	}
+	if _, ok := resource["service.name"]; !ok {
+		serviceName := cfg.ServiceName
+		if serviceName == "" {
+			serviceName = "shode"
+		}
+		resource["service.name"] = serviceName
+	}
+	return resource
+}
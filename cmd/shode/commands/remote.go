@@ -0,0 +1,150 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"gitee.com/com_818cloud/shode/pkg/output"
+	"gitee.com/com_818cloud/shode/pkg/remote"
+	"github.com/spf13/cobra"
+)
+
+// remoteRunResult is the --json payload for 'remote run'.
+type remoteRunResult struct {
+	Group        string             `json:"group"`
+	HostCount    int                `json:"hostCount"`
+	SuccessCount int                `json:"successCount"`
+	Results      []remoteHostResult `json:"results"`
+}
+
+type remoteHostResult struct {
+	Host    string `json:"host"`
+	Success bool   `json:"success"`
+	Output  string `json:"output,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+// NewRemoteCommand creates the 'remote' command and its subcommands
+// for fanning a script out to many hosts over SSH.
+func NewRemoteCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "remote",
+		Short: "Run scripts across many hosts over SSH",
+	}
+
+	cmd.AddCommand(newRemoteRunCommand())
+
+	return cmd
+}
+
+func newRemoteRunCommand() *cobra.Command {
+	var inventoryPath string
+	var hostsGroup string
+	var jsonOutput bool
+
+	cmd := &cobra.Command{
+		Use:   "run [script-file]",
+		Short: "Run a script on every host in an inventory group",
+		Long: `Run reads script-file's raw contents and pipes them to "sh -s" on
+every host in --hosts, connecting to each one concurrently over SSH and
+aggregating per-host output and failures - an "Ansible-lite" for teams
+that already have a shode.toml-adjacent inventory file and don't want
+to bring in the real thing for a handful of hosts.
+
+The inventory file (--inventory, default "inventory.toml") groups hosts
+under [groups.<name>] sections:
+
+    [groups.web]
+    hosts = ["web1.example.com", "web2.example.com"]
+    user = "deploy"
+    key_file = "~/.ssh/id_rsa"
+
+A failure on one host never stops the others; each host's result is
+reported independently.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			scriptFile := args[0]
+			script, err := os.ReadFile(scriptFile)
+			if err != nil {
+				err = fmt.Errorf("failed to read script file: %w", err)
+				if jsonOutput {
+					return output.Err(err).Write(os.Stdout)
+				}
+				return err
+			}
+
+			inv, err := remote.LoadInventory(inventoryPath)
+			if err != nil {
+				if jsonOutput {
+					return output.Err(err).Write(os.Stdout)
+				}
+				return err
+			}
+
+			group, err := inv.Group(hostsGroup)
+			if err != nil {
+				if jsonOutput {
+					return output.Err(err).Write(os.Stdout)
+				}
+				return err
+			}
+
+			if !jsonOutput {
+				fmt.Printf("Running %s on %d host(s) in group %q\n", scriptFile, len(group.Hosts), hostsGroup)
+			}
+
+			results := remote.RunScript(group, string(script))
+
+			successCount := 0
+			for _, r := range results {
+				if r.Success {
+					successCount++
+				}
+			}
+
+			if jsonOutput {
+				hostResults := make([]remoteHostResult, len(results))
+				for i, r := range results {
+					hostResults[i] = remoteHostResult{Host: r.Host, Success: r.Success, Output: r.Output, Error: r.Error}
+				}
+				envelope := output.OK(remoteRunResult{
+					Group:        hostsGroup,
+					HostCount:    len(results),
+					SuccessCount: successCount,
+					Results:      hostResults,
+				})
+				if successCount < len(results) {
+					envelope.Status = output.StatusError
+				}
+				if err := envelope.Write(os.Stdout); err != nil {
+					return err
+				}
+				if successCount < len(results) {
+					return fmt.Errorf("%d of %d host(s) failed", len(results)-successCount, len(results))
+				}
+				return nil
+			}
+
+			for _, r := range results {
+				if r.Success {
+					fmt.Printf("\n--- %s: OK ---\n%s", r.Host, r.Output)
+				} else {
+					fmt.Printf("\n--- %s: FAILED (%s) ---\n%s", r.Host, r.Error, r.Output)
+				}
+			}
+			fmt.Printf("\n%d/%d host(s) succeeded\n", successCount, len(results))
+
+			if successCount < len(results) {
+				return fmt.Errorf("%d of %d host(s) failed", len(results)-successCount, len(results))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&inventoryPath, "inventory", "inventory.toml", "path to the inventory file")
+	cmd.Flags().StringVar(&hostsGroup, "hosts", "", "inventory group to run against (required)")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "emit machine-readable JSON output")
+	cmd.MarkFlagRequired("hosts")
+
+	return cmd
+}
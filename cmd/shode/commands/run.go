@@ -10,39 +10,88 @@ import (
 
 	"gitee.com/com_818cloud/shode/pkg/engine"
 	"gitee.com/com_818cloud/shode/pkg/environment"
+	"gitee.com/com_818cloud/shode/pkg/events"
 	"gitee.com/com_818cloud/shode/pkg/module"
+	"gitee.com/com_818cloud/shode/pkg/optimizer"
+	"gitee.com/com_818cloud/shode/pkg/output"
 	"gitee.com/com_818cloud/shode/pkg/parser"
 	"gitee.com/com_818cloud/shode/pkg/sandbox"
 	"gitee.com/com_818cloud/shode/pkg/stdlib"
 	"github.com/spf13/cobra"
 )
 
+// runResult is the --json payload for the 'run' command.
+type runResult struct {
+	Script       string `json:"script"`
+	Success      bool   `json:"success"`
+	ExitCode     int    `json:"exitCode"`
+	Duration     string `json:"duration"`
+	CommandCount int    `json:"commandCount"`
+	Output       string `json:"output,omitempty"`
+	Error        string `json:"error,omitempty"`
+}
+
 // NewRunCommand creates the 'run' command for executing script files
 func NewRunCommand() *cobra.Command {
+	var jsonOutput bool
+	var explainOptimizations bool
+
 	cmd := &cobra.Command{
 		Use:   "run [script-file]",
 		Short: "Run a shell script file",
 		Long: `Run executes a shell script file with Shode's security features enabled.
-The script will be parsed, analyzed for security risks, and executed in a sandboxed environment.`,
-		Args: cobra.ExactArgs(1),
+The script will be parsed, analyzed for security risks, and executed in a sandboxed environment.
+With no arguments, the first "entry" script from the project's shode.toml is used.`,
+		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			scriptFile := args[0]
+			scriptFile, err := resolveScriptFile(args)
+			if err != nil {
+				if jsonOutput {
+					return output.Err(err).Write(os.Stdout)
+				}
+				return err
+			}
 
 			// Check if file exists
 			if _, err := os.Stat(scriptFile); os.IsNotExist(err) {
-				return fmt.Errorf("script file not found: %s", scriptFile)
+				err = fmt.Errorf("script file not found: %s", scriptFile)
+				if jsonOutput {
+					return output.Err(err).Write(os.Stdout)
+				}
+				return err
 			}
 
-			fmt.Printf("Running script: %s\n", scriptFile)
+			if !jsonOutput {
+				fmt.Printf("Running script: %s\n", scriptFile)
+			}
 
 			// Parse the script file using tree-sitter parser for better heredoc support
 			treeParser := parser.NewParser()
 			script, err := treeParser.ParseFile(scriptFile)
 			if err != nil {
-				return fmt.Errorf("failed to parse script: %v", err)
+				err = fmt.Errorf("failed to parse script: %v", err)
+				if jsonOutput {
+					return output.Err(err).Write(os.Stdout)
+				}
+				return err
 			}
 
-			fmt.Printf("Parsed %d commands successfully\n", len(script.Nodes))
+			if !jsonOutput {
+				fmt.Printf("Parsed %d commands successfully\n", len(script.Nodes))
+			}
+
+			// Run the AST optimization pipeline before execution.
+			explanations := optimizer.NewOptimizer().Optimize(script)
+			if explainOptimizations && !jsonOutput {
+				if len(explanations) == 0 {
+					fmt.Println("\n--- Optimizations ---\nno optimizations applied")
+				} else {
+					fmt.Println("\n--- Optimizations ---")
+					for _, e := range explanations {
+						fmt.Printf("  [%s] line %d: %s\n", e.Pass, e.Line, e.Detail)
+					}
+				}
+			}
 
 			// Initialize execution engine components
 			envManager := environment.NewEnvironmentManager()
@@ -52,6 +101,86 @@ The script will be parsed, analyzed for security risks, and executed in a sandbo
 
 			// Create execution engine
 			executionEngine := engine.NewExecutionEngine(envManager, stdLib, moduleMgr, security)
+			executionEngine.SetScriptPath(scriptFile)
+
+			// Share one event bus across the engine, the security
+			// checker, and the cache so command/job lifecycle, security
+			// denials, and cache evictions all flow through a single
+			// place a TUI, a WebSocket bridge, or the audit logger can
+			// subscribe to instead of each growing its own hooks.
+			eventBus := events.NewBus()
+			executionEngine.SetEventBus(eventBus)
+			security.SetEventBus(eventBus)
+			stdLib.SetEventBus(eventBus)
+
+			// Let the Docker, Kubernetes, Git, object storage, SSH,
+			// PowerShell, and resource builtins consult the same
+			// SecurityChecker the engine checks every command against,
+			// so shode.toml's [sandbox] section can restrict
+			// DockerBuild/DockerRun/DockerPush/DockerPs,
+			// K8sApply/K8sGet/K8sLogs/K8sWaitFor,
+			// GitClone/GitCheckout/GitStatus/GitTag/GitPush,
+			// ObjectPut/ObjectGet/ObjectList/PresignURL, SSHRun,
+			// PowerShellInvoke, and ResourceFile/ResourcePackage/
+			// ResourceService/ResourceUser independently of the
+			// dangerous-command blacklist.
+			stdLib.SetSecurityChecker(security)
+
+			// Attach the configured tracer (a no-op unless shode.toml
+			// enables tracing) to the engine and the components it
+			// delegates to, so a trace follows a command all the way
+			// into module loads and DB/Mongo builtins.
+			if projectCfg, err := loadProjectConfig(); err == nil {
+				tracer := newTracer(projectCfg)
+				executionEngine.SetTracer(tracer)
+				moduleMgr.SetTracer(tracer)
+				stdLib.SetTracer(tracer)
+
+				// Attach the configured error reporter (a no-op unless
+				// shode.toml enables error reporting) so an engine panic
+				// reaches the collector instead of just crashing the
+				// process.
+				if reporter := newErrorReporter(projectCfg); reporter != nil {
+					executionEngine.SetErrorReporter(reporter)
+				}
+
+				// Subscribe the configured auditor (absent unless
+				// shode.toml enables auditing) to the event bus, so
+				// command/security events are shipped to its outputs -
+				// including any SIEM destination - as they happen.
+				if auditor := newAuditor(projectCfg); auditor != nil {
+					unsubscribe := auditor.SubscribeEvents(eventBus)
+					defer unsubscribe()
+					defer auditor.Close()
+				}
+
+				// Restrict the Docker, Kubernetes, Git, object storage,
+				// SSH, PowerShell, and resource builtins to their
+				// configured allowlists (every operation stays
+				// permitted when [sandbox] is absent or the relevant
+				// list is empty).
+				if len(projectCfg.Sandbox.DockerAllow) > 0 {
+					security.AllowDockerOperations(projectCfg.Sandbox.DockerAllow)
+				}
+				if len(projectCfg.Sandbox.KubernetesAllow) > 0 {
+					security.AllowKubernetesOperations(projectCfg.Sandbox.KubernetesAllow)
+				}
+				if len(projectCfg.Sandbox.GitAllow) > 0 {
+					security.AllowGitOperations(projectCfg.Sandbox.GitAllow)
+				}
+				if len(projectCfg.Sandbox.ObjectAllow) > 0 {
+					security.AllowObjectOperations(projectCfg.Sandbox.ObjectAllow)
+				}
+				if len(projectCfg.Sandbox.SSHAllow) > 0 {
+					security.AllowSSHOperations(projectCfg.Sandbox.SSHAllow)
+				}
+				if len(projectCfg.Sandbox.PowerShellAllow) > 0 {
+					security.AllowPowerShellOperations(projectCfg.Sandbox.PowerShellAllow)
+				}
+				if len(projectCfg.Sandbox.ResourceAllow) > 0 {
+					security.AllowResourceOperations(projectCfg.Sandbox.ResourceAllow)
+				}
+			}
 
 			// Set engine factory for HTTP handlers
 			// Use the main execution engine so functions are available
@@ -66,10 +195,38 @@ The script will be parsed, analyzed for security risks, and executed in a sandbo
 			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
 			defer cancel()
 
-			fmt.Println("\n--- Execution Output ---")
+			if !jsonOutput {
+				fmt.Println("\n--- Execution Output ---")
+			}
 			result, err := executionEngine.Execute(ctx, script)
 			if err != nil {
-				return fmt.Errorf("execution error: %v", err)
+				err = fmt.Errorf("execution error: %v", err)
+				if jsonOutput {
+					return output.Err(err).Write(os.Stdout)
+				}
+				return err
+			}
+
+			if jsonOutput {
+				envelope := output.OK(runResult{
+					Script:       scriptFile,
+					Success:      result.Success,
+					ExitCode:     result.ExitCode,
+					Duration:     result.Duration.String(),
+					CommandCount: len(result.Commands),
+					Output:       result.Output,
+					Error:        result.Error,
+				})
+				if !result.Success {
+					envelope.Status = output.StatusError
+				}
+				if err := envelope.Write(os.Stdout); err != nil {
+					return err
+				}
+				if !result.Success {
+					return fmt.Errorf("script execution failed with exit code %d", result.ExitCode)
+				}
+				return nil
 			}
 
 			// Display results
@@ -123,5 +280,8 @@ The script will be parsed, analyzed for security risks, and executed in a sandbo
 		},
 	}
 
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "emit machine-readable JSON output")
+	cmd.Flags().BoolVar(&explainOptimizations, "explain-optimizations", false, "print the AST optimizations applied before execution")
+
 	return cmd
 }
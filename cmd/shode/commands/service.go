@@ -0,0 +1,85 @@
+package commands
+
+import (
+	"fmt"
+
+	"gitee.com/com_818cloud/shode/pkg/service"
+	"github.com/spf13/cobra"
+)
+
+// NewServiceCommand creates the 'service' command and its subcommands
+// for installing a script as a supervised OS service (a systemd user
+// unit on Linux, a launchd agent on macOS).
+func NewServiceCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "service",
+		Short: "Install, check, or remove a script as an OS service",
+	}
+
+	cmd.AddCommand(newServiceInstallCommand())
+	cmd.AddCommand(newServiceStatusCommand())
+	cmd.AddCommand(newServiceUninstallCommand())
+
+	return cmd
+}
+
+func newServiceInstallCommand() *cobra.Command {
+	var name, description string
+
+	cmd := &cobra.Command{
+		Use:   "install [script-file]",
+		Short: "Generate and install a service unit that runs a script under the shode runtime",
+		Long: `Install generates a systemd user unit (or, on macOS, a launchd agent) that
+runs script-file via "shode run" - picking up the script's own shode.toml and
+[sandbox] policy exactly as an interactive run would - and registers it with
+the OS service manager so it starts on login and restarts on failure.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts, err := service.ResolveOptions(args[0], name, description)
+			if err != nil {
+				return err
+			}
+			path, err := service.Install(opts)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("Installed %s as service %q\n", path, opts.Name)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&name, "name", "", "service name (default: the script's base file name)")
+	cmd.Flags().StringVar(&description, "description", "", "human-readable description for the generated unit")
+
+	return cmd
+}
+
+func newServiceStatusCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "status [name]",
+		Short: "Show whether an installed service is running",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			out, err := service.Status(args[0])
+			if out != "" {
+				fmt.Print(out)
+			}
+			return err
+		},
+	}
+}
+
+func newServiceUninstallCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "uninstall [name]",
+		Short: "Stop and remove an installed service",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := service.Uninstall(args[0]); err != nil {
+				return err
+			}
+			fmt.Printf("Uninstalled service %q\n", args[0])
+			return nil
+		},
+	}
+}
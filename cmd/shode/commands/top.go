@@ -0,0 +1,95 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"gitee.com/com_818cloud/shode/pkg/dashboard"
+	"gitee.com/com_818cloud/shode/pkg/engine"
+	"gitee.com/com_818cloud/shode/pkg/environment"
+	"gitee.com/com_818cloud/shode/pkg/module"
+	"gitee.com/com_818cloud/shode/pkg/parser"
+	"gitee.com/com_818cloud/shode/pkg/sandbox"
+	"gitee.com/com_818cloud/shode/pkg/stdlib"
+	"github.com/spf13/cobra"
+)
+
+// NewTopCommand creates the 'top' command, a terminal dashboard that
+// re-runs a script on an interval and shows execution history, engine
+// metrics, performance alerts, and a log tail.
+func NewTopCommand() *cobra.Command {
+	var interval time.Duration
+	var logFile string
+
+	cmd := &cobra.Command{
+		Use:   "top [script-file]",
+		Short: "Watch a script's executions in a live terminal dashboard",
+		Long: `Top runs a script repeatedly on a fixed interval and renders a
+refreshing dashboard of recent executions, engine metrics, and performance
+alerts for runs that take unusually long. With no arguments, the first
+"entry" script from the project's shode.toml is used.
+Press Ctrl+C to stop.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			scriptFile, err := resolveScriptFile(args)
+			if err != nil {
+				return err
+			}
+			if _, err := os.Stat(scriptFile); os.IsNotExist(err) {
+				return fmt.Errorf("script file not found: %s", scriptFile)
+			}
+
+			envManager := environment.NewEnvironmentManager()
+			stdLib := stdlib.New()
+			moduleMgr := module.NewModuleManager()
+			security := sandbox.NewSecurityChecker()
+			executionEngine := engine.NewExecutionEngine(envManager, stdLib, moduleMgr, security)
+
+			dash := dashboard.New(executionEngine, logFile, 20)
+
+			sigChan := make(chan os.Signal, 1)
+			signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
+
+			ticker := time.NewTicker(interval)
+			defer ticker.Stop()
+
+			runOnce := func() {
+				p := parser.NewSimpleParser()
+				script, err := p.ParseFile(scriptFile)
+				if err != nil {
+					return
+				}
+				ctx, cancel := context.WithTimeout(context.Background(), interval)
+				result, err := executionEngine.Execute(ctx, script)
+				cancel()
+				if err == nil {
+					dash.Record(scriptFile, result)
+				}
+			}
+
+			runOnce()
+			fmt.Print("\033[H\033[2J")
+			fmt.Print(dash.Render())
+
+			for {
+				select {
+				case <-sigChan:
+					return nil
+				case <-ticker.C:
+					runOnce()
+					fmt.Print("\033[H\033[2J")
+					fmt.Print(dash.Render())
+				}
+			}
+		},
+	}
+
+	cmd.Flags().DurationVar(&interval, "interval", 2*time.Second, "how often to re-run the script and refresh the dashboard")
+	cmd.Flags().StringVar(&logFile, "log-file", "", "path to a log file to tail in the dashboard")
+
+	return cmd
+}
@@ -0,0 +1,154 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gitee.com/com_818cloud/shode/pkg/engine"
+	"gitee.com/com_818cloud/shode/pkg/environment"
+	"gitee.com/com_818cloud/shode/pkg/module"
+	"gitee.com/com_818cloud/shode/pkg/output"
+	"gitee.com/com_818cloud/shode/pkg/parser"
+	"gitee.com/com_818cloud/shode/pkg/performance"
+	"gitee.com/com_818cloud/shode/pkg/sandbox"
+	"gitee.com/com_818cloud/shode/pkg/stdlib"
+	"github.com/spf13/cobra"
+)
+
+// traceResult is the --json payload for the 'trace' command.
+type traceResult struct {
+	Script      string `json:"script"`
+	SampleCount int    `json:"sampleCount"`
+	Duration    string `json:"duration"`
+	FoldedFile  string `json:"foldedFile"`
+	HTMLFile    string `json:"htmlFile"`
+	SVGFile     string `json:"svgFile,omitempty"`
+}
+
+// NewTraceCommand creates the 'trace' command (aliased 'profile') for
+// producing flame graphs from a script's execution.
+func NewTraceCommand() *cobra.Command {
+	var foldedOut string
+	var htmlOut string
+	var svgOut string
+	var jsonOutput bool
+	var open bool
+
+	cmd := &cobra.Command{
+		Use:     "trace [script-file]",
+		Aliases: []string{"profile"},
+		Short:   "Run a script and record a flame graph of its execution",
+		Long: `Trace executes a shell script and records per-command timing, emitting a
+folded-stack file (compatible with flamegraph.pl), a self-contained HTML
+flame graph, and optionally a static SVG, so hot commands are easy to spot.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			scriptFile := args[0]
+			if _, err := os.Stat(scriptFile); os.IsNotExist(err) {
+				err = fmt.Errorf("script file not found: %s", scriptFile)
+				return traceFail(jsonOutput, err)
+			}
+
+			p := parser.NewSimpleParser()
+			script, err := p.ParseFile(scriptFile)
+			if err != nil {
+				return traceFail(jsonOutput, fmt.Errorf("failed to parse script: %v", err))
+			}
+
+			envManager := environment.NewEnvironmentManager()
+			stdLib := stdlib.New()
+			moduleMgr := module.NewModuleManager()
+			security := sandbox.NewSecurityChecker()
+			executionEngine := engine.NewExecutionEngine(envManager, stdLib, moduleMgr, security)
+
+			result, err := executionEngine.Execute(context.Background(), script)
+			if err != nil {
+				return traceFail(jsonOutput, fmt.Errorf("execution error: %v", err))
+			}
+
+			scriptName := strings.TrimSuffix(filepath.Base(scriptFile), filepath.Ext(scriptFile))
+			profiler := performance.NewProfiler(scriptName)
+			samples := profiler.Samples(result)
+
+			if foldedOut == "" {
+				foldedOut = scriptName + ".folded"
+			}
+			if htmlOut == "" {
+				htmlOut = scriptName + ".flamegraph.html"
+			}
+
+			if err := os.WriteFile(foldedOut, []byte(performance.FoldedStacks(samples)), 0644); err != nil {
+				return traceFail(jsonOutput, fmt.Errorf("failed to write folded stacks: %v", err))
+			}
+
+			htmlFile, err := os.Create(htmlOut)
+			if err != nil {
+				return traceFail(jsonOutput, fmt.Errorf("failed to create flame graph file: %v", err))
+			}
+			defer htmlFile.Close()
+
+			if err := performance.WriteHTML(htmlFile, samples); err != nil {
+				return traceFail(jsonOutput, fmt.Errorf("failed to render flame graph: %v", err))
+			}
+
+			if svgOut != "" {
+				svgFile, err := os.Create(svgOut)
+				if err != nil {
+					return traceFail(jsonOutput, fmt.Errorf("failed to create SVG file: %v", err))
+				}
+				defer svgFile.Close()
+
+				if err := performance.WriteSVG(svgFile, samples); err != nil {
+					return traceFail(jsonOutput, fmt.Errorf("failed to render SVG flame graph: %v", err))
+				}
+			}
+
+			if open {
+				if err := openInBrowser(htmlOut); err != nil {
+					return traceFail(jsonOutput, fmt.Errorf("failed to open flame graph in browser: %v", err))
+				}
+			}
+
+			totalDuration := performance.TotalDuration(samples)
+
+			if jsonOutput {
+				return output.OK(traceResult{
+					Script:      scriptFile,
+					SampleCount: len(samples),
+					Duration:    totalDuration.String(),
+					FoldedFile:  foldedOut,
+					HTMLFile:    htmlOut,
+					SVGFile:     svgOut,
+				}).Write(os.Stdout)
+			}
+
+			fmt.Printf("Traced %d commands in %v\n", len(samples), totalDuration)
+			fmt.Printf("Folded stacks: %s\n", foldedOut)
+			fmt.Printf("Flame graph:   %s\n", htmlOut)
+			if svgOut != "" {
+				fmt.Printf("SVG:           %s\n", svgOut)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&foldedOut, "folded-out", "", "path to write the folded-stack file (default: <script>.folded)")
+	cmd.Flags().StringVar(&htmlOut, "html-out", "", "path to write the HTML flame graph (default: <script>.flamegraph.html)")
+	cmd.Flags().StringVar(&svgOut, "svg-out", "", "path to write a static SVG flame graph (default: not written)")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "emit machine-readable JSON output")
+	cmd.Flags().BoolVar(&open, "open", false, "open the HTML flame graph in the default browser when done")
+
+	return cmd
+}
+
+// traceFail reports err either as a JSON envelope on stdout or as a plain
+// Go error, depending on whether --json was requested.
+func traceFail(jsonOutput bool, err error) error {
+	if jsonOutput {
+		return output.Err(err).Write(os.Stdout)
+	}
+	return err
+}
@@ -24,7 +24,22 @@ modern development practices, safety features, and ecosystem tools
 	rootCmd.AddCommand(commands.NewReplCommand())
 	rootCmd.AddCommand(commands.NewPkgCommand())
 	rootCmd.AddCommand(commands.NewInitCommandEnhanced())
+	rootCmd.AddCommand(commands.NewNewCommand())
 	rootCmd.AddCommand(commands.NewVersionCommand())
+	rootCmd.AddCommand(commands.NewTraceCommand())
+	rootCmd.AddCommand(commands.NewMigrateCommand())
+	rootCmd.AddCommand(commands.NewGraphCommand())
+	rootCmd.AddCommand(commands.NewTopCommand())
+	rootCmd.AddCommand(commands.NewDaemonCommand())
+	rootCmd.AddCommand(commands.NewBenchCommand())
+	rootCmd.AddCommand(commands.NewFnCommand())
+	rootCmd.AddCommand(commands.NewDBCommand())
+	rootCmd.AddCommand(commands.NewObservabilityCommand())
+	rootCmd.AddCommand(commands.NewRemoteCommand())
+	rootCmd.AddCommand(commands.NewServiceCommand())
+	rootCmd.AddCommand(commands.NewCICommand())
+	rootCmd.AddCommand(commands.NewPackCommand())
+	rootCmd.AddCommand(commands.NewAICommand())
 
 	if err := rootCmd.Execute(); err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
@@ -0,0 +1,36 @@
+// Package agi provides shode's interface to large language models:
+// a Provider abstraction with OpenAI-compatible HTTP, Ollama, and
+// local GGUF backends, and a thin MultimodalLLM wrapper the rest of
+// the codebase (the `shode ai` command, in particular) calls through.
+//
+// The "multimodal" name anticipates image/audio prompts; today every
+// Provider only accepts a text prompt, so MultimodalLLM is no wider
+// than a plain text LLM client until a Provider actually implements
+// non-text input.
+package agi
+
+import "context"
+
+// MultimodalLLM generates text by delegating to a Provider, so
+// callers don't need to know which backend is configured.
+type MultimodalLLM struct {
+	Provider Provider
+}
+
+// NewMultimodalLLM wraps provider.
+func NewMultimodalLLM(provider Provider) *MultimodalLLM {
+	return &MultimodalLLM{Provider: provider}
+}
+
+// Generate returns provider's full response to prompt.
+func (m *MultimodalLLM) Generate(ctx context.Context, prompt string, opts Options) (string, error) {
+	return m.Provider.Generate(ctx, prompt, opts, nil)
+}
+
+// GenerateStreaming returns provider's full response to prompt,
+// additionally invoking onToken as each token arrives - for a CLI
+// that wants to print tokens as they're generated rather than waiting
+// for the whole response.
+func (m *MultimodalLLM) GenerateStreaming(ctx context.Context, prompt string, opts Options, onToken TokenFunc) (string, error) {
+	return m.Provider.Generate(ctx, prompt, opts, onToken)
+}
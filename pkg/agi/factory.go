@@ -0,0 +1,30 @@
+package agi
+
+import "fmt"
+
+// Config selects and configures a Provider - a parallel, dependency-free
+// copy of pkg/config's AGIConfig fields so this package doesn't need to
+// import pkg/config just to read four strings.
+type Config struct {
+	Provider      string
+	Model         string
+	BaseURL       string
+	APIKey        string
+	GGUFBinary    string
+	GGUFModelPath string
+}
+
+// NewProviderFromConfig builds the Provider cfg.Provider names
+// ("openai", "ollama", or "gguf").
+func NewProviderFromConfig(cfg Config) (Provider, error) {
+	switch cfg.Provider {
+	case "", "openai":
+		return NewOpenAIProvider(cfg.BaseURL, cfg.APIKey, cfg.Model), nil
+	case "ollama":
+		return NewOllamaProvider(cfg.BaseURL, cfg.Model), nil
+	case "gguf":
+		return NewGGUFProvider(cfg.GGUFBinary, cfg.GGUFModelPath), nil
+	default:
+		return nil, fmt.Errorf("unknown agi provider %q (expected \"openai\", \"ollama\", or \"gguf\")", cfg.Provider)
+	}
+}
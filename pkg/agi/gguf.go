@@ -0,0 +1,109 @@
+package agi
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// DefaultGGUFExecutables are tried in order to find a local GGUF
+// inference binary - llama.cpp's CLI under its current and historical
+// names.
+var DefaultGGUFExecutables = []string{"llama-cli", "main", "llama"}
+
+// GGUFProvider runs local inference against a .gguf model file by
+// shelling out to a llama.cpp-compatible binary, the same way
+// pkg/powershell shells out to a PowerShell host rather than linking
+// an inference runtime directly - GGUF decoding has no pure-Go
+// implementation this repo can reasonably vendor.
+type GGUFProvider struct {
+	// Executable is the binary to run; an empty value searches
+	// DefaultGGUFExecutables on PATH.
+	Executable string
+	// ModelPath is the .gguf file to load.
+	ModelPath string
+}
+
+// NewGGUFProvider builds a Provider that runs modelPath through
+// executable (found on PATH via DefaultGGUFExecutables when empty).
+func NewGGUFProvider(executable, modelPath string) *GGUFProvider {
+	return &GGUFProvider{Executable: executable, ModelPath: modelPath}
+}
+
+// Name implements Provider.
+func (p *GGUFProvider) Name() string { return "gguf" }
+
+// Generate implements Provider.
+func (p *GGUFProvider) Generate(ctx context.Context, prompt string, opts Options, onToken TokenFunc) (string, error) {
+	if p.ModelPath == "" {
+		return "", fmt.Errorf("GGUF provider requires a model path")
+	}
+
+	executable, err := p.findExecutable()
+	if err != nil {
+		return "", err
+	}
+
+	maxTokens := opts.MaxTokens
+	if maxTokens == 0 {
+		maxTokens = 256
+	}
+
+	args := []string{"-m", p.ModelPath, "-p", prompt, "-n", strconv.Itoa(maxTokens), "--no-display-prompt"}
+	if opts.Temperature != 0 {
+		args = append(args, "--temp", strconv.FormatFloat(opts.Temperature, 'f', -1, 64))
+	}
+
+	cmd := exec.CommandContext(ctx, executable, args...)
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return "", fmt.Errorf("opening %s stdout: %w", executable, err)
+	}
+	var stderr strings.Builder
+	cmd.Stderr = &stderr
+
+	if err := cmd.Start(); err != nil {
+		return "", fmt.Errorf("starting %s: %w", executable, err)
+	}
+
+	// llama.cpp writes generated text to stdout as it's produced with
+	// no per-token framing, so the best this can do without parsing
+	// its internal token stream is treat each whitespace-delimited
+	// word as one streamed "token".
+	var full strings.Builder
+	reader := bufio.NewReader(stdout)
+	for {
+		word, err := reader.ReadString(' ')
+		if word != "" {
+			full.WriteString(word)
+			if onToken != nil {
+				onToken(word)
+			}
+		}
+		if err != nil {
+			break
+		}
+	}
+
+	if err := cmd.Wait(); err != nil {
+		return full.String(), fmt.Errorf("%s exited with an error: %w: %s", executable, err, strings.TrimSpace(stderr.String()))
+	}
+	return full.String(), nil
+}
+
+// findExecutable returns p.Executable if set, otherwise the first of
+// DefaultGGUFExecutables found on PATH.
+func (p *GGUFProvider) findExecutable() (string, error) {
+	if p.Executable != "" {
+		return p.Executable, nil
+	}
+	for _, name := range DefaultGGUFExecutables {
+		if path, err := exec.LookPath(name); err == nil {
+			return path, nil
+		}
+	}
+	return "", fmt.Errorf("no local GGUF inference binary found on PATH (tried %s)", strings.Join(DefaultGGUFExecutables, ", "))
+}
@@ -0,0 +1,68 @@
+package agi
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeFakeLlama writes a fake llama.cpp-compatible binary that prints
+// a canned response, standing in for llama-cli on machines without a
+// real GGUF inference binary installed.
+func writeFakeLlama(t *testing.T, name, script string) string {
+	t.Helper()
+	dir := t.TempDir()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte("#!/bin/sh\n"+script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestGGUFProviderGenerateReturnsStdoutAndStreamsWords(t *testing.T) {
+	exe := writeFakeLlama(t, "llama-cli", `echo "hello world"`)
+
+	provider := NewGGUFProvider(exe, "/models/test.gguf")
+	var tokens []string
+	result, err := provider.Generate(context.Background(), "hi", Options{}, func(token string) {
+		tokens = append(tokens, token)
+	})
+	if err != nil {
+		t.Fatalf("Generate returned error: %v", err)
+	}
+	if strings.TrimSpace(result) != "hello world" {
+		t.Errorf("expected %q, got %q", "hello world", result)
+	}
+	if len(tokens) == 0 {
+		t.Errorf("expected at least one streamed token")
+	}
+}
+
+func TestGGUFProviderGenerateRequiresModelPath(t *testing.T) {
+	provider := NewGGUFProvider("llama-cli", "")
+	if _, err := provider.Generate(context.Background(), "hi", Options{}, nil); err == nil {
+		t.Fatalf("expected an error when ModelPath is empty")
+	}
+}
+
+func TestGGUFProviderGenerateSurfacesNonZeroExit(t *testing.T) {
+	exe := writeFakeLlama(t, "llama-cli", `echo "boom" 1>&2; exit 1`)
+
+	provider := NewGGUFProvider(exe, "/models/test.gguf")
+	_, err := provider.Generate(context.Background(), "hi", Options{}, nil)
+	if err == nil || !strings.Contains(err.Error(), "boom") {
+		t.Fatalf("expected error to include stderr, got %v", err)
+	}
+}
+
+func TestGGUFProviderFindExecutableErrorsWhenNoneOnPath(t *testing.T) {
+	t.Setenv("PATH", t.TempDir())
+
+	provider := NewGGUFProvider("", "/models/test.gguf")
+	_, err := provider.Generate(context.Background(), "hi", Options{}, nil)
+	if err == nil || !strings.Contains(err.Error(), "no local GGUF inference binary found") {
+		t.Fatalf("expected 'no local GGUF inference binary found' error, got %v", err)
+	}
+}
@@ -0,0 +1,14 @@
+package agi
+
+import "io"
+
+// maxErrorBodyBytes caps how much of an HTTP error response body
+// providers read into an error message, so a misbehaving server
+// returning an enormous body can't blow up memory.
+const maxErrorBodyBytes = 4096
+
+// readAllLimited reads up to maxErrorBodyBytes from r, for rendering
+// into an error message.
+func readAllLimited(r io.Reader) ([]byte, error) {
+	return io.ReadAll(io.LimitReader(r, maxErrorBodyBytes))
+}
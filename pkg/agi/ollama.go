@@ -0,0 +1,121 @@
+package agi
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// DefaultOllamaBaseURL is used when OllamaProvider.BaseURL is empty.
+const DefaultOllamaBaseURL = "http://localhost:11434"
+
+// OllamaProvider talks to a local or remote Ollama server's
+// /api/generate endpoint.
+type OllamaProvider struct {
+	BaseURL string
+	Model   string
+
+	httpClient *http.Client
+}
+
+// NewOllamaProvider builds a Provider against baseURL
+// (DefaultOllamaBaseURL when empty), defaulting to model when an
+// Options.Model isn't given per-call.
+func NewOllamaProvider(baseURL, model string) *OllamaProvider {
+	if baseURL == "" {
+		baseURL = DefaultOllamaBaseURL
+	}
+	return &OllamaProvider{
+		BaseURL:    strings.TrimSuffix(baseURL, "/"),
+		Model:      model,
+		httpClient: &http.Client{Timeout: 2 * time.Minute},
+	}
+}
+
+// Name implements Provider.
+func (p *OllamaProvider) Name() string { return "ollama" }
+
+type ollamaGenerateRequest struct {
+	Model   string                 `json:"model"`
+	Prompt  string                 `json:"prompt"`
+	Stream  bool                   `json:"stream"`
+	Options map[string]interface{} `json:"options,omitempty"`
+}
+
+type ollamaGenerateChunk struct {
+	Response string `json:"response"`
+	Done     bool   `json:"done"`
+}
+
+// Generate implements Provider.
+func (p *OllamaProvider) Generate(ctx context.Context, prompt string, opts Options, onToken TokenFunc) (string, error) {
+	model := p.Model
+	if opts.Model != "" {
+		model = opts.Model
+	}
+
+	reqBody := ollamaGenerateRequest{Model: model, Prompt: prompt, Stream: true}
+	if opts.Temperature != 0 {
+		reqBody.Options = map[string]interface{}{"temperature": opts.Temperature}
+	}
+	if opts.MaxTokens != 0 {
+		if reqBody.Options == nil {
+			reqBody.Options = map[string]interface{}{}
+		}
+		reqBody.Options["num_predict"] = opts.MaxTokens
+	}
+
+	payload, err := json.Marshal(reqBody)
+	if err != nil {
+		return "", fmt.Errorf("marshaling Ollama request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.BaseURL+"/api/generate", bytes.NewReader(payload))
+	if err != nil {
+		return "", fmt.Errorf("building Ollama request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("calling Ollama API: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		body, _ := readAllLimited(resp.Body)
+		return "", fmt.Errorf("Ollama API returned status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	var full strings.Builder
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		var chunk ollamaGenerateChunk
+		if err := json.Unmarshal([]byte(line), &chunk); err != nil {
+			return full.String(), fmt.Errorf("decoding Ollama stream chunk: %w", err)
+		}
+		if chunk.Response != "" {
+			full.WriteString(chunk.Response)
+			if onToken != nil {
+				onToken(chunk.Response)
+			}
+		}
+		if chunk.Done {
+			break
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return full.String(), fmt.Errorf("reading Ollama stream: %w", err)
+	}
+	return full.String(), nil
+}
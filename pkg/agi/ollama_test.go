@@ -0,0 +1,67 @@
+package agi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestOllamaProviderGenerateAccumulatesStreamedChunks(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "/api/generate") {
+			t.Fatalf("unexpected path %s", r.URL.Path)
+		}
+		w.Write([]byte(`{"response":"hel","done":false}` + "\n"))
+		w.Write([]byte(`{"response":"lo","done":false}` + "\n"))
+		w.Write([]byte(`{"response":"","done":true}` + "\n"))
+	}))
+	defer server.Close()
+
+	provider := NewOllamaProvider(server.URL, "llama3")
+	var tokens []string
+	result, err := provider.Generate(context.Background(), "hi", Options{}, func(token string) {
+		tokens = append(tokens, token)
+	})
+	if err != nil {
+		t.Fatalf("Generate returned error: %v", err)
+	}
+	if result != "hello" {
+		t.Errorf("expected accumulated result %q, got %q", "hello", result)
+	}
+	if strings.Join(tokens, "") != "hello" {
+		t.Errorf("expected streamed tokens to join to %q, got %v", "hello", tokens)
+	}
+}
+
+func TestOllamaProviderGenerateStopsAtDone(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"response":"done-before-extra","done":true}` + "\n"))
+		w.Write([]byte(`{"response":"should-not-appear","done":false}` + "\n"))
+	}))
+	defer server.Close()
+
+	provider := NewOllamaProvider(server.URL, "llama3")
+	result, err := provider.Generate(context.Background(), "hi", Options{}, nil)
+	if err != nil {
+		t.Fatalf("Generate returned error: %v", err)
+	}
+	if result != "done-before-extra" {
+		t.Errorf("expected stream to stop at done=true, got %q", result)
+	}
+}
+
+func TestOllamaProviderGenerateSurfacesErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte("model not found"))
+	}))
+	defer server.Close()
+
+	provider := NewOllamaProvider(server.URL, "missing-model")
+	_, err := provider.Generate(context.Background(), "hi", Options{}, nil)
+	if err == nil || !strings.Contains(err.Error(), "model not found") {
+		t.Fatalf("expected error mentioning response body, got %v", err)
+	}
+}
@@ -0,0 +1,154 @@
+package agi
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// DefaultOpenAIBaseURL is used when OpenAIProvider.BaseURL is empty.
+const DefaultOpenAIBaseURL = "https://api.openai.com"
+
+// OpenAIProvider talks to an OpenAI-compatible chat completions HTTP
+// API - OpenAI itself, or any self-hosted server (vLLM, LM Studio,
+// etc.) that speaks the same /v1/chat/completions contract.
+type OpenAIProvider struct {
+	BaseURL string
+	APIKey  string
+	Model   string
+
+	httpClient *http.Client
+}
+
+// NewOpenAIProvider builds a Provider against baseURL (DefaultOpenAIBaseURL
+// when empty), authenticating with apiKey and defaulting to model when
+// an Options.Model isn't given per-call.
+func NewOpenAIProvider(baseURL, apiKey, model string) *OpenAIProvider {
+	if baseURL == "" {
+		baseURL = DefaultOpenAIBaseURL
+	}
+	return &OpenAIProvider{
+		BaseURL:    strings.TrimSuffix(baseURL, "/"),
+		APIKey:     apiKey,
+		Model:      model,
+		httpClient: &http.Client{Timeout: 2 * time.Minute},
+	}
+}
+
+// Name implements Provider.
+func (p *OpenAIProvider) Name() string { return "openai" }
+
+type openAIChatRequest struct {
+	Model       string              `json:"model"`
+	Messages    []openAIChatMessage `json:"messages"`
+	Stream      bool                `json:"stream"`
+	MaxTokens   int                 `json:"max_tokens,omitempty"`
+	Temperature float64             `json:"temperature,omitempty"`
+}
+
+type openAIChatMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type openAIChatResponse struct {
+	Choices []struct {
+		Message openAIChatMessage `json:"message"`
+		Delta   openAIChatMessage `json:"delta"`
+	} `json:"choices"`
+}
+
+// Generate implements Provider.
+func (p *OpenAIProvider) Generate(ctx context.Context, prompt string, opts Options, onToken TokenFunc) (string, error) {
+	model := p.Model
+	if opts.Model != "" {
+		model = opts.Model
+	}
+
+	reqBody := openAIChatRequest{
+		Model:       model,
+		Messages:    []openAIChatMessage{{Role: "user", Content: prompt}},
+		Stream:      onToken != nil,
+		MaxTokens:   opts.MaxTokens,
+		Temperature: opts.Temperature,
+	}
+	payload, err := json.Marshal(reqBody)
+	if err != nil {
+		return "", fmt.Errorf("marshaling OpenAI request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.BaseURL+"/v1/chat/completions", bytes.NewReader(payload))
+	if err != nil {
+		return "", fmt.Errorf("building OpenAI request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if p.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+p.APIKey)
+	}
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("calling OpenAI-compatible API: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		body, _ := readAllLimited(resp.Body)
+		return "", fmt.Errorf("OpenAI-compatible API returned status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	if onToken == nil {
+		var result openAIChatResponse
+		if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+			return "", fmt.Errorf("decoding OpenAI response: %w", err)
+		}
+		if len(result.Choices) == 0 {
+			return "", nil
+		}
+		return result.Choices[0].Message.Content, nil
+	}
+
+	return readOpenAISSEStream(resp.Body, onToken)
+}
+
+// readOpenAISSEStream reads an OpenAI-style "data: {...}" SSE stream,
+// calling onToken for each delta.content chunk and accumulating the
+// full response. The stream ends with a literal "data: [DONE]" line.
+func readOpenAISSEStream(body io.Reader, onToken TokenFunc) (string, error) {
+	var full strings.Builder
+	scanner := bufio.NewScanner(body)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || !strings.HasPrefix(line, "data:") {
+			continue
+		}
+		data := strings.TrimSpace(strings.TrimPrefix(line, "data:"))
+		if data == "[DONE]" {
+			break
+		}
+
+		var chunk openAIChatResponse
+		if err := json.Unmarshal([]byte(data), &chunk); err != nil {
+			return full.String(), fmt.Errorf("decoding OpenAI stream chunk: %w", err)
+		}
+		if len(chunk.Choices) == 0 {
+			continue
+		}
+		token := chunk.Choices[0].Delta.Content
+		if token == "" {
+			continue
+		}
+		full.WriteString(token)
+		onToken(token)
+	}
+	if err := scanner.Err(); err != nil {
+		return full.String(), fmt.Errorf("reading OpenAI stream: %w", err)
+	}
+	return full.String(), nil
+}
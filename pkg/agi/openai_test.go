@@ -0,0 +1,69 @@
+package agi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestOpenAIProviderGenerateDecodesMessageContent(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "/v1/chat/completions") {
+			t.Fatalf("unexpected path %s", r.URL.Path)
+		}
+		if got := r.Header.Get("Authorization"); got != "Bearer test-key" {
+			t.Errorf("expected Authorization: Bearer test-key, got %q", got)
+		}
+		w.Write([]byte(`{"choices":[{"message":{"role":"assistant","content":"hello there"}}]}`))
+	}))
+	defer server.Close()
+
+	provider := NewOpenAIProvider(server.URL, "test-key", "gpt-test")
+	result, err := provider.Generate(context.Background(), "hi", Options{}, nil)
+	if err != nil {
+		t.Fatalf("Generate returned error: %v", err)
+	}
+	if result != "hello there" {
+		t.Errorf("expected %q, got %q", "hello there", result)
+	}
+}
+
+func TestOpenAIProviderGenerateStreamsTokens(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("data: {\"choices\":[{\"delta\":{\"content\":\"hel\"}}]}\n\n"))
+		w.Write([]byte("data: {\"choices\":[{\"delta\":{\"content\":\"lo\"}}]}\n\n"))
+		w.Write([]byte("data: [DONE]\n\n"))
+	}))
+	defer server.Close()
+
+	provider := NewOpenAIProvider(server.URL, "", "gpt-test")
+	var tokens []string
+	result, err := provider.Generate(context.Background(), "hi", Options{}, func(token string) {
+		tokens = append(tokens, token)
+	})
+	if err != nil {
+		t.Fatalf("Generate returned error: %v", err)
+	}
+	if result != "hello" {
+		t.Errorf("expected accumulated result %q, got %q", "hello", result)
+	}
+	if strings.Join(tokens, "") != "hello" {
+		t.Errorf("expected streamed tokens to join to %q, got %v", "hello", tokens)
+	}
+}
+
+func TestOpenAIProviderGenerateSurfacesErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte("invalid api key"))
+	}))
+	defer server.Close()
+
+	provider := NewOpenAIProvider(server.URL, "bad-key", "gpt-test")
+	_, err := provider.Generate(context.Background(), "hi", Options{}, nil)
+	if err == nil || !strings.Contains(err.Error(), "invalid api key") {
+		t.Fatalf("expected error mentioning response body, got %v", err)
+	}
+}
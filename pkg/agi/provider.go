@@ -0,0 +1,28 @@
+package agi
+
+import "context"
+
+// Options controls a single Generate call. A zero Options uses each
+// Provider's own defaults.
+type Options struct {
+	Model       string
+	MaxTokens   int
+	Temperature float64
+}
+
+// TokenFunc receives one streamed token (or chunk of text, for
+// backends that can't split at token boundaries) as it's generated.
+type TokenFunc func(token string)
+
+// Provider generates text from a prompt against a specific LLM
+// backend.
+type Provider interface {
+	// Name identifies the backend, e.g. "openai", "ollama", "gguf".
+	Name() string
+	// Generate returns the full response to prompt. When onToken is
+	// non-nil, it's called with each token as it arrives and the
+	// backend streams its response instead of waiting for it to
+	// finish; the full response is still returned once generation
+	// completes.
+	Generate(ctx context.Context, prompt string, opts Options, onToken TokenFunc) (string, error)
+}
@@ -0,0 +1,46 @@
+package auth
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSignAndParseHS256(t *testing.T) {
+	secret := []byte("top-secret")
+	claims := NewClaims("user-42", time.Hour)
+
+	token, err := SignHS256(claims, secret)
+	if err != nil {
+		t.Fatalf("unexpected error signing: %v", err)
+	}
+
+	parsed, err := ParseHS256(token, secret)
+	if err != nil {
+		t.Fatalf("unexpected error parsing: %v", err)
+	}
+	if parsed.Subject() != "user-42" {
+		t.Errorf("expected subject %q, got %q", "user-42", parsed.Subject())
+	}
+}
+
+func TestParseHS256RejectsBadSignature(t *testing.T) {
+	token, err := SignHS256(NewClaims("user-42", time.Hour), []byte("secret-a"))
+	if err != nil {
+		t.Fatalf("unexpected error signing: %v", err)
+	}
+
+	if _, err := ParseHS256(token, []byte("secret-b")); err != ErrInvalidToken {
+		t.Errorf("expected ErrInvalidToken, got %v", err)
+	}
+}
+
+func TestParseHS256RejectsExpiredToken(t *testing.T) {
+	token, err := SignHS256(NewClaims("user-42", -time.Hour), []byte("secret"))
+	if err != nil {
+		t.Fatalf("unexpected error signing: %v", err)
+	}
+
+	if _, err := ParseHS256(token, []byte("secret")); err != ErrExpiredToken {
+		t.Errorf("expected ErrExpiredToken, got %v", err)
+	}
+}
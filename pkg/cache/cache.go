@@ -6,6 +6,8 @@
 //   - 自动清理过期条目
 //   - 线程安全（使用读写锁）
 //   - 支持通配符删除
+//   - 可选的最大字节预算，超出时按 LRU 淘汰
+//   - 可选的快照持久化
 //
 // 使用示例：
 //
@@ -17,33 +19,127 @@
 package cache
 
 import (
+	"container/list"
+	"encoding/json"
+	"os"
 	"strings"
 	"sync"
 	"time"
+
+	"gitee.com/com_818cloud/shode/pkg/events"
 )
 
+// Provider is the interface SetCache/GetCache and friends use, so the
+// standard library's cache builtins can be backed by either the
+// in-memory Cache or an alternate backend (e.g. RedisCache) without
+// changing call sites.
+type Provider interface {
+	Set(key, value string, ttlSeconds int)
+	Get(key string) (string, bool)
+	Delete(key string)
+	Clear()
+	Exists(key string) bool
+	GetTTL(key string) int
+	SetBatch(keyValues map[string]string, ttlSeconds int)
+	GetKeys(pattern string) []string
+}
+
+var _ Provider = (*Cache)(nil)
+
+// StatsProvider is implemented by cache backends that track hit/miss/
+// eviction counters locally. RedisCache doesn't implement it, since
+// that bookkeeping would live on the Redis server instead; callers
+// should type-assert a Provider to StatsProvider and handle the
+// "unsupported" case rather than assuming every backend has it.
+type StatsProvider interface {
+	Stats() CacheStats
+}
+
+var _ StatsProvider = (*Cache)(nil)
+
+// CacheStats reports cumulative counters for a Cache, exposed to
+// scripts via the GetCacheStats builtin.
+type CacheStats struct {
+	Entries   int   `json:"entries"`
+	Bytes     int64 `json:"bytes"`
+	MaxBytes  int64 `json:"maxBytes"`
+	Hits      int64 `json:"hits"`
+	Misses    int64 `json:"misses"`
+	Evictions int64 `json:"evictions"`
+}
+
 // CacheEntry represents a cache entry with value and expiration
 type CacheEntry struct {
 	Value     string
 	ExpiresAt time.Time
+
+	key     string
+	size    int64
+	element *list.Element
+}
+
+// CacheOptions configures an *Cache beyond the zero-value defaults
+// (unbounded size, no persistence).
+type CacheOptions struct {
+	// MaxBytes caps the total size of stored keys and values. Once
+	// exceeded, the least recently used entry is evicted to make
+	// room. Zero or negative means unbounded.
+	MaxBytes int64
+
+	// SnapshotPath, if set, is where Save/LoadSnapshot read and
+	// write a JSON snapshot of the cache's contents.
+	SnapshotPath string
 }
 
 // Cache provides thread-safe in-memory caching
 type Cache struct {
-	entries map[string]*CacheEntry
-	mu      sync.RWMutex
+	entries      map[string]*CacheEntry
+	lru          *list.List // front = most recently used
+	mu           sync.RWMutex
+	maxBytes     int64
+	usedBytes    int64
+	snapshotPath string
+
+	hits      int64
+	misses    int64
+	evictions int64
+
+	events *events.Bus // Publishes CacheEvicted; nil Bus is a safe no-op, so this stays unset until SetEventBus attaches one
 }
 
-// NewCache creates a new cache instance
+// SetEventBus attaches the bus evictUntilWithinBudget publishes a
+// CacheEvicted event to whenever it drops an entry. Leaving it unset
+// keeps publishing a no-op.
+func (c *Cache) SetEventBus(bus *events.Bus) {
+	c.events = bus
+}
+
+// NewCache creates a new cache instance with no size limit and no
+// persistence.
 func NewCache() *Cache {
+	return NewCacheWithOptions(CacheOptions{})
+}
+
+// NewCacheWithOptions creates a new cache instance configured by opts.
+func NewCacheWithOptions(opts CacheOptions) *Cache {
 	c := &Cache{
-		entries: make(map[string]*CacheEntry),
+		entries:      make(map[string]*CacheEntry),
+		lru:          list.New(),
+		maxBytes:     opts.MaxBytes,
+		snapshotPath: opts.SnapshotPath,
 	}
 	// Start cleanup goroutine
 	go c.cleanupExpired()
 	return c
 }
 
+// entrySize estimates the memory a key/value pair occupies, for
+// enforcing MaxBytes. It's an approximation (string header + bytes),
+// not an exact accounting of Go's runtime overhead.
+func entrySize(key, value string) int64 {
+	return int64(len(key) + len(value))
+}
+
 // Set stores a value in the cache with optional TTL
 // If ttlSeconds is 0 or negative, the entry never expires
 func (c *Cache) Set(key, value string, ttlSeconds int) {
@@ -52,6 +148,8 @@ func (c *Cache) Set(key, value string, ttlSeconds int) {
 
 	entry := &CacheEntry{
 		Value: value,
+		key:   key,
+		size:  entrySize(key, value),
 	}
 
 	if ttlSeconds > 0 {
@@ -61,26 +159,75 @@ func (c *Cache) Set(key, value string, ttlSeconds int) {
 		entry.ExpiresAt = time.Time{}
 	}
 
-	c.entries[key] = entry
+	c.setEntry(entry)
+	c.evictUntilWithinBudget(key)
+}
+
+// setEntry replaces any existing entry for entry.key, updating the
+// LRU list and the used-bytes accounting.
+func (c *Cache) setEntry(entry *CacheEntry) {
+	if existing, ok := c.entries[entry.key]; ok {
+		c.usedBytes -= existing.size
+		c.lru.Remove(existing.element)
+	}
+
+	entry.element = c.lru.PushFront(entry.key)
+	c.entries[entry.key] = entry
+	c.usedBytes += entry.size
+}
+
+// evictUntilWithinBudget removes least-recently-used entries (other
+// than keepKey, the entry just written) until usedBytes fits within
+// maxBytes. No-op when maxBytes is unset.
+func (c *Cache) evictUntilWithinBudget(keepKey string) {
+	if c.maxBytes <= 0 {
+		return
+	}
+
+	for c.usedBytes > c.maxBytes {
+		oldest := c.lru.Back()
+		if oldest == nil {
+			return
+		}
+		key := oldest.Value.(string)
+		if key == keepKey && c.lru.Len() == 1 {
+			// A single oversized entry can't be evicted any
+			// further without losing the write that just happened.
+			return
+		}
+		entry := c.entries[key]
+		c.lru.Remove(oldest)
+		delete(c.entries, key)
+		c.usedBytes -= entry.size
+		c.evictions++
+		c.events.Publish(events.CacheEvicted, map[string]interface{}{
+			"key":  key,
+			"size": entry.size,
+		})
+	}
 }
 
 // Get retrieves a value from the cache
 // Returns the value and true if found and not expired, false otherwise
 func (c *Cache) Get(key string) (string, bool) {
-	c.mu.RLock()
-	defer c.mu.RUnlock()
+	c.mu.Lock()
+	defer c.mu.Unlock()
 
 	entry, exists := c.entries[key]
 	if !exists {
+		c.misses++
 		return "", false
 	}
 
 	// Check if expired
 	if !entry.ExpiresAt.IsZero() && time.Now().After(entry.ExpiresAt) {
 		// Entry expired, but we don't delete it here (cleanup goroutine will)
+		c.misses++
 		return "", false
 	}
 
+	c.lru.MoveToFront(entry.element)
+	c.hits++
 	return entry.Value, true
 }
 
@@ -88,7 +235,17 @@ func (c *Cache) Get(key string) (string, bool) {
 func (c *Cache) Delete(key string) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
+	c.removeEntry(key)
+}
+
+func (c *Cache) removeEntry(key string) {
+	entry, exists := c.entries[key]
+	if !exists {
+		return
+	}
+	c.lru.Remove(entry.element)
 	delete(c.entries, key)
+	c.usedBytes -= entry.size
 }
 
 // Clear removes all entries from the cache
@@ -96,6 +253,8 @@ func (c *Cache) Clear() {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 	c.entries = make(map[string]*CacheEntry)
+	c.lru = list.New()
+	c.usedBytes = 0
 }
 
 // Exists checks if a key exists and is not expired
@@ -151,12 +310,17 @@ func (c *Cache) SetBatch(keyValues map[string]string, ttlSeconds int) {
 		expiresAt = time.Now().Add(time.Duration(ttlSeconds) * time.Second)
 	}
 
+	var lastKey string
 	for key, value := range keyValues {
-		c.entries[key] = &CacheEntry{
+		c.setEntry(&CacheEntry{
 			Value:     value,
 			ExpiresAt: expiresAt,
-		}
+			key:       key,
+			size:      entrySize(key, value),
+		})
+		lastKey = key
 	}
+	c.evictUntilWithinBudget(lastKey)
 }
 
 // GetKeys returns all keys matching a pattern
@@ -218,7 +382,9 @@ func (c *Cache) cleanupExpired() {
 		now := time.Now()
 		for key, entry := range c.entries {
 			if !entry.ExpiresAt.IsZero() && now.After(entry.ExpiresAt) {
+				c.lru.Remove(entry.element)
 				delete(c.entries, key)
+				c.usedBytes -= entry.size
 			}
 		}
 		c.mu.Unlock()
@@ -241,3 +407,115 @@ func (c *Cache) GetStats() (total, expired int) {
 
 	return total, expired
 }
+
+// Stats returns the cumulative hit/miss/eviction counters alongside
+// the cache's current size, satisfying StatsProvider.
+func (c *Cache) Stats() CacheStats {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return CacheStats{
+		Entries:   len(c.entries),
+		Bytes:     c.usedBytes,
+		MaxBytes:  c.maxBytes,
+		Hits:      c.hits,
+		Misses:    c.misses,
+		Evictions: c.evictions,
+	}
+}
+
+// cacheSnapshot is the on-disk format written by SaveSnapshot and
+// read back by LoadSnapshot.
+type cacheSnapshot struct {
+	Entries map[string]cacheSnapshotEntry `json:"entries"`
+}
+
+type cacheSnapshotEntry struct {
+	Value     string    `json:"value"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// SaveSnapshot writes the cache's current (non-expired) contents as
+// JSON to path, or to the path configured via CacheOptions if path
+// is empty. Entries that never expire are saved with a zero
+// ExpiresAt, same as in memory.
+func (c *Cache) SaveSnapshot(path string) error {
+	if path == "" {
+		path = c.snapshotPath
+	}
+	if path == "" {
+		return &snapshotError{op: "save", reason: "no snapshot path configured"}
+	}
+
+	c.mu.RLock()
+	snapshot := cacheSnapshot{Entries: make(map[string]cacheSnapshotEntry, len(c.entries))}
+	now := time.Now()
+	for key, entry := range c.entries {
+		if !entry.ExpiresAt.IsZero() && now.After(entry.ExpiresAt) {
+			continue
+		}
+		snapshot.Entries[key] = cacheSnapshotEntry{Value: entry.Value, ExpiresAt: entry.ExpiresAt}
+	}
+	c.mu.RUnlock()
+
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+// LoadSnapshot replaces the cache's contents with the snapshot
+// previously written by SaveSnapshot at path (or at the path
+// configured via CacheOptions if path is empty). Entries already
+// expired in the snapshot are skipped.
+func (c *Cache) LoadSnapshot(path string) error {
+	if path == "" {
+		path = c.snapshotPath
+	}
+	if path == "" {
+		return &snapshotError{op: "load", reason: "no snapshot path configured"}
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	var snapshot cacheSnapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries = make(map[string]*CacheEntry)
+	c.lru = list.New()
+	c.usedBytes = 0
+
+	now := time.Now()
+	for key, saved := range snapshot.Entries {
+		if !saved.ExpiresAt.IsZero() && now.After(saved.ExpiresAt) {
+			continue
+		}
+		c.setEntry(&CacheEntry{
+			Value:     saved.Value,
+			ExpiresAt: saved.ExpiresAt,
+			key:       key,
+			size:      entrySize(key, saved.Value),
+		})
+	}
+	c.evictUntilWithinBudget("")
+
+	return nil
+}
+
+type snapshotError struct {
+	op     string
+	reason string
+}
+
+func (e *snapshotError) Error() string {
+	return "cache: cannot " + e.op + " snapshot: " + e.reason
+}
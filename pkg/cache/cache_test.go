@@ -184,15 +184,88 @@ func TestGetTTL(t *testing.T) {
 // TestNoExpiration 测试永不过期的缓存
 func TestNoExpiration(t *testing.T) {
 	c := NewCache()
-	
+
 	// 设置 TTL 为 0，表示永不过期
 	c.Set("key1", "value1", 0)
-	
+
 	// 等待一小段时间
 	time.Sleep(100 * time.Millisecond)
-	
+
 	// 应该仍然存在
 	if !c.Exists("key1") {
 		t.Error("key1 should not expire when TTL is 0")
 	}
 }
+
+// TestLRUEvictionUnderMaxBytes 测试超出 MaxBytes 时按 LRU 淘汰
+func TestLRUEvictionUnderMaxBytes(t *testing.T) {
+	c := NewCacheWithOptions(CacheOptions{MaxBytes: 12})
+
+	c.Set("a", "12345", 0) // size 6
+	c.Set("b", "12345", 0) // size 6, total 12, still fits
+
+	// 访问 a，让它变为最近使用
+	c.Get("a")
+
+	c.Set("c", "12345", 0) // size 6, pushes total to 18, must evict
+
+	if c.Exists("b") {
+		t.Error("b should have been evicted as the least recently used entry")
+	}
+	if !c.Exists("a") {
+		t.Error("a should still exist, it was accessed most recently")
+	}
+	if !c.Exists("c") {
+		t.Error("c should still exist, it was just written")
+	}
+
+	stats := c.Stats()
+	if stats.Evictions != 1 {
+		t.Errorf("Stats().Evictions = %d, want 1", stats.Evictions)
+	}
+}
+
+// TestStatsTracksHitsAndMisses 测试命中/未命中计数
+func TestStatsTracksHitsAndMisses(t *testing.T) {
+	c := NewCache()
+	c.Set("key1", "value1", 0)
+
+	c.Get("key1")
+	c.Get("missing")
+
+	stats := c.Stats()
+	if stats.Hits != 1 {
+		t.Errorf("Stats().Hits = %d, want 1", stats.Hits)
+	}
+	if stats.Misses != 1 {
+		t.Errorf("Stats().Misses = %d, want 1", stats.Misses)
+	}
+	if stats.Entries != 1 {
+		t.Errorf("Stats().Entries = %d, want 1", stats.Entries)
+	}
+}
+
+// TestSnapshotSaveAndLoad 测试快照持久化
+func TestSnapshotSaveAndLoad(t *testing.T) {
+	path := t.TempDir() + "/cache-snapshot.json"
+
+	c := NewCacheWithOptions(CacheOptions{SnapshotPath: path})
+	c.Set("key1", "value1", 0)
+	c.Set("key2", "value2", 60)
+
+	if err := c.SaveSnapshot(""); err != nil {
+		t.Fatalf("SaveSnapshot() error = %v", err)
+	}
+
+	restored := NewCacheWithOptions(CacheOptions{SnapshotPath: path})
+	if err := restored.LoadSnapshot(""); err != nil {
+		t.Fatalf("LoadSnapshot() error = %v", err)
+	}
+
+	if value, ok := restored.Get("key1"); !ok || value != "value1" {
+		t.Errorf("Get(key1) = (%q, %v), want (%q, true)", value, ok, "value1")
+	}
+	if value, ok := restored.Get("key2"); !ok || value != "value2" {
+		t.Errorf("Get(key2) = (%q, %v), want (%q, true)", value, ok, "value2")
+	}
+}
@@ -0,0 +1,286 @@
+package cache
+
+import (
+	"bufio"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RedisConfig configures a RedisCache backend.
+type RedisConfig struct {
+	// Addrs is one "host:port" per node. A single address talks to a
+	// standalone Redis server; more than one distributes keys across
+	// nodes by hashing (see RedisCache).
+	Addrs        []string
+	Password     string
+	DB           int
+	DialTimeout  time.Duration
+	ReadTimeout  time.Duration
+	WriteTimeout time.Duration
+}
+
+// RedisCache is a Provider backed by Redis, so cached data survives
+// process restarts and is shared across instances. It speaks RESP2
+// directly over net.Conn rather than depending on a client library.
+//
+// With more than one address configured, it's cluster-aware in the
+// sense that reads and writes for a given key are always routed to
+// the same node (by hashing the key across the configured address
+// list) - it does not implement the full Redis Cluster protocol
+// (MOVED/ASK redirection, live resharding), which would need a real
+// cluster client.
+type RedisCache struct {
+	nodes []*redisNode
+}
+
+var _ Provider = (*RedisCache)(nil)
+
+// NewRedisCache dials every address in cfg.Addrs and returns a
+// RedisCache backed by all of them.
+func NewRedisCache(cfg RedisConfig) (*RedisCache, error) {
+	if len(cfg.Addrs) == 0 {
+		return nil, fmt.Errorf("redis cache requires at least one address")
+	}
+
+	rc := &RedisCache{}
+	for _, addr := range cfg.Addrs {
+		node, err := newRedisNode(addr, cfg)
+		if err != nil {
+			rc.Close()
+			return nil, fmt.Errorf("connecting to redis node %s: %w", addr, err)
+		}
+		rc.nodes = append(rc.nodes, node)
+	}
+	return rc, nil
+}
+
+// Close closes the connection to every node.
+func (rc *RedisCache) Close() error {
+	var firstErr error
+	for _, node := range rc.nodes {
+		if err := node.close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// nodeFor picks the node that owns key, by hashing when more than one
+// node is configured.
+func (rc *RedisCache) nodeFor(key string) *redisNode {
+	if len(rc.nodes) == 1 {
+		return rc.nodes[0]
+	}
+	idx := crc32.ChecksumIEEE([]byte(key)) % uint32(len(rc.nodes))
+	return rc.nodes[idx]
+}
+
+// Set stores a value in Redis with optional TTL. If ttlSeconds is 0
+// or negative, the key never expires.
+func (rc *RedisCache) Set(key, value string, ttlSeconds int) {
+	node := rc.nodeFor(key)
+	if ttlSeconds > 0 {
+		node.do("SET", key, value, "EX", strconv.Itoa(ttlSeconds))
+	} else {
+		node.do("SET", key, value)
+	}
+}
+
+// Get retrieves a value from Redis. Returns the value and true if
+// found, false otherwise (including on a connection error).
+func (rc *RedisCache) Get(key string) (string, bool) {
+	reply, err := rc.nodeFor(key).do("GET", key)
+	if err != nil || reply == nil {
+		return "", false
+	}
+	value, ok := reply.(string)
+	return value, ok
+}
+
+// Delete removes a key from Redis.
+func (rc *RedisCache) Delete(key string) {
+	rc.nodeFor(key).do("DEL", key)
+}
+
+// Clear removes all keys from every configured node's selected
+// database.
+func (rc *RedisCache) Clear() {
+	for _, node := range rc.nodes {
+		node.do("FLUSHDB")
+	}
+}
+
+// Exists checks if a key exists in Redis.
+func (rc *RedisCache) Exists(key string) bool {
+	reply, err := rc.nodeFor(key).do("EXISTS", key)
+	if err != nil {
+		return false
+	}
+	count, _ := reply.(int64)
+	return count > 0
+}
+
+// GetTTL returns the remaining TTL in seconds for a key. Returns -1
+// if the key doesn't exist, never expires, or on a connection error.
+func (rc *RedisCache) GetTTL(key string) int {
+	reply, err := rc.nodeFor(key).do("TTL", key)
+	if err != nil {
+		return -1
+	}
+	seconds, _ := reply.(int64)
+	if seconds < 0 {
+		return -1
+	}
+	return int(seconds)
+}
+
+// SetBatch sets multiple key-value pairs at once. ttlSeconds applies
+// to all entries.
+func (rc *RedisCache) SetBatch(keyValues map[string]string, ttlSeconds int) {
+	for key, value := range keyValues {
+		rc.Set(key, value, ttlSeconds)
+	}
+}
+
+// GetKeys returns all keys matching pattern (Redis glob syntax)
+// across every configured node.
+func (rc *RedisCache) GetKeys(pattern string) []string {
+	seen := make(map[string]bool)
+	var keys []string
+	for _, node := range rc.nodes {
+		reply, err := node.do("KEYS", pattern)
+		if err != nil {
+			continue
+		}
+		items, _ := reply.([]interface{})
+		for _, item := range items {
+			key, ok := item.(string)
+			if ok && !seen[key] {
+				seen[key] = true
+				keys = append(keys, key)
+			}
+		}
+	}
+	return keys
+}
+
+// redisNode is a single RESP2 connection to one Redis server.
+type redisNode struct {
+	mu   sync.Mutex
+	conn net.Conn
+	r    *bufio.Reader
+}
+
+func newRedisNode(addr string, cfg RedisConfig) (*redisNode, error) {
+	dialTimeout := cfg.DialTimeout
+	if dialTimeout <= 0 {
+		dialTimeout = 5 * time.Second
+	}
+
+	conn, err := net.DialTimeout("tcp", addr, dialTimeout)
+	if err != nil {
+		return nil, err
+	}
+
+	node := &redisNode{conn: conn, r: bufio.NewReader(conn)}
+
+	if cfg.Password != "" {
+		if _, err := node.do("AUTH", cfg.Password); err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
+	if cfg.DB != 0 {
+		if _, err := node.do("SELECT", strconv.Itoa(cfg.DB)); err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
+
+	return node, nil
+}
+
+func (n *redisNode) close() error {
+	return n.conn.Close()
+}
+
+// do sends a command as a RESP2 array of bulk strings and returns the
+// decoded reply: nil for a RESP nil, string for simple/bulk strings,
+// int64 for integers, []interface{} for arrays, or an error for a
+// RESP error reply (or a connection failure).
+func (n *redisNode) do(args ...string) (interface{}, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "*%d\r\n", len(args))
+	for _, arg := range args {
+		fmt.Fprintf(&b, "$%d\r\n%s\r\n", len(arg), arg)
+	}
+	if _, err := n.conn.Write([]byte(b.String())); err != nil {
+		return nil, err
+	}
+	return n.readReply()
+}
+
+func (n *redisNode) readReply() (interface{}, error) {
+	line, err := n.r.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	line = strings.TrimRight(line, "\r\n")
+	if len(line) == 0 {
+		return nil, fmt.Errorf("redis: empty reply")
+	}
+
+	switch line[0] {
+	case '+':
+		return line[1:], nil
+	case '-':
+		return nil, fmt.Errorf("redis: %s", line[1:])
+	case ':':
+		value, err := strconv.ParseInt(line[1:], 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		return value, nil
+	case '$':
+		size, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return nil, err
+		}
+		if size < 0 {
+			return nil, nil
+		}
+		buf := make([]byte, size+2)
+		if _, err := io.ReadFull(n.r, buf); err != nil {
+			return nil, err
+		}
+		return string(buf[:size]), nil
+	case '*':
+		count, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return nil, err
+		}
+		if count < 0 {
+			return nil, nil
+		}
+		items := make([]interface{}, count)
+		for i := 0; i < count; i++ {
+			item, err := n.readReply()
+			if err != nil {
+				return nil, err
+			}
+			items[i] = item
+		}
+		return items, nil
+	default:
+		return nil, fmt.Errorf("redis: unknown reply type %q", line[0])
+	}
+}
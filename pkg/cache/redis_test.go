@@ -0,0 +1,228 @@
+package cache
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeRedisServer is a minimal RESP2 server backing the handful of
+// commands RedisCache issues, so tests exercise the real wire
+// protocol without requiring an actual Redis server in the sandbox.
+type fakeRedisServer struct {
+	ln       net.Listener
+	mu       sync.Mutex
+	data     map[string]string
+	selected int
+}
+
+func startFakeRedisServer(t *testing.T) *fakeRedisServer {
+	t.Helper()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("net.Listen() error = %v", err)
+	}
+	srv := &fakeRedisServer{ln: ln, data: make(map[string]string)}
+	go srv.serve()
+	t.Cleanup(func() { ln.Close() })
+	return srv
+}
+
+func (s *fakeRedisServer) addr() string {
+	return s.ln.Addr().String()
+}
+
+func (s *fakeRedisServer) serve() {
+	for {
+		conn, err := s.ln.Accept()
+		if err != nil {
+			return
+		}
+		go s.handle(conn)
+	}
+}
+
+func (s *fakeRedisServer) handle(conn net.Conn) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+
+	for {
+		args, err := readCommand(r)
+		if err != nil {
+			return
+		}
+		if len(args) == 0 {
+			continue
+		}
+
+		reply := s.apply(args)
+		if _, err := conn.Write([]byte(reply)); err != nil {
+			return
+		}
+	}
+}
+
+func readCommand(r *bufio.Reader) ([]string, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	line = strings.TrimRight(line, "\r\n")
+	if len(line) == 0 || line[0] != '*' {
+		return nil, fmt.Errorf("expected array, got %q", line)
+	}
+	count, err := strconv.Atoi(line[1:])
+	if err != nil {
+		return nil, err
+	}
+
+	args := make([]string, count)
+	for i := 0; i < count; i++ {
+		sizeLine, err := r.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		sizeLine = strings.TrimRight(sizeLine, "\r\n")
+		size, err := strconv.Atoi(sizeLine[1:])
+		if err != nil {
+			return nil, err
+		}
+		buf := make([]byte, size+2)
+		if _, err := r.Read(buf); err != nil {
+			return nil, err
+		}
+		args[i] = string(buf[:size])
+	}
+	return args, nil
+}
+
+func (s *fakeRedisServer) apply(args []string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch strings.ToUpper(args[0]) {
+	case "AUTH", "SELECT":
+		return "+OK\r\n"
+	case "SET":
+		s.data[args[1]] = args[2]
+		return "+OK\r\n"
+	case "GET":
+		value, ok := s.data[args[1]]
+		if !ok {
+			return "$-1\r\n"
+		}
+		return fmt.Sprintf("$%d\r\n%s\r\n", len(value), value)
+	case "DEL":
+		if _, ok := s.data[args[1]]; ok {
+			delete(s.data, args[1])
+			return ":1\r\n"
+		}
+		return ":0\r\n"
+	case "EXISTS":
+		if _, ok := s.data[args[1]]; ok {
+			return ":1\r\n"
+		}
+		return ":0\r\n"
+	case "TTL":
+		if _, ok := s.data[args[1]]; ok {
+			return ":-1\r\n"
+		}
+		return ":-2\r\n"
+	case "KEYS":
+		var b strings.Builder
+		keys := make([]string, 0, len(s.data))
+		for k := range s.data {
+			keys = append(keys, k)
+		}
+		fmt.Fprintf(&b, "*%d\r\n", len(keys))
+		for _, k := range keys {
+			fmt.Fprintf(&b, "$%d\r\n%s\r\n", len(k), k)
+		}
+		return b.String()
+	case "FLUSHDB":
+		s.data = make(map[string]string)
+		return "+OK\r\n"
+	default:
+		return fmt.Sprintf("-ERR unknown command %q\r\n", args[0])
+	}
+}
+
+func TestRedisCacheSetGetDelete(t *testing.T) {
+	srv := startFakeRedisServer(t)
+	rc, err := NewRedisCache(RedisConfig{Addrs: []string{srv.addr()}, DialTimeout: time.Second})
+	if err != nil {
+		t.Fatalf("NewRedisCache() error = %v", err)
+	}
+	defer rc.Close()
+
+	if _, ok := rc.Get("missing"); ok {
+		t.Error("Get(missing) = found, want not found")
+	}
+
+	rc.Set("greeting", "hello", 0)
+	value, ok := rc.Get("greeting")
+	if !ok || value != "hello" {
+		t.Errorf("Get(greeting) = (%q, %v), want (%q, true)", value, ok, "hello")
+	}
+
+	if !rc.Exists("greeting") {
+		t.Error("Exists(greeting) = false, want true")
+	}
+
+	rc.Delete("greeting")
+	if rc.Exists("greeting") {
+		t.Error("Exists(greeting) after Delete = true, want false")
+	}
+}
+
+func TestRedisCacheSetBatchAndGetKeys(t *testing.T) {
+	srv := startFakeRedisServer(t)
+	rc, err := NewRedisCache(RedisConfig{Addrs: []string{srv.addr()}, DialTimeout: time.Second})
+	if err != nil {
+		t.Fatalf("NewRedisCache() error = %v", err)
+	}
+	defer rc.Close()
+
+	rc.SetBatch(map[string]string{"a": "1", "b": "2"}, 0)
+
+	keys := rc.GetKeys("*")
+	if len(keys) != 2 {
+		t.Errorf("GetKeys(*) returned %d keys, want 2", len(keys))
+	}
+}
+
+func TestRedisCacheDistributesAcrossNodes(t *testing.T) {
+	srv1 := startFakeRedisServer(t)
+	srv2 := startFakeRedisServer(t)
+	rc, err := NewRedisCache(RedisConfig{Addrs: []string{srv1.addr(), srv2.addr()}, DialTimeout: time.Second})
+	if err != nil {
+		t.Fatalf("NewRedisCache() error = %v", err)
+	}
+	defer rc.Close()
+
+	for i := 0; i < 20; i++ {
+		rc.Set(fmt.Sprintf("key-%d", i), "v", 0)
+	}
+
+	total := len(rc.GetKeys("*"))
+	if total != 20 {
+		t.Errorf("GetKeys(*) across both nodes returned %d keys, want 20", total)
+	}
+
+	srv1.mu.Lock()
+	n1 := len(srv1.data)
+	srv1.mu.Unlock()
+	srv2.mu.Lock()
+	n2 := len(srv2.data)
+	srv2.mu.Unlock()
+
+	if n1 == 0 || n2 == 0 {
+		t.Errorf("expected keys spread across both nodes, got %d and %d", n1, n2)
+	}
+}
@@ -0,0 +1,39 @@
+package ci
+
+import "sort"
+
+// ExpandMatrix returns every combination of matrix's axes as a list
+// of variable assignments, in deterministic order (axes sorted by
+// name, values in the order given). An empty matrix expands to a
+// single empty combination, so a job with no [jobs.matrix] section
+// still runs exactly once.
+func ExpandMatrix(matrix map[string][]string) []map[string]string {
+	if len(matrix) == 0 {
+		return []map[string]string{{}}
+	}
+
+	axes := make([]string, 0, len(matrix))
+	for axis := range matrix {
+		axes = append(axes, axis)
+	}
+	sort.Strings(axes)
+
+	combos := []map[string]string{{}}
+	for _, axis := range axes {
+		values := matrix[axis]
+		next := make([]map[string]string, 0, len(combos)*len(values))
+		for _, combo := range combos {
+			for _, value := range values {
+				extended := make(map[string]string, len(combo)+1)
+				for k, v := range combo {
+					extended[k] = v
+				}
+				extended[axis] = value
+				next = append(next, extended)
+			}
+		}
+		combos = next
+	}
+
+	return combos
+}
@@ -0,0 +1,42 @@
+package ci
+
+import "testing"
+
+func TestExpandMatrixEmptyYieldsOneEmptyCombo(t *testing.T) {
+	combos := ExpandMatrix(nil)
+	if len(combos) != 1 || len(combos[0]) != 0 {
+		t.Fatalf("expected a single empty combination, got %v", combos)
+	}
+}
+
+func TestExpandMatrixCartesianProduct(t *testing.T) {
+	combos := ExpandMatrix(map[string][]string{
+		"os":         {"linux", "darwin"},
+		"go_version": {"1.21", "1.22"},
+	})
+
+	if len(combos) != 4 {
+		t.Fatalf("expected 4 combinations, got %d: %v", len(combos), combos)
+	}
+
+	seen := map[string]bool{}
+	for _, combo := range combos {
+		seen[combo["os"]+"/"+combo["go_version"]] = true
+	}
+	for _, want := range []string{"linux/1.21", "linux/1.22", "darwin/1.21", "darwin/1.22"} {
+		if !seen[want] {
+			t.Errorf("expected combination %q, got %v", want, combos)
+		}
+	}
+}
+
+func TestMatrixSuffixDeterministicOrder(t *testing.T) {
+	a := matrixSuffix(map[string]string{"os": "linux", "go_version": "1.22"})
+	b := matrixSuffix(map[string]string{"go_version": "1.22", "os": "linux"})
+	if a != b {
+		t.Errorf("expected matrixSuffix to be order-independent, got %q vs %q", a, b)
+	}
+	if a != "-go_version-1.22-os-linux" {
+		t.Errorf("unexpected suffix: %q", a)
+	}
+}
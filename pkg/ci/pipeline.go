@@ -0,0 +1,77 @@
+// Package ci implements shode's pipeline runner: a stages/jobs/matrix
+// definition format, executed through the same execution engine and
+// sandbox policies "shode run" uses, so a team can run the exact same
+// pipeline locally with "shode ci run" and in their CI provider of
+// choice without maintaining two definitions.
+package ci
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/BurntSushi/toml"
+)
+
+// PipelineConfigFile is the default name of a pipeline definition.
+const PipelineConfigFile = "shode-ci.toml"
+
+// Pipeline is a full pipeline definition: an ordered list of stages,
+// each run to completion before the next one starts.
+type Pipeline struct {
+	Stages []Stage `toml:"stages"`
+}
+
+// Stage is a named group of jobs that run concurrently with each
+// other, bounded by the runner's concurrency limit.
+type Stage struct {
+	Name string `toml:"name"`
+	Jobs []Job  `toml:"jobs"`
+}
+
+// Job is a single script to run, optionally expanded into several
+// runs by Matrix, with its own artifacts and cache.
+type Job struct {
+	Name      string              `toml:"name"`
+	Script    string              `toml:"script" validate:"required"`
+	Matrix    map[string][]string `toml:"matrix"`
+	Artifacts []string            `toml:"artifacts"`
+	Cache     CacheConfig         `toml:"cache"`
+}
+
+// CacheConfig describes a directory cache keyed by Key: Paths are
+// restored from the cache before the job runs and saved back after it
+// succeeds, so dependency directories (module caches, build output)
+// survive between pipeline runs instead of being rebuilt every time.
+type CacheConfig struct {
+	Key   string   `toml:"key"`
+	Paths []string `toml:"paths"`
+}
+
+// LoadPipeline reads and parses the pipeline definition at path.
+func LoadPipeline(path string) (*Pipeline, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read pipeline file: %w", err)
+	}
+
+	var p Pipeline
+	if _, err := toml.Decode(string(data), &p); err != nil {
+		return nil, fmt.Errorf("failed to parse pipeline file: %w", err)
+	}
+
+	for _, stage := range p.Stages {
+		if stage.Name == "" {
+			return nil, fmt.Errorf("pipeline has a stage with no name")
+		}
+		for _, job := range stage.Jobs {
+			if job.Name == "" {
+				return nil, fmt.Errorf("stage %q has a job with no name", stage.Name)
+			}
+			if job.Script == "" {
+				return nil, fmt.Errorf("job %q in stage %q has no script", job.Name, stage.Name)
+			}
+		}
+	}
+
+	return &p, nil
+}
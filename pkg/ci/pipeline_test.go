@@ -0,0 +1,90 @@
+package ci
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writePipeline(t *testing.T, contents string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "shode-ci.toml")
+	if err := os.WriteFile(path, []byte(contents), 0644); err != nil {
+		t.Fatalf("failed to write pipeline file: %v", err)
+	}
+	return path
+}
+
+func TestLoadPipelineParsesStagesAndJobs(t *testing.T) {
+	path := writePipeline(t, `
+[[stages]]
+name = "test"
+
+[[stages.jobs]]
+name = "unit"
+script = "test.sh"
+artifacts = ["coverage.out"]
+
+[stages.jobs.matrix]
+go_version = ["1.21", "1.22"]
+
+[stages.jobs.cache]
+key = "gomodcache"
+paths = [".cache"]
+
+[[stages]]
+name = "build"
+
+[[stages.jobs]]
+name = "compile"
+script = "build.sh"
+`)
+
+	pipeline, err := LoadPipeline(path)
+	if err != nil {
+		t.Fatalf("LoadPipeline returned error: %v", err)
+	}
+	if len(pipeline.Stages) != 2 {
+		t.Fatalf("expected 2 stages, got %d", len(pipeline.Stages))
+	}
+
+	testStage := pipeline.Stages[0]
+	if testStage.Name != "test" || len(testStage.Jobs) != 1 {
+		t.Fatalf("unexpected test stage: %+v", testStage)
+	}
+	job := testStage.Jobs[0]
+	if job.Script != "test.sh" {
+		t.Errorf("expected script %q, got %q", "test.sh", job.Script)
+	}
+	if len(job.Matrix["go_version"]) != 2 {
+		t.Errorf("expected 2 go_version matrix values, got %v", job.Matrix["go_version"])
+	}
+	if job.Cache.Key != "gomodcache" {
+		t.Errorf("expected cache key %q, got %q", "gomodcache", job.Cache.Key)
+	}
+
+	buildStage := pipeline.Stages[1]
+	if buildStage.Name != "build" || len(buildStage.Jobs) != 1 {
+		t.Fatalf("unexpected build stage: %+v", buildStage)
+	}
+}
+
+func TestLoadPipelineRejectsJobWithoutScript(t *testing.T) {
+	path := writePipeline(t, `
+[[stages]]
+name = "test"
+
+[[stages.jobs]]
+name = "unit"
+`)
+
+	if _, err := LoadPipeline(path); err == nil {
+		t.Fatal("expected an error for a job with no script")
+	}
+}
+
+func TestLoadPipelineRejectsMissingFile(t *testing.T) {
+	if _, err := LoadPipeline("/does/not/exist.toml"); err == nil {
+		t.Fatal("expected an error loading a missing pipeline file")
+	}
+}
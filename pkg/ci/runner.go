@@ -0,0 +1,267 @@
+package ci
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"gitee.com/com_818cloud/shode/pkg/engine"
+	"gitee.com/com_818cloud/shode/pkg/environment"
+	"gitee.com/com_818cloud/shode/pkg/module"
+	"gitee.com/com_818cloud/shode/pkg/parser"
+	"gitee.com/com_818cloud/shode/pkg/sandbox"
+	"gitee.com/com_818cloud/shode/pkg/stdlib"
+)
+
+// JobResult is the outcome of one concrete job run - one matrix
+// combination of a Job.
+type JobResult struct {
+	Stage     string
+	Job       string
+	Matrix    map[string]string
+	Success   bool
+	ExitCode  int
+	Output    string
+	Error     string
+	Duration  time.Duration
+	Artifacts []string
+}
+
+// Runner executes a Pipeline's stages and jobs against real scripts
+// under ProjectDir, building a fresh execution engine per job run the
+// same way "shode run" does, so a job sees the same sandbox policy an
+// interactive run would.
+type Runner struct {
+	ProjectDir   string
+	Security     *sandbox.SecurityChecker
+	ArtifactsDir string // default: <ProjectDir>/.shode/ci-artifacts
+	CacheDir     string // default: <ProjectDir>/.shode/ci-cache
+	Concurrency  int    // max jobs run at once within a stage; <1 means 1
+}
+
+// NewRunner creates a Runner rooted at projectDir with the given
+// sandbox policy (nil permits every operation) and concurrency limit.
+func NewRunner(projectDir string, security *sandbox.SecurityChecker, concurrency int) *Runner {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	return &Runner{
+		ProjectDir:   projectDir,
+		Security:     security,
+		ArtifactsDir: filepath.Join(projectDir, ".shode", "ci-artifacts"),
+		CacheDir:     filepath.Join(projectDir, ".shode", "ci-cache"),
+		Concurrency:  concurrency,
+	}
+}
+
+// Run executes every stage in order. Jobs within a stage (after
+// matrix expansion) run concurrently, bounded by r.Concurrency - the
+// pipeline's parallel scheduler. A stage with any failed job stops
+// the pipeline before the next stage starts; Run still returns every
+// result produced so far, including the failing stage's.
+func (r *Runner) Run(ctx context.Context, pipeline *Pipeline) ([]JobResult, error) {
+	// Scripts and their redirects/cache paths are written relative to
+	// the process's working directory, the same way an interactive
+	// "shode run" resolves them - so jobs need that directory to be
+	// ProjectDir for the duration of the pipeline. Every job in a
+	// pipeline shares ProjectDir, so one chdir up front is enough even
+	// though jobs within a stage run concurrently.
+	previousDir, err := os.Getwd()
+	if err != nil {
+		return nil, fmt.Errorf("failed to determine current directory: %w", err)
+	}
+	if err := os.Chdir(r.ProjectDir); err != nil {
+		return nil, fmt.Errorf("failed to enter project directory %s: %w", r.ProjectDir, err)
+	}
+	defer os.Chdir(previousDir)
+
+	var all []JobResult
+	for _, stage := range pipeline.Stages {
+		results := r.runStage(ctx, stage)
+		all = append(all, results...)
+
+		failed := false
+		for _, res := range results {
+			if !res.Success {
+				failed = true
+			}
+		}
+		if failed {
+			return all, fmt.Errorf("stage %q failed", stage.Name)
+		}
+	}
+	return all, nil
+}
+
+type jobRun struct {
+	job    Job
+	matrix map[string]string
+}
+
+func (r *Runner) runStage(ctx context.Context, stage Stage) []JobResult {
+	var runs []jobRun
+	for _, job := range stage.Jobs {
+		for _, combo := range ExpandMatrix(job.Matrix) {
+			runs = append(runs, jobRun{job: job, matrix: combo})
+		}
+	}
+
+	results := make([]JobResult, len(runs))
+	sem := make(chan struct{}, r.Concurrency)
+	var wg sync.WaitGroup
+	for i, run := range runs {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, run jobRun) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			results[i] = r.runJob(ctx, stage.Name, run.job, run.matrix)
+		}(i, run)
+	}
+	wg.Wait()
+	return results
+}
+
+func (r *Runner) runJob(ctx context.Context, stageName string, job Job, matrix map[string]string) JobResult {
+	result := JobResult{Stage: stageName, Job: job.Name, Matrix: matrix}
+
+	scriptPath := job.Script
+	if !filepath.IsAbs(scriptPath) {
+		scriptPath = filepath.Join(r.ProjectDir, scriptPath)
+	}
+
+	if len(job.Cache.Paths) > 0 {
+		r.restoreCache(job.Cache)
+	}
+
+	treeParser := parser.NewParser()
+	script, err := treeParser.ParseFile(scriptPath)
+	if err != nil {
+		result.Error = fmt.Sprintf("failed to parse script: %v", err)
+		return result
+	}
+
+	envManager := environment.NewEnvironmentManager()
+	for k, v := range matrix {
+		envManager.Set("MATRIX_"+strings.ToUpper(k), v)
+	}
+
+	security := r.Security
+	if security == nil {
+		security = sandbox.NewSecurityChecker()
+	}
+	stdLib := stdlib.New()
+	stdLib.SetSecurityChecker(security)
+	moduleMgr := module.NewModuleManager()
+
+	executionEngine := engine.NewExecutionEngine(envManager, stdLib, moduleMgr, security)
+	executionEngine.SetScriptPath(scriptPath)
+
+	start := time.Now()
+	execResult, err := executionEngine.Execute(ctx, script)
+	result.Duration = time.Since(start)
+	if err != nil {
+		result.Error = fmt.Sprintf("execution error: %v", err)
+		return result
+	}
+
+	result.Success = execResult.Success
+	result.ExitCode = execResult.ExitCode
+	result.Output = execResult.Output
+	result.Error = execResult.Error
+
+	if result.Success && len(job.Cache.Paths) > 0 {
+		r.saveCache(job.Cache)
+	}
+	if len(job.Artifacts) > 0 {
+		result.Artifacts = r.collectArtifacts(stageName, job.Name, matrix, job.Artifacts)
+	}
+
+	return result
+}
+
+// collectArtifacts copies each declared artifact path into
+// r.ArtifactsDir, under a directory named for the stage, job, and
+// matrix combination so runs don't overwrite each other's artifacts.
+// A path that doesn't exist is silently skipped - a job that didn't
+// produce a declared artifact isn't itself a pipeline failure.
+func (r *Runner) collectArtifacts(stage, job string, matrix map[string]string, paths []string) []string {
+	destDir := filepath.Join(r.ArtifactsDir, stage, job+matrixSuffix(matrix))
+
+	var collected []string
+	for _, p := range paths {
+		src := p
+		if !filepath.IsAbs(src) {
+			src = filepath.Join(r.ProjectDir, p)
+		}
+		if _, err := os.Stat(src); err != nil {
+			continue
+		}
+		dst := filepath.Join(destDir, filepath.Base(p))
+		if err := copyPath(src, dst); err != nil {
+			continue
+		}
+		collected = append(collected, dst)
+	}
+	return collected
+}
+
+// restoreCache copies each of cache.Paths's previously saved contents
+// (keyed by cache.Key) back into place before the job runs. A cache
+// miss - nothing saved yet for this key - is not an error.
+func (r *Runner) restoreCache(cache CacheConfig) {
+	cacheRoot := filepath.Join(r.CacheDir, cache.Key)
+	for _, p := range cache.Paths {
+		dst := p
+		if !filepath.IsAbs(dst) {
+			dst = filepath.Join(r.ProjectDir, p)
+		}
+		src := filepath.Join(cacheRoot, filepath.Base(p))
+		if _, err := os.Stat(src); err != nil {
+			continue
+		}
+		copyPath(src, dst)
+	}
+}
+
+// saveCache copies each of cache.Paths's current contents into the
+// cache keyed by cache.Key, for the next run to restore.
+func (r *Runner) saveCache(cache CacheConfig) {
+	cacheRoot := filepath.Join(r.CacheDir, cache.Key)
+	for _, p := range cache.Paths {
+		src := p
+		if !filepath.IsAbs(src) {
+			src = filepath.Join(r.ProjectDir, p)
+		}
+		if _, err := os.Stat(src); err != nil {
+			continue
+		}
+		dst := filepath.Join(cacheRoot, filepath.Base(p))
+		copyPath(src, dst)
+	}
+}
+
+// matrixSuffix renders matrix as a deterministic, filesystem-safe
+// directory suffix ("-axis-value-axis-value..."), or "" when matrix
+// is empty.
+func matrixSuffix(matrix map[string]string) string {
+	if len(matrix) == 0 {
+		return ""
+	}
+	keys := make([]string, 0, len(matrix))
+	for k := range matrix {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	parts := make([]string, 0, len(keys))
+	for _, k := range keys {
+		parts = append(parts, k+"-"+matrix[k])
+	}
+	return "-" + strings.Join(parts, "-")
+}
@@ -0,0 +1,179 @@
+package ci
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeScript(t *testing.T, dir, name, contents string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(contents), 0755); err != nil {
+		t.Fatalf("failed to write script %s: %v", name, err)
+	}
+	return path
+}
+
+func TestRunnerRunsJobsWithinStageConcurrently(t *testing.T) {
+	dir := t.TempDir()
+	writeScript(t, dir, "ok.sh", "true\n")
+
+	pipeline := &Pipeline{Stages: []Stage{
+		{Name: "test", Jobs: []Job{
+			{Name: "a", Script: "ok.sh"},
+			{Name: "b", Script: "ok.sh"},
+		}},
+	}}
+
+	runner := NewRunner(dir, nil, 4)
+	results, err := runner.Run(context.Background(), pipeline)
+	if err != nil {
+		t.Fatalf("Run returned error: %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("expected 2 results, got %d", len(results))
+	}
+	for _, r := range results {
+		if !r.Success {
+			t.Errorf("expected job %q to succeed, got %+v", r.Job, r)
+		}
+	}
+}
+
+func TestRunnerExpandsMatrixIntoSeparateRuns(t *testing.T) {
+	dir := t.TempDir()
+	writeScript(t, dir, "ok.sh", "true\n")
+
+	pipeline := &Pipeline{Stages: []Stage{
+		{Name: "test", Jobs: []Job{
+			{Name: "unit", Script: "ok.sh", Matrix: map[string][]string{
+				"go_version": {"1.21", "1.22"},
+			}},
+		}},
+	}}
+
+	runner := NewRunner(dir, nil, 2)
+	results, err := runner.Run(context.Background(), pipeline)
+	if err != nil {
+		t.Fatalf("Run returned error: %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("expected 2 matrix runs, got %d", len(results))
+	}
+	seen := map[string]bool{}
+	for _, r := range results {
+		seen[r.Matrix["go_version"]] = true
+	}
+	if !seen["1.21"] || !seen["1.22"] {
+		t.Errorf("expected both matrix values to run, got %v", results)
+	}
+}
+
+func TestRunnerStopsAfterFailedStage(t *testing.T) {
+	dir := t.TempDir()
+	writeScript(t, dir, "ok.sh", "true\n")
+	writeScript(t, dir, "fail.sh", "false\n")
+
+	pipeline := &Pipeline{Stages: []Stage{
+		{Name: "test", Jobs: []Job{{Name: "broken", Script: "fail.sh"}}},
+		{Name: "deploy", Jobs: []Job{{Name: "ship", Script: "ok.sh"}}},
+	}}
+
+	runner := NewRunner(dir, nil, 1)
+	results, err := runner.Run(context.Background(), pipeline)
+	if err == nil {
+		t.Fatal("expected an error when a stage fails")
+	}
+	if len(results) != 1 {
+		t.Fatalf("expected only the failed stage's result, got %d: %+v", len(results), results)
+	}
+	if results[0].Success {
+		t.Errorf("expected the job to have failed: %+v", results[0])
+	}
+}
+
+func TestRunnerCollectsArtifacts(t *testing.T) {
+	dir := t.TempDir()
+	writeScript(t, dir, "build.sh", "echo building > built.txt\n")
+
+	pipeline := &Pipeline{Stages: []Stage{
+		{Name: "build", Jobs: []Job{
+			{Name: "compile", Script: "build.sh", Artifacts: []string{"built.txt"}},
+		}},
+	}}
+
+	runner := NewRunner(dir, nil, 1)
+	results, err := runner.Run(context.Background(), pipeline)
+	if err != nil {
+		t.Fatalf("Run returned error: %v", err)
+	}
+	if len(results) != 1 || len(results[0].Artifacts) != 1 {
+		t.Fatalf("expected 1 collected artifact, got %+v", results)
+	}
+	if _, err := os.Stat(results[0].Artifacts[0]); err != nil {
+		t.Errorf("expected artifact to exist at %s: %v", results[0].Artifacts[0], err)
+	}
+}
+
+func TestRunnerSkipsMissingArtifactsWithoutFailing(t *testing.T) {
+	dir := t.TempDir()
+	writeScript(t, dir, "ok.sh", "true\n")
+
+	pipeline := &Pipeline{Stages: []Stage{
+		{Name: "build", Jobs: []Job{
+			{Name: "compile", Script: "ok.sh", Artifacts: []string{"never-written.txt"}},
+		}},
+	}}
+
+	runner := NewRunner(dir, nil, 1)
+	results, err := runner.Run(context.Background(), pipeline)
+	if err != nil {
+		t.Fatalf("Run returned error: %v", err)
+	}
+	if len(results[0].Artifacts) != 0 {
+		t.Errorf("expected no artifacts collected, got %v", results[0].Artifacts)
+	}
+}
+
+func TestRunnerSavesAndRestoresCache(t *testing.T) {
+	dir := t.TempDir()
+	writeScript(t, dir, "save.sh", "mkdir cache-dir\necho cached > cache-dir/data.txt\n")
+
+	pipeline := &Pipeline{Stages: []Stage{
+		{Name: "build", Jobs: []Job{
+			{Name: "compile", Script: "save.sh", Cache: CacheConfig{Key: "mycache", Paths: []string{"cache-dir"}}},
+		}},
+	}}
+
+	runner := NewRunner(dir, nil, 1)
+	if _, err := runner.Run(context.Background(), pipeline); err != nil {
+		t.Fatalf("Run returned error: %v", err)
+	}
+
+	savedPath := filepath.Join(runner.CacheDir, "mycache", "cache-dir", "data.txt")
+	if _, err := os.Stat(savedPath); err != nil {
+		t.Fatalf("expected cache to have been saved at %s: %v", savedPath, err)
+	}
+
+	if err := os.RemoveAll(filepath.Join(dir, "cache-dir")); err != nil {
+		t.Fatalf("failed to remove working copy: %v", err)
+	}
+
+	// A second job that only reads the cache, never recreating
+	// cache-dir itself, proves restoreCache actually ran before it.
+	writeScript(t, dir, "read.sh", "cat cache-dir/data.txt\n")
+	pipeline2 := &Pipeline{Stages: []Stage{
+		{Name: "build", Jobs: []Job{
+			{Name: "compile", Script: "read.sh", Cache: CacheConfig{Key: "mycache", Paths: []string{"cache-dir"}}},
+		}},
+	}}
+	results, err := runner.Run(context.Background(), pipeline2)
+	if err != nil {
+		t.Fatalf("second Run returned error: %v", err)
+	}
+	if results[0].Output == "" {
+		t.Errorf("expected restored cache contents in output, got empty output: %+v", results[0])
+	}
+}
@@ -2,26 +2,33 @@
 package generate
 
 import (
+	"bytes"
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
+	"text/template"
 )
 
 // Generator 代码生成器
 type Generator struct {
-	Package string
-	Type    string
-	Name    string
-	Fields  map[string]string
+	Package      string
+	Type         string
+	Name         string
+	Fields       map[string]string
+	TemplatesDir string                 // project directory holding <kind>.tmpl overrides, defaults to "templates"
+	Vars         map[string]interface{} // custom variables exposed to user templates
 }
 
 // NewGenerator 创建生成器
 func NewGenerator(pkg, typ, name string) *Generator {
 	return &Generator{
-		Package: pkg,
-		Type:    typ,
-		Name:    name,
-		Fields:  make(map[string]string),
+		Package:      pkg,
+		Type:         typ,
+		Name:         name,
+		Fields:       make(map[string]string),
+		TemplatesDir: "templates",
+		Vars:         make(map[string]interface{}),
 	}
 }
 
@@ -32,6 +39,8 @@ func (g *Generator) Generate() error {
 		return g.generateModel()
 	case "crud", "handler":
 		return g.generateCRUD()
+	case "repository":
+		return g.generateRepository()
 	case "service":
 		return g.generateService()
 	default:
@@ -39,10 +48,62 @@ func (g *Generator) Generate() error {
 	}
 }
 
+// TemplateData is the value passed to a user-supplied template when
+// rendering model/repository/service/handler code.
+type TemplateData struct {
+	Package string
+	Type    string
+	Name    string
+	Fields  map[string]string
+	Vars    map[string]interface{}
+}
+
+func (g *Generator) templateData() TemplateData {
+	return TemplateData{Package: g.Package, Type: g.Type, Name: g.Name, Fields: g.Fields, Vars: g.Vars}
+}
+
+// renderCustomTemplate looks for <TemplatesDir>/<kind>.tmpl and, if
+// present, renders it (Go text/template, given TemplateData) to
+// filename instead of the built-in generator for that kind - this is
+// how a project overrides generated code to match its own conventions.
+// used is false when no such template file exists, so the caller
+// should fall back to its built-in template.
+func (g *Generator) renderCustomTemplate(kind, filename string) (used bool, err error) {
+	dir := g.TemplatesDir
+	if dir == "" {
+		dir = "templates"
+	}
+	path := filepath.Join(dir, kind+".tmpl")
+
+	content, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return true, err
+	}
+
+	tmpl, err := template.New(kind).Parse(string(content))
+	if err != nil {
+		return true, fmt.Errorf("parse %s template: %w", path, err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, g.templateData()); err != nil {
+		return true, fmt.Errorf("render %s template: %w", path, err)
+	}
+
+	return true, os.WriteFile(filename, buf.Bytes(), 0644)
+}
+
 // generateModel 生成 Model
 func (g *Generator) generateModel() error {
 	filename := fmt.Sprintf("internal/model/%s.go", strings.ToLower(g.Name))
 
+	if used, err := g.renderCustomTemplate("model", filename); used {
+		return err
+	}
+
 	content := "package model\n\nimport \"time\"\n\n// " + g.Name + " 数据模型\ntype " + g.Name + " struct {\n"
 	content += "\tID        uint      " + "`" + "json:\"id\" gorm:\"primaryKey\"" + "`" + "\n"
 	content += "\tCreatedAt time.Time " + "`" + "json:\"created_at\"" + "`" + "\n"
@@ -56,6 +117,10 @@ func (g *Generator) generateModel() error {
 func (g *Generator) generateCRUD() error {
 	filename := fmt.Sprintf("internal/handler/%s_handler.go", strings.ToLower(g.Name))
 
+	if used, err := g.renderCustomTemplate("handler", filename); used {
+		return err
+	}
+
 	content := "package handler\n\n"
 	content += "import (\n"
 	content += "\t\"net/http\"\n"
@@ -92,10 +157,62 @@ func (g *Generator) generateCRUD() error {
 	return os.WriteFile(filename, []byte(content), 0644)
 }
 
+// generateRepository 生成 Repository
+func (g *Generator) generateRepository() error {
+	filename := fmt.Sprintf("internal/repository/%s_repository.go", strings.ToLower(g.Name))
+
+	if used, err := g.renderCustomTemplate("repository", filename); used {
+		return err
+	}
+
+	content := "package repository\n\n"
+	content += "// " + g.Name + "Repository " + g.Name + " 仓储接口\n"
+	content += "type " + g.Name + "Repository interface {\n"
+	content += "\tCreate(data interface{}) error\n"
+	content += "\tGet(id uint) (interface{}, error)\n"
+	content += "\tUpdate(id uint, data interface{}) error\n"
+	content += "\tDelete(id uint) error\n"
+	content += "\tList() ([]interface{}, error)\n"
+	content += "}\n\n"
+	content += "// " + g.Name + "RepositoryImpl " + g.Name + " 仓储实现\n"
+	content += "type " + g.Name + "RepositoryImpl struct {\n"
+	content += "}\n\n"
+	content += "// New" + g.Name + "Repository 创建仓储\n"
+	content += "func New" + g.Name + "Repository() " + g.Name + "Repository {\n"
+	content += "\treturn &" + g.Name + "RepositoryImpl{}\n"
+	content += "}\n\n"
+	content += "// Create 创建\n"
+	content += "func (r *" + g.Name + "RepositoryImpl) Create(data interface{}) error {\n"
+	content += "\treturn nil\n"
+	content += "}\n\n"
+	content += "// Get 获取\n"
+	content += "func (r *" + g.Name + "RepositoryImpl) Get(id uint) (interface{}, error) {\n"
+	content += "\treturn nil, nil\n"
+	content += "}\n\n"
+	content += "// Update 更新\n"
+	content += "func (r *" + g.Name + "RepositoryImpl) Update(id uint, data interface{}) error {\n"
+	content += "\treturn nil\n"
+	content += "}\n\n"
+	content += "// Delete 删除\n"
+	content += "func (r *" + g.Name + "RepositoryImpl) Delete(id uint) error {\n"
+	content += "\treturn nil\n"
+	content += "}\n\n"
+	content += "// List 列表\n"
+	content += "func (r *" + g.Name + "RepositoryImpl) List() ([]interface{}, error) {\n"
+	content += "\treturn nil, nil\n"
+	content += "}\n"
+
+	return os.WriteFile(filename, []byte(content), 0644)
+}
+
 // generateService 生成 Service
 func (g *Generator) generateService() error {
 	filename := fmt.Sprintf("internal/service/%s_service.go", strings.ToLower(g.Name))
 
+	if used, err := g.renderCustomTemplate("service", filename); used {
+		return err
+	}
+
 	content := "package service\n\n"
 	content += "// " + g.Name + "Service " + g.Name + " 服务\n"
 	content += "type " + g.Name + "Service struct {\n"
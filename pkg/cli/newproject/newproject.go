@@ -0,0 +1,105 @@
+// Package newproject 为 `shode new` 命令提供项目模板：生成 shode.toml、
+// 目录结构、示例脚本和 CI 配置，供 cli-script、web-api、
+// serverless-function、package 四种项目类型使用。
+package newproject
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Template 是一个可生成的项目模板名称。
+type Template string
+
+const (
+	TemplateCLIScript  Template = "cli-script"
+	TemplateWebAPI     Template = "web-api"
+	TemplateServerless Template = "serverless-function"
+	TemplatePackage    Template = "package"
+)
+
+// Templates 返回所有受支持的模板名称，供 `shode new --list-templates`
+// 展示。
+func Templates() []string {
+	return []string{
+		string(TemplateCLIScript),
+		string(TemplateWebAPI),
+		string(TemplateServerless),
+		string(TemplatePackage),
+	}
+}
+
+// IsValidTemplate 检查 name 是否是受支持的模板。
+func IsValidTemplate(name string) bool {
+	for _, t := range Templates() {
+		if t == name {
+			return true
+		}
+	}
+	return false
+}
+
+// file 是模板生成的一个相对路径及其内容。
+type file struct {
+	path    string
+	content string
+}
+
+// Generator 根据模板和项目名生成项目骨架。
+type Generator struct {
+	Template    Template
+	ProjectName string
+}
+
+// NewGenerator 创建一个 Generator。template 必须是 Templates() 中的
+// 一个值，否则 Generate 会返回错误。
+func NewGenerator(template, projectName string) *Generator {
+	return &Generator{
+		Template:    Template(template),
+		ProjectName: projectName,
+	}
+}
+
+// Generate 在 destDir 下创建项目骨架：shode.toml、目录结构、示例脚本
+// 和 CI 配置。destDir 必须不存在或为空目录。
+func (g *Generator) Generate(destDir string) error {
+	if !IsValidTemplate(string(g.Template)) {
+		return fmt.Errorf("unknown template: %s", g.Template)
+	}
+	if g.ProjectName == "" {
+		return fmt.Errorf("project name is required")
+	}
+
+	files, err := g.files()
+	if err != nil {
+		return err
+	}
+
+	for _, f := range files {
+		destPath := filepath.Join(destDir, f.path)
+		if err := os.MkdirAll(filepath.Dir(destPath), 0755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(destPath, []byte(f.content), 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// files 返回该模板要生成的全部文件，按模板类型分派。
+func (g *Generator) files() ([]file, error) {
+	switch g.Template {
+	case TemplateCLIScript:
+		return g.cliScriptFiles(), nil
+	case TemplateWebAPI:
+		return g.webAPIFiles(), nil
+	case TemplateServerless:
+		return g.serverlessFiles(), nil
+	case TemplatePackage:
+		return g.packageFiles(), nil
+	default:
+		return nil, fmt.Errorf("unknown template: %s", g.Template)
+	}
+}
@@ -0,0 +1,68 @@
+package newproject
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/BurntSushi/toml"
+
+	"gitee.com/com_818cloud/shode/pkg/config"
+)
+
+func TestGenerateRejectsUnknownTemplate(t *testing.T) {
+	g := NewGenerator("not-a-template", "myapp")
+	if err := g.Generate(t.TempDir()); err == nil {
+		t.Fatal("expected an error for an unknown template")
+	}
+}
+
+func TestGenerateRejectsEmptyProjectName(t *testing.T) {
+	g := NewGenerator(string(TemplateCLIScript), "")
+	if err := g.Generate(t.TempDir()); err == nil {
+		t.Fatal("expected an error for an empty project name")
+	}
+}
+
+func TestGenerateWritesValidShodeTomlForEveryTemplate(t *testing.T) {
+	for _, tmpl := range Templates() {
+		dir := t.TempDir()
+		g := NewGenerator(tmpl, "myapp")
+		if err := g.Generate(dir); err != nil {
+			t.Fatalf("template %s: Generate returned error: %v", tmpl, err)
+		}
+
+		var cfg config.ProjectConfig
+		if _, err := toml.DecodeFile(filepath.Join(dir, "shode.toml"), &cfg); err != nil {
+			t.Fatalf("template %s: shode.toml did not decode: %v", tmpl, err)
+		}
+		if len(cfg.Entry) == 0 {
+			t.Fatalf("template %s: expected a non-empty Entry", tmpl)
+		}
+		if err := cfg.Validate(); err != nil {
+			t.Fatalf("template %s: generated shode.toml failed validation: %v", tmpl, err)
+		}
+
+		for _, name := range []string{"shode.toml", ".github/workflows/ci.yml", "README.md"} {
+			if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+				t.Fatalf("template %s: expected %s to exist: %v", tmpl, name, err)
+			}
+		}
+	}
+}
+
+func TestGenerateWritesScriptContent(t *testing.T) {
+	dir := t.TempDir()
+	g := NewGenerator(string(TemplateCLIScript), "myapp")
+	if err := g.Generate(dir); err != nil {
+		t.Fatalf("first Generate returned error: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "main.sh"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(data) == 0 {
+		t.Fatal("expected main.sh to have content")
+	}
+}
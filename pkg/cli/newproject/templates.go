@@ -0,0 +1,194 @@
+package newproject
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/BurntSushi/toml"
+
+	"gitee.com/com_818cloud/shode/pkg/config"
+)
+
+// projectConfigTOML 将 cfg 编码为 shode.toml 的文本内容。
+func projectConfigTOML(cfg *config.ProjectConfig) (string, error) {
+	var buf bytes.Buffer
+	if err := toml.NewEncoder(&buf).Encode(cfg); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// ciWorkflow 渲染一个最小的 GitHub Actions 工作流，对生成的脚本运行
+// `shode check` 和 `shode run`。
+func ciWorkflow(runScript string) string {
+	return fmt.Sprintf(`name: CI
+
+on:
+  push:
+  pull_request:
+
+jobs:
+  shode:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/checkout@v4
+      - name: Install shode
+        run: go install gitee.com/com_818cloud/shode/cmd/shode
+      - name: Lint
+        run: shode check %s
+      - name: Run
+        run: shode run %s
+`, runScript, runScript)
+}
+
+func (g *Generator) cliScriptFiles() []file {
+	cfg := config.DefaultProjectConfig()
+	cfg.Entry = []string{"main.sh"}
+	tomlContent, _ := projectConfigTOML(cfg)
+
+	main := fmt.Sprintf(`#!/usr/bin/env shode
+# %s - a cli-script project generated by 'shode new --template cli-script'
+
+Println "Usage: shode run main.sh [name]"
+
+name = "world"
+if len(Args) > 0 {
+    name = Args[0]
+}
+
+Println "Hello, " + name + "!"
+`, g.ProjectName)
+
+	// There is no shode-native test framework yet, so this is a plain
+	// example script run by hand or in CI - not a replacement for one.
+	test := `#!/usr/bin/env shode
+# Example script showing main.sh's expected output.
+# shode has no built-in test/assert framework yet, so this is run
+# manually (or from CI) and its output checked by eye.
+
+Println "Running main.sh with no arguments:"
+RunScript "main.sh"
+
+Println "Running main.sh with an argument:"
+RunScript "main.sh" "shode"
+`
+
+	return []file{
+		{"shode.toml", tomlContent},
+		{"main.sh", main},
+		{"examples/smoke_test.sh", test},
+		{".github/workflows/ci.yml", ciWorkflow("main.sh")},
+		{"README.md", fmt.Sprintf("# %s\n\nA cli-script shode project.\n\nRun it with:\n\n```\nshode run main.sh\n```\n", g.ProjectName)},
+	}
+}
+
+func (g *Generator) webAPIFiles() []file {
+	cfg := config.DefaultProjectConfig()
+	cfg.Entry = []string{"server.sh"}
+	tomlContent, _ := projectConfigTOML(cfg)
+
+	server := fmt.Sprintf(`#!/usr/bin/env shode
+# %s - a web-api project generated by 'shode new --template web-api'
+
+Println "Starting HTTP server on port 8080..."
+StartHTTPServer "8080"
+
+RegisterRouteWithResponse "/health" "ok"
+
+Println "Server is running on http://localhost:8080"
+Println "Try: curl http://localhost:8080/health"
+`, g.ProjectName)
+
+	test := `#!/usr/bin/env shode
+# Smoke-checks server.sh's routes.
+# shode has no built-in test/assert framework yet, so this just makes
+# requests and prints the responses for manual inspection.
+
+Println "Starting server for a smoke check..."
+RunScript "server.sh"
+sleep 1
+
+status, body = HTTPRequest "GET" "http://localhost:8080/health" {} ""
+Println "GET /health -> " + status + " " + body
+`
+
+	return []file{
+		{"shode.toml", tomlContent},
+		{"server.sh", server},
+		{"routes/health.sh", "# add additional route handlers here and RunScript them from server.sh\n"},
+		{"examples/smoke_test.sh", test},
+		{".github/workflows/ci.yml", ciWorkflow("server.sh")},
+		{"README.md", fmt.Sprintf("# %s\n\nA web-api shode project.\n\nRun it with:\n\n```\nshode run server.sh\n```\n", g.ProjectName)},
+	}
+}
+
+func (g *Generator) serverlessFiles() []file {
+	cfg := config.DefaultProjectConfig()
+	cfg.Entry = []string{"handler.sh"}
+	tomlContent, _ := projectConfigTOML(cfg)
+
+	handler := fmt.Sprintf(`#!/usr/bin/env shode
+# %s - a serverless-function project generated by
+# 'shode new --template serverless-function'
+#
+# The runtime is expected to invoke this script once per event, passing
+# the event payload as Args[0] (a JSON string) and reading the response
+# from stdout.
+
+event = "{}"
+if len(Args) > 0 {
+    event = Args[0]
+}
+
+Println "{\"statusCode\": 200, \"body\": \"received event: " + event + "\"}"
+`, g.ProjectName)
+
+	test := `#!/usr/bin/env shode
+# Invokes handler.sh with a sample event and prints its response.
+# shode has no built-in test/assert framework yet, so this is checked
+# by eye.
+
+RunScript "handler.sh" "{\"hello\": \"world\"}"
+`
+
+	return []file{
+		{"shode.toml", tomlContent},
+		{"handler.sh", handler},
+		{"examples/invoke.sh", test},
+		{".github/workflows/ci.yml", ciWorkflow("handler.sh")},
+		{"README.md", fmt.Sprintf("# %s\n\nA serverless-function shode project.\n\nInvoke it locally with:\n\n```\nshode run handler.sh '{\"hello\": \"world\"}'\n```\n", g.ProjectName)},
+	}
+}
+
+func (g *Generator) packageFiles() []file {
+	cfg := config.DefaultProjectConfig()
+	cfg.Entry = []string{"lib.sh"}
+	tomlContent, _ := projectConfigTOML(cfg)
+
+	lib := fmt.Sprintf(`#!/usr/bin/env shode
+# %s - a package project generated by 'shode new --template package'
+#
+# This script is meant to be sourced by other shode scripts rather than
+# run directly: source it, then call the functions it defines.
+
+function Greet(name) {
+    return "Hello, " + name + "!"
+}
+`, g.ProjectName)
+
+	usage := `#!/usr/bin/env shode
+# Shows how another project depends on this package.
+
+source "../lib.sh"
+
+Println Greet("world")
+`
+
+	return []file{
+		{"shode.toml", tomlContent},
+		{"lib.sh", lib},
+		{"examples/usage.sh", usage},
+		{".github/workflows/ci.yml", ciWorkflow("lib.sh")},
+		{"README.md", fmt.Sprintf("# %s\n\nA shode package. Source lib.sh from another script to use it:\n\n```\nsource \"%s/lib.sh\"\n```\n", g.ProjectName, g.ProjectName)},
+	}
+}
@@ -8,7 +8,6 @@ import (
 	"os/exec"
 	"path/filepath"
 	"sync"
-	"time"
 )
 
 // Server 开发服务器
@@ -19,9 +18,11 @@ type Server struct {
 	Proxy    string
 	Env      string
 	Command  []string
+	WatchDir string // directory watched for hot reload, defaults to "."
 	mu       sync.RWMutex
 	running  bool
 	process  *exec.Cmd
+	watcher  *FileWatcher
 }
 
 // NewServer 创建服务器
@@ -31,6 +32,7 @@ func NewServer() *Server {
 		Host:     "localhost",
 		HotReload: true,
 		Env:      "development",
+		WatchDir: ".",
 	}
 }
 
@@ -75,24 +77,21 @@ func (s *Server) startWithHotReload() error {
 
 // watchFiles 监听文件变化
 func (s *Server) watchFiles() {
-	ticker := time.NewTicker(1 * time.Second)
-	defer ticker.Stop()
-
-	for {
-		select {
-		case <-ticker.C:
-			if s.hasChanges() {
-				fmt.Println("Changes detected, rebuilding...")
-				s.restart()
-			}
-		}
+	watchDir := s.WatchDir
+	if watchDir == "" {
+		watchDir = "."
 	}
-}
 
-// hasChanges 检查是否有变化
-func (s *Server) hasChanges() bool {
-	// 简化实现：实际应该使用文件监听
-	return false
+	watcher := NewFileWatcher(watchDir, func(changed []string) {
+		fmt.Printf("Changes detected in %d file(s), rebuilding...\n", len(changed))
+		s.restart()
+	})
+
+	s.mu.Lock()
+	s.watcher = watcher
+	s.mu.Unlock()
+
+	watcher.Run()
 }
 
 // buildAndRun 构建并运行
@@ -143,6 +142,11 @@ func (s *Server) Stop() error {
 
 	s.running = false
 
+	if s.watcher != nil {
+		s.watcher.Stop()
+		s.watcher = nil
+	}
+
 	if s.process != nil && s.process.Process != nil {
 		return s.process.Process.Kill()
 	}
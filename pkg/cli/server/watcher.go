@@ -0,0 +1,172 @@
+package server
+
+import (
+	"bufio"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// FileWatcher recursively polls a directory tree for file changes,
+// batching everything that changed within a debounce window into a
+// single OnChange call. There's no fsnotify/inotify dependency vendored
+// in this module, so changes are detected by periodically re-walking
+// the tree and comparing modification times rather than receiving
+// kernel events - the same observable behavior, at polling-interval
+// latency instead of instant.
+type FileWatcher struct {
+	Root     string        // directory to watch recursively
+	Interval time.Duration // how often to re-scan Root
+	Debounce time.Duration // how long to wait for more changes before firing OnChange
+	Ignore   []string      // patterns (from .gitignore plus built-in defaults) to skip
+	OnChange func(changed []string)
+
+	stop   chan struct{}
+	mtimes map[string]time.Time
+}
+
+// NewFileWatcher creates a watcher over root, loading ignore patterns
+// from root/.gitignore if present.
+func NewFileWatcher(root string, onChange func(changed []string)) *FileWatcher {
+	return &FileWatcher{
+		Root:     root,
+		Interval: 500 * time.Millisecond,
+		Debounce: 300 * time.Millisecond,
+		Ignore:   append(defaultIgnorePatterns(), loadGitignore(root)...),
+		OnChange: onChange,
+		stop:     make(chan struct{}),
+		mtimes:   make(map[string]time.Time),
+	}
+}
+
+// defaultIgnorePatterns covers directories generated/owned by shode
+// itself, which would otherwise trigger reload loops as the watcher's
+// own rebuild writes into them.
+func defaultIgnorePatterns() []string {
+	return []string{".git", "tmp", "node_modules"}
+}
+
+// loadGitignore reads simple (non-negated) .gitignore patterns from
+// root/.gitignore. A missing file is not an error - it just means no
+// extra patterns.
+func loadGitignore(root string) []string {
+	f, err := os.Open(filepath.Join(root, ".gitignore"))
+	if err != nil {
+		return nil
+	}
+	defer f.Close()
+
+	var patterns []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		patterns = append(patterns, strings.TrimSuffix(line, "/"))
+	}
+	return patterns
+}
+
+// isIgnored reports whether relPath (relative to Root) matches any
+// ignore pattern, either as an exact path component or a glob against
+// that component - the common subset of .gitignore semantics used by
+// generated/vendored directories.
+func (w *FileWatcher) isIgnored(relPath string) bool {
+	for _, part := range strings.Split(relPath, string(filepath.Separator)) {
+		for _, pattern := range w.Ignore {
+			if part == pattern {
+				return true
+			}
+			if matched, _ := filepath.Match(pattern, part); matched {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// scan walks Root once and returns every file path whose modification
+// time changed (or that was created or deleted) since the previous scan.
+func (w *FileWatcher) scan() []string {
+	var changed []string
+	seen := make(map[string]time.Time)
+
+	filepath.WalkDir(w.Root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		rel, relErr := filepath.Rel(w.Root, path)
+		if relErr != nil {
+			rel = path
+		}
+		if rel != "." && w.isIgnored(rel) {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.IsDir() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return nil
+		}
+		seen[path] = info.ModTime()
+		if prev, ok := w.mtimes[path]; !ok || !prev.Equal(info.ModTime()) {
+			changed = append(changed, path)
+		}
+		return nil
+	})
+
+	for path := range w.mtimes {
+		if _, ok := seen[path]; !ok {
+			changed = append(changed, path)
+		}
+	}
+
+	w.mtimes = seen
+	return changed
+}
+
+// Run polls Root every Interval until Stop is called, debouncing bursts
+// of changes (e.g. an editor save touching several files at once) into
+// a single OnChange call. It blocks, so callers run it in a goroutine.
+func (w *FileWatcher) Run() {
+	ticker := time.NewTicker(w.Interval)
+	defer ticker.Stop()
+
+	w.scan() // baseline, so the first real edit is the first change reported
+
+	debounce := time.NewTimer(w.Debounce)
+	debounce.Stop()
+	defer debounce.Stop()
+
+	var pending []string
+	for {
+		select {
+		case <-w.stop:
+			return
+		case <-ticker.C:
+			changed := w.scan()
+			if len(changed) == 0 {
+				continue
+			}
+			pending = append(pending, changed...)
+			debounce.Reset(w.Debounce)
+		case <-debounce.C:
+			if len(pending) > 0 && w.OnChange != nil {
+				w.OnChange(pending)
+			}
+			pending = nil
+		}
+	}
+}
+
+// Stop halts polling.
+func (w *FileWatcher) Stop() {
+	close(w.stop)
+}
@@ -0,0 +1,211 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/BurntSushi/toml"
+
+	"gitee.com/com_818cloud/shode/pkg/validator"
+)
+
+// ProjectConfigFile 是项目配置文件的默认名称
+const ProjectConfigFile = "shode.toml"
+
+// ProjectConfig 是每个项目的配置文件（shode.toml），供所有 CLI 命令共用，
+// 这样常用选项（入口脚本、策略、环境文件等）不需要在每条命令上重复传入。
+type ProjectConfig struct {
+	Entry          []string             `toml:"entry" validate:"required"`
+	Policy         string               `toml:"policy"`
+	EnvFiles       []string             `toml:"env_files"`
+	Registry       string               `toml:"registry" validate:"url"`
+	Lint           LintConfig           `toml:"lint"`
+	Fmt            FmtConfig            `toml:"fmt"`
+	Env            map[string]string    `toml:"env"`
+	Tracing        TracingConfig        `toml:"tracing"`
+	ErrorReporting ErrorReportingConfig `toml:"error_reporting"`
+	Audit          AuditConfig          `toml:"audit"`
+	Sandbox        SandboxConfig        `toml:"sandbox"`
+	AGI            AGIConfig            `toml:"agi"`
+}
+
+// TracingConfig 控制执行引擎的 OTel 风格追踪导出，由 shode.toml 的
+// [tracing] 小节配置。
+type TracingConfig struct {
+	Enabled      bool              `toml:"enabled"`
+	OTLPEndpoint string            `toml:"otlp_endpoint"`
+	ServiceName  string            `toml:"service_name"`
+	Resource     map[string]string `toml:"resource"`
+}
+
+// ErrorReportingConfig 控制引擎 panic 与 devtools 崩溃上报到 Sentry 兼容
+// 端点，由 shode.toml 的 [error_reporting] 小节配置。
+type ErrorReportingConfig struct {
+	Enabled bool   `toml:"enabled"`
+	DSN     string `toml:"dsn"`
+}
+
+// AuditConfig 控制安全审计日志的输出目标，由 shode.toml 的 [audit] 小节
+// 配置。Output 除 "stdout"/"stderr"/文件路径外，还接受
+// "splunk-hec://<token>@<host>/<path>?sourcetype=..."、
+// "elastic://<api_key>@<host>/<index>"、"syslog+tls://<host:port>"
+// 这三种 SIEM 目标，均带缓冲与重试，参见 pkg/security/audit。
+type AuditConfig struct {
+	Enabled              bool     `toml:"enabled"`
+	Output               []string `toml:"output"`
+	BufferSize           int      `toml:"buffer_size"`
+	FlushIntervalSeconds int      `toml:"flush_interval_seconds"`
+	MaxRetries           int      `toml:"max_retries"`
+}
+
+// SandboxConfig 控制 SecurityChecker 对容器、集群、Git、对象存储、SSH、
+// PowerShell 与资源编排类内建函数的额外策略，由 shode.toml 的 [sandbox]
+// 小节配置。DockerAllow/KubernetesAllow/GitAllow/ObjectAllow/SSHAllow/
+// PowerShellAllow/ResourceAllow 为空（默认）时，对应内建函数全部允许；
+// 一旦列出任意操作名（Docker："build"、"run"、"push"、"ps"；
+// Kubernetes："apply"、"get"、"logs"、"waitfor"；
+// Git："clone"、"checkout"、"status"、"tag"、"push"；
+// 对象存储："put"、"get"、"list"、"presign"；SSH："run"；
+// PowerShell："invoke"；资源编排（pkg/resources 的种类）：
+// "file"、"package"、"service"、"user"），未列出的操作会被拒绝。
+type SandboxConfig struct {
+	DockerAllow     []string `toml:"docker_allow"`
+	KubernetesAllow []string `toml:"kubernetes_allow"`
+	GitAllow        []string `toml:"git_allow"`
+	ObjectAllow     []string `toml:"object_allow"`
+	SSHAllow        []string `toml:"ssh_allow"`
+	PowerShellAllow []string `toml:"powershell_allow"`
+	ResourceAllow   []string `toml:"resource_allow"`
+}
+
+// AGIConfig 控制 pkg/agi 选用并配置哪个大语言模型后端，由 shode.toml 的
+// [agi] 小节配置。Provider 为 "openai"（默认，兼容 OpenAI 的
+// /v1/chat/completions 接口，BaseURL 留空时指向 OpenAI 本身）、"ollama"
+// （BaseURL 留空时指向本机 11434 端口）或 "gguf"（通过 GGUFBinary 指定
+// 的 llama.cpp 兼容二进制对 GGUFModelPath 做本地推理，GGUFBinary 留空时
+// 在 PATH 中查找 llama-cli/main/llama）。
+type AGIConfig struct {
+	Provider      string `toml:"provider"`
+	Model         string `toml:"model"`
+	BaseURL       string `toml:"base_url"`
+	APIKey        string `toml:"api_key"`
+	GGUFBinary    string `toml:"gguf_binary"`
+	GGUFModelPath string `toml:"gguf_model_path"`
+}
+
+// LintConfig 控制 shode check 使用的规则
+type LintConfig struct {
+	Enabled bool     `toml:"enabled"`
+	Rules   []string `toml:"rules"`
+}
+
+// FmtConfig 控制 shode fmt 的格式化选项
+type FmtConfig struct {
+	Indent  int  `toml:"indent"`
+	UseTabs bool `toml:"use_tabs"`
+}
+
+// DefaultProjectConfig 返回带有合理默认值的项目配置
+func DefaultProjectConfig() *ProjectConfig {
+	return &ProjectConfig{
+		Entry:   []string{"main.sh"},
+		Policy:  "default",
+		Lint:    LintConfig{Enabled: true},
+		Fmt:     FmtConfig{Indent: 2},
+		Tracing: TracingConfig{ServiceName: "shode"},
+	}
+}
+
+// LoadProjectConfig 从 path 加载 shode.toml。当文件不存在时返回默认配置，
+// 这样尚未创建配置文件的项目仍可以正常运行命令。
+func LoadProjectConfig(path string) (*ProjectConfig, error) {
+	cfg := DefaultProjectConfig()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return cfg, nil
+		}
+		return nil, fmt.Errorf("failed to read project config: %w", err)
+	}
+
+	if _, err := toml.Decode(string(data), cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse project config: %w", err)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+// FindProjectConfig 从 dir 开始向上查找 shode.toml，模仿 git 查找
+// .git 目录的方式逐级向上搜索。
+func FindProjectConfig(dir string) (string, bool) {
+	for {
+		candidate := filepath.Join(dir, ProjectConfigFile)
+		if _, err := os.Stat(candidate); err == nil {
+			return candidate, true
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", false
+		}
+		dir = parent
+	}
+}
+
+// Validate 校验配置字段，复用 pkg/validator 的结构体验证能力
+func (pc *ProjectConfig) Validate() error {
+	if pc.Registry == "" {
+		// 未配置 registry 时跳过 URL 校验
+		return validator.ValidateStruct(&struct {
+			Entry []string `validate:"required"`
+		}{Entry: pc.Entry})
+	}
+
+	if !validator.IsURL(pc.Registry) {
+		return validator.ValidationErrors{{
+			Field:   "registry",
+			Message: "must be a valid URL",
+			Value:   pc.Registry,
+		}}
+	}
+
+	if len(pc.Entry) == 0 {
+		return validator.ValidationErrors{{
+			Field:   "entry",
+			Message: "at least one entry script is required",
+		}}
+	}
+
+	if pc.Tracing.Enabled && pc.Tracing.OTLPEndpoint == "" {
+		return validator.ValidationErrors{{
+			Field:   "tracing.otlp_endpoint",
+			Message: "must be set when tracing is enabled",
+		}}
+	}
+
+	if pc.ErrorReporting.Enabled && pc.ErrorReporting.DSN == "" {
+		return validator.ValidationErrors{{
+			Field:   "error_reporting.dsn",
+			Message: "must be set when error reporting is enabled",
+		}}
+	}
+
+	return nil
+}
+
+// Save writes the project config back to path as TOML.
+func (pc *ProjectConfig) Save(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create project config: %w", err)
+	}
+	defer f.Close()
+
+	return toml.NewEncoder(f).Encode(pc)
+}
@@ -0,0 +1,77 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestLoadProjectConfig 测试加载 shode.toml
+func TestLoadProjectConfig(t *testing.T) {
+	content := `
+entry = ["main.sh"]
+policy = "strict"
+registry = "https://registry.example.com"
+
+[lint]
+enabled = true
+rules = ["no-eval"]
+`
+	tmpDir := t.TempDir()
+	path := filepath.Join(tmpDir, ProjectConfigFile)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write project config: %v", err)
+	}
+
+	cfg, err := LoadProjectConfig(path)
+	if err != nil {
+		t.Fatalf("LoadProjectConfig() error = %v", err)
+	}
+
+	if cfg.Policy != "strict" {
+		t.Errorf("Policy = %q, want %q", cfg.Policy, "strict")
+	}
+	if len(cfg.Entry) != 1 || cfg.Entry[0] != "main.sh" {
+		t.Errorf("Entry = %v, want [main.sh]", cfg.Entry)
+	}
+}
+
+// TestLoadProjectConfigMissingFileUsesDefaults 测试文件缺失时使用默认配置
+func TestLoadProjectConfigMissingFileUsesDefaults(t *testing.T) {
+	cfg, err := LoadProjectConfig(filepath.Join(t.TempDir(), ProjectConfigFile))
+	if err != nil {
+		t.Fatalf("LoadProjectConfig() error = %v", err)
+	}
+	if len(cfg.Entry) == 0 {
+		t.Error("expected default entry to be set")
+	}
+}
+
+// TestProjectConfigValidateRejectsBadRegistry 测试非法 registry URL 被拒绝
+func TestProjectConfigValidateRejectsBadRegistry(t *testing.T) {
+	cfg := DefaultProjectConfig()
+	cfg.Registry = "not a url"
+	if err := cfg.Validate(); err == nil {
+		t.Error("expected validation error for invalid registry URL")
+	}
+}
+
+func TestFindProjectConfig(t *testing.T) {
+	tmpDir := t.TempDir()
+	sub := filepath.Join(tmpDir, "a", "b")
+	if err := os.MkdirAll(sub, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(tmpDir, ProjectConfigFile), []byte("entry = [\"x.sh\"]\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	path, found := FindProjectConfig(sub)
+	if !found {
+		t.Fatal("expected to find project config")
+	}
+	want := filepath.Join(tmpDir, ProjectConfigFile)
+	if path != want {
+		t.Errorf("path = %q, want %q", path, want)
+	}
+}
@@ -15,6 +15,8 @@ const (
 	RequestIDKey    ContextKey = "request_id"
 	UserIDKey       ContextKey = "user_id"
 	TraceIDKey      ContextKey = "trace_id"
+	SpanIDKey       ContextKey = "span_id"
+	ScriptExecutionIDKey ContextKey = "script_execution_id"
 	SessionIDKey    ContextKey = "session_id"
 	CorrelationIDKey ContextKey = "correlation_id"
 	LanguageKey     ContextKey = "language"
@@ -63,6 +65,32 @@ func GetTraceID(ctx context.Context) string {
 	return ""
 }
 
+// WithSpanID 设置跨度ID
+func WithSpanID(ctx context.Context, spanID string) context.Context {
+	return context.WithValue(ctx, SpanIDKey, spanID)
+}
+
+// GetSpanID 获取跨度ID
+func GetSpanID(ctx context.Context) string {
+	if spanID, ok := ctx.Value(SpanIDKey).(string); ok {
+		return spanID
+	}
+	return ""
+}
+
+// WithScriptExecutionID 设置脚本执行ID
+func WithScriptExecutionID(ctx context.Context, executionID string) context.Context {
+	return context.WithValue(ctx, ScriptExecutionIDKey, executionID)
+}
+
+// GetScriptExecutionID 获取脚本执行ID
+func GetScriptExecutionID(ctx context.Context) string {
+	if executionID, ok := ctx.Value(ScriptExecutionIDKey).(string); ok {
+		return executionID
+	}
+	return ""
+}
+
 // WithSessionID 设置会话ID
 func WithSessionID(ctx context.Context, sessionID string) context.Context {
 	return context.WithValue(ctx, SessionIDKey, sessionID)
@@ -0,0 +1,95 @@
+package daemon
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+)
+
+// Client talks to a running Daemon over its Unix socket.
+type Client struct {
+	socketPath string
+	http       *http.Client
+}
+
+// NewClient creates a Client for the daemon listening on socketPath. If
+// socketPath is empty, DefaultSocketPath is used.
+func NewClient(socketPath string) *Client {
+	if socketPath == "" {
+		socketPath = DefaultSocketPath()
+	}
+	return &Client{
+		socketPath: socketPath,
+		http: &http.Client{
+			Timeout: 10 * time.Second,
+			Transport: &http.Transport{
+				DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+					return net.Dial("unix", socketPath)
+				},
+			},
+		},
+	}
+}
+
+// Ping checks whether the daemon is reachable and returns its status.
+func (c *Client) Ping() (*statusResponse, error) {
+	resp, err := c.http.Get("http://unix/status")
+	if err != nil {
+		return nil, fmt.Errorf("daemon not reachable at %s: %v", c.socketPath, err)
+	}
+	defer resp.Body.Close()
+
+	var status statusResponse
+	if err := json.NewDecoder(resp.Body).Decode(&status); err != nil {
+		return nil, fmt.Errorf("failed to decode status: %v", err)
+	}
+	return &status, nil
+}
+
+// Submit asks the daemon to run scriptPath and returns the job it
+// created. The job runs asynchronously; poll JobStatus for completion.
+func (c *Client) Submit(scriptPath string) (*Job, error) {
+	body, err := json.Marshal(submitRequest{Script: scriptPath})
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.http.Post("http://unix/jobs", "application/json", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("daemon not reachable at %s: %v", c.socketPath, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusAccepted {
+		return nil, fmt.Errorf("daemon rejected job: %s", resp.Status)
+	}
+
+	var job Job
+	if err := json.NewDecoder(resp.Body).Decode(&job); err != nil {
+		return nil, fmt.Errorf("failed to decode job: %v", err)
+	}
+	return &job, nil
+}
+
+// JobStatus fetches the current state of the job with the given id.
+func (c *Client) JobStatus(id int) (*Job, error) {
+	resp, err := c.http.Get(fmt.Sprintf("http://unix/jobs/%d", id))
+	if err != nil {
+		return nil, fmt.Errorf("daemon not reachable at %s: %v", c.socketPath, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("job %d not found", id)
+	}
+
+	var job Job
+	if err := json.NewDecoder(resp.Body).Decode(&job); err != nil {
+		return nil, fmt.Errorf("failed to decode job: %v", err)
+	}
+	return &job, nil
+}
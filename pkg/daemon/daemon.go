@@ -0,0 +1,251 @@
+// Package daemon implements a long-lived Shode runtime that keeps the
+// standard library and module cache warm, and exposes a local control
+// API over a Unix domain socket so tools can submit scripts without
+// paying process-startup cost on every invocation.
+package daemon
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"gitee.com/com_818cloud/shode/pkg/engine"
+	"gitee.com/com_818cloud/shode/pkg/environment"
+	"gitee.com/com_818cloud/shode/pkg/module"
+	"gitee.com/com_818cloud/shode/pkg/parser"
+	"gitee.com/com_818cloud/shode/pkg/sandbox"
+	"gitee.com/com_818cloud/shode/pkg/stdlib"
+)
+
+// JobStatus is the lifecycle state of a submitted script.
+type JobStatus string
+
+const (
+	JobRunning JobStatus = "running"
+	JobDone    JobStatus = "done"
+	JobFailed  JobStatus = "failed"
+)
+
+// Job is a script submission tracked by the daemon.
+type Job struct {
+	ID         int       `json:"id"`
+	Script     string    `json:"script"`
+	Status     JobStatus `json:"status"`
+	ExitCode   int       `json:"exitCode"`
+	Output     string    `json:"output,omitempty"`
+	Error      string    `json:"error,omitempty"`
+	SubmittedAt time.Time `json:"submittedAt"`
+	FinishedAt  time.Time `json:"finishedAt,omitempty"`
+}
+
+// DefaultSocketPath returns the Unix socket the daemon listens on by
+// default, under the user's home directory.
+func DefaultSocketPath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ".shode.sock"
+	}
+	return filepath.Join(home, ".shode.sock")
+}
+
+// Daemon keeps a warm ExecutionEngine and module manager, and serves a
+// control API over a Unix socket for submitting scripts and querying
+// job status.
+type Daemon struct {
+	socketPath string
+	engine     *engine.ExecutionEngine
+	stdlib     *stdlib.StdLib
+
+	mu         sync.Mutex
+	jobs       map[int]*Job
+	jobCounter int
+
+	server   *http.Server
+	listener net.Listener
+}
+
+// New creates a Daemon that will listen on socketPath. If socketPath is
+// empty, DefaultSocketPath is used.
+func New(socketPath string) *Daemon {
+	if socketPath == "" {
+		socketPath = DefaultSocketPath()
+	}
+
+	envManager := environment.NewEnvironmentManager()
+	stdLib := stdlib.New()
+	moduleMgr := module.NewModuleManager()
+	security := sandbox.NewSecurityChecker()
+	executionEngine := engine.NewExecutionEngine(envManager, stdLib, moduleMgr, security)
+
+	return &Daemon{
+		socketPath: socketPath,
+		engine:     executionEngine,
+		stdlib:     stdLib,
+		jobs:       make(map[int]*Job),
+	}
+}
+
+// Serve listens on the daemon's Unix socket and blocks serving the
+// control API until the socket is closed (see Shutdown).
+func (d *Daemon) Serve() error {
+	if err := os.RemoveAll(d.socketPath); err != nil {
+		return fmt.Errorf("failed to clear stale socket: %v", err)
+	}
+
+	listener, err := net.Listen("unix", d.socketPath)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %v", d.socketPath, err)
+	}
+	d.listener = listener
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/status", d.handleStatus)
+	mux.HandleFunc("/jobs", d.handleJobs)
+	mux.HandleFunc("/jobs/", d.handleJob)
+
+	d.server = &http.Server{Handler: mux}
+
+	err = d.server.Serve(listener)
+	if err == http.ErrServerClosed {
+		return nil
+	}
+	return err
+}
+
+// Shutdown stops the daemon's control API and removes its socket file.
+func (d *Daemon) Shutdown(ctx context.Context) error {
+	if d.server == nil {
+		return nil
+	}
+	err := d.server.Shutdown(ctx)
+	os.RemoveAll(d.socketPath)
+	return err
+}
+
+type statusResponse struct {
+	Uptime   string `json:"uptime"`
+	JobCount int    `json:"jobCount"`
+}
+
+var startTime = time.Now()
+
+func (d *Daemon) handleStatus(w http.ResponseWriter, r *http.Request) {
+	d.mu.Lock()
+	jobCount := len(d.jobs)
+	d.mu.Unlock()
+
+	writeJSON(w, http.StatusOK, statusResponse{
+		Uptime:   time.Since(startTime).String(),
+		JobCount: jobCount,
+	})
+}
+
+type submitRequest struct {
+	Script string `json:"script"`
+}
+
+func (d *Daemon) handleJobs(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPost:
+		d.handleSubmit(w, r)
+	case http.MethodGet:
+		d.mu.Lock()
+		jobs := make([]*Job, 0, len(d.jobs))
+		for _, job := range d.jobs {
+			jobs = append(jobs, job)
+		}
+		d.mu.Unlock()
+		writeJSON(w, http.StatusOK, jobs)
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (d *Daemon) handleSubmit(w http.ResponseWriter, r *http.Request) {
+	var req submitRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, fmt.Sprintf("invalid request: %v", err), http.StatusBadRequest)
+		return
+	}
+	if req.Script == "" {
+		http.Error(w, "script is required", http.StatusBadRequest)
+		return
+	}
+
+	d.mu.Lock()
+	d.jobCounter++
+	job := &Job{
+		ID:          d.jobCounter,
+		Script:      req.Script,
+		Status:      JobRunning,
+		SubmittedAt: time.Now(),
+	}
+	d.jobs[job.ID] = job
+	d.mu.Unlock()
+
+	go d.run(job)
+
+	writeJSON(w, http.StatusAccepted, job)
+}
+
+func (d *Daemon) run(job *Job) {
+	p := parser.NewSimpleParser()
+	script, err := p.ParseFile(job.Script)
+	if err != nil {
+		d.finish(job, JobFailed, 1, "", err.Error())
+		return
+	}
+
+	result, err := d.engine.Execute(context.Background(), script)
+	if err != nil {
+		d.finish(job, JobFailed, 1, "", err.Error())
+		return
+	}
+
+	status := JobDone
+	if !result.Success {
+		status = JobFailed
+	}
+	d.finish(job, status, result.ExitCode, result.Output, result.Error)
+}
+
+func (d *Daemon) finish(job *Job, status JobStatus, exitCode int, output, errMsg string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	job.Status = status
+	job.ExitCode = exitCode
+	job.Output = output
+	job.Error = errMsg
+	job.FinishedAt = time.Now()
+}
+
+func (d *Daemon) handleJob(w http.ResponseWriter, r *http.Request) {
+	idStr := filepath.Base(r.URL.Path)
+	var id int
+	if _, err := fmt.Sscanf(idStr, "%d", &id); err != nil {
+		http.Error(w, "invalid job id", http.StatusBadRequest)
+		return
+	}
+
+	d.mu.Lock()
+	job, ok := d.jobs[id]
+	d.mu.Unlock()
+	if !ok {
+		http.Error(w, "job not found", http.StatusNotFound)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, job)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,129 @@
+// Package dashboard renders a refreshing terminal view of an
+// ExecutionEngine's recent activity: executions, metrics, and alerts.
+// It has no external UI dependency - it redraws with plain ANSI escapes,
+// matching the rest of Shode's terminal tooling (see pkg/repl).
+package dashboard
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"gitee.com/com_818cloud/shode/pkg/engine"
+)
+
+// AlertThreshold is the execution duration above which a run is flagged
+// as a performance alert. There is no AutoOptimizer yet to source a
+// threshold from, so this is a simple, documented heuristic.
+const AlertThreshold = 2 * time.Second
+
+// Execution is one recorded run shown in the dashboard's history.
+type Execution struct {
+	Script   string
+	Success  bool
+	ExitCode int
+	Duration time.Duration
+	At       time.Time
+}
+
+// Alert is a performance warning surfaced for a slow execution.
+type Alert struct {
+	Script   string
+	Duration time.Duration
+	At       time.Time
+}
+
+// Dashboard tracks an engine's recent execution history for display.
+type Dashboard struct {
+	engine     *engine.ExecutionEngine
+	history    []Execution
+	alerts     []Alert
+	maxHistory int
+	logPath    string
+}
+
+// New creates a Dashboard that watches eng, keeping up to maxHistory
+// recent executions. logPath, if non-empty, is tailed in the log panel.
+func New(eng *engine.ExecutionEngine, logPath string, maxHistory int) *Dashboard {
+	if maxHistory <= 0 {
+		maxHistory = 20
+	}
+	return &Dashboard{engine: eng, maxHistory: maxHistory, logPath: logPath}
+}
+
+// Record adds a completed execution to the dashboard's history, raising a
+// performance alert if it exceeded AlertThreshold.
+func (d *Dashboard) Record(script string, result *engine.ExecutionResult) {
+	exec := Execution{
+		Script:   script,
+		Success:  result.Success,
+		ExitCode: result.ExitCode,
+		Duration: result.Duration,
+		At:       time.Now(),
+	}
+	d.history = append(d.history, exec)
+	if len(d.history) > d.maxHistory {
+		d.history = d.history[len(d.history)-d.maxHistory:]
+	}
+
+	if result.Duration > AlertThreshold {
+		d.alerts = append(d.alerts, Alert{Script: script, Duration: result.Duration, At: exec.At})
+		if len(d.alerts) > d.maxHistory {
+			d.alerts = d.alerts[len(d.alerts)-d.maxHistory:]
+		}
+	}
+}
+
+// Render draws the full dashboard as a single string, ready to be written
+// to the terminal after clearing the screen.
+func (d *Dashboard) Render() string {
+	var b strings.Builder
+
+	fmt.Fprintln(&b, "=== Shode Top ===")
+	fmt.Fprintf(&b, "Time: %s\n\n", time.Now().Format(time.RFC3339))
+
+	if d.engine != nil {
+		fmt.Fprintf(&b, "Background jobs submitted: %d\n\n", d.engine.GetJobCount())
+	}
+
+	fmt.Fprintln(&b, "-- Recent Executions --")
+	if len(d.history) == 0 {
+		fmt.Fprintln(&b, "(none yet)")
+	}
+	for _, e := range d.history {
+		status := "OK"
+		if !e.Success {
+			status = fmt.Sprintf("FAIL(%d)", e.ExitCode)
+		}
+		fmt.Fprintf(&b, "  %s  %-6s  %-20s  %v\n", e.At.Format("15:04:05"), status, e.Script, e.Duration)
+	}
+
+	fmt.Fprintln(&b, "\n-- Performance Alerts --")
+	if len(d.alerts) == 0 {
+		fmt.Fprintln(&b, "(none)")
+	}
+	for _, a := range d.alerts {
+		fmt.Fprintf(&b, "  %s  %s took %v (> %v)\n", a.At.Format("15:04:05"), a.Script, a.Duration, AlertThreshold)
+	}
+
+	if d.engine != nil {
+		fmt.Fprintln(&b, "\n-- Engine Metrics --")
+		if m := d.engine.GetMetrics(); m != nil {
+			fmt.Fprint(&b, m.Format())
+		}
+	}
+
+	if d.logPath != "" {
+		fmt.Fprintln(&b, "\n-- Log Tail --")
+		lines, err := tailLines(d.logPath, 10)
+		if err != nil {
+			fmt.Fprintf(&b, "(could not read %s: %v)\n", d.logPath, err)
+		} else {
+			for _, line := range lines {
+				fmt.Fprintln(&b, "  "+line)
+			}
+		}
+	}
+
+	return b.String()
+}
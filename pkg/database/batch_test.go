@@ -0,0 +1,98 @@
+package database
+
+import (
+	"context"
+	"testing"
+)
+
+func TestExecuteBatchRunsEveryArgSet(t *testing.T) {
+	dm, connName := newTestDatabaseManager(t)
+
+	argSets := [][]interface{}{
+		{"c", 10},
+		{"d", 20},
+		{"e", 30},
+	}
+	results, err := dm.ExecuteBatch(context.Background(), connName, "INSERT INTO accounts (name, balance) VALUES (?, ?)", argSets)
+	if err != nil {
+		t.Fatalf("ExecuteBatch() error = %v", err)
+	}
+	if len(results) != 3 {
+		t.Fatalf("ExecuteBatch() returned %d results, want 3", len(results))
+	}
+
+	if got := balance(t, dm, connName, "e"); got != 30 {
+		t.Errorf("balance(e) = %d, want 30", got)
+	}
+}
+
+func TestExecuteBatchReusesPreparedStatement(t *testing.T) {
+	dm, connName := newTestDatabaseManager(t)
+	conn, err := dm.GetConnection(connName)
+	if err != nil {
+		t.Fatalf("GetConnection() error = %v", err)
+	}
+
+	argSets := [][]interface{}{{"f", 1}, {"g", 2}}
+	if _, err := dm.ExecuteBatch(context.Background(), connName, "INSERT INTO accounts (name, balance) VALUES (?, ?)", argSets); err != nil {
+		t.Fatalf("ExecuteBatch() error = %v", err)
+	}
+
+	if len(conn.stmtByQuery) != 1 {
+		t.Errorf("stmtByQuery has %d entries, want 1 cached statement for the batch's single query", len(conn.stmtByQuery))
+	}
+}
+
+func TestExecuteBatchInsideTransaction(t *testing.T) {
+	dm, connName := newTestDatabaseManager(t)
+
+	if _, err := dm.BeginTx(context.Background(), connName); err != nil {
+		t.Fatalf("BeginTx() error = %v", err)
+	}
+
+	argSets := [][]interface{}{{"h", 5}}
+	if _, err := dm.ExecuteBatch(context.Background(), connName, "INSERT INTO accounts (name, balance) VALUES (?, ?)", argSets); err != nil {
+		t.Fatalf("ExecuteBatch() error = %v", err)
+	}
+
+	if err := dm.RollbackTx(connName); err != nil {
+		t.Fatalf("RollbackTx() error = %v", err)
+	}
+
+	rows, err := dm.Query(context.Background(), connName, "SELECT COUNT(*) FROM accounts WHERE name = ?", "h")
+	if err != nil {
+		t.Fatalf("Query() error = %v", err)
+	}
+	defer rows.Close()
+
+	var count int
+	if !rows.Next() {
+		t.Fatal("expected a row from COUNT(*)")
+	}
+	if err := rows.Scan(&count); err != nil {
+		t.Fatalf("Scan() error = %v", err)
+	}
+	if count != 0 {
+		t.Errorf("count after rollback = %d, want 0", count)
+	}
+}
+
+func TestConnectionCloseStatementsClearsCache(t *testing.T) {
+	dm, connName := newTestDatabaseManager(t)
+	conn, err := dm.GetConnection(connName)
+	if err != nil {
+		t.Fatalf("GetConnection() error = %v", err)
+	}
+
+	if _, err := conn.preparedStatement(context.Background(), "SELECT 1"); err != nil {
+		t.Fatalf("preparedStatement() error = %v", err)
+	}
+	if len(conn.stmtByQuery) != 1 {
+		t.Fatalf("stmtByQuery has %d entries, want 1", len(conn.stmtByQuery))
+	}
+
+	conn.CloseStatements()
+	if len(conn.stmtByQuery) != 0 {
+		t.Errorf("stmtByQuery has %d entries after CloseStatements(), want 0", len(conn.stmtByQuery))
+	}
+}
@@ -249,11 +249,25 @@ func (b *Builder) Build() (string, []interface{}) {
 	return query, args
 }
 
-// First executes the query and returns the first result
+// First executes the query and scans the first matching row into dest.
+// If dest points to a struct, columns are matched to fields by "db"
+// tag (see ScanRow); otherwise dest is scanned as a single column
+// value.
 func (b *Builder) First(ctx context.Context, dest interface{}) error {
 	query, args := b.Limit(1).Build()
-	row := b.orm.db.QueryRowContext(ctx, query, args...)
-	return row.Scan(dest)
+	rows, err := b.orm.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return err
+		}
+		return sql.ErrNoRows
+	}
+	return ScanRow(rows, dest)
 }
 
 // All executes the query and returns all results
@@ -262,6 +276,19 @@ func (b *Builder) All(ctx context.Context) (*sql.Rows, error) {
 	return b.orm.db.QueryContext(ctx, query, args...)
 }
 
+// AllStructs executes the query and scans every matching row into
+// dest, a pointer to a slice of structs. Columns are matched to
+// fields by "db" tag, the same convention ScanRow uses, so any
+// struct works - not just types implementing Model.
+func (b *Builder) AllStructs(ctx context.Context, dest interface{}) error {
+	rows, err := b.All(ctx)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	return ScanRows(rows, dest)
+}
+
 // Count executes a COUNT query
 func (b *Builder) Count(ctx context.Context) (int64, error) {
 	query := fmt.Sprintf("SELECT COUNT(*) FROM %s", b.tableName)
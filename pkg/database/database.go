@@ -17,12 +17,13 @@ type DatabaseManager struct {
 	pool        *ConnectionPool
 	monitor     *QueryMonitor
 	backup      *BackupManager
+	txManager   *TransactionManager
 	mu          sync.RWMutex
 }
 
 // NewDatabaseManager 创建数据库管理器
 func NewDatabaseManager() *DatabaseManager {
-	return &DatabaseManager{
+	dm := &DatabaseManager{
 		connections: make(map[string]*Connection),
 		orms:        make(map[string]*ORMManager),
 		migrator:    NewMigrator(),
@@ -30,6 +31,8 @@ func NewDatabaseManager() *DatabaseManager {
 		monitor:     NewQueryMonitor(),
 		backup:      NewBackupManager(),
 	}
+	dm.txManager = NewTransactionManager(dm)
+	return dm
 }
 
 // RegisterConnection 注册连接
@@ -54,50 +57,177 @@ func (dm *DatabaseManager) GetConnection(name string) (*Connection, error) {
 	return conn, nil
 }
 
-// Query 执行查询
+// Query 执行查询。如果 connName 上有一个通过 BeginTx 开启的活动事务，
+// 查询会在该事务上执行，而不是直接使用连接，从而让事务对调用方透明。
 func (dm *DatabaseManager) Query(ctx context.Context, connName string, query string, args ...interface{}) (*sql.Rows, error) {
 	conn, err := dm.GetConnection(connName)
 	if err != nil {
 		return nil, err
 	}
 
+	query = translatePlaceholders(conn.Driver, query)
+
 	start := time.Now()
-	rows, err := conn.DB.QueryContext(ctx, query, args...)
+	var rows *sql.Rows
+	if tx, ok := dm.txManager.currentTx(connName); ok {
+		rows, err = tx.Tx.QueryContext(ctx, query, args...)
+	} else {
+		rows, err = conn.DB.QueryContext(ctx, query, args...)
+	}
 	duration := time.Since(start)
 
 	// 监控
 	dm.monitor.RecordQuery(connName, query, duration, err)
 
-	return rows, err
+	if err != nil {
+		return rows, mapDriverError(conn.Driver, err)
+	}
+	return rows, nil
 }
 
-// Execute 执行语句
+// Execute 执行语句。如果 connName 上有一个通过 BeginTx 开启的活动事务，
+// 语句会在该事务上执行，而不是直接使用连接，从而让事务对调用方透明。
 func (dm *DatabaseManager) Execute(ctx context.Context, connName string, query string, args ...interface{}) (sql.Result, error) {
 	conn, err := dm.GetConnection(connName)
 	if err != nil {
 		return nil, err
 	}
 
+	query = translatePlaceholders(conn.Driver, query)
+
 	start := time.Now()
-	result, err := conn.DB.ExecContext(ctx, query, args...)
+	var result sql.Result
+	if tx, ok := dm.txManager.currentTx(connName); ok {
+		result, err = tx.Tx.ExecContext(ctx, query, args...)
+	} else {
+		result, err = conn.DB.ExecContext(ctx, query, args...)
+	}
 	duration := time.Since(start)
 
 	// 监控
 	dm.monitor.RecordQuery(connName, query, duration, err)
 
-	return result, err
+	if err != nil {
+		return result, mapDriverError(conn.Driver, err)
+	}
+	return result, nil
+}
+
+// ExecuteBatch prepares query once against connName and executes it
+// once per set of args in argSets, reusing the same prepared
+// statement across the whole batch instead of re-parsing and
+// re-planning it on every call - the win data-loading scripts want
+// when inserting many rows with the same statement shape. If a
+// transaction is active on connName (via BeginTx), the batch runs
+// inside it, same as Query/Execute.
+func (dm *DatabaseManager) ExecuteBatch(ctx context.Context, connName string, query string, argSets [][]interface{}) ([]sql.Result, error) {
+	conn, err := dm.GetConnection(connName)
+	if err != nil {
+		return nil, err
+	}
+
+	query = translatePlaceholders(conn.Driver, query)
+
+	var stmt *sql.Stmt
+	if tx, ok := dm.txManager.currentTx(connName); ok {
+		// conn.DB is a pool; preparing against it while a transaction
+		// holds its own dedicated connection can silently prepare
+		// against a *different* connection. Prepare directly on the
+		// transaction instead of going through the connection-level
+		// cache, and let it go when the batch is done.
+		stmt, err = tx.Tx.PrepareContext(ctx, query)
+		if err != nil {
+			return nil, mapDriverError(conn.Driver, err)
+		}
+		defer stmt.Close()
+	} else {
+		stmt, err = conn.preparedStatement(ctx, query)
+		if err != nil {
+			return nil, mapDriverError(conn.Driver, err)
+		}
+	}
+
+	start := time.Now()
+	results := make([]sql.Result, 0, len(argSets))
+	for _, args := range argSets {
+		result, err := stmt.ExecContext(ctx, args...)
+		if err != nil {
+			dm.monitor.RecordQuery(connName, query, time.Since(start), err)
+			return results, mapDriverError(conn.Driver, err)
+		}
+		results = append(results, result)
+	}
+	dm.monitor.RecordQuery(connName, query, time.Since(start), nil)
+
+	return results, nil
+}
+
+// BeginTx 为 connName 开启一个事务，并将其设为该连接当前的活动事务，
+// 使后续通过 Query/Execute（以及 stdlib 的 QueryDB/ExecDB）对该连接
+// 发出的调用自动在事务内执行，直到 CommitTx 或 RollbackTx 结束它。
+func (dm *DatabaseManager) BeginTx(ctx context.Context, connName string) (string, error) {
+	return dm.txManager.BeginCurrent(ctx, connName)
+}
+
+// CommitTx 提交 connName 上当前的活动事务。
+func (dm *DatabaseManager) CommitTx(connName string) error {
+	return dm.txManager.CommitCurrent(connName)
+}
+
+// RollbackTx 回滚 connName 上当前的活动事务。
+func (dm *DatabaseManager) RollbackTx(connName string) error {
+	return dm.txManager.RollbackCurrent(connName)
 }
 
 // Connection 数据库连接
 type Connection struct {
-	Name       string       `json:"name"`
-	Driver     string       `json:"driver"`     // "postgres", "mysql", "mongodb", "redis"
-	DSN        string       `json:"dsn"`
-	DB         *sql.DB      `json:"-"`
-	Config     *ConnConfig  `json:"config"`
-	Master     string       `json:"master"`     // 主库地址
-	Replicas   []string     `json:"replicas"`   // 从库地址
-	Status     string       `json:"status"`
+	Name     string      `json:"name"`
+	Driver   string      `json:"driver"` // "postgres", "mysql", "mongodb", "redis"
+	DSN      string      `json:"dsn"`
+	DB       *sql.DB     `json:"-"`
+	Config   *ConnConfig `json:"config"`
+	Master   string      `json:"master"`   // 主库地址
+	Replicas []string    `json:"replicas"` // 从库地址
+	Status   string      `json:"status"`
+
+	stmtMu      sync.Mutex
+	stmtByQuery map[string]*sql.Stmt
+}
+
+// preparedStatement returns a *sql.Stmt for query on this connection,
+// preparing it on first use and caching the result by query text so
+// later calls with the same SQL skip re-parsing/re-planning it.
+func (c *Connection) preparedStatement(ctx context.Context, query string) (*sql.Stmt, error) {
+	c.stmtMu.Lock()
+	defer c.stmtMu.Unlock()
+
+	if stmt, ok := c.stmtByQuery[query]; ok {
+		return stmt, nil
+	}
+
+	stmt, err := c.DB.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.stmtByQuery == nil {
+		c.stmtByQuery = make(map[string]*sql.Stmt)
+	}
+	c.stmtByQuery[query] = stmt
+	return stmt, nil
+}
+
+// CloseStatements closes every prepared statement cached for this
+// connection. Callers close it before closing the underlying *sql.DB,
+// same as any other resource the connection owns.
+func (c *Connection) CloseStatements() {
+	c.stmtMu.Lock()
+	defer c.stmtMu.Unlock()
+
+	for query, stmt := range c.stmtByQuery {
+		stmt.Close()
+		delete(c.stmtByQuery, query)
+	}
 }
 
 // ConnConfig 连接配置
@@ -273,12 +403,12 @@ type ConnectionPool struct {
 
 // Pool 池
 type Pool struct {
-	Name        string       `json:"name"`
+	Name        string        `json:"name"`
 	Connections []*Connection `json:"connections"`
-	MaxSize     int          `json:"max_size"`
-	CurrentSize int          `json:"current_size"`
-	Idle        int          `json:"idle"`
-	Busy        int          `json:"busy"`
+	MaxSize     int           `json:"max_size"`
+	CurrentSize int           `json:"current_size"`
+	Idle        int           `json:"idle"`
+	Busy        int           `json:"busy"`
 }
 
 // NewConnectionPool 创建连接池
@@ -295,12 +425,12 @@ func (cp *ConnectionPool) CreatePool(name string, maxSize int) {
 	defer cp.mu.Unlock()
 
 	cp.pools[name] = &Pool{
-		Name:         name,
-		Connections:  make([]*Connection, 0),
-		MaxSize:      maxSize,
-		CurrentSize:  0,
-		Idle:         0,
-		Busy:         0,
+		Name:        name,
+		Connections: make([]*Connection, 0),
+		MaxSize:     maxSize,
+		CurrentSize: 0,
+		Idle:        0,
+		Busy:        0,
 	}
 }
 
@@ -453,14 +583,14 @@ type BackupManager struct {
 
 // Backup 备份
 type Backup struct {
-	ID        string       `json:"id"`
-	ConnName  string       `json:"conn_name"`
-	Type      string       `json:"type"` // "full", "incremental"
-	Path      string       `json:"path"`
-	Size      int64        `json:"size"`
-	Status    string       `json:"status"`
-	StartTime time.Time    `json:"start_time"`
-	EndTime   time.Time    `json:"end_time"`
+	ID        string    `json:"id"`
+	ConnName  string    `json:"conn_name"`
+	Type      string    `json:"type"` // "full", "incremental"
+	Path      string    `json:"path"`
+	Size      int64     `json:"size"`
+	Status    string    `json:"status"`
+	StartTime time.Time `json:"start_time"`
+	EndTime   time.Time `json:"end_time"`
 }
 
 // BackupSchedule 备份计划
@@ -626,8 +756,9 @@ func (rws *ReadWriteSplit) GetWrite() string {
 // TransactionManager 事务管理器
 type TransactionManager struct {
 	transactions map[string]*Transaction
-	db          *DatabaseManager
-	mu          sync.RWMutex
+	current      map[string]string // connName -> 该连接当前活动事务的 ID
+	db           *DatabaseManager
+	mu           sync.RWMutex
 }
 
 // Transaction 事务
@@ -643,7 +774,8 @@ type Transaction struct {
 func NewTransactionManager(db *DatabaseManager) *TransactionManager {
 	return &TransactionManager{
 		transactions: make(map[string]*Transaction),
-		db:          db,
+		current:      make(map[string]string),
+		db:           db,
 	}
 }
 
@@ -712,6 +844,72 @@ func (tm *TransactionManager) Rollback(transactionID string) error {
 	return nil
 }
 
+// currentTx 返回 connName 当前的活动事务（如果有）。
+func (tm *TransactionManager) currentTx(connName string) (*Transaction, bool) {
+	tm.mu.RLock()
+	defer tm.mu.RUnlock()
+
+	id, ok := tm.current[connName]
+	if !ok {
+		return nil, false
+	}
+	tx, exists := tm.transactions[id]
+	if !exists || tx.Status != "active" {
+		return nil, false
+	}
+	return tx, true
+}
+
+// BeginCurrent 为 connName 开启一个事务并将其记为当前活动事务，
+// 供 currentTx 查找。connName 上已有一个活动事务时返回错误 -
+// 这里不支持嵌套或并发事务，调用方需先 Commit 或 Rollback。
+func (tm *TransactionManager) BeginCurrent(ctx context.Context, connName string) (string, error) {
+	if _, ok := tm.currentTx(connName); ok {
+		return "", fmt.Errorf("transaction already active on connection: %s", connName)
+	}
+
+	transaction, err := tm.Begin(ctx, connName)
+	if err != nil {
+		return "", err
+	}
+
+	tm.mu.Lock()
+	tm.current[connName] = transaction.ID
+	tm.mu.Unlock()
+
+	return transaction.ID, nil
+}
+
+// CommitCurrent 提交 connName 上当前的活动事务。
+func (tm *TransactionManager) CommitCurrent(connName string) error {
+	tx, ok := tm.currentTx(connName)
+	if !ok {
+		return fmt.Errorf("no active transaction on connection: %s", connName)
+	}
+	if err := tm.Commit(tx.ID); err != nil {
+		return err
+	}
+	tm.mu.Lock()
+	delete(tm.current, connName)
+	tm.mu.Unlock()
+	return nil
+}
+
+// RollbackCurrent 回滚 connName 上当前的活动事务。
+func (tm *TransactionManager) RollbackCurrent(connName string) error {
+	tx, ok := tm.currentTx(connName)
+	if !ok {
+		return fmt.Errorf("no active transaction on connection: %s", connName)
+	}
+	if err := tm.Rollback(tx.ID); err != nil {
+		return err
+	}
+	tm.mu.Lock()
+	delete(tm.current, connName)
+	tm.mu.Unlock()
+	return nil
+}
+
 // Repository 仓储
 type Repository struct {
 	name string
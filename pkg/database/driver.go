@@ -0,0 +1,169 @@
+package database
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/lib/pq"
+	"github.com/mattn/go-sqlite3"
+)
+
+// NormalizeDSN adapts a few common DSN spellings into the form each
+// driver's database/sql implementation actually expects. lib/pq and
+// mattn/go-sqlite3 already accept DSNs written the way users expect
+// (including postgres:// URLs); go-sql-driver/mysql does not
+// understand a "mysql://" URL, so this rewrites that into its native
+// "user:pass@tcp(host:port)/dbname" form. Any other driver or DSN
+// spelling is returned unchanged.
+func NormalizeDSN(driver, dsn string) string {
+	if driver != "mysql" || !strings.HasPrefix(dsn, "mysql://") {
+		return dsn
+	}
+
+	u, err := url.Parse(dsn)
+	if err != nil || u.Host == "" {
+		return dsn
+	}
+
+	var userInfo string
+	if u.User != nil {
+		if password, ok := u.User.Password(); ok {
+			userInfo = fmt.Sprintf("%s:%s@", u.User.Username(), password)
+		} else {
+			userInfo = fmt.Sprintf("%s@", u.User.Username())
+		}
+	}
+
+	native := fmt.Sprintf("%stcp(%s)/%s", userInfo, u.Host, strings.TrimPrefix(u.Path, "/"))
+	if u.RawQuery != "" {
+		native += "?" + u.RawQuery
+	}
+	return native
+}
+
+// translatePlaceholders rewrites a query written with the ?-style
+// positional placeholders QueryDB/ExecDB scripts use (matching SQLite
+// and MySQL) into the placeholder syntax PostgreSQL expects ($1, $2,
+// ...). Placeholders inside single-quoted string literals are left
+// untouched. Other drivers are returned unchanged.
+func translatePlaceholders(driver, query string) string {
+	if driver != "postgres" {
+		return query
+	}
+
+	var b strings.Builder
+	n := 0
+	inString := false
+	for i := 0; i < len(query); i++ {
+		c := query[i]
+		switch {
+		case c == '\'':
+			inString = !inString
+			b.WriteByte(c)
+		case c == '?' && !inString:
+			n++
+			b.WriteByte('$')
+			b.WriteString(strconv.Itoa(n))
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
+
+// QueryError is a driver-agnostic view of an error returned by a
+// database driver, classified by Kind so callers can handle "that row
+// already exists" or "a required column was missing" the same way
+// regardless of whether the connection is SQLite, PostgreSQL or MySQL.
+type QueryError struct {
+	Kind    string
+	Driver  string
+	Message string
+	Cause   error
+}
+
+func (e *QueryError) Error() string {
+	return fmt.Sprintf("%s (%s): %s", e.Kind, e.Driver, e.Message)
+}
+
+func (e *QueryError) Unwrap() error {
+	return e.Cause
+}
+
+const (
+	KindUniqueViolation     = "unique_violation"
+	KindNotNullViolation    = "not_null_violation"
+	KindForeignKeyViolation = "foreign_key_violation"
+	KindUnknown             = "unknown"
+)
+
+// mysqlErrorCode matches the "Error <code> (<sqlstate>): <message>"
+// format go-sql-driver/mysql's MySQLError.Error() produces.
+var mysqlErrorCode = regexp.MustCompile(`^Error (\d+)`)
+
+// mapDriverError translates a database/sql driver error into a
+// *QueryError. Errors it doesn't recognize are returned unchanged, so
+// callers can still fall back to err.Error()/errors.Is as before.
+func mapDriverError(driver string, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	switch driver {
+	case "postgres":
+		if pqErr, ok := err.(*pq.Error); ok {
+			kind := pqErr.Code.Name()
+			if kind == "" {
+				kind = KindUnknown
+			}
+			return &QueryError{Kind: kind, Driver: driver, Message: pqErr.Message, Cause: err}
+		}
+	case "sqlite3":
+		if sqliteErr, ok := err.(sqlite3.Error); ok {
+			return &QueryError{Kind: classifySQLiteCode(sqliteErr.ExtendedCode), Driver: driver, Message: sqliteErr.Error(), Cause: err}
+		}
+	case "mysql":
+		if m := mysqlErrorCode.FindStringSubmatch(err.Error()); m != nil {
+			return &QueryError{Kind: classifyMySQLCode(m[1]), Driver: driver, Message: err.Error(), Cause: err}
+		}
+	}
+
+	return err
+}
+
+func classifySQLiteCode(code sqlite3.ErrNoExtended) string {
+	switch code {
+	case sqlite3.ErrConstraintUnique, sqlite3.ErrConstraintPrimaryKey:
+		return KindUniqueViolation
+	case sqlite3.ErrConstraintForeignKey:
+		return KindForeignKeyViolation
+	case sqlite3.ErrConstraintNotNull:
+		return KindNotNullViolation
+	default:
+		return KindUnknown
+	}
+}
+
+// MySQL error numbers, from the server's errmsg.h.
+const (
+	mysqlErrDupEntry      = "1062"
+	mysqlErrNoReferenced  = "1451"
+	mysqlErrRowReferenced = "1452"
+	mysqlErrBadNull       = "1048"
+)
+
+func classifyMySQLCode(code string) string {
+	switch code {
+	case mysqlErrDupEntry:
+		return KindUniqueViolation
+	case mysqlErrNoReferenced, mysqlErrRowReferenced:
+		return KindForeignKeyViolation
+	case mysqlErrBadNull:
+		return KindNotNullViolation
+	default:
+		return KindUnknown
+	}
+}
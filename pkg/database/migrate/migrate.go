@@ -19,16 +19,32 @@ type Migration struct {
 	Name    string
 	Up      string
 	Down    string
+	// Lang is "sql" (the default) or "shode". Shode migrations hold a
+	// script body instead of SQL and are executed via the Migrator's
+	// script runner (see SetScriptRunner) rather than tx.Exec.
+	Lang string
 }
 
 // Migrator 迁移器
 type Migrator struct {
-	db          *sql.DB
-	dialect     string
-	tableName   string
-	migrations  []*Migration
-	currentVer  uint
-	targetVer   uint
+	db           *sql.DB
+	dialect      string
+	tableName    string
+	migrations   []*Migration
+	currentVer   uint
+	targetVer    uint
+	scriptRunner func(script string) error
+}
+
+// SetScriptRunner configures how "shode"-language migrations are
+// executed. pkg/database can't depend on the script engine itself
+// (that would be an import cycle, since the engine depends on this
+// package through the stdlib), so callers that want to support
+// *.up.shode / *.down.shode migration files wire in a real
+// ExecutionEngine-backed runner; without one, shode migrations fail
+// with a clear error instead of silently doing nothing.
+func (m *Migrator) SetScriptRunner(run func(script string) error) {
+	m.scriptRunner = run
 }
 
 // Config 迁移配置
@@ -75,8 +91,9 @@ func (m *Migrator) LoadMigrationsFromDir(dir string) error {
 		return fmt.Errorf("failed to read migrations directory: %w", err)
 	}
 
-	// 解析迁移文件: 001_initial.up.sql, 001_initial.down.sql
-	pattern := regexp.MustCompile(`^(\d+)_([^\.]+)\.(up|down)\.sql$`)
+	// 解析迁移文件: 001_initial.up.sql, 001_initial.down.sql (or
+	// .up.shode/.down.shode for a shode script migration)
+	pattern := regexp.MustCompile(`^(\d+)_([^\.]+)\.(up|down)\.(sql|shode)$`)
 
 	migrationMap := make(map[uint]*Migration)
 
@@ -93,6 +110,7 @@ func (m *Migrator) LoadMigrationsFromDir(dir string) error {
 		version, _ := strconv.ParseUint(matches[1], 10, 32)
 		name := matches[2]
 		direction := matches[3]
+		lang := matches[4]
 
 		filePath := filepath.Join(dir, file.Name())
 		content, err := os.ReadFile(filePath)
@@ -105,8 +123,11 @@ func (m *Migrator) LoadMigrationsFromDir(dir string) error {
 			migration = &Migration{
 				Version: uint(version),
 				Name:    name,
+				Lang:    lang,
 			}
 			migrationMap[uint(version)] = migration
+		} else if migration.Lang != lang {
+			return fmt.Errorf("migration %d (%s) has mismatched up/down languages: %s vs %s", version, name, migration.Lang, lang)
 		}
 
 		if direction == "up" {
@@ -276,6 +297,10 @@ func (m *Migrator) DownTo(version uint) error {
 
 // applyMigration 应用迁移
 func (m *Migrator) applyMigration(migration *Migration, direction string) error {
+	if migration.Lang == "shode" {
+		return m.applyScriptMigration(migration, direction)
+	}
+
 	// 开始事务
 	tx, err := m.db.Begin()
 	if err != nil {
@@ -314,6 +339,36 @@ func (m *Migrator) applyMigration(migration *Migration, direction string) error
 	return tx.Commit()
 }
 
+// applyScriptMigration runs a "shode"-language migration through the
+// configured script runner. Shode scripts aren't run inside a SQL
+// transaction (the runner may talk to the database through its own
+// connection handling), so the migration record is only written once
+// the script has finished without error.
+func (m *Migrator) applyScriptMigration(migration *Migration, direction string) error {
+	if m.scriptRunner == nil {
+		return fmt.Errorf("migration %d (%s) is a shode script but no script runner is configured", migration.Version, migration.Name)
+	}
+
+	script := migration.Up
+	if direction == "down" {
+		script = migration.Down
+	}
+
+	if err := m.scriptRunner(script); err != nil {
+		return fmt.Errorf("failed to run migration script: %w", err)
+	}
+
+	if direction == "up" {
+		insertSQL := fmt.Sprintf("INSERT INTO %s (version, name, applied_at) VALUES (?, ?, ?)", m.tableName)
+		_, err := m.db.Exec(insertSQL, migration.Version, migration.Name, time.Now())
+		return err
+	}
+
+	deleteSQL := fmt.Sprintf("DELETE FROM %s WHERE version = ?", m.tableName)
+	_, err := m.db.Exec(deleteSQL, migration.Version)
+	return err
+}
+
 // Status 获取迁移状态
 func (m *Migrator) Status() (*Status, error) {
 	current, err := m.CurrentVersion()
@@ -371,33 +426,54 @@ func (m *Migrator) PrintStatus() error {
 	return nil
 }
 
-// Create 创建新的迁移文件
-func (m *Migrator) Create(dir, name string) error {
-	// 获取下一个版本号
-	nextVersion := uint(len(m.migrations) + 1)
+// Create scaffolds a new pair of migration files in dir, numbered one
+// past the highest version already present on disk (it re-scans dir
+// itself, so it's safe to call without a prior LoadMigrationsFromDir
+// and repeatedly within the same process).
+// lang is "sql" (the default, for an empty string) or "shode".
+func (m *Migrator) Create(dir, name, lang string) error {
+	if lang == "" {
+		lang = "sql"
+	}
+	if lang != "sql" && lang != "shode" {
+		return fmt.Errorf("unsupported migration language: %s (want %q or %q)", lang, "sql", "shode")
+	}
+
+	nextVersion, err := nextMigrationVersion(dir)
+	if err != nil {
+		return err
+	}
 
 	// 格式化版本号
 	versionStr := fmt.Sprintf("%03d", nextVersion)
+	slug := strings.ToLower(strings.ReplaceAll(name, " ", "_"))
 
 	// 创建文件名
-	upFileName := fmt.Sprintf("%s_%s.up.sql", versionStr, strings.ToLower(strings.ReplaceAll(name, " ", "_")))
-	downFileName := fmt.Sprintf("%s_%s.down.sql", versionStr, strings.ToLower(strings.ReplaceAll(name, " ", "_")))
+	upFileName := fmt.Sprintf("%s_%s.up.%s", versionStr, slug, lang)
+	downFileName := fmt.Sprintf("%s_%s.down.%s", versionStr, slug, lang)
 
 	// 创建目录
 	if err := os.MkdirAll(dir, 0755); err != nil {
 		return err
 	}
 
+	var upContent, downContent string
+	if lang == "shode" {
+		upContent = fmt.Sprintf("# Migration: %s\n# Version: %d\n# Up\n\n", name, nextVersion)
+		downContent = fmt.Sprintf("# Migration: %s\n# Version: %d\n# Down\n\n", name, nextVersion)
+	} else {
+		upContent = fmt.Sprintf("-- Migration: %s\n-- Version: %d\n-- Up\n\n", name, nextVersion)
+		downContent = fmt.Sprintf("-- Migration: %s\n-- Version: %d\n-- Down\n\n", name, nextVersion)
+	}
+
 	// 创建 up 文件
 	upPath := filepath.Join(dir, upFileName)
-	upContent := fmt.Sprintf("-- Migration: %s\n-- Version: %d\n-- Up\n\n", name, nextVersion)
 	if err := os.WriteFile(upPath, []byte(upContent), 0644); err != nil {
 		return err
 	}
 
 	// 创建 down 文件
 	downPath := filepath.Join(dir, downFileName)
-	downContent := fmt.Sprintf("-- Migration: %s\n-- Version: %d\n-- Down\n\n", name, nextVersion)
 	if err := os.WriteFile(downPath, []byte(downContent), 0644); err != nil {
 		return err
 	}
@@ -409,6 +485,36 @@ func (m *Migrator) Create(dir, name string) error {
 	return nil
 }
 
+// nextMigrationVersion scans dir for existing numbered migration
+// files and returns one past the highest version found, or 1 if dir
+// doesn't exist yet or has none.
+func nextMigrationVersion(dir string) (uint, error) {
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 1, nil
+		}
+		return 0, fmt.Errorf("failed to read migrations directory: %w", err)
+	}
+
+	pattern := regexp.MustCompile(`^(\d+)_`)
+	var maxVersion uint
+	for _, file := range files {
+		matches := pattern.FindStringSubmatch(file.Name())
+		if matches == nil {
+			continue
+		}
+		version, err := strconv.ParseUint(matches[1], 10, 32)
+		if err != nil {
+			continue
+		}
+		if uint(version) > maxVersion {
+			maxVersion = uint(version)
+		}
+	}
+	return maxVersion + 1, nil
+}
+
 // Validate 验证迁移
 func (m *Migrator) Validate() error {
 	// 检查版本号重复
@@ -0,0 +1,170 @@
+package migrate
+
+import (
+	"database/sql"
+	"os"
+	"path/filepath"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func newTestMigrator(t *testing.T) (*Migrator, string) {
+	t.Helper()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("sql.Open() error = %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	dir := t.TempDir()
+	m := NewMigrator(&Config{DB: db, Dialect: "sqlite3"})
+	if err := m.Init(); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+	return m, dir
+}
+
+func writeMigrationFile(t *testing.T, dir, name, content string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+		t.Fatalf("writing %s: %v", name, err)
+	}
+}
+
+func TestLoadMigrationsFromDirParsesSQLAndShode(t *testing.T) {
+	m, dir := newTestMigrator(t)
+
+	writeMigrationFile(t, dir, "001_create_accounts.up.sql", "CREATE TABLE accounts (id INTEGER PRIMARY KEY)")
+	writeMigrationFile(t, dir, "001_create_accounts.down.sql", "DROP TABLE accounts")
+	writeMigrationFile(t, dir, "002_seed_accounts.up.shode", "ExecDB \"INSERT INTO accounts (id) VALUES (1)\"")
+	writeMigrationFile(t, dir, "002_seed_accounts.down.shode", "ExecDB \"DELETE FROM accounts WHERE id = 1\"")
+
+	if err := m.LoadMigrationsFromDir(dir); err != nil {
+		t.Fatalf("LoadMigrationsFromDir() error = %v", err)
+	}
+
+	if len(m.migrations) != 2 {
+		t.Fatalf("len(migrations) = %d, want 2", len(m.migrations))
+	}
+	if m.migrations[0].Lang != "sql" || m.migrations[1].Lang != "shode" {
+		t.Errorf("migrations = %+v, want lang sql then shode", m.migrations)
+	}
+}
+
+func TestUpAndDownApplySQLMigrations(t *testing.T) {
+	m, dir := newTestMigrator(t)
+
+	writeMigrationFile(t, dir, "001_create_accounts.up.sql", "CREATE TABLE accounts (id INTEGER PRIMARY KEY)")
+	writeMigrationFile(t, dir, "001_create_accounts.down.sql", "DROP TABLE accounts")
+	if err := m.LoadMigrationsFromDir(dir); err != nil {
+		t.Fatalf("LoadMigrationsFromDir() error = %v", err)
+	}
+
+	if err := m.Up(); err != nil {
+		t.Fatalf("Up() error = %v", err)
+	}
+	if _, err := m.db.Exec("INSERT INTO accounts (id) VALUES (1)"); err != nil {
+		t.Fatalf("insert after Up() failed: %v", err)
+	}
+
+	version, err := m.CurrentVersion()
+	if err != nil || version != 1 {
+		t.Fatalf("CurrentVersion() = (%d, %v), want (1, nil)", version, err)
+	}
+
+	if err := m.Down(); err != nil {
+		t.Fatalf("Down() error = %v", err)
+	}
+	if _, err := m.db.Exec("SELECT 1 FROM accounts"); err == nil {
+		t.Error("accounts table should have been dropped by Down()")
+	}
+}
+
+func TestShodeMigrationRequiresScriptRunner(t *testing.T) {
+	m, dir := newTestMigrator(t)
+
+	writeMigrationFile(t, dir, "001_seed.up.shode", "echo hi")
+	writeMigrationFile(t, dir, "001_seed.down.shode", "echo bye")
+	if err := m.LoadMigrationsFromDir(dir); err != nil {
+		t.Fatalf("LoadMigrationsFromDir() error = %v", err)
+	}
+
+	if err := m.Up(); err == nil {
+		t.Fatal("Up() with no script runner configured = nil error, want an error")
+	}
+}
+
+func TestShodeMigrationRunsThroughScriptRunner(t *testing.T) {
+	m, dir := newTestMigrator(t)
+
+	writeMigrationFile(t, dir, "001_seed.up.shode", "# up")
+	writeMigrationFile(t, dir, "001_seed.down.shode", "# down")
+	if err := m.LoadMigrationsFromDir(dir); err != nil {
+		t.Fatalf("LoadMigrationsFromDir() error = %v", err)
+	}
+
+	var ran []string
+	m.SetScriptRunner(func(script string) error {
+		ran = append(ran, script)
+		return nil
+	})
+
+	if err := m.Up(); err != nil {
+		t.Fatalf("Up() error = %v", err)
+	}
+	if len(ran) != 1 || ran[0] != "# up" {
+		t.Errorf("scriptRunner ran %v, want [%q]", ran, "# up")
+	}
+
+	version, err := m.CurrentVersion()
+	if err != nil || version != 1 {
+		t.Fatalf("CurrentVersion() = (%d, %v), want (1, nil)", version, err)
+	}
+}
+
+func TestStatusReportsAppliedAndPending(t *testing.T) {
+	m, dir := newTestMigrator(t)
+
+	writeMigrationFile(t, dir, "001_a.up.sql", "CREATE TABLE a (id INTEGER)")
+	writeMigrationFile(t, dir, "001_a.down.sql", "DROP TABLE a")
+	writeMigrationFile(t, dir, "002_b.up.sql", "CREATE TABLE b (id INTEGER)")
+	writeMigrationFile(t, dir, "002_b.down.sql", "DROP TABLE b")
+	if err := m.LoadMigrationsFromDir(dir); err != nil {
+		t.Fatalf("LoadMigrationsFromDir() error = %v", err)
+	}
+
+	if err := m.UpTo(1); err != nil {
+		t.Fatalf("UpTo(1) error = %v", err)
+	}
+
+	status, err := m.Status()
+	if err != nil {
+		t.Fatalf("Status() error = %v", err)
+	}
+	if len(status.Applied) != 1 || len(status.Pending) != 1 {
+		t.Errorf("Status() = %+v, want 1 applied and 1 pending", status)
+	}
+}
+
+func TestCreateScaffoldsSQLAndShodeMigrations(t *testing.T) {
+	m, dir := newTestMigrator(t)
+
+	if err := m.Create(dir, "add users", "sql"); err != nil {
+		t.Fatalf("Create(sql) error = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "001_add_users.up.sql")); err != nil {
+		t.Errorf("expected up.sql file: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "001_add_users.down.sql")); err != nil {
+		t.Errorf("expected down.sql file: %v", err)
+	}
+
+	if err := m.Create(dir, "seed users", "shode"); err != nil {
+		t.Fatalf("Create(shode) error = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "002_seed_users.up.shode")); err != nil {
+		t.Errorf("expected up.shode file: %v", err)
+	}
+}
@@ -0,0 +1,115 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// structFieldsByColumn maps each column name a struct exposes (via its
+// "db" tag, falling back to the lowercased field name - the same
+// convention GetModelInfo uses) to the addressable field.Value. Unlike
+// GetModelInfo, this works on any struct, not just types implementing
+// Model, so callers don't have to write TableName/PrimaryKey methods
+// just to scan a query result.
+func structFieldsByColumn(v reflect.Value) map[string]reflect.Value {
+	t := v.Type()
+	fields := make(map[string]reflect.Value, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		column := field.Tag.Get("db")
+		if column == "" {
+			column = strings.ToLower(field.Name)
+		}
+		fields[column] = v.Field(i)
+	}
+	return fields
+}
+
+// ScanRow scans the current row of rows into dest. If dest points to a
+// struct, each column is matched to a field by "db" tag (or the
+// lowercased field name); a column with no matching field is
+// discarded. Otherwise dest is scanned as a single column value,
+// same as rows.Scan.
+func ScanRow(rows *sql.Rows, dest interface{}) error {
+	v := reflect.ValueOf(dest)
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Struct {
+		return rows.Scan(dest)
+	}
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return err
+	}
+
+	fields := structFieldsByColumn(v.Elem())
+	targets := make([]interface{}, len(columns))
+	for i, column := range columns {
+		if field, ok := fields[column]; ok {
+			targets[i] = field.Addr().Interface()
+		} else {
+			targets[i] = new(interface{})
+		}
+	}
+
+	return rows.Scan(targets...)
+}
+
+// ScanRows scans every remaining row of rows into dest, a pointer to a
+// slice of structs. It closes no connections and advances rows to
+// exhaustion; the caller is still responsible for rows.Close().
+func ScanRows(rows *sql.Rows, dest interface{}) error {
+	slicePtr := reflect.ValueOf(dest)
+	if slicePtr.Kind() != reflect.Ptr || slicePtr.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("ScanRows: dest must be a pointer to a slice, got %T", dest)
+	}
+
+	sliceValue := slicePtr.Elem()
+	elemType := sliceValue.Type().Elem()
+
+	for rows.Next() {
+		elemPtr := reflect.New(elemType)
+		if err := ScanRow(rows, elemPtr.Interface()); err != nil {
+			return err
+		}
+		sliceValue.Set(reflect.Append(sliceValue, elemPtr.Elem()))
+	}
+
+	return rows.Err()
+}
+
+// RowsToMaps drains rows into a slice of column-name-to-value maps,
+// the same shape QueryDB's JSON output uses. It's shared so Go code
+// building its own JSON responses (e.g. pkg/web handlers) produces
+// output consistent with the script engine's DB builtins.
+func RowsToMaps(rows *sql.Rows) ([]map[string]interface{}, error) {
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]map[string]interface{}, 0)
+	for rows.Next() {
+		values := make([]interface{}, len(columns))
+		pointers := make([]interface{}, len(columns))
+		for i := range values {
+			pointers[i] = &values[i]
+		}
+		if err := rows.Scan(pointers...); err != nil {
+			return nil, err
+		}
+
+		row := make(map[string]interface{}, len(columns))
+		for i, col := range columns {
+			if b, ok := values[i].([]byte); ok {
+				row[col] = string(b)
+			} else {
+				row[col] = values[i]
+			}
+		}
+		results = append(results, row)
+	}
+
+	return results, rows.Err()
+}
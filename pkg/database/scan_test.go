@@ -0,0 +1,116 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+type scanTestUser struct {
+	ID    int64  `db:"id"`
+	Name  string `db:"name"`
+	Email string `db:"email"`
+}
+
+func newScanTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("sql.Open() error = %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	if _, err := db.Exec("CREATE TABLE users (id INTEGER PRIMARY KEY, name TEXT, email TEXT)"); err != nil {
+		t.Fatalf("creating users table: %v", err)
+	}
+	if _, err := db.Exec("INSERT INTO users (id, name, email) VALUES (1, 'Alice', 'alice@example.com'), (2, 'Bob', 'bob@example.com')"); err != nil {
+		t.Fatalf("seeding users table: %v", err)
+	}
+
+	return db
+}
+
+func TestBuilderFirstScansIntoStruct(t *testing.T) {
+	db := newScanTestDB(t)
+	orm := NewORM(db, "sqlite")
+
+	var user scanTestUser
+	if err := orm.QueryBuilder("users").Where("id = ?", 1).First(context.Background(), &user); err != nil {
+		t.Fatalf("First() error = %v", err)
+	}
+
+	if user.ID != 1 || user.Name != "Alice" || user.Email != "alice@example.com" {
+		t.Errorf("First() scanned %+v, want {1 Alice alice@example.com}", user)
+	}
+}
+
+func TestBuilderFirstNoRows(t *testing.T) {
+	db := newScanTestDB(t)
+	orm := NewORM(db, "sqlite")
+
+	var user scanTestUser
+	err := orm.QueryBuilder("users").Where("id = ?", 999).First(context.Background(), &user)
+	if err != sql.ErrNoRows {
+		t.Errorf("First() error = %v, want sql.ErrNoRows", err)
+	}
+}
+
+func TestBuilderAllStructsScansEveryRow(t *testing.T) {
+	db := newScanTestDB(t)
+	orm := NewORM(db, "sqlite")
+
+	var users []scanTestUser
+	if err := orm.QueryBuilder("users").OrderBy("id").AllStructs(context.Background(), &users); err != nil {
+		t.Fatalf("AllStructs() error = %v", err)
+	}
+
+	if len(users) != 2 {
+		t.Fatalf("AllStructs() returned %d users, want 2", len(users))
+	}
+	if users[0].Name != "Alice" || users[1].Name != "Bob" {
+		t.Errorf("AllStructs() = %+v, want Alice then Bob", users)
+	}
+}
+
+func TestAllStructsIgnoresUnmappedColumns(t *testing.T) {
+	db := newScanTestDB(t)
+	orm := NewORM(db, "sqlite")
+
+	type nameOnly struct {
+		Name string `db:"name"`
+	}
+
+	var names []nameOnly
+	if err := orm.QueryBuilder("users").OrderBy("id").AllStructs(context.Background(), &names); err != nil {
+		t.Fatalf("AllStructs() error = %v", err)
+	}
+
+	if len(names) != 2 || names[0].Name != "Alice" {
+		t.Errorf("AllStructs() = %+v, want [{Alice} {Bob}]", names)
+	}
+}
+
+func TestRowsToMaps(t *testing.T) {
+	db := newScanTestDB(t)
+
+	rows, err := db.QueryContext(context.Background(), "SELECT id, name FROM users ORDER BY id")
+	if err != nil {
+		t.Fatalf("query error = %v", err)
+	}
+	defer rows.Close()
+
+	maps, err := RowsToMaps(rows)
+	if err != nil {
+		t.Fatalf("RowsToMaps() error = %v", err)
+	}
+
+	if len(maps) != 2 {
+		t.Fatalf("RowsToMaps() returned %d rows, want 2", len(maps))
+	}
+	if maps[0]["name"] != "Alice" {
+		t.Errorf("RowsToMaps()[0][\"name\"] = %v, want Alice", maps[0]["name"])
+	}
+}
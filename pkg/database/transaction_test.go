@@ -0,0 +1,111 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func newTestDatabaseManager(t *testing.T) (*DatabaseManager, string) {
+	t.Helper()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("sql.Open() error = %v", err)
+	}
+	if _, err := db.Exec("CREATE TABLE accounts (name TEXT, balance INTEGER)"); err != nil {
+		t.Fatalf("creating accounts table: %v", err)
+	}
+	if _, err := db.Exec("INSERT INTO accounts (name, balance) VALUES ('a', 100), ('b', 0)"); err != nil {
+		t.Fatalf("seeding accounts table: %v", err)
+	}
+
+	dm := NewDatabaseManager()
+	connName := "default"
+	if err := dm.RegisterConnection(connName, &Connection{Name: connName, Driver: "sqlite3", DB: db}); err != nil {
+		t.Fatalf("RegisterConnection() error = %v", err)
+	}
+	return dm, connName
+}
+
+func balance(t *testing.T, dm *DatabaseManager, connName, name string) int {
+	t.Helper()
+	rows, err := dm.Query(context.Background(), connName, "SELECT balance FROM accounts WHERE name = ?", name)
+	if err != nil {
+		t.Fatalf("Query() error = %v", err)
+	}
+	defer rows.Close()
+	if !rows.Next() {
+		t.Fatalf("no row for account %q", name)
+	}
+	var bal int
+	if err := rows.Scan(&bal); err != nil {
+		t.Fatalf("Scan() error = %v", err)
+	}
+	return bal
+}
+
+func TestDatabaseManagerBeginTxCommitsAcrossExecCalls(t *testing.T) {
+	dm, connName := newTestDatabaseManager(t)
+
+	if _, err := dm.BeginTx(context.Background(), connName); err != nil {
+		t.Fatalf("BeginTx() error = %v", err)
+	}
+	if _, err := dm.Execute(context.Background(), connName, "UPDATE accounts SET balance = balance - 10 WHERE name = 'a'"); err != nil {
+		t.Fatalf("Execute() debit error = %v", err)
+	}
+	if _, err := dm.Execute(context.Background(), connName, "UPDATE accounts SET balance = balance + 10 WHERE name = 'b'"); err != nil {
+		t.Fatalf("Execute() credit error = %v", err)
+	}
+	if err := dm.CommitTx(connName); err != nil {
+		t.Fatalf("CommitTx() error = %v", err)
+	}
+
+	if got := balance(t, dm, connName, "a"); got != 90 {
+		t.Errorf("account a balance = %d, want 90", got)
+	}
+	if got := balance(t, dm, connName, "b"); got != 10 {
+		t.Errorf("account b balance = %d, want 10", got)
+	}
+}
+
+func TestDatabaseManagerRollbackTxUndoesExecCalls(t *testing.T) {
+	dm, connName := newTestDatabaseManager(t)
+
+	if _, err := dm.BeginTx(context.Background(), connName); err != nil {
+		t.Fatalf("BeginTx() error = %v", err)
+	}
+	if _, err := dm.Execute(context.Background(), connName, "UPDATE accounts SET balance = balance - 10 WHERE name = 'a'"); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if err := dm.RollbackTx(connName); err != nil {
+		t.Fatalf("RollbackTx() error = %v", err)
+	}
+
+	if got := balance(t, dm, connName, "a"); got != 100 {
+		t.Errorf("account a balance after rollback = %d, want 100", got)
+	}
+}
+
+func TestDatabaseManagerBeginTxRejectsNestedTransaction(t *testing.T) {
+	dm, connName := newTestDatabaseManager(t)
+
+	if _, err := dm.BeginTx(context.Background(), connName); err != nil {
+		t.Fatalf("BeginTx() error = %v", err)
+	}
+	defer dm.RollbackTx(connName)
+
+	if _, err := dm.BeginTx(context.Background(), connName); err == nil {
+		t.Error("BeginTx() while a transaction is active = nil error, want error")
+	}
+}
+
+func TestDatabaseManagerCommitTxWithoutBeginReturnsError(t *testing.T) {
+	dm, connName := newTestDatabaseManager(t)
+
+	if err := dm.CommitTx(connName); err == nil {
+		t.Error("CommitTx() without BeginTx = nil error, want error")
+	}
+}
@@ -0,0 +1,141 @@
+package apidoc
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+type address struct {
+	City string `json:"city"`
+	Zip  string `json:"zip,omitempty"`
+}
+
+type user struct {
+	ID      int      `json:"id"`
+	Name    string   `json:"name,omitempty"`
+	Tags    []string `json:"tags,omitempty"`
+	Address address  `json:"address"`
+	secret  string
+}
+
+func TestAddDefinitionFromStructInfersFields(t *testing.T) {
+	g := NewGenerator("Test API", "1.0.0")
+	g.AddDefinitionFromStruct("User", user{})
+
+	schema := g.definitions["User"]
+	if schema == nil {
+		t.Fatal("expected a definition named User")
+	}
+	if schema.Type != "object" {
+		t.Fatalf("expected type object, got %q", schema.Type)
+	}
+
+	id, ok := schema.Properties["id"]
+	if !ok || id.Type != "integer" {
+		t.Fatalf("expected id:integer property, got %+v", id)
+	}
+
+	tags, ok := schema.Properties["tags"]
+	if !ok || tags.Type != "array" || tags.Items == nil || tags.Items.Type != "string" {
+		t.Fatalf("expected tags:array of string, got %+v", tags)
+	}
+
+	addr, ok := schema.Properties["address"]
+	if !ok || addr.Type != "object" {
+		t.Fatalf("expected address:object property, got %+v", addr)
+	}
+	if _, ok := addr.Properties["city"]; !ok {
+		t.Fatalf("expected nested address.city property, got %+v", addr.Properties)
+	}
+
+	if _, ok := schema.Properties["secret"]; ok {
+		t.Fatal("unexported field secret should not be present")
+	}
+
+	requiredSet := map[string]bool{}
+	for _, r := range schema.Required {
+		requiredSet[r] = true
+	}
+	if !requiredSet["id"] || !requiredSet["address"] {
+		t.Fatalf("expected id and address to be required, got %v", schema.Required)
+	}
+	if requiredSet["name"] || requiredSet["tags"] {
+		t.Fatalf("omitempty fields should not be required, got %v", schema.Required)
+	}
+}
+
+func TestGenerateOpenAPIUsesComponentsAndRewritesRefs(t *testing.T) {
+	dir := t.TempDir()
+
+	g := NewGenerator("Test API", "1.0.0")
+	g.SetOutputDir(dir)
+	g.AddDefinitionFromStruct("User", user{})
+	g.AddPath("GET", "/users/{id}", &Path{
+		Summary: "Get a user",
+		Responses: map[int]*Response{
+			200: {Description: "OK", Schema: &Schema{Ref: "#/definitions/User"}},
+		},
+	})
+
+	if err := g.GenerateOpenAPI(); err != nil {
+		t.Fatalf("GenerateOpenAPI returned error: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "openapi.json"))
+	if err != nil {
+		t.Fatalf("reading openapi.json: %v", err)
+	}
+
+	var doc map[string]interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatalf("unmarshal openapi.json: %v", err)
+	}
+
+	if doc["openapi"] != "3.1.0" {
+		t.Fatalf("expected openapi 3.1.0, got %v", doc["openapi"])
+	}
+
+	components, ok := doc["components"].(map[string]interface{})
+	if !ok {
+		t.Fatal("expected top-level components object")
+	}
+	if _, ok := components["schemas"].(map[string]interface{})["User"]; !ok {
+		t.Fatal("expected components.schemas.User")
+	}
+
+	op := doc["paths"].(map[string]interface{})["/users/{id}"].(map[string]interface{})["get"].(map[string]interface{})
+	resp := op["responses"].(map[string]interface{})["200"].(map[string]interface{})
+	content := resp["content"].(map[string]interface{})["application/json"].(map[string]interface{})
+	ref := content["schema"].(map[string]interface{})["$ref"]
+	if ref != "#/components/schemas/User" {
+		t.Fatalf("expected rewritten ref, got %v", ref)
+	}
+}
+
+func TestGenerateMarkdownWritesAPIFile(t *testing.T) {
+	dir := t.TempDir()
+
+	g := NewGenerator("Test API", "1.0.0")
+	g.SetOutputDir(dir)
+	g.AddTag("users", "user management")
+	g.AddPath("GET", "/users", &Path{
+		Summary: "List users",
+		Responses: map[int]*Response{
+			200: {Description: "OK"},
+		},
+	})
+
+	if err := g.GenerateMarkdown(); err != nil {
+		t.Fatalf("GenerateMarkdown returned error: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "API.md"))
+	if err != nil {
+		t.Fatalf("reading API.md: %v", err)
+	}
+	if len(data) == 0 {
+		t.Fatal("expected non-empty API.md")
+	}
+}
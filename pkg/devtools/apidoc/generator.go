@@ -0,0 +1,61 @@
+package apidoc
+
+import "strings"
+
+// tagEntry is one tag registered via AddTag.
+type tagEntry struct {
+	Name        string
+	Description string
+}
+
+// routeEntry is one path operation registered via AddPath.
+type routeEntry struct {
+	Method string
+	Path   string
+	Spec   *Path
+}
+
+// Generator collects API metadata and renders it as OpenAPI 3.1 JSON
+// or a Markdown reference.
+type Generator struct {
+	Title     string
+	Version   string
+	OutputDir string
+
+	tags        []tagEntry
+	definitions map[string]*Schema
+	routes      []routeEntry
+}
+
+// NewGenerator creates a Generator for an API named title at version.
+func NewGenerator(title, version string) *Generator {
+	return &Generator{
+		Title:       title,
+		Version:     version,
+		OutputDir:   ".",
+		definitions: make(map[string]*Schema),
+	}
+}
+
+// SetOutputDir sets the directory GenerateOpenAPI/GenerateMarkdown write to.
+func (g *Generator) SetOutputDir(dir string) {
+	g.OutputDir = dir
+}
+
+// AddTag registers a tag used to group related paths.
+func (g *Generator) AddTag(name, description string) {
+	g.tags = append(g.tags, tagEntry{Name: name, Description: description})
+}
+
+// AddDefinition registers a named schema, referenceable from a Path's
+// Response via &Schema{Ref: "#/definitions/<name>"} (rewritten to the
+// OpenAPI 3.1 "#/components/schemas/<name>" form when rendered).
+func (g *Generator) AddDefinition(name string, schema *Schema) {
+	g.definitions[name] = schema
+}
+
+// AddPath registers an operation for method (e.g. "GET") and path
+// (e.g. "/api/users").
+func (g *Generator) AddPath(method, path string, spec *Path) {
+	g.routes = append(g.routes, routeEntry{Method: strings.ToUpper(method), Path: path, Spec: spec})
+}
@@ -0,0 +1,71 @@
+package apidoc
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// GenerateMarkdown renders the collected API as a human-readable
+// Markdown reference at <OutputDir>/API.md.
+func (g *Generator) GenerateMarkdown() error {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# %s (v%s)\n\n", g.Title, g.Version)
+
+	if len(g.tags) > 0 {
+		b.WriteString("## Tags\n\n")
+		for _, t := range g.tags {
+			if t.Description != "" {
+				fmt.Fprintf(&b, "- **%s** - %s\n", t.Name, t.Description)
+			} else {
+				fmt.Fprintf(&b, "- **%s**\n", t.Name)
+			}
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString("## Endpoints\n\n")
+	for _, r := range g.routes {
+		fmt.Fprintf(&b, "### %s %s\n\n", r.Method, r.Path)
+		if r.Spec.Summary != "" {
+			fmt.Fprintf(&b, "%s\n\n", r.Spec.Summary)
+		}
+		if r.Spec.Description != "" {
+			fmt.Fprintf(&b, "%s\n\n", r.Spec.Description)
+		}
+		if len(r.Spec.Parameters) > 0 {
+			b.WriteString("Parameters:\n\n")
+			for _, p := range r.Spec.Parameters {
+				fmt.Fprintf(&b, "- `%s` (%s)%s - %s\n", p.Name, p.In, requiredSuffix(p.Required), p.Description)
+			}
+			b.WriteString("\n")
+		}
+		if len(r.Spec.Responses) > 0 {
+			b.WriteString("Responses:\n\n")
+			statuses := make([]int, 0, len(r.Spec.Responses))
+			for status := range r.Spec.Responses {
+				statuses = append(statuses, status)
+			}
+			sort.Ints(statuses)
+			for _, status := range statuses {
+				fmt.Fprintf(&b, "- `%d` - %s\n", status, r.Spec.Responses[status].Description)
+			}
+			b.WriteString("\n")
+		}
+	}
+
+	if err := os.MkdirAll(g.OutputDir, 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(g.OutputDir, "API.md"), []byte(b.String()), 0644)
+}
+
+func requiredSuffix(required bool) string {
+	if required {
+		return ", required"
+	}
+	return ""
+}
@@ -0,0 +1,137 @@
+package apidoc
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// openAPIDocument is the root of a rendered OpenAPI 3.1 document.
+type openAPIDocument struct {
+	OpenAPI    string                          `json:"openapi"`
+	Info       openAPIInfo                     `json:"info"`
+	Tags       []openAPITag                    `json:"tags,omitempty"`
+	Paths      map[string]map[string]openAPIOp `json:"paths"`
+	Components openAPIComponents               `json:"components,omitempty"`
+}
+
+type openAPIInfo struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+type openAPITag struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
+
+type openAPIComponents struct {
+	Schemas map[string]*Schema `json:"schemas,omitempty"`
+}
+
+type openAPIOp struct {
+	Summary     string                     `json:"summary,omitempty"`
+	Description string                     `json:"description,omitempty"`
+	Tags        []string                   `json:"tags,omitempty"`
+	Parameters  []*Parameter               `json:"parameters,omitempty"`
+	Responses   map[string]openAPIResponse `json:"responses"`
+}
+
+// openAPIResponse is the OpenAPI 3.x response shape, which wraps a
+// schema in content/media-type instead of the bare "schema" field
+// Swagger 2 used directly on the response object.
+type openAPIResponse struct {
+	Description string                      `json:"description"`
+	Content     map[string]openAPIMediaType `json:"content,omitempty"`
+}
+
+type openAPIMediaType struct {
+	Schema *Schema `json:"schema,omitempty"`
+}
+
+// GenerateOpenAPI renders the collected API as an OpenAPI 3.1 JSON
+// document at <OutputDir>/openapi.json.
+func (g *Generator) GenerateOpenAPI() error {
+	doc := openAPIDocument{
+		OpenAPI: "3.1.0",
+		Info:    openAPIInfo{Title: g.Title, Version: g.Version},
+		Paths:   make(map[string]map[string]openAPIOp),
+		Components: openAPIComponents{
+			Schemas: g.componentSchemas(),
+		},
+	}
+
+	for _, t := range g.tags {
+		doc.Tags = append(doc.Tags, openAPITag{Name: t.Name, Description: t.Description})
+	}
+
+	for _, r := range g.routes {
+		op := openAPIOp{
+			Summary:     r.Spec.Summary,
+			Description: r.Spec.Description,
+			Tags:        r.Spec.Tags,
+			Parameters:  r.Spec.Parameters,
+			Responses:   make(map[string]openAPIResponse),
+		}
+		for status, resp := range r.Spec.Responses {
+			media := map[string]openAPIMediaType{}
+			if resp.Schema != nil {
+				schema := *resp.Schema
+				schema.Ref = rewriteRef(schema.Ref)
+				media["application/json"] = openAPIMediaType{Schema: &schema}
+			}
+			op.Responses[statusKey(status)] = openAPIResponse{Description: resp.Description, Content: media}
+		}
+
+		if doc.Paths[r.Path] == nil {
+			doc.Paths[r.Path] = make(map[string]openAPIOp)
+		}
+		doc.Paths[r.Path][strings.ToLower(r.Method)] = op
+	}
+
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(g.OutputDir, 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(g.OutputDir, "openapi.json"), data, 0644)
+}
+
+// componentSchemas renders g.definitions with any "#/definitions/..."
+// refs they contain rewritten to the OpenAPI 3.1 components form.
+func (g *Generator) componentSchemas() map[string]*Schema {
+	if len(g.definitions) == 0 {
+		return nil
+	}
+	out := make(map[string]*Schema, len(g.definitions))
+	for name, schema := range g.definitions {
+		rendered := *schema
+		rendered.Ref = rewriteRef(rendered.Ref)
+		out[name] = &rendered
+	}
+	return out
+}
+
+// rewriteRef upgrades the Swagger-2-style "#/definitions/X" refs shown
+// in this package's own documentation to OpenAPI 3.1's
+// "#/components/schemas/X" - callers that already pass 3.1-style refs
+// are left untouched.
+func rewriteRef(ref string) string {
+	const oldPrefix = "#/definitions/"
+	if strings.HasPrefix(ref, oldPrefix) {
+		return "#/components/schemas/" + strings.TrimPrefix(ref, oldPrefix)
+	}
+	return ref
+}
+
+func statusKey(status int) string {
+	if status == 0 {
+		return "default"
+	}
+	return strconv.Itoa(status)
+}
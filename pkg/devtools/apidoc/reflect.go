@@ -0,0 +1,126 @@
+package apidoc
+
+import (
+	"reflect"
+	"strings"
+)
+
+// AddDefinitionFromStruct registers a schema for name inferred via
+// reflection from v's fields, so callers don't have to hand-write a
+// Properties map. v may be a struct or a pointer to one. Field names
+// come from "json" tags (falling back to the Go field name); fields
+// tagged json:"-" are skipped, and fields without ",omitempty" in
+// their tag are added to the schema's Required list.
+func (g *Generator) AddDefinitionFromStruct(name string, v interface{}) {
+	g.definitions[name] = schemaFromType(reflect.TypeOf(v))
+}
+
+// schemaFromType builds a Schema (or, for non-struct types, a bare
+// typed Schema with no properties) describing t.
+func schemaFromType(t reflect.Type) *Schema {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if t.Kind() != reflect.Struct {
+		schema := &Schema{Type: openAPIType(t)}
+		if t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+			schema.Items = schemaFromType(t.Elem())
+		}
+		return schema
+	}
+
+	properties, required := propertiesFromStruct(t)
+	return &Schema{Type: "object", Properties: properties, Required: required}
+}
+
+// propertyFromType builds a Property describing t, recursing into
+// nested structs and slice/array element types.
+func propertyFromType(t reflect.Type) *Property {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		properties, required := propertiesFromStruct(t)
+		return &Property{Type: "object", Properties: properties, Required: required}
+	case reflect.Slice, reflect.Array:
+		return &Property{Type: "array", Items: schemaFromType(t.Elem())}
+	default:
+		return &Property{Type: openAPIType(t)}
+	}
+}
+
+// propertiesFromStruct walks t's exported fields, returning the
+// Properties map and Required list shared by Schema and (nested)
+// Property's object representation.
+func propertiesFromStruct(t reflect.Type) (map[string]*Property, []string) {
+	properties := make(map[string]*Property)
+	var required []string
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" {
+			continue // unexported
+		}
+
+		name, omitempty, skip := jsonFieldName(field)
+		if skip {
+			continue
+		}
+
+		properties[name] = propertyFromType(field.Type)
+		if !omitempty {
+			required = append(required, name)
+		}
+	}
+
+	return properties, required
+}
+
+// jsonFieldName parses field's "json" tag, returning the name to use
+// (the Go field name when the tag is absent or has no name), whether
+// "omitempty" was set, and whether the field should be skipped
+// (json:"-").
+func jsonFieldName(field reflect.StructField) (name string, omitempty bool, skip bool) {
+	tag := field.Tag.Get("json")
+	if tag == "" {
+		return field.Name, false, false
+	}
+
+	parts := strings.Split(tag, ",")
+	if parts[0] == "-" && len(parts) == 1 {
+		return "", false, true
+	}
+
+	name = parts[0]
+	if name == "" {
+		name = field.Name
+	}
+	for _, opt := range parts[1:] {
+		if opt == "omitempty" {
+			omitempty = true
+		}
+	}
+	return name, omitempty, false
+}
+
+// openAPIType maps a Go kind to an OpenAPI primitive "type".
+func openAPIType(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "integer"
+	case reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.Map:
+		return "object"
+	default:
+		return "string"
+	}
+}
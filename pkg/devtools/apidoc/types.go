@@ -0,0 +1,51 @@
+// Package apidoc collects a project's API surface (tags, schema
+// definitions, and path operations) as it's declared by calling code,
+// and renders it as an OpenAPI 3.1 document or a Markdown reference.
+package apidoc
+
+// Schema describes an OpenAPI schema object - either an inline type or
+// a $ref to a named definition added via Generator.AddDefinition.
+type Schema struct {
+	Type       string               `json:"type,omitempty"`
+	Format     string               `json:"format,omitempty"`
+	Ref        string               `json:"$ref,omitempty"`
+	Properties map[string]*Property `json:"properties,omitempty"`
+	Items      *Schema              `json:"items,omitempty"`
+	Required   []string             `json:"required,omitempty"`
+}
+
+// Property describes a single field within a Schema. Properties and
+// Required are only meaningful when Type is "object" - they describe
+// the nested object's own fields, mirroring Schema's shape.
+type Property struct {
+	Type        string               `json:"type,omitempty"`
+	Format      string               `json:"format,omitempty"`
+	Description string               `json:"description,omitempty"`
+	Items       *Schema              `json:"items,omitempty"`
+	Properties  map[string]*Property `json:"properties,omitempty"`
+	Required    []string             `json:"required,omitempty"`
+}
+
+// Response describes one HTTP status code's response for a Path.
+type Response struct {
+	Description string  `json:"description"`
+	Schema      *Schema `json:"schema,omitempty"`
+}
+
+// Parameter describes a single request parameter for a Path.
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"` // "path", "query", "header", or "cookie"
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required,omitempty"`
+	Schema      *Schema `json:"schema,omitempty"`
+}
+
+// Path describes a single operation (method+route) in the API.
+type Path struct {
+	Summary     string            `json:"summary,omitempty"`
+	Description string            `json:"description,omitempty"`
+	Tags        []string          `json:"tags,omitempty"`
+	Parameters  []*Parameter      `json:"parameters,omitempty"`
+	Responses   map[int]*Response `json:"responses,omitempty"`
+}
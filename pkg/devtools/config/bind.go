@@ -0,0 +1,184 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// bindStruct copies data onto target (a pointer to a struct), matching
+// each field to a dotted key via its "config" tag (falling back to the
+// lowercased field name), and recursing into nested struct fields.
+func bindStruct(data map[string]interface{}, target interface{}) error {
+	val := reflect.ValueOf(target)
+	if val.Kind() != reflect.Ptr || val.IsNil() {
+		return fmt.Errorf("Load target must be a non-nil pointer")
+	}
+	val = val.Elem()
+	if val.Kind() != reflect.Struct {
+		return fmt.Errorf("Load target must point to a struct")
+	}
+	return bindFields(data, val, "")
+}
+
+func bindFields(data map[string]interface{}, val reflect.Value, prefix string) error {
+	typ := val.Type()
+	for i := 0; i < typ.NumField(); i++ {
+		field := val.Field(i)
+		fieldType := typ.Field(i)
+		if !field.CanSet() {
+			continue
+		}
+
+		key := strings.ToLower(fieldType.Name)
+		if tag := fieldType.Tag.Get("config"); tag != "" {
+			if tag == "-" {
+				continue
+			}
+			key = tag
+		}
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+
+		if field.Kind() == reflect.Struct && field.Type() != reflect.TypeOf(time.Duration(0)) {
+			if err := bindFields(data, field, key); err != nil {
+				return err
+			}
+			continue
+		}
+
+		value, ok := getNested(data, key)
+		if !ok {
+			continue
+		}
+		if err := setField(field, value); err != nil {
+			return fmt.Errorf("field %s: %w", key, err)
+		}
+	}
+	return nil
+}
+
+func setField(field reflect.Value, value interface{}) error {
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(fmt.Sprintf("%v", value))
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if field.Type() == reflect.TypeOf(time.Duration(0)) {
+			d, err := toDuration(value)
+			if err != nil {
+				return err
+			}
+			field.Set(reflect.ValueOf(d))
+			return nil
+		}
+		i, err := toInt64(value)
+		if err != nil {
+			return err
+		}
+		field.SetInt(i)
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		i, err := toInt64(value)
+		if err != nil {
+			return err
+		}
+		field.SetUint(uint64(i))
+
+	case reflect.Float32, reflect.Float64:
+		f, err := toFloat64(value)
+		if err != nil {
+			return err
+		}
+		field.SetFloat(f)
+
+	case reflect.Bool:
+		b, err := toBool(value)
+		if err != nil {
+			return err
+		}
+		field.SetBool(b)
+
+	case reflect.Slice:
+		return setSliceField(field, value)
+
+	default:
+		return fmt.Errorf("unsupported field kind: %s", field.Kind())
+	}
+	return nil
+}
+
+func setSliceField(field reflect.Value, value interface{}) error {
+	items, ok := value.([]interface{})
+	if !ok {
+		return fmt.Errorf("cannot convert %T to slice", value)
+	}
+	elemType := field.Type().Elem()
+	out := reflect.MakeSlice(field.Type(), len(items), len(items))
+	for i, item := range items {
+		elem := reflect.New(elemType).Elem()
+		if err := setField(elem, item); err != nil {
+			return err
+		}
+		out.Index(i).Set(elem)
+	}
+	field.Set(out)
+	return nil
+}
+
+func toInt64(value interface{}) (int64, error) {
+	switch v := value.(type) {
+	case int:
+		return int64(v), nil
+	case int64:
+		return v, nil
+	case float64:
+		return int64(v), nil
+	case string:
+		return strconv.ParseInt(v, 10, 64)
+	default:
+		return 0, fmt.Errorf("cannot convert %T to int", value)
+	}
+}
+
+func toFloat64(value interface{}) (float64, error) {
+	switch v := value.(type) {
+	case float64:
+		return v, nil
+	case int:
+		return float64(v), nil
+	case string:
+		return strconv.ParseFloat(v, 64)
+	default:
+		return 0, fmt.Errorf("cannot convert %T to float", value)
+	}
+}
+
+func toBool(value interface{}) (bool, error) {
+	switch v := value.(type) {
+	case bool:
+		return v, nil
+	case string:
+		return strconv.ParseBool(v)
+	default:
+		return false, fmt.Errorf("cannot convert %T to bool", value)
+	}
+}
+
+func toDuration(value interface{}) (time.Duration, error) {
+	switch v := value.(type) {
+	case time.Duration:
+		return v, nil
+	case int:
+		return time.Duration(v), nil
+	case float64:
+		return time.Duration(v), nil
+	case string:
+		return time.ParseDuration(v)
+	default:
+		return 0, fmt.Errorf("cannot convert %T to duration", value)
+	}
+}
@@ -0,0 +1,202 @@
+package config
+
+import (
+	"flag"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Loader merges configuration from defaults, config files (TOML, YAML,
+// or JSON, selected by file extension), environment variables, and
+// command-line flags into a single result - each source overriding the
+// ones before it in that order - then optionally binds the result onto
+// a struct, validates it, and can hot-reload it when a watched file
+// changes on disk.
+type Loader struct {
+	mu          sync.RWMutex
+	defaults    map[string]interface{}
+	configFiles []string
+	envPrefix   string
+	flagSet     *flag.FlagSet
+	validator   *Validator
+	data        map[string]interface{}
+
+	watchStop chan struct{}
+	onReload  []func(map[string]interface{})
+}
+
+// NewLoader creates an empty Loader.
+func NewLoader() *Loader {
+	return &Loader{
+		defaults: make(map[string]interface{}),
+		data:     make(map[string]interface{}),
+	}
+}
+
+// SetDefaults registers the lowest-precedence values, overridden by
+// every other source. Keys may be dotted ("server.port") to reach
+// nested values.
+func (l *Loader) SetDefaults(defaults map[string]interface{}) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.defaults = defaults
+}
+
+// AddConfigFile queues a TOML/YAML/JSON file to merge in, in the order
+// added - a later file overrides an earlier one wherever they overlap.
+func (l *Loader) AddConfigFile(path string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.configFiles = append(l.configFiles, path)
+}
+
+// SetEnvPrefix sets the prefix (e.g. "APP_") environment variables must
+// carry to be considered overrides; APP_SERVER_PORT becomes the key
+// "server.port". An empty prefix (the default) disables env overrides.
+func (l *Loader) SetEnvPrefix(prefix string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.envPrefix = prefix
+}
+
+// BindFlags registers a FlagSet whose explicitly-set flags (per
+// flag.FlagSet.Visit, so unset flags don't clobber lower-precedence
+// values with their zero defaults) take highest precedence. A flag's
+// own name is used as the dotted key.
+func (l *Loader) BindFlags(fs *flag.FlagSet) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.flagSet = fs
+}
+
+// SetValidator registers the Validator Load runs the bound target
+// through after merging, if a non-nil target was passed to Load.
+func (l *Loader) SetValidator(v *Validator) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.validator = v
+}
+
+// OnReload registers a callback invoked with the freshly merged data
+// whenever Watch detects a config file change and reloads.
+func (l *Loader) OnReload(fn func(map[string]interface{})) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.onReload = append(l.onReload, fn)
+}
+
+// Load merges every configured source and, if target is non-nil (a
+// pointer to a struct), binds the merged result onto it and validates
+// it with the registered Validator, if any.
+func (l *Loader) Load(target interface{}) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.load(target)
+}
+
+func (l *Loader) load(target interface{}) error {
+	merged := map[string]interface{}{}
+	mergeInto(merged, l.defaults)
+
+	for _, path := range l.configFiles {
+		fileData, err := loadConfigFile(path)
+		if err != nil {
+			return fmt.Errorf("loading config file %s: %w", path, err)
+		}
+		mergeInto(merged, fileData)
+	}
+
+	applyEnvOverrides(merged, l.envPrefix)
+	applyFlagOverrides(merged, l.flagSet)
+
+	l.data = merged
+
+	if target == nil {
+		return nil
+	}
+	if err := bindStruct(merged, target); err != nil {
+		return err
+	}
+	if l.validator != nil {
+		return l.validator.Validate(target)
+	}
+	return nil
+}
+
+// Get returns the dotted key's value from the most recent Load.
+func (l *Loader) Get(key string) (interface{}, bool) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return getNested(l.data, key)
+}
+
+// Watch starts polling every configured file at interval and, when any
+// of their modification times advance, re-runs Load(target) and
+// notifies every OnReload callback with the freshly merged data. Call
+// Stop to end watching.
+func (l *Loader) Watch(target interface{}, interval time.Duration) error {
+	l.mu.Lock()
+	if l.watchStop != nil {
+		l.mu.Unlock()
+		return fmt.Errorf("already watching")
+	}
+	stop := make(chan struct{})
+	l.watchStop = stop
+	l.mu.Unlock()
+
+	mtimes := l.fileModTimes()
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				current := l.fileModTimes()
+				if !modTimesEqual(mtimes, current) {
+					mtimes = current
+					l.reload(target)
+				}
+			}
+		}
+	}()
+
+	return nil
+}
+
+// Stop ends a Watch goroutine started by this Loader, if any.
+func (l *Loader) Stop() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.watchStop != nil {
+		close(l.watchStop)
+		l.watchStop = nil
+	}
+}
+
+func (l *Loader) reload(target interface{}) {
+	l.mu.Lock()
+	err := l.load(target)
+	data := l.data
+	callbacks := append([]func(map[string]interface{}){}, l.onReload...)
+	l.mu.Unlock()
+
+	if err != nil {
+		return
+	}
+	for _, cb := range callbacks {
+		cb(data)
+	}
+}
+
+func (l *Loader) fileModTimes() map[string]time.Time {
+	l.mu.RLock()
+	files := append([]string{}, l.configFiles...)
+	l.mu.RUnlock()
+
+	return statModTimes(files)
+}
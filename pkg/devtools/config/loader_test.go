@@ -0,0 +1,107 @@
+package config
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+type appConfig struct {
+	Host string `config:"host"`
+	Port int    `config:"port"`
+}
+
+func TestLoaderMergesDefaultsFilesEnvAndFlags(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.yaml")
+	if err := os.WriteFile(path, []byte("host: file-host\nport: 9000\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("APP_PORT", "9100")
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	host := fs.String("host", "default-from-flag", "")
+	if err := fs.Parse([]string{"-host", "flag-host"}); err != nil {
+		t.Fatal(err)
+	}
+	_ = host
+
+	l := NewLoader()
+	l.SetDefaults(map[string]interface{}{"host": "default-host", "port": 8000})
+	l.AddConfigFile(path)
+	l.SetEnvPrefix("APP_")
+	l.BindFlags(fs)
+
+	var cfg appConfig
+	if err := l.Load(&cfg); err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+
+	if cfg.Host != "flag-host" {
+		t.Fatalf("expected flag to win for host, got %q", cfg.Host)
+	}
+	if cfg.Port != 9100 {
+		t.Fatalf("expected env to win for port, got %d", cfg.Port)
+	}
+}
+
+func TestLoaderRunsValidator(t *testing.T) {
+	type strict struct {
+		Host string `config:"host" validate:"required,ip"`
+	}
+
+	l := NewLoader()
+	l.SetDefaults(map[string]interface{}{"host": "not-an-ip"})
+	l.SetValidator(NewValidator())
+
+	var cfg strict
+	err := l.Load(&cfg)
+	if err == nil {
+		t.Fatal("expected validation to fail for a non-IP host")
+	}
+}
+
+func TestLoaderWatchReloadsOnFileChange(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.json")
+	if err := os.WriteFile(path, []byte(`{"host": "v1"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	l := NewLoader()
+	l.AddConfigFile(path)
+
+	var cfg appConfig
+	if err := l.Load(&cfg); err != nil {
+		t.Fatalf("initial Load failed: %v", err)
+	}
+
+	reloaded := make(chan map[string]interface{}, 1)
+	l.OnReload(func(data map[string]interface{}) { reloaded <- data })
+
+	if err := l.Watch(&cfg, 20*time.Millisecond); err != nil {
+		t.Fatalf("Watch returned error: %v", err)
+	}
+	defer l.Stop()
+
+	time.Sleep(30 * time.Millisecond)
+	if err := os.WriteFile(path, []byte(`{"host": "v2"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case data := <-reloaded:
+		if data["host"] != "v2" {
+			t.Fatalf("expected reloaded host v2, got %v", data["host"])
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for reload callback")
+	}
+
+	if cfg.Host != "v2" {
+		t.Fatalf("expected bound target to be updated, got %q", cfg.Host)
+	}
+}
@@ -0,0 +1,162 @@
+package config
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// loadConfigFile reads path and decodes it as TOML, YAML, or JSON based
+// on its extension.
+func loadConfigFile(path string) (map[string]interface{}, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]interface{})
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".toml":
+		if err := toml.Unmarshal(data, &result); err != nil {
+			return nil, fmt.Errorf("parsing TOML: %w", err)
+		}
+	case ".yaml", ".yml":
+		if err := yaml.Unmarshal(data, &result); err != nil {
+			return nil, fmt.Errorf("parsing YAML: %w", err)
+		}
+	case ".json":
+		if err := json.Unmarshal(data, &result); err != nil {
+			return nil, fmt.Errorf("parsing JSON: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported config file extension %q", filepath.Ext(path))
+	}
+	return result, nil
+}
+
+// mergeInto recursively merges src into dest, src's values overriding
+// dest's wherever they overlap, except when both sides hold a nested
+// map, where the merge recurses instead of replacing.
+func mergeInto(dest, src map[string]interface{}) {
+	for key, value := range src {
+		if srcMap, ok := value.(map[string]interface{}); ok {
+			if destMap, ok := dest[key].(map[string]interface{}); ok {
+				mergeInto(destMap, srcMap)
+				continue
+			}
+		}
+		dest[key] = value
+	}
+}
+
+// getNested looks up a dot-separated key in a tree of nested
+// map[string]interface{} values.
+func getNested(data map[string]interface{}, key string) (interface{}, bool) {
+	parts := strings.Split(key, ".")
+	var current interface{} = data
+	for _, part := range parts {
+		m, ok := current.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		value, ok := m[part]
+		if !ok {
+			return nil, false
+		}
+		current = value
+	}
+	return current, true
+}
+
+// setNested sets a dot-separated key in a tree of nested
+// map[string]interface{} values, creating intermediate maps as needed.
+func setNested(data map[string]interface{}, key string, value interface{}) {
+	parts := strings.Split(key, ".")
+	current := data
+	for _, part := range parts[:len(parts)-1] {
+		next, ok := current[part].(map[string]interface{})
+		if !ok {
+			next = make(map[string]interface{})
+			current[part] = next
+		}
+		current = next
+	}
+	current[parts[len(parts)-1]] = value
+}
+
+// applyEnvOverrides sets data[key] for every environment variable
+// prefixed with prefix, converting APP_SERVER_PORT under prefix "APP_"
+// into the key "server.port". No-op when prefix is empty.
+func applyEnvOverrides(data map[string]interface{}, prefix string) {
+	if prefix == "" {
+		return
+	}
+	for _, entry := range os.Environ() {
+		name, value, ok := strings.Cut(entry, "=")
+		if !ok || !strings.HasPrefix(name, prefix) {
+			continue
+		}
+		key := strings.ToLower(strings.TrimPrefix(name, prefix))
+		key = strings.ReplaceAll(key, "_", ".")
+		setNested(data, key, parseScalar(value))
+	}
+}
+
+// applyFlagOverrides sets data[key] for every flag explicitly passed on
+// the command line (flag.FlagSet.Visit, so unset flags keep whatever
+// lower-precedence source already set), using the flag's own name as
+// the dotted key.
+func applyFlagOverrides(data map[string]interface{}, fs *flag.FlagSet) {
+	if fs == nil {
+		return
+	}
+	fs.Visit(func(f *flag.Flag) {
+		setNested(data, f.Name, parseScalar(f.Value.String()))
+	})
+}
+
+// parseScalar converts a raw string (from an env var or flag value)
+// into a bool, int, float64, or, failing those, the original string.
+func parseScalar(s string) interface{} {
+	if b, err := strconv.ParseBool(s); err == nil {
+		return b
+	}
+	if i, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return int(i)
+	}
+	if f, err := strconv.ParseFloat(s, 64); err == nil {
+		return f
+	}
+	return s
+}
+
+// statModTimes stats every path, returning only the ones that exist.
+func statModTimes(paths []string) map[string]time.Time {
+	mtimes := make(map[string]time.Time, len(paths))
+	for _, path := range paths {
+		if info, err := os.Stat(path); err == nil {
+			mtimes[path] = info.ModTime()
+		}
+	}
+	return mtimes
+}
+
+func modTimesEqual(a, b map[string]time.Time) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for path, t := range a {
+		if !b[path].Equal(t) {
+			return false
+		}
+	}
+	return true
+}
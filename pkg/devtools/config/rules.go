@@ -0,0 +1,173 @@
+package config
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// builtinRules maps a validate tag name to the RuleFunc that
+// implements it.
+var builtinRules = map[string]RuleFunc{
+	"required": required,
+	"min":      min_,
+	"max":      max_,
+	"email":    email,
+	"url":      urlRule,
+	"port":     port,
+	"ip":       ip,
+	"oneof":    oneof,
+	"env":      env,
+	"file":     file,
+}
+
+var emailPattern = regexp.MustCompile(`^[^\s@]+@[^\s@]+\.[^\s@]+$`)
+
+func required(value reflect.Value, _ string) error {
+	if value.IsZero() {
+		return fmt.Errorf("is required")
+	}
+	return nil
+}
+
+func min_(value reflect.Value, param string) error {
+	n, err := strconv.ParseFloat(param, 64)
+	if err != nil {
+		return fmt.Errorf("invalid min parameter %q", param)
+	}
+
+	switch value.Kind() {
+	case reflect.String:
+		if float64(len(value.String())) < n {
+			return fmt.Errorf("must be at least %s characters", param)
+		}
+	case reflect.Slice, reflect.Array, reflect.Map:
+		if float64(value.Len()) < n {
+			return fmt.Errorf("must have at least %s elements", param)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if float64(value.Int()) < n {
+			return fmt.Errorf("must be at least %s", param)
+		}
+	case reflect.Float32, reflect.Float64:
+		if value.Float() < n {
+			return fmt.Errorf("must be at least %s", param)
+		}
+	}
+	return nil
+}
+
+func max_(value reflect.Value, param string) error {
+	n, err := strconv.ParseFloat(param, 64)
+	if err != nil {
+		return fmt.Errorf("invalid max parameter %q", param)
+	}
+
+	switch value.Kind() {
+	case reflect.String:
+		if float64(len(value.String())) > n {
+			return fmt.Errorf("must be at most %s characters", param)
+		}
+	case reflect.Slice, reflect.Array, reflect.Map:
+		if float64(value.Len()) > n {
+			return fmt.Errorf("must have at most %s elements", param)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if float64(value.Int()) > n {
+			return fmt.Errorf("must be at most %s", param)
+		}
+	case reflect.Float32, reflect.Float64:
+		if value.Float() > n {
+			return fmt.Errorf("must be at most %s", param)
+		}
+	}
+	return nil
+}
+
+func email(value reflect.Value, _ string) error {
+	if !emailPattern.MatchString(value.String()) {
+		return fmt.Errorf("must be a valid email address")
+	}
+	return nil
+}
+
+func urlRule(value reflect.Value, _ string) error {
+	u, err := url.ParseRequestURI(value.String())
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return fmt.Errorf("must be a valid URL")
+	}
+	return nil
+}
+
+func port(value reflect.Value, _ string) error {
+	var n int64
+	switch value.Kind() {
+	case reflect.String:
+		parsed, err := strconv.ParseInt(value.String(), 10, 64)
+		if err != nil {
+			return fmt.Errorf("must be a valid port number")
+		}
+		n = parsed
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n = value.Int()
+	default:
+		return fmt.Errorf("must be a valid port number")
+	}
+
+	if n < 1 || n > 65535 {
+		return fmt.Errorf("must be a port number between 1 and 65535")
+	}
+	return nil
+}
+
+func ip(value reflect.Value, _ string) error {
+	if net.ParseIP(value.String()) == nil {
+		return fmt.Errorf("must be a valid IP address")
+	}
+	return nil
+}
+
+func oneof(value reflect.Value, param string) error {
+	options := strings.Split(param, "|")
+	actual := fmt.Sprintf("%v", value.Interface())
+	for _, opt := range options {
+		if actual == opt {
+			return nil
+		}
+	}
+	return fmt.Errorf("must be one of: %s", strings.Join(options, ", "))
+}
+
+func env(value reflect.Value, _ string) error {
+	name := value.String()
+	if _, ok := os.LookupEnv(name); !ok {
+		return fmt.Errorf("environment variable %q is not set", name)
+	}
+	return nil
+}
+
+func file(value reflect.Value, _ string) error {
+	path := value.String()
+	if _, err := os.Stat(path); err != nil {
+		return fmt.Errorf("file %q does not exist", path)
+	}
+	return nil
+}
+
+// requiredWith fails value if it is zero while the sibling field
+// named param (looked up on parent) is non-zero.
+func requiredWith(value, parent reflect.Value, param string) error {
+	sibling := parent.FieldByName(param)
+	if !sibling.IsValid() {
+		return fmt.Errorf("required_with references unknown field %q", param)
+	}
+	if !sibling.IsZero() && value.IsZero() {
+		return fmt.Errorf("is required when %s is set", param)
+	}
+	return nil
+}
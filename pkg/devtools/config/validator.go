@@ -0,0 +1,209 @@
+// Package config provides declarative validation of configuration
+// structs via `validate:"..."` field tags - required fields, format
+// checks (email/url/ip/port), bounds, enums, nested structs, slices,
+// maps, cross-field rules, and user-registered custom rules.
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// ValidationError describes a single failed rule.
+type ValidationError struct {
+	Field   string
+	Tag     string
+	Message string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Field, e.Message)
+}
+
+// ValidationErrors collects every failed rule from one Validate call.
+type ValidationErrors []*ValidationError
+
+func (errs ValidationErrors) Error() string {
+	messages := make([]string, len(errs))
+	for i, e := range errs {
+		messages[i] = e.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+// RuleFunc implements a single validate tag rule. value is the tagged
+// field (or, for a dived slice/map element, the element); param is the
+// text after "=" in the tag (empty if the rule takes no parameter).
+type RuleFunc func(value reflect.Value, param string) error
+
+// Validator applies validate tags to a struct, recursing into nested
+// structs, slices, arrays, and maps.
+type Validator struct {
+	custom map[string]RuleFunc
+}
+
+// NewValidator creates a Validator with only the built-in rules
+// (required, min, max, email, url, port, ip, oneof, env, file,
+// required_with) registered.
+func NewValidator() *Validator {
+	return &Validator{custom: make(map[string]RuleFunc)}
+}
+
+// RegisterValidation adds a custom rule under tag, usable in a
+// `validate:"..."` tag alongside the built-ins. Registering a tag
+// that already exists (built-in or custom) replaces it.
+func (v *Validator) RegisterValidation(tag string, fn RuleFunc) {
+	v.custom[tag] = fn
+}
+
+// Validate walks s (a struct or pointer to one) and returns a non-nil
+// ValidationErrors if any tagged field fails its rules.
+func (v *Validator) Validate(s interface{}) error {
+	rv := reflect.ValueOf(s)
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return &ValidationError{Field: "", Tag: "", Message: "cannot validate a nil pointer"}
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return &ValidationError{Field: "", Tag: "", Message: "Validate requires a struct or pointer to struct"}
+	}
+
+	var errs ValidationErrors
+	v.validateStruct(rv, "", &errs)
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
+// validateStruct applies each exported field's validate tag, then
+// recurses into nested structs/slices/arrays/maps regardless of
+// whether the field itself was tagged - this is what makes nested
+// structs and dive-into-slice/map validation automatic.
+func (v *Validator) validateStruct(rv reflect.Value, prefix string, errs *ValidationErrors) {
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		if field.PkgPath != "" {
+			continue // unexported
+		}
+
+		fv := rv.Field(i)
+		name := prefix + field.Name
+
+		if tag := field.Tag.Get("validate"); tag != "" {
+			v.applyTag(fv, rv, name, tag, errs)
+		}
+
+		v.recurse(fv, name, errs)
+	}
+}
+
+// recurse descends into fv's nested structs/slices/arrays/maps so
+// their own fields (or, for collections, elements) get validated even
+// when the container field itself carries no validate tag.
+func (v *Validator) recurse(fv reflect.Value, name string, errs *ValidationErrors) {
+	switch fv.Kind() {
+	case reflect.Struct:
+		v.validateStruct(fv, name+".", errs)
+	case reflect.Ptr:
+		if !fv.IsNil() && fv.Elem().Kind() == reflect.Struct {
+			v.validateStruct(fv.Elem(), name+".", errs)
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < fv.Len(); i++ {
+			elemName := fmt.Sprintf("%s[%d]", name, i)
+			v.recurse(fv.Index(i), elemName, errs)
+		}
+	case reflect.Map:
+		for _, key := range fv.MapKeys() {
+			elemName := fmt.Sprintf("%s[%v]", name, key.Interface())
+			v.recurse(fv.MapIndex(key), elemName, errs)
+		}
+	}
+}
+
+// applyTag parses tag's comma-separated rules and runs each against
+// fv. A "dive" rule switches the remaining rules to apply to each
+// element of fv (a slice, array, or map) instead of fv itself -
+// required_with and other rules named before "dive" still see parent
+// for sibling lookups.
+func (v *Validator) applyTag(fv, parent reflect.Value, name, tag string, errs *ValidationErrors) {
+	containerRules, elemRules, hasDive := splitDive(tag)
+
+	for _, rule := range containerRules {
+		v.runRule(fv, parent, name, rule, errs)
+	}
+
+	if !hasDive {
+		return
+	}
+
+	switch fv.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < fv.Len(); i++ {
+			elemName := fmt.Sprintf("%s[%d]", name, i)
+			for _, rule := range elemRules {
+				v.runRule(fv.Index(i), parent, elemName, rule, errs)
+			}
+		}
+	case reflect.Map:
+		for _, key := range fv.MapKeys() {
+			elemName := fmt.Sprintf("%s[%v]", name, key.Interface())
+			for _, rule := range elemRules {
+				v.runRule(fv.MapIndex(key), parent, elemName, rule, errs)
+			}
+		}
+	}
+}
+
+// splitDive splits a validate tag's comma-separated rules into the
+// rules that apply before "dive" and the ones that apply after it.
+func splitDive(tag string) (before, after []string, hasDive bool) {
+	for _, rule := range strings.Split(tag, ",") {
+		if rule == "dive" {
+			hasDive = true
+			continue
+		}
+		if hasDive {
+			after = append(after, rule)
+		} else {
+			before = append(before, rule)
+		}
+	}
+	return before, after, hasDive
+}
+
+// runRule looks up rule's tag name (the part before "="), resolves it
+// to a RuleFunc (custom rules take precedence over built-ins so a
+// project can override one), and records a ValidationError if it
+// fails.
+func (v *Validator) runRule(value, parent reflect.Value, name, rule string, errs *ValidationErrors) {
+	tagName, param := rule, ""
+	if idx := strings.Index(rule, "="); idx >= 0 {
+		tagName, param = rule[:idx], rule[idx+1:]
+	}
+
+	if tagName == "required_with" {
+		if err := requiredWith(value, parent, param); err != nil {
+			*errs = append(*errs, &ValidationError{Field: name, Tag: tagName, Message: err.Error()})
+		}
+		return
+	}
+
+	fn, ok := v.custom[tagName]
+	if !ok {
+		fn, ok = builtinRules[tagName]
+	}
+	if !ok {
+		*errs = append(*errs, &ValidationError{Field: name, Tag: tagName, Message: fmt.Sprintf("unknown validation rule %q", tagName)})
+		return
+	}
+
+	if err := fn(value, param); err != nil {
+		*errs = append(*errs, &ValidationError{Field: name, Tag: tagName, Message: err.Error()})
+	}
+}
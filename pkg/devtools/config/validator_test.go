@@ -0,0 +1,120 @@
+package config
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type serverConfig struct {
+	Host     string `validate:"required,ip"`
+	Port     int    `validate:"required,port"`
+	Database string `validate:"required,min=3,max=32"`
+	Email    string `validate:"required,email"`
+	LogLevel string `validate:"required,oneof=debug|info|warn|error"`
+}
+
+func TestValidateFlatFields(t *testing.T) {
+	v := NewValidator()
+
+	bad := serverConfig{Host: "not-an-ip", Port: 99999, Database: "x", Email: "nope", LogLevel: "loud"}
+	err := v.Validate(&bad)
+	if err == nil {
+		t.Fatal("expected validation errors")
+	}
+	errs := err.(ValidationErrors)
+	if len(errs) != 5 {
+		t.Fatalf("expected 5 errors, got %d: %v", len(errs), errs)
+	}
+
+	good := serverConfig{Host: "127.0.0.1", Port: 8080, Database: "app_db", Email: "a@b.com", LogLevel: "info"}
+	if err := v.Validate(&good); err != nil {
+		t.Fatalf("expected no errors, got %v", err)
+	}
+}
+
+type credentials struct {
+	Username string `validate:"required"`
+	Password string `validate:"required_with=Username"`
+}
+
+type nestedConfig struct {
+	Name  string `validate:"required"`
+	Creds credentials
+}
+
+func TestValidateRecursesIntoNestedStruct(t *testing.T) {
+	v := NewValidator()
+
+	cfg := nestedConfig{Name: "svc", Creds: credentials{Username: "admin"}}
+	err := v.Validate(&cfg)
+	if err == nil {
+		t.Fatal("expected an error for missing Password")
+	}
+	if !strings.Contains(err.Error(), "Creds.Password") {
+		t.Fatalf("expected nested field name Creds.Password in error, got %v", err)
+	}
+}
+
+type serverList struct {
+	Hosts []string `validate:"min=1,dive,ip"`
+}
+
+func TestValidateDivesIntoSlice(t *testing.T) {
+	v := NewValidator()
+
+	cfg := serverList{Hosts: []string{"127.0.0.1", "not-an-ip"}}
+	err := v.Validate(&cfg)
+	if err == nil {
+		t.Fatal("expected an error for the bad IP in Hosts")
+	}
+	if !strings.Contains(err.Error(), "Hosts[1]") {
+		t.Fatalf("expected element index in error, got %v", err)
+	}
+
+	ok := serverList{Hosts: []string{"127.0.0.1", "10.0.0.1"}}
+	if err := v.Validate(&ok); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+}
+
+type withMapOfStructs struct {
+	Backends map[string]serverConfig
+}
+
+func TestValidateDivesIntoMapOfStructs(t *testing.T) {
+	v := NewValidator()
+
+	cfg := withMapOfStructs{Backends: map[string]serverConfig{
+		"primary": {Host: "bad", Port: 1, Database: "db", Email: "a@b.com", LogLevel: "info"},
+	}}
+	err := v.Validate(&cfg)
+	if err == nil {
+		t.Fatal("expected an error from the nested map value")
+	}
+	if !strings.Contains(err.Error(), "Backends[primary]") {
+		t.Fatalf("expected map key in error field path, got %v", err)
+	}
+}
+
+func TestRegisterValidationAddsCustomRule(t *testing.T) {
+	v := NewValidator()
+	v.RegisterValidation("even", func(value reflect.Value, _ string) error {
+		if value.Int()%2 != 0 {
+			return errors.New("must be even")
+		}
+		return nil
+	})
+
+	type cfg struct {
+		Count int `validate:"even"`
+	}
+
+	if err := v.Validate(&cfg{Count: 3}); err == nil {
+		t.Fatal("expected odd count to fail the custom even rule")
+	}
+	if err := v.Validate(&cfg{Count: 4}); err != nil {
+		t.Fatalf("expected even count to pass, got %v", err)
+	}
+}
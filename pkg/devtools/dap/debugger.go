@@ -0,0 +1,126 @@
+package dap
+
+import "sync"
+
+// stepMode controls what happens the next time OnBeforeCommand is called.
+type stepMode int
+
+const (
+	stepNone stepMode = iota // run until a breakpoint is hit
+	stepOnce                 // pause at the very next command
+)
+
+// StopReason describes why the debuggee just paused.
+type StopReason struct {
+	Reason string // "breakpoint" or "step"
+	Line   int
+}
+
+// Debugger implements engine.DebugHook, pausing script execution at
+// breakpoints or single steps and exposing the paused state (current
+// line, variables) to a Server. The engine only calls OnBeforeCommand
+// once per command, so step-over and step-into are not distinguishable
+// at this granularity - both behave as "run until the next command".
+type Debugger struct {
+	mu          sync.Mutex
+	breakpoints map[int]bool
+	step        stepMode
+	paused      bool
+	line        int
+	vars        map[string]string
+	resume      chan struct{}
+	stopped     chan StopReason
+}
+
+// NewDebugger creates a Debugger with no breakpoints set.
+func NewDebugger() *Debugger {
+	return &Debugger{
+		breakpoints: make(map[int]bool),
+		stopped:     make(chan StopReason, 1),
+	}
+}
+
+// SetBreakpoints replaces the full set of active breakpoint lines.
+func (d *Debugger) SetBreakpoints(lines []int) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.breakpoints = make(map[int]bool, len(lines))
+	for _, line := range lines {
+		d.breakpoints[line] = true
+	}
+}
+
+// OnBeforeCommand implements engine.DebugHook. It blocks the calling
+// (engine execution) goroutine for as long as the debuggee is paused.
+func (d *Debugger) OnBeforeCommand(line int, vars map[string]string) {
+	d.mu.Lock()
+	_, atBreakpoint := d.breakpoints[line]
+	if !atBreakpoint && d.step == stepNone {
+		d.mu.Unlock()
+		return
+	}
+
+	reason := "breakpoint"
+	if d.step != stepNone {
+		reason = "step"
+	}
+	d.step = stepNone
+	d.line = line
+	d.vars = vars
+	d.paused = true
+	resume := make(chan struct{})
+	d.resume = resume
+	d.mu.Unlock()
+
+	select {
+	case d.stopped <- StopReason{Reason: reason, Line: line}:
+	default:
+	}
+	<-resume
+}
+
+// Continue resumes a paused debuggee, running until the next breakpoint.
+func (d *Debugger) Continue() {
+	d.resumeWith(stepNone)
+}
+
+// Step resumes a paused debuggee for exactly one more command.
+func (d *Debugger) Step() {
+	d.resumeWith(stepOnce)
+}
+
+func (d *Debugger) resumeWith(mode stepMode) {
+	d.mu.Lock()
+	d.step = mode
+	if !d.paused {
+		d.mu.Unlock()
+		return
+	}
+	d.paused = false
+	resume := d.resume
+	d.mu.Unlock()
+	close(resume)
+}
+
+// Stopped returns the channel StopReason events are delivered on.
+func (d *Debugger) Stopped() <-chan StopReason {
+	return d.stopped
+}
+
+// Variables returns a snapshot of the variables captured at the last pause.
+func (d *Debugger) Variables() map[string]string {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	out := make(map[string]string, len(d.vars))
+	for k, v := range d.vars {
+		out[k] = v
+	}
+	return out
+}
+
+// CurrentLine returns the line the debuggee is paused at, or 0 when running.
+func (d *Debugger) CurrentLine() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.line
+}
@@ -0,0 +1,69 @@
+package dap
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDebuggerPausesAtBreakpointAndContinues(t *testing.T) {
+	d := NewDebugger()
+	d.SetBreakpoints([]int{3})
+
+	done := make(chan struct{})
+	go func() {
+		d.OnBeforeCommand(1, map[string]string{"x": "1"})
+		d.OnBeforeCommand(3, map[string]string{"x": "2"})
+		close(done)
+	}()
+
+	select {
+	case reason := <-d.Stopped():
+		if reason.Line != 3 || reason.Reason != "breakpoint" {
+			t.Fatalf("Stopped() = %+v, want line 3 breakpoint", reason)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for breakpoint pause")
+	}
+
+	if got := d.Variables()["x"]; got != "2" {
+		t.Errorf("Variables()[x] = %v, want 2", got)
+	}
+
+	d.Continue()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for Continue() to resume execution")
+	}
+}
+
+func TestDebuggerStepPausesOnNextCommandRegardlessOfBreakpoints(t *testing.T) {
+	d := NewDebugger()
+	// No breakpoints set; a pending step should still pause the very
+	// next command the engine reports.
+	d.Step()
+
+	done := make(chan struct{})
+	go func() {
+		d.OnBeforeCommand(1, nil)
+		close(done)
+	}()
+
+	select {
+	case reason := <-d.Stopped():
+		if reason.Reason != "step" {
+			t.Fatalf("Stopped().Reason = %v, want step", reason.Reason)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for step pause")
+	}
+
+	d.Continue()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for stepped command to finish")
+	}
+}
@@ -0,0 +1,203 @@
+package dap
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"runtime/debug"
+	"sort"
+	"sync"
+
+	"gitee.com/com_818cloud/shode/pkg/errorreport"
+)
+
+// Server handles DAP requests for a single client connection, backed
+// by a Debugger attached to the running ExecutionEngine via SetDebugHook.
+type Server struct {
+	debugger *Debugger
+
+	writeMu sync.Mutex
+	seq     int
+
+	errorReporter *errorreport.Reporter // Reports panics recovered from handle to an external collector; nil until SetErrorReporter attaches one
+}
+
+// NewServer creates a Server driving debugger.
+func NewServer(debugger *Debugger) *Server {
+	return &Server{debugger: debugger}
+}
+
+// SetErrorReporter attaches a reporter that handle sends a recovered
+// panic to, along with the offending DAP command and the stack
+// captured at the recover() site, before responding to the client
+// with a failure instead of taking the connection down. A nil
+// Reporter (the default) makes CapturePanic a no-op.
+func (s *Server) SetErrorReporter(reporter *errorreport.Reporter) {
+	s.errorReporter = reporter
+}
+
+// Serve reads DAP requests from conn and writes responses/events back
+// to it until the client disconnects or conn returns an error.
+func (s *Server) Serve(conn io.ReadWriter) error {
+	reader := bufio.NewReader(conn)
+	stop := make(chan struct{})
+	defer close(stop)
+	go s.forwardStops(conn, stop)
+
+	for {
+		var req Request
+		if err := readMessage(reader, &req); err != nil {
+			return err
+		}
+
+		resp := s.handle(req)
+		if err := s.send(conn, resp); err != nil {
+			return err
+		}
+		if req.Command == "disconnect" {
+			return nil
+		}
+	}
+}
+
+// forwardStops turns Debugger pauses into unsolicited "stopped" events.
+func (s *Server) forwardStops(conn io.Writer, stop <-chan struct{}) {
+	for {
+		select {
+		case <-stop:
+			return
+		case reason := <-s.debugger.Stopped():
+			s.send(conn, &Event{
+				Type:  "event",
+				Event: "stopped",
+				Body: map[string]interface{}{
+					"reason":            reason.Reason,
+					"threadId":          1,
+					"allThreadsStopped": true,
+					"line":              reason.Line,
+				},
+			})
+		}
+	}
+}
+
+func (s *Server) send(w io.Writer, v interface{}) error {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+	s.seq++
+	switch m := v.(type) {
+	case *Response:
+		m.Seq = s.seq
+	case *Event:
+		m.Seq = s.seq
+	}
+	return writeMessage(w, v)
+}
+
+func (s *Server) handle(req Request) (resp *Response) {
+	resp = &Response{Type: "response", RequestSeq: req.Seq, Command: req.Command}
+
+	defer func() {
+		if rec := recover(); rec != nil {
+			stack := debug.Stack()
+			s.errorReporter.CapturePanic(rec, stack, map[string]interface{}{"command": req.Command})
+			resp.Success = false
+			resp.Message = fmt.Sprintf("internal error handling %q: %v", req.Command, rec)
+		}
+	}()
+
+	switch req.Command {
+	case "initialize":
+		resp.Success = true
+		resp.Body = map[string]interface{}{
+			"supportsConfigurationDoneRequest": true,
+			"supportsEvaluateForHovers":        true,
+		}
+	case "setBreakpoints":
+		var args struct {
+			Breakpoints []struct {
+				Line int `json:"line"`
+			} `json:"breakpoints"`
+		}
+		if err := json.Unmarshal(req.Arguments, &args); err != nil {
+			resp.Message = err.Error()
+			return resp
+		}
+		lines := make([]int, 0, len(args.Breakpoints))
+		verified := make([]map[string]interface{}, 0, len(args.Breakpoints))
+		for _, bp := range args.Breakpoints {
+			lines = append(lines, bp.Line)
+			verified = append(verified, map[string]interface{}{"verified": true, "line": bp.Line})
+		}
+		s.debugger.SetBreakpoints(lines)
+		resp.Success = true
+		resp.Body = map[string]interface{}{"breakpoints": verified}
+	case "configurationDone", "launch", "attach", "disconnect":
+		resp.Success = true
+	case "continue":
+		s.debugger.Continue()
+		resp.Success = true
+		resp.Body = map[string]interface{}{"allThreadsContinued": true}
+	case "next", "stepIn", "stepOut":
+		s.debugger.Step()
+		resp.Success = true
+	case "threads":
+		resp.Success = true
+		resp.Body = map[string]interface{}{
+			"threads": []map[string]interface{}{{"id": 1, "name": "main"}},
+		}
+	case "stackTrace":
+		resp.Success = true
+		resp.Body = map[string]interface{}{
+			"stackFrames": []map[string]interface{}{
+				{"id": 0, "name": "script", "line": s.debugger.CurrentLine(), "column": 0},
+			},
+			"totalFrames": 1,
+		}
+	case "scopes":
+		resp.Success = true
+		resp.Body = map[string]interface{}{
+			"scopes": []map[string]interface{}{
+				{"name": "Variables", "variablesReference": 1, "expensive": false},
+			},
+		}
+	case "variables":
+		resp.Success = true
+		resp.Body = map[string]interface{}{"variables": variableList(s.debugger.Variables())}
+	case "evaluate":
+		var args struct {
+			Expression string `json:"expression"`
+		}
+		if err := json.Unmarshal(req.Arguments, &args); err != nil {
+			resp.Message = err.Error()
+			return resp
+		}
+		value, ok := s.debugger.Variables()[args.Expression]
+		if !ok {
+			resp.Message = "not found: " + args.Expression
+			return resp
+		}
+		resp.Success = true
+		resp.Body = map[string]interface{}{"result": value, "variablesReference": 0}
+	default:
+		resp.Message = "unsupported command: " + req.Command
+	}
+	return resp
+}
+
+// variableList renders vars as DAP Variable objects, sorted by name so
+// responses are deterministic.
+func variableList(vars map[string]string) []map[string]interface{} {
+	names := make([]string, 0, len(vars))
+	for name := range vars {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	out := make([]map[string]interface{}, 0, len(names))
+	for _, name := range names {
+		out = append(out, map[string]interface{}{"name": name, "value": vars[name], "variablesReference": 0})
+	}
+	return out
+}
@@ -0,0 +1,165 @@
+package dap
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"gitee.com/com_818cloud/shode/pkg/errorreport"
+)
+
+// dapClient is a tiny asynchronous DAP client used only by tests, so
+// the Server's wire format can be exercised without a real editor.
+// Responses and unsolicited events are demultiplexed onto separate
+// channels, since the Server can push a "stopped" event between a
+// request and its response.
+type dapClient struct {
+	conn      net.Conn
+	seq       int
+	responses chan *Response
+	events    chan *Event
+}
+
+func newDAPClient(conn net.Conn) *dapClient {
+	c := &dapClient{
+		conn:      conn,
+		responses: make(chan *Response, 8),
+		events:    make(chan *Event, 8),
+	}
+	go c.readLoop()
+	return c
+}
+
+func (c *dapClient) readLoop() {
+	reader := bufio.NewReader(c.conn)
+	for {
+		var envelope struct {
+			Type string `json:"type"`
+		}
+		raw := json.RawMessage{}
+		if err := readMessage(reader, &raw); err != nil {
+			return
+		}
+		if err := json.Unmarshal(raw, &envelope); err != nil {
+			return
+		}
+		switch envelope.Type {
+		case "response":
+			var resp Response
+			json.Unmarshal(raw, &resp)
+			c.responses <- &resp
+		case "event":
+			var event Event
+			json.Unmarshal(raw, &event)
+			c.events <- &event
+		}
+	}
+}
+
+func (c *dapClient) request(t *testing.T, command string, args interface{}) *Response {
+	c.seq++
+	var raw json.RawMessage
+	if args != nil {
+		raw, _ = json.Marshal(args)
+	}
+	if err := writeMessage(c.conn, &Request{Seq: c.seq, Type: "request", Command: command, Arguments: raw}); err != nil {
+		t.Fatalf("writeMessage(%s) error = %v", command, err)
+	}
+	select {
+	case resp := <-c.responses:
+		return resp
+	case <-time.After(time.Second):
+		t.Fatalf("timed out waiting for response to %s", command)
+		return nil
+	}
+}
+
+func TestServerSetBreakpointsAndContinueRoundTrip(t *testing.T) {
+	server, client := net.Pipe()
+	defer server.Close()
+	defer client.Close()
+
+	debugger := NewDebugger()
+	s := NewServer(debugger)
+	go s.Serve(server)
+
+	c := newDAPClient(client)
+
+	if resp := c.request(t, "initialize", nil); !resp.Success {
+		t.Fatalf("initialize failed: %v", resp.Message)
+	}
+
+	resp := c.request(t, "setBreakpoints", map[string]interface{}{
+		"breakpoints": []map[string]interface{}{{"line": 5}},
+	})
+	if !resp.Success {
+		t.Fatalf("setBreakpoints failed: %v", resp.Message)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		debugger.OnBeforeCommand(5, map[string]string{"count": "3"})
+		close(done)
+	}()
+
+	select {
+	case event := <-c.events:
+		if event.Event != "stopped" {
+			t.Fatalf("event.Event = %v, want stopped", event.Event)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for stopped event")
+	}
+
+	resp = c.request(t, "variables", nil)
+	if !resp.Success {
+		t.Fatalf("variables failed: %v", resp.Message)
+	}
+
+	resp = c.request(t, "continue", nil)
+	if !resp.Success {
+		t.Fatalf("continue failed: %v", resp.Message)
+	}
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for continue to resume execution")
+	}
+
+	c.request(t, "disconnect", nil)
+}
+
+func TestHandleRecoversFromPanicAndReportsIt(t *testing.T) {
+	var received atomic.Bool
+	collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received.Store(true)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer collector.Close()
+
+	reporter, err := errorreport.NewReporter(fmt.Sprintf("http://testkey@%s/1", collector.Listener.Addr().String()))
+	if err != nil {
+		t.Fatalf("NewReporter error = %v", err)
+	}
+
+	s := NewServer(nil) // no debugger attached, so "variables" panics on the nil dereference
+	s.SetErrorReporter(reporter)
+
+	resp := s.handle(Request{Seq: 1, Type: "request", Command: "variables"})
+	if resp.Success {
+		t.Fatal("expected handle to report failure after recovering from a panic")
+	}
+	if resp.Message == "" {
+		t.Fatal("expected a message describing the recovered panic")
+	}
+	if !received.Load() {
+		t.Fatal("expected the recovered panic to be reported to the collector")
+	}
+}
@@ -0,0 +1,18 @@
+package dap
+
+import (
+	"io"
+	"os"
+)
+
+// stdio pairs os.Stdin and os.Stdout into a single io.ReadWriter, the
+// transport DAP clients (editors) speak to a debug adapter over by default.
+type stdio struct {
+	io.Reader
+	io.Writer
+}
+
+// Stdio returns the standard stdin/stdout transport for Server.Serve.
+func Stdio() io.ReadWriter {
+	return stdio{Reader: os.Stdin, Writer: os.Stdout}
+}
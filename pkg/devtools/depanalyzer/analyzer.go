@@ -0,0 +1,247 @@
+// Package depanalyzer inspects a Go module's package import graph -
+// reporting internal/external/standard-library composition, import
+// cycles, and unused packages - and renders that graph as DOT or
+// Mermaid for visualization.
+package depanalyzer
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"sort"
+	"strings"
+)
+
+// pkgInfo is what Analyze records for a single package.
+type pkgInfo struct {
+	ImportPath string
+	Imports    []string
+	Standard   bool
+	importedBy map[string]bool
+}
+
+// Analyzer walks a module's import graph via `go list` and answers
+// questions about it: package statistics, unused packages, import
+// trees, and import cycles.
+type Analyzer struct {
+	ignore   map[string]bool
+	packages map[string]*pkgInfo
+	order    []string // ImportPath in the order Analyze first saw them, for stable output
+}
+
+// NewAnalyzer creates an empty Analyzer.
+func NewAnalyzer() *Analyzer {
+	return &Analyzer{
+		ignore:   make(map[string]bool),
+		packages: make(map[string]*pkgInfo),
+	}
+}
+
+// IgnorePackage excludes name (an import path, or "C" for cgo) from
+// analysis - it won't appear in reports and its edges are dropped.
+func (a *Analyzer) IgnorePackage(name string) {
+	a.ignore[name] = true
+}
+
+// goListPackage mirrors the subset of `go list -json` output this
+// package needs.
+type goListPackage struct {
+	ImportPath string   `json:"ImportPath"`
+	Imports    []string `json:"Imports"`
+	Standard   bool     `json:"Standard"`
+}
+
+// Analyze runs `go list -json <pattern>` (e.g. "./...") and records
+// the resulting packages and their import edges.
+func (a *Analyzer) Analyze(pattern string) error {
+	cmd := exec.Command("go", "list", "-json", pattern)
+	out, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+
+	dec := json.NewDecoder(bufio.NewReader(out))
+	for {
+		var pkg goListPackage
+		if err := dec.Decode(&pkg); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			_ = cmd.Wait()
+			return err
+		}
+		a.addPackage(pkg)
+	}
+
+	if err := cmd.Wait(); err != nil {
+		return fmt.Errorf("go list %s: %w", pattern, err)
+	}
+
+	a.linkImportedBy()
+	return nil
+}
+
+func (a *Analyzer) addPackage(pkg goListPackage) {
+	if a.ignore[pkg.ImportPath] {
+		return
+	}
+
+	imports := make([]string, 0, len(pkg.Imports))
+	for _, imp := range pkg.Imports {
+		if !a.ignore[imp] {
+			imports = append(imports, imp)
+		}
+	}
+
+	if _, exists := a.packages[pkg.ImportPath]; !exists {
+		a.order = append(a.order, pkg.ImportPath)
+	}
+	a.packages[pkg.ImportPath] = &pkgInfo{
+		ImportPath: pkg.ImportPath,
+		Imports:    imports,
+		Standard:   pkg.Standard,
+		importedBy: make(map[string]bool),
+	}
+}
+
+func (a *Analyzer) linkImportedBy() {
+	for _, p := range a.packages {
+		for _, imp := range p.Imports {
+			if target, ok := a.packages[imp]; ok {
+				target.importedBy[p.ImportPath] = true
+			}
+		}
+	}
+}
+
+// Statistics summarizes the analyzed package set.
+type Statistics struct {
+	Total    int
+	Internal int
+	External int
+	Standard int
+}
+
+// GetPackageStatistics classifies every analyzed package as standard
+// library, or - based on whether it shares a prefix with any other
+// analyzed package's import path - internal or external to the module
+// under analysis.
+func (a *Analyzer) GetPackageStatistics() Statistics {
+	stats := Statistics{Total: len(a.packages)}
+
+	modulePrefix := a.modulePrefix()
+	for path, p := range a.packages {
+		switch {
+		case p.Standard:
+			stats.Standard++
+		case modulePrefix != "" && strings.HasPrefix(path, modulePrefix):
+			stats.Internal++
+		default:
+			stats.External++
+		}
+	}
+	return stats
+}
+
+// modulePrefix guesses the analyzed module's own import path prefix
+// from the shortest recorded non-standard package path, which for a
+// `go list ./...` run is always the module root itself.
+func (a *Analyzer) modulePrefix() string {
+	shortest := ""
+	for path, p := range a.packages {
+		if p.Standard {
+			continue
+		}
+		if shortest == "" || len(path) < len(shortest) {
+			shortest = path
+		}
+	}
+	return shortest
+}
+
+// FindUnusedPackages returns the import paths of analyzed,
+// non-standard packages that nothing else in the analyzed set
+// imports - candidates for removal.
+func (a *Analyzer) FindUnusedPackages() []string {
+	var unused []string
+	for _, path := range a.order {
+		p := a.packages[path]
+		if p.Standard {
+			continue
+		}
+		if len(p.importedBy) == 0 {
+			unused = append(unused, path)
+		}
+	}
+	sort.Strings(unused)
+	return unused
+}
+
+// ImportNode is one node of the tree returned by GetImportTree.
+type ImportNode struct {
+	ImportPath string
+	Children   []*ImportNode
+}
+
+// GetImportTree builds the import tree rooted at root, descending at
+// most depth levels (depth <= 0 means unlimited). Cycles are broken
+// by not revisiting an ancestor already on the current path.
+func (a *Analyzer) GetImportTree(root string, depth int) *ImportNode {
+	return a.buildTree(root, depth, map[string]bool{})
+}
+
+func (a *Analyzer) buildTree(path string, depth int, visiting map[string]bool) *ImportNode {
+	node := &ImportNode{ImportPath: path}
+
+	p, ok := a.packages[path]
+	if !ok || visiting[path] || depth == 1 {
+		return node
+	}
+
+	visiting[path] = true
+	defer delete(visiting, path)
+
+	nextDepth := depth
+	if depth > 0 {
+		nextDepth = depth - 1
+	}
+
+	imports := append([]string(nil), p.Imports...)
+	sort.Strings(imports)
+	for _, imp := range imports {
+		node.Children = append(node.Children, a.buildTree(imp, nextDepth, visiting))
+	}
+	return node
+}
+
+// PrintReport prints a human-readable summary of the analyzed
+// packages: totals, unused packages, and any import cycles found
+// (with the offending path, so they can actually be acted on).
+func (a *Analyzer) PrintReport() {
+	stats := a.GetPackageStatistics()
+	fmt.Printf("Packages analyzed: %d (internal: %d, external: %d, standard: %d)\n",
+		stats.Total, stats.Internal, stats.External, stats.Standard)
+
+	if unused := a.FindUnusedPackages(); len(unused) > 0 {
+		fmt.Println("Unused packages:")
+		for _, path := range unused {
+			fmt.Printf("  - %s\n", path)
+		}
+	}
+
+	cycles := a.FindCycles()
+	if len(cycles) == 0 {
+		fmt.Println("No import cycles found.")
+		return
+	}
+	fmt.Printf("Import cycles (%d):\n", len(cycles))
+	for _, c := range cycles {
+		fmt.Printf("  - %s\n", strings.Join(c.Path, " -> "))
+	}
+}
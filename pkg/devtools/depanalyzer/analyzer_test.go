@@ -0,0 +1,132 @@
+package depanalyzer
+
+import (
+	"strings"
+	"testing"
+)
+
+// newTestAnalyzer builds an Analyzer directly from an edge map,
+// bypassing Analyze (which shells out to `go list`), so these tests
+// stay hermetic.
+func newTestAnalyzer(edges map[string][]string) *Analyzer {
+	a := NewAnalyzer()
+	for path, imports := range edges {
+		a.addPackage(goListPackage{ImportPath: path, Imports: imports})
+	}
+	a.linkImportedBy()
+	return a
+}
+
+func TestFindCyclesDetectsDirectCycle(t *testing.T) {
+	a := newTestAnalyzer(map[string][]string{
+		"mod/a": {"mod/b"},
+		"mod/b": {"mod/a"},
+	})
+
+	cycles := a.FindCycles()
+	if len(cycles) != 1 {
+		t.Fatalf("expected 1 cycle, got %d: %+v", len(cycles), cycles)
+	}
+	path := cycles[0].Path
+	// DFS may start from either package depending on map iteration
+	// order, so the cycle can be reported as either rotation.
+	if len(path) != 3 || path[0] != path[2] {
+		t.Fatalf("expected a 3-element closed path, got %v", path)
+	}
+	members := map[string]bool{path[0]: true, path[1]: true}
+	if !members["mod/a"] || !members["mod/b"] {
+		t.Fatalf("expected cycle over mod/a and mod/b, got %v", path)
+	}
+}
+
+func TestFindCyclesNoneOnAcyclicGraph(t *testing.T) {
+	a := newTestAnalyzer(map[string][]string{
+		"mod/a": {"mod/b"},
+		"mod/b": {"mod/c"},
+		"mod/c": nil,
+	})
+
+	if cycles := a.FindCycles(); len(cycles) != 0 {
+		t.Fatalf("expected no cycles, got %+v", cycles)
+	}
+}
+
+func TestFindUnusedPackages(t *testing.T) {
+	a := newTestAnalyzer(map[string][]string{
+		"mod/a": {"mod/b"},
+		"mod/b": nil,
+		"mod/c": nil, // nothing imports mod/c
+	})
+
+	unused := a.FindUnusedPackages()
+	if len(unused) != 2 || unused[0] != "mod/a" || unused[1] != "mod/c" {
+		t.Fatalf("unexpected unused packages: %v", unused)
+	}
+}
+
+func TestGetImportTreeBreaksCycles(t *testing.T) {
+	a := newTestAnalyzer(map[string][]string{
+		"mod/a": {"mod/b"},
+		"mod/b": {"mod/a"},
+	})
+
+	tree := a.GetImportTree("mod/a", 0)
+	if tree.ImportPath != "mod/a" {
+		t.Fatalf("expected root mod/a, got %s", tree.ImportPath)
+	}
+	if len(tree.Children) != 1 || tree.Children[0].ImportPath != "mod/b" {
+		t.Fatalf("expected one child mod/b, got %+v", tree.Children)
+	}
+	// mod/b -> mod/a would re-enter mod/a, which must be cut off rather than looping forever.
+	grandchild := tree.Children[0].Children
+	if len(grandchild) != 1 || len(grandchild[0].Children) != 0 {
+		t.Fatalf("expected cycle back-edge to be a childless leaf, got %+v", grandchild)
+	}
+}
+
+func TestExportDOTMarksCycleEdgesRed(t *testing.T) {
+	a := newTestAnalyzer(map[string][]string{
+		"mod/a": {"mod/b"},
+		"mod/b": {"mod/a"},
+	})
+
+	dot := a.ExportDOT()
+	if !strings.HasPrefix(dot, "digraph dependencies {") {
+		t.Fatalf("expected a digraph header, got: %s", dot)
+	}
+	if !strings.Contains(dot, `"mod/a" -> "mod/b" [color=red];`) {
+		t.Fatalf("expected red cycle edge, got: %s", dot)
+	}
+}
+
+func TestExportMermaidIncludesAllNodesAndEdges(t *testing.T) {
+	a := newTestAnalyzer(map[string][]string{
+		"mod/a": {"mod/b"},
+		"mod/b": nil,
+	})
+
+	mermaid := a.ExportMermaid()
+	if !strings.HasPrefix(mermaid, "flowchart LR") {
+		t.Fatalf("expected a flowchart header, got: %s", mermaid)
+	}
+	if !strings.Contains(mermaid, `"mod/a"`) || !strings.Contains(mermaid, `"mod/b"`) {
+		t.Fatalf("expected both nodes labeled, got: %s", mermaid)
+	}
+	if !strings.Contains(mermaid, "-->") {
+		t.Fatalf("expected an edge, got: %s", mermaid)
+	}
+}
+
+func TestGetPackageStatistics(t *testing.T) {
+	a := NewAnalyzer()
+	a.addPackage(goListPackage{ImportPath: "mod", Imports: []string{"mod/a"}})
+	a.addPackage(goListPackage{ImportPath: "mod/a", Imports: []string{"mod/b", "fmt"}})
+	a.addPackage(goListPackage{ImportPath: "mod/b", Imports: nil})
+	a.addPackage(goListPackage{ImportPath: "fmt", Standard: true})
+	a.linkImportedBy()
+
+	stats := a.GetPackageStatistics()
+	if stats.Total != 4 || stats.Standard != 1 || stats.Internal != 3 {
+		t.Fatalf("unexpected stats: %+v", stats)
+	}
+}
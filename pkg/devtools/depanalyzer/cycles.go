@@ -0,0 +1,87 @@
+package depanalyzer
+
+import "sort"
+
+// Cycle is one import cycle, Path listing each package in order
+// followed by the first package again (e.g. A -> B -> C -> A).
+type Cycle struct {
+	Path []string
+}
+
+// cycleState tracks DFS coloring for FindCycles.
+type cycleState int
+
+const (
+	unvisited cycleState = iota
+	visiting
+	done
+)
+
+// FindCycles detects import cycles among the analyzed packages via
+// depth-first search, returning each distinct cycle with its full
+// path so it can be acted on directly instead of just counted.
+func (a *Analyzer) FindCycles() []Cycle {
+	state := make(map[string]cycleState, len(a.packages))
+	var cycles []Cycle
+	seen := make(map[string]bool)
+
+	for _, path := range a.order {
+		if state[path] == unvisited {
+			a.walkForCycles(path, state, nil, &cycles, seen)
+		}
+	}
+
+	sort.Slice(cycles, func(i, j int) bool {
+		return joinPath(cycles[i].Path) < joinPath(cycles[j].Path)
+	})
+	return cycles
+}
+
+func (a *Analyzer) walkForCycles(path string, state map[string]cycleState, stack []string, cycles *[]Cycle, seen map[string]bool) {
+	state[path] = visiting
+	stack = append(stack, path)
+
+	for _, imp := range a.packages[path].Imports {
+		if _, ok := a.packages[imp]; !ok {
+			continue
+		}
+
+		switch state[imp] {
+		case unvisited:
+			a.walkForCycles(imp, state, stack, cycles, seen)
+		case visiting:
+			cycle := extractCycle(stack, imp)
+			key := joinPath(cycle)
+			if !seen[key] {
+				seen[key] = true
+				*cycles = append(*cycles, Cycle{Path: cycle})
+			}
+		}
+	}
+
+	state[path] = done
+}
+
+// extractCycle returns the portion of stack from its first occurrence
+// of target through its end, with target appended again to close the
+// loop.
+func extractCycle(stack []string, target string) []string {
+	for i, p := range stack {
+		if p == target {
+			cycle := append([]string(nil), stack[i:]...)
+			return append(cycle, target)
+		}
+	}
+	return append(append([]string(nil), stack...), target)
+}
+
+func joinPath(path []string) string {
+	out := ""
+	for i, p := range path {
+		if i > 0 {
+			out += "\x00"
+		}
+		out += p
+	}
+	return out
+}
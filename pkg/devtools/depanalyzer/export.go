@@ -0,0 +1,75 @@
+package depanalyzer
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ExportDOT renders the analyzed import graph as Graphviz DOT, with
+// edges that participate in an import cycle drawn in red so cycles
+// are easy to spot visually.
+func (a *Analyzer) ExportDOT() string {
+	cycleEdges := a.cycleEdgeSet()
+
+	var b strings.Builder
+	b.WriteString("digraph dependencies {\n")
+	b.WriteString("  rankdir=LR;\n")
+
+	for _, path := range a.order {
+		for _, imp := range a.packages[path].Imports {
+			if _, ok := a.packages[imp]; !ok {
+				continue
+			}
+			if cycleEdges[edgeKey(path, imp)] {
+				fmt.Fprintf(&b, "  %q -> %q [color=red];\n", path, imp)
+			} else {
+				fmt.Fprintf(&b, "  %q -> %q;\n", path, imp)
+			}
+		}
+	}
+
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// ExportMermaid renders the analyzed import graph as a Mermaid
+// flowchart definition.
+func (a *Analyzer) ExportMermaid() string {
+	ids := make(map[string]string, len(a.order))
+	for i, path := range a.order {
+		ids[path] = fmt.Sprintf("n%d", i)
+	}
+
+	var b strings.Builder
+	b.WriteString("flowchart LR\n")
+
+	for _, path := range a.order {
+		fmt.Fprintf(&b, "  %s[%q]\n", ids[path], path)
+	}
+	for _, path := range a.order {
+		for _, imp := range a.packages[path].Imports {
+			if _, ok := a.packages[imp]; !ok {
+				continue
+			}
+			fmt.Fprintf(&b, "  %s --> %s\n", ids[path], ids[imp])
+		}
+	}
+
+	return b.String()
+}
+
+// cycleEdgeSet returns the set of "from\x00to" edges that appear in
+// at least one detected cycle.
+func (a *Analyzer) cycleEdgeSet() map[string]bool {
+	edges := make(map[string]bool)
+	for _, cycle := range a.FindCycles() {
+		for i := 0; i+1 < len(cycle.Path); i++ {
+			edges[edgeKey(cycle.Path[i], cycle.Path[i+1])] = true
+		}
+	}
+	return edges
+}
+
+func edgeKey(from, to string) string {
+	return from + "\x00" + to
+}
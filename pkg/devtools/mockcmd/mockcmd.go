@@ -0,0 +1,127 @@
+// Package mockcmd generates command doubles: small shell scripts that
+// stand in for real external commands (curl, kubectl, ...) during
+// tests, printing scripted output instead of making real network or
+// cluster calls. Point an engine.ExecutionEngine at the generated
+// directory with SetMockCommandDir to have it prefer these doubles over
+// the real commands on PATH.
+package mockcmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Response is one scripted reply a command double gives when its
+// arguments match. Match is compared against the double's arguments
+// joined with spaces; an empty Match matches any call not matched by an
+// earlier Response, acting as the double's default reply.
+type Response struct {
+	Match    []string
+	Stdout   string
+	Stderr   string
+	ExitCode int
+}
+
+// CommandDouble is a fake implementation of one external command, with
+// a list of scripted Responses tried in order.
+type CommandDouble struct {
+	Name      string
+	Responses []Response
+}
+
+// AddResponse appends a Response matched on the given arguments.
+func (d *CommandDouble) AddResponse(match []string, stdout string, exitCode int) {
+	d.Responses = append(d.Responses, Response{Match: match, Stdout: stdout, ExitCode: exitCode})
+}
+
+// AddDefaultResponse appends a catch-all Response for arguments no
+// earlier Response matched.
+func (d *CommandDouble) AddDefaultResponse(stdout string, exitCode int) {
+	d.AddResponse(nil, stdout, exitCode)
+}
+
+// Generator writes a directory of command doubles.
+type Generator struct {
+	doubles map[string]*CommandDouble
+	order   []string
+}
+
+// NewGenerator creates an empty Generator.
+func NewGenerator() *Generator {
+	return &Generator{doubles: make(map[string]*CommandDouble)}
+}
+
+// Command returns the CommandDouble for name, creating it if this is
+// the first call for that name.
+func (g *Generator) Command(name string) *CommandDouble {
+	if d, ok := g.doubles[name]; ok {
+		return d
+	}
+	d := &CommandDouble{Name: name}
+	g.doubles[name] = d
+	g.order = append(g.order, name)
+	return d
+}
+
+// Generate writes every registered CommandDouble to dir as an
+// executable shell script named after the command, e.g. dir/curl.
+// Prepend dir to PATH (or pass it to
+// engine.ExecutionEngine.SetMockCommandDir) to have scripts under test
+// call the doubles instead of the real commands.
+func (g *Generator) Generate(dir string) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	for _, name := range g.order {
+		script := renderScript(g.doubles[name])
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(script), 0755); err != nil {
+			return fmt.Errorf("writing command double %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// renderScript renders d as a POSIX shell script dispatching on "$*".
+func renderScript(d *CommandDouble) string {
+	var b strings.Builder
+	b.WriteString("#!/bin/sh\n")
+	fmt.Fprintf(&b, "# mock %s, generated by pkg/devtools/mockcmd - do not edit by hand\n", d.Name)
+	b.WriteString("case \"$*\" in\n")
+
+	haveDefault := false
+	for _, r := range d.Responses {
+		pattern := "*"
+		if len(r.Match) > 0 {
+			pattern = shellQuote(strings.Join(r.Match, " "))
+		} else {
+			haveDefault = true
+		}
+		fmt.Fprintf(&b, "%s)\n", pattern)
+		if r.Stdout != "" {
+			fmt.Fprintf(&b, "  printf '%%s' %s\n", shellQuote(r.Stdout))
+		}
+		if r.Stderr != "" {
+			fmt.Fprintf(&b, "  printf '%%s' %s >&2\n", shellQuote(r.Stderr))
+		}
+		fmt.Fprintf(&b, "  exit %d\n", r.ExitCode)
+		b.WriteString("  ;;\n")
+		if pattern == "*" {
+			break // a "*)" case ends the dispatch; anything after is unreachable
+		}
+	}
+	if !haveDefault {
+		fmt.Fprintf(&b, "*)\n  echo %s: unexpected arguments: \"$*\" >&2\n  exit 127\n  ;;\n", d.Name)
+	}
+
+	b.WriteString("esac\n")
+	return b.String()
+}
+
+// shellQuote wraps s in single quotes, escaping any single quotes it
+// contains, so it can be safely embedded in the generated script.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,60 @@
+package mockcmd
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestGenerateWritesExecutableScriptsMatchingArguments(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("generated doubles are POSIX shell scripts")
+	}
+
+	g := NewGenerator()
+	curl := g.Command("curl")
+	curl.AddResponse([]string{"-s", "https://example.com/health"}, "ok", 0)
+	curl.AddDefaultResponse("", 22)
+
+	dir := t.TempDir()
+	if err := g.Generate(dir); err != nil {
+		t.Fatalf("Generate returned error: %v", err)
+	}
+
+	path := filepath.Join(dir, "curl")
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("expected curl double to exist: %v", err)
+	}
+	if info.Mode()&0111 == 0 {
+		t.Fatalf("expected curl double to be executable, got mode %v", info.Mode())
+	}
+
+	out, err := exec.Command(path, "-s", "https://example.com/health").Output()
+	if err != nil {
+		t.Fatalf("matched call failed: %v", err)
+	}
+	if string(out) != "ok" {
+		t.Fatalf("expected stdout %q, got %q", "ok", string(out))
+	}
+
+	cmd := exec.Command(path, "-s", "https://other.example.com")
+	if err := cmd.Run(); err == nil {
+		t.Fatal("expected the default response's exit code to produce an error")
+	} else if exitErr, ok := err.(*exec.ExitError); !ok || exitErr.ExitCode() != 22 {
+		t.Fatalf("expected exit code 22 from the default response, got %v", err)
+	}
+}
+
+func TestCommandReturnsTheSameDoubleForRepeatedCalls(t *testing.T) {
+	g := NewGenerator()
+	a := g.Command("kubectl")
+	a.AddResponse([]string{"get", "pods"}, "pod1 Running\n", 0)
+
+	b := g.Command("kubectl")
+	if len(b.Responses) != 1 {
+		t.Fatalf("expected Command to return the same double, got %d responses", len(b.Responses))
+	}
+}
@@ -0,0 +1,150 @@
+package tester
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// FileCoverage is one source file's coverage, aggregated from a `go
+// test -coverprofile` profile's per-block counts.
+type FileCoverage struct {
+	FileName   string // the profile's import-path-style file name, e.g. "example.com/m/pkg/foo.go"
+	Statements int
+	Covered    int
+}
+
+// Percent returns the fraction of Statements with a non-zero count,
+// as a 0-100 percentage.
+func (f FileCoverage) Percent() float64 {
+	if f.Statements == 0 {
+		return 0
+	}
+	return float64(f.Covered) / float64(f.Statements) * 100
+}
+
+// PackageCoverage aggregates FileCoverage for every file the profile
+// recorded under one package import path.
+type PackageCoverage struct {
+	Package    string
+	Files      []FileCoverage
+	Statements int
+	Covered    int
+}
+
+// Percent returns the package's overall statement coverage, as a
+// 0-100 percentage.
+func (p PackageCoverage) Percent() float64 {
+	if p.Statements == 0 {
+		return 0
+	}
+	return float64(p.Covered) / float64(p.Statements) * 100
+}
+
+// ParseCoverageProfile reads a `go test -coverprofile` file (the
+// "mode: ..." header followed by "file:startLine.startCol,endLine.endCol numStmt count"
+// lines) and aggregates it into per-package statistics.
+func ParseCoverageProfile(profilePath string) ([]PackageCoverage, error) {
+	f, err := os.Open(profilePath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return parseCoverageProfile(f)
+}
+
+func parseCoverageProfile(r io.Reader) ([]PackageCoverage, error) {
+	files := map[string]*FileCoverage{}
+	var order []string
+
+	scanner := bufio.NewScanner(r)
+	first := true
+	for scanner.Scan() {
+		line := scanner.Text()
+		if first {
+			first = false
+			if strings.HasPrefix(line, "mode:") {
+				continue // header line, no per-file data
+			}
+		}
+		if line == "" {
+			continue
+		}
+
+		fileName, numStmt, count, err := parseCoverageLine(line)
+		if err != nil {
+			return nil, err
+		}
+
+		fc, ok := files[fileName]
+		if !ok {
+			fc = &FileCoverage{FileName: fileName}
+			files[fileName] = fc
+			order = append(order, fileName)
+		}
+		fc.Statements += numStmt
+		if count > 0 {
+			fc.Covered += numStmt
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	packages := map[string]*PackageCoverage{}
+	var pkgOrder []string
+	for _, fileName := range order {
+		fc := files[fileName]
+		pkgPath := path.Dir(fileName)
+
+		pc, ok := packages[pkgPath]
+		if !ok {
+			pc = &PackageCoverage{Package: pkgPath}
+			packages[pkgPath] = pc
+			pkgOrder = append(pkgOrder, pkgPath)
+		}
+		pc.Files = append(pc.Files, *fc)
+		pc.Statements += fc.Statements
+		pc.Covered += fc.Covered
+	}
+
+	sort.Strings(pkgOrder)
+	result := make([]PackageCoverage, 0, len(pkgOrder))
+	for _, pkgPath := range pkgOrder {
+		result = append(result, *packages[pkgPath])
+	}
+	return result, nil
+}
+
+// parseCoverageLine parses one profile data line:
+// "file.go:12.3,15.4 2 1" -> ("file.go", numStmt=2, count=1).
+func parseCoverageLine(line string) (fileName string, numStmt, count int, err error) {
+	fileAndRange, rest, ok := strings.Cut(line, " ")
+	if !ok {
+		return "", 0, 0, fmt.Errorf("malformed coverage line: %q", line)
+	}
+	colon := strings.LastIndex(fileAndRange, ":")
+	if colon < 0 {
+		return "", 0, 0, fmt.Errorf("malformed coverage line: %q", line)
+	}
+	fileName = fileAndRange[:colon]
+
+	fields := strings.Fields(rest)
+	if len(fields) != 2 {
+		return "", 0, 0, fmt.Errorf("malformed coverage line: %q", line)
+	}
+	numStmt, err = strconv.Atoi(fields[0])
+	if err != nil {
+		return "", 0, 0, fmt.Errorf("malformed coverage line: %q", line)
+	}
+	count, err = strconv.Atoi(fields[1])
+	if err != nil {
+		return "", 0, 0, fmt.Errorf("malformed coverage line: %q", line)
+	}
+	return fileName, numStmt, count, nil
+}
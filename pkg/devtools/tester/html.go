@@ -0,0 +1,64 @@
+package tester
+
+import (
+	"fmt"
+	"html"
+	"os"
+	"sort"
+	"strings"
+)
+
+// WriteHTMLReport renders packages as a coverage summary page -
+// overall and per-package percentages, with each package's files and
+// their own percentages listed underneath - to outputPath.
+func WriteHTMLReport(packages []PackageCoverage, outputPath string) error {
+	var b strings.Builder
+
+	b.WriteString("<!DOCTYPE html>\n<html><head><meta charset=\"utf-8\"><title>Coverage Report</title>\n")
+	b.WriteString("<style>\n")
+	b.WriteString("body{font-family:sans-serif;margin:2em;}\n")
+	b.WriteString("table{border-collapse:collapse;width:100%;}\n")
+	b.WriteString("td,th{border:1px solid #ddd;padding:6px 10px;text-align:left;}\n")
+	b.WriteString(".high{color:#0a7c2f;}.medium{color:#b8860b;}.low{color:#b00020;}\n")
+	b.WriteString("</style></head><body>\n")
+
+	totalStmts, totalCovered := 0, 0
+	for _, pkg := range packages {
+		totalStmts += pkg.Statements
+		totalCovered += pkg.Covered
+	}
+	overall := 0.0
+	if totalStmts > 0 {
+		overall = float64(totalCovered) / float64(totalStmts) * 100
+	}
+	fmt.Fprintf(&b, "<h1>Coverage Report</h1>\n<p>Overall: <span class=%q>%.1f%%</span></p>\n",
+		coverageClass(overall), overall)
+
+	sorted := append([]PackageCoverage(nil), packages...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Package < sorted[j].Package })
+
+	for _, pkg := range sorted {
+		fmt.Fprintf(&b, "<h2>%s <span class=%q>(%.1f%%)</span></h2>\n",
+			html.EscapeString(pkg.Package), coverageClass(pkg.Percent()), pkg.Percent())
+		b.WriteString("<table>\n<tr><th>File</th><th>Statements</th><th>Covered</th><th>Coverage</th></tr>\n")
+		for _, f := range pkg.Files {
+			fmt.Fprintf(&b, "<tr><td>%s</td><td>%d</td><td>%d</td><td class=%q>%.1f%%</td></tr>\n",
+				html.EscapeString(f.FileName), f.Statements, f.Covered, coverageClass(f.Percent()), f.Percent())
+		}
+		b.WriteString("</table>\n")
+	}
+
+	b.WriteString("</body></html>\n")
+	return os.WriteFile(outputPath, []byte(b.String()), 0644)
+}
+
+func coverageClass(percent float64) string {
+	switch {
+	case percent >= 80:
+		return "high"
+	case percent >= 50:
+		return "medium"
+	default:
+		return "low"
+	}
+}
@@ -0,0 +1,165 @@
+// Package tester runs `go test` and turns its output into data
+// structures a caller can act on: per-test pass/fail/skip results
+// parsed from `go test -json` (instead of a single blob of combined
+// output), coverage profiles parsed into per-package statistics, and
+// an HTML coverage report rendered from those statistics.
+package tester
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os/exec"
+)
+
+// TestCase is one test function's outcome.
+type TestCase struct {
+	Package string
+	Name    string
+	Result  string // "pass", "fail", or "skip"
+	Elapsed float64
+	Output  []string
+}
+
+// Report is every test case from one Run, plus pass/fail/skip totals.
+type Report struct {
+	Cases   []*TestCase
+	Passed  int
+	Failed  int
+	Skipped int
+}
+
+// FailedCases returns the subset of Cases whose Result is "fail".
+func (r *Report) FailedCases() []*TestCase {
+	var failed []*TestCase
+	for _, c := range r.Cases {
+		if c.Result == "fail" {
+			failed = append(failed, c)
+		}
+	}
+	return failed
+}
+
+// Tester runs `go test` for a set of packages.
+type Tester struct {
+	Dir          string   // working directory to run `go test` in; "" means the current directory
+	Packages     []string // package patterns, e.g. "./..."; defaults to ["./..."]
+	CoverProfile string   // when set, passed to `go test` as -coverprofile
+}
+
+// NewTester creates a Tester that runs `go test ./...` in the current
+// directory with no coverage profile.
+func NewTester() *Tester {
+	return &Tester{Packages: []string{"./..."}}
+}
+
+// Run executes `go test -json` (plus -coverprofile, if CoverProfile is
+// set) over t.Packages and parses its streamed JSON events into a
+// Report. A non-nil error means the test binary itself could not be
+// run (bad arguments, compile failure before any test ran) - a Report
+// with FailedCases is how individual test failures are reported.
+func (t *Tester) Run() (*Report, error) {
+	packages := t.Packages
+	if len(packages) == 0 {
+		packages = []string{"./..."}
+	}
+
+	args := []string{"test", "-json"}
+	if t.CoverProfile != "" {
+		args = append(args, "-coverprofile="+t.CoverProfile)
+	}
+	args = append(args, packages...)
+
+	cmd := exec.Command("go", args...)
+	cmd.Dir = t.Dir
+
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+
+	report, parseErr := parseTestJSON(stdout)
+	runErr := cmd.Wait()
+
+	if parseErr != nil {
+		return nil, parseErr
+	}
+	// go test exits non-zero when any test fails - that's reflected in
+	// report.Failed, not treated as a Run error.
+	if runErr != nil && len(report.Cases) == 0 {
+		return nil, fmt.Errorf("go test: %w", runErr)
+	}
+	return report, nil
+}
+
+// testEvent mirrors one line of `go test -json` output.
+type testEvent struct {
+	Action  string  `json:"Action"`
+	Package string  `json:"Package"`
+	Test    string  `json:"Test"`
+	Elapsed float64 `json:"Elapsed"`
+	Output  string  `json:"Output"`
+}
+
+// parseTestJSON decodes a stream of newline-delimited testEvent
+// objects (as produced by `go test -json`) into a Report, one
+// TestCase per distinct (Package, Test) pair.
+func parseTestJSON(r io.Reader) (*Report, error) {
+	report := &Report{}
+	cases := map[string]*TestCase{}
+	var order []string
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+
+		var event testEvent
+		if err := json.Unmarshal(line, &event); err != nil {
+			continue // non-JSON noise on stdout; ignore rather than fail the whole run
+		}
+		if event.Test == "" {
+			continue // package-level event, not an individual test case
+		}
+
+		key := event.Package + "/" + event.Test
+		tc, ok := cases[key]
+		if !ok {
+			tc = &TestCase{Package: event.Package, Name: event.Test}
+			cases[key] = tc
+			order = append(order, key)
+		}
+
+		switch event.Action {
+		case "output":
+			tc.Output = append(tc.Output, event.Output)
+		case "pass", "fail", "skip":
+			tc.Result = event.Action
+			tc.Elapsed = event.Elapsed
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, key := range order {
+		tc := cases[key]
+		report.Cases = append(report.Cases, tc)
+		switch tc.Result {
+		case "pass":
+			report.Passed++
+		case "fail":
+			report.Failed++
+		case "skip":
+			report.Skipped++
+		}
+	}
+	return report, nil
+}
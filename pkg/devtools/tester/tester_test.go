@@ -0,0 +1,141 @@
+package tester
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseTestJSONAggregatesPerTestCaseResults(t *testing.T) {
+	const stream = `
+{"Action":"run","Package":"example.com/m/pkg","Test":"TestOK"}
+{"Action":"output","Package":"example.com/m/pkg","Test":"TestOK","Output":"=== RUN   TestOK\n"}
+{"Action":"pass","Package":"example.com/m/pkg","Test":"TestOK","Elapsed":0.01}
+{"Action":"run","Package":"example.com/m/pkg","Test":"TestBad"}
+{"Action":"output","Package":"example.com/m/pkg","Test":"TestBad","Output":"bad_test.go:10: expected 1, got 2\n"}
+{"Action":"fail","Package":"example.com/m/pkg","Test":"TestBad","Elapsed":0.02}
+{"Action":"run","Package":"example.com/m/pkg","Test":"TestSkipped"}
+{"Action":"skip","Package":"example.com/m/pkg","Test":"TestSkipped","Elapsed":0}
+{"Action":"fail","Package":"example.com/m/pkg"}
+`
+	report, err := parseTestJSON(strings.NewReader(stream))
+	if err != nil {
+		t.Fatalf("parseTestJSON returned error: %v", err)
+	}
+
+	if report.Passed != 1 || report.Failed != 1 || report.Skipped != 1 {
+		t.Fatalf("unexpected totals: %+v", report)
+	}
+	if len(report.Cases) != 3 {
+		t.Fatalf("expected 3 test cases, got %d", len(report.Cases))
+	}
+
+	failed := report.FailedCases()
+	if len(failed) != 1 || failed[0].Name != "TestBad" {
+		t.Fatalf("unexpected failed cases: %+v", failed)
+	}
+	if !strings.Contains(failed[0].Output[0], "expected 1, got 2") {
+		t.Fatalf("expected captured output on the failed case, got %+v", failed[0].Output)
+	}
+}
+
+func TestParseCoverageProfileAggregatesByPackage(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "coverage.out")
+	profile := `mode: set
+example.com/m/pkg/foo.go:3.1,5.2 2 1
+example.com/m/pkg/foo.go:7.1,9.2 1 0
+example.com/m/pkg/bar.go:1.1,2.2 3 1
+example.com/m/other/baz.go:1.1,2.2 4 0
+`
+	if err := os.WriteFile(path, []byte(profile), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	packages, err := ParseCoverageProfile(path)
+	if err != nil {
+		t.Fatalf("ParseCoverageProfile returned error: %v", err)
+	}
+	if len(packages) != 2 {
+		t.Fatalf("expected 2 packages, got %d: %+v", len(packages), packages)
+	}
+
+	pkg := packages[0]
+	if pkg.Package != "example.com/m/other" {
+		t.Fatalf("expected packages sorted with example.com/m/other first, got %s", pkg.Package)
+	}
+	if pkg.Statements != 4 || pkg.Covered != 0 {
+		t.Fatalf("unexpected other package totals: %+v", pkg)
+	}
+
+	pkgFoo := packages[1]
+	if pkgFoo.Statements != 6 || pkgFoo.Covered != 5 {
+		t.Fatalf("unexpected pkg totals: %+v", pkgFoo)
+	}
+	if got := pkgFoo.Percent(); got < 83.3 || got > 83.4 {
+		t.Fatalf("expected ~83.3%% coverage, got %f", got)
+	}
+}
+
+func TestWriteHTMLReportProducesValidFile(t *testing.T) {
+	dir := t.TempDir()
+	outPath := filepath.Join(dir, "coverage.html")
+
+	packages := []PackageCoverage{
+		{Package: "example.com/m/pkg", Statements: 10, Covered: 9, Files: []FileCoverage{
+			{FileName: "example.com/m/pkg/foo.go", Statements: 10, Covered: 9},
+		}},
+	}
+
+	if err := WriteHTMLReport(packages, outPath); err != nil {
+		t.Fatalf("WriteHTMLReport returned error: %v", err)
+	}
+
+	data, err := os.ReadFile(outPath)
+	if err != nil {
+		t.Fatalf("reading report: %v", err)
+	}
+	content := string(data)
+	if !strings.Contains(content, "90.0%") {
+		t.Fatalf("expected overall percentage in report, got: %s", content)
+	}
+	if !strings.Contains(content, "example.com/m/pkg/foo.go") {
+		t.Fatalf("expected file name in report, got: %s", content)
+	}
+}
+
+func TestTesterRunAgainstRealPackage(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping real go test invocation in short mode")
+	}
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module example.com/fixture\n\ngo 1.21\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	src := `package fixture
+
+import "testing"
+
+func TestPasses(t *testing.T) {}
+
+func TestFails(t *testing.T) {
+	t.Fatal("boom")
+}
+`
+	if err := os.WriteFile(filepath.Join(dir, "fixture_test.go"), []byte(src), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tester := NewTester()
+	tester.Dir = dir
+	report, err := tester.Run()
+	if err != nil {
+		t.Fatalf("Run returned error: %v", err)
+	}
+
+	if report.Passed != 1 || report.Failed != 1 {
+		t.Fatalf("unexpected totals from real go test run: %+v", report)
+	}
+}
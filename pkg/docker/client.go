@@ -0,0 +1,379 @@
+// Package docker talks to the Docker Engine API directly over its
+// Unix socket, the same way pkg/mongo speaks MongoDB's wire protocol
+// directly over net.Conn rather than shelling out to the mongo CLI.
+package docker
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultSocket is the Docker Engine API's default Unix socket path.
+const DefaultSocket = "/var/run/docker.sock"
+
+// apiVersion is the Docker Engine API version this client speaks.
+const apiVersion = "v1.43"
+
+// Client is a connection to a single Docker Engine API endpoint.
+type Client struct {
+	httpClient *http.Client
+	baseURL    string
+}
+
+// NewClient builds a Client against addr - a Unix socket path, or an
+// http(s):// URL for a TCP-exposed daemon. An empty addr uses
+// DOCKER_HOST if set, falling back to DefaultSocket.
+func NewClient(addr string) (*Client, error) {
+	if addr == "" {
+		addr = os.Getenv("DOCKER_HOST")
+	}
+	if addr == "" {
+		addr = DefaultSocket
+	}
+
+	if strings.HasPrefix(addr, "http://") || strings.HasPrefix(addr, "https://") {
+		return &Client{
+			httpClient: &http.Client{Timeout: 5 * time.Minute},
+			baseURL:    strings.TrimSuffix(addr, "/"),
+		}, nil
+	}
+
+	socket := strings.TrimPrefix(addr, "unix://")
+	transport := &http.Transport{
+		DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			return net.Dial("unix", socket)
+		},
+	}
+	return &Client{
+		httpClient: &http.Client{Transport: transport, Timeout: 5 * time.Minute},
+		baseURL:    "http://docker",
+	}, nil
+}
+
+// do sends an HTTP request against path (joined under /<apiVersion>)
+// and returns the response, with a *APIError in place of a bare
+// non-2xx status so callers can surface the daemon's own message.
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, contentType string, body io.Reader) (*http.Response, error) {
+	u := fmt.Sprintf("%s/%s%s", c.baseURL, apiVersion, path)
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, u, body)
+	if err != nil {
+		return nil, fmt.Errorf("building docker request: %w", err)
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("calling docker engine API: %w", err)
+	}
+
+	if resp.StatusCode >= 300 {
+		defer resp.Body.Close()
+		msg, _ := io.ReadAll(resp.Body)
+		return nil, &APIError{StatusCode: resp.StatusCode, Message: strings.TrimSpace(decodeErrorMessage(msg))}
+	}
+
+	return resp, nil
+}
+
+// APIError is returned when the Docker Engine API responds with a
+// non-2xx status. Message is the daemon's own error text when it
+// returned one.
+type APIError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("docker engine API: %d %s", e.StatusCode, e.Message)
+}
+
+// decodeErrorMessage extracts the "message" field the daemon wraps
+// its errors in, falling back to the raw body when it isn't JSON.
+func decodeErrorMessage(body []byte) string {
+	var wrapped struct {
+		Message string `json:"message"`
+	}
+	if err := json.Unmarshal(body, &wrapped); err == nil && wrapped.Message != "" {
+		return wrapped.Message
+	}
+	return string(body)
+}
+
+// BuildResult is the outcome of a Build call.
+type BuildResult struct {
+	ImageID string `json:"imageId"`
+	Log     string `json:"log"`
+}
+
+// Build builds the tar archive read from context into an image
+// tagged tag, using dockerfile (relative to the build context root;
+// "" means the daemon's default of "Dockerfile").
+func (c *Client) Build(ctx context.Context, buildContext io.Reader, dockerfile, tag string) (*BuildResult, error) {
+	query := url.Values{}
+	query.Set("t", tag)
+	if dockerfile != "" {
+		query.Set("dockerfile", dockerfile)
+	}
+
+	resp, err := c.do(ctx, http.MethodPost, "/build", query, "application/x-tar", buildContext)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var log strings.Builder
+	var imageID string
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var msg struct {
+			Stream string `json:"stream"`
+			Error  string `json:"error"`
+			Aux    struct {
+				ID string `json:"ID"`
+			} `json:"aux"`
+		}
+		if err := json.Unmarshal(scanner.Bytes(), &msg); err != nil {
+			continue
+		}
+		if msg.Error != "" {
+			return nil, fmt.Errorf("docker build failed: %s", msg.Error)
+		}
+		log.WriteString(msg.Stream)
+		if msg.Aux.ID != "" {
+			imageID = msg.Aux.ID
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading docker build response: %w", err)
+	}
+
+	return &BuildResult{ImageID: imageID, Log: log.String()}, nil
+}
+
+// RunResult is the outcome of a Run call.
+type RunResult struct {
+	ContainerID string `json:"containerId"`
+	ExitCode    int    `json:"exitCode"`
+	Logs        string `json:"logs"`
+}
+
+// Run creates a container from image, starts it, waits for it to
+// exit, and returns its logs and exit code - equivalent to
+// `docker run` without -d. The container is not removed afterwards.
+func (c *Client) Run(ctx context.Context, image string, cmd []string, env []string) (*RunResult, error) {
+	createBody, err := json.Marshal(map[string]interface{}{
+		"Image": image,
+		"Cmd":   cmd,
+		"Env":   env,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("encoding container config: %w", err)
+	}
+
+	resp, err := c.do(ctx, http.MethodPost, "/containers/create", nil, "application/json", bytes.NewReader(createBody))
+	if err != nil {
+		return nil, fmt.Errorf("creating container: %w", err)
+	}
+	var created struct {
+		ID string `json:"Id"`
+	}
+	decodeErr := json.NewDecoder(resp.Body).Decode(&created)
+	resp.Body.Close()
+	if decodeErr != nil {
+		return nil, fmt.Errorf("decoding create response: %w", decodeErr)
+	}
+
+	startResp, err := c.do(ctx, http.MethodPost, "/containers/"+created.ID+"/start", nil, "", nil)
+	if err != nil {
+		return nil, fmt.Errorf("starting container %s: %w", created.ID, err)
+	}
+	startResp.Body.Close()
+
+	waitResp, err := c.do(ctx, http.MethodPost, "/containers/"+created.ID+"/wait", nil, "", nil)
+	if err != nil {
+		return nil, fmt.Errorf("waiting for container %s: %w", created.ID, err)
+	}
+	var waited struct {
+		StatusCode int `json:"StatusCode"`
+	}
+	decodeErr = json.NewDecoder(waitResp.Body).Decode(&waited)
+	waitResp.Body.Close()
+	if decodeErr != nil {
+		return nil, fmt.Errorf("decoding wait response: %w", decodeErr)
+	}
+
+	logs, err := c.Logs(ctx, created.ID)
+	if err != nil {
+		return nil, fmt.Errorf("fetching logs for container %s: %w", created.ID, err)
+	}
+
+	return &RunResult{ContainerID: created.ID, ExitCode: waited.StatusCode, Logs: logs}, nil
+}
+
+// Logs returns a container's combined stdout/stderr, with the eight
+// byte multiplexing header Docker's non-TTY logs stream uses stripped
+// from each frame.
+func (c *Client) Logs(ctx context.Context, containerID string) (string, error) {
+	query := url.Values{}
+	query.Set("stdout", "1")
+	query.Set("stderr", "1")
+
+	resp, err := c.do(ctx, http.MethodGet, "/containers/"+containerID+"/logs", query, "", nil)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	raw, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("reading logs: %w", err)
+	}
+	return demuxLogs(raw), nil
+}
+
+// demuxLogs strips Docker's 8-byte stream-multiplexing header from
+// each frame of a non-TTY container's log stream, concatenating the
+// remaining payloads in order.
+func demuxLogs(raw []byte) string {
+	var out strings.Builder
+	for len(raw) >= 8 {
+		size := int(raw[4])<<24 | int(raw[5])<<16 | int(raw[6])<<8 | int(raw[7])
+		raw = raw[8:]
+		if size > len(raw) {
+			size = len(raw)
+		}
+		out.Write(raw[:size])
+		raw = raw[size:]
+	}
+	return out.String()
+}
+
+// RegistryAuth carries credentials for Push, sent to the daemon as
+// the base64-encoded X-Registry-Auth header Docker's registry push
+// API expects.
+type RegistryAuth struct {
+	Username      string `json:"username"`
+	Password      string `json:"password"`
+	ServerAddress string `json:"serveraddress,omitempty"`
+}
+
+// Push pushes image (e.g. "registry.example.com/app:latest") to its
+// registry, authenticating with auth if it's non-nil.
+func (c *Client) Push(ctx context.Context, image string, auth *RegistryAuth) error {
+	query := url.Values{}
+	query.Set("tag", "")
+
+	name := image
+	if idx := strings.LastIndex(image, ":"); idx > strings.LastIndex(image, "/") {
+		name = image[:idx]
+		query.Set("tag", image[idx+1:])
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost,
+		fmt.Sprintf("%s/%s/images/%s/push?%s", c.baseURL, apiVersion, name, query.Encode()), nil)
+	if err != nil {
+		return fmt.Errorf("building push request: %w", err)
+	}
+	if auth != nil {
+		authJSON, err := json.Marshal(auth)
+		if err != nil {
+			return fmt.Errorf("encoding registry auth: %w", err)
+		}
+		req.Header.Set("X-Registry-Auth", base64.StdEncoding.EncodeToString(authJSON))
+	} else {
+		req.Header.Set("X-Registry-Auth", base64.StdEncoding.EncodeToString([]byte("{}")))
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("calling docker engine API: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(resp.Body)
+		return &APIError{StatusCode: resp.StatusCode, Message: strings.TrimSpace(decodeErrorMessage(msg))}
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("reading push response: %w", err)
+	}
+	return checkPushStream(body)
+}
+
+// checkPushStream scans a push response's streamed JSON lines for an
+// "error" message, since the daemon reports push failures (e.g. "no
+// basic auth credentials") as a 200 OK with an error line mid-stream
+// rather than a non-2xx status.
+func checkPushStream(body []byte) error {
+	scanner := bufio.NewScanner(bytes.NewReader(body))
+	for scanner.Scan() {
+		var msg struct {
+			Error string `json:"error"`
+		}
+		if err := json.Unmarshal(scanner.Bytes(), &msg); err == nil && msg.Error != "" {
+			return fmt.Errorf("docker push failed: %s", msg.Error)
+		}
+	}
+	return nil
+}
+
+// ContainerSummary mirrors the subset of `GET /containers/json`'s
+// response this package surfaces.
+type ContainerSummary struct {
+	ID     string   `json:"id"`
+	Names  []string `json:"names"`
+	Image  string   `json:"image"`
+	State  string   `json:"state"`
+	Status string   `json:"status"`
+}
+
+// List returns containers known to the daemon - equivalent to
+// `docker ps` (all == false) or `docker ps -a` (all == true).
+func (c *Client) List(ctx context.Context, all bool) ([]ContainerSummary, error) {
+	query := url.Values{}
+	query.Set("all", strconv.FormatBool(all))
+
+	resp, err := c.do(ctx, http.MethodGet, "/containers/json", query, "", nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var raw []struct {
+		ID     string   `json:"Id"`
+		Names  []string `json:"Names"`
+		Image  string   `json:"Image"`
+		State  string   `json:"State"`
+		Status string   `json:"Status"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&raw); err != nil {
+		return nil, fmt.Errorf("decoding container list: %w", err)
+	}
+
+	containers := make([]ContainerSummary, len(raw))
+	for i, r := range raw {
+		containers[i] = ContainerSummary{ID: r.ID, Names: r.Names, Image: r.Image, State: r.State, Status: r.Status}
+	}
+	return containers, nil
+}
@@ -0,0 +1,154 @@
+package docker
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestBuildReturnsImageIDFromAuxMessage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "/build") {
+			t.Fatalf("unexpected path %s", r.URL.Path)
+		}
+		if r.URL.Query().Get("t") != "myimage:latest" {
+			t.Fatalf("expected tag myimage:latest, got %q", r.URL.Query().Get("t"))
+		}
+		w.Write([]byte(`{"stream":"Step 1/1 : FROM scratch\n"}` + "\n"))
+		w.Write([]byte(`{"aux":{"ID":"sha256:abc123"}}` + "\n"))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL)
+	if err != nil {
+		t.Fatalf("NewClient returned error: %v", err)
+	}
+
+	result, err := client.Build(context.Background(), strings.NewReader("fake-tar-bytes"), "", "myimage:latest")
+	if err != nil {
+		t.Fatalf("Build returned error: %v", err)
+	}
+	if result.ImageID != "sha256:abc123" {
+		t.Errorf("expected image ID sha256:abc123, got %q", result.ImageID)
+	}
+	if !strings.Contains(result.Log, "Step 1/1") {
+		t.Errorf("expected build log to contain step output, got %q", result.Log)
+	}
+}
+
+func TestBuildReturnsErrorOnStreamedFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"error":"failed to fetch base image"}` + "\n"))
+	}))
+	defer server.Close()
+
+	client, _ := NewClient(server.URL)
+	_, err := client.Build(context.Background(), strings.NewReader("fake-tar-bytes"), "", "myimage:latest")
+	if err == nil || !strings.Contains(err.Error(), "failed to fetch base image") {
+		t.Fatalf("expected build failure error, got %v", err)
+	}
+}
+
+func TestRunCreatesStartsWaitsAndFetchesLogs(t *testing.T) {
+	var created, started, waited, logged bool
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/containers/create"):
+			created = true
+			json.NewEncoder(w).Encode(map[string]string{"Id": "container123"})
+		case strings.HasSuffix(r.URL.Path, "/containers/container123/start"):
+			started = true
+			w.WriteHeader(http.StatusNoContent)
+		case strings.HasSuffix(r.URL.Path, "/containers/container123/wait"):
+			waited = true
+			json.NewEncoder(w).Encode(map[string]int{"StatusCode": 0})
+		case strings.HasSuffix(r.URL.Path, "/containers/container123/logs"):
+			logged = true
+			frame := []byte{1, 0, 0, 0, 0, 0, 0, 5}
+			w.Write(append(frame, []byte("hello")...))
+		default:
+			t.Fatalf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client, _ := NewClient(server.URL)
+	result, err := client.Run(context.Background(), "alpine", []string{"echo", "hello"}, nil)
+	if err != nil {
+		t.Fatalf("Run returned error: %v", err)
+	}
+	if !created || !started || !waited || !logged {
+		t.Fatalf("expected create/start/wait/logs all called, got %v %v %v %v", created, started, waited, logged)
+	}
+	if result.ContainerID != "container123" {
+		t.Errorf("expected container ID container123, got %q", result.ContainerID)
+	}
+	if result.ExitCode != 0 {
+		t.Errorf("expected exit code 0, got %d", result.ExitCode)
+	}
+	if result.Logs != "hello" {
+		t.Errorf("expected demuxed logs %q, got %q", "hello", result.Logs)
+	}
+}
+
+func TestPushReturnsErrorFromStreamedMessage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Registry-Auth") == "" {
+			t.Fatalf("expected X-Registry-Auth header to be set")
+		}
+		w.Write([]byte(`{"error":"no basic auth credentials"}` + "\n"))
+	}))
+	defer server.Close()
+
+	client, _ := NewClient(server.URL)
+	err := client.Push(context.Background(), "example.com/app:latest", &RegistryAuth{Username: "u", Password: "p"})
+	if err == nil || !strings.Contains(err.Error(), "no basic auth credentials") {
+		t.Fatalf("expected push failure error, got %v", err)
+	}
+}
+
+func TestListDecodesContainerSummaries(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("all") != "true" {
+			t.Fatalf("expected all=true, got %q", r.URL.Query().Get("all"))
+		}
+		json.NewEncoder(w).Encode([]map[string]interface{}{
+			{"Id": "abc", "Names": []string{"/myapp"}, "Image": "alpine", "State": "running", "Status": "Up 2 minutes"},
+		})
+	}))
+	defer server.Close()
+
+	client, _ := NewClient(server.URL)
+	containers, err := client.List(context.Background(), true)
+	if err != nil {
+		t.Fatalf("List returned error: %v", err)
+	}
+	if len(containers) != 1 || containers[0].ID != "abc" || containers[0].State != "running" {
+		t.Fatalf("unexpected containers: %+v", containers)
+	}
+}
+
+func TestAPIErrorSurfacesDaemonMessage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(map[string]string{"message": "No such container: ghost"})
+	}))
+	defer server.Close()
+
+	client, _ := NewClient(server.URL)
+	_, err := client.List(context.Background(), false)
+	if err == nil {
+		t.Fatalf("expected an error")
+	}
+	apiErr, ok := err.(*APIError)
+	if !ok {
+		t.Fatalf("expected *APIError, got %T", err)
+	}
+	if apiErr.StatusCode != http.StatusNotFound || !strings.Contains(apiErr.Message, "No such container") {
+		t.Fatalf("unexpected APIError: %+v", apiErr)
+	}
+}
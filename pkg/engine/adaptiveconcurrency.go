@@ -0,0 +1,124 @@
+package engine
+
+import (
+	"sync"
+	"time"
+)
+
+// AdaptiveConcurrencyController tunes a worker pool's size with an
+// AIMD (additive-increase/multiplicative-decrease) feedback loop over
+// observed command latency and failure rate, instead of a fixed worker
+// count that has to be hand-tuned per script: it grows the limit one
+// worker at a time while commands stay fast and successful, and cuts
+// it in half as soon as latency or failures spike.
+type AdaptiveConcurrencyController struct {
+	mu sync.Mutex
+
+	min, max      int
+	current       int
+	targetLatency time.Duration
+	windowSize    int
+	onResize      func(newSize int)
+
+	windowDurations []time.Duration
+	windowFailures  int
+}
+
+// NewAdaptiveConcurrencyController creates a controller starting at
+// initial workers, clamped to [min, max]. It re-evaluates the limit
+// every windowSize observations. onResize, if non-nil, is called
+// (synchronously, inside Observe) whenever the limit changes, so a
+// CommandWorkerPool or any other parallel-execution scheduler can
+// react immediately.
+func NewAdaptiveConcurrencyController(min, max, initial int, targetLatency time.Duration, windowSize int, onResize func(newSize int)) *AdaptiveConcurrencyController {
+	if min < 1 {
+		min = 1
+	}
+	if max < min {
+		max = min
+	}
+	if initial < min {
+		initial = min
+	}
+	if initial > max {
+		initial = max
+	}
+	if windowSize < 1 {
+		windowSize = 1
+	}
+
+	return &AdaptiveConcurrencyController{
+		min:           min,
+		max:           max,
+		current:       initial,
+		targetLatency: targetLatency,
+		windowSize:    windowSize,
+		onResize:      onResize,
+	}
+}
+
+// Observe records one completed unit of work's latency and outcome.
+// Every windowSize observations it re-evaluates the limit: a failure
+// anywhere in the window, or a mean latency above targetLatency,
+// halves the limit (multiplicative decrease); an all-successful,
+// under-target window grows it by one (additive increase).
+func (c *AdaptiveConcurrencyController) Observe(latency time.Duration, success bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.windowDurations = append(c.windowDurations, latency)
+	if !success {
+		c.windowFailures++
+	}
+
+	if len(c.windowDurations) < c.windowSize {
+		return
+	}
+
+	mean := meanDuration(c.windowDurations)
+	regressed := c.windowFailures > 0 || mean > c.targetLatency
+
+	next := c.current
+	if regressed {
+		next = c.current / 2
+	} else {
+		next = c.current + 1
+	}
+	next = clamp(next, c.min, c.max)
+
+	c.windowDurations = c.windowDurations[:0]
+	c.windowFailures = 0
+
+	if next == c.current {
+		return
+	}
+	c.current = next
+	if c.onResize != nil {
+		c.onResize(next)
+	}
+}
+
+// Limit returns the controller's current concurrency limit.
+func (c *AdaptiveConcurrencyController) Limit() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.current
+}
+
+func meanDuration(durations []time.Duration) time.Duration {
+	var total time.Duration
+	for _, d := range durations {
+		total += d
+	}
+	return total / time.Duration(len(durations))
+}
+
+func clamp(n, min, max int) int {
+	if n < min {
+		return min
+	}
+	if n > max {
+		return max
+	}
+	return n
+}
@@ -0,0 +1,82 @@
+package engine
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAdaptiveConcurrencyControllerGrowsOnFastSuccess(t *testing.T) {
+	var resizes []int
+	c := NewAdaptiveConcurrencyController(1, 8, 2, 10*time.Millisecond, 3, func(n int) {
+		resizes = append(resizes, n)
+	})
+
+	for i := 0; i < 3; i++ {
+		c.Observe(1*time.Millisecond, true)
+	}
+
+	if c.Limit() != 3 {
+		t.Errorf("Limit() = %d, want 3", c.Limit())
+	}
+	if len(resizes) != 1 || resizes[0] != 3 {
+		t.Errorf("resizes = %v, want [3]", resizes)
+	}
+}
+
+func TestAdaptiveConcurrencyControllerHalvesOnFailure(t *testing.T) {
+	var resizes []int
+	c := NewAdaptiveConcurrencyController(1, 8, 6, 10*time.Millisecond, 2, func(n int) {
+		resizes = append(resizes, n)
+	})
+
+	c.Observe(1*time.Millisecond, true)
+	c.Observe(1*time.Millisecond, false)
+
+	if c.Limit() != 3 {
+		t.Errorf("Limit() = %d, want 3", c.Limit())
+	}
+	if len(resizes) != 1 || resizes[0] != 3 {
+		t.Errorf("resizes = %v, want [3]", resizes)
+	}
+}
+
+func TestAdaptiveConcurrencyControllerHalvesOnHighLatency(t *testing.T) {
+	c := NewAdaptiveConcurrencyController(1, 8, 6, 10*time.Millisecond, 2, nil)
+
+	c.Observe(50*time.Millisecond, true)
+	c.Observe(50*time.Millisecond, true)
+
+	if c.Limit() != 3 {
+		t.Errorf("Limit() = %d, want 3", c.Limit())
+	}
+}
+
+func TestAdaptiveConcurrencyControllerClampsToBounds(t *testing.T) {
+	c := NewAdaptiveConcurrencyController(2, 4, 4, 10*time.Millisecond, 1, nil)
+
+	for i := 0; i < 5; i++ {
+		c.Observe(1*time.Millisecond, true)
+	}
+	if c.Limit() != 4 {
+		t.Errorf("Limit() = %d, want 4 (clamped to max)", c.Limit())
+	}
+
+	for i := 0; i < 5; i++ {
+		c.Observe(1*time.Millisecond, false)
+	}
+	if c.Limit() != 2 {
+		t.Errorf("Limit() = %d, want 2 (clamped to min)", c.Limit())
+	}
+}
+
+func TestAdaptiveConcurrencyControllerConstructorClampsInitial(t *testing.T) {
+	c := NewAdaptiveConcurrencyController(4, 8, 1, time.Second, 1, nil)
+	if c.Limit() != 4 {
+		t.Errorf("Limit() = %d, want 4 (initial clamped up to min)", c.Limit())
+	}
+
+	c2 := NewAdaptiveConcurrencyController(1, 4, 100, time.Second, 1, nil)
+	if c2.Limit() != 4 {
+		t.Errorf("Limit() = %d, want 4 (initial clamped down to max)", c2.Limit())
+	}
+}
@@ -0,0 +1,35 @@
+package engine
+
+import "sync"
+
+// argvPool reuses the []string backing arrays used to build a spawned
+// process's environment slice, so repeated command executions don't
+// pay for a fresh allocation every time - profiling showed allocation
+// churn around process spawn was measurable when scripts run many
+// short-lived external commands.
+type argvPool struct {
+	pool sync.Pool
+}
+
+// newArgvPool creates an empty argvPool.
+func newArgvPool() *argvPool {
+	return &argvPool{
+		pool: sync.Pool{
+			New: func() interface{} {
+				return make([]string, 0, 16)
+			},
+		},
+	}
+}
+
+// Get returns a zero-length []string with spare capacity, ready to be
+// appended to.
+func (p *argvPool) Get() []string {
+	return p.pool.Get().([]string)[:0]
+}
+
+// Put returns s to the pool for reuse. Callers must not use s again
+// after calling Put.
+func (p *argvPool) Put(s []string) {
+	p.pool.Put(s) //nolint:staticcheck // intentionally pooling a slice header
+}
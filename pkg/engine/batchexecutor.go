@@ -0,0 +1,121 @@
+package engine
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"gitee.com/com_818cloud/shode/pkg/types"
+)
+
+// BatchExecutor is a reusable command executor for scripts that run
+// many short-lived external commands: it dispatches work to a
+// CommandWorkerPool of pre-forked helpers, and - for runs of commands
+// that are trivially combinable - joins them into a single shell
+// invocation so the whole run costs one dispatch instead of many.
+type BatchExecutor struct {
+	pool       *CommandWorkerPool
+	controller *AdaptiveConcurrencyController
+}
+
+// NewBatchExecutor creates a BatchExecutor backed by a fixed pool of
+// size pre-forked workers.
+func NewBatchExecutor(size int) (*BatchExecutor, error) {
+	pool, err := NewCommandWorkerPool(size)
+	if err != nil {
+		return nil, err
+	}
+	return &BatchExecutor{pool: pool}, nil
+}
+
+// NewAdaptiveBatchExecutor creates a BatchExecutor whose worker pool
+// size is tuned at runtime by an AdaptiveConcurrencyController,
+// instead of staying fixed at whatever size it was created with: it
+// starts at initial workers and is resized within [min, max] based on
+// the latency and success rate RunBatch observes.
+func NewAdaptiveBatchExecutor(minWorkers, maxWorkers, initial int, targetLatency time.Duration) (*BatchExecutor, error) {
+	pool, err := NewCommandWorkerPool(initial)
+	if err != nil {
+		return nil, err
+	}
+
+	b := &BatchExecutor{pool: pool}
+	b.controller = NewAdaptiveConcurrencyController(minWorkers, maxWorkers, initial, targetLatency, 5, func(newSize int) {
+		_ = pool.Resize(newSize)
+	})
+	return b, nil
+}
+
+// ConcurrencyLimit returns the executor's current worker count as
+// last set by its AdaptiveConcurrencyController, or 0 if this
+// executor was created with a fixed size.
+func (b *BatchExecutor) ConcurrencyLimit() int {
+	if b.controller == nil {
+		return 0
+	}
+	return b.controller.Limit()
+}
+
+// Close releases the executor's worker pool.
+func (b *BatchExecutor) Close() {
+	b.pool.Close()
+}
+
+// Batchable reports whether cmd can be safely combined with other
+// commands into a single dispatched shell line: it must have no
+// redirect of its own (the batch only supports plain stdout/stderr
+// capture) and take no stdin input.
+func Batchable(cmd *types.CommandNode) bool {
+	return cmd.Redirect == nil
+}
+
+// RunBatch runs a run of Batchable commands as a single dispatch to
+// the worker pool, joined with "&&" so a failing command stops the
+// ones after it - matching how the engine's own Execute loop stops a
+// script at the first failing command. It returns one combined
+// CommandResult for the whole batch, not one per input command: a
+// single combined exit code can't be attributed back to exactly which
+// command in the chain produced it, so callers that need per-command
+// results should not batch commands whose individual outcomes matter.
+func (b *BatchExecutor) RunBatch(cmds []*types.CommandNode) (*CommandResult, error) {
+	if len(cmds) == 0 {
+		return nil, fmt.Errorf("RunBatch requires at least one command")
+	}
+
+	lines := make([]string, len(cmds))
+	for i, cmd := range cmds {
+		if !Batchable(cmd) {
+			return nil, fmt.Errorf("command %q at index %d is not batchable", cmd.Name, i)
+		}
+		lines[i] = shellQuoteWorkerPath(cmd.Name) + argsToShell(cmd.Args)
+	}
+
+	line := strings.Join(lines, " && ")
+	start := time.Now()
+	stdout, stderr, exitCode, err := b.pool.Run(line)
+	if b.controller != nil {
+		b.controller.Observe(time.Since(start), err == nil && exitCode == 0)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &CommandResult{
+		Command:  cmds[len(cmds)-1],
+		Success:  exitCode == 0,
+		ExitCode: exitCode,
+		Output:   stdout,
+		Error:    stderr,
+	}, nil
+}
+
+// argsToShell renders args as a space-prefixed, individually quoted
+// shell argument list.
+func argsToShell(args []string) string {
+	var b strings.Builder
+	for _, a := range args {
+		b.WriteByte(' ')
+		b.WriteString(shellQuoteWorkerPath(a))
+	}
+	return b.String()
+}
@@ -0,0 +1,106 @@
+package engine
+
+import (
+	"strings"
+	"testing"
+
+	"gitee.com/com_818cloud/shode/pkg/types"
+)
+
+func TestBatchableRejectsCommandsWithRedirects(t *testing.T) {
+	plain := &types.CommandNode{Name: "echo", Args: []string{"hi"}}
+	if !Batchable(plain) {
+		t.Errorf("Batchable(plain) = false, want true")
+	}
+
+	redirected := &types.CommandNode{
+		Name: "echo",
+		Args: []string{"hi"},
+		Redirect: &types.RedirectNode{
+			Op:   ">>",
+			File: "out.txt",
+		},
+	}
+	if Batchable(redirected) {
+		t.Errorf("Batchable(redirected) = true, want false")
+	}
+}
+
+func TestRunBatchCombinesCommandsIntoOneDispatch(t *testing.T) {
+	exec, err := NewBatchExecutor(1)
+	if err != nil {
+		t.Fatalf("NewBatchExecutor() error = %v", err)
+	}
+	defer exec.Close()
+
+	cmds := []*types.CommandNode{
+		{Name: "echo", Args: []string{"a"}},
+		{Name: "echo", Args: []string{"b"}},
+	}
+
+	result, err := exec.RunBatch(cmds)
+	if err != nil {
+		t.Fatalf("RunBatch() error = %v", err)
+	}
+	if !result.Success {
+		t.Errorf("Success = false, want true")
+	}
+	if !strings.Contains(result.Output, "b") {
+		t.Errorf("Output = %q, want it to contain %q", result.Output, "b")
+	}
+}
+
+func TestRunBatchStopsChainOnFailure(t *testing.T) {
+	exec, err := NewBatchExecutor(1)
+	if err != nil {
+		t.Fatalf("NewBatchExecutor() error = %v", err)
+	}
+	defer exec.Close()
+
+	cmds := []*types.CommandNode{
+		{Name: "false", Args: nil},
+		{Name: "echo", Args: []string{"never"}},
+	}
+
+	result, err := exec.RunBatch(cmds)
+	if err != nil {
+		t.Fatalf("RunBatch() error = %v", err)
+	}
+	if result.Success {
+		t.Errorf("Success = true, want false")
+	}
+	if result.ExitCode == 0 {
+		t.Errorf("ExitCode = 0, want non-zero")
+	}
+	if strings.Contains(result.Output, "never") {
+		t.Errorf("Output = %q, should not contain output from the command after the failure", result.Output)
+	}
+}
+
+func TestRunBatchRejectsNonBatchableCommand(t *testing.T) {
+	exec, err := NewBatchExecutor(1)
+	if err != nil {
+		t.Fatalf("NewBatchExecutor() error = %v", err)
+	}
+	defer exec.Close()
+
+	cmds := []*types.CommandNode{
+		{Name: "echo", Args: []string{"a"}, Redirect: &types.RedirectNode{Op: ">>", File: "out.txt"}},
+	}
+
+	if _, err := exec.RunBatch(cmds); err == nil {
+		t.Errorf("RunBatch() error = nil, want error for non-batchable command")
+	}
+}
+
+func TestRunBatchRejectsEmptyInput(t *testing.T) {
+	exec, err := NewBatchExecutor(1)
+	if err != nil {
+		t.Fatalf("NewBatchExecutor() error = %v", err)
+	}
+	defer exec.Close()
+
+	if _, err := exec.RunBatch(nil); err == nil {
+		t.Errorf("RunBatch(nil) error = nil, want error")
+	}
+}
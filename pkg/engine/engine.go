@@ -2,22 +2,27 @@ package engine
 
 import (
 	"context"
+	"database/sql"
 	"encoding/json"
 	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"runtime/debug"
 	"strconv"
 	"strings"
 	"time"
 
 	"gitee.com/com_818cloud/shode/pkg/environment"
+	"gitee.com/com_818cloud/shode/pkg/errorreport"
 	"gitee.com/com_818cloud/shode/pkg/errors"
+	"gitee.com/com_818cloud/shode/pkg/events"
 	"gitee.com/com_818cloud/shode/pkg/metrics"
 	"gitee.com/com_818cloud/shode/pkg/module"
 	shodeparser "gitee.com/com_818cloud/shode/pkg/parser"
 	"gitee.com/com_818cloud/shode/pkg/sandbox"
 	"gitee.com/com_818cloud/shode/pkg/stdlib"
+	"gitee.com/com_818cloud/shode/pkg/tracing"
 	"gitee.com/com_818cloud/shode/pkg/types"
 )
 
@@ -43,6 +48,49 @@ type ExecutionEngine struct {
 	backgroundJobs map[int]*exec.Cmd              // Background jobs (PID -> Cmd)
 	jobCounter     int                            // Counter for job IDs
 	arrays         map[string][]string            // Array variables
+	scriptPath     string                         // Path of the script currently executing, for log attribution
+	debugHook      DebugHook                      // Optional debugger attached via SetDebugHook
+	mockCommandDir string                         // Optional directory of command doubles, checked before PATH; see SetMockCommandDir
+	argvPool       *argvPool                      // Reused []string buffers for spawned commands' environment slices
+	tracer         *tracing.Tracer                // Span tracer for Execute/ExecuteCommand; exports nowhere until SetTracer attaches a real exporter
+	events         *events.Bus                    // Publishes command/job lifecycle events; nil until SetEventBus attaches one
+	errorReporter  *errorreport.Reporter          // Reports panics recovered from Execute to an external collector; nil until SetErrorReporter attaches one
+}
+
+// DebugHook lets a debugger (e.g. a DAP server) observe and pause
+// script execution. OnBeforeCommand is called synchronously just
+// before every command runs, with the source line and a snapshot of
+// the current variables; it blocks the engine for as long as the
+// debugger wants execution paused (e.g. at a breakpoint or single step).
+type DebugHook interface {
+	OnBeforeCommand(line int, vars map[string]string)
+}
+
+// SetDebugHook attaches a debugger to the engine. Pass nil to detach.
+func (ee *ExecutionEngine) SetDebugHook(hook DebugHook) {
+	ee.debugHook = hook
+}
+
+// SetMockCommandDir points the engine at a directory of command doubles
+// (see pkg/devtools/mockcmd) that should be preferred over PATH when
+// resolving external commands, so tests can run against fake curl,
+// kubectl, etc. instead of the real executables. Pass "" to disable.
+func (ee *ExecutionEngine) SetMockCommandDir(dir string) {
+	ee.mockCommandDir = dir
+}
+
+// resolveCommandPath returns the path exec.Command should run for name:
+// a matching executable under mockCommandDir if one exists, otherwise
+// name unchanged (left for exec.Command's normal PATH lookup).
+func (ee *ExecutionEngine) resolveCommandPath(name string) string {
+	if ee.mockCommandDir == "" {
+		return name
+	}
+	candidate := filepath.Join(ee.mockCommandDir, name)
+	if info, err := os.Stat(candidate); err == nil && !info.IsDir() {
+		return candidate
+	}
+	return name
 }
 
 // ExecutionResult represents the result of executing an AST
@@ -96,9 +144,42 @@ func NewExecutionEngine(
 		backgroundJobs: make(map[int]*exec.Cmd),
 		jobCounter:     0,
 		arrays:         make(map[string][]string),
+		argvPool:       newArgvPool(),
+		tracer:         tracing.NewTracer(nil),
 	}
 }
 
+// SetTracer attaches a tracer that exports spans for Execute and
+// ExecuteCommand (and, through them, module loading and stdlib
+// network/DB builtins) to an OTLP collector. Pass a Tracer built with
+// a nil exporter, or simply leave the engine's default in place, to
+// disable tracing.
+func (ee *ExecutionEngine) SetTracer(tracer *tracing.Tracer) {
+	ee.tracer = tracer
+}
+
+// SetEventBus attaches the bus the engine publishes CommandStarted,
+// CommandFinished, and JobStateChanged events to. A nil Bus (the
+// default) makes those publishes no-ops.
+func (ee *ExecutionEngine) SetEventBus(bus *events.Bus) {
+	ee.events = bus
+}
+
+// SetErrorReporter attaches a reporter that Execute sends a recovered
+// panic to, along with the script path and the stack captured at the
+// recover() site, before converting the panic into a returned error. A
+// nil Reporter (the default) makes CapturePanic a no-op.
+func (ee *ExecutionEngine) SetErrorReporter(reporter *errorreport.Reporter) {
+	ee.errorReporter = reporter
+}
+
+// SetScriptPath records the path of the script about to run, so
+// interpreted builtins like LogInfo/LogWarn/LogError can attribute
+// their output to the script that produced it.
+func (ee *ExecutionEngine) SetScriptPath(path string) {
+	ee.scriptPath = path
+}
+
 // Helper methods for Execute function refactoring
 
 // executeCommandNode handles CommandNode execution including special commands
@@ -479,17 +560,34 @@ func (ee *ExecutionEngine) collectOutput(result *ExecutionResult, output string)
 //	    },
 //	}
 //	result, err := ee.Execute(ctx, script)
-func (ee *ExecutionEngine) Execute(ctx context.Context, script *types.ScriptNode) (*ExecutionResult, error) {
+func (ee *ExecutionEngine) Execute(ctx context.Context, script *types.ScriptNode) (result *ExecutionResult, err error) {
+	defer func() {
+		if rec := recover(); rec != nil {
+			stack := debug.Stack()
+			ee.errorReporter.CapturePanic(rec, stack, map[string]interface{}{"script": ee.scriptPath})
+			result = nil
+			err = errors.NewExecutionError(errors.ErrExecutionFailed, fmt.Sprintf("panic during execution: %v", rec)).
+				WithContext("script", ee.scriptPath)
+		}
+	}()
+
 	startTime := time.Now()
 
+	ctx, span := ee.tracer.Start(ctx, "shode.execute")
+	span.SetAttribute("script", ee.scriptPath)
+	defer span.End()
+
 	// Check for context cancellation before starting
 	if ctx.Err() != nil {
-		return nil, errors.NewTimeoutError("script execution").
+		err := errors.NewTimeoutError("script execution").
 			WithContext("reason", ctx.Err().Error())
+		span.SetErr(err)
+		return nil, err
 	}
 
-	result := &ExecutionResult{
+	result = &ExecutionResult{
 		Commands: make([]*CommandResult, 0, len(script.Nodes)),
+		Success:  true,
 	}
 
 	for _, node := range script.Nodes {
@@ -576,14 +674,6 @@ func (ee *ExecutionEngine) Execute(ctx context.Context, script *types.ScriptNode
 	}
 
 	result.Duration = time.Since(startTime)
-	result.Success = true
-	// Ensure ExitCode is set to 0 on success
-	if result.ExitCode != 0 {
-		// This shouldn't happen if all commands succeeded
-		// But if it does, log it and fix it
-		fmt.Fprintf(os.Stderr, "[WARNING] Execute: Success=true but ExitCode=%d, resetting to 0\n", result.ExitCode)
-		result.ExitCode = 0
-	}
 	return result, nil
 }
 
@@ -617,6 +707,15 @@ func (ee *ExecutionEngine) ExecuteCommand(ctx context.Context, cmd *types.Comman
 
 	startTime := time.Now()
 
+	ctx, span := ee.tracer.Start(ctx, "shode.command")
+	span.SetAttribute("command", cmd.Name)
+	span.SetAttribute("line", strconv.Itoa(cmd.Pos.Line))
+	defer span.End()
+
+	if ee.debugHook != nil {
+		ee.debugHook.OnBeforeCommand(cmd.Pos.Line, ee.envManager.GetAll())
+	}
+
 	// Expand variables in command arguments
 	expandedArgs := ee.expandArgs(cmd.Args)
 	// Create a copy of command with expanded args
@@ -638,6 +737,11 @@ func (ee *ExecutionEngine) ExecuteCommand(ctx context.Context, cmd *types.Comman
 		}, nil
 	}
 
+	ee.events.Publish(events.CommandStarted, map[string]interface{}{
+		"command": cmd.Name,
+		"line":    cmd.Pos.Line,
+	})
+
 	// Decide execution mode
 	mode := ee.decideExecutionMode(expandedCmd)
 
@@ -652,21 +756,43 @@ func (ee *ExecutionEngine) ExecuteCommand(ctx context.Context, cmd *types.Comman
 	case ModeHybrid:
 		result, err = ee.executeHybrid(ctx, expandedCmd)
 	default:
-		return nil, errors.NewExecutionError(errors.ErrExecutionFailed,
+		err := errors.NewExecutionError(errors.ErrExecutionFailed,
 			fmt.Sprintf("unknown execution mode: %v", mode)).
 			WithContext("mode", mode).
 			WithContext("command", cmd.Name)
+		span.SetErr(err)
+		ee.events.Publish(events.CommandFinished, map[string]interface{}{
+			"command": cmd.Name,
+			"success": false,
+		})
+		return nil, err
 	}
 
 	if err != nil {
+		span.SetErr(err)
+		ee.events.Publish(events.CommandFinished, map[string]interface{}{
+			"command": cmd.Name,
+			"success": false,
+		})
 		return nil, err
 	}
 
 	result.Duration = time.Since(startTime)
+	ee.events.Publish(events.CommandFinished, map[string]interface{}{
+		"command": cmd.Name,
+		"success": result.Success,
+	})
 	result.Mode = mode
+	ee.metrics.RecordCommandExecution(result.Duration, result.Success, false)
 	return result, nil
 }
 
+// Metrics returns the engine's metrics collector, so callers such as a
+// metrics exporter can read command execution counts and durations.
+func (ee *ExecutionEngine) Metrics() *metrics.MetricsCollector {
+	return ee.metrics
+}
+
 // ExecutePipeline executes a pipeline of commands with proper data flow
 func (ee *ExecutionEngine) ExecutePipeline(ctx context.Context, pipeline *types.PipeNode) (*PipelineResult, error) {
 	// Collect all commands in the pipeline
@@ -765,10 +891,11 @@ func (ee *ExecutionEngine) ExecuteCommandWithInput(ctx context.Context, cmd *typ
 // executeProcessWithInput executes a command with stdin input
 func (ee *ExecutionEngine) executeProcessWithInput(ctx context.Context, cmd *types.CommandNode, input string) (*CommandResult, error) {
 	// Create command with context for timeout support
-	command := exec.CommandContext(ctx, cmd.Name, cmd.Args...)
+	command := exec.CommandContext(ctx, ee.resolveCommandPath(cmd.Name), cmd.Args...)
 
 	// Set environment
-	envVars := make([]string, 0, len(ee.envManager.GetAllEnv()))
+	envVars := ee.argvPool.Get()
+	defer ee.argvPool.Put(envVars)
 	for key, value := range ee.envManager.GetAllEnv() {
 		envVars = append(envVars, key+"="+value)
 	}
@@ -887,64 +1014,101 @@ func (ee *ExecutionEngine) decideExecutionMode(cmd *types.CommandNode) Execution
 func (ee *ExecutionEngine) isStdLibFunction(funcName string) bool {
 	// Map of standard library functions
 	stdlibFunctions := map[string]bool{
-		"Print":                     true,
-		"Println":                   true,
-		"Error":                     true,
-		"Errorln":                   true,
-		"ReadFile":                  true,
-		"WriteFile":                 true,
-		"ListFiles":                 true,
-		"FileExists":                true,
-		"Contains":                  true,
-		"Replace":                   true,
-		"ToUpper":                   true,
-		"ToLower":                   true,
-		"Trim":                      true,
-		"GetEnv":                    true,
-		"SetEnv":                    true,
-		"WorkingDir":                true,
-		"ChangeDir":                 true,
-		"StartHTTPServer":           true,
-		"RegisterRoute":             true,
-		"RegisterHTTPRoute":         true,
-		"RegisterRouteWithResponse": true,
-		"RegisterStaticRoute":         true,
-		"RegisterStaticRouteAdvanced": true,
-		"RegisterHTTPRouteAdvanced":   true,
-		"EnableRequestLog":            true,
-		"RegisterWebSocketRoute":      true,
-		"BroadcastWebSocketMessage":   true,
+		"Print":                           true,
+		"Println":                         true,
+		"Error":                           true,
+		"Errorln":                         true,
+		"LogInfo":                         true,
+		"LogWarn":                         true,
+		"LogError":                        true,
+		"ReadFile":                        true,
+		"WriteFile":                       true,
+		"ListFiles":                       true,
+		"FileExists":                      true,
+		"Contains":                        true,
+		"Replace":                         true,
+		"ToUpper":                         true,
+		"ToLower":                         true,
+		"Trim":                            true,
+		"GetEnv":                          true,
+		"SetEnv":                          true,
+		"WorkingDir":                      true,
+		"ChangeDir":                       true,
+		"StartHTTPServer":                 true,
+		"RegisterRoute":                   true,
+		"RegisterHTTPRoute":               true,
+		"RegisterRouteWithResponse":       true,
+		"RegisterStaticRoute":             true,
+		"RegisterStaticRouteAdvanced":     true,
+		"RegisterHTTPRouteAdvanced":       true,
+		"EnableRequestLog":                true,
+		"RegisterWebSocketRoute":          true,
+		"BroadcastWebSocketMessage":       true,
 		"BroadcastWebSocketMessageToRoom": true,
-		"SendWebSocketMessage":        true,
-		"JoinRoom":                   true,
-		"LeaveRoom":                  true,
-		"GetWebSocketConnectionCount": true,
-		"GetWebSocketRoomCount":       true,
-		"ListWebSocketRooms":         true,
-		"StopHTTPServer":              true,
-		"IsHTTPServerRunning":       true,
-		"GetHTTPMethod":             true,
-		"GetHTTPPath":               true,
-		"GetHTTPQuery":              true,
-		"GetHTTPHeader":             true,
-		"GetHTTPBody":               true,
-		"SetHTTPResponse":           true,
-		"SetHTTPHeader":             true,
-		"SetCache":                  true,
-		"GetCache":                  true,
-		"DeleteCache":               true,
-		"ClearCache":                true,
-		"CacheExists":               true,
-		"GetCacheTTL":               true,
-		"SetCacheBatch":             true,
-		"GetCacheKeys":              true,
-		"ConnectDB":                 true,
-		"CloseDB":                   true,
-		"IsDBConnected":             true,
-		"QueryDB":                   true,
-		"QueryRowDB":                true,
-		"ExecDB":                    true,
-		"GetQueryResult":            true,
+		"SendWebSocketMessage":            true,
+		"JoinRoom":                        true,
+		"LeaveRoom":                       true,
+		"GetWebSocketConnectionCount":     true,
+		"GetWebSocketRoomCount":           true,
+		"ListWebSocketRooms":              true,
+		"StopHTTPServer":                  true,
+		"IsHTTPServerRunning":             true,
+		"GetHTTPMethod":                   true,
+		"GetHTTPPath":                     true,
+		"GetHTTPQuery":                    true,
+		"GetHTTPHeader":                   true,
+		"GetHTTPBody":                     true,
+		"SetHTTPResponse":                 true,
+		"SetHTTPHeader":                   true,
+		"SetCache":                        true,
+		"GetCache":                        true,
+		"DeleteCache":                     true,
+		"ClearCache":                      true,
+		"CacheExists":                     true,
+		"GetCacheTTL":                     true,
+		"SetCacheBatch":                   true,
+		"GetCacheKeys":                    true,
+		"GetCacheStats":                   true,
+		"ConnectDB":                       true,
+		"CloseDB":                         true,
+		"IsDBConnected":                   true,
+		"QueryDB":                         true,
+		"QueryRowDB":                      true,
+		"ExecDB":                          true,
+		"ExecBatchDB":                     true,
+		"GetQueryResult":                  true,
+		"BeginTxDB":                       true,
+		"CommitDB":                        true,
+		"RollbackDB":                      true,
+		"ConnectMongo":                    true,
+		"FindMongo":                       true,
+		"InsertMongo":                     true,
+		"AggregateMongo":                  true,
+		"DockerBuild":                     true,
+		"DockerRun":                       true,
+		"DockerPush":                      true,
+		"DockerPs":                        true,
+		"K8sApply":                        true,
+		"K8sGet":                          true,
+		"K8sLogs":                         true,
+		"K8sWaitFor":                      true,
+		"GitClone":                        true,
+		"GitCheckout":                     true,
+		"GitStatus":                       true,
+		"GitTag":                          true,
+		"GitPush":                         true,
+		"ObjectPut":                       true,
+		"ObjectGet":                       true,
+		"ObjectList":                      true,
+		"PresignURL":                      true,
+		"SSHRun":                          true,
+		"PowerShellInvoke":                true,
+		"ResourceFile":                    true,
+		"ResourcePackage":                 true,
+		"ResourceService":                 true,
+		"ResourceUser":                    true,
+		"ResourcePlan":                    true,
+		"ResourceApply":                   true,
 		// IoC functions
 		"RegisterBean": true,
 		"GetBean":      true,
@@ -978,7 +1142,7 @@ func (ee *ExecutionEngine) executeInterpreted(ctx context.Context, cmd *types.Co
 	}
 
 	// Execute using standard library
-	result, err := ee.executeStdLibFunction(cmd.Name, cmd.Args)
+	result, err := ee.executeStdLibFunction(cmd.Name, cmd.Args, cmd.Pos.Line)
 	if err != nil {
 		return &CommandResult{
 			Command:  cmd,
@@ -1003,8 +1167,84 @@ func (ee *ExecutionEngine) executeInterpreted(ctx context.Context, cmd *types.Co
 }
 
 // executeStdLibFunction executes a standard library function
-func (ee *ExecutionEngine) executeStdLibFunction(funcName string, args []string) (string, error) {
+// queryResult is the JSON shape QueryDB's output takes: a list of rows,
+// each a column name to value map, matching what callers parsing the
+// result (see tests/integration) expect.
+type queryResult struct {
+	Rows []map[string]interface{} `json:"rows"`
+}
+
+// rowsToQueryResult drains rows into a queryResult. It does not close
+// rows - the caller owns that, same as any other *sql.Rows consumer.
+func rowsToQueryResult(rows *sql.Rows) queryResult {
+	result := queryResult{Rows: []map[string]interface{}{}}
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return result
+	}
+
+	for rows.Next() {
+		values := make([]interface{}, len(columns))
+		pointers := make([]interface{}, len(columns))
+		for i := range values {
+			pointers[i] = &values[i]
+		}
+		if err := rows.Scan(pointers...); err != nil {
+			continue
+		}
+
+		row := make(map[string]interface{}, len(columns))
+		for i, col := range columns {
+			if b, ok := values[i].([]byte); ok {
+				row[col] = string(b)
+			} else {
+				row[col] = values[i]
+			}
+		}
+		result.Rows = append(result.Rows, row)
+	}
+
+	return result
+}
+
+// parseConnSelector strips a leading "--on <name>" pair off args, used
+// by QueryDB/ExecDB to target a connection other than the default one
+// registered by ConnectDB. It returns connName == "" when no selector
+// is present, which callers treat as the default connection.
+func parseConnSelector(args []string) (connName string, rest []string) {
+	if len(args) >= 2 && args[0] == "--on" {
+		return args[1], args[2:]
+	}
+	return "", args
+}
+
+func (ee *ExecutionEngine) executeStdLibFunction(funcName string, args []string, line int) (string, error) {
 	switch funcName {
+	case "LogInfo":
+		if len(args) > 0 {
+			expanded := ee.expandVariables(args[0])
+			ee.stdlib.LogInfo(ee.scriptPath, line, expanded)
+			return expanded, nil
+		}
+		ee.stdlib.LogInfo(ee.scriptPath, line, "")
+		return "", nil
+	case "LogWarn":
+		if len(args) > 0 {
+			expanded := ee.expandVariables(args[0])
+			ee.stdlib.LogWarn(ee.scriptPath, line, expanded)
+			return expanded, nil
+		}
+		ee.stdlib.LogWarn(ee.scriptPath, line, "")
+		return "", nil
+	case "LogError":
+		if len(args) > 0 {
+			expanded := ee.expandVariables(args[0])
+			ee.stdlib.LogError(ee.scriptPath, line, expanded)
+			return expanded, nil
+		}
+		ee.stdlib.LogError(ee.scriptPath, line, "")
+		return "", nil
 	case "Print":
 		if len(args) > 0 {
 			// Expand variables in the argument
@@ -1537,13 +1777,25 @@ func (ee *ExecutionEngine) executeStdLibFunction(funcName string, args []string)
 		}
 		keys := ee.stdlib.GetCacheKeys(pattern)
 		return strings.Join(keys, "\n"), nil
+	case "GetCacheStats":
+		stats, ok := ee.stdlib.GetCacheStats()
+		if !ok {
+			return "", errors.NewExecutionError(errors.ErrInvalidInput,
+				"GetCacheStats is not supported by the active cache backend").
+				WithContext("function", "GetCacheStats")
+		}
+		jsonBytes, err := json.Marshal(stats)
+		if err != nil {
+			return "", err
+		}
+		return string(jsonBytes), nil
 	case "ConnectDB":
 		if len(args) < 2 {
 			return "", errors.NewExecutionError(errors.ErrInvalidInput,
-				"ConnectDB requires dbType and dsn arguments").
+				"ConnectDB requires dbType and dsn arguments (optionally a connection name)").
 				WithContext("function", "ConnectDB")
 		}
-		err := ee.stdlib.ConnectDB(args[0], args[1])
+		err := ee.stdlib.ConnectDB(args...)
 		if err != nil {
 			return "", err
 		}
@@ -1558,16 +1810,18 @@ func (ee *ExecutionEngine) executeStdLibFunction(funcName string, args []string)
 		connected := ee.stdlib.IsDBConnected()
 		return fmt.Sprintf("%v", connected), nil
 	case "QueryDB":
+		connName, args := parseConnSelector(args)
 		if len(args) == 0 {
 			return "", errors.NewExecutionError(errors.ErrInvalidInput,
 				"QueryDB requires sql argument").
 				WithContext("function", "QueryDB")
 		}
-		result, err := ee.stdlib.QueryDB(args[0], args[1:]...)
+		rows, err := ee.stdlib.QueryDBOn(connName, args[0], args[1:]...)
 		if err != nil {
 			return "", err
 		}
-		jsonBytes, err := json.Marshal(result)
+		defer rows.Close()
+		jsonBytes, err := json.Marshal(rowsToQueryResult(rows))
 		if err != nil {
 			return "", err
 		}
@@ -1588,12 +1842,133 @@ func (ee *ExecutionEngine) executeStdLibFunction(funcName string, args []string)
 		}
 		return string(jsonBytes), nil
 	case "ExecDB":
+		connName, args := parseConnSelector(args)
 		if len(args) == 0 {
 			return "", errors.NewExecutionError(errors.ErrInvalidInput,
 				"ExecDB requires sql argument").
 				WithContext("function", "ExecDB")
 		}
-		result, err := ee.stdlib.ExecDB(args[0], args[1:]...)
+		result, err := ee.stdlib.ExecDBOn(connName, args[0], args[1:]...)
+		if err != nil {
+			return "", err
+		}
+		jsonBytes, err := json.Marshal(result)
+		if err != nil {
+			return "", err
+		}
+		return string(jsonBytes), nil
+	case "ExecBatchDB":
+		connName, args := parseConnSelector(args)
+		if len(args) < 2 {
+			return "", errors.NewExecutionError(errors.ErrInvalidInput,
+				"ExecBatchDB requires sql and argSets arguments").
+				WithContext("function", "ExecBatchDB")
+		}
+		count, err := ee.stdlib.ExecBatchDBOn(connName, args[0], args[1])
+		if err != nil {
+			return "", err
+		}
+		return strconv.Itoa(count), nil
+	case "BeginTxDB":
+		txID, err := ee.stdlib.BeginTxDB()
+		if err != nil {
+			return "", err
+		}
+		return txID, nil
+	case "CommitDB":
+		if err := ee.stdlib.CommitDB(); err != nil {
+			return "", err
+		}
+		return "Transaction committed", nil
+	case "RollbackDB":
+		if err := ee.stdlib.RollbackDB(); err != nil {
+			return "", err
+		}
+		return "Transaction rolled back", nil
+	case "ConnectMongo":
+		if len(args) < 2 {
+			return "", errors.NewExecutionError(errors.ErrInvalidInput,
+				"ConnectMongo requires an addr and db (optionally a connection name)").
+				WithContext("function", "ConnectMongo")
+		}
+		if err := ee.stdlib.ConnectMongo(args...); err != nil {
+			return "", err
+		}
+		return "Mongo connected", nil
+	case "FindMongo":
+		connName, args := parseConnSelector(args)
+		if len(args) < 2 {
+			return "", errors.NewExecutionError(errors.ErrInvalidInput,
+				"FindMongo requires collection and filter arguments").
+				WithContext("function", "FindMongo")
+		}
+		limit := 0
+		if len(args) >= 3 {
+			limit, _ = strconv.Atoi(args[2])
+		}
+		docs, err := ee.stdlib.FindMongoOn(connName, args[0], args[1], limit)
+		if err != nil {
+			return "", err
+		}
+		jsonBytes, err := json.Marshal(docs)
+		if err != nil {
+			return "", err
+		}
+		return string(jsonBytes), nil
+	case "InsertMongo":
+		connName, args := parseConnSelector(args)
+		if len(args) < 2 {
+			return "", errors.NewExecutionError(errors.ErrInvalidInput,
+				"InsertMongo requires collection and documents arguments").
+				WithContext("function", "InsertMongo")
+		}
+		count, err := ee.stdlib.InsertMongoOn(connName, args[0], args[1])
+		if err != nil {
+			return "", err
+		}
+		return strconv.Itoa(count), nil
+	case "AggregateMongo":
+		connName, args := parseConnSelector(args)
+		if len(args) < 2 {
+			return "", errors.NewExecutionError(errors.ErrInvalidInput,
+				"AggregateMongo requires collection and pipeline arguments").
+				WithContext("function", "AggregateMongo")
+		}
+		docs, err := ee.stdlib.AggregateMongoOn(connName, args[0], args[1])
+		if err != nil {
+			return "", err
+		}
+		jsonBytes, err := json.Marshal(docs)
+		if err != nil {
+			return "", err
+		}
+		return string(jsonBytes), nil
+	case "DockerBuild":
+		if len(args) < 2 {
+			return "", errors.NewExecutionError(errors.ErrInvalidInput,
+				"DockerBuild requires a contextDir and tag argument (optionally a dockerfile: DockerBuild <contextDir> [dockerfile] <tag>)").
+				WithContext("function", "DockerBuild")
+		}
+		contextDir, dockerfile, tag := args[0], "", args[1]
+		if len(args) >= 3 {
+			dockerfile, tag = args[1], args[2]
+		}
+		result, err := ee.stdlib.DockerBuild(contextDir, dockerfile, tag)
+		if err != nil {
+			return "", err
+		}
+		jsonBytes, err := json.Marshal(result)
+		if err != nil {
+			return "", err
+		}
+		return string(jsonBytes), nil
+	case "DockerRun":
+		if len(args) < 1 {
+			return "", errors.NewExecutionError(errors.ErrInvalidInput,
+				"DockerRun requires an image argument (optionally a command: DockerRun <image> [cmd...])").
+				WithContext("function", "DockerRun")
+		}
+		result, err := ee.stdlib.DockerRun(args[0], args[1:], nil)
 		if err != nil {
 			return "", err
 		}
@@ -1602,6 +1977,322 @@ func (ee *ExecutionEngine) executeStdLibFunction(funcName string, args []string)
 			return "", err
 		}
 		return string(jsonBytes), nil
+	case "DockerPush":
+		if len(args) < 1 {
+			return "", errors.NewExecutionError(errors.ErrInvalidInput,
+				"DockerPush requires an image argument (optionally username and password: DockerPush <image> [username] [password])").
+				WithContext("function", "DockerPush")
+		}
+		var username, password string
+		if len(args) >= 2 {
+			username = args[1]
+		}
+		if len(args) >= 3 {
+			password = args[2]
+		}
+		if err := ee.stdlib.DockerPush(args[0], username, password); err != nil {
+			return "", err
+		}
+		return "Image pushed", nil
+	case "DockerPs":
+		all := len(args) >= 1 && args[0] == "-a"
+		containers, err := ee.stdlib.DockerPs(all)
+		if err != nil {
+			return "", err
+		}
+		jsonBytes, err := json.Marshal(containers)
+		if err != nil {
+			return "", err
+		}
+		return string(jsonBytes), nil
+	case "K8sApply":
+		if len(args) < 2 {
+			return "", errors.NewExecutionError(errors.ErrInvalidInput,
+				"K8sApply requires a namespace and manifestPath argument: K8sApply <namespace> <manifestPath>").
+				WithContext("function", "K8sApply")
+		}
+		result, err := ee.stdlib.K8sApply(args[0], args[1])
+		if err != nil {
+			return "", err
+		}
+		jsonBytes, err := json.Marshal(result)
+		if err != nil {
+			return "", err
+		}
+		return string(jsonBytes), nil
+	case "K8sGet":
+		if len(args) < 3 {
+			return "", errors.NewExecutionError(errors.ErrInvalidInput,
+				"K8sGet requires namespace, kind, and name arguments: K8sGet <namespace> <kind> <name>").
+				WithContext("function", "K8sGet")
+		}
+		result, err := ee.stdlib.K8sGet(args[0], args[1], args[2])
+		if err != nil {
+			return "", err
+		}
+		jsonBytes, err := json.Marshal(result)
+		if err != nil {
+			return "", err
+		}
+		return string(jsonBytes), nil
+	case "K8sLogs":
+		if len(args) < 2 {
+			return "", errors.NewExecutionError(errors.ErrInvalidInput,
+				"K8sLogs requires a namespace and pod argument (optionally a container: K8sLogs <namespace> <pod> [container])").
+				WithContext("function", "K8sLogs")
+		}
+		var container string
+		if len(args) >= 3 {
+			container = args[2]
+		}
+		logs, err := ee.stdlib.K8sLogs(args[0], args[1], container)
+		if err != nil {
+			return "", err
+		}
+		return logs, nil
+	case "K8sWaitFor":
+		if len(args) < 4 {
+			return "", errors.NewExecutionError(errors.ErrInvalidInput,
+				"K8sWaitFor requires namespace, kind, name, and condition arguments (optionally a timeout in seconds: K8sWaitFor <namespace> <kind> <name> <condition> [timeoutSeconds])").
+				WithContext("function", "K8sWaitFor")
+		}
+		timeoutSeconds := 60
+		if len(args) >= 5 {
+			if parsed, err := strconv.Atoi(args[4]); err == nil {
+				timeoutSeconds = parsed
+			}
+		}
+		if err := ee.stdlib.K8sWaitFor(args[0], args[1], args[2], args[3], timeoutSeconds); err != nil {
+			return "", err
+		}
+		return "Condition met", nil
+	case "GitClone":
+		if len(args) < 2 {
+			return "", errors.NewExecutionError(errors.ErrInvalidInput,
+				"GitClone requires a url and dest argument (optionally a shallow-clone depth and username/token: GitClone <url> <dest> [depth] [username] [token])").
+				WithContext("function", "GitClone")
+		}
+		depth := 0
+		if len(args) >= 3 {
+			if parsed, err := strconv.Atoi(args[2]); err == nil {
+				depth = parsed
+			}
+		}
+		var username, token string
+		if len(args) >= 4 {
+			username = args[3]
+		}
+		if len(args) >= 5 {
+			token = args[4]
+		}
+		if err := ee.stdlib.GitClone(args[0], args[1], depth, username, token); err != nil {
+			return "", err
+		}
+		return "Repository cloned", nil
+	case "GitCheckout":
+		if len(args) < 2 {
+			return "", errors.NewExecutionError(errors.ErrInvalidInput,
+				"GitCheckout requires a repoDir and ref argument: GitCheckout <repoDir> <ref>").
+				WithContext("function", "GitCheckout")
+		}
+		if err := ee.stdlib.GitCheckout(args[0], args[1]); err != nil {
+			return "", err
+		}
+		return "Checked out", nil
+	case "GitStatus":
+		if len(args) < 1 {
+			return "", errors.NewExecutionError(errors.ErrInvalidInput,
+				"GitStatus requires a repoDir argument: GitStatus <repoDir>").
+				WithContext("function", "GitStatus")
+		}
+		status, err := ee.stdlib.GitStatus(args[0])
+		if err != nil {
+			return "", err
+		}
+		return status, nil
+	case "GitTag":
+		if len(args) < 2 {
+			return "", errors.NewExecutionError(errors.ErrInvalidInput,
+				"GitTag requires a repoDir and tag argument (optionally a message: GitTag <repoDir> <tag> [message])").
+				WithContext("function", "GitTag")
+		}
+		var message string
+		if len(args) >= 3 {
+			message = args[2]
+		}
+		if err := ee.stdlib.GitTag(args[0], args[1], message); err != nil {
+			return "", err
+		}
+		return "Tag created", nil
+	case "GitPush":
+		if len(args) < 2 {
+			return "", errors.NewExecutionError(errors.ErrInvalidInput,
+				"GitPush requires a repoDir and remote argument (optionally a ref and username/token: GitPush <repoDir> <remote> [ref] [username] [token])").
+				WithContext("function", "GitPush")
+		}
+		ref := "HEAD"
+		if len(args) >= 3 {
+			ref = args[2]
+		}
+		var username, token string
+		if len(args) >= 4 {
+			username = args[3]
+		}
+		if len(args) >= 5 {
+			token = args[4]
+		}
+		if err := ee.stdlib.GitPush(args[0], args[1], ref, username, token); err != nil {
+			return "", err
+		}
+		return "Pushed", nil
+	case "ObjectPut":
+		if len(args) < 2 {
+			return "", errors.NewExecutionError(errors.ErrInvalidInput,
+				"ObjectPut requires a localPath and key argument: ObjectPut <localPath> <key>").
+				WithContext("function", "ObjectPut")
+		}
+		if err := ee.stdlib.ObjectPut(args[0], args[1]); err != nil {
+			return "", err
+		}
+		return "Object uploaded", nil
+	case "ObjectGet":
+		if len(args) < 2 {
+			return "", errors.NewExecutionError(errors.ErrInvalidInput,
+				"ObjectGet requires a key and localPath argument: ObjectGet <key> <localPath>").
+				WithContext("function", "ObjectGet")
+		}
+		if err := ee.stdlib.ObjectGet(args[0], args[1]); err != nil {
+			return "", err
+		}
+		return "Object downloaded", nil
+	case "ObjectList":
+		if len(args) < 1 {
+			return "", errors.NewExecutionError(errors.ErrInvalidInput,
+				"ObjectList requires a prefix argument: ObjectList <prefix>").
+				WithContext("function", "ObjectList")
+		}
+		keys, err := ee.stdlib.ObjectList(args[0])
+		if err != nil {
+			return "", err
+		}
+		jsonBytes, err := json.Marshal(keys)
+		if err != nil {
+			return "", err
+		}
+		return string(jsonBytes), nil
+	case "PresignURL":
+		if len(args) < 1 {
+			return "", errors.NewExecutionError(errors.ErrInvalidInput,
+				"PresignURL requires a key argument (optionally a TTL in seconds: PresignURL <key> [ttlSeconds])").
+				WithContext("function", "PresignURL")
+		}
+		ttlSeconds := 3600
+		if len(args) >= 2 {
+			if parsed, err := strconv.Atoi(args[1]); err == nil {
+				ttlSeconds = parsed
+			}
+		}
+		url, err := ee.stdlib.PresignURL(args[0], ttlSeconds)
+		if err != nil {
+			return "", err
+		}
+		return url, nil
+	case "SSHRun":
+		if len(args) < 6 {
+			return "", errors.NewExecutionError(errors.ErrInvalidInput,
+				"SSHRun requires host, port, user, password, keyFile, and command arguments: SSHRun <host> <port> <user> <password> <keyFile> <command>").
+				WithContext("function", "SSHRun")
+		}
+		port, err := strconv.Atoi(args[1])
+		if err != nil {
+			return "", errors.NewExecutionError(errors.ErrInvalidInput,
+				"SSHRun port must be an integer").
+				WithContext("function", "SSHRun")
+		}
+		output, err := ee.stdlib.SSHRun(args[0], port, args[2], args[3], args[4], args[5])
+		if err != nil {
+			return "", err
+		}
+		return output, nil
+	case "PowerShellInvoke":
+		if len(args) < 1 {
+			return "", errors.NewExecutionError(errors.ErrInvalidInput,
+				"PowerShellInvoke requires a cmdlet argument: PowerShellInvoke <cmdlet>").
+				WithContext("function", "PowerShellInvoke")
+		}
+		result, err := ee.stdlib.PowerShellInvoke(args[0])
+		if err != nil {
+			return "", err
+		}
+		jsonBytes, err := json.Marshal(result)
+		if err != nil {
+			return "", err
+		}
+		return string(jsonBytes), nil
+	case "ResourceFile":
+		if len(args) < 4 {
+			return "", errors.NewExecutionError(errors.ErrInvalidInput,
+				"ResourceFile requires path, content, mode, and state arguments: ResourceFile <path> <content> <mode> <state>").
+				WithContext("function", "ResourceFile")
+		}
+		id, err := ee.stdlib.ResourceFile(args[0], args[1], args[2], args[3])
+		if err != nil {
+			return "", err
+		}
+		return id, nil
+	case "ResourcePackage":
+		if len(args) < 3 {
+			return "", errors.NewExecutionError(errors.ErrInvalidInput,
+				"ResourcePackage requires name, version, and state arguments: ResourcePackage <name> <version> <state>").
+				WithContext("function", "ResourcePackage")
+		}
+		id, err := ee.stdlib.ResourcePackage(args[0], args[1], args[2])
+		if err != nil {
+			return "", err
+		}
+		return id, nil
+	case "ResourceService":
+		if len(args) < 3 {
+			return "", errors.NewExecutionError(errors.ErrInvalidInput,
+				"ResourceService requires name, runState, and enableState arguments: ResourceService <name> <runState> <enableState>").
+				WithContext("function", "ResourceService")
+		}
+		id, err := ee.stdlib.ResourceService(args[0], args[1], args[2])
+		if err != nil {
+			return "", err
+		}
+		return id, nil
+	case "ResourceUser":
+		if len(args) < 4 {
+			return "", errors.NewExecutionError(errors.ErrInvalidInput,
+				"ResourceUser requires name, shell, home, and state arguments: ResourceUser <name> <shell> <home> <state>").
+				WithContext("function", "ResourceUser")
+		}
+		id, err := ee.stdlib.ResourceUser(args[0], args[1], args[2], args[3])
+		if err != nil {
+			return "", err
+		}
+		return id, nil
+	case "ResourcePlan":
+		plan, err := ee.stdlib.ResourcePlan()
+		if err != nil {
+			return "", err
+		}
+		jsonBytes, err := json.Marshal(plan)
+		if err != nil {
+			return "", err
+		}
+		return string(jsonBytes), nil
+	case "ResourceApply":
+		applyResults, err := ee.stdlib.ResourceApply()
+		if err != nil {
+			return "", err
+		}
+		jsonBytes, err := json.Marshal(applyResults)
+		if err != nil {
+			return "", err
+		}
+		return string(jsonBytes), nil
 	// IoC functions
 	case "RegisterBean":
 		if len(args) < 3 {
@@ -1762,32 +2453,37 @@ func (ee *ExecutionEngine) executeProcess(ctx context.Context, cmd *types.Comman
 	// Check cache first (only if no redirects)
 	if actualCmd.Redirect == nil {
 		if cached, ok := ee.cache.Get(actualCmd.Name, actualCmd.Args); ok {
+			ee.metrics.RecordCacheHit()
 			return cached, nil
 		}
+		ee.metrics.RecordCacheMiss()
 	}
 
 	// Create command with context
-	command := exec.CommandContext(ctx, actualCmd.Name, actualCmd.Args...)
+	command := exec.CommandContext(ctx, ee.resolveCommandPath(actualCmd.Name), actualCmd.Args...)
 
-	// Set environment - convert map[string]string to []string
-	envVars := make([]string, 0, len(ee.envManager.GetAllEnv()))
+	// Set environment - convert map[string]string to []string, reusing
+	// a pooled buffer rather than allocating a fresh slice per command
+	envVars := ee.argvPool.Get()
+	defer ee.argvPool.Put(envVars)
 	for key, value := range ee.envManager.GetAllEnv() {
 		envVars = append(envVars, key+"="+value)
 	}
 
-	// Debug: log environment for problematic commands
-	// if len(envVars) == 0 {
-	// }
-
 	command.Env = envVars
 
 	// Set working directory
 	command.Dir = ee.envManager.GetWorkingDir()
 
+	// Inherit the process's stdin so external commands that read input
+	// (cat, read, jq, ...) see whatever is piped into Shode itself.
+	command.Stdin = os.Stdin
+
 	// Handle redirections
 	var stdout, stderr strings.Builder
 	if cmd.Redirect != nil {
-		if err := ee.setupRedirect(command, cmd.Redirect, &stdout, &stderr); err != nil {
+		redirectFile, err := ee.setupRedirect(command, cmd.Redirect, &stdout, &stderr)
+		if err != nil {
 			return &CommandResult{
 				Command:  cmd,
 				Success:  false,
@@ -1795,6 +2491,12 @@ func (ee *ExecutionEngine) executeProcess(ctx context.Context, cmd *types.Comman
 				Error:    fmt.Sprintf("redirect error: %v", err),
 			}, nil
 		}
+		// The redirect's file must stay open for the lifetime of the
+		// command - closing it right after setup (before Run) would
+		// hand the child a dangling fd and make it fail to write.
+		if redirectFile != nil {
+			defer redirectFile.Close()
+		}
 	} else {
 		// No redirect - capture output
 		command.Stdout = &stdout
@@ -1847,72 +2549,74 @@ func (ee *ExecutionEngine) executeProcess(ctx context.Context, cmd *types.Comman
 	return result, nil
 }
 
-// setupRedirect sets up input/output redirection for a command
-func (ee *ExecutionEngine) setupRedirect(cmd *exec.Cmd, redirect *types.RedirectNode, stdout, stderr *strings.Builder) error {
+// setupRedirect sets up input/output redirection for a command. The
+// returned file, if non-nil, is the opened redirect target and must
+// stay open until the command has finished running - the caller
+// closes it once cmd.Run() returns.
+func (ee *ExecutionEngine) setupRedirect(cmd *exec.Cmd, redirect *types.RedirectNode, stdout, stderr *strings.Builder) (*os.File, error) {
 	switch redirect.Op {
 	case ">": // Output redirection (overwrite)
 		file, err := os.Create(redirect.File)
 		if err != nil {
-			return errors.WrapError(errors.ErrFileNotFound,
+			return nil, errors.WrapError(errors.ErrFileNotFound,
 				fmt.Sprintf("failed to create file %s", redirect.File), err).
 				WithContext("file", redirect.File).
 				WithContext("operation", "create")
 		}
-		defer file.Close()
 
 		if redirect.Fd == 1 || redirect.Fd == 0 { // stdout
 			cmd.Stdout = file
 		} else if redirect.Fd == 2 { // stderr
 			cmd.Stderr = file
 		}
+		return file, nil
 
 	case ">>": // Output redirection (append)
 		file, err := os.OpenFile(redirect.File, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
 		if err != nil {
-			return errors.WrapError(errors.ErrFileNotFound,
+			return nil, errors.WrapError(errors.ErrFileNotFound,
 				fmt.Sprintf("failed to open file %s", redirect.File), err).
 				WithContext("file", redirect.File).
 				WithContext("operation", "append")
 		}
-		defer file.Close()
 
 		if redirect.Fd == 1 || redirect.Fd == 0 {
 			cmd.Stdout = file
 		} else if redirect.Fd == 2 {
 			cmd.Stderr = file
 		}
+		return file, nil
 
 	case "<": // Input redirection
 		file, err := os.Open(redirect.File)
 		if err != nil {
-			return errors.NewFileNotFoundError(redirect.File).
+			return nil, errors.NewFileNotFoundError(redirect.File).
 				WithContext("operation", "read")
 		}
-		defer file.Close()
 		cmd.Stdin = file
+		return file, nil
 
 	case "2>&1": // Redirect stderr to stdout
 		cmd.Stderr = cmd.Stdout
+		return nil, nil
 
 	case "&>": // Redirect both stdout and stderr to file
 		file, err := os.Create(redirect.File)
 		if err != nil {
-			return errors.WrapError(errors.ErrFileNotFound,
+			return nil, errors.WrapError(errors.ErrFileNotFound,
 				fmt.Sprintf("failed to create file %s", redirect.File), err).
 				WithContext("file", redirect.File).
 				WithContext("operation", "create")
 		}
-		defer file.Close()
 		cmd.Stdout = file
 		cmd.Stderr = file
+		return file, nil
 
 	default:
-		return errors.NewExecutionError(errors.ErrInvalidInput,
+		return nil, errors.NewExecutionError(errors.ErrInvalidInput,
 			fmt.Sprintf("unsupported redirect operator: %s", redirect.Op)).
 			WithContext("operator", redirect.Op)
 	}
-
-	return nil
 }
 
 // executeHybrid executes a command using hybrid approach
@@ -2219,6 +2923,11 @@ func (ee *ExecutionEngine) ExecuteBackground(ctx context.Context, bgNode *types.
 	ee.jobCounter++
 	jobID := ee.jobCounter
 
+	ee.events.Publish(events.JobStateChanged, map[string]interface{}{
+		"jobID": jobID,
+		"state": "started",
+	})
+
 	// Store job info (we can't store exec.Cmd directly for background jobs,
 	// but we can track them by job ID)
 	// For now, just return the result immediately
@@ -2237,6 +2946,11 @@ func (ee *ExecutionEngine) ExecuteBackground(ctx context.Context, bgNode *types.
 	}, nil
 }
 
+// GetJobCount returns the number of background jobs submitted so far.
+func (ee *ExecutionEngine) GetJobCount() int {
+	return ee.jobCounter
+}
+
 // Helper function to convert error to string
 func errorToString(err error) string {
 	if err == nil {
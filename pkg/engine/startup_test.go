@@ -0,0 +1,53 @@
+package engine
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"gitee.com/com_818cloud/shode/pkg/environment"
+	"gitee.com/com_818cloud/shode/pkg/module"
+	"gitee.com/com_818cloud/shode/pkg/parser"
+	"gitee.com/com_818cloud/shode/pkg/sandbox"
+	"gitee.com/com_818cloud/shode/pkg/stdlib"
+)
+
+// startupBudget is the maximum wall-clock time a cold start - building
+// every manager shode run needs, then parsing and executing a small
+// script - should take. It's only achievable because pkg/stdlib's
+// cache, database, IoC, config, and middleware managers are built
+// lazily on first use rather than unconditionally in stdlib.New(), so
+// a script that never touches them doesn't pay for them.
+const startupBudget = 10 * time.Millisecond
+
+// TestColdStartStaysUnderBudget uses Println, a stdlib function run in
+// ModeInterpreted, rather than an external command: process spawn
+// time is unrelated to what this test guards (lazy manager
+// construction) and would make the budget flaky on a loaded machine.
+func TestColdStartStaysUnderBudget(t *testing.T) {
+	start := time.Now()
+
+	envManager := environment.NewEnvironmentManager()
+	stdLib := stdlib.New()
+	moduleMgr := module.NewModuleManager()
+	security := sandbox.NewSecurityChecker()
+	ee := NewExecutionEngine(envManager, stdLib, moduleMgr, security)
+
+	p := parser.NewSimpleParser()
+	script, err := p.ParseString(`Println "hello"`)
+	if err != nil {
+		t.Fatalf("ParseString() error = %v", err)
+	}
+
+	result, err := ee.Execute(context.Background(), script)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if !result.Success {
+		t.Fatalf("Execute() did not succeed: %+v", result)
+	}
+
+	if elapsed := time.Since(start); elapsed > startupBudget {
+		t.Errorf("cold start took %v, want under %v", elapsed, startupBudget)
+	}
+}
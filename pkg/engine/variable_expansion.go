@@ -4,10 +4,28 @@ import (
 	"context"
 	"regexp"
 	"strings"
+	"sync"
 
 	"gitee.com/com_818cloud/shode/pkg/parser"
 )
 
+// These patterns are used on every call to expandVariables and
+// expandCommandSubstitution, which run once per command argument, so
+// they're compiled once here rather than re-compiled on every call.
+var (
+	braceVarPattern      = regexp.MustCompile(`\$\{([^}]+)\}`)
+	bareVarPattern       = regexp.MustCompile(`\$([A-Za-z_][A-Za-z0-9_]*)`)
+	commandSubstPattern  = regexp.MustCompile(`\$\(([^)]+)\)`)
+	backtickSubstPattern = regexp.MustCompile("`([^`]+)`")
+)
+
+// concatBuilderPool reuses the strings.Builder that splitStringConcat
+// uses to accumulate each part, so expanding an argument list doesn't
+// allocate a fresh builder per argument.
+var concatBuilderPool = sync.Pool{
+	New: func() interface{} { return &strings.Builder{} },
+}
+
 // expandVariables expands environment variables in a string
 // Supports ${VAR}, $VAR syntax, command substitution $(...), and direct variable names (for string concatenation)
 func (ee *ExecutionEngine) expandVariables(s string) string {
@@ -39,8 +57,7 @@ func (ee *ExecutionEngine) expandVariables(s string) string {
 	}
 
 	// Expand ${VAR} syntax
-	re := regexp.MustCompile(`\$\{([^}]+)\}`)
-	s = re.ReplaceAllStringFunc(s, func(match string) string {
+	s = braceVarPattern.ReplaceAllStringFunc(s, func(match string) string {
 		varName := match[2 : len(match)-1] // Extract variable name
 		value := ee.envManager.GetEnv(varName)
 		if value == "" {
@@ -50,8 +67,7 @@ func (ee *ExecutionEngine) expandVariables(s string) string {
 	})
 
 	// Expand $VAR syntax (but not ${VAR} which we already handled)
-	re2 := regexp.MustCompile(`\$([A-Za-z_][A-Za-z0-9_]*)`)
-	s = re2.ReplaceAllStringFunc(s, func(match string) string {
+	s = bareVarPattern.ReplaceAllStringFunc(s, func(match string) string {
 		varName := match[1:] // Extract variable name (skip $)
 		value := ee.envManager.GetEnv(varName)
 		if value == "" {
@@ -76,8 +92,7 @@ func (ee *ExecutionEngine) expandVariables(s string) string {
 // expandCommandSubstitution expands command substitution $(command) or `command`
 func (ee *ExecutionEngine) expandCommandSubstitution(s string) string {
 	// Handle $(command) syntax
-	re := regexp.MustCompile(`\$\(([^)]+)\)`)
-	s = re.ReplaceAllStringFunc(s, func(match string) string {
+	s = commandSubstPattern.ReplaceAllStringFunc(s, func(match string) string {
 		// Extract command from $(command)
 		cmdStr := match[2 : len(match)-1] // Remove $() wrapper
 		// Execute command and return output
@@ -86,8 +101,7 @@ func (ee *ExecutionEngine) expandCommandSubstitution(s string) string {
 	})
 
 	// Handle backtick syntax `command`
-	re2 := regexp.MustCompile("`([^`]+)`")
-	s = re2.ReplaceAllStringFunc(s, func(match string) string {
+	s = backtickSubstPattern.ReplaceAllStringFunc(s, func(match string) string {
 		// Extract command from `command`
 		cmdStr := match[1 : len(match)-1] // Remove backticks
 		// Execute command and return output
@@ -126,7 +140,9 @@ func (ee *ExecutionEngine) executeCommandSubstitution(cmdStr string) string {
 // splitStringConcat splits a string by + operator, handling quoted strings
 func splitStringConcat(s string) []string {
 	var parts []string
-	var current strings.Builder
+	current := concatBuilderPool.Get().(*strings.Builder)
+	current.Reset()
+	defer concatBuilderPool.Put(current)
 	inQuotes := false
 	quoteChar := byte(0)
 
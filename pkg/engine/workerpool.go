@@ -0,0 +1,204 @@
+package engine
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// CommandWorkerPool maintains a small set of pre-forked shell helper
+// processes. Each worker is spawned once, up front, and then stays
+// alive reading command lines from its own stdin for the lifetime of
+// the pool - so the fork+exec cost profiling attributed to dominating
+// script time is paid once per worker rather than once per command.
+//
+// Go's os/exec has no posix_spawn entry point to call into directly
+// (and this repo avoids cgo), so this pool gets the same effect the
+// other way around: reuse already-spawned processes instead of
+// spawning a new one for every command.
+type CommandWorkerPool struct {
+	mu      sync.RWMutex
+	workers []*commandWorker
+	next    atomic.Uint64
+}
+
+// NewCommandWorkerPool spawns size pre-forked helper processes.
+func NewCommandWorkerPool(size int) (*CommandWorkerPool, error) {
+	if size < 1 {
+		size = 1
+	}
+
+	p := &CommandWorkerPool{workers: make([]*commandWorker, 0, size)}
+	for i := 0; i < size; i++ {
+		w, err := newCommandWorker()
+		if err != nil {
+			p.Close()
+			return nil, fmt.Errorf("starting worker %d: %w", i, err)
+		}
+		p.workers = append(p.workers, w)
+	}
+	return p, nil
+}
+
+// Run executes line - a complete, already-quoted shell command line,
+// optionally chaining several commands with "&&" - on the next
+// available pre-forked worker, and returns its captured output and
+// exit code.
+func (p *CommandWorkerPool) Run(line string) (stdout, stderr string, exitCode int, err error) {
+	p.mu.RLock()
+	idx := p.next.Add(1) % uint64(len(p.workers))
+	w := p.workers[idx]
+	p.mu.RUnlock()
+	return w.run(line)
+}
+
+// Size returns the pool's current worker count.
+func (p *CommandWorkerPool) Size() int {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return len(p.workers)
+}
+
+// Resize grows or shrinks the pool to n workers (clamped to at least
+// 1), spawning new pre-forked helpers or closing surplus ones as
+// needed. It's the hook an AdaptiveConcurrencyController uses to act
+// on its feedback loop without callers having to recreate the pool.
+func (p *CommandWorkerPool) Resize(n int) error {
+	if n < 1 {
+		n = 1
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for len(p.workers) < n {
+		w, err := newCommandWorker()
+		if err != nil {
+			return fmt.Errorf("growing pool to %d workers: %w", n, err)
+		}
+		p.workers = append(p.workers, w)
+	}
+
+	for len(p.workers) > n {
+		last := p.workers[len(p.workers)-1]
+		p.workers = p.workers[:len(p.workers)-1]
+		last.close()
+	}
+
+	return nil
+}
+
+// Close terminates every worker in the pool.
+func (p *CommandWorkerPool) Close() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, w := range p.workers {
+		w.close()
+	}
+}
+
+// commandWorker is a single pre-forked "sh" process that evaluates one
+// command line at a time, redirecting its output to dedicated temp
+// files so the worker's own stdout can be used purely as a sentinel
+// channel reporting each job's exit code.
+type commandWorker struct {
+	mu      sync.Mutex
+	cmd     *exec.Cmd
+	stdin   io.WriteCloser
+	stdout  *bufio.Reader
+	outPath string
+	errPath string
+}
+
+func newCommandWorker() (*commandWorker, error) {
+	dir, err := os.MkdirTemp("", "shode-worker-")
+	if err != nil {
+		return nil, err
+	}
+
+	outPath := filepath.Join(dir, "stdout")
+	errPath := filepath.Join(dir, "stderr")
+
+	// Read a command line, eval it with its output redirected to the
+	// dedicated files, then print its exit code on our own stdout as a
+	// sentinel the pool waits for.
+	script := fmt.Sprintf(`while IFS= read -r line; do eval "$line" >%s 2>%s; echo "$?"; done`,
+		shellQuoteWorkerPath(outPath), shellQuoteWorkerPath(errPath))
+
+	cmd := exec.Command("sh", "-c", script)
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		os.RemoveAll(dir)
+		return nil, err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		stdin.Close()
+		os.RemoveAll(dir)
+		return nil, err
+	}
+
+	if err := cmd.Start(); err != nil {
+		stdin.Close()
+		os.RemoveAll(dir)
+		return nil, err
+	}
+
+	return &commandWorker{
+		cmd:     cmd,
+		stdin:   stdin,
+		stdout:  bufio.NewReader(stdout),
+		outPath: outPath,
+		errPath: errPath,
+	}, nil
+}
+
+// run dispatches line to this worker and blocks until it completes.
+// Only one job may run on a worker at a time.
+func (w *commandWorker) run(line string) (stdout, stderr string, exitCode int, err error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if _, err := io.WriteString(w.stdin, line+"\n"); err != nil {
+		return "", "", 0, fmt.Errorf("dispatching command to worker: %w", err)
+	}
+
+	sentinel, err := w.stdout.ReadString('\n')
+	if err != nil {
+		return "", "", 0, fmt.Errorf("reading worker result: %w", err)
+	}
+	exitCode, err = strconv.Atoi(strings.TrimSpace(sentinel))
+	if err != nil {
+		return "", "", 0, fmt.Errorf("parsing worker exit code %q: %w", sentinel, err)
+	}
+
+	outBytes, err := os.ReadFile(w.outPath)
+	if err != nil {
+		return "", "", exitCode, fmt.Errorf("reading worker stdout capture: %w", err)
+	}
+	errBytes, err := os.ReadFile(w.errPath)
+	if err != nil {
+		return "", "", exitCode, fmt.Errorf("reading worker stderr capture: %w", err)
+	}
+
+	return string(outBytes), string(errBytes), exitCode, nil
+}
+
+func (w *commandWorker) close() {
+	w.stdin.Close()
+	w.cmd.Wait()
+	os.RemoveAll(filepath.Dir(w.outPath))
+}
+
+// shellQuoteWorkerPath quotes a temp file path for safe embedding in
+// the worker's redirection script.
+func shellQuoteWorkerPath(path string) string {
+	return "'" + strings.ReplaceAll(path, "'", `'\''`) + "'"
+}
@@ -0,0 +1,73 @@
+package engine
+
+import "testing"
+
+func TestCommandWorkerPoolResizeGrowsAndShrinks(t *testing.T) {
+	pool, err := NewCommandWorkerPool(1)
+	if err != nil {
+		t.Fatalf("NewCommandWorkerPool() error = %v", err)
+	}
+	defer pool.Close()
+
+	if err := pool.Resize(3); err != nil {
+		t.Fatalf("Resize(3) error = %v", err)
+	}
+	if got := pool.Size(); got != 3 {
+		t.Errorf("Size() = %d, want 3", got)
+	}
+
+	if err := pool.Resize(1); err != nil {
+		t.Fatalf("Resize(1) error = %v", err)
+	}
+	if got := pool.Size(); got != 1 {
+		t.Errorf("Size() = %d, want 1", got)
+	}
+
+	stdout, _, exitCode, err := pool.Run("echo still-alive")
+	if err != nil {
+		t.Fatalf("Run() after resize error = %v", err)
+	}
+	if exitCode != 0 {
+		t.Errorf("exitCode = %d, want 0", exitCode)
+	}
+	_ = stdout
+}
+
+func TestCommandWorkerPoolResizeClampsBelowOne(t *testing.T) {
+	pool, err := NewCommandWorkerPool(2)
+	if err != nil {
+		t.Fatalf("NewCommandWorkerPool() error = %v", err)
+	}
+	defer pool.Close()
+
+	if err := pool.Resize(0); err != nil {
+		t.Fatalf("Resize(0) error = %v", err)
+	}
+	if got := pool.Size(); got != 1 {
+		t.Errorf("Size() = %d, want 1 (clamped)", got)
+	}
+}
+
+func TestNewAdaptiveBatchExecutorReportsConcurrencyLimit(t *testing.T) {
+	exec, err := NewAdaptiveBatchExecutor(1, 4, 1, 0)
+	if err != nil {
+		t.Fatalf("NewAdaptiveBatchExecutor() error = %v", err)
+	}
+	defer exec.Close()
+
+	if got := exec.ConcurrencyLimit(); got != 1 {
+		t.Errorf("ConcurrencyLimit() = %d, want 1", got)
+	}
+}
+
+func TestNewBatchExecutorHasNoConcurrencyController(t *testing.T) {
+	exec, err := NewBatchExecutor(1)
+	if err != nil {
+		t.Fatalf("NewBatchExecutor() error = %v", err)
+	}
+	defer exec.Close()
+
+	if got := exec.ConcurrencyLimit(); got != 0 {
+		t.Errorf("ConcurrencyLimit() = %d, want 0 for a fixed-size executor", got)
+	}
+}
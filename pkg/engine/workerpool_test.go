@@ -0,0 +1,74 @@
+package engine
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCommandWorkerPoolRunCapturesOutputAndExitCode(t *testing.T) {
+	pool, err := NewCommandWorkerPool(2)
+	if err != nil {
+		t.Fatalf("NewCommandWorkerPool() error = %v", err)
+	}
+	defer pool.Close()
+
+	stdout, stderr, exitCode, err := pool.Run("echo hello")
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if exitCode != 0 {
+		t.Errorf("exitCode = %d, want 0", exitCode)
+	}
+	if strings.TrimSpace(stdout) != "hello" {
+		t.Errorf("stdout = %q, want %q", stdout, "hello")
+	}
+	if stderr != "" {
+		t.Errorf("stderr = %q, want empty", stderr)
+	}
+}
+
+func TestCommandWorkerPoolRunReportsNonZeroExitCode(t *testing.T) {
+	pool, err := NewCommandWorkerPool(1)
+	if err != nil {
+		t.Fatalf("NewCommandWorkerPool() error = %v", err)
+	}
+	defer pool.Close()
+
+	_, _, exitCode, err := pool.Run("(exit 7)")
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if exitCode != 7 {
+		t.Errorf("exitCode = %d, want 7", exitCode)
+	}
+}
+
+func TestCommandWorkerPoolReusesWorkersAcrossRuns(t *testing.T) {
+	pool, err := NewCommandWorkerPool(1)
+	if err != nil {
+		t.Fatalf("NewCommandWorkerPool() error = %v", err)
+	}
+	defer pool.Close()
+
+	for i := 0; i < 3; i++ {
+		stdout, _, exitCode, err := pool.Run("echo run")
+		if err != nil {
+			t.Fatalf("Run() iteration %d error = %v", i, err)
+		}
+		if exitCode != 0 || strings.TrimSpace(stdout) != "run" {
+			t.Errorf("iteration %d: stdout = %q, exitCode = %d", i, stdout, exitCode)
+		}
+	}
+}
+
+func TestNewCommandWorkerPoolDefaultsSizeToOne(t *testing.T) {
+	pool, err := NewCommandWorkerPool(0)
+	if err != nil {
+		t.Fatalf("NewCommandWorkerPool(0) error = %v", err)
+	}
+	defer pool.Close()
+
+	if len(pool.workers) != 1 {
+		t.Errorf("len(workers) = %d, want 1", len(pool.workers))
+	}
+}
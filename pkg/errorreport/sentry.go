@@ -0,0 +1,260 @@
+// Package errorreport captures engine panics, script failures, and
+// devtools crashes to a Sentry-compatible endpoint, so an operator
+// learns about them without tailing logs. It speaks the legacy
+// Sentry "store" HTTP API (a single JSON POST per event, authenticated
+// via the X-Sentry-Auth header), which every self-hosted
+// Sentry-compatible collector still accepts alongside the newer
+// envelope API.
+package errorreport
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	shodeerrors "gitee.com/com_818cloud/shode/pkg/errors"
+)
+
+// Reporter sends captured errors to a Sentry-compatible endpoint
+// parsed from a DSN. A nil *Reporter is a safe no-op, so callers can
+// report unconditionally and only pay for it once a real Reporter is
+// attached.
+type Reporter struct {
+	storeURL string
+	authKey  string
+	client   *http.Client
+}
+
+// NewReporter parses a Sentry DSN of the form
+// "https://<key>@<host>/<project_id>" and returns a Reporter that
+// posts events to that project's store endpoint.
+func NewReporter(dsn string) (*Reporter, error) {
+	u, err := url.Parse(dsn)
+	if err != nil {
+		return nil, fmt.Errorf("errorreport: invalid DSN: %w", err)
+	}
+	if u.User == nil || u.User.Username() == "" {
+		return nil, fmt.Errorf("errorreport: DSN missing public key")
+	}
+	projectID := strings.TrimPrefix(u.Path, "/")
+	if projectID == "" {
+		return nil, fmt.Errorf("errorreport: DSN missing project id")
+	}
+
+	return &Reporter{
+		storeURL: fmt.Sprintf("%s://%s/api/%s/store/", u.Scheme, u.Host, projectID),
+		authKey:  u.User.Username(),
+		client:   &http.Client{Timeout: 5 * time.Second},
+	}, nil
+}
+
+// sentryEvent mirrors just the fields of the Sentry event JSON schema
+// this package populates; everything else is left to the server's
+// defaults.
+type sentryEvent struct {
+	EventID   string                 `json:"event_id"`
+	Timestamp string                 `json:"timestamp"`
+	Level     string                 `json:"level"`
+	Message   string                 `json:"message"`
+	Exception sentryExceptionWrapper `json:"exception"`
+	Extra     map[string]interface{} `json:"extra,omitempty"`
+}
+
+type sentryExceptionWrapper struct {
+	Values []sentryException `json:"values"`
+}
+
+type sentryException struct {
+	Type       string           `json:"type"`
+	Value      string           `json:"value"`
+	Stacktrace sentryStacktrace `json:"stacktrace"`
+}
+
+type sentryStacktrace struct {
+	Frames []sentryFrame `json:"frames"`
+}
+
+type sentryFrame struct {
+	Filename string `json:"filename"`
+	Function string `json:"function"`
+	Lineno   int    `json:"lineno"`
+}
+
+// Capture reports err at level, with extra carried as additional
+// context (e.g. the script path or AST node a failure occurred at).
+// When err is a *shodeerrors.ExecutionError, its captured Stack and
+// Context travel with the event automatically. Extra (and any
+// ExecutionError.Context) is scrubbed of anything that looks like a
+// secret before it leaves the process; see Scrub. A nil Reporter, or
+// a nil err, makes Capture a no-op.
+func (r *Reporter) Capture(level string, err error, extra map[string]interface{}) error {
+	if r == nil || err == nil {
+		return nil
+	}
+
+	var frames []sentryFrame
+	merged := make(map[string]interface{}, len(extra))
+	for k, v := range extra {
+		merged[k] = v
+	}
+	if execErr, ok := err.(*shodeerrors.ExecutionError); ok {
+		frames = framesFromStack(execErr.Stack)
+		for k, v := range execErr.Context {
+			merged[k] = v
+		}
+	}
+
+	return r.send(level, err.Error(), frames, Scrub(merged))
+}
+
+// CapturePanic reports a recovered panic value and the stack captured
+// at the recover() site (typically debug.Stack()), along with extra
+// context. Callers use it from a deferred recover() so an engine
+// panic or a devtools crash is reported before the process decides
+// whether to re-panic.
+func (r *Reporter) CapturePanic(recovered interface{}, stack []byte, extra map[string]interface{}) error {
+	if r == nil || recovered == nil {
+		return nil
+	}
+	frames := framesFromStack(strings.Split(string(stack), "\n"))
+	return r.send("fatal", fmt.Sprintf("%v", recovered), frames, Scrub(extra))
+}
+
+func (r *Reporter) send(level, message string, frames []sentryFrame, extra map[string]interface{}) error {
+	event := sentryEvent{
+		EventID:   eventID(),
+		Timestamp: time.Now().UTC().Format(time.RFC3339),
+		Level:     level,
+		Message:   message,
+		Exception: sentryExceptionWrapper{
+			Values: []sentryException{{
+				Type:       "ExecutionError",
+				Value:      message,
+				Stacktrace: sentryStacktrace{Frames: frames},
+			}},
+		},
+		Extra: extra,
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("errorreport: marshaling event: %w", err)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, r.storeURL, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Sentry-Auth", fmt.Sprintf("Sentry sentry_version=7, sentry_key=%s", r.authKey))
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("errorreport: sending event: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("errorreport: collector returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// framesFromStack converts plain "file:line function" lines (the
+// format pkg/errors.captureStack and runtime/debug.Stack both
+// approximate) into Sentry frames, best-effort - a line it can't
+// parse is still sent, as a frame with only Function set, rather than
+// dropped.
+func framesFromStack(lines []string) []sentryFrame {
+	frames := make([]sentryFrame, 0, len(lines))
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		frames = append(frames, parseFrame(line))
+	}
+	return frames
+}
+
+func parseFrame(line string) sentryFrame {
+	parts := strings.SplitN(line, " ", 2)
+	loc := parts[0]
+	function := ""
+	if len(parts) > 1 {
+		function = parts[1]
+	}
+
+	filename := loc
+	lineno := 0
+	if idx := strings.LastIndex(loc, ":"); idx != -1 {
+		if n, err := strconv.Atoi(loc[idx+1:]); err == nil {
+			filename = loc[:idx]
+			lineno = n
+		}
+	}
+
+	return sentryFrame{Filename: filename, Function: function, Lineno: lineno}
+}
+
+// eventID generates a 32-hex-character id, the format Sentry's event
+// schema requires, without pulling in a UUID dependency.
+func eventID() string {
+	return fmt.Sprintf("%016x%08x", time.Now().UnixNano(), os.Getpid())
+}
+
+// scrubbedKeyPatterns matches the names of context/environment keys
+// whose value Scrub redacts outright, case-insensitively.
+var scrubbedKeyPatterns = []string{"key", "secret", "token", "password", "passwd", "auth", "credential", "dsn"}
+
+// Scrub returns a copy of extra with any value whose key looks like a
+// secret (API key, token, password, ...) replaced with "[scrubbed]",
+// so an engine environment snapshot attached as context doesn't leak
+// credentials to the error-reporting endpoint. Matching is
+// substring-based and case-insensitive. A map[string]string value
+// (e.g. an environment variable snapshot) is scrubbed entry by entry.
+func Scrub(extra map[string]interface{}) map[string]interface{} {
+	if extra == nil {
+		return nil
+	}
+	scrubbed := make(map[string]interface{}, len(extra))
+	for k, v := range extra {
+		if looksSecret(k) {
+			scrubbed[k] = "[scrubbed]"
+			continue
+		}
+		if env, ok := v.(map[string]string); ok {
+			scrubbed[k] = scrubEnv(env)
+			continue
+		}
+		scrubbed[k] = v
+	}
+	return scrubbed
+}
+
+func scrubEnv(env map[string]string) map[string]string {
+	scrubbed := make(map[string]string, len(env))
+	for k, v := range env {
+		if looksSecret(k) {
+			scrubbed[k] = "[scrubbed]"
+			continue
+		}
+		scrubbed[k] = v
+	}
+	return scrubbed
+}
+
+func looksSecret(key string) bool {
+	lower := strings.ToLower(key)
+	for _, pattern := range scrubbedKeyPatterns {
+		if strings.Contains(lower, pattern) {
+			return true
+		}
+	}
+	return false
+}
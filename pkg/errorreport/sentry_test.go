@@ -0,0 +1,178 @@
+package errorreport
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	shodeerrors "gitee.com/com_818cloud/shode/pkg/errors"
+)
+
+func newTestReporter(t *testing.T, handler http.HandlerFunc) (*Reporter, *httptest.Server) {
+	server := httptest.NewServer(handler)
+	dsn := fmt.Sprintf("http://testkey@%s/42", server.Listener.Addr().String())
+	r, err := NewReporter(dsn)
+	if err != nil {
+		t.Fatalf("NewReporter returned error: %v", err)
+	}
+	return r, server
+}
+
+func TestNewReporterRejectsDSNWithoutKeyOrProject(t *testing.T) {
+	if _, err := NewReporter("http://example.com/42"); err == nil {
+		t.Error("expected error for DSN missing public key")
+	}
+	if _, err := NewReporter("http://key@example.com"); err == nil {
+		t.Error("expected error for DSN missing project id")
+	}
+}
+
+func TestCapturePostsEventWithAuthHeaderAndMessage(t *testing.T) {
+	var received sentryEvent
+	var authHeader string
+
+	reporter, server := newTestReporter(t, func(w http.ResponseWriter, r *http.Request) {
+		authHeader = r.Header.Get("X-Sentry-Auth")
+		if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+			t.Errorf("failed to decode event: %v", err)
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+	defer server.Close()
+
+	err := reporter.Capture("error", fmt.Errorf("boom"), map[string]interface{}{"script": "deploy.sh"})
+	if err != nil {
+		t.Fatalf("Capture returned error: %v", err)
+	}
+
+	if authHeader == "" || !contains(authHeader, "testkey") {
+		t.Errorf("expected auth header to carry the DSN key, got %q", authHeader)
+	}
+	if received.Message != "boom" {
+		t.Errorf("expected message %q, got %q", "boom", received.Message)
+	}
+	if received.Extra["script"] != "deploy.sh" {
+		t.Errorf("expected extra context to carry through, got %v", received.Extra)
+	}
+}
+
+func TestCaptureIncludesExecutionErrorStackAndContext(t *testing.T) {
+	var received sentryEvent
+
+	reporter, server := newTestReporter(t, func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&received)
+		w.WriteHeader(http.StatusOK)
+	})
+	defer server.Close()
+
+	execErr := shodeerrors.NewExecutionError(shodeerrors.ErrExecutionFailed, "script failed").
+		WithContext("line", 12)
+
+	if err := reporter.Capture("error", execErr, nil); err != nil {
+		t.Fatalf("Capture returned error: %v", err)
+	}
+
+	if len(received.Exception.Values) == 0 || len(received.Exception.Values[0].Stacktrace.Frames) == 0 {
+		t.Error("expected stack frames captured from the ExecutionError")
+	}
+	if received.Extra["line"] != float64(12) {
+		t.Errorf("expected the ExecutionError's context to be attached, got %v", received.Extra)
+	}
+}
+
+func TestCaptureScrubsSecretsFromExtra(t *testing.T) {
+	var received sentryEvent
+
+	reporter, server := newTestReporter(t, func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&received)
+		w.WriteHeader(http.StatusOK)
+	})
+	defer server.Close()
+
+	extra := map[string]interface{}{
+		"api_key": "sk-super-secret",
+		"script":  "deploy.sh",
+		"env":     map[string]string{"DB_PASSWORD": "hunter2", "HOME": "/root"},
+	}
+
+	if err := reporter.Capture("error", fmt.Errorf("boom"), extra); err != nil {
+		t.Fatalf("Capture returned error: %v", err)
+	}
+
+	if received.Extra["api_key"] != "[scrubbed]" {
+		t.Errorf("expected api_key to be scrubbed, got %v", received.Extra["api_key"])
+	}
+	if received.Extra["script"] != "deploy.sh" {
+		t.Errorf("expected non-secret extra to pass through, got %v", received.Extra["script"])
+	}
+	env, ok := received.Extra["env"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("expected env to decode as a map, got %T", received.Extra["env"])
+	}
+	if env["DB_PASSWORD"] != "[scrubbed]" {
+		t.Errorf("expected DB_PASSWORD to be scrubbed, got %v", env["DB_PASSWORD"])
+	}
+	if env["HOME"] != "/root" {
+		t.Errorf("expected non-secret env vars to pass through, got %v", env["HOME"])
+	}
+}
+
+func TestCapturePanicReportsRecoveredValueAndStack(t *testing.T) {
+	var received sentryEvent
+
+	reporter, server := newTestReporter(t, func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&received)
+		w.WriteHeader(http.StatusOK)
+	})
+	defer server.Close()
+
+	stack := []byte("pkg/engine/engine.go:123 gitee.com/com_818cloud/shode/pkg/engine.(*ExecutionEngine).Execute\n")
+	err := reporter.CapturePanic("index out of range", stack, map[string]interface{}{"script": "deploy.sh"})
+	if err != nil {
+		t.Fatalf("CapturePanic returned error: %v", err)
+	}
+
+	if received.Level != "fatal" {
+		t.Errorf("expected level %q, got %q", "fatal", received.Level)
+	}
+	if received.Message != "index out of range" {
+		t.Errorf("expected message %q, got %q", "index out of range", received.Message)
+	}
+	if len(received.Exception.Values[0].Stacktrace.Frames) == 0 {
+		t.Error("expected at least one stack frame")
+	}
+}
+
+func TestNilReporterMethodsAreNoops(t *testing.T) {
+	var r *Reporter
+	if err := r.Capture("error", fmt.Errorf("boom"), nil); err != nil {
+		t.Errorf("expected nil Reporter Capture to be a no-op, got %v", err)
+	}
+	if err := r.CapturePanic("boom", nil, nil); err != nil {
+		t.Errorf("expected nil Reporter CapturePanic to be a no-op, got %v", err)
+	}
+}
+
+func TestCaptureOnCollectorErrorReturnsError(t *testing.T) {
+	reporter, server := newTestReporter(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+	defer server.Close()
+
+	if err := reporter.Capture("error", fmt.Errorf("boom"), nil); err == nil {
+		t.Error("expected an error when the collector returns a non-2xx status")
+	}
+}
+
+func contains(s, substr string) bool {
+	return len(s) >= len(substr) && (func() bool {
+		for i := 0; i+len(substr) <= len(s); i++ {
+			if s[i:i+len(substr)] == substr {
+				return true
+			}
+		}
+		return false
+	})()
+}
@@ -0,0 +1,118 @@
+// Package events provides a small in-process publish/subscribe bus for
+// runtime events - a command starting or finishing, a background job
+// changing state, a cache entry being evicted, a security check denying
+// a command. It exists so those signals have one place to be published
+// and subscribed to (the TUI, a WebSocket bridge, the audit logger)
+// instead of each feature growing its own bespoke hook/callback.
+//
+// The subscription model mirrors pkg/websocket's channel registry:
+// Subscribe returns a buffered channel and an unsubscribe func, and
+// Publish delivers to every subscriber of that Type without blocking on
+// a slow or absent reader.
+package events
+
+import (
+	"sync"
+	"time"
+)
+
+// Type identifies the kind of event flowing through a Bus. Subscribers
+// register for a specific Type, which is what makes the subscription
+// "typed" - a TUI watching CommandFinished never sees a CacheEvicted
+// event land on the same channel.
+type Type string
+
+const (
+	// CommandStarted fires right before the execution engine runs a
+	// command, once it has passed the security check.
+	CommandStarted Type = "command.started"
+	// CommandFinished fires after a command finishes, successfully or
+	// not.
+	CommandFinished Type = "command.finished"
+	// JobStateChanged fires when a background job changes state (e.g.
+	// started).
+	JobStateChanged Type = "job.state_changed"
+	// CacheEvicted fires each time the in-memory cache evicts an entry
+	// to stay within its byte budget.
+	CacheEvicted Type = "cache.evicted"
+	// SecurityDenied fires when the security checker refuses to run a
+	// command.
+	SecurityDenied Type = "security.denied"
+)
+
+// Event is the unit of data a Bus delivers to subscribers. Data carries
+// the event-specific payload (e.g. a command name, a job ID, an evicted
+// cache key) as a plain map, the same shape pkg/security/audit.Event
+// uses for its own Metadata, so a subscriber can forward one straight
+// into the other without translation.
+type Event struct {
+	Type Type
+	Time time.Time
+	Data map[string]interface{}
+}
+
+// Bus is an in-process, typed publish/subscribe registry. The zero
+// value is not usable; create one with NewBus. A *Bus is safe for
+// concurrent use.
+type Bus struct {
+	mu          sync.Mutex
+	subscribers map[Type]map[chan Event]bool
+}
+
+// NewBus creates an empty Bus ready to accept subscribers and
+// publishes.
+func NewBus() *Bus {
+	return &Bus{subscribers: make(map[Type]map[chan Event]bool)}
+}
+
+// Subscribe registers a listener for every event of type t published
+// after this call. The returned channel is buffered so a burst of
+// events doesn't block the publisher; a subscriber that falls behind
+// drops events rather than stalling Publish. The returned func cancels
+// the subscription; callers must call it when done to avoid leaking the
+// channel.
+func (b *Bus) Subscribe(t Type) (<-chan Event, func()) {
+	ch := make(chan Event, 16)
+
+	b.mu.Lock()
+	if b.subscribers[t] == nil {
+		b.subscribers[t] = make(map[chan Event]bool)
+	}
+	b.subscribers[t][ch] = true
+	b.mu.Unlock()
+
+	unsubscribe := func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		if _, ok := b.subscribers[t][ch]; !ok {
+			return
+		}
+		delete(b.subscribers[t], ch)
+		if len(b.subscribers[t]) == 0 {
+			delete(b.subscribers, t)
+		}
+		close(ch)
+	}
+	return ch, unsubscribe
+}
+
+// Publish delivers an event of type t, carrying data, to every current
+// subscriber of t. Publish never blocks: a subscriber whose channel is
+// full simply misses the event. A nil Bus is a safe no-op, so callers
+// that haven't had a bus attached can publish unconditionally.
+func (b *Bus) Publish(t Type, data map[string]interface{}) {
+	if b == nil {
+		return
+	}
+
+	event := Event{Type: t, Time: time.Now(), Data: data}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for ch := range b.subscribers[t] {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+}
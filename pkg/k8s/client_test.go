@@ -0,0 +1,191 @@
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testClient(server *httptest.Server) *Client {
+	return &Client{httpClient: server.Client(), baseURL: server.URL}
+}
+
+func TestApplyCreatesMissingResource(t *testing.T) {
+	var created bool
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/deployments/web"):
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"message":"deployments.apps \"web\" not found"}`))
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/deployments"):
+			created = true
+			w.Write([]byte(`{"kind":"Deployment","metadata":{"name":"web"}}`))
+		default:
+			t.Fatalf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := testClient(server)
+	manifest := []byte(`
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+spec:
+  replicas: 2
+`)
+
+	obj, err := client.Apply(context.Background(), "default", manifest)
+	if err != nil {
+		t.Fatalf("Apply returned error: %v", err)
+	}
+	if !created {
+		t.Fatalf("expected Apply to create the missing deployment")
+	}
+	if obj["kind"] != "Deployment" {
+		t.Errorf("expected decoded kind Deployment, got %v", obj["kind"])
+	}
+}
+
+func TestApplyPatchesExistingResource(t *testing.T) {
+	var patched bool
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/configmaps/app-config"):
+			w.Write([]byte(`{"kind":"ConfigMap","metadata":{"name":"app-config"}}`))
+		case r.Method == http.MethodPatch && strings.HasSuffix(r.URL.Path, "/configmaps/app-config"):
+			if r.Header.Get("Content-Type") != "application/merge-patch+json" {
+				t.Fatalf("expected merge-patch content type, got %q", r.Header.Get("Content-Type"))
+			}
+			patched = true
+			w.Write([]byte(`{"kind":"ConfigMap","metadata":{"name":"app-config"}}`))
+		default:
+			t.Fatalf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := testClient(server)
+	manifest := []byte(`
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: app-config
+  namespace: staging
+data:
+  key: value
+`)
+
+	if _, err := client.Apply(context.Background(), "default", manifest); err != nil {
+		t.Fatalf("Apply returned error: %v", err)
+	}
+	if !patched {
+		t.Fatalf("expected Apply to patch the existing configmap")
+	}
+}
+
+func TestGetDecodesResource(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/namespaces/default/pods/web-0" {
+			t.Fatalf("unexpected path %s", r.URL.Path)
+		}
+		w.Write([]byte(`{"kind":"Pod","status":{"phase":"Running"}}`))
+	}))
+	defer server.Close()
+
+	client := testClient(server)
+	obj, err := client.Get(context.Background(), "default", "Pod", "web-0")
+	if err != nil {
+		t.Fatalf("Get returned error: %v", err)
+	}
+	status, _ := obj["status"].(map[string]interface{})
+	if status["phase"] != "Running" {
+		t.Errorf("expected phase Running, got %v", status["phase"])
+	}
+}
+
+func TestGetRejectsUnsupportedKind(t *testing.T) {
+	client := &Client{}
+	if _, err := client.Get(context.Background(), "default", "Widget", "foo"); err == nil {
+		t.Fatalf("expected an error for an unsupported kind")
+	}
+}
+
+func TestLogsStripsNothingFromPlainBody(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/namespaces/default/pods/web-0/log" {
+			t.Fatalf("unexpected path %s", r.URL.Path)
+		}
+		if r.URL.Query().Get("container") != "app" {
+			t.Fatalf("expected container=app query param, got %q", r.URL.Query().Get("container"))
+		}
+		w.Write([]byte("listening on :8080\n"))
+	}))
+	defer server.Close()
+
+	client := testClient(server)
+	logs, err := client.Logs(context.Background(), "default", "web-0", "app")
+	if err != nil {
+		t.Fatalf("Logs returned error: %v", err)
+	}
+	if logs != "listening on :8080\n" {
+		t.Errorf("unexpected logs: %q", logs)
+	}
+}
+
+func TestWaitForReturnsOnceConditionMet(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls < 2 {
+			w.Write([]byte(`{"kind":"Pod","status":{"phase":"Pending"}}`))
+			return
+		}
+		w.Write([]byte(`{"kind":"Pod","status":{"phase":"Running"}}`))
+	}))
+	defer server.Close()
+
+	client := testClient(server)
+	err := client.WaitFor(context.Background(), "default", "Pod", "web-0", "Ready", 5*time.Second)
+	if err != nil {
+		t.Fatalf("WaitFor returned error: %v", err)
+	}
+	if calls < 2 {
+		t.Errorf("expected WaitFor to poll more than once, got %d calls", calls)
+	}
+}
+
+func TestWaitForTimesOut(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"kind":"Pod","status":{"phase":"Pending"}}`))
+	}))
+	defer server.Close()
+
+	client := testClient(server)
+	err := client.WaitFor(context.Background(), "default", "Pod", "web-0", "Ready", 10*time.Millisecond)
+	if err == nil || !strings.Contains(err.Error(), "timed out") {
+		t.Fatalf("expected a timeout error, got %v", err)
+	}
+}
+
+func TestAPIErrorSurfacesServerMessage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		body, _ := json.Marshal(map[string]string{"message": "forbidden: User cannot get resource"})
+		w.Write(body)
+	}))
+	defer server.Close()
+
+	client := testClient(server)
+	_, err := client.Get(context.Background(), "default", "Pod", "web-0")
+	if err == nil || !strings.Contains(err.Error(), "forbidden: User cannot get resource") {
+		t.Fatalf("expected forbidden error with server message, got %v", err)
+	}
+}
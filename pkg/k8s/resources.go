@@ -0,0 +1,141 @@
+package k8s
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// manifestObject is the decoded TypeMeta/ObjectMeta of a manifest, plus
+// its full body so Apply can round-trip spec fields it doesn't parse.
+type manifestObject struct {
+	APIVersion string `json:"apiVersion" yaml:"apiVersion"`
+	Kind       string `json:"kind" yaml:"kind"`
+	Name       string `json:"-" yaml:"-"`
+	Namespace  string `json:"-" yaml:"-"`
+	raw        map[string]interface{}
+}
+
+// decodeManifest parses a single YAML or JSON manifest document into its
+// TypeMeta/ObjectMeta fields plus the full object body.
+func decodeManifest(manifest []byte) (*manifestObject, error) {
+	var raw map[string]interface{}
+	if err := yaml.Unmarshal(manifest, &raw); err != nil {
+		return nil, fmt.Errorf("parsing manifest: %w", err)
+	}
+
+	kind, _ := raw["kind"].(string)
+	apiVersion, _ := raw["apiVersion"].(string)
+	if kind == "" || apiVersion == "" {
+		return nil, fmt.Errorf("manifest is missing apiVersion or kind")
+	}
+
+	metadata, _ := raw["metadata"].(map[string]interface{})
+	name, _ := metadata["name"].(string)
+	if name == "" {
+		return nil, fmt.Errorf("manifest is missing metadata.name")
+	}
+	namespace, _ := metadata["namespace"].(string)
+
+	// yaml.Unmarshal into map[string]interface{} produces
+	// map[string]interface{} nodes already, but re-marshal through JSON
+	// once so nested maps decoded as map[interface{}]interface{} by some
+	// YAML parsers can't leak through - gopkg.in/yaml.v3 already
+	// produces string-keyed maps, so this is a cheap safety net.
+	normalized, err := normalizeViaJSON(raw)
+	if err != nil {
+		return nil, fmt.Errorf("normalizing manifest: %w", err)
+	}
+
+	return &manifestObject{APIVersion: apiVersion, Kind: kind, Name: name, Namespace: namespace, raw: normalized}, nil
+}
+
+func normalizeViaJSON(v map[string]interface{}) (map[string]interface{}, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var out map[string]interface{}
+	if err := json.Unmarshal(data, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// MarshalJSON lets manifestObject be passed directly to json.Marshal,
+// emitting the full manifest body rather than just its TypeMeta fields.
+func (m *manifestObject) MarshalJSON() ([]byte, error) {
+	return json.Marshal(m.raw)
+}
+
+// resourcePath describes where a resource kind lives in the API server's
+// URL space: its group/version prefix, plural resource name, and
+// whether it's namespaced.
+type resourcePath struct {
+	prefix     string // e.g. "/api/v1" or "/apis/apps/v1"
+	resource   string // e.g. "pods", "deployments"
+	namespaced bool
+	namespace  string
+}
+
+func (r resourcePath) collectionPath() string {
+	if r.namespaced {
+		return fmt.Sprintf("%s/namespaces/%s/%s", r.prefix, r.namespace, r.resource)
+	}
+	return fmt.Sprintf("%s/%s", r.prefix, r.resource)
+}
+
+func (r resourcePath) itemPath(name string) string {
+	return r.collectionPath() + "/" + name
+}
+
+// kindTable maps the Kind names this package supports to their REST
+// location, covering the resource kinds deployment scripts most
+// commonly apply, get, or wait on.
+var kindTable = map[string]struct {
+	prefix     string
+	resource   string
+	namespaced bool
+}{
+	"Pod":         {"/api/v1", "pods", true},
+	"Service":     {"/api/v1", "services", true},
+	"ConfigMap":   {"/api/v1", "configmaps", true},
+	"Secret":      {"/api/v1", "secrets", true},
+	"Namespace":   {"/api/v1", "namespaces", false},
+	"Deployment":  {"/apis/apps/v1", "deployments", true},
+	"StatefulSet": {"/apis/apps/v1", "statefulsets", true},
+	"DaemonSet":   {"/apis/apps/v1", "daemonsets", true},
+	"Job":         {"/apis/batch/v1", "jobs", true},
+	"CronJob":     {"/apis/batch/v1", "cronjobs", true},
+	"Ingress":     {"/apis/networking.k8s.io/v1", "ingresses", true},
+}
+
+// resourcePathForKind resolves a Kind name to its REST location.
+func resourcePathForKind(kind, namespace string) (resourcePath, error) {
+	entry, ok := kindTable[kind]
+	if !ok {
+		return resourcePath{}, fmt.Errorf("unsupported resource kind %q (supported: %s)", kind, supportedKinds())
+	}
+	return resourcePath{prefix: entry.prefix, resource: entry.resource, namespaced: entry.namespaced, namespace: namespace}, nil
+}
+
+// resourcePathFor resolves a decoded manifest to its REST location,
+// preferring the namespace set on the object itself over the namespace
+// argument when both are present.
+func resourcePathFor(obj *manifestObject, namespace string) (resourcePath, error) {
+	ns := namespace
+	if obj.Namespace != "" {
+		ns = obj.Namespace
+	}
+	return resourcePathForKind(obj.Kind, ns)
+}
+
+func supportedKinds() string {
+	names := make([]string, 0, len(kindTable))
+	for k := range kindTable {
+		names = append(names, k)
+	}
+	return strings.Join(names, ", ")
+}
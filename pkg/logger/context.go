@@ -0,0 +1,32 @@
+package logger
+
+import (
+	"context"
+
+	contextx "gitee.com/com_818cloud/shode/pkg/context"
+)
+
+// FromContext returns a LoggerContext carrying the trace ID, span ID,
+// and script execution ID that pkg/context's With* helpers attached to
+// ctx - the identifiers the engine injects as a script runs - so every
+// log line written through it can be correlated back to that run. Any
+// identifier ctx doesn't carry is simply left unset.
+func (l *Logger) FromContext(ctx context.Context) *LoggerContext {
+	lc := &LoggerContext{
+		logger:  l,
+		fields:  make(map[string]interface{}),
+		traceID: contextx.GetTraceID(ctx),
+	}
+	if spanID := contextx.GetSpanID(ctx); spanID != "" {
+		lc.fields["span_id"] = spanID
+	}
+	if executionID := contextx.GetScriptExecutionID(ctx); executionID != "" {
+		lc.fields["script_execution_id"] = executionID
+	}
+	return lc
+}
+
+// FromContext is the package-level counterpart for DefaultLogger.
+func FromContext(ctx context.Context) *LoggerContext {
+	return DefaultLogger.FromContext(ctx)
+}
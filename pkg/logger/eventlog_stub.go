@@ -0,0 +1,14 @@
+//go:build !windows
+
+package logger
+
+import (
+	"fmt"
+	"io"
+)
+
+// newEventLogWriter is the stub for non-Windows platforms, which have
+// no Windows Event Log to report to.
+func newEventLogWriter(config Config) (io.WriteCloser, error) {
+	return nil, fmt.Errorf("logger: Windows Event Log output is only supported on windows")
+}
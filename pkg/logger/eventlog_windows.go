@@ -0,0 +1,75 @@
+//go:build windows
+
+package logger
+
+import (
+	"fmt"
+	"io"
+	"syscall"
+	"unsafe"
+)
+
+var (
+	modAdvapi32               = syscall.NewLazyDLL("advapi32.dll")
+	procRegisterEventSourceW  = modAdvapi32.NewProc("RegisterEventSourceW")
+	procDeregisterEventSource = modAdvapi32.NewProc("DeregisterEventSource")
+	procReportEventW          = modAdvapi32.NewProc("ReportEventW")
+)
+
+// eventLogInfo is EVENTLOG_INFORMATION_TYPE - the closest match for our
+// LogEntry, which already carries its own severity in the message text.
+const eventLogInfo = 0x0004
+
+// eventLogWriter reports log entries to the local machine's "Application"
+// event log under a registered source name.
+type eventLogWriter struct {
+	handle syscall.Handle
+}
+
+// newEventLogWriter registers config.EventLogSource as an event source
+// and returns a writer that reports entries to it.
+func newEventLogWriter(config Config) (io.WriteCloser, error) {
+	source := config.EventLogSource
+	if source == "" {
+		source = "shode"
+	}
+	sourcePtr, err := syscall.UTF16PtrFromString(source)
+	if err != nil {
+		return nil, fmt.Errorf("logger: invalid event log source: %w", err)
+	}
+
+	h, _, callErr := procRegisterEventSourceW.Call(0, uintptr(unsafe.Pointer(sourcePtr)))
+	if h == 0 {
+		return nil, fmt.Errorf("logger: RegisterEventSource failed: %w", callErr)
+	}
+	return &eventLogWriter{handle: syscall.Handle(h)}, nil
+}
+
+func (w *eventLogWriter) Write(p []byte) (int, error) {
+	msgPtr, err := syscall.UTF16PtrFromString(string(p))
+	if err != nil {
+		return 0, err
+	}
+	strings := []*uint16{msgPtr}
+
+	ret, _, callErr := procReportEventW.Call(
+		uintptr(w.handle),
+		uintptr(eventLogInfo),
+		0, // event category
+		0, // event ID
+		0, // user SID
+		1, // number of strings
+		0, // raw data size
+		uintptr(unsafe.Pointer(&strings[0])),
+		0, // raw data
+	)
+	if ret == 0 {
+		return 0, fmt.Errorf("logger: ReportEvent failed: %w", callErr)
+	}
+	return len(p), nil
+}
+
+func (w *eventLogWriter) Close() error {
+	procDeregisterEventSource.Call(uintptr(w.handle))
+	return nil
+}
@@ -0,0 +1,52 @@
+package logger
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net"
+)
+
+// journaldSocket is where systemd-journald listens for the native
+// journal protocol.
+const journaldSocket = "/run/systemd/journal/socket"
+
+// journaldWriter sends log entries to journald over its native
+// datagram socket. Only single-line fields are used since LogEntry's
+// text/JSON rendering never embeds newlines, so the protocol's
+// length-prefixed form for multi-line values isn't needed.
+type journaldWriter struct {
+	conn net.Conn
+	tag  string
+}
+
+// newJournaldWriter dials the local journald socket, tagging every
+// message's SYSLOG_IDENTIFIER field with config.SyslogTag.
+func newJournaldWriter(config Config) (io.WriteCloser, error) {
+	conn, err := net.Dial("unixgram", journaldSocket)
+	if err != nil {
+		return nil, fmt.Errorf("logger: dial journald socket: %w", err)
+	}
+	tag := config.SyslogTag
+	if tag == "" {
+		tag = "shode"
+	}
+	return &journaldWriter{conn: conn, tag: tag}, nil
+}
+
+func (w *journaldWriter) Write(p []byte) (int, error) {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "SYSLOG_IDENTIFIER=%s\n", w.tag)
+	buf.WriteString("MESSAGE=")
+	buf.Write(bytes.TrimRight(p, "\n"))
+	buf.WriteByte('\n')
+
+	if _, err := w.conn.Write(buf.Bytes()); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+func (w *journaldWriter) Close() error {
+	return w.conn.Close()
+}
@@ -3,8 +3,9 @@
 // 日志系统特点：
 //   - 结构化日志格式 (JSON)
 //   - 多日志级别 (DEBUG/INFO/WARN/ERROR/FATAL)
-//   - 多输出目标 (控制台/文件/syslog)
+//   - 多输出目标 (控制台/文件/syslog/journald/Windows 事件日志)
 //   - 日志轮转 (按大小/时间)
+//   - 异步缓冲写入，支持阻塞/丢弃两种背压策略
 //   - 请求追踪集成
 //   - 性能监控
 //
@@ -27,6 +28,7 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"sort"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -104,24 +106,58 @@ const (
 	FileOutput
 	// MultiOutput 多目标输出
 	MultiOutput
+	// SyslogOutput 系统日志 (syslog)，仅支持类 Unix 系统
+	SyslogOutput
+	// JournaldOutput systemd-journald，通过本地 socket 发送日志
+	JournaldOutput
+	// EventLogOutput Windows 事件日志，仅支持 Windows 系统
+	EventLogOutput
+	// OTLPOutput 通过 OTLP/HTTP 将日志导出到与 trace/metrics 相同的后端
+	OTLPOutput
 )
 
 // Config 日志配置
 type Config struct {
-	Level          LogLevel     // 日志级别
-	Format         OutputFormat // 输出格式
-	Output         OutputTarget // 输出目标
-	FilePath       string       // 日志文件路径
-	MaxSize        int64        // 最大文件大小 (字节)
-	MaxBackups     int          // 最大备份数
-	MaxAge         int          // 最大保留天数
-	Compress       bool         // 是否压缩旧日志
-	CallerSkip     int          // 调用栈跳过层数
-	EnableTrace    bool         // 启用请求追踪
-	EnableCaller   bool         // 启用调用位置
-	EnableStackTrace bool       // 错误时打印堆栈
+	Level          LogLevel      // 日志级别
+	Format         OutputFormat  // 输出格式
+	Output         OutputTarget  // 输出目标
+	FilePath       string        // 日志文件路径
+	MaxSize        int64         // 最大文件大小 (字节)
+	RotateInterval time.Duration // 基于时间的轮转间隔，0 表示不启用
+	MaxBackups     int           // 最大备份数
+	MaxAge         int           // 最大保留天数
+	Compress       bool          // 是否压缩旧日志
+	CallerSkip     int           // 调用栈跳过层数
+	EnableTrace    bool          // 启用请求追踪
+	EnableCaller   bool          // 启用调用位置
+	EnableStackTrace bool        // 错误时打印堆栈
+	SyslogTag      string        // syslog/journald 标识符，默认 "shode"
+	EventLogSource string        // Windows 事件日志的事件源名称，默认 "shode"
+	Async          bool               // 启用异步缓冲日志，写入不阻塞调用方
+	QueueSize      int                // 异步队列容量，默认 defaultAsyncQueueSize
+	Backpressure   BackpressurePolicy // 异步队列满时的处理策略
+	OTLPEndpoint   string             // OTLP/HTTP 日志接收端点，如 http://localhost:4318/v1/logs
+	OTLPResource   map[string]string  // 资源属性，如 {"service.name": "shode"}
+	OTLPTimeout    time.Duration      // 单次导出请求超时，默认 5s
 }
 
+// BackpressurePolicy controls what happens when an async logger's
+// queue is full.
+type BackpressurePolicy int
+
+const (
+	// BlockPolicy blocks the caller until the queue has room - no log
+	// entries are lost, but a slow sink can stall the caller.
+	BlockPolicy BackpressurePolicy = iota
+	// DropPolicy discards the entry and increments LoggerStats.DroppedLogs
+	// instead of blocking the caller.
+	DropPolicy
+)
+
+// defaultAsyncQueueSize is used when Config.Async is set but
+// QueueSize isn't.
+const defaultAsyncQueueSize = 1024
+
 // DefaultConfig 默认配置
 var DefaultConfig = Config{
 	Level:           INFO,
@@ -141,6 +177,7 @@ var DefaultConfig = Config{
 type LogEntry struct {
 	Time      string                 `json:"time"`
 	Level     string                 `json:"level"`
+	Module    string                 `json:"module,omitempty"`
 	Message   string                 `json:"msg"`
 	Fields    map[string]interface{} `json:"fields,omitempty"`
 	TraceID   string                 `json:"trace_id,omitempty"`
@@ -155,29 +192,44 @@ type LogEntry struct {
 
 // Logger 日志记录器
 type Logger struct {
-	config    Config
-	mu        sync.Mutex
-	file      *os.File
-	writer    io.Writer
+	config      Config
+	mu          sync.Mutex
+	file        *os.File
+	writer      io.Writer
+	closer      io.Closer // 非文件输出（syslog/journald/事件日志）持有的底层连接，Close 时一并关闭
 	atomicLevel atomic.Value // 存储 LogLevel
-	stats     *LoggerStats
+	stats       *LoggerStats
+	lastRotate  time.Time // 上一次轮转的时间，用于基于时间的轮转
+	asyncQueue  chan asyncItem // 非 nil 表示启用了异步模式
+	asyncWG     sync.WaitGroup
+	sampleCounters sync.Map // map[string]*uint64，按消息文本统计出现次数，供 *Sampled 方法使用
+}
+
+// asyncItem is what flows through a Logger's asyncQueue: either an
+// entry to write, or - when done is set - a flush barrier that the
+// worker closes once every item queued ahead of it has been written.
+type asyncItem struct {
+	entry *LogEntry
+	done  chan struct{}
 }
 
 // LoggerStats 日志统计
 type LoggerStats struct {
 	DebugLogs int64
 	InfoLogs  int64
-	WarnLogs  int64
-	ErrorLogs int64
-	FatalLogs int64
-	TotalLogs int64
+	WarnLogs    int64
+	ErrorLogs   int64
+	FatalLogs   int64
+	TotalLogs   int64
+	DroppedLogs int64 // 异步模式下因队列已满且策略为 DropPolicy 被丢弃的日志数
 }
 
 // NewLogger 创建新的日志记录器
 func NewLogger(config Config) *Logger {
 	l := &Logger{
-		config: config,
-		stats:  &LoggerStats{},
+		config:     config,
+		stats:      &LoggerStats{},
+		lastRotate: time.Now(),
 	}
 
 	// 如果所有值都是默认/零值，使用默认配置
@@ -213,14 +265,48 @@ func NewLogger(config Config) *Logger {
 		l.config.MaxAge = DefaultConfig.MaxAge
 	}
 
+	// OTLP 日志记录需要结构化的资源属性/字段，文本格式无法承载
+	if l.config.Output == OTLPOutput {
+		l.config.Format = JSONFormat
+	}
+
 	l.atomicLevel.Store(l.config.Level)
 
 	// 初始化输出
 	l.initOutput()
 
+	if l.config.Async {
+		l.startAsyncWorker()
+	}
+
 	return l
 }
 
+// startAsyncWorker launches the single goroutine that drains
+// asyncQueue, writing each entry in the order it was queued so
+// relative ordering within a Logger is preserved even though the
+// hot-path caller no longer waits for the write.
+func (l *Logger) startAsyncWorker() {
+	size := l.config.QueueSize
+	if size <= 0 {
+		size = defaultAsyncQueueSize
+	}
+	l.asyncQueue = make(chan asyncItem, size)
+
+	l.asyncWG.Add(1)
+	go func() {
+		defer l.asyncWG.Done()
+		for item := range l.asyncQueue {
+			if item.entry != nil {
+				l.writeEntrySync(item.entry)
+			}
+			if item.done != nil {
+				close(item.done)
+			}
+		}
+	}()
+}
+
 // initOutput 初始化输出
 func (l *Logger) initOutput() {
 	switch l.config.Output {
@@ -236,46 +322,92 @@ func (l *Logger) initOutput() {
 			}
 		}
 		l.writer = io.MultiWriter(writers...)
+	case SyslogOutput:
+		l.openRemoteSink("syslog", newSyslogWriter)
+	case JournaldOutput:
+		l.openRemoteSink("journald", newJournaldWriter)
+	case EventLogOutput:
+		l.openRemoteSink("event log", newEventLogWriter)
+	case OTLPOutput:
+		l.openRemoteSink("OTLP exporter", newOTLPWriter)
 	}
 }
 
-// openLogFile 打开日志文件
+// openRemoteSink opens a non-file sink (syslog/journald/Windows event
+// log) via open, falling back to stdout and logging the failure to
+// stderr if the sink is unavailable - e.g. no local syslog daemon, no
+// journald socket, or the wrong OS for the sink in question.
+func (l *Logger) openRemoteSink(name string, open func(Config) (io.WriteCloser, error)) {
+	w, err := open(l.config)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to open %s: %v\n", name, err)
+		l.writer = os.Stdout
+		return
+	}
+	l.writer = w
+	l.closer = w
+}
+
+// openLogFile 打开日志文件. Acquires l.mu itself; callers that already
+// hold it (such as rotateLogFile) must use openLogFileLocked instead.
 func (l *Logger) openLogFile() *os.File {
+	f := l.createLogFile()
+	if f == nil {
+		return nil
+	}
+
+	l.mu.Lock()
+	l.file = f
+	l.writer = f
+	l.mu.Unlock()
+
+	return f
+}
+
+// openLogFileLocked is openLogFile's counterpart for callers that
+// already hold l.mu.
+func (l *Logger) openLogFileLocked() *os.File {
+	f := l.createLogFile()
+	if f == nil {
+		return nil
+	}
+
+	l.file = f
+	l.writer = f
+	return f
+}
+
+// createLogFile creates the log directory if needed and opens the
+// configured log file for appending, without touching any Logger
+// fields - both openLogFile and openLogFileLocked build on this.
+func (l *Logger) createLogFile() *os.File {
 	if l.config.FilePath == "" {
 		return nil
 	}
 
-	// 确保目录存在
 	dir := filepath.Dir(l.config.FilePath)
 	if err := os.MkdirAll(dir, 0755); err != nil {
 		fmt.Fprintf(os.Stderr, "Failed to create log directory: %v\n", err)
 		return nil
 	}
 
-	// 打开文件
 	f, err := os.OpenFile(l.config.FilePath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Failed to open log file: %v\n", err)
 		return nil
 	}
 
-	l.mu.Lock()
-	l.file = f
-	l.writer = f
-	l.mu.Unlock()
-
 	return f
 }
 
-// rotateLogFile 轮转日志文件
+// rotateLogFile 轮转日志文件: 关闭当前文件、重命名为带时间戳的备份、
+// 按需压缩并清理旧备份，然后打开一个新文件。Callers must already hold
+// l.mu - it's called from writeEntry, which does.
 func (l *Logger) rotateLogFile() error {
 	if l.config.FilePath == "" {
 		return nil
 	}
 
-	l.mu.Lock()
-	defer l.mu.Unlock()
-
 	if l.file != nil {
 		l.file.Close()
 	}
@@ -298,7 +430,8 @@ func (l *Logger) rotateLogFile() error {
 	}
 
 	// 打开新文件
-	l.openLogFile()
+	l.openLogFileLocked()
+	l.lastRotate = time.Now()
 	return nil
 }
 
@@ -347,7 +480,6 @@ func (l *Logger) cleanupOldLogs() {
 		return
 	}
 
-	// 按修改时间排序
 	type fileInfo struct {
 		name    string
 		modTime time.Time
@@ -361,6 +493,25 @@ func (l *Logger) cleanupOldLogs() {
 		sortedFiles = append(sortedFiles, fileInfo{f, info.ModTime()})
 	}
 
+	// 按修改时间排序，最新的在前
+	sort.Slice(sortedFiles, func(i, j int) bool {
+		return sortedFiles[i].modTime.After(sortedFiles[j].modTime)
+	})
+
+	// 删除超过最大保留天数的文件
+	if l.config.MaxAge > 0 {
+		cutoff := time.Now().AddDate(0, 0, -l.config.MaxAge)
+		kept := sortedFiles[:0]
+		for _, f := range sortedFiles {
+			if f.modTime.Before(cutoff) {
+				os.Remove(f.name)
+				continue
+			}
+			kept = append(kept, f)
+		}
+		sortedFiles = kept
+	}
+
 	// 删除超过保留数量的文件
 	if len(sortedFiles) > l.config.MaxBackups {
 		for _, f := range sortedFiles[l.config.MaxBackups:] {
@@ -381,13 +532,27 @@ func (l *Logger) GetLevel() LogLevel {
 
 // log 内部日志方法
 func (l *Logger) log(level LogLevel, msg string, fields ...interface{}) {
-	if level < l.GetLevel() {
+	l.logWithModule(level, "", msg, fields...)
+}
+
+// logWithModule is log's module-aware counterpart, used by
+// ModuleLogger so a SetModuleLevel override - rather than l's own
+// level - gates whether the entry is emitted.
+func (l *Logger) logWithModule(level LogLevel, module string, msg string, fields ...interface{}) {
+	threshold := l.GetLevel()
+	if module != "" {
+		if override, ok := GetModuleLevel(module); ok {
+			threshold = override
+		}
+	}
+	if level < threshold {
 		return
 	}
 
 	entry := &LogEntry{
 		Time:    time.Now().Format(time.RFC3339Nano),
 		Level:   level.String(),
+		Module:  module,
 		Message: msg,
 		Fields:  make(map[string]interface{}),
 	}
@@ -439,14 +604,42 @@ func (l *Logger) log(level LogLevel, msg string, fields ...interface{}) {
 		atomic.AddInt64(&l.stats.FatalLogs, 1)
 	}
 
-	// FATAL 级别退出程序
+	// FATAL 级别退出程序前先确保已入队的日志（包括这一条）写出
 	if level == FATAL {
+		l.Flush()
 		os.Exit(1)
 	}
 }
 
-// writeEntry 写入日志条目
+// writeEntry dispatches entry to its sink. In async mode the entry is
+// handed to asyncQueue and this returns immediately; otherwise it's
+// written synchronously on the caller's goroutine.
 func (l *Logger) writeEntry(entry *LogEntry) {
+	if l.asyncQueue != nil {
+		l.enqueueAsync(entry)
+		return
+	}
+	l.writeEntrySync(entry)
+}
+
+// enqueueAsync applies Config.Backpressure when asyncQueue is full:
+// BlockPolicy waits for room, DropPolicy discards the entry and counts
+// it in LoggerStats.DroppedLogs instead of stalling the caller.
+func (l *Logger) enqueueAsync(entry *LogEntry) {
+	item := asyncItem{entry: entry}
+	if l.config.Backpressure == DropPolicy {
+		select {
+		case l.asyncQueue <- item:
+		default:
+			atomic.AddInt64(&l.stats.DroppedLogs, 1)
+		}
+		return
+	}
+	l.asyncQueue <- item
+}
+
+// writeEntrySync 写入日志条目
+func (l *Logger) writeEntrySync(entry *LogEntry) {
 	l.mu.Lock()
 	defer l.mu.Unlock()
 
@@ -463,6 +656,10 @@ func (l *Logger) writeEntry(entry *LogEntry) {
 	} else {
 		// 文本格式
 		output = []byte(fmt.Sprintf("[%s] %s %s", entry.Time, entry.Level, entry.Message))
+		if entry.Module != "" {
+			output = append(output, ' ')
+			output = append(output, fmt.Sprintf("module=%s", entry.Module)...)
+		}
 		for k, v := range entry.Fields {
 			output = append(output, ' ')
 			output = append(output, fmt.Sprintf("%s=%v", k, v)...)
@@ -474,12 +671,17 @@ func (l *Logger) writeEntry(entry *LogEntry) {
 		output = append(output, '\n')
 	}
 
-	// 检查文件大小并轮转
+	// 检查文件大小/时间间隔并轮转
 	if l.file != nil {
-		if info, err := l.file.Stat(); err == nil {
-			if info.Size() >= l.config.MaxSize {
-				l.rotateLogFile()
-			}
+		rotate := false
+		if info, err := l.file.Stat(); err == nil && info.Size() >= l.config.MaxSize {
+			rotate = true
+		}
+		if !rotate && l.config.RotateInterval > 0 && time.Since(l.lastRotate) >= l.config.RotateInterval {
+			rotate = true
+		}
+		if rotate {
+			l.rotateLogFile()
 		}
 	}
 
@@ -520,6 +722,136 @@ func (l *Logger) Fatal(msg string, fields ...interface{}) {
 	l.log(FATAL, msg, fields...)
 }
 
+// logSampled emits msg through the underlying level-specific log call
+// only on the every-th occurrence of that exact msg text logged
+// through l, dropping the rest - so a noisy loop logging the same
+// warning on every iteration emits 1 of every `every` instead of
+// flooding the sink. every <= 1 always logs.
+func (l *Logger) logSampled(level LogLevel, every int, msg string, fields ...interface{}) {
+	if every > 1 && !l.shouldSample(msg, every) {
+		return
+	}
+	l.log(level, msg, fields...)
+}
+
+// shouldSample reports whether this occurrence of key is the every-th
+// one seen by l, using a per-key counter that starts counting from the
+// first call. It's a fixed-window sampler: occurrences 1, every+1,
+// 2*every+1, ... are kept.
+func (l *Logger) shouldSample(key string, every int) bool {
+	counterAny, _ := l.sampleCounters.LoadOrStore(key, new(uint64))
+	counter := counterAny.(*uint64)
+	n := atomic.AddUint64(counter, 1)
+	return (n-1)%uint64(every) == 0
+}
+
+// DebugSampled 记录调试信息，每 every 次相同消息只记录 1 次
+func (l *Logger) DebugSampled(every int, msg string, fields ...interface{}) {
+	l.logSampled(DEBUG, every, msg, fields...)
+}
+
+// InfoSampled 记录一般信息，每 every 次相同消息只记录 1 次
+func (l *Logger) InfoSampled(every int, msg string, fields ...interface{}) {
+	l.logSampled(INFO, every, msg, fields...)
+}
+
+// WarnSampled 记录警告信息，每 every 次相同消息只记录 1 次
+func (l *Logger) WarnSampled(every int, msg string, fields ...interface{}) {
+	l.logSampled(WARN, every, msg, fields...)
+}
+
+// ErrorSampled 记录错误信息，每 every 次相同消息只记录 1 次
+func (l *Logger) ErrorSampled(every int, msg string, fields ...interface{}) {
+	l.logSampled(ERROR, every, msg, fields...)
+}
+
+// With returns a log context carrying the given key/value pairs, e.g.
+// logger.With("request_id", id).Info("handled request"). A trailing
+// key without a value is dropped.
+func (l *Logger) With(keysAndValues ...interface{}) *LoggerContext {
+	fields := make(map[string]interface{}, len(keysAndValues)/2)
+	for i := 0; i+1 < len(keysAndValues); i += 2 {
+		fields[fmt.Sprintf("%v", keysAndValues[i])] = keysAndValues[i+1]
+	}
+	return &LoggerContext{
+		logger:  l,
+		fields:  fields,
+		traceID: generateTraceID(),
+	}
+}
+
+// moduleLevels holds per-module level overrides set by SetModuleLevel,
+// keyed by module name. A ModuleLogger checks this before falling
+// back to its underlying Logger's own level, so individual packages
+// can be made more or less verbose at runtime without touching every
+// other module's logging.
+var moduleLevels sync.Map
+
+// SetModuleLevel overrides the effective log level for every
+// ModuleLogger created with that module name, regardless of the
+// underlying Logger's own level. It takes effect immediately and can
+// be changed at runtime.
+func SetModuleLevel(module string, level LogLevel) {
+	moduleLevels.Store(module, level)
+}
+
+// GetModuleLevel returns the level override set for module by
+// SetModuleLevel, if any.
+func GetModuleLevel(module string) (LogLevel, bool) {
+	v, ok := moduleLevels.Load(module)
+	if !ok {
+		return 0, false
+	}
+	return v.(LogLevel), true
+}
+
+// ClearModuleLevel removes module's level override, so it falls back
+// to its underlying Logger's own level again.
+func ClearModuleLevel(module string) {
+	moduleLevels.Delete(module)
+}
+
+// ModuleLogger scopes log calls to a named module - typically a
+// package name - tagging each entry's Module field and consulting
+// SetModuleLevel overrides instead of the underlying Logger's level.
+// It shares the underlying Logger's output and configuration, so
+// there's no separate file handle or mutex to keep in sync.
+type ModuleLogger struct {
+	logger *Logger
+	module string
+}
+
+// Named returns a ModuleLogger tagged with module, sharing l's output
+// and configuration.
+func (l *Logger) Named(module string) *ModuleLogger {
+	return &ModuleLogger{logger: l, module: module}
+}
+
+// Debug 记录调试信息
+func (ml *ModuleLogger) Debug(msg string, fields ...interface{}) {
+	ml.logger.logWithModule(DEBUG, ml.module, msg, fields...)
+}
+
+// Info 记录一般信息
+func (ml *ModuleLogger) Info(msg string, fields ...interface{}) {
+	ml.logger.logWithModule(INFO, ml.module, msg, fields...)
+}
+
+// Warn 记录警告信息
+func (ml *ModuleLogger) Warn(msg string, fields ...interface{}) {
+	ml.logger.logWithModule(WARN, ml.module, msg, fields...)
+}
+
+// Error 记录错误信息
+func (ml *ModuleLogger) Error(msg string, fields ...interface{}) {
+	ml.logger.logWithModule(ERROR, ml.module, msg, fields...)
+}
+
+// Fatal 记录致命错误并退出
+func (ml *ModuleLogger) Fatal(msg string, fields ...interface{}) {
+	ml.logger.logWithModule(FATAL, ml.module, msg, fields...)
+}
+
 // WithFields 返回带预设字段的日志上下文
 func (l *Logger) WithFields(fields map[string]interface{}) *LoggerContext {
 	return &LoggerContext{
@@ -628,12 +960,13 @@ func (lc *LoggerContext) log(level LogLevel, msg string, fields ...interface{})
 // GetStats 获取日志统计
 func (l *Logger) GetStats() LoggerStats {
 	return LoggerStats{
-		DebugLogs: atomic.LoadInt64(&l.stats.DebugLogs),
-		InfoLogs:  atomic.LoadInt64(&l.stats.InfoLogs),
-		WarnLogs:  atomic.LoadInt64(&l.stats.WarnLogs),
-		ErrorLogs: atomic.LoadInt64(&l.stats.ErrorLogs),
-		FatalLogs: atomic.LoadInt64(&l.stats.FatalLogs),
-		TotalLogs: atomic.LoadInt64(&l.stats.TotalLogs),
+		DebugLogs:   atomic.LoadInt64(&l.stats.DebugLogs),
+		InfoLogs:    atomic.LoadInt64(&l.stats.InfoLogs),
+		WarnLogs:    atomic.LoadInt64(&l.stats.WarnLogs),
+		ErrorLogs:   atomic.LoadInt64(&l.stats.ErrorLogs),
+		FatalLogs:   atomic.LoadInt64(&l.stats.FatalLogs),
+		TotalLogs:   atomic.LoadInt64(&l.stats.TotalLogs),
+		DroppedLogs: atomic.LoadInt64(&l.stats.DroppedLogs),
 	}
 }
 
@@ -645,13 +978,39 @@ func (l *Logger) ResetStats() {
 	atomic.StoreInt64(&l.stats.ErrorLogs, 0)
 	atomic.StoreInt64(&l.stats.FatalLogs, 0)
 	atomic.StoreInt64(&l.stats.TotalLogs, 0)
+	atomic.StoreInt64(&l.stats.DroppedLogs, 0)
+}
+
+// Flush blocks until every entry queued ahead of the call has been
+// written to the underlying sink. It's a no-op for a synchronous
+// logger. Callers that want to keep logging afterwards should use
+// Flush instead of Close.
+func (l *Logger) Flush() {
+	if l.asyncQueue == nil {
+		return
+	}
+	done := make(chan struct{})
+	l.asyncQueue <- asyncItem{done: done}
+	<-done
 }
 
-// Close 关闭日志记录器
+// Close 关闭日志记录器. For an async logger this flushes any queued
+// entries and stops the worker goroutine first; callers must not log
+// through l after calling Close.
 func (l *Logger) Close() error {
+	if l.asyncQueue != nil {
+		l.Flush()
+		close(l.asyncQueue)
+		l.asyncWG.Wait()
+		l.asyncQueue = nil
+	}
+
 	l.mu.Lock()
 	defer l.mu.Unlock()
 
+	if l.closer != nil {
+		l.closer.Close()
+	}
 	if l.file != nil {
 		return l.file.Close()
 	}
@@ -690,3 +1049,23 @@ func Error(msg string, fields ...interface{}) {
 func Fatal(msg string, fields ...interface{}) {
 	DefaultLogger.Fatal(msg, fields...)
 }
+
+// DebugSampled 记录调试信息，每 every 次相同消息只记录 1 次
+func DebugSampled(every int, msg string, fields ...interface{}) {
+	DefaultLogger.DebugSampled(every, msg, fields...)
+}
+
+// InfoSampled 记录一般信息，每 every 次相同消息只记录 1 次
+func InfoSampled(every int, msg string, fields ...interface{}) {
+	DefaultLogger.InfoSampled(every, msg, fields...)
+}
+
+// WarnSampled 记录警告信息，每 every 次相同消息只记录 1 次
+func WarnSampled(every int, msg string, fields ...interface{}) {
+	DefaultLogger.WarnSampled(every, msg, fields...)
+}
+
+// ErrorSampled 记录错误信息，每 every 次相同消息只记录 1 次
+func ErrorSampled(every int, msg string, fields ...interface{}) {
+	DefaultLogger.ErrorSampled(every, msg, fields...)
+}
@@ -2,11 +2,19 @@ package logger
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"strings"
+	"sync"
 	"testing"
 	"time"
+
+	contextx "gitee.com/com_818cloud/shode/pkg/context"
 )
 
 // TestNewLogger tests creating a new logger
@@ -454,3 +462,591 @@ func TestChainedFieldAdding(t *testing.T) {
 		t.Errorf("Expected field3=true, got %v", entry.Fields["field3"])
 	}
 }
+
+// TestWith tests the variadic field-based With API
+func TestWith(t *testing.T) {
+	var buf bytes.Buffer
+
+	logger := NewLogger(Config{
+		Level:  INFO,
+		Format: JSONFormat,
+	})
+	logger.mu.Lock()
+	logger.writer = &buf
+	logger.mu.Unlock()
+
+	logger.With("request_id", "req-1", "user_id", 42).Info("handled request")
+
+	var entry LogEntry
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("Failed to parse JSON: %v", err)
+	}
+
+	if entry.Fields["request_id"] != "req-1" {
+		t.Errorf("Expected request_id='req-1', got %v", entry.Fields["request_id"])
+	}
+	if entry.Fields["user_id"] != float64(42) {
+		t.Errorf("Expected user_id=42, got %v", entry.Fields["user_id"])
+	}
+}
+
+// TestModuleLevelOverride tests that SetModuleLevel overrides a
+// ModuleLogger's effective level independently of the underlying
+// Logger's own level
+func TestModuleLevelOverride(t *testing.T) {
+	var buf bytes.Buffer
+
+	logger := NewLogger(Config{
+		Level:  INFO,
+		Format: JSONFormat,
+	})
+	logger.mu.Lock()
+	logger.writer = &buf
+	logger.mu.Unlock()
+
+	dbLogger := logger.Named("db")
+	defer ClearModuleLevel("db")
+
+	dbLogger.Debug("should be filtered by the global INFO level")
+	if buf.Len() != 0 {
+		t.Errorf("Expected no output before the override, got: %s", buf.String())
+	}
+
+	SetModuleLevel("db", DEBUG)
+	dbLogger.Debug("now visible because db is overridden to DEBUG")
+
+	var entry LogEntry
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("Failed to parse JSON: %v", err)
+	}
+	if entry.Module != "db" {
+		t.Errorf("Expected module='db', got %q", entry.Module)
+	}
+	if entry.Level != "DEBUG" {
+		t.Errorf("Expected level='DEBUG', got %q", entry.Level)
+	}
+}
+
+// TestModuleLevelDoesNotAffectOtherModulesOrDefaultLogger tests that a
+// module-level override is scoped to that module only
+func TestModuleLevelDoesNotAffectOtherModulesOrDefaultLogger(t *testing.T) {
+	var buf bytes.Buffer
+
+	logger := NewLogger(Config{
+		Level:  WARN,
+		Format: JSONFormat,
+	})
+	logger.mu.Lock()
+	logger.writer = &buf
+	logger.mu.Unlock()
+
+	SetModuleLevel("cache", ERROR)
+	defer ClearModuleLevel("cache")
+
+	httpLogger := logger.Named("http")
+	httpLogger.Info("http has no override, falls back to the logger's WARN level")
+	if buf.Len() != 0 {
+		t.Errorf("Expected http's Info call to be filtered by the logger's WARN level, got: %s", buf.String())
+	}
+
+	logger.Info("the underlying logger's own level is unaffected by cache's override")
+	if buf.Len() != 0 {
+		t.Errorf("Expected the underlying logger's Info call to be filtered, got: %s", buf.String())
+	}
+}
+
+// TestRotateLogFileBySize tests that writing past MaxSize rotates the
+// current log file into a timestamped backup
+func TestRotateLogFileBySize(t *testing.T) {
+	dir := t.TempDir()
+	logPath := filepath.Join(dir, "app.log")
+
+	logger := NewLogger(Config{
+		Level:    INFO,
+		Format:   JSONFormat,
+		Output:   FileOutput,
+		FilePath: logPath,
+		MaxSize:  10, // bytes - any real entry rotates immediately
+		Compress: false,
+	})
+	defer logger.Close()
+
+	logger.Info("first message")
+	logger.Info("second message")
+
+	// cleanupOldLogs/compressLog run in goroutines kicked off by rotation
+	time.Sleep(50 * time.Millisecond)
+
+	matches, err := filepath.Glob(logPath + ".*")
+	if err != nil {
+		t.Fatalf("glob failed: %v", err)
+	}
+	if len(matches) == 0 {
+		t.Error("expected at least one rotated backup file after exceeding MaxSize")
+	}
+}
+
+// TestRotateLogFileByInterval tests that RotateInterval triggers
+// time-based rotation independent of file size
+func TestRotateLogFileByInterval(t *testing.T) {
+	dir := t.TempDir()
+	logPath := filepath.Join(dir, "app.log")
+
+	logger := NewLogger(Config{
+		Level:          INFO,
+		Format:         JSONFormat,
+		Output:         FileOutput,
+		FilePath:       logPath,
+		MaxSize:        DefaultConfig.MaxSize,
+		RotateInterval: 10 * time.Millisecond,
+		Compress:       false,
+	})
+	defer logger.Close()
+
+	logger.Info("first message")
+	time.Sleep(20 * time.Millisecond)
+	logger.Info("second message")
+	time.Sleep(50 * time.Millisecond)
+
+	matches, err := filepath.Glob(logPath + ".*")
+	if err != nil {
+		t.Fatalf("glob failed: %v", err)
+	}
+	if len(matches) == 0 {
+		t.Error("expected RotateInterval to trigger a rotation even though MaxSize wasn't reached")
+	}
+}
+
+// TestCleanupOldLogsEnforcesMaxAge tests that backups older than
+// MaxAge days are removed, regardless of MaxBackups
+func TestCleanupOldLogsEnforcesMaxAge(t *testing.T) {
+	dir := t.TempDir()
+	logPath := filepath.Join(dir, "app.log")
+
+	logger := NewLogger(Config{
+		FilePath:   logPath,
+		MaxBackups: 10,
+		MaxAge:     1,
+	})
+	defer logger.Close()
+
+	oldBackup := logPath + ".2000-01-01_00-00-00"
+	if err := os.WriteFile(oldBackup, []byte("old"), 0644); err != nil {
+		t.Fatalf("failed to create backup file: %v", err)
+	}
+	oldTime := time.Now().AddDate(0, 0, -10)
+	if err := os.Chtimes(oldBackup, oldTime, oldTime); err != nil {
+		t.Fatalf("failed to set backup mtime: %v", err)
+	}
+
+	recentBackup := logPath + ".2099-01-01_00-00-00"
+	if err := os.WriteFile(recentBackup, []byte("recent"), 0644); err != nil {
+		t.Fatalf("failed to create backup file: %v", err)
+	}
+
+	logger.cleanupOldLogs()
+
+	if _, err := os.Stat(oldBackup); !os.IsNotExist(err) {
+		t.Error("expected the backup older than MaxAge to be removed")
+	}
+	if _, err := os.Stat(recentBackup); err != nil {
+		t.Errorf("expected the recent backup to survive, got: %v", err)
+	}
+}
+
+// TestCleanupOldLogsEnforcesMaxBackups tests that only the most
+// recent MaxBackups files are kept, oldest removed first
+func TestCleanupOldLogsEnforcesMaxBackups(t *testing.T) {
+	dir := t.TempDir()
+	logPath := filepath.Join(dir, "app.log")
+
+	logger := NewLogger(Config{
+		FilePath:   logPath,
+		MaxBackups: 1,
+		MaxAge:     0,
+	})
+	defer logger.Close()
+
+	older := logPath + ".2000-01-01_00-00-00"
+	newer := logPath + ".2099-01-01_00-00-00"
+	for _, name := range []string{older, newer} {
+		if err := os.WriteFile(name, []byte("x"), 0644); err != nil {
+			t.Fatalf("failed to create backup file: %v", err)
+		}
+	}
+	oldTime := time.Now().Add(-time.Hour)
+	if err := os.Chtimes(older, oldTime, oldTime); err != nil {
+		t.Fatalf("failed to set backup mtime: %v", err)
+	}
+
+	logger.cleanupOldLogs()
+
+	if _, err := os.Stat(older); !os.IsNotExist(err) {
+		t.Error("expected the older backup beyond MaxBackups to be removed")
+	}
+	if _, err := os.Stat(newer); err != nil {
+		t.Errorf("expected the newer backup to survive, got: %v", err)
+	}
+}
+
+// TestWarnSampledLogsOnlyEveryNthOccurrence tests that WarnSampled
+// keeps the first occurrence and every N-th one after it, dropping the
+// rest of an identical message logged from a noisy loop.
+func TestWarnSampledLogsOnlyEveryNthOccurrence(t *testing.T) {
+	var buf bytes.Buffer
+
+	logger := NewLogger(Config{
+		Level:  INFO,
+		Format: TextFormat,
+	})
+	logger.mu.Lock()
+	logger.writer = &buf
+	logger.mu.Unlock()
+
+	for i := 0; i < 250; i++ {
+		logger.WarnSampled(100, "retrying connection")
+	}
+
+	count := strings.Count(buf.String(), "retrying connection")
+	if count != 3 {
+		t.Errorf("expected 3 sampled occurrences (1, 101, 201) out of 250, got %d", count)
+	}
+}
+
+// TestInfoSampledTracksKeysIndependently tests that the per-key
+// counters used for sampling don't interfere across distinct messages.
+func TestInfoSampledTracksKeysIndependently(t *testing.T) {
+	var buf bytes.Buffer
+
+	logger := NewLogger(Config{
+		Level:  INFO,
+		Format: TextFormat,
+	})
+	logger.mu.Lock()
+	logger.writer = &buf
+	logger.mu.Unlock()
+
+	for i := 0; i < 5; i++ {
+		logger.InfoSampled(2, "message a")
+		logger.InfoSampled(2, "message b")
+	}
+
+	if got := strings.Count(buf.String(), "message a"); got != 3 {
+		t.Errorf("expected 3 occurrences of message a (1,3,5), got %d", got)
+	}
+	if got := strings.Count(buf.String(), "message b"); got != 3 {
+		t.Errorf("expected 3 occurrences of message b (1,3,5), got %d", got)
+	}
+}
+
+// TestErrorSampledWithEveryOneOrLessAlwaysLogs tests that every <= 1
+// disables sampling, matching the documented behavior.
+func TestErrorSampledWithEveryOneOrLessAlwaysLogs(t *testing.T) {
+	var buf bytes.Buffer
+
+	logger := NewLogger(Config{
+		Level:  INFO,
+		Format: TextFormat,
+	})
+	logger.mu.Lock()
+	logger.writer = &buf
+	logger.mu.Unlock()
+
+	for i := 0; i < 3; i++ {
+		logger.ErrorSampled(1, "boom")
+	}
+
+	if got := strings.Count(buf.String(), "boom"); got != 3 {
+		t.Errorf("expected every call to log with every<=1, got %d", got)
+	}
+}
+
+// TestAsyncLoggerWritesEveryEntryBeforeFlushReturns tests that Flush
+// blocks until every queued entry has reached the sink.
+func TestAsyncLoggerWritesEveryEntryBeforeFlushReturns(t *testing.T) {
+	var buf bytes.Buffer
+
+	logger := NewLogger(Config{
+		Level:  INFO,
+		Format: TextFormat,
+		Async:  true,
+	})
+	logger.mu.Lock()
+	logger.writer = &buf
+	logger.mu.Unlock()
+	defer logger.Close()
+
+	for i := 0; i < 100; i++ {
+		logger.Info("message")
+	}
+	logger.Flush()
+
+	count := strings.Count(buf.String(), "message")
+	if count != 100 {
+		t.Errorf("expected 100 messages written after Flush, got %d", count)
+	}
+}
+
+// TestAsyncLoggerBlockPolicyBlocksUntilQueueHasRoom tests that the
+// default backpressure policy never drops an entry, even when the
+// queue is tiny and the sink is slow.
+func TestAsyncLoggerBlockPolicyBlocksUntilQueueHasRoom(t *testing.T) {
+	var buf bytes.Buffer
+
+	logger := NewLogger(Config{
+		Level:        INFO,
+		Format:       TextFormat,
+		Async:        true,
+		QueueSize:    1,
+		Backpressure: BlockPolicy,
+	})
+	logger.mu.Lock()
+	logger.writer = &buf
+	logger.mu.Unlock()
+	defer logger.Close()
+
+	for i := 0; i < 50; i++ {
+		logger.Info("message")
+	}
+	logger.Flush()
+
+	stats := logger.GetStats()
+	if stats.DroppedLogs != 0 {
+		t.Errorf("expected no drops under BlockPolicy, got %d", stats.DroppedLogs)
+	}
+	if got := strings.Count(buf.String(), "message"); got != 50 {
+		t.Errorf("expected 50 messages written, got %d", got)
+	}
+}
+
+// TestAsyncLoggerDropPolicyCountsDroppedLogs tests that DropPolicy
+// discards entries once the queue is full rather than blocking the
+// caller, and that the drop is reflected in LoggerStats.
+func TestAsyncLoggerDropPolicyCountsDroppedLogs(t *testing.T) {
+	block := make(chan struct{})
+	logger := NewLogger(Config{
+		Level:        INFO,
+		Format:       TextFormat,
+		Async:        true,
+		QueueSize:    1,
+		Backpressure: DropPolicy,
+	})
+	logger.mu.Lock()
+	logger.writer = blockingWriter{block}
+	logger.mu.Unlock()
+
+	for i := 0; i < 100; i++ {
+		logger.Info("message")
+	}
+
+	close(block)
+	logger.Close()
+
+	stats := logger.GetStats()
+	if stats.DroppedLogs == 0 {
+		t.Error("expected some entries to be dropped once the queue filled up")
+	}
+}
+
+// blockingWriter blocks every Write until block is closed, standing in
+// for a slow sink so the async queue backs up deterministically.
+type blockingWriter struct {
+	block chan struct{}
+}
+
+func (w blockingWriter) Write(p []byte) (int, error) {
+	<-w.block
+	return len(p), nil
+}
+
+// TestFromContextCarriesEngineInjectedIDs tests that FromContext pulls
+// the trace/span/script execution IDs attached via pkg/context onto
+// the emitted log entry.
+func TestFromContextCarriesEngineInjectedIDs(t *testing.T) {
+	var buf bytes.Buffer
+
+	logger := NewLogger(Config{
+		Level:  INFO,
+		Format: JSONFormat,
+	})
+	logger.mu.Lock()
+	logger.writer = &buf
+	logger.mu.Unlock()
+
+	ctx := context.Background()
+	ctx = contextx.WithTraceID(ctx, "trace-123")
+	ctx = contextx.WithSpanID(ctx, "span-456")
+	ctx = contextx.WithScriptExecutionID(ctx, "exec-789")
+
+	logger.FromContext(ctx).Info("test message")
+
+	var entry LogEntry
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("Failed to parse JSON: %v", err)
+	}
+
+	if entry.Fields["trace_id"] != "trace-123" {
+		t.Errorf("Expected trace_id='trace-123', got %v", entry.Fields["trace_id"])
+	}
+	if entry.Fields["span_id"] != "span-456" {
+		t.Errorf("Expected span_id='span-456', got %v", entry.Fields["span_id"])
+	}
+	if entry.Fields["script_execution_id"] != "exec-789" {
+		t.Errorf("Expected script_execution_id='exec-789', got %v", entry.Fields["script_execution_id"])
+	}
+}
+
+// TestFromContextWithoutIDsLeavesThemUnset tests that FromContext on a
+// bare context doesn't fabricate any identifiers.
+func TestFromContextWithoutIDsLeavesThemUnset(t *testing.T) {
+	var buf bytes.Buffer
+
+	logger := NewLogger(Config{
+		Level:  INFO,
+		Format: JSONFormat,
+	})
+	logger.mu.Lock()
+	logger.writer = &buf
+	logger.mu.Unlock()
+
+	logger.FromContext(context.Background()).Info("test message")
+
+	var entry LogEntry
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("Failed to parse JSON: %v", err)
+	}
+
+	if _, ok := entry.Fields["trace_id"]; ok {
+		t.Errorf("Expected no trace_id, got %v", entry.Fields["trace_id"])
+	}
+	if _, ok := entry.Fields["span_id"]; ok {
+		t.Errorf("Expected no span_id, got %v", entry.Fields["span_id"])
+	}
+}
+
+// TestOTLPOutputExportsLogRecordToCollector tests that OTLPOutput
+// posts a LogRecord carrying the message, severity, resource
+// attributes, and fields to the configured OTLP/HTTP endpoint.
+func TestOTLPOutputExportsLogRecordToCollector(t *testing.T) {
+	var mu sync.Mutex
+	var received otlpLogsRequest
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+			t.Errorf("failed to decode OTLP request: %v", err)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	logger := NewLogger(Config{
+		Level:        INFO,
+		Output:       OTLPOutput,
+		OTLPEndpoint: server.URL,
+		OTLPResource: map[string]string{"service.name": "shode"},
+	})
+	defer logger.Close()
+
+	logger.Warn("disk usage high", "percent", 92)
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(received.ResourceLogs) != 1 {
+		t.Fatalf("expected 1 resourceLogs entry, got %d", len(received.ResourceLogs))
+	}
+	rl := received.ResourceLogs[0]
+
+	var sawServiceName bool
+	for _, attr := range rl.Resource.Attributes {
+		if attr.Key == "service.name" && attr.Value.StringValue == "shode" {
+			sawServiceName = true
+		}
+	}
+	if !sawServiceName {
+		t.Errorf("expected resource attribute service.name=shode, got %+v", rl.Resource.Attributes)
+	}
+
+	if len(rl.ScopeLogs) != 1 || len(rl.ScopeLogs[0].LogRecords) != 1 {
+		t.Fatalf("expected exactly 1 log record, got %+v", rl.ScopeLogs)
+	}
+	record := rl.ScopeLogs[0].LogRecords[0]
+
+	if record.Body.StringValue != "disk usage high" {
+		t.Errorf("expected body 'disk usage high', got %q", record.Body.StringValue)
+	}
+	if record.SeverityText != "WARN" || record.SeverityNumber != 13 {
+		t.Errorf("expected WARN/13, got %s/%d", record.SeverityText, record.SeverityNumber)
+	}
+
+	var sawPercent bool
+	for _, attr := range record.Attributes {
+		if attr.Key == "percent" && attr.Value.StringValue == "92" {
+			sawPercent = true
+		}
+	}
+	if !sawPercent {
+		t.Errorf("expected attribute percent=92, got %+v", record.Attributes)
+	}
+}
+
+// TestOTLPOutputFallsBackToConsoleWithoutEndpoint tests that a missing
+// OTLPEndpoint degrades gracefully instead of panicking.
+func TestOTLPOutputFallsBackToConsoleWithoutEndpoint(t *testing.T) {
+	logger := NewLogger(Config{
+		Level:  INFO,
+		Output: OTLPOutput,
+	})
+	defer logger.Close()
+
+	if logger.writer != os.Stdout {
+		t.Error("expected OTLP output to fall back to os.Stdout when OTLPEndpoint is unset")
+	}
+	logger.Info("still logging")
+}
+
+// TestJournaldOutputFallsBackToConsoleWhenSocketMissing tests that a
+// logger configured for JournaldOutput still works - falling back to
+// stdout - when no journald socket is present, instead of panicking or
+// silently dropping logs.
+func TestJournaldOutputFallsBackToConsoleWhenSocketMissing(t *testing.T) {
+	logger := NewLogger(Config{
+		Output: JournaldOutput,
+		Format: TextFormat,
+	})
+	defer logger.Close()
+
+	if logger.writer != os.Stdout {
+		t.Error("expected journald output to fall back to os.Stdout when the socket is unavailable")
+	}
+
+	// Should not panic even though there's nowhere real for it to go.
+	logger.Info("still logging")
+}
+
+// TestSyslogOutputFallsBackToConsoleWhenUnavailable tests the same
+// fallback behavior for SyslogOutput on a host with no local syslog
+// daemon to dial.
+func TestSyslogOutputFallsBackToConsoleWhenUnavailable(t *testing.T) {
+	logger := NewLogger(Config{
+		Output: SyslogOutput,
+		Format: TextFormat,
+	})
+	defer logger.Close()
+
+	logger.Info("still logging")
+}
+
+// TestEventLogOutputUnsupportedOnThisPlatformFallsBackToConsole tests
+// that EventLogOutput degrades gracefully rather than failing to build
+// or crashing on non-Windows hosts.
+func TestEventLogOutputUnsupportedOnThisPlatformFallsBackToConsole(t *testing.T) {
+	logger := NewLogger(Config{
+		Output: EventLogOutput,
+		Format: TextFormat,
+	})
+	defer logger.Close()
+
+	logger.Info("still logging")
+}
@@ -0,0 +1,175 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// otlpWriter adapts Logger's JSON-encoded LogEntry output (Output:
+// OTLPOutput forces Format: JSONFormat) into OTLP/HTTP log export
+// requests, POSTing one ExportLogsServiceRequest per entry - logger
+// sinks in this package write synchronously or from the single async
+// worker goroutine, so there's no batching to get right here.
+type otlpWriter struct {
+	endpoint string
+	resource map[string]string
+	client   *http.Client
+}
+
+// newOTLPWriter builds an otlpWriter from config.OTLPEndpoint/
+// OTLPResource/OTLPTimeout.
+func newOTLPWriter(config Config) (io.WriteCloser, error) {
+	if config.OTLPEndpoint == "" {
+		return nil, fmt.Errorf("logger: OTLPEndpoint is required for OTLPOutput")
+	}
+	timeout := config.OTLPTimeout
+	if timeout <= 0 {
+		timeout = 5 * time.Second
+	}
+	return &otlpWriter{
+		endpoint: config.OTLPEndpoint,
+		resource: config.OTLPResource,
+		client:   &http.Client{Timeout: timeout},
+	}, nil
+}
+
+func (w *otlpWriter) Write(p []byte) (int, error) {
+	var entry LogEntry
+	if err := json.Unmarshal(bytes.TrimSpace(p), &entry); err != nil {
+		return 0, fmt.Errorf("logger: OTLPOutput requires Format: JSONFormat: %w", err)
+	}
+
+	payload, err := buildOTLPLogsRequest(entry, w.resource)
+	if err != nil {
+		return 0, err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, w.endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("logger: OTLP export failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return 0, fmt.Errorf("logger: OTLP collector returned status %d", resp.StatusCode)
+	}
+
+	return len(p), nil
+}
+
+func (w *otlpWriter) Close() error {
+	w.client.CloseIdleConnections()
+	return nil
+}
+
+// otlpAnyValue, otlpKeyValue, otlpLogRecord, otlpScopeLogs,
+// otlpResource, otlpResourceLogs, and otlpLogsRequest mirror just
+// enough of the OTLP/HTTP JSON log data model (protobuf JSON mapping
+// of ExportLogsServiceRequest) to carry a LogEntry across - every
+// attribute value is encoded as a string, since LogEntry's own fields
+// are already a loosely-typed map[string]interface{}.
+type otlpAnyValue struct {
+	StringValue string `json:"stringValue"`
+}
+
+type otlpKeyValue struct {
+	Key   string       `json:"key"`
+	Value otlpAnyValue `json:"value"`
+}
+
+type otlpLogRecord struct {
+	TimeUnixNano   string         `json:"timeUnixNano"`
+	SeverityNumber int            `json:"severityNumber"`
+	SeverityText   string         `json:"severityText"`
+	Body           otlpAnyValue   `json:"body"`
+	Attributes     []otlpKeyValue `json:"attributes,omitempty"`
+}
+
+type otlpScopeLogs struct {
+	LogRecords []otlpLogRecord `json:"logRecords"`
+}
+
+type otlpResource struct {
+	Attributes []otlpKeyValue `json:"attributes,omitempty"`
+}
+
+type otlpResourceLogs struct {
+	Resource  otlpResource    `json:"resource"`
+	ScopeLogs []otlpScopeLogs `json:"scopeLogs"`
+}
+
+type otlpLogsRequest struct {
+	ResourceLogs []otlpResourceLogs `json:"resourceLogs"`
+}
+
+// buildOTLPLogsRequest renders entry as a single-record
+// ExportLogsServiceRequest, tagging the resource with resource's
+// attributes (e.g. service.name) and the log record with entry's own
+// module/trace ID/fields.
+func buildOTLPLogsRequest(entry LogEntry, resource map[string]string) ([]byte, error) {
+	ts, err := time.Parse(time.RFC3339Nano, entry.Time)
+	if err != nil {
+		ts = time.Now()
+	}
+
+	attrs := make([]otlpKeyValue, 0, len(entry.Fields)+2)
+	if entry.Module != "" {
+		attrs = append(attrs, otlpKeyValue{Key: "module", Value: otlpAnyValue{StringValue: entry.Module}})
+	}
+	if entry.TraceID != "" {
+		attrs = append(attrs, otlpKeyValue{Key: "trace_id", Value: otlpAnyValue{StringValue: entry.TraceID}})
+	}
+	for k, v := range entry.Fields {
+		attrs = append(attrs, otlpKeyValue{Key: k, Value: otlpAnyValue{StringValue: fmt.Sprintf("%v", v)}})
+	}
+
+	resourceAttrs := make([]otlpKeyValue, 0, len(resource))
+	for k, v := range resource {
+		resourceAttrs = append(resourceAttrs, otlpKeyValue{Key: k, Value: otlpAnyValue{StringValue: v}})
+	}
+
+	record := otlpLogRecord{
+		TimeUnixNano:   fmt.Sprintf("%d", ts.UnixNano()),
+		SeverityNumber: otlpSeverityNumber(entry.Level),
+		SeverityText:   entry.Level,
+		Body:           otlpAnyValue{StringValue: entry.Message},
+		Attributes:     attrs,
+	}
+
+	request := otlpLogsRequest{
+		ResourceLogs: []otlpResourceLogs{{
+			Resource:  otlpResource{Attributes: resourceAttrs},
+			ScopeLogs: []otlpScopeLogs{{LogRecords: []otlpLogRecord{record}}},
+		}},
+	}
+
+	return json.Marshal(request)
+}
+
+// otlpSeverityNumber maps a LogLevel's String() to the OTLP
+// SeverityNumber enum (DEBUG=5, INFO=9, WARN=13, ERROR=17, FATAL=21).
+func otlpSeverityNumber(level string) int {
+	switch level {
+	case "DEBUG":
+		return 5
+	case "INFO":
+		return 9
+	case "WARN":
+		return 13
+	case "ERROR":
+		return 17
+	case "FATAL":
+		return 21
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,14 @@
+//go:build windows || plan9 || js
+
+package logger
+
+import (
+	"fmt"
+	"io"
+)
+
+// newSyslogWriter is the stub for platforms without a local syslog
+// daemon to dial.
+func newSyslogWriter(config Config) (io.WriteCloser, error) {
+	return nil, fmt.Errorf("logger: syslog output is not supported on this platform")
+}
@@ -0,0 +1,18 @@
+//go:build !windows && !plan9 && !js
+
+package logger
+
+import (
+	"io"
+	"log/syslog"
+)
+
+// newSyslogWriter dials the local syslog daemon (/dev/log or
+// equivalent), tagging every message with config.SyslogTag.
+func newSyslogWriter(config Config) (io.WriteCloser, error) {
+	tag := config.SyslogTag
+	if tag == "" {
+		tag = "shode"
+	}
+	return syslog.New(syslog.LOG_INFO, tag)
+}
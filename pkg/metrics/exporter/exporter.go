@@ -0,0 +1,154 @@
+// Package exporter renders a live snapshot of a running shode process -
+// engine execution counts and durations, cache hit rate, background job
+// count, WebSocket client count, and AutoOptimizer CPU alerts - in
+// Prometheus text exposition format, and serves it on /metrics.
+package exporter
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"gitee.com/com_818cloud/shode/pkg/engine"
+	"gitee.com/com_818cloud/shode/pkg/performance"
+	"gitee.com/com_818cloud/shode/pkg/websocket"
+)
+
+// Sources is the set of live runtime components an Exporter reads from.
+// Engine is required; WebSocket and Optimizer are optional - a nil
+// value simply omits that section's metrics.
+type Sources struct {
+	Engine    *engine.ExecutionEngine
+	WebSocket *websocket.Manager
+	Optimizer *performance.AutoOptimizer
+}
+
+// MetricDef describes one metric this package can export, independent
+// of whether a live snapshot currently has a value for it. Anything
+// that needs to know the exporter's metric names ahead of a running
+// process - for example pkg/observability's dashboard and alert-rule
+// generator - reads Catalog instead of hardcoding its own copy, so the
+// two can't drift apart.
+type MetricDef struct {
+	Name string
+	Type string // "counter" or "gauge"
+	Help string
+}
+
+var (
+	metricCommandExecutions = MetricDef{"shode_engine_command_executions_total", "counter",
+		"Total commands executed by the engine"}
+	metricCommandSuccesses = MetricDef{"shode_engine_command_successes_total", "counter",
+		"Commands that completed successfully"}
+	metricCommandFailures = MetricDef{"shode_engine_command_failures_total", "counter",
+		"Commands that failed or timed out"}
+	metricCommandDurationAvg = MetricDef{"shode_engine_command_duration_seconds_avg", "gauge",
+		"Average command execution duration in seconds"}
+	metricCommandDurationP50 = MetricDef{"shode_engine_command_duration_seconds_p50", "gauge",
+		"Median command execution duration in seconds"}
+	metricCommandDurationP95 = MetricDef{"shode_engine_command_duration_seconds_p95", "gauge",
+		"95th percentile command execution duration in seconds"}
+	metricCommandDurationP99 = MetricDef{"shode_engine_command_duration_seconds_p99", "gauge",
+		"99th percentile command execution duration in seconds"}
+	metricCacheHitRatio = MetricDef{"shode_engine_cache_hit_ratio", "gauge",
+		"Command result cache hit rate, 0-1"}
+	metricBackgroundJobs = MetricDef{"shode_engine_background_jobs_total", "counter",
+		"Total background jobs submitted to the engine"}
+	metricWebSocketClients = MetricDef{"shode_websocket_clients", "gauge",
+		"Currently connected WebSocket clients"}
+	metricAutoOptimizerCPU = MetricDef{"shode_autooptimizer_cpu_percent", "gauge",
+		"Most recently sampled process CPU usage percent"}
+	metricAutoOptimizerAlerts = MetricDef{"shode_autooptimizer_alerts_total", "counter",
+		"Samples whose CPU usage met or exceeded the optimization threshold"}
+)
+
+// Catalog lists every metric Render can emit, in the order Render
+// emits them when all Sources are present.
+var Catalog = []MetricDef{
+	metricCommandExecutions,
+	metricCommandSuccesses,
+	metricCommandFailures,
+	metricCommandDurationAvg,
+	metricCommandDurationP50,
+	metricCommandDurationP95,
+	metricCommandDurationP99,
+	metricCacheHitRatio,
+	metricBackgroundJobs,
+	metricWebSocketClients,
+	metricAutoOptimizerCPU,
+	metricAutoOptimizerAlerts,
+}
+
+// Exporter renders Sources as Prometheus text on demand. It holds no
+// state of its own - every Render call reads the current values
+// straight from the sources it was given.
+type Exporter struct {
+	sources Sources
+}
+
+// NewExporter creates an Exporter over sources.
+func NewExporter(sources Sources) *Exporter {
+	return &Exporter{sources: sources}
+}
+
+// Render returns the current snapshot in Prometheus text exposition
+// format.
+func (e *Exporter) Render() string {
+	var b strings.Builder
+
+	if e.sources.Engine != nil {
+		m := e.sources.Engine.Metrics().GetMetrics()
+
+		writeMetric(&b, metricCommandExecutions, fmt.Sprintf("%d", m.CommandExecutions))
+		writeMetric(&b, metricCommandSuccesses, fmt.Sprintf("%d", m.CommandSuccesses))
+		writeMetric(&b, metricCommandFailures, fmt.Sprintf("%d", m.CommandFailures))
+		writeMetric(&b, metricCommandDurationAvg, formatSeconds(m.AverageDuration))
+		writeMetric(&b, metricCommandDurationP50, formatSeconds(m.P50Duration))
+		writeMetric(&b, metricCommandDurationP95, formatSeconds(m.P95Duration))
+		writeMetric(&b, metricCommandDurationP99, formatSeconds(m.P99Duration))
+		writeMetric(&b, metricCacheHitRatio, fmt.Sprintf("%f", m.CacheHitRate/100))
+		writeMetric(&b, metricBackgroundJobs, fmt.Sprintf("%d", e.sources.Engine.GetJobCount()))
+	}
+
+	if e.sources.WebSocket != nil {
+		writeMetric(&b, metricWebSocketClients, fmt.Sprintf("%d", e.sources.WebSocket.GetConnectionCount()))
+	}
+
+	if e.sources.Optimizer != nil {
+		if latest := e.sources.Optimizer.History(); len(latest) > 0 {
+			writeMetric(&b, metricAutoOptimizerCPU, fmt.Sprintf("%f", latest[len(latest)-1].CPUPercent))
+		}
+		writeMetric(&b, metricAutoOptimizerAlerts, fmt.Sprintf("%d", e.sources.Optimizer.AlertCount()))
+	}
+
+	return b.String()
+}
+
+func writeMetric(b *strings.Builder, def MetricDef, value string) {
+	fmt.Fprintf(b, "# HELP %s %s\n", def.Name, def.Help)
+	fmt.Fprintf(b, "# TYPE %s %s\n", def.Name, def.Type)
+	fmt.Fprintf(b, "%s %s\n", def.Name, value)
+}
+
+func formatSeconds(d interface{ Seconds() float64 }) string {
+	return fmt.Sprintf("%f", d.Seconds())
+}
+
+// NewServer builds an *http.Server exposing sources on /metrics in
+// Prometheus text format, on its own ServeMux rather than
+// http.DefaultServeMux, matching performance.NewPprofServer.
+func NewServer(addr string, sources Sources) *http.Server {
+	exp := NewExporter(sources)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/metrics", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		io.WriteString(w, exp.Render())
+	})
+
+	return &http.Server{
+		Addr:    addr,
+		Handler: mux,
+	}
+}
@@ -0,0 +1,90 @@
+package exporter
+
+import (
+	"context"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"gitee.com/com_818cloud/shode/pkg/engine"
+	"gitee.com/com_818cloud/shode/pkg/environment"
+	"gitee.com/com_818cloud/shode/pkg/module"
+	"gitee.com/com_818cloud/shode/pkg/performance"
+	"gitee.com/com_818cloud/shode/pkg/sandbox"
+	"gitee.com/com_818cloud/shode/pkg/stdlib"
+	"gitee.com/com_818cloud/shode/pkg/types"
+	"gitee.com/com_818cloud/shode/pkg/websocket"
+)
+
+func newTestEngine() *engine.ExecutionEngine {
+	return engine.NewExecutionEngine(
+		environment.NewEnvironmentManager(),
+		stdlib.New(),
+		module.NewModuleManager(),
+		sandbox.NewSecurityChecker(),
+	)
+}
+
+func TestRenderIncludesEngineMetrics(t *testing.T) {
+	eng := newTestEngine()
+	if _, err := eng.ExecuteCommand(context.Background(), &types.CommandNode{Name: "true"}); err != nil {
+		t.Fatalf("ExecuteCommand returned error: %v", err)
+	}
+
+	exp := NewExporter(Sources{Engine: eng})
+	out := exp.Render()
+
+	if !strings.Contains(out, "shode_engine_command_executions_total") {
+		t.Fatalf("expected engine command metrics in output, got: %s", out)
+	}
+	if !strings.Contains(out, "shode_engine_command_executions_total 1\n") {
+		t.Fatalf("expected exactly 1 recorded execution, got: %s", out)
+	}
+}
+
+func TestRenderIncludesWebSocketAndOptimizerMetricsWhenProvided(t *testing.T) {
+	mgr := websocket.NewManager()
+	opt := performance.NewAutoOptimizer()
+	opt.Sample()
+
+	exp := NewExporter(Sources{
+		Engine:    newTestEngine(),
+		WebSocket: mgr,
+		Optimizer: opt,
+	})
+	out := exp.Render()
+
+	if !strings.Contains(out, "shode_websocket_clients 0\n") {
+		t.Fatalf("expected zero connected websocket clients, got: %s", out)
+	}
+	if !strings.Contains(out, "shode_autooptimizer_cpu_percent") {
+		t.Fatalf("expected autooptimizer CPU metric, got: %s", out)
+	}
+}
+
+func TestRenderOmitsOptionalSectionsWhenNotProvided(t *testing.T) {
+	exp := NewExporter(Sources{Engine: newTestEngine()})
+	out := exp.Render()
+
+	if strings.Contains(out, "shode_websocket_clients") {
+		t.Fatalf("expected no websocket metrics without a WebSocket source, got: %s", out)
+	}
+	if strings.Contains(out, "shode_autooptimizer") {
+		t.Fatalf("expected no autooptimizer metrics without an Optimizer source, got: %s", out)
+	}
+}
+
+func TestNewServerServesMetricsEndpoint(t *testing.T) {
+	srv := NewServer(":0", Sources{Engine: newTestEngine()})
+
+	req := httptest.NewRequest("GET", "/metrics", nil)
+	rec := httptest.NewRecorder()
+	srv.Handler.ServeHTTP(rec, req)
+
+	if rec.Code != 200 {
+		t.Fatalf("expected status 200, got %d", rec.Code)
+	}
+	if !strings.Contains(rec.Body.String(), "shode_engine_command_executions_total") {
+		t.Fatalf("expected metrics body, got: %s", rec.Body.String())
+	}
+}
@@ -0,0 +1,142 @@
+package exporter
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// StatsDSink periodically pushes the same metrics Render serves as
+// Prometheus text to a StatsD (or DogStatsD) daemon over UDP, for
+// shops that consume metrics that way instead of scraping /metrics. It
+// reads from the same Sources an Exporter does, so both surfaces
+// report identical numbers.
+type StatsDSink struct {
+	sources  Sources
+	interval time.Duration
+	prefix   string
+
+	conn *net.UDPConn
+
+	stop chan struct{}
+	wg   sync.WaitGroup
+}
+
+// NewStatsDSink creates a sink that pushes sources to the StatsD
+// daemon at addr (host:port) every interval, with every metric name
+// prefixed by prefix (e.g. "shode"). UDP being connectionless,
+// NewStatsDSink succeeds even if nothing is listening at addr yet;
+// send failures are logged rather than returned, matching
+// performance.Agent's capture loop.
+func NewStatsDSink(addr string, sources Sources, interval time.Duration, prefix string) (*StatsDSink, error) {
+	udpAddr, err := net.ResolveUDPAddr("udp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("resolving statsd address: %w", err)
+	}
+	conn, err := net.DialUDP("udp", nil, udpAddr)
+	if err != nil {
+		return nil, fmt.Errorf("dialing statsd daemon: %w", err)
+	}
+
+	return &StatsDSink{
+		sources:  sources,
+		interval: interval,
+		prefix:   prefix,
+		conn:     conn,
+	}, nil
+}
+
+// Start begins pushing metrics in the background every interval. Call
+// Stop to end the loop.
+func (s *StatsDSink) Start() {
+	s.stop = make(chan struct{})
+	s.wg.Add(1)
+	go s.run()
+}
+
+// Stop ends the push loop, waits for it to finish, and closes the
+// underlying UDP socket.
+func (s *StatsDSink) Stop() {
+	if s.stop != nil {
+		close(s.stop)
+		s.wg.Wait()
+	}
+	s.conn.Close()
+}
+
+func (s *StatsDSink) run() {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.stop:
+			return
+		case <-ticker.C:
+			if err := s.Send(); err != nil {
+				fmt.Fprintf(os.Stderr, "exporter: statsd send failed: %v\n", err)
+			}
+		}
+	}
+}
+
+// Send renders the current metrics as StatsD lines and writes them to
+// the daemon in a single UDP packet, newline-separated - the batching
+// format DogStatsD and most StatsD daemons accept. Exported so a
+// caller that wants one-shot pushes (e.g. around a short-lived script)
+// doesn't need to go through Start/Stop.
+func (s *StatsDSink) Send() error {
+	var b strings.Builder
+	s.writeMetrics(&b)
+	if b.Len() == 0 {
+		return nil
+	}
+	_, err := s.conn.Write([]byte(b.String()))
+	return err
+}
+
+// writeMetrics mirrors Exporter.Render's set of metrics, one StatsD
+// line per metric, so both sinks report identical numbers from the
+// same Sources. Cumulative counts are sent as gauges rather than
+// StatsD counters, since Sources.Engine tracks running totals rather
+// than deltas and a StatsD counter adds each reported value to its own
+// running total.
+func (s *StatsDSink) writeMetrics(b *strings.Builder) {
+	if s.sources.Engine != nil {
+		m := s.sources.Engine.Metrics().GetMetrics()
+
+		s.gauge(b, "engine.command_executions_total", float64(m.CommandExecutions))
+		s.gauge(b, "engine.command_successes_total", float64(m.CommandSuccesses))
+		s.gauge(b, "engine.command_failures_total", float64(m.CommandFailures))
+		s.timer(b, "engine.command_duration_avg", m.AverageDuration)
+		s.timer(b, "engine.command_duration_p50", m.P50Duration)
+		s.timer(b, "engine.command_duration_p95", m.P95Duration)
+		s.timer(b, "engine.command_duration_p99", m.P99Duration)
+		s.gauge(b, "engine.cache_hit_ratio", m.CacheHitRate/100)
+		s.gauge(b, "engine.background_jobs_total", float64(s.sources.Engine.GetJobCount()))
+	}
+
+	if s.sources.WebSocket != nil {
+		s.gauge(b, "websocket.clients", float64(s.sources.WebSocket.GetConnectionCount()))
+	}
+
+	if s.sources.Optimizer != nil {
+		if latest := s.sources.Optimizer.History(); len(latest) > 0 {
+			s.gauge(b, "autooptimizer.cpu_percent", latest[len(latest)-1].CPUPercent)
+		}
+		s.gauge(b, "autooptimizer.alerts_total", float64(s.sources.Optimizer.AlertCount()))
+	}
+}
+
+func (s *StatsDSink) gauge(b *strings.Builder, name string, value float64) {
+	fmt.Fprintf(b, "%s.%s:%f|g\n", s.prefix, name, value)
+}
+
+func (s *StatsDSink) timer(b *strings.Builder, name string, d interface{ Seconds() float64 }) {
+	fmt.Fprintf(b, "%s.%s:%f|ms\n", s.prefix, name, d.Seconds()*1000)
+}
@@ -0,0 +1,106 @@
+package exporter
+
+import (
+	"context"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"gitee.com/com_818cloud/shode/pkg/types"
+)
+
+func listenUDP(t *testing.T) *net.UDPConn {
+	addr, err := net.ResolveUDPAddr("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("resolving listen address: %v", err)
+	}
+	conn, err := net.ListenUDP("udp", addr)
+	if err != nil {
+		t.Fatalf("listening on udp: %v", err)
+	}
+	return conn
+}
+
+func TestSendWritesStatsDLinesForEngineMetrics(t *testing.T) {
+	listener := listenUDP(t)
+	defer listener.Close()
+
+	eng := newTestEngine()
+	if _, err := eng.ExecuteCommand(context.Background(), &types.CommandNode{Name: "true"}); err != nil {
+		t.Fatalf("ExecuteCommand returned error: %v", err)
+	}
+
+	sink, err := NewStatsDSink(listener.LocalAddr().String(), Sources{Engine: eng}, time.Second, "shode")
+	if err != nil {
+		t.Fatalf("NewStatsDSink returned error: %v", err)
+	}
+	defer sink.Stop()
+
+	if err := sink.Send(); err != nil {
+		t.Fatalf("Send returned error: %v", err)
+	}
+
+	buf := make([]byte, 4096)
+	listener.SetReadDeadline(time.Now().Add(time.Second))
+	n, _, err := listener.ReadFromUDP(buf)
+	if err != nil {
+		t.Fatalf("reading statsd packet: %v", err)
+	}
+	packet := string(buf[:n])
+
+	if !strings.Contains(packet, "shode.engine.command_executions_total:1.000000|g") {
+		t.Fatalf("expected a command_executions_total gauge line, got: %s", packet)
+	}
+	if !strings.Contains(packet, "|ms") {
+		t.Fatalf("expected at least one timer line, got: %s", packet)
+	}
+}
+
+func TestSendOmitsOptionalSectionsWhenNotProvided(t *testing.T) {
+	listener := listenUDP(t)
+	defer listener.Close()
+
+	sink, err := NewStatsDSink(listener.LocalAddr().String(), Sources{Engine: newTestEngine()}, time.Second, "shode")
+	if err != nil {
+		t.Fatalf("NewStatsDSink returned error: %v", err)
+	}
+	defer sink.Stop()
+
+	if err := sink.Send(); err != nil {
+		t.Fatalf("Send returned error: %v", err)
+	}
+
+	buf := make([]byte, 4096)
+	listener.SetReadDeadline(time.Now().Add(time.Second))
+	n, _, err := listener.ReadFromUDP(buf)
+	if err != nil {
+		t.Fatalf("reading statsd packet: %v", err)
+	}
+	packet := string(buf[:n])
+
+	if strings.Contains(packet, "websocket.clients") {
+		t.Fatalf("expected no websocket metrics without a WebSocket source, got: %s", packet)
+	}
+	if strings.Contains(packet, "autooptimizer") {
+		t.Fatalf("expected no autooptimizer metrics without an Optimizer source, got: %s", packet)
+	}
+}
+
+func TestStartStopPushesOnInterval(t *testing.T) {
+	listener := listenUDP(t)
+	defer listener.Close()
+
+	sink, err := NewStatsDSink(listener.LocalAddr().String(), Sources{Engine: newTestEngine()}, 10*time.Millisecond, "shode")
+	if err != nil {
+		t.Fatalf("NewStatsDSink returned error: %v", err)
+	}
+	sink.Start()
+	defer sink.Stop()
+
+	buf := make([]byte, 4096)
+	listener.SetReadDeadline(time.Now().Add(time.Second))
+	if _, _, err := listener.ReadFromUDP(buf); err != nil {
+		t.Fatalf("expected a push within the interval, got error: %v", err)
+	}
+}
@@ -0,0 +1,154 @@
+// Package migrate analyzes existing bash scripts for bash-to-shode
+// compatibility: constructs Shode's parser and engine don't support yet,
+// and builtin replacements for common external commands.
+package migrate
+
+import (
+	"bufio"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Severity classifies how much attention a Finding needs.
+type Severity string
+
+const (
+	// SeverityInfo marks a trivially convertible pattern.
+	SeverityInfo Severity = "info"
+	// SeverityWarning marks a construct Shode doesn't support yet.
+	SeverityWarning Severity = "warning"
+)
+
+// Finding is one line-level observation from analyzing a script.
+type Finding struct {
+	Line       int
+	Severity   Severity
+	Message    string
+	Suggestion string // non-empty when a rewrite is available
+}
+
+// Report is the result of analyzing a script.
+type Report struct {
+	ScriptPath string
+	Findings   []Finding
+}
+
+// builtinReplacements maps external commands to the Shode stdlib builtin
+// that replaces them, mirroring the comments in pkg/stdlib/function_map.go.
+var builtinReplacements = map[string]string{
+	"cp":     "CopyFile",
+	"mv":     "MoveFile",
+	"rm":     "DeleteFile",
+	"mkdir":  "MakeDir",
+	"rmdir":  "DeleteDir",
+	"cat":    "ReadFile",
+	"ls":     "ListFiles",
+	"chmod":  "Chmod",
+	"chown":  "Chown",
+	"touch":  "WriteFile",
+	"pwd":    "WorkingDir",
+	"whoami": "GetUsername",
+	"sleep":  "Sleep",
+}
+
+// unsupportedPatterns flags bash syntax the SimpleParser/engine don't
+// understand, each paired with a human-readable explanation.
+var unsupportedPatterns = []struct {
+	pattern *regexp.Regexp
+	message string
+}{
+	{regexp.MustCompile(`<\([^)]*\)`), "process substitution is not supported"},
+	{regexp.MustCompile(`\$\{[A-Za-z_][A-Za-z0-9_]*\[@\]\}`), "bash arrays are not supported"},
+	{regexp.MustCompile(`\(\([^)]*\)\)`), "arithmetic ((...)) expressions are not supported"},
+	{regexp.MustCompile(`<<<`), "here-strings are not supported"},
+	{regexp.MustCompile(`\[\[.*\]\]`), "[[ ]] conditional expressions are not supported"},
+	{regexp.MustCompile(`\bselect\b`), "the select builtin is not supported"},
+	{regexp.MustCompile(`\btrap\b`), "trap handlers are not supported"},
+}
+
+// rewriteLine matches "<cmd> <args...>" so a trivially convertible command
+// invocation can be rewritten to its builtin equivalent.
+var rewriteLine = regexp.MustCompile(`^(\s*)([A-Za-z_][A-Za-z0-9_.-]*)\s+(.*)$`)
+
+// Analyze scans bash source line by line and reports unsupported
+// constructs and builtin replacement suggestions.
+func Analyze(scriptPath, source string) (*Report, error) {
+	report := &Report{ScriptPath: scriptPath}
+
+	scanner := bufio.NewScanner(strings.NewReader(source))
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := scanner.Text()
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+
+		for _, up := range unsupportedPatterns {
+			if up.pattern.MatchString(line) {
+				report.Findings = append(report.Findings, Finding{
+					Line:     lineNum,
+					Severity: SeverityWarning,
+					Message:  up.message,
+				})
+			}
+		}
+
+		if cmd, builtin, ok := matchReplaceable(trimmed); ok {
+			report.Findings = append(report.Findings, Finding{
+				Line:       lineNum,
+				Severity:   SeverityInfo,
+				Message:    fmt.Sprintf("%q can be replaced with the %s builtin", cmd, builtin),
+				Suggestion: Rewrite(line),
+			})
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+// matchReplaceable reports whether line invokes a command with a known
+// builtin replacement.
+func matchReplaceable(line string) (cmd, builtin string, ok bool) {
+	m := rewriteLine.FindStringSubmatch(line)
+	if m == nil {
+		return "", "", false
+	}
+	cmd = m[2]
+	builtin, ok = builtinReplacements[cmd]
+	return cmd, builtin, ok
+}
+
+// Rewrite rewrites a single trivially convertible command invocation line
+// (e.g. "cp a b") to its builtin call form ("CopyFile a b"). Lines with no
+// known replacement are returned unchanged.
+func Rewrite(line string) string {
+	m := rewriteLine.FindStringSubmatch(line)
+	if m == nil {
+		return line
+	}
+	indent, cmd, rest := m[1], m[2], m[3]
+	builtin, ok := builtinReplacements[cmd]
+	if !ok {
+		return line
+	}
+	return fmt.Sprintf("%s%s %s", indent, builtin, rest)
+}
+
+// RewriteScript rewrites every trivially convertible line in source,
+// leaving unsupported constructs untouched.
+func RewriteScript(source string) string {
+	lines := strings.Split(source, "\n")
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if _, _, ok := matchReplaceable(trimmed); ok {
+			lines[i] = Rewrite(line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
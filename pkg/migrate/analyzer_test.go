@@ -0,0 +1,34 @@
+package migrate
+
+import "testing"
+
+func TestAnalyzeFindsReplaceableCommand(t *testing.T) {
+	report, err := Analyze("test.sh", "cp a.txt b.txt\n")
+	if err != nil {
+		t.Fatalf("Analyze() error = %v", err)
+	}
+	if len(report.Findings) != 1 {
+		t.Fatalf("len(Findings) = %d, want 1", len(report.Findings))
+	}
+	if report.Findings[0].Suggestion != "CopyFile a.txt b.txt" {
+		t.Errorf("Suggestion = %q, want %q", report.Findings[0].Suggestion, "CopyFile a.txt b.txt")
+	}
+}
+
+func TestAnalyzeFindsUnsupportedConstruct(t *testing.T) {
+	report, err := Analyze("test.sh", "x=$((1 + 2))\n")
+	if err != nil {
+		t.Fatalf("Analyze() error = %v", err)
+	}
+	if len(report.Findings) != 1 || report.Findings[0].Severity != SeverityWarning {
+		t.Fatalf("Findings = %+v, want one warning", report.Findings)
+	}
+}
+
+func TestRewriteScript(t *testing.T) {
+	out := RewriteScript("mkdir build\necho done\n")
+	want := "MakeDir build\necho done\n"
+	if out != want {
+		t.Errorf("RewriteScript() = %q, want %q", out, want)
+	}
+}
@@ -1,6 +1,7 @@
 package module
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -10,6 +11,7 @@ import (
 	"gitee.com/com_818cloud/shode/pkg/environment"
 	"gitee.com/com_818cloud/shode/pkg/errors"
 	"gitee.com/com_818cloud/shode/pkg/parser"
+	"gitee.com/com_818cloud/shode/pkg/tracing"
 	"gitee.com/com_818cloud/shode/pkg/types"
 )
 
@@ -18,6 +20,7 @@ type ModuleManager struct {
 	envManager *environment.EnvironmentManager
 	parser     *parser.SimpleParser
 	modules    map[string]*Module
+	tracer     *tracing.Tracer // Span tracer for LoadModule; exports nowhere until SetTracer attaches a real exporter
 }
 
 // Module represents a loaded Shode module
@@ -55,11 +58,24 @@ func NewModuleManager() *ModuleManager {
 		envManager: environment.NewEnvironmentManager(),
 		parser:     parser.NewSimpleParser(),
 		modules:    make(map[string]*Module),
+		tracer:     tracing.NewTracer(nil),
 	}
 }
 
+// SetTracer attaches a tracer that exports a span for every LoadModule
+// call, tagged with the module's path. Pass a Tracer built with a nil
+// exporter, or simply leave the manager's default in place, to disable
+// tracing.
+func (mm *ModuleManager) SetTracer(tracer *tracing.Tracer) {
+	mm.tracer = tracer
+}
+
 // LoadModule loads a module from the given path
 func (mm *ModuleManager) LoadModule(path string) (*Module, error) {
+	_, span := mm.tracer.Start(context.Background(), "shode.module.load")
+	span.SetAttribute("module.path", path)
+	defer span.End()
+
 	// Check if module is already loaded
 	if module, exists := mm.modules[path]; exists && module.IsLoaded {
 		return module, nil
@@ -68,12 +84,15 @@ func (mm *ModuleManager) LoadModule(path string) (*Module, error) {
 	// Resolve absolute path
 	absPath, err := mm.resolveModulePath(path)
 	if err != nil {
+		span.SetErr(err)
 		return nil, err
 	}
 
 	// Check if module exists
 	if _, err := os.Stat(absPath); os.IsNotExist(err) {
-		return nil, fmt.Errorf("module not found: %s", path)
+		err := fmt.Errorf("module not found: %s", path)
+		span.SetErr(err)
+		return nil, err
 	}
 
 	// Try to get module name from package.json first
@@ -96,6 +115,7 @@ func (mm *ModuleManager) LoadModule(path string) (*Module, error) {
 
 	// Load module exports
 	if err := mm.loadModuleExports(module); err != nil {
+		span.SetErr(err)
 		return nil, err
 	}
 
@@ -0,0 +1,314 @@
+// Package mongo implements a minimal MongoDB wire-protocol client, in
+// the same spirit as pkg/cache's hand-rolled RESP2 client: it speaks
+// BSON and OP_MSG directly over net.Conn rather than depending on the
+// official driver.
+package mongo
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"time"
+)
+
+// E is a single ordered BSON element, used to build command documents
+// where field order matters (MongoDB requires the command name be the
+// first field of a command document).
+type E struct {
+	Key   string
+	Value interface{}
+}
+
+// D is an ordered BSON document, analogous to bson.D in the official
+// driver. Nested documents that don't need a specific field order can
+// use a plain map[string]interface{} instead.
+type D []E
+
+const (
+	bsonTypeDouble   = 0x01
+	bsonTypeString   = 0x02
+	bsonTypeDocument = 0x03
+	bsonTypeArray    = 0x04
+	bsonTypeBinary   = 0x05
+	bsonTypeObjectID = 0x07
+	bsonTypeBool     = 0x08
+	bsonTypeDateTime = 0x09
+	bsonTypeNull     = 0x0A
+	bsonTypeInt32    = 0x10
+	bsonTypeInt64    = 0x12
+)
+
+// Marshal encodes a document (a D, or a map[string]interface{} for
+// documents where field order doesn't matter) into BSON bytes.
+func Marshal(doc interface{}) ([]byte, error) {
+	elements, err := toElements(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	body := make([]byte, 0, 64)
+	for _, el := range elements {
+		encoded, err := marshalElement(el.Key, el.Value)
+		if err != nil {
+			return nil, fmt.Errorf("bson: field %q: %w", el.Key, err)
+		}
+		body = append(body, encoded...)
+	}
+	body = append(body, 0x00)
+
+	out := make([]byte, 4+len(body))
+	binary.LittleEndian.PutUint32(out, uint32(len(out)))
+	copy(out[4:], body)
+	return out, nil
+}
+
+// toElements normalizes a D or map[string]interface{} into an
+// ordered slice of elements. Maps iterate in Go's randomized order,
+// which is fine for any document whose field order MongoDB doesn't
+// care about.
+func toElements(doc interface{}) ([]E, error) {
+	switch v := doc.(type) {
+	case D:
+		return v, nil
+	case map[string]interface{}:
+		elements := make([]E, 0, len(v))
+		for key, val := range v {
+			elements = append(elements, E{Key: key, Value: val})
+		}
+		return elements, nil
+	default:
+		return nil, fmt.Errorf("bson: cannot marshal %T as a document", doc)
+	}
+}
+
+func marshalElement(key string, value interface{}) ([]byte, error) {
+	switch v := value.(type) {
+	case nil:
+		return append([]byte{bsonTypeNull}, cstring(key)...), nil
+	case bool:
+		b := byte(0x00)
+		if v {
+			b = 0x01
+		}
+		return append(append([]byte{bsonTypeBool}, cstring(key)...), b), nil
+	case float64:
+		return marshalFixed(bsonTypeDouble, key, math.Float64bits(v), 8)
+	case float32:
+		return marshalFixed(bsonTypeDouble, key, math.Float64bits(float64(v)), 8)
+	case int:
+		return marshalInt(key, int64(v))
+	case int32:
+		return marshalFixed(bsonTypeInt32, key, uint64(uint32(v)), 4)
+	case int64:
+		return marshalInt(key, v)
+	case string:
+		return marshalString(key, v)
+	case time.Time:
+		millis := v.UnixNano() / int64(time.Millisecond)
+		return marshalFixed(bsonTypeDateTime, key, uint64(millis), 8)
+	case ObjectID:
+		return append(append([]byte{bsonTypeObjectID}, cstring(key)...), v[:]...), nil
+	case []byte:
+		header := make([]byte, 0, 6)
+		header = append(header, bsonTypeBinary)
+		header = append(header, cstring(key)...)
+		length := make([]byte, 4)
+		binary.LittleEndian.PutUint32(length, uint32(len(v)))
+		header = append(header, length...)
+		header = append(header, 0x00) // generic binary subtype
+		return append(header, v...), nil
+	case D:
+		return marshalSubdocument(bsonTypeDocument, key, v)
+	case map[string]interface{}:
+		return marshalSubdocument(bsonTypeDocument, key, v)
+	case []interface{}:
+		arrayDoc := make(D, len(v))
+		for i, item := range v {
+			arrayDoc[i] = E{Key: fmt.Sprintf("%d", i), Value: item}
+		}
+		return marshalSubdocument(bsonTypeArray, key, arrayDoc)
+	case []D:
+		arrayDoc := make(D, len(v))
+		for i, item := range v {
+			arrayDoc[i] = E{Key: fmt.Sprintf("%d", i), Value: item}
+		}
+		return marshalSubdocument(bsonTypeArray, key, arrayDoc)
+	default:
+		return nil, fmt.Errorf("unsupported BSON value type %T", v)
+	}
+}
+
+func marshalInt(key string, v int64) ([]byte, error) {
+	if v >= math.MinInt32 && v <= math.MaxInt32 {
+		return marshalFixed(bsonTypeInt32, key, uint64(uint32(int32(v))), 4)
+	}
+	return marshalFixed(bsonTypeInt64, key, uint64(v), 8)
+}
+
+func marshalFixed(bsonType byte, key string, bits uint64, size int) ([]byte, error) {
+	out := append([]byte{bsonType}, cstring(key)...)
+	buf := make([]byte, 8)
+	binary.LittleEndian.PutUint64(buf, bits)
+	return append(out, buf[:size]...), nil
+}
+
+func marshalString(key, value string) ([]byte, error) {
+	out := append([]byte{bsonTypeString}, cstring(key)...)
+	length := make([]byte, 4)
+	binary.LittleEndian.PutUint32(length, uint32(len(value)+1))
+	out = append(out, length...)
+	out = append(out, value...)
+	return append(out, 0x00), nil
+}
+
+func marshalSubdocument(bsonType byte, key string, doc interface{}) ([]byte, error) {
+	encoded, err := Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+	out := append([]byte{bsonType}, cstring(key)...)
+	return append(out, encoded...), nil
+}
+
+func cstring(s string) []byte {
+	return append([]byte(s), 0x00)
+}
+
+// Unmarshal decodes BSON document bytes into a generic
+// map[string]interface{}, with nested documents and arrays decoded
+// the same way encoding/json would decode them - so the result can be
+// handed straight to json.Marshal.
+func Unmarshal(data []byte) (map[string]interface{}, error) {
+	if len(data) < 5 {
+		return nil, fmt.Errorf("bson: document too short")
+	}
+	result := make(map[string]interface{})
+	pos := 4 // skip the leading int32 total length
+	for pos < len(data)-1 {
+		elemType := data[pos]
+		pos++
+		if elemType == 0x00 {
+			break
+		}
+		key, newPos, err := readCString(data, pos)
+		if err != nil {
+			return nil, err
+		}
+		pos = newPos
+		value, newPos, err := readValue(elemType, data, pos)
+		if err != nil {
+			return nil, err
+		}
+		pos = newPos
+		result[key] = value
+	}
+	return result, nil
+}
+
+func readCString(data []byte, pos int) (string, int, error) {
+	end := pos
+	for end < len(data) && data[end] != 0x00 {
+		end++
+	}
+	if end >= len(data) {
+		return "", 0, fmt.Errorf("bson: unterminated cstring")
+	}
+	return string(data[pos:end]), end + 1, nil
+}
+
+func readValue(elemType byte, data []byte, pos int) (interface{}, int, error) {
+	switch elemType {
+	case bsonTypeDouble:
+		if pos+8 > len(data) {
+			return nil, 0, fmt.Errorf("bson: truncated double")
+		}
+		bits := binary.LittleEndian.Uint64(data[pos : pos+8])
+		return math.Float64frombits(bits), pos + 8, nil
+	case bsonTypeString:
+		return readBSONString(data, pos)
+	case bsonTypeDocument:
+		return readSubdocument(data, pos, false)
+	case bsonTypeArray:
+		return readSubdocument(data, pos, true)
+	case bsonTypeBinary:
+		if pos+5 > len(data) {
+			return nil, 0, fmt.Errorf("bson: truncated binary")
+		}
+		length := int(binary.LittleEndian.Uint32(data[pos : pos+4]))
+		start := pos + 5 // length(4) + subtype(1)
+		if start+length > len(data) {
+			return nil, 0, fmt.Errorf("bson: truncated binary payload")
+		}
+		return append([]byte{}, data[start:start+length]...), start + length, nil
+	case bsonTypeObjectID:
+		if pos+12 > len(data) {
+			return nil, 0, fmt.Errorf("bson: truncated objectid")
+		}
+		var id ObjectID
+		copy(id[:], data[pos:pos+12])
+		return id.Hex(), pos + 12, nil
+	case bsonTypeBool:
+		if pos+1 > len(data) {
+			return nil, 0, fmt.Errorf("bson: truncated bool")
+		}
+		return data[pos] != 0x00, pos + 1, nil
+	case bsonTypeDateTime:
+		if pos+8 > len(data) {
+			return nil, 0, fmt.Errorf("bson: truncated datetime")
+		}
+		millis := int64(binary.LittleEndian.Uint64(data[pos : pos+8]))
+		return time.Unix(0, millis*int64(time.Millisecond)).UTC(), pos + 8, nil
+	case bsonTypeNull:
+		return nil, pos, nil
+	case bsonTypeInt32:
+		if pos+4 > len(data) {
+			return nil, 0, fmt.Errorf("bson: truncated int32")
+		}
+		return int32(binary.LittleEndian.Uint32(data[pos : pos+4])), pos + 4, nil
+	case bsonTypeInt64:
+		if pos+8 > len(data) {
+			return nil, 0, fmt.Errorf("bson: truncated int64")
+		}
+		return int64(binary.LittleEndian.Uint64(data[pos : pos+8])), pos + 8, nil
+	default:
+		return nil, 0, fmt.Errorf("bson: unsupported element type 0x%02x", elemType)
+	}
+}
+
+func readBSONString(data []byte, pos int) (string, int, error) {
+	if pos+4 > len(data) {
+		return "", 0, fmt.Errorf("bson: truncated string length")
+	}
+	length := int(binary.LittleEndian.Uint32(data[pos : pos+4]))
+	start := pos + 4
+	if length < 1 || start+length > len(data) {
+		return "", 0, fmt.Errorf("bson: truncated string")
+	}
+	return string(data[start : start+length-1]), start + length, nil
+}
+
+// readSubdocument decodes a nested document (or array, whose keys are
+// "0", "1", ... by BSON convention) starting at pos, and returns it
+// as a map or a slice respectively.
+func readSubdocument(data []byte, pos int, isArray bool) (interface{}, int, error) {
+	if pos+4 > len(data) {
+		return nil, 0, fmt.Errorf("bson: truncated document length")
+	}
+	size := int(binary.LittleEndian.Uint32(data[pos : pos+4]))
+	end := pos + size
+	if end > len(data) {
+		return nil, 0, fmt.Errorf("bson: truncated document body")
+	}
+	doc, err := Unmarshal(data[pos:end])
+	if err != nil {
+		return nil, 0, err
+	}
+	if !isArray {
+		return doc, end, nil
+	}
+	items := make([]interface{}, len(doc))
+	for i := range items {
+		items[i] = doc[fmt.Sprintf("%d", i)]
+	}
+	return items, end, nil
+}
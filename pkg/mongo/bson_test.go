@@ -0,0 +1,120 @@
+package mongo
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestMarshalUnmarshalRoundTrip(t *testing.T) {
+	now := time.Unix(1700000000, 0).UTC()
+	id := NewObjectID()
+
+	doc := D{
+		{Key: "str", Value: "hello"},
+		{Key: "i32", Value: int32(42)},
+		{Key: "i64", Value: int64(9000000000)},
+		{Key: "f64", Value: 3.5},
+		{Key: "flag", Value: true},
+		{Key: "nothing", Value: nil},
+		{Key: "when", Value: now},
+		{Key: "id", Value: id},
+		{Key: "bin", Value: []byte("raw")},
+		{Key: "sub", Value: D{{Key: "nested", Value: "value"}}},
+		{Key: "list", Value: []interface{}{int32(1), int32(2), int32(3)}},
+	}
+
+	encoded, err := Marshal(doc)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+
+	decoded, err := Unmarshal(encoded)
+	if err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	if decoded["str"] != "hello" {
+		t.Errorf("str = %v, want hello", decoded["str"])
+	}
+	if decoded["i32"] != int32(42) {
+		t.Errorf("i32 = %v, want 42", decoded["i32"])
+	}
+	if decoded["i64"] != int64(9000000000) {
+		t.Errorf("i64 = %v, want 9000000000", decoded["i64"])
+	}
+	if decoded["f64"] != 3.5 {
+		t.Errorf("f64 = %v, want 3.5", decoded["f64"])
+	}
+	if decoded["flag"] != true {
+		t.Errorf("flag = %v, want true", decoded["flag"])
+	}
+	if decoded["nothing"] != nil {
+		t.Errorf("nothing = %v, want nil", decoded["nothing"])
+	}
+	if decodedTime, ok := decoded["when"].(time.Time); !ok || !decodedTime.Equal(now) {
+		t.Errorf("when = %v, want %v", decoded["when"], now)
+	}
+	if decoded["id"] != id.Hex() {
+		t.Errorf("id = %v, want %v", decoded["id"], id.Hex())
+	}
+	if bin, ok := decoded["bin"].([]byte); !ok || string(bin) != "raw" {
+		t.Errorf("bin = %v, want raw", decoded["bin"])
+	}
+	sub, ok := decoded["sub"].(map[string]interface{})
+	if !ok || sub["nested"] != "value" {
+		t.Errorf("sub = %v, want map with nested=value", decoded["sub"])
+	}
+	list, ok := decoded["list"].([]interface{})
+	if !ok || !reflect.DeepEqual(list, []interface{}{int32(1), int32(2), int32(3)}) {
+		t.Errorf("list = %v, want [1 2 3]", decoded["list"])
+	}
+}
+
+func TestMarshalMap(t *testing.T) {
+	encoded, err := Marshal(map[string]interface{}{"key": "value"})
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	decoded, err := Unmarshal(encoded)
+	if err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if decoded["key"] != "value" {
+		t.Errorf("key = %v, want value", decoded["key"])
+	}
+}
+
+func TestUnmarshalTruncated(t *testing.T) {
+	if _, err := Unmarshal([]byte{1, 2, 3}); err == nil {
+		t.Error("Unmarshal() on truncated input returned no error")
+	}
+}
+
+func TestObjectIDHexRoundTrip(t *testing.T) {
+	id := NewObjectID()
+	parsed, err := ObjectIDFromHex(id.Hex())
+	if err != nil {
+		t.Fatalf("ObjectIDFromHex() error = %v", err)
+	}
+	if parsed != id {
+		t.Errorf("parsed = %v, want %v", parsed, id)
+	}
+}
+
+func TestObjectIDFromHexInvalid(t *testing.T) {
+	if _, err := ObjectIDFromHex("not-hex"); err == nil {
+		t.Error("ObjectIDFromHex() on invalid hex returned no error")
+	}
+	if _, err := ObjectIDFromHex("abcd"); err == nil {
+		t.Error("ObjectIDFromHex() on short hex returned no error")
+	}
+}
+
+func TestObjectIDsAreUnique(t *testing.T) {
+	a := NewObjectID()
+	b := NewObjectID()
+	if a == b {
+		t.Error("NewObjectID() returned the same id twice in a row")
+	}
+}
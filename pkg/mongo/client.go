@@ -0,0 +1,200 @@
+package mongo
+
+import (
+	"fmt"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Client is a connection to a single mongod/mongos, speaking OP_MSG
+// directly over net.Conn (see pkg/cache's RedisCache for the same
+// approach applied to RESP2).
+type Client struct {
+	conn      net.Conn
+	mu        sync.Mutex
+	requestID int32
+}
+
+// Connect dials addr ("host:port") and returns a Client. It does not
+// perform the optional "hello" handshake real drivers use to
+// negotiate wire version and topology - every command is sent
+// directly as OP_MSG, which every server supporting OP_MSG at all
+// (MongoDB 3.6+) accepts without it.
+func Connect(addr string) (*Client, error) {
+	conn, err := net.DialTimeout("tcp", addr, 5*time.Second)
+	if err != nil {
+		return nil, fmt.Errorf("connecting to mongo at %s: %w", addr, err)
+	}
+	return &Client{conn: conn}, nil
+}
+
+// Close closes the underlying connection.
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
+
+// RunCommand sends a command document against db and returns the
+// server's reply document. $db is added automatically if not already
+// present.
+func (c *Client) RunCommand(db string, cmd D) (map[string]interface{}, error) {
+	hasDB := false
+	for _, el := range cmd {
+		if el.Key == "$db" {
+			hasDB = true
+			break
+		}
+	}
+	if !hasDB {
+		cmd = append(cmd, E{Key: "$db", Value: db})
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	reqID := atomic.AddInt32(&c.requestID, 1)
+	if err := sendCommand(c.conn, reqID, cmd); err != nil {
+		return nil, err
+	}
+	reply, err := readReply(c.conn)
+	if err != nil {
+		return nil, err
+	}
+	if ok, _ := reply["ok"].(float64); ok != 1 {
+		if errmsg, ok := reply["errmsg"].(string); ok {
+			return reply, fmt.Errorf("mongo command failed: %s", errmsg)
+		}
+		return reply, fmt.Errorf("mongo command failed: %v", reply)
+	}
+	return reply, nil
+}
+
+// Find runs a find command against collection, returning the first
+// batch of matching documents. limit <= 0 means no limit.
+func (c *Client) Find(db, collection string, filter interface{}, limit int64) ([]interface{}, error) {
+	cmd := D{
+		{Key: "find", Value: collection},
+		{Key: "filter", Value: filter},
+	}
+	if limit > 0 {
+		cmd = append(cmd, E{Key: "limit", Value: limit})
+	}
+
+	reply, err := c.RunCommand(db, cmd)
+	if err != nil {
+		return nil, err
+	}
+	return firstBatch(reply)
+}
+
+// Insert runs an insert command, writing docs to collection, and
+// returns the number of documents the server reported as inserted.
+// Any document missing an "_id" field is assigned a fresh ObjectID.
+func (c *Client) Insert(db, collection string, docs []interface{}) (int, error) {
+	for _, doc := range docs {
+		if m, ok := doc.(map[string]interface{}); ok {
+			if _, ok := m["_id"]; !ok {
+				m["_id"] = NewObjectID().Hex()
+			}
+		}
+	}
+
+	reply, err := c.RunCommand(db, D{
+		{Key: "insert", Value: collection},
+		{Key: "documents", Value: docs},
+	})
+	if err != nil {
+		return 0, err
+	}
+	n, _ := reply["n"].(int32)
+	return int(n), nil
+}
+
+// Aggregate runs an aggregate command with the given pipeline against
+// collection, returning the first batch of result documents.
+func (c *Client) Aggregate(db, collection string, pipeline []interface{}) ([]interface{}, error) {
+	reply, err := c.RunCommand(db, D{
+		{Key: "aggregate", Value: collection},
+		{Key: "pipeline", Value: pipeline},
+		{Key: "cursor", Value: map[string]interface{}{}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return firstBatch(reply)
+}
+
+// firstBatch extracts cursor.firstBatch from a find/aggregate reply.
+func firstBatch(reply map[string]interface{}) ([]interface{}, error) {
+	cursor, ok := reply["cursor"].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("mongo reply missing cursor document: %v", reply)
+	}
+	batch, ok := cursor["firstBatch"].([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("mongo reply missing cursor.firstBatch: %v", cursor)
+	}
+	return batch, nil
+}
+
+// Manager tracks named Client connections, the same role
+// database.DatabaseManager plays for SQL connections.
+type Manager struct {
+	mu      sync.RWMutex
+	clients map[string]*namedClient
+}
+
+type namedClient struct {
+	client *Client
+	db     string
+}
+
+// NewManager creates an empty connection registry.
+func NewManager() *Manager {
+	return &Manager{clients: make(map[string]*namedClient)}
+}
+
+// Register connects to addr and registers it under name, along with
+// the default database subsequent commands target. Registering again
+// under a name that's already registered replaces the existing
+// connection, closing the old one.
+func (m *Manager) Register(name, addr, db string) error {
+	client, err := Connect(addr)
+	if err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if existing, ok := m.clients[name]; ok {
+		existing.client.Close()
+	}
+	m.clients[name] = &namedClient{client: client, db: db}
+	return nil
+}
+
+// Get returns the named connection's client and default database.
+func (m *Manager) Get(name string) (*Client, string, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	nc, ok := m.clients[name]
+	if !ok {
+		return nil, "", fmt.Errorf("no mongo connection named %q (did you call ConnectMongo?)", name)
+	}
+	return nc.client, nc.db, nil
+}
+
+// Close closes every registered connection.
+func (m *Manager) Close() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var firstErr error
+	for name, nc := range m.clients {
+		if err := nc.client.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+		delete(m.clients, name)
+	}
+	return firstErr
+}
@@ -0,0 +1,196 @@
+package mongo
+
+import (
+	"net"
+	"sync"
+	"testing"
+)
+
+// fakeMongoServer is a minimal OP_MSG server backing the find/insert/
+// aggregate commands Client issues, so tests exercise the real wire
+// protocol without requiring an actual mongod in the sandbox.
+type fakeMongoServer struct {
+	ln   net.Listener
+	mu   sync.Mutex
+	docs []interface{}
+}
+
+func startFakeMongoServer(t *testing.T) *fakeMongoServer {
+	t.Helper()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("net.Listen() error = %v", err)
+	}
+	srv := &fakeMongoServer{ln: ln}
+	go srv.serve()
+	t.Cleanup(func() { ln.Close() })
+	return srv
+}
+
+func (s *fakeMongoServer) addr() string {
+	return s.ln.Addr().String()
+}
+
+func (s *fakeMongoServer) serve() {
+	for {
+		conn, err := s.ln.Accept()
+		if err != nil {
+			return
+		}
+		go s.handle(conn)
+	}
+}
+
+func (s *fakeMongoServer) handle(conn net.Conn) {
+	defer conn.Close()
+	for {
+		cmd, err := readReply(conn) // a request has the same OP_MSG framing as a reply
+		if err != nil {
+			return
+		}
+
+		reply := s.apply(cmd)
+		if err := sendCommand(conn, 0, toD(reply)); err != nil {
+			return
+		}
+	}
+}
+
+func toD(m map[string]interface{}) D {
+	d := make(D, 0, len(m))
+	for k, v := range m {
+		d = append(d, E{Key: k, Value: v})
+	}
+	return d
+}
+
+func (s *fakeMongoServer) apply(cmd map[string]interface{}) map[string]interface{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := cmd["find"]; ok {
+		return map[string]interface{}{
+			"ok": float64(1),
+			"cursor": map[string]interface{}{
+				"firstBatch": append([]interface{}{}, s.docs...),
+			},
+		}
+	}
+	if _, ok := cmd["aggregate"]; ok {
+		return map[string]interface{}{
+			"ok": float64(1),
+			"cursor": map[string]interface{}{
+				"firstBatch": append([]interface{}{}, s.docs...),
+			},
+		}
+	}
+	if _, ok := cmd["insert"]; ok {
+		documents, _ := cmd["documents"].([]interface{})
+		s.docs = append(s.docs, documents...)
+		return map[string]interface{}{
+			"ok": float64(1),
+			"n":  int32(len(documents)),
+		}
+	}
+	return map[string]interface{}{
+		"ok":     float64(0),
+		"errmsg": "unsupported command in fake mongo server",
+	}
+}
+
+func TestClientInsertAndFind(t *testing.T) {
+	srv := startFakeMongoServer(t)
+	client, err := Connect(srv.addr())
+	if err != nil {
+		t.Fatalf("Connect() error = %v", err)
+	}
+	defer client.Close()
+
+	docs := []interface{}{
+		map[string]interface{}{"name": "alice"},
+		map[string]interface{}{"name": "bob"},
+	}
+	n, err := client.Insert("testdb", "users", docs)
+	if err != nil {
+		t.Fatalf("Insert() error = %v", err)
+	}
+	if n != 2 {
+		t.Errorf("Insert() = %d, want 2", n)
+	}
+
+	for _, doc := range docs {
+		m := doc.(map[string]interface{})
+		if _, ok := m["_id"]; !ok {
+			t.Errorf("document %v was not assigned an _id", m)
+		}
+	}
+
+	found, err := client.Find("testdb", "users", map[string]interface{}{}, 0)
+	if err != nil {
+		t.Fatalf("Find() error = %v", err)
+	}
+	if len(found) != 2 {
+		t.Errorf("Find() returned %d documents, want 2", len(found))
+	}
+}
+
+func TestClientAggregate(t *testing.T) {
+	srv := startFakeMongoServer(t)
+	client, err := Connect(srv.addr())
+	if err != nil {
+		t.Fatalf("Connect() error = %v", err)
+	}
+	defer client.Close()
+
+	srv.docs = []interface{}{map[string]interface{}{"count": int32(3)}}
+
+	results, err := client.Aggregate("testdb", "users", []interface{}{
+		map[string]interface{}{"$count": "count"},
+	})
+	if err != nil {
+		t.Fatalf("Aggregate() error = %v", err)
+	}
+	if len(results) != 1 {
+		t.Fatalf("Aggregate() returned %d documents, want 1", len(results))
+	}
+}
+
+func TestClientRunCommandError(t *testing.T) {
+	srv := startFakeMongoServer(t)
+	client, err := Connect(srv.addr())
+	if err != nil {
+		t.Fatalf("Connect() error = %v", err)
+	}
+	defer client.Close()
+
+	_, err = client.RunCommand("testdb", D{{Key: "ping", Value: int32(1)}})
+	if err == nil {
+		t.Error("RunCommand() on an unsupported command returned no error")
+	}
+}
+
+func TestManagerRegisterAndGet(t *testing.T) {
+	srv := startFakeMongoServer(t)
+	m := NewManager()
+	defer m.Close()
+
+	if err := m.Register("primary", srv.addr(), "testdb"); err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+
+	client, db, err := m.Get("primary")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if client == nil {
+		t.Error("Get() returned a nil client")
+	}
+	if db != "testdb" {
+		t.Errorf("Get() db = %q, want testdb", db)
+	}
+
+	if _, _, err := m.Get("missing"); err == nil {
+		t.Error("Get() on an unregistered name returned no error")
+	}
+}
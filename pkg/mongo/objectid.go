@@ -0,0 +1,63 @@
+package mongo
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+// ObjectID is MongoDB's 12-byte document identifier: a 4-byte
+// timestamp, a 5-byte random machine/process identifier, and a
+// 3-byte counter.
+type ObjectID [12]byte
+
+// processUnique is generated once per process and used as the 5-byte
+// "random" component of every ObjectID it creates, matching the
+// official driver's approach.
+var processUnique = func() [5]byte {
+	var b [5]byte
+	_, _ = rand.Read(b[:])
+	return b
+}()
+
+var objectIDCounter uint32
+
+// NewObjectID generates a fresh ObjectID, suitable as the _id of a
+// document InsertMongo is about to write.
+func NewObjectID() ObjectID {
+	var id ObjectID
+	binary.BigEndian.PutUint32(id[0:4], uint32(time.Now().Unix()))
+	copy(id[4:9], processUnique[:])
+
+	count := atomic.AddUint32(&objectIDCounter, 1)
+	id[9] = byte(count >> 16)
+	id[10] = byte(count >> 8)
+	id[11] = byte(count)
+	return id
+}
+
+// Hex returns the ObjectID's canonical 24-character hex encoding.
+func (id ObjectID) Hex() string {
+	return hex.EncodeToString(id[:])
+}
+
+func (id ObjectID) String() string {
+	return fmt.Sprintf("ObjectID(%q)", id.Hex())
+}
+
+// ObjectIDFromHex parses a 24-character hex string into an ObjectID.
+func ObjectIDFromHex(s string) (ObjectID, error) {
+	var id ObjectID
+	decoded, err := hex.DecodeString(s)
+	if err != nil {
+		return id, fmt.Errorf("invalid ObjectID %q: %w", s, err)
+	}
+	if len(decoded) != 12 {
+		return id, fmt.Errorf("invalid ObjectID %q: must be 12 bytes, got %d", s, len(decoded))
+	}
+	copy(id[:], decoded)
+	return id, nil
+}
@@ -0,0 +1,100 @@
+package mongo
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// opMsg is the wire protocol opcode for OP_MSG (MongoDB 3.6+), the
+// only message type this client speaks - modern servers accept OP_MSG
+// for every command, including the initial handshake.
+const opMsg = 2013
+
+// sendCommand writes cmd as a single-section OP_MSG message: a
+// section-kind-0 BSON document is both the simplest and, for find /
+// insert / aggregate, a fully valid way to send the command (document
+// arrays like "documents" or "pipeline" are embedded inline rather
+// than streamed as a kind-1 section).
+func sendCommand(w io.Writer, requestID int32, cmd D) error {
+	body, err := Marshal(cmd)
+	if err != nil {
+		return fmt.Errorf("marshaling command: %w", err)
+	}
+
+	// flagBits(4) + section kind(1) + document
+	payload := make([]byte, 0, 4+1+len(body))
+	payload = append(payload, 0, 0, 0, 0) // flagBits: none set
+	payload = append(payload, 0x00)       // section kind 0: body document
+	payload = append(payload, body...)
+
+	header := make([]byte, 16)
+	binary.LittleEndian.PutUint32(header[0:4], uint32(16+len(payload)))
+	binary.LittleEndian.PutUint32(header[4:8], uint32(requestID))
+	binary.LittleEndian.PutUint32(header[8:12], 0) // responseTo
+	binary.LittleEndian.PutUint32(header[12:16], opMsg)
+
+	if _, err := w.Write(header); err != nil {
+		return err
+	}
+	_, err = w.Write(payload)
+	return err
+}
+
+// readReply reads one OP_MSG response and returns its section-kind-0
+// document (the command reply). Only section kind 0 is supported on
+// read, since that's all a server sends back for the commands this
+// client issues.
+func readReply(r io.Reader) (map[string]interface{}, error) {
+	header := make([]byte, 16)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, fmt.Errorf("reading message header: %w", err)
+	}
+	messageLength := binary.LittleEndian.Uint32(header[0:4])
+	opCode := binary.LittleEndian.Uint32(header[12:16])
+	if opCode != opMsg {
+		return nil, fmt.Errorf("unexpected opcode %d in reply (only OP_MSG is supported)", opCode)
+	}
+	if messageLength < 16 {
+		return nil, fmt.Errorf("invalid message length %d", messageLength)
+	}
+
+	body := make([]byte, messageLength-16)
+	if _, err := io.ReadFull(r, body); err != nil {
+		return nil, fmt.Errorf("reading message body: %w", err)
+	}
+
+	pos := 4 // skip flagBits
+	for pos < len(body) {
+		kind := body[pos]
+		pos++
+		switch kind {
+		case 0x00:
+			if pos+4 > len(body) {
+				return nil, fmt.Errorf("truncated section 0 document")
+			}
+			docLen := int(binary.LittleEndian.Uint32(body[pos : pos+4]))
+			if pos+docLen > len(body) {
+				return nil, fmt.Errorf("truncated section 0 document body")
+			}
+			doc, err := Unmarshal(body[pos : pos+docLen])
+			if err != nil {
+				return nil, err
+			}
+			return doc, nil
+		case 0x01:
+			// Document sequence section: length(4) + identifier cstring +
+			// documents. Not produced by any command this client sends,
+			// but skip it correctly rather than misreading the rest of
+			// the message if a server ever includes one.
+			if pos+4 > len(body) {
+				return nil, fmt.Errorf("truncated section 1")
+			}
+			seqLen := int(binary.LittleEndian.Uint32(body[pos : pos+4]))
+			pos += seqLen
+		default:
+			return nil, fmt.Errorf("unsupported OP_MSG section kind %d", kind)
+		}
+	}
+	return nil, fmt.Errorf("OP_MSG reply had no section 0 document")
+}
@@ -0,0 +1,194 @@
+// Package observability generates Grafana dashboards and Prometheus
+// alert rules for the metrics pkg/metrics/exporter exposes, reading
+// metric names and help text from exporter.Catalog rather than
+// hardcoding its own copy, so the generated panels and rules can't
+// drift out of sync with the metrics the runtime actually emits.
+package observability
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"gitee.com/com_818cloud/shode/pkg/metrics/exporter"
+	"gopkg.in/yaml.v3"
+)
+
+// DashboardTitle is the title Grafana shows for the generated
+// dashboard.
+const DashboardTitle = "Shode Runtime"
+
+// grafanaDashboard mirrors the subset of Grafana's dashboard JSON
+// schema this package fills in. Fields Grafana itself defaults are
+// left out.
+type grafanaDashboard struct {
+	Title  string            `json:"title"`
+	Tags   []string          `json:"tags"`
+	Panels []grafanaPanel    `json:"panels"`
+	Time   map[string]string `json:"time"`
+}
+
+type grafanaPanel struct {
+	ID        int                  `json:"id"`
+	Title     string               `json:"title"`
+	Type      string               `json:"type"`
+	GridPos   grafanaGridPos       `json:"gridPos"`
+	Targets   []grafanaPanelTarget `json:"targets"`
+	FieldUnit string               `json:"fieldUnit,omitempty"`
+}
+
+type grafanaGridPos struct {
+	H int `json:"h"`
+	W int `json:"w"`
+	X int `json:"x"`
+	Y int `json:"y"`
+}
+
+type grafanaPanelTarget struct {
+	Expr         string `json:"expr"`
+	LegendFormat string `json:"legendFormat"`
+}
+
+// panelsPerRow controls the dashboard grid layout; four panels of
+// width 6 fill Grafana's 24-column grid per row.
+const panelsPerRow = 4
+
+// GenerateDashboard renders a Grafana dashboard JSON document with one
+// panel per metric in exporter.Catalog, gauges and counters both shown
+// as time series (rates, for counters, read better as "per second" in
+// Grafana's own query editor than baked into the generated expr).
+func GenerateDashboard() ([]byte, error) {
+	dashboard := grafanaDashboard{
+		Title: DashboardTitle,
+		Tags:  []string{"shode", "generated"},
+		Time:  map[string]string{"from": "now-6h", "to": "now"},
+	}
+
+	for i, def := range exporter.Catalog {
+		panel := grafanaPanel{
+			ID:    i + 1,
+			Title: def.Help,
+			Type:  "timeseries",
+			GridPos: grafanaGridPos{
+				H: 8,
+				W: 24 / panelsPerRow,
+				X: (i % panelsPerRow) * (24 / panelsPerRow),
+				Y: (i / panelsPerRow) * 8,
+			},
+			Targets: []grafanaPanelTarget{{
+				Expr:         panelExpr(def),
+				LegendFormat: def.Name,
+			}},
+		}
+		dashboard.Panels = append(dashboard.Panels, panel)
+	}
+
+	return json.MarshalIndent(dashboard, "", "  ")
+}
+
+// panelExpr returns the PromQL expression a panel should query:
+// counters are rendered as a per-second rate over 5 minutes, gauges as
+// the raw instant value.
+func panelExpr(def exporter.MetricDef) string {
+	if def.Type == "counter" {
+		return fmt.Sprintf("rate(%s[5m])", def.Name)
+	}
+	return def.Name
+}
+
+// AlertRule is one Prometheus alerting rule. Fields follow
+// Prometheus's own rule file schema.
+type AlertRule struct {
+	Alert       string            `yaml:"alert"`
+	Expr        string            `yaml:"expr"`
+	For         string            `yaml:"for"`
+	Labels      map[string]string `yaml:"labels"`
+	Annotations map[string]string `yaml:"annotations"`
+}
+
+type alertRuleGroup struct {
+	Name  string      `yaml:"name"`
+	Rules []AlertRule `yaml:"rules"`
+}
+
+type alertRuleFile struct {
+	Groups []alertRuleGroup `yaml:"groups"`
+}
+
+// alertDefs are the curated alert rules this package knows how to
+// derive from exporter.Catalog. Each references metric names pulled
+// from the catalog's MetricDef values, so a metric rename in
+// exporter.go is a compile error here rather than a silently stale
+// alert.
+var alertDefs = func() []AlertRule {
+	commandFailureRate := fmt.Sprintf(
+		"rate(%s[5m]) / clamp_min(rate(%s[5m]) + rate(%s[5m]), 1) > 0.1",
+		metricName("shode_engine_command_failures_total"),
+		metricName("shode_engine_command_failures_total"),
+		metricName("shode_engine_command_successes_total"),
+	)
+
+	return []AlertRule{
+		{
+			Alert: "ShodeHighCommandFailureRate",
+			Expr:  commandFailureRate,
+			For:   "5m",
+			Labels: map[string]string{
+				"severity": "warning",
+			},
+			Annotations: map[string]string{
+				"summary":     "More than 10% of shode commands are failing",
+				"description": "Over the last 5 minutes, {{ $value | humanizePercentage }} of commands executed by the engine failed or timed out.",
+			},
+		},
+		{
+			Alert: "ShodeLowCacheHitRatio",
+			Expr:  fmt.Sprintf("%s < 0.5", metricName("shode_engine_cache_hit_ratio")),
+			For:   "15m",
+			Labels: map[string]string{
+				"severity": "warning",
+			},
+			Annotations: map[string]string{
+				"summary":     "Shode command result cache hit rate is below 50%",
+				"description": "The command result cache hit ratio has been below 0.5 for 15 minutes, suggesting caching isn't paying off for this workload.",
+			},
+		},
+		{
+			Alert: "ShodeAutoOptimizerCPUAlertsFiring",
+			Expr:  fmt.Sprintf("increase(%s[10m]) > 0", metricName("shode_autooptimizer_alerts_total")),
+			For:   "0m",
+			Labels: map[string]string{
+				"severity": "info",
+			},
+			Annotations: map[string]string{
+				"summary":     "AutoOptimizer recorded a CPU usage alert",
+				"description": "The AutoOptimizer sampled process CPU usage at or above its configured threshold within the last 10 minutes.",
+			},
+		},
+	}
+}()
+
+// metricName looks up name in exporter.Catalog and panics if it isn't
+// there - a safeguard against this file and exporter.go drifting
+// apart, since a rename in one without the other would otherwise
+// silently generate an alert rule against a metric that no longer
+// exists.
+func metricName(name string) string {
+	for _, def := range exporter.Catalog {
+		if def.Name == name {
+			return def.Name
+		}
+	}
+	panic(fmt.Sprintf("observability: alert rule references unknown metric %q - update exporter.Catalog or this rule", name))
+}
+
+// GenerateAlertRules renders alertDefs as a Prometheus rule file
+// ("groups:" document), ready to drop into rule_files.
+func GenerateAlertRules() ([]byte, error) {
+	file := alertRuleFile{
+		Groups: []alertRuleGroup{{
+			Name:  "shode.rules",
+			Rules: alertDefs,
+		}},
+	}
+	return yaml.Marshal(file)
+}
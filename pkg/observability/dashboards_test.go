@@ -0,0 +1,105 @@
+package observability
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"gitee.com/com_818cloud/shode/pkg/metrics/exporter"
+	"gopkg.in/yaml.v3"
+)
+
+func TestGenerateDashboardCoversCatalog(t *testing.T) {
+	data, err := GenerateDashboard()
+	if err != nil {
+		t.Fatalf("GenerateDashboard returned error: %v", err)
+	}
+
+	var dashboard grafanaDashboard
+	if err := json.Unmarshal(data, &dashboard); err != nil {
+		t.Fatalf("generated dashboard is not valid JSON: %v", err)
+	}
+
+	if dashboard.Title != DashboardTitle {
+		t.Fatalf("expected title %q, got %q", DashboardTitle, dashboard.Title)
+	}
+	if len(dashboard.Panels) != len(exporter.Catalog) {
+		t.Fatalf("expected %d panels (one per catalog metric), got %d", len(exporter.Catalog), len(dashboard.Panels))
+	}
+
+	for _, def := range exporter.Catalog {
+		found := false
+		for _, panel := range dashboard.Panels {
+			if len(panel.Targets) == 1 && strings.Contains(panel.Targets[0].Expr, def.Name) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Errorf("expected a panel querying metric %q, found none", def.Name)
+		}
+	}
+}
+
+func TestGenerateDashboardRendersCounterRatesAndGaugeValues(t *testing.T) {
+	data, err := GenerateDashboard()
+	if err != nil {
+		t.Fatalf("GenerateDashboard returned error: %v", err)
+	}
+	var dashboard grafanaDashboard
+	if err := json.Unmarshal(data, &dashboard); err != nil {
+		t.Fatalf("generated dashboard is not valid JSON: %v", err)
+	}
+
+	byName := make(map[string]grafanaPanel)
+	for _, panel := range dashboard.Panels {
+		byName[panel.Targets[0].LegendFormat] = panel
+	}
+
+	counter := byName["shode_engine_command_executions_total"]
+	if !strings.HasPrefix(counter.Targets[0].Expr, "rate(") {
+		t.Errorf("expected counter metric to be queried as a rate, got %q", counter.Targets[0].Expr)
+	}
+
+	gauge := byName["shode_engine_cache_hit_ratio"]
+	if gauge.Targets[0].Expr != "shode_engine_cache_hit_ratio" {
+		t.Errorf("expected gauge metric to be queried directly, got %q", gauge.Targets[0].Expr)
+	}
+}
+
+func TestGenerateAlertRulesReferenceKnownMetrics(t *testing.T) {
+	data, err := GenerateAlertRules()
+	if err != nil {
+		t.Fatalf("GenerateAlertRules returned error: %v", err)
+	}
+
+	var file alertRuleFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		t.Fatalf("generated alert rules are not valid YAML: %v", err)
+	}
+
+	if len(file.Groups) != 1 || len(file.Groups[0].Rules) == 0 {
+		t.Fatalf("expected at least one alert rule, got %+v", file.Groups)
+	}
+
+	known := make(map[string]bool, len(exporter.Catalog))
+	for _, def := range exporter.Catalog {
+		known[def.Name] = true
+	}
+
+	for _, rule := range file.Groups[0].Rules {
+		if rule.Alert == "" || rule.Expr == "" {
+			t.Errorf("alert rule missing name or expr: %+v", rule)
+		}
+		matched := false
+		for name := range known {
+			if strings.Contains(rule.Expr, name) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			t.Errorf("alert rule %q expr %q does not reference any known catalog metric", rule.Alert, rule.Expr)
+		}
+	}
+}
@@ -0,0 +1,165 @@
+package optimizer
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// evalConstantArithmetic evaluates expr - the body of a $((expr))
+// arithmetic expansion - as a constant integer expression. It supports
+// +, -, *, /, %, unary +/-, and parentheses over integer literals only;
+// it returns an error for anything else (variables, command
+// substitutions, etc.), since those aren't foldable at parse time.
+func evalConstantArithmetic(expr string) (int64, error) {
+	p := &arithParser{input: expr}
+	p.skipSpace()
+	v, err := p.parseExpr()
+	if err != nil {
+		return 0, err
+	}
+	p.skipSpace()
+	if p.pos != len(p.input) {
+		return 0, fmt.Errorf("unexpected trailing input %q", p.input[p.pos:])
+	}
+	return v, nil
+}
+
+type arithParser struct {
+	input string
+	pos   int
+}
+
+func (p *arithParser) skipSpace() {
+	for p.pos < len(p.input) && (p.input[p.pos] == ' ' || p.input[p.pos] == '\t') {
+		p.pos++
+	}
+}
+
+func (p *arithParser) peek() byte {
+	if p.pos >= len(p.input) {
+		return 0
+	}
+	return p.input[p.pos]
+}
+
+// parseExpr handles + and - at the lowest precedence.
+func (p *arithParser) parseExpr() (int64, error) {
+	v, err := p.parseTerm()
+	if err != nil {
+		return 0, err
+	}
+	for {
+		p.skipSpace()
+		switch p.peek() {
+		case '+':
+			p.pos++
+			rhs, err := p.parseTerm()
+			if err != nil {
+				return 0, err
+			}
+			v += rhs
+		case '-':
+			p.pos++
+			rhs, err := p.parseTerm()
+			if err != nil {
+				return 0, err
+			}
+			v -= rhs
+		default:
+			return v, nil
+		}
+	}
+}
+
+// parseTerm handles *, /, and % at the next precedence up.
+func (p *arithParser) parseTerm() (int64, error) {
+	v, err := p.parseUnary()
+	if err != nil {
+		return 0, err
+	}
+	for {
+		p.skipSpace()
+		switch p.peek() {
+		case '*':
+			p.pos++
+			rhs, err := p.parseUnary()
+			if err != nil {
+				return 0, err
+			}
+			v *= rhs
+		case '/':
+			p.pos++
+			rhs, err := p.parseUnary()
+			if err != nil {
+				return 0, err
+			}
+			if rhs == 0 {
+				return 0, fmt.Errorf("division by zero")
+			}
+			v /= rhs
+		case '%':
+			p.pos++
+			rhs, err := p.parseUnary()
+			if err != nil {
+				return 0, err
+			}
+			if rhs == 0 {
+				return 0, fmt.Errorf("division by zero")
+			}
+			v %= rhs
+		default:
+			return v, nil
+		}
+	}
+}
+
+func (p *arithParser) parseUnary() (int64, error) {
+	p.skipSpace()
+	switch p.peek() {
+	case '+':
+		p.pos++
+		return p.parseUnary()
+	case '-':
+		p.pos++
+		v, err := p.parseUnary()
+		return -v, err
+	default:
+		return p.parsePrimary()
+	}
+}
+
+func (p *arithParser) parsePrimary() (int64, error) {
+	p.skipSpace()
+	if p.peek() == '(' {
+		p.pos++
+		v, err := p.parseExpr()
+		if err != nil {
+			return 0, err
+		}
+		p.skipSpace()
+		if p.peek() != ')' {
+			return 0, fmt.Errorf("expected ')'")
+		}
+		p.pos++
+		return v, nil
+	}
+
+	start := p.pos
+	for p.pos < len(p.input) && p.input[p.pos] >= '0' && p.input[p.pos] <= '9' {
+		p.pos++
+	}
+	if p.pos == start {
+		return 0, fmt.Errorf("expected a number at %q", p.input[p.pos:])
+	}
+	return strconv.ParseInt(p.input[start:p.pos], 10, 64)
+}
+
+// arithmeticExpansion reports whether arg is a $((...)) token and, if
+// so, returns its inner expression.
+func arithmeticExpansion(arg string) (string, bool) {
+	if !strings.HasPrefix(arg, "$((") || !strings.HasSuffix(arg, "))") {
+		return "", false
+	}
+	return arg[3 : len(arg)-2], true
+}
@@ -0,0 +1,42 @@
+package optimizer
+
+import "testing"
+
+func TestEvalConstantArithmetic(t *testing.T) {
+	cases := map[string]int64{
+		"2+3":       5,
+		"2+3*4":     14,
+		"(2+3)*4":   20,
+		"10/3":      3,
+		"10%3":      1,
+		"-5+2":      -3,
+		"1 + 2 - 3": 0,
+	}
+	for expr, want := range cases {
+		got, err := evalConstantArithmetic(expr)
+		if err != nil {
+			t.Fatalf("evalConstantArithmetic(%q) returned error: %v", expr, err)
+		}
+		if got != want {
+			t.Errorf("evalConstantArithmetic(%q) = %d, want %d", expr, got, want)
+		}
+	}
+}
+
+func TestEvalConstantArithmeticRejectsNonConstantInput(t *testing.T) {
+	cases := []string{"x+1", "2+$y", "1/0", "2+"}
+	for _, expr := range cases {
+		if _, err := evalConstantArithmetic(expr); err == nil {
+			t.Errorf("evalConstantArithmetic(%q) expected an error", expr)
+		}
+	}
+}
+
+func TestArithmeticExpansionDetection(t *testing.T) {
+	if expr, ok := arithmeticExpansion("$((1+1))"); !ok || expr != "1+1" {
+		t.Fatalf("expected to detect and extract 1+1, got %q, %v", expr, ok)
+	}
+	if _, ok := arithmeticExpansion("$(echo hi)"); ok {
+		t.Fatal("expected command substitution to not be treated as arithmetic")
+	}
+}
@@ -0,0 +1,302 @@
+// Package optimizer rewrites a parsed script's AST before execution:
+// folding constant arithmetic expansions, eliminating branches whose
+// condition is statically known, and merging consecutive append
+// redirects to the same file. Every rewrite is recorded as an
+// Explanation so callers (see cmd/shode/commands/run.go's
+// --explain-optimizations flag) can show the user what changed.
+package optimizer
+
+import (
+	"fmt"
+
+	"gitee.com/com_818cloud/shode/pkg/types"
+)
+
+// Explanation describes one optimization applied to the script.
+type Explanation struct {
+	Pass   string
+	Detail string
+	Line   int
+}
+
+// Pass rewrites script in place and returns an Explanation for each
+// change it made.
+type Pass interface {
+	Name() string
+	Run(script *types.ScriptNode) []Explanation
+}
+
+// Optimizer runs a fixed pipeline of Passes over a script.
+type Optimizer struct {
+	passes []Pass
+}
+
+// NewOptimizer creates an Optimizer with the standard pass pipeline:
+// constant folding, dead-branch elimination, then redirect merging -
+// in that order, since folding and branch elimination can each expose
+// more opportunities for the passes after them.
+func NewOptimizer() *Optimizer {
+	return &Optimizer{
+		passes: []Pass{
+			&constantFoldPass{},
+			&deadBranchPass{},
+			&mergeRedirectsPass{},
+		},
+	}
+}
+
+// Optimize runs every pass over script in order, mutating it in place,
+// and returns the combined list of explanations.
+func (o *Optimizer) Optimize(script *types.ScriptNode) []Explanation {
+	var explanations []Explanation
+	for _, pass := range o.passes {
+		explanations = append(explanations, pass.Run(script)...)
+	}
+	return explanations
+}
+
+// walkCommands calls fn on every CommandNode reachable from nodes,
+// including ones nested inside if/for/while/function bodies, pipes,
+// &&/||, background jobs, and heredocs.
+func walkCommands(nodes []types.Node, fn func(*types.CommandNode)) {
+	for _, n := range nodes {
+		walkCommandsNode(n, fn)
+	}
+}
+
+func walkCommandsNode(n types.Node, fn func(*types.CommandNode)) {
+	switch v := n.(type) {
+	case *types.CommandNode:
+		fn(v)
+	case *types.PipeNode:
+		walkCommandsNode(v.Left, fn)
+		walkCommandsNode(v.Right, fn)
+	case *types.AndNode:
+		walkCommandsNode(v.Left, fn)
+		walkCommandsNode(v.Right, fn)
+	case *types.OrNode:
+		walkCommandsNode(v.Left, fn)
+		walkCommandsNode(v.Right, fn)
+	case *types.BackgroundNode:
+		walkCommandsNode(v.Command, fn)
+	case *types.HeredocNode:
+		walkCommandsNode(v.Command, fn)
+	case *types.ScriptNode:
+		walkCommands(v.Nodes, fn)
+	case *types.IfNode:
+		walkCommandsNode(v.Condition, fn)
+		if v.Then != nil {
+			walkCommands(v.Then.Nodes, fn)
+		}
+		if v.Else != nil {
+			walkCommands(v.Else.Nodes, fn)
+		}
+	case *types.ForNode:
+		if v.Body != nil {
+			walkCommands(v.Body.Nodes, fn)
+		}
+	case *types.WhileNode:
+		walkCommandsNode(v.Condition, fn)
+		if v.Body != nil {
+			walkCommands(v.Body.Nodes, fn)
+		}
+	case *types.FunctionNode:
+		if v.Body != nil {
+			walkCommands(v.Body.Nodes, fn)
+		}
+	}
+}
+
+// constantFoldPass replaces $((...)) arithmetic expansions whose
+// operands are all integer literals with their computed value.
+type constantFoldPass struct{}
+
+func (p *constantFoldPass) Name() string { return "constant-fold" }
+
+func (p *constantFoldPass) Run(script *types.ScriptNode) []Explanation {
+	var explanations []Explanation
+	walkCommands(script.Nodes, func(cmd *types.CommandNode) {
+		for i, arg := range cmd.Args {
+			expr, ok := arithmeticExpansion(arg)
+			if !ok {
+				continue
+			}
+			value, err := evalConstantArithmetic(expr)
+			if err != nil {
+				continue
+			}
+			folded := fmt.Sprintf("%d", value)
+			explanations = append(explanations, Explanation{
+				Pass:   p.Name(),
+				Detail: fmt.Sprintf("folded %s to %s in %q", arg, folded, cmd.Name),
+				Line:   cmd.Pos.Line,
+			})
+			cmd.Args[i] = folded
+		}
+	})
+	return explanations
+}
+
+// deadBranchPass removes IfNode branches whose condition is a literal
+// "true" or "false" command with no arguments - the only conditions
+// that are statically known without actually running anything.
+type deadBranchPass struct{}
+
+func (p *deadBranchPass) Name() string { return "dead-branch-elimination" }
+
+func (p *deadBranchPass) Run(script *types.ScriptNode) []Explanation {
+	var explanations []Explanation
+	script.Nodes = p.rewrite(script.Nodes, &explanations)
+	return explanations
+}
+
+func (p *deadBranchPass) rewrite(nodes []types.Node, explanations *[]Explanation) []types.Node {
+	out := make([]types.Node, 0, len(nodes))
+	for _, n := range nodes {
+		ifNode, ok := n.(*types.IfNode)
+		if !ok {
+			out = append(out, n)
+			continue
+		}
+
+		if ifNode.Then != nil {
+			ifNode.Then.Nodes = p.rewrite(ifNode.Then.Nodes, explanations)
+		}
+		if ifNode.Else != nil {
+			ifNode.Else.Nodes = p.rewrite(ifNode.Else.Nodes, explanations)
+		}
+
+		taken, known := staticCondition(ifNode.Condition)
+		if !known {
+			out = append(out, ifNode)
+			continue
+		}
+
+		*explanations = append(*explanations, Explanation{
+			Pass:   p.Name(),
+			Detail: fmt.Sprintf("condition is statically %v, inlining the %s branch", taken, branchName(taken)),
+			Line:   ifNode.Pos.Line,
+		})
+
+		if taken {
+			out = append(out, ifNode.Then.Nodes...)
+		} else if ifNode.Else != nil {
+			out = append(out, ifNode.Else.Nodes...)
+		}
+	}
+	return out
+}
+
+func branchName(taken bool) string {
+	if taken {
+		return "then"
+	}
+	return "else"
+}
+
+// staticCondition reports whether cond is the literal command "true"
+// or "false" with no arguments, and if so what it statically evaluates
+// to.
+func staticCondition(cond types.Node) (taken bool, known bool) {
+	cmd, ok := cond.(*types.CommandNode)
+	if !ok || len(cmd.Args) > 0 {
+		return false, false
+	}
+	switch cmd.Name {
+	case "true":
+		return true, true
+	case "false":
+		return false, true
+	default:
+		return false, false
+	}
+}
+
+// mergeRedirectsPass merges consecutive "echo" commands that append to
+// the same file into a single "printf" call, so the file is opened and
+// written to once instead of once per echo.
+type mergeRedirectsPass struct{}
+
+func (p *mergeRedirectsPass) Name() string { return "merge-redirects" }
+
+func (p *mergeRedirectsPass) Run(script *types.ScriptNode) []Explanation {
+	var explanations []Explanation
+	script.Nodes = p.rewrite(script.Nodes, &explanations)
+	return explanations
+}
+
+func (p *mergeRedirectsPass) rewrite(nodes []types.Node, explanations *[]Explanation) []types.Node {
+	out := make([]types.Node, 0, len(nodes))
+	i := 0
+	for i < len(nodes) {
+		group := p.collectRun(nodes, i)
+		if len(group) < 2 {
+			out = append(out, nodes[i])
+			i++
+			continue
+		}
+
+		merged := mergeEchoAppends(group)
+		*explanations = append(*explanations, Explanation{
+			Pass:   p.Name(),
+			Detail: fmt.Sprintf("merged %d consecutive echo appends to %q into one printf", len(group), group[0].Redirect.File),
+			Line:   group[0].Pos.Line,
+		})
+		out = append(out, merged)
+		i += len(group)
+	}
+	return out
+}
+
+// collectRun returns the longest run of consecutive "echo" commands
+// starting at index i that append to the same file.
+func (p *mergeRedirectsPass) collectRun(nodes []types.Node, i int) []*types.CommandNode {
+	var group []*types.CommandNode
+	first, ok := mergeableEcho(nodes[i])
+	if !ok {
+		return nil
+	}
+	group = append(group, first)
+	for j := i + 1; j < len(nodes); j++ {
+		next, ok := mergeableEcho(nodes[j])
+		if !ok || next.Redirect.File != first.Redirect.File {
+			break
+		}
+		group = append(group, next)
+	}
+	return group
+}
+
+func mergeableEcho(n types.Node) (*types.CommandNode, bool) {
+	cmd, ok := n.(*types.CommandNode)
+	if !ok || cmd.Name != "echo" || cmd.Redirect == nil || cmd.Redirect.Op != ">>" {
+		return nil, false
+	}
+	return cmd, true
+}
+
+// mergeEchoAppends combines a run of same-target echo commands into a
+// single printf call that writes each echo's line, in order, with one
+// open of the redirect target.
+func mergeEchoAppends(group []*types.CommandNode) *types.CommandNode {
+	format := ""
+	args := make([]string, 0, len(group))
+	for _, cmd := range group {
+		format += "%s\n"
+		line := ""
+		for i, a := range cmd.Args {
+			if i > 0 {
+				line += " "
+			}
+			line += a
+		}
+		args = append(args, line)
+	}
+
+	return &types.CommandNode{
+		Pos:      group[0].Pos,
+		Name:     "printf",
+		Args:     append([]string{format}, args...),
+		Redirect: group[0].Redirect,
+	}
+}
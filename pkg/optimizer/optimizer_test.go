@@ -0,0 +1,145 @@
+package optimizer
+
+import (
+	"testing"
+
+	"gitee.com/com_818cloud/shode/pkg/types"
+)
+
+func TestConstantFoldReplacesArithmeticExpansion(t *testing.T) {
+	script := &types.ScriptNode{Nodes: []types.Node{
+		&types.CommandNode{Name: "echo", Args: []string{"$((2+3*4))"}},
+	}}
+
+	explanations := NewOptimizer().Optimize(script)
+
+	cmd := script.Nodes[0].(*types.CommandNode)
+	if cmd.Args[0] != "14" {
+		t.Fatalf("expected folded constant 14, got %q", cmd.Args[0])
+	}
+	if len(explanations) == 0 {
+		t.Fatal("expected at least one explanation for the fold")
+	}
+}
+
+func TestConstantFoldLeavesNonConstantExpressionsAlone(t *testing.T) {
+	script := &types.ScriptNode{Nodes: []types.Node{
+		&types.CommandNode{Name: "echo", Args: []string{"$((x+1))"}},
+	}}
+
+	NewOptimizer().Optimize(script)
+
+	cmd := script.Nodes[0].(*types.CommandNode)
+	if cmd.Args[0] != "$((x+1))" {
+		t.Fatalf("expected the non-constant expansion to be left untouched, got %q", cmd.Args[0])
+	}
+}
+
+func TestDeadBranchEliminationInlinesStaticallyTrueCondition(t *testing.T) {
+	script := &types.ScriptNode{Nodes: []types.Node{
+		&types.IfNode{
+			Condition: &types.CommandNode{Name: "true"},
+			Then: &types.ScriptNode{Nodes: []types.Node{
+				&types.CommandNode{Name: "echo", Args: []string{"yes"}},
+			}},
+			Else: &types.ScriptNode{Nodes: []types.Node{
+				&types.CommandNode{Name: "echo", Args: []string{"no"}},
+			}},
+		},
+	}}
+
+	NewOptimizer().Optimize(script)
+
+	if len(script.Nodes) != 1 {
+		t.Fatalf("expected the if to be replaced by its then-branch, got %d nodes", len(script.Nodes))
+	}
+	cmd, ok := script.Nodes[0].(*types.CommandNode)
+	if !ok || cmd.Args[0] != "yes" {
+		t.Fatalf("expected the inlined then-branch command, got %#v", script.Nodes[0])
+	}
+}
+
+func TestDeadBranchEliminationDropsStaticallyFalseConditionWithNoElse(t *testing.T) {
+	script := &types.ScriptNode{Nodes: []types.Node{
+		&types.IfNode{
+			Condition: &types.CommandNode{Name: "false"},
+			Then: &types.ScriptNode{Nodes: []types.Node{
+				&types.CommandNode{Name: "echo", Args: []string{"unreachable"}},
+			}},
+		},
+		&types.CommandNode{Name: "echo", Args: []string{"after"}},
+	}}
+
+	NewOptimizer().Optimize(script)
+
+	if len(script.Nodes) != 1 {
+		t.Fatalf("expected the dead branch to be dropped, got %d nodes", len(script.Nodes))
+	}
+	cmd := script.Nodes[0].(*types.CommandNode)
+	if cmd.Args[0] != "after" {
+		t.Fatalf("expected only the command after the dead if to remain, got %#v", script.Nodes[0])
+	}
+}
+
+func TestDeadBranchEliminationLeavesDynamicConditionsAlone(t *testing.T) {
+	script := &types.ScriptNode{Nodes: []types.Node{
+		&types.IfNode{
+			Condition: &types.CommandNode{Name: "test", Args: []string{"-f", "foo"}},
+			Then: &types.ScriptNode{Nodes: []types.Node{
+				&types.CommandNode{Name: "echo", Args: []string{"maybe"}},
+			}},
+		},
+	}}
+
+	NewOptimizer().Optimize(script)
+
+	if _, ok := script.Nodes[0].(*types.IfNode); !ok {
+		t.Fatalf("expected the dynamic if to be left in place, got %#v", script.Nodes[0])
+	}
+}
+
+func TestMergeRedirectsCombinesConsecutiveAppendsToSameFile(t *testing.T) {
+	script := &types.ScriptNode{Nodes: []types.Node{
+		&types.CommandNode{Name: "echo", Args: []string{"line1"}, Redirect: &types.RedirectNode{Op: ">>", File: "out.log"}},
+		&types.CommandNode{Name: "echo", Args: []string{"line2"}, Redirect: &types.RedirectNode{Op: ">>", File: "out.log"}},
+		&types.CommandNode{Name: "echo", Args: []string{"other"}, Redirect: &types.RedirectNode{Op: ">>", File: "elsewhere.log"}},
+	}}
+
+	explanations := NewOptimizer().Optimize(script)
+
+	if len(script.Nodes) != 2 {
+		t.Fatalf("expected the two out.log appends to merge into one node, got %d", len(script.Nodes))
+	}
+	merged := script.Nodes[0].(*types.CommandNode)
+	if merged.Name != "printf" || merged.Redirect.File != "out.log" {
+		t.Fatalf("expected a merged printf targeting out.log, got %#v", merged)
+	}
+	if merged.Args[0] != "%s\n%s\n" || merged.Args[1] != "line1" || merged.Args[2] != "line2" {
+		t.Fatalf("expected merged printf format and lines, got %#v", merged.Args)
+	}
+
+	found := false
+	for _, e := range explanations {
+		if e.Pass == "merge-redirects" {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatal("expected a merge-redirects explanation")
+	}
+}
+
+func TestMergeRedirectsLeavesSingleEchoAlone(t *testing.T) {
+	script := &types.ScriptNode{Nodes: []types.Node{
+		&types.CommandNode{Name: "echo", Args: []string{"solo"}, Redirect: &types.RedirectNode{Op: ">>", File: "out.log"}},
+	}}
+
+	NewOptimizer().Optimize(script)
+
+	if len(script.Nodes) != 1 {
+		t.Fatalf("expected the lone echo to be left alone, got %d nodes", len(script.Nodes))
+	}
+	if script.Nodes[0].(*types.CommandNode).Name != "echo" {
+		t.Fatal("expected the command to remain an echo")
+	}
+}
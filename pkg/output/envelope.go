@@ -0,0 +1,52 @@
+// Package output defines the JSON envelope CLI commands use for
+// machine-readable --json output, so CI pipelines and other tools can
+// consume Shode results without parsing human-oriented text.
+package output
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// Status is the top-level outcome of a command invocation.
+type Status string
+
+const (
+	StatusOK    Status = "ok"
+	StatusError Status = "error"
+)
+
+// Diagnostic is a single warning or error surfaced alongside the command's
+// primary result (e.g. a migrate finding, a lint warning).
+type Diagnostic struct {
+	Severity string `json:"severity"`
+	Message  string `json:"message"`
+	Line     int    `json:"line,omitempty"`
+}
+
+// Envelope is the standard JSON shape emitted by every Shode command that
+// supports --json: a status, the command-specific payload, and any
+// diagnostics collected along the way.
+type Envelope struct {
+	Status      Status       `json:"status"`
+	Data        any          `json:"data,omitempty"`
+	Diagnostics []Diagnostic `json:"diagnostics,omitempty"`
+	Error       string       `json:"error,omitempty"`
+}
+
+// OK builds a successful envelope wrapping data.
+func OK(data any, diagnostics ...Diagnostic) Envelope {
+	return Envelope{Status: StatusOK, Data: data, Diagnostics: diagnostics}
+}
+
+// Err builds a failed envelope carrying err's message.
+func Err(err error, diagnostics ...Diagnostic) Envelope {
+	return Envelope{Status: StatusError, Error: err.Error(), Diagnostics: diagnostics}
+}
+
+// Write marshals the envelope as indented JSON to w.
+func (e Envelope) Write(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(e)
+}
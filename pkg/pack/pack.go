@@ -0,0 +1,202 @@
+// Package pack builds a minimal container image for a shode script -
+// the bundled shode runtime binary, the script itself, its declared
+// sh_models packages, and its shode.toml policy - without the caller
+// writing a Dockerfile. It generates one in memory and feeds it to the
+// Docker Engine API the same way pkg/docker already talks to the
+// daemon for `docker build`.
+package pack
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"gitee.com/com_818cloud/shode/pkg/docker"
+)
+
+// DefaultBaseImage is the base image used when Spec.BaseImage is
+// empty. It's a full (if small) userland rather than "scratch" because
+// a shode script's commands exec real OS binaries (echo, cat, git,
+// ...) through the sandbox, not just the shode binary itself.
+const DefaultBaseImage = "alpine:3.19"
+
+// imageWorkdir is where the bundled files land inside the image.
+const imageWorkdir = "/app"
+
+// Spec describes what to bundle into an image.
+type Spec struct {
+	ScriptPath  string // entry script; required
+	BinaryPath  string // shode runtime binary to bundle; "" uses os.Executable()
+	PackagesDir string // sh_models directory to copy in; "" or missing is skipped
+	PolicyPath  string // shode.toml (or other policy file) to copy in; "" or missing is skipped
+	BaseImage   string // FROM image; "" uses DefaultBaseImage
+}
+
+// resolve fills in Spec defaults and validates ScriptPath/BinaryPath
+// actually exist, since a missing required input should fail before
+// any tar bytes are written rather than surface as an opaque build
+// error from the daemon.
+func (s Spec) resolve() (Spec, error) {
+	if s.ScriptPath == "" {
+		return Spec{}, fmt.Errorf("pack: ScriptPath is required")
+	}
+	if _, err := os.Stat(s.ScriptPath); err != nil {
+		return Spec{}, fmt.Errorf("pack: script %s: %w", s.ScriptPath, err)
+	}
+
+	if s.BinaryPath == "" {
+		exe, err := os.Executable()
+		if err != nil {
+			return Spec{}, fmt.Errorf("pack: resolving current shode binary: %w", err)
+		}
+		s.BinaryPath = exe
+	}
+	if _, err := os.Stat(s.BinaryPath); err != nil {
+		return Spec{}, fmt.Errorf("pack: runtime binary %s: %w", s.BinaryPath, err)
+	}
+
+	if s.BaseImage == "" {
+		s.BaseImage = DefaultBaseImage
+	}
+	return s, nil
+}
+
+// BuildContext renders spec into an uncompressed tar archive: a
+// generated Dockerfile at its root, plus the runtime binary, script,
+// packages, and policy file it COPYs in. The returned reader is the
+// build context pkg/docker.Client.Build expects.
+func BuildContext(spec Spec) (io.Reader, error) {
+	spec, err := spec.resolve()
+	if err != nil {
+		return nil, err
+	}
+
+	scriptName := filepath.Base(spec.ScriptPath)
+
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+
+	if err := addFile(tw, spec.BinaryPath, "shode", 0755); err != nil {
+		return nil, err
+	}
+	if err := addFile(tw, spec.ScriptPath, scriptName, 0644); err != nil {
+		return nil, err
+	}
+
+	hasPackages := false
+	if spec.PackagesDir != "" {
+		if info, statErr := os.Stat(spec.PackagesDir); statErr == nil && info.IsDir() {
+			if err := addDir(tw, spec.PackagesDir, "sh_models"); err != nil {
+				return nil, err
+			}
+			hasPackages = true
+		}
+	}
+
+	hasPolicy := false
+	if spec.PolicyPath != "" {
+		if _, statErr := os.Stat(spec.PolicyPath); statErr == nil {
+			if err := addFile(tw, spec.PolicyPath, "shode.toml", 0644); err != nil {
+				return nil, err
+			}
+			hasPolicy = true
+		}
+	}
+
+	dockerfile := renderDockerfile(spec.BaseImage, scriptName, hasPackages, hasPolicy)
+	if err := tw.WriteHeader(&tar.Header{
+		Name:    "Dockerfile",
+		Mode:    0644,
+		Size:    int64(len(dockerfile)),
+		ModTime: time.Unix(0, 0),
+	}); err != nil {
+		return nil, fmt.Errorf("pack: writing Dockerfile header: %w", err)
+	}
+	if _, err := tw.Write([]byte(dockerfile)); err != nil {
+		return nil, fmt.Errorf("pack: writing Dockerfile: %w", err)
+	}
+
+	if err := tw.Close(); err != nil {
+		return nil, fmt.Errorf("pack: closing build context: %w", err)
+	}
+	return &buf, nil
+}
+
+// renderDockerfile generates the Dockerfile text for the build
+// context - the caller never writes one themselves.
+func renderDockerfile(baseImage, scriptName string, hasPackages, hasPolicy bool) string {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "FROM %s\n", baseImage)
+	fmt.Fprintf(&b, "WORKDIR %s\n", imageWorkdir)
+	fmt.Fprintf(&b, "COPY shode /usr/local/bin/shode\n")
+	fmt.Fprintf(&b, "COPY %s %s\n", scriptName, scriptName)
+	if hasPackages {
+		fmt.Fprintf(&b, "COPY sh_models sh_models\n")
+	}
+	if hasPolicy {
+		fmt.Fprintf(&b, "COPY shode.toml shode.toml\n")
+	}
+	fmt.Fprintf(&b, "ENTRYPOINT [\"/usr/local/bin/shode\", \"run\", \"%s\"]\n", scriptName)
+	return b.String()
+}
+
+// addFile copies the file at srcPath into tw as name, with mode.
+func addFile(tw *tar.Writer, srcPath, name string, mode int64) error {
+	data, err := os.ReadFile(srcPath)
+	if err != nil {
+		return fmt.Errorf("pack: reading %s: %w", srcPath, err)
+	}
+	if err := tw.WriteHeader(&tar.Header{
+		Name:    name,
+		Mode:    mode,
+		Size:    int64(len(data)),
+		ModTime: time.Unix(0, 0),
+	}); err != nil {
+		return fmt.Errorf("pack: writing header for %s: %w", name, err)
+	}
+	if _, err := tw.Write(data); err != nil {
+		return fmt.Errorf("pack: writing %s: %w", name, err)
+	}
+	return nil
+}
+
+// addDir recursively copies srcDir's contents into tw under name.
+func addDir(tw *tar.Writer, srcDir, name string) error {
+	return filepath.Walk(srcDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(srcDir, path)
+		if err != nil {
+			return err
+		}
+		entryName := name
+		if rel != "." {
+			entryName = filepath.ToSlash(filepath.Join(name, rel))
+		}
+		if info.IsDir() {
+			return tw.WriteHeader(&tar.Header{
+				Name:     entryName + "/",
+				Mode:     0755,
+				Typeflag: tar.TypeDir,
+				ModTime:  time.Unix(0, 0),
+			})
+		}
+		return addFile(tw, path, entryName, 0644)
+	})
+}
+
+// Build renders spec into a build context and builds it into an image
+// tagged tag via client.
+func Build(ctx context.Context, client *docker.Client, spec Spec, tag string) (*docker.BuildResult, error) {
+	buildContext, err := BuildContext(spec)
+	if err != nil {
+		return nil, err
+	}
+	return client.Build(ctx, buildContext, "", tag)
+}
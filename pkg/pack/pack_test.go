@@ -0,0 +1,171 @@
+package pack
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gitee.com/com_818cloud/shode/pkg/docker"
+)
+
+func writeTestSpec(t *testing.T) Spec {
+	t.Helper()
+	dir := t.TempDir()
+
+	binaryPath := filepath.Join(dir, "shode")
+	if err := os.WriteFile(binaryPath, []byte("fake-binary"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	scriptPath := filepath.Join(dir, "script.sh")
+	if err := os.WriteFile(scriptPath, []byte("echo hello\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	policyPath := filepath.Join(dir, "shode.toml")
+	if err := os.WriteFile(policyPath, []byte("entry = [\"script.sh\"]\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	packagesDir := filepath.Join(dir, "sh_models")
+	if err := os.MkdirAll(filepath.Join(packagesDir, "leftpad"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(packagesDir, "leftpad", "index.sh"), []byte("# leftpad\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	return Spec{
+		ScriptPath:  scriptPath,
+		BinaryPath:  binaryPath,
+		PolicyPath:  policyPath,
+		PackagesDir: packagesDir,
+	}
+}
+
+func readTarNames(t *testing.T, r io.Reader) map[string][]byte {
+	t.Helper()
+	names := make(map[string][]byte)
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("reading tar: %v", err)
+		}
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			t.Fatalf("reading tar entry %s: %v", hdr.Name, err)
+		}
+		names[hdr.Name] = data
+	}
+	return names
+}
+
+func TestBuildContextIncludesBundledFilesAndGeneratedDockerfile(t *testing.T) {
+	spec := writeTestSpec(t)
+
+	ctxReader, err := BuildContext(spec)
+	if err != nil {
+		t.Fatalf("BuildContext returned error: %v", err)
+	}
+
+	entries := readTarNames(t, ctxReader)
+
+	if string(entries["shode"]) != "fake-binary" {
+		t.Errorf("expected bundled shode binary, got %q", entries["shode"])
+	}
+	if string(entries["script.sh"]) != "echo hello\n" {
+		t.Errorf("expected bundled script, got %q", entries["script.sh"])
+	}
+	if string(entries["shode.toml"]) == "" {
+		t.Errorf("expected bundled policy file")
+	}
+	if string(entries["sh_models/leftpad/index.sh"]) != "# leftpad\n" {
+		t.Errorf("expected bundled package file, got %q", entries["sh_models/leftpad/index.sh"])
+	}
+
+	dockerfile := string(entries["Dockerfile"])
+	if !strings.Contains(dockerfile, "FROM "+DefaultBaseImage) {
+		t.Errorf("expected Dockerfile to use default base image, got %q", dockerfile)
+	}
+	if !strings.Contains(dockerfile, `ENTRYPOINT ["/usr/local/bin/shode", "run", "script.sh"]`) {
+		t.Errorf("expected Dockerfile to run the bundled script, got %q", dockerfile)
+	}
+	if !strings.Contains(dockerfile, "COPY sh_models sh_models") {
+		t.Errorf("expected Dockerfile to copy packages, got %q", dockerfile)
+	}
+}
+
+func TestBuildContextSkipsMissingPackagesAndPolicy(t *testing.T) {
+	spec := writeTestSpec(t)
+	spec.PackagesDir = ""
+	spec.PolicyPath = ""
+
+	ctxReader, err := BuildContext(spec)
+	if err != nil {
+		t.Fatalf("BuildContext returned error: %v", err)
+	}
+
+	entries := readTarNames(t, ctxReader)
+	if _, ok := entries["shode.toml"]; ok {
+		t.Errorf("expected no bundled policy file")
+	}
+	dockerfile := string(entries["Dockerfile"])
+	if strings.Contains(dockerfile, "sh_models") {
+		t.Errorf("expected Dockerfile to skip packages, got %q", dockerfile)
+	}
+	if strings.Contains(dockerfile, "shode.toml") {
+		t.Errorf("expected Dockerfile to skip policy, got %q", dockerfile)
+	}
+}
+
+func TestBuildContextRejectsMissingScript(t *testing.T) {
+	spec := writeTestSpec(t)
+	spec.ScriptPath = filepath.Join(t.TempDir(), "missing.sh")
+
+	if _, err := BuildContext(spec); err == nil {
+		t.Fatalf("expected an error for a missing script")
+	}
+}
+
+func TestBuildSendsContextToDockerEngineAPI(t *testing.T) {
+	spec := writeTestSpec(t)
+
+	var gotTag string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotTag = r.URL.Query().Get("t")
+		body, _ := io.ReadAll(r.Body)
+		entries := readTarNames(t, bytes.NewReader(body))
+		if _, ok := entries["Dockerfile"]; !ok {
+			t.Errorf("expected build context to contain a Dockerfile")
+		}
+		w.Write([]byte(`{"aux":{"ID":"sha256:def456"}}` + "\n"))
+	}))
+	defer server.Close()
+
+	client, err := docker.NewClient(server.URL)
+	if err != nil {
+		t.Fatalf("NewClient returned error: %v", err)
+	}
+
+	result, err := Build(context.Background(), client, spec, "myscript:latest")
+	if err != nil {
+		t.Fatalf("Build returned error: %v", err)
+	}
+	if result.ImageID != "sha256:def456" {
+		t.Errorf("expected image ID sha256:def456, got %q", result.ImageID)
+	}
+	if gotTag != "myscript:latest" {
+		t.Errorf("expected tag myscript:latest, got %q", gotTag)
+	}
+}
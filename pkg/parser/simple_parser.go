@@ -5,10 +5,19 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"sync"
 
 	"gitee.com/com_818cloud/shode/pkg/types"
 )
 
+// tokenBuilderPool reuses the strings.Builder that tokenize uses to
+// accumulate each token, since a script with many lines calls
+// tokenize once per command and each call would otherwise allocate a
+// fresh builder.
+var tokenBuilderPool = sync.Pool{
+	New: func() interface{} { return &strings.Builder{} },
+}
+
 // SimpleParser provides basic shell command parsing without external dependencies
 type SimpleParser struct{}
 
@@ -357,7 +366,9 @@ func (p *SimpleParser) parseAssignment(line string, lineNum int) *types.Assignme
 // tokenize splits a command line into tokens, handling quotes
 func (p *SimpleParser) tokenize(line string) []string {
 	var tokens []string
-	var currentToken strings.Builder
+	currentToken := tokenBuilderPool.Get().(*strings.Builder)
+	currentToken.Reset()
+	defer tokenBuilderPool.Put(currentToken)
 	inQuotes := false
 	quoteChar := byte(0)
 
@@ -0,0 +1,176 @@
+package performance
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/pprof"
+	"sync"
+	"time"
+)
+
+// Agent periodically captures CPU and heap profiles for a running
+// script, writes them to OutputDir labeled with ScriptLabel and the
+// capture time, and - when UploadURL is set - POSTs each profile to it.
+type Agent struct {
+	// ScriptLabel identifies the running script in profile file names
+	// and in the X-Shode-Script header sent with uploads.
+	ScriptLabel string
+	// OutputDir is where captured profiles are written.
+	OutputDir string
+	// Interval is how often to capture a CPU+heap profile pair.
+	Interval time.Duration
+	// CPUProfileDuration is how long each CPU profile samples for.
+	// Defaults to 5s in NewAgent.
+	CPUProfileDuration time.Duration
+	// UploadURL, if set, receives an HTTP POST of each captured profile.
+	UploadURL string
+
+	httpClient *http.Client
+
+	stop chan struct{}
+	wg   sync.WaitGroup
+}
+
+// NewAgent creates an Agent that captures profiles for scriptLabel into
+// outputDir every interval.
+func NewAgent(scriptLabel, outputDir string, interval time.Duration) *Agent {
+	return &Agent{
+		ScriptLabel:        scriptLabel,
+		OutputDir:          outputDir,
+		Interval:           interval,
+		CPUProfileDuration: 5 * time.Second,
+		httpClient:         &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// Start begins capturing profiles in the background. Call Stop to end
+// the capture loop and wait for the in-flight capture, if any, to finish.
+func (a *Agent) Start() error {
+	if err := os.MkdirAll(a.OutputDir, 0755); err != nil {
+		return fmt.Errorf("creating profile output dir: %w", err)
+	}
+
+	a.stop = make(chan struct{})
+	a.wg.Add(1)
+	go a.run()
+	return nil
+}
+
+// Stop ends the capture loop and waits for it to finish.
+func (a *Agent) Stop() {
+	if a.stop == nil {
+		return
+	}
+	close(a.stop)
+	a.wg.Wait()
+}
+
+func (a *Agent) run() {
+	defer a.wg.Done()
+
+	ticker := time.NewTicker(a.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-a.stop:
+			return
+		case <-ticker.C:
+			a.capture()
+		}
+	}
+}
+
+// capture writes one CPU profile and one heap profile, logging failures
+// to stderr rather than stopping the loop - a single bad capture
+// shouldn't take down continuous profiling.
+func (a *Agent) capture() {
+	ts := time.Now()
+
+	if path, err := a.captureCPUProfile(ts); err != nil {
+		fmt.Fprintf(os.Stderr, "performance: cpu profile capture failed: %v\n", err)
+	} else {
+		a.maybeUpload(path)
+	}
+
+	if path, err := a.captureHeapProfile(ts); err != nil {
+		fmt.Fprintf(os.Stderr, "performance: heap profile capture failed: %v\n", err)
+	} else {
+		a.maybeUpload(path)
+	}
+}
+
+func (a *Agent) profilePath(kind string, ts time.Time) string {
+	name := fmt.Sprintf("%s-%s-%s.pprof", a.ScriptLabel, kind, ts.Format("20060102T150405.000"))
+	return filepath.Join(a.OutputDir, name)
+}
+
+func (a *Agent) captureCPUProfile(ts time.Time) (string, error) {
+	path := a.profilePath("cpu", ts)
+	f, err := os.Create(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	if err := pprof.StartCPUProfile(f); err != nil {
+		return "", err
+	}
+	time.Sleep(a.CPUProfileDuration)
+	pprof.StopCPUProfile()
+	return path, nil
+}
+
+func (a *Agent) captureHeapProfile(ts time.Time) (string, error) {
+	path := a.profilePath("heap", ts)
+	f, err := os.Create(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	runtime.GC()
+	if err := pprof.WriteHeapProfile(f); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+func (a *Agent) maybeUpload(path string) {
+	if a.UploadURL == "" {
+		return
+	}
+	if err := a.upload(path); err != nil {
+		fmt.Fprintf(os.Stderr, "performance: uploading profile %s failed: %v\n", path, err)
+	}
+}
+
+func (a *Agent) upload(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, a.UploadURL, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/octet-stream")
+	req.Header.Set("X-Shode-Script", a.ScriptLabel)
+	req.Header.Set("X-Shode-Profile-Name", filepath.Base(path))
+
+	resp, err := a.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("upload endpoint returned status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package performance
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestAgentCapturesLabeledProfiles(t *testing.T) {
+	dir := t.TempDir()
+
+	a := NewAgent("myscript", dir, 10*time.Millisecond)
+	a.CPUProfileDuration = 5 * time.Millisecond
+
+	if err := a.Start(); err != nil {
+		t.Fatalf("Start returned error: %v", err)
+	}
+	time.Sleep(60 * time.Millisecond)
+	a.Stop()
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var sawCPU, sawHeap bool
+	for _, e := range entries {
+		if !strings.HasPrefix(e.Name(), "myscript-") {
+			t.Fatalf("expected profile %q to be labeled with the script name", e.Name())
+		}
+		if strings.Contains(e.Name(), "-cpu-") {
+			sawCPU = true
+		}
+		if strings.Contains(e.Name(), "-heap-") {
+			sawHeap = true
+		}
+	}
+	if !sawCPU || !sawHeap {
+		t.Fatalf("expected both cpu and heap profiles, got: %v", entries)
+	}
+}
+
+func TestAgentUploadsProfilesToConfiguredEndpoint(t *testing.T) {
+	dir := t.TempDir()
+
+	var uploads atomic.Int32
+	var gotLabel string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		uploads.Add(1)
+		gotLabel = r.Header.Get("X-Shode-Script")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	a := NewAgent("uploader", dir, 10*time.Millisecond)
+	a.CPUProfileDuration = 5 * time.Millisecond
+	a.UploadURL = srv.URL
+
+	if err := a.Start(); err != nil {
+		t.Fatalf("Start returned error: %v", err)
+	}
+	time.Sleep(60 * time.Millisecond)
+	a.Stop()
+
+	if uploads.Load() == 0 {
+		t.Fatal("expected at least one upload")
+	}
+	if gotLabel != "uploader" {
+		t.Fatalf("expected X-Shode-Script header to carry the script label, got %q", gotLabel)
+	}
+}
+
+func TestProfilePathLabelsWithScriptAndKind(t *testing.T) {
+	a := NewAgent("demo", "/tmp/out", time.Second)
+	ts := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	path := a.profilePath("cpu", ts)
+	if filepath.Dir(path) != "/tmp/out" {
+		t.Fatalf("expected path under OutputDir, got %s", path)
+	}
+	if !strings.HasPrefix(filepath.Base(path), "demo-cpu-") {
+		t.Fatalf("expected file name to start with demo-cpu-, got %s", filepath.Base(path))
+	}
+}
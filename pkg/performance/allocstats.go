@@ -0,0 +1,42 @@
+package performance
+
+import "runtime"
+
+// AllocStats is a snapshot of the Go runtime's allocation counters,
+// used to measure the effect of allocation-reducing changes (such as
+// object pooling) by comparing a snapshot taken before and after a
+// piece of work.
+type AllocStats struct {
+	TotalAllocBytes uint64 `json:"totalAllocBytes"`
+	Mallocs         uint64 `json:"mallocs"`
+	NumGC           uint32 `json:"numGC"`
+}
+
+// CaptureAllocStats reads the current runtime allocation counters.
+func CaptureAllocStats() AllocStats {
+	var m runtime.MemStats
+	runtime.ReadMemStats(&m)
+	return AllocStats{
+		TotalAllocBytes: m.TotalAlloc,
+		Mallocs:         m.Mallocs,
+		NumGC:           m.NumGC,
+	}
+}
+
+// AllocDelta is the difference between two AllocStats snapshots,
+// reported as how much work happened in between.
+type AllocDelta struct {
+	AllocBytes uint64 `json:"allocBytes"`
+	Mallocs    uint64 `json:"mallocs"`
+	NumGC      uint32 `json:"numGC"`
+}
+
+// Since returns how much allocation happened between before and the
+// receiver (the "after" snapshot).
+func (after AllocStats) Since(before AllocStats) AllocDelta {
+	return AllocDelta{
+		AllocBytes: after.TotalAllocBytes - before.TotalAllocBytes,
+		Mallocs:    after.Mallocs - before.Mallocs,
+		NumGC:      after.NumGC - before.NumGC,
+	}
+}
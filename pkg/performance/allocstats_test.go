@@ -0,0 +1,27 @@
+package performance
+
+import "testing"
+
+func TestAllocStatsSinceComputesDelta(t *testing.T) {
+	before := AllocStats{TotalAllocBytes: 1000, Mallocs: 10, NumGC: 1}
+	after := AllocStats{TotalAllocBytes: 1500, Mallocs: 25, NumGC: 2}
+
+	delta := after.Since(before)
+
+	if delta.AllocBytes != 500 {
+		t.Errorf("AllocBytes = %d, want 500", delta.AllocBytes)
+	}
+	if delta.Mallocs != 15 {
+		t.Errorf("Mallocs = %d, want 15", delta.Mallocs)
+	}
+	if delta.NumGC != 1 {
+		t.Errorf("NumGC = %d, want 1", delta.NumGC)
+	}
+}
+
+func TestCaptureAllocStatsReturnsNonZeroCounters(t *testing.T) {
+	stats := CaptureAllocStats()
+	if stats.TotalAllocBytes == 0 {
+		t.Errorf("TotalAllocBytes = 0, want non-zero once the runtime has allocated anything")
+	}
+}
@@ -0,0 +1,148 @@
+package performance
+
+import (
+	"runtime"
+	"sync"
+	"time"
+)
+
+// PerformanceMetrics is one point-in-time snapshot of the running
+// process's resource usage, used by AutoOptimizer to decide whether an
+// optimization strategy should trigger.
+type PerformanceMetrics struct {
+	Timestamp  time.Time
+	CPUPercent float64 // process CPU usage since the previous sample, 0-100 per core
+	GCPauseNs  uint64  // most recent GC pause
+	HeapAlloc  uint64  // bytes currently allocated and in use
+	NumGC      uint32
+}
+
+// cpuSample is the process CPU time captured at a point in time, kept
+// around so the next Sample can compute a CPU% from the delta.
+type cpuSample struct {
+	at      time.Time
+	cpuTime time.Duration
+}
+
+// DefaultCPUThreshold is the CPU% above which ShouldOptimizeCPU reports
+// that a CPU-bound optimization strategy should trigger.
+const DefaultCPUThreshold = 80.0
+
+// AutoOptimizer watches PerformanceMetrics over time, keeping a
+// baseline (the first sample) and a bounded history so later requests
+// can compare against both and decide when to trigger an optimization
+// strategy.
+type AutoOptimizer struct {
+	mu           sync.Mutex
+	baseline     *PerformanceMetrics
+	history      []*PerformanceMetrics
+	maxHistory   int
+	lastCPU      *cpuSample
+	cpuThreshold float64
+	alertCount   int64
+}
+
+// NewAutoOptimizer creates an AutoOptimizer with a 50-sample history and
+// DefaultCPUThreshold.
+func NewAutoOptimizer() *AutoOptimizer {
+	return &AutoOptimizer{maxHistory: 50, cpuThreshold: DefaultCPUThreshold}
+}
+
+// SetCPUThreshold overrides the CPU% ShouldOptimizeCPU triggers at.
+func (o *AutoOptimizer) SetCPUThreshold(percent float64) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.cpuThreshold = percent
+}
+
+// Sample captures the current PerformanceMetrics, records it as the
+// baseline if this is the first call, and appends it to the bounded
+// history.
+func (o *AutoOptimizer) Sample() *PerformanceMetrics {
+	m := &PerformanceMetrics{
+		Timestamp:  time.Now(),
+		CPUPercent: o.getCPUUsage(),
+	}
+
+	var ms runtime.MemStats
+	runtime.ReadMemStats(&ms)
+	m.HeapAlloc = ms.HeapAlloc
+	m.NumGC = ms.NumGC
+	if ms.NumGC > 0 {
+		m.GCPauseNs = ms.PauseNs[(ms.NumGC+255)%256]
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if o.baseline == nil {
+		o.baseline = m
+	}
+	o.history = append(o.history, m)
+	if len(o.history) > o.maxHistory {
+		o.history = o.history[len(o.history)-o.maxHistory:]
+	}
+	if m.CPUPercent >= o.cpuThreshold {
+		o.alertCount++
+	}
+	return m
+}
+
+// AlertCount returns the number of samples recorded so far whose CPU
+// usage met or exceeded the optimization threshold.
+func (o *AutoOptimizer) AlertCount() int64 {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.alertCount
+}
+
+// getCPUUsage measures this process's CPU usage, as a percentage of one
+// core, since the previous call. The first call has no prior sample to
+// diff against and returns 0.
+func (o *AutoOptimizer) getCPUUsage() float64 {
+	cpuTime, err := processCPUTime()
+	if err != nil {
+		return 0
+	}
+	now := time.Now()
+
+	o.mu.Lock()
+	prev := o.lastCPU
+	o.lastCPU = &cpuSample{at: now, cpuTime: cpuTime}
+	o.mu.Unlock()
+
+	if prev == nil {
+		return 0
+	}
+	wallDelta := now.Sub(prev.at)
+	if wallDelta <= 0 {
+		return 0
+	}
+	cpuDelta := cpuTime - prev.cpuTime
+	return float64(cpuDelta) / float64(wallDelta) * 100
+}
+
+// Baseline returns the first sample ever recorded, or nil if Sample has
+// never been called.
+func (o *AutoOptimizer) Baseline() *PerformanceMetrics {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.baseline
+}
+
+// History returns every sample kept, oldest first.
+func (o *AutoOptimizer) History() []*PerformanceMetrics {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	out := make([]*PerformanceMetrics, len(o.history))
+	copy(out, o.history)
+	return out
+}
+
+// ShouldOptimizeCPU reports whether m's CPU usage is high enough to
+// trigger a CPU-bound optimization strategy.
+func (o *AutoOptimizer) ShouldOptimizeCPU(m *PerformanceMetrics) bool {
+	o.mu.Lock()
+	threshold := o.cpuThreshold
+	o.mu.Unlock()
+	return m.CPUPercent >= threshold
+}
@@ -0,0 +1,91 @@
+package performance
+
+import (
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestSampleRecordsBaselineAndHistory(t *testing.T) {
+	o := NewAutoOptimizer()
+
+	if o.Baseline() != nil {
+		t.Fatal("expected no baseline before the first Sample")
+	}
+
+	first := o.Sample()
+	if o.Baseline() != first {
+		t.Fatalf("expected the first sample to become the baseline")
+	}
+
+	o.Sample()
+	o.Sample()
+
+	history := o.History()
+	if len(history) != 3 {
+		t.Fatalf("expected 3 samples in history, got %d", len(history))
+	}
+}
+
+func TestHistoryIsBoundedByMaxHistory(t *testing.T) {
+	o := NewAutoOptimizer()
+	o.maxHistory = 2
+
+	o.Sample()
+	o.Sample()
+	o.Sample()
+
+	if len(o.History()) != 2 {
+		t.Fatalf("expected history capped at 2, got %d", len(o.History()))
+	}
+}
+
+func TestGetCPUUsageMeasuresRealProcessCPUTime(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("per-process CPU time is only implemented on Linux")
+	}
+
+	o := NewAutoOptimizer()
+	o.Sample() // establish a baseline CPU sample
+
+	deadline := time.Now().Add(150 * time.Millisecond)
+	for time.Now().Before(deadline) {
+		_ = fib(22)
+	}
+
+	m := o.Sample()
+	if m.CPUPercent <= 0 {
+		t.Fatalf("expected non-zero CPU usage after busy-looping, got %f", m.CPUPercent)
+	}
+}
+
+func TestAlertCountTracksSamplesOverThreshold(t *testing.T) {
+	o := NewAutoOptimizer()
+	o.SetCPUThreshold(1000) // unreachable: no samples should count as alerts
+
+	o.Sample()
+	o.Sample()
+	if o.AlertCount() != 0 {
+		t.Fatalf("expected no alerts below threshold, got %d", o.AlertCount())
+	}
+
+	o.SetCPUThreshold(-1) // every sample's CPUPercent (>= 0) now counts
+
+	o.Sample()
+	o.Sample()
+	if o.AlertCount() != 2 {
+		t.Fatalf("expected 2 alerts once every sample exceeds threshold, got %d", o.AlertCount())
+	}
+}
+
+func TestShouldOptimizeCPURespectsThreshold(t *testing.T) {
+	o := NewAutoOptimizer()
+	o.SetCPUThreshold(50)
+
+	if o.ShouldOptimizeCPU(&PerformanceMetrics{CPUPercent: 49}) {
+		t.Fatal("expected 49%% to be below the threshold")
+	}
+	if !o.ShouldOptimizeCPU(&PerformanceMetrics{CPUPercent: 51}) {
+		t.Fatal("expected 51%% to be above the threshold")
+	}
+}
@@ -0,0 +1,208 @@
+package performance
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// BenchmarkResult summarizes a series of repeated timings of the same
+// script: central tendency, spread, and which runs (if any) were
+// statistical outliers.
+type BenchmarkResult struct {
+	Durations []time.Duration `json:"durations"`
+	Mean      time.Duration   `json:"mean"`
+	P95       time.Duration   `json:"p95"`
+	StdDev    time.Duration   `json:"stdDev"`
+	// Outliers holds the indexes into Durations whose value lies more
+	// than 3 standard deviations from Mean.
+	Outliers []int `json:"outliers"`
+}
+
+// Summarize computes a BenchmarkResult from a set of run durations.
+// Durations is not mutated; the result's own copy is sorted separately
+// where needed (e.g. for P95) without disturbing run order.
+func Summarize(durations []time.Duration) *BenchmarkResult {
+	r := &BenchmarkResult{Durations: durations}
+	if len(durations) == 0 {
+		return r
+	}
+
+	r.Mean = mean(durations)
+	r.StdDev = stddev(durations, r.Mean)
+	r.P95 = percentile(durations, 0.95)
+	r.Outliers = outliers(durations)
+
+	return r
+}
+
+// outliers flags runs using a modified z-score against the median and
+// median absolute deviation, rather than the mean and standard
+// deviation: a single extreme run inflates the standard deviation
+// enough to mask itself, but barely moves the median.
+func outliers(durations []time.Duration) []int {
+	if len(durations) < 2 {
+		return nil
+	}
+
+	med := medianDuration(durations)
+
+	deviations := make([]time.Duration, len(durations))
+	for i, d := range durations {
+		deviations[i] = time.Duration(math.Abs(float64(d - med)))
+	}
+	mad := medianDuration(deviations)
+	if mad == 0 {
+		// At least half the runs are identical, so MAD can't be used as
+		// a scale estimate - fall back to flagging anything that
+		// differs from that shared value at all.
+		var flagged []int
+		for i, d := range durations {
+			if d != med {
+				flagged = append(flagged, i)
+			}
+		}
+		return flagged
+	}
+
+	var flagged []int
+	for i, d := range durations {
+		modifiedZ := 0.6745 * float64(d-med) / float64(mad)
+		if math.Abs(modifiedZ) > 3.5 {
+			flagged = append(flagged, i)
+		}
+	}
+	return flagged
+}
+
+func medianDuration(durations []time.Duration) time.Duration {
+	sorted := make([]time.Duration, len(durations))
+	copy(sorted, durations)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 1 {
+		return sorted[mid]
+	}
+	return (sorted[mid-1] + sorted[mid]) / 2
+}
+
+func mean(durations []time.Duration) time.Duration {
+	var total time.Duration
+	for _, d := range durations {
+		total += d
+	}
+	return total / time.Duration(len(durations))
+}
+
+func stddev(durations []time.Duration, m time.Duration) time.Duration {
+	if len(durations) < 2 {
+		return 0
+	}
+	var sumSq float64
+	for _, d := range durations {
+		diff := float64(d - m)
+		sumSq += diff * diff
+	}
+	return time.Duration(math.Sqrt(sumSq / float64(len(durations)-1)))
+}
+
+func percentile(durations []time.Duration, p float64) time.Duration {
+	sorted := make([]time.Duration, len(durations))
+	copy(sorted, durations)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	idx := int(math.Ceil(p*float64(len(sorted)))) - 1
+	if idx < 0 {
+		idx = 0
+	}
+	if idx >= len(sorted) {
+		idx = len(sorted) - 1
+	}
+	return sorted[idx]
+}
+
+// BenchmarkBaseline is the persisted summary of a prior benchmark run,
+// used to detect regressions in later runs of the same script.
+type BenchmarkBaseline struct {
+	ScriptLabel string        `json:"scriptLabel"`
+	Mean        time.Duration `json:"mean"`
+	StdDev      time.Duration `json:"stdDev"`
+	SampleCount int           `json:"sampleCount"`
+	RecordedAt  time.Time     `json:"recordedAt"`
+}
+
+// NewBenchmarkBaseline builds a BenchmarkBaseline from a BenchmarkResult.
+func NewBenchmarkBaseline(scriptLabel string, result *BenchmarkResult, recordedAt time.Time) BenchmarkBaseline {
+	return BenchmarkBaseline{
+		ScriptLabel: scriptLabel,
+		Mean:        result.Mean,
+		StdDev:      result.StdDev,
+		SampleCount: len(result.Durations),
+		RecordedAt:  recordedAt,
+	}
+}
+
+// RegressionReport is the outcome of comparing a fresh BenchmarkResult
+// against a previously recorded BenchmarkBaseline.
+type RegressionReport struct {
+	Regressed  bool    `json:"regressed"`
+	ZScore     float64 `json:"zScore"`
+	PercentOff float64 `json:"percentOff"`
+	Detail     string  `json:"detail"`
+}
+
+// CompareToBaseline reports whether result is a statistically
+// significant regression against baseline: it runs a two-sample
+// z-test on the means (Welch-style, using each sample's own standard
+// error) and flags a regression only when result is both slower than
+// baseline and the z-score clears thresholdSigma standard errors -
+// a single slow run from ordinary noise should not fail CI.
+func CompareToBaseline(result *BenchmarkResult, baseline BenchmarkBaseline, thresholdSigma float64) RegressionReport {
+	if baseline.SampleCount == 0 || len(result.Durations) == 0 {
+		return RegressionReport{Detail: "not enough data to compare against baseline"}
+	}
+
+	n1 := float64(len(result.Durations))
+	n2 := float64(baseline.SampleCount)
+	se := math.Sqrt(variance(result.StdDev)/n1 + variance(baseline.StdDev)/n2)
+
+	diff := float64(result.Mean - baseline.Mean)
+	percentOff := 0.0
+	if baseline.Mean > 0 {
+		percentOff = diff / float64(baseline.Mean) * 100
+	}
+
+	if se == 0 {
+		return RegressionReport{
+			Regressed:  diff > 0,
+			PercentOff: percentOff,
+			Detail:     "baseline and current runs both have zero variance",
+		}
+	}
+
+	z := diff / se
+	regressed := z > thresholdSigma
+
+	return RegressionReport{
+		Regressed:  regressed,
+		ZScore:     z,
+		PercentOff: percentOff,
+		Detail:     regressionDetail(regressed, percentOff, z),
+	}
+}
+
+func variance(stddev time.Duration) float64 {
+	v := float64(stddev)
+	return v * v
+}
+
+func regressionDetail(regressed bool, percentOff, z float64) string {
+	if regressed {
+		return "mean duration regressed by a statistically significant margin"
+	}
+	if percentOff > 0 {
+		return "mean duration increased but within normal run-to-run noise"
+	}
+	return "no regression detected"
+}
@@ -0,0 +1,104 @@
+package performance
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSummarizeComputesMeanP95AndStdDev(t *testing.T) {
+	durations := []time.Duration{
+		10 * time.Millisecond,
+		12 * time.Millisecond,
+		11 * time.Millisecond,
+		13 * time.Millisecond,
+		9 * time.Millisecond,
+	}
+
+	result := Summarize(durations)
+
+	if result.Mean != 11*time.Millisecond {
+		t.Errorf("Mean = %v, want %v", result.Mean, 11*time.Millisecond)
+	}
+	if result.P95 != 13*time.Millisecond {
+		t.Errorf("P95 = %v, want %v", result.P95, 13*time.Millisecond)
+	}
+	if result.StdDev == 0 {
+		t.Errorf("StdDev = 0, want non-zero")
+	}
+	if len(result.Outliers) != 0 {
+		t.Errorf("Outliers = %v, want none", result.Outliers)
+	}
+}
+
+func TestSummarizeFlagsOutliers(t *testing.T) {
+	durations := []time.Duration{
+		10 * time.Millisecond,
+		10 * time.Millisecond,
+		10 * time.Millisecond,
+		10 * time.Millisecond,
+		500 * time.Millisecond,
+	}
+
+	result := Summarize(durations)
+
+	if len(result.Outliers) != 1 || result.Outliers[0] != 4 {
+		t.Errorf("Outliers = %v, want [4]", result.Outliers)
+	}
+}
+
+func TestSummarizeHandlesEmptyInput(t *testing.T) {
+	result := Summarize(nil)
+	if result.Mean != 0 || result.P95 != 0 || result.StdDev != 0 {
+		t.Errorf("expected zero-value result for empty input, got %+v", result)
+	}
+}
+
+func TestCompareToBaselineFlagsSignificantRegression(t *testing.T) {
+	baseline := BenchmarkBaseline{
+		ScriptLabel: "demo",
+		Mean:        10 * time.Millisecond,
+		StdDev:      1 * time.Millisecond,
+		SampleCount: 30,
+	}
+
+	slower := Summarize(repeatedDurations(30*time.Millisecond, 1*time.Millisecond, 30))
+
+	report := CompareToBaseline(slower, baseline, 2)
+	if !report.Regressed {
+		t.Errorf("Regressed = false, want true for a 3x slowdown")
+	}
+	if report.ZScore <= 2 {
+		t.Errorf("ZScore = %v, want > 2", report.ZScore)
+	}
+}
+
+func TestCompareToBaselineIgnoresNoise(t *testing.T) {
+	baseline := BenchmarkBaseline{
+		ScriptLabel: "demo",
+		Mean:        10 * time.Millisecond,
+		StdDev:      2 * time.Millisecond,
+		SampleCount: 30,
+	}
+
+	similar := Summarize(repeatedDurations(10500*time.Microsecond, 2*time.Millisecond, 30))
+
+	report := CompareToBaseline(similar, baseline, 2)
+	if report.Regressed {
+		t.Errorf("Regressed = true, want false for a small, noisy difference")
+	}
+}
+
+// repeatedDurations returns n durations alternating a fixed amount
+// above and below mean by spread, so the sample has a known mean and
+// non-zero standard deviation without relying on randomness.
+func repeatedDurations(mean, spread time.Duration, n int) []time.Duration {
+	durations := make([]time.Duration, n)
+	for i := range durations {
+		if i%2 == 0 {
+			durations[i] = mean + spread
+		} else {
+			durations[i] = mean - spread
+		}
+	}
+	return durations
+}
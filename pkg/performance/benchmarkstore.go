@@ -0,0 +1,77 @@
+package performance
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// DefaultBenchmarkDir returns the directory where per-script benchmark
+// baselines are persisted, or "" if the home directory can't be
+// determined.
+func DefaultBenchmarkDir() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".shode", "benchmarks")
+}
+
+// BenchmarkStore persists BenchmarkBaselines to disk under Dir, one
+// JSON file per script label, so `shode bench --compare` can detect
+// regressions across separate invocations of the CLI.
+type BenchmarkStore struct {
+	Dir string
+}
+
+// NewBenchmarkStore creates a BenchmarkStore rooted at dir. If dir is
+// "", DefaultBenchmarkDir is used.
+func NewBenchmarkStore(dir string) *BenchmarkStore {
+	if dir == "" {
+		dir = DefaultBenchmarkDir()
+	}
+	return &BenchmarkStore{Dir: dir}
+}
+
+func (s *BenchmarkStore) path(scriptLabel string) string {
+	return filepath.Join(s.Dir, scriptLabel+".json")
+}
+
+// Save writes baseline to disk, overwriting any previously saved
+// baseline for the same script label.
+func (s *BenchmarkStore) Save(baseline BenchmarkBaseline) error {
+	if s.Dir == "" {
+		return fmt.Errorf("performance: could not determine benchmark directory")
+	}
+	if err := os.MkdirAll(s.Dir, 0755); err != nil {
+		return fmt.Errorf("creating benchmark dir: %w", err)
+	}
+
+	data, err := json.MarshalIndent(&baseline, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(s.path(baseline.ScriptLabel), data, 0644)
+}
+
+// Load reads a previously saved baseline for scriptLabel. It returns
+// (zero value, false, nil) if no baseline has been saved for that
+// label yet.
+func (s *BenchmarkStore) Load(scriptLabel string) (BenchmarkBaseline, bool, error) {
+	data, err := os.ReadFile(s.path(scriptLabel))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return BenchmarkBaseline{}, false, nil
+		}
+		return BenchmarkBaseline{}, false, err
+	}
+
+	var baseline BenchmarkBaseline
+	if err := json.Unmarshal(data, &baseline); err != nil {
+		return BenchmarkBaseline{}, false, fmt.Errorf("parsing benchmark baseline for %s: %w", scriptLabel, err)
+	}
+
+	return baseline, true, nil
+}
@@ -0,0 +1,56 @@
+package performance
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBenchmarkStoreRoundTripsBaseline(t *testing.T) {
+	dir := t.TempDir()
+	store := NewBenchmarkStore(dir)
+
+	baseline := BenchmarkBaseline{
+		ScriptLabel: "demo",
+		Mean:        10 * time.Millisecond,
+		StdDev:      1 * time.Millisecond,
+		SampleCount: 30,
+		RecordedAt:  time.Now(),
+	}
+
+	if err := store.Save(baseline); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	restored, ok, err := store.Load("demo")
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if !ok {
+		t.Fatalf("Load() ok = false, want true")
+	}
+	if restored.Mean != baseline.Mean || restored.StdDev != baseline.StdDev || restored.SampleCount != baseline.SampleCount {
+		t.Errorf("restored = %+v, want %+v", restored, baseline)
+	}
+}
+
+func TestBenchmarkStoreLoadMissingReturnsFalse(t *testing.T) {
+	store := NewBenchmarkStore(t.TempDir())
+
+	_, ok, err := store.Load("missing")
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if ok {
+		t.Errorf("Load() ok = true, want false for missing baseline")
+	}
+}
+
+func TestDefaultBenchmarkDirIsUnderDotShode(t *testing.T) {
+	dir := DefaultBenchmarkDir()
+	if dir == "" {
+		t.Skip("home directory not available in this environment")
+	}
+	if got, want := dir[len(dir)-len("benchmarks"):], "benchmarks"; got != want {
+		t.Errorf("DefaultBenchmarkDir() = %q, want it to end in %q", dir, want)
+	}
+}
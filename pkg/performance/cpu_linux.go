@@ -0,0 +1,53 @@
+//go:build linux
+
+package performance
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// clockTicksPerSecond is the kernel's USER_HZ, exposed to userspace via
+// sysconf(_SC_CLK_TCK). 100 is the overwhelmingly common value on Linux
+// and is what /proc/[pid]/stat's utime/stime fields are measured in.
+const clockTicksPerSecond = 100
+
+// processCPUTime reads this process's total (user + system) CPU time
+// from /proc/self/stat.
+func processCPUTime() (time.Duration, error) {
+	data, err := os.ReadFile("/proc/self/stat")
+	if err != nil {
+		return 0, err
+	}
+
+	// The comm field (2nd field) is parenthesized and may itself contain
+	// spaces or parens, so split on the closing paren rather than on
+	// whitespace.
+	parenEnd := strings.LastIndexByte(string(data), ')')
+	if parenEnd < 0 {
+		return 0, fmt.Errorf("unexpected /proc/self/stat format")
+	}
+	fields := strings.Fields(string(data)[parenEnd+1:])
+
+	// utime is field 14, stime is field 15 overall; fields here start
+	// counting from field 3, so index 11 and 12.
+	const utimeIdx, stimeIdx = 11, 12
+	if len(fields) <= stimeIdx {
+		return 0, fmt.Errorf("unexpected /proc/self/stat format")
+	}
+
+	utime, err := strconv.ParseUint(fields[utimeIdx], 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	stime, err := strconv.ParseUint(fields[stimeIdx], 10, 64)
+	if err != nil {
+		return 0, err
+	}
+
+	ticks := utime + stime
+	return time.Duration(ticks) * time.Second / clockTicksPerSecond, nil
+}
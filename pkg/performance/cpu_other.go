@@ -0,0 +1,15 @@
+//go:build !linux
+
+package performance
+
+import (
+	"errors"
+	"time"
+)
+
+// processCPUTime is only implemented on Linux, where /proc/self/stat is
+// available; on other platforms, AutoOptimizer falls back to reporting
+// CPUPercent as 0 rather than measuring it.
+func processCPUTime() (time.Duration, error) {
+	return 0, errors.New("per-process CPU time is not supported on this platform")
+}
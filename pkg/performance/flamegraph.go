@@ -0,0 +1,233 @@
+// Package performance turns per-command execution timings collected by the
+// engine into flame-graph artifacts: the folded-stack format used by
+// Brendan Gregg's flamegraph.pl, and a self-contained interactive HTML view.
+package performance
+
+import (
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Sample is one timed unit of execution: a call stack (outermost frame
+// first) and how long that frame took.
+type Sample struct {
+	Stack    []string
+	Duration time.Duration
+}
+
+// FoldedStacks renders samples in the "a;b;c duration_in_microseconds"
+// format expected by flamegraph.pl and most flame-graph tooling.
+func FoldedStacks(samples []Sample) string {
+	var b strings.Builder
+	for _, s := range samples {
+		fmt.Fprintf(&b, "%s %d\n", strings.Join(s.Stack, ";"), s.Duration.Microseconds())
+	}
+	return b.String()
+}
+
+// frame is one node of the aggregated flame-graph tree, used to drive the
+// HTML template.
+type frame struct {
+	Name     string
+	Total    time.Duration
+	Children []*frame
+}
+
+// buildTree aggregates samples that share a stack prefix into a single
+// tree, summing durations at each level - the shape a flame graph renders.
+func buildTree(samples []Sample) *frame {
+	root := &frame{Name: "root"}
+	for _, s := range samples {
+		node := root
+		node.Total += s.Duration
+		for _, name := range s.Stack {
+			var child *frame
+			for _, c := range node.Children {
+				if c.Name == name {
+					child = c
+					break
+				}
+			}
+			if child == nil {
+				child = &frame{Name: name}
+				node.Children = append(node.Children, child)
+			}
+			child.Total += s.Duration
+			node = child
+		}
+	}
+	sortTree(root)
+	return root
+}
+
+func sortTree(f *frame) {
+	sort.Slice(f.Children, func(i, j int) bool {
+		return f.Children[i].Total > f.Children[j].Total
+	})
+	for _, c := range f.Children {
+		sortTree(c)
+	}
+}
+
+const flameGraphTemplate = `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Shode Flame Graph</title>
+<style>
+  body { font-family: monospace; margin: 0; background: #111; color: #eee; }
+  #root { position: relative; }
+  .frame { position: absolute; box-sizing: border-box; border: 1px solid #111;
+           overflow: hidden; white-space: nowrap; font-size: 12px; cursor: pointer; }
+  .frame:hover { outline: 2px solid #fff; }
+  #tooltip { position: fixed; background: #222; color: #fff; padding: 4px 8px;
+             border-radius: 4px; pointer-events: none; display: none; font-size: 12px; }
+</style>
+</head>
+<body>
+<div id="root" style="height: 640px;"></div>
+<div id="tooltip"></div>
+<script>
+const data = {{.TreeJSON}};
+const rootEl = document.getElementById('root');
+const tooltip = document.getElementById('tooltip');
+const rowHeight = 20;
+
+function render(node, x, y, width) {
+  if (width <= 0) return;
+  const div = document.createElement('div');
+  div.className = 'frame';
+  div.style.left = x + 'px';
+  div.style.top = y + 'px';
+  div.style.width = width + 'px';
+  div.style.height = (rowHeight - 1) + 'px';
+  const hue = (node.name.length * 37) % 360;
+  div.style.background = 'hsl(' + hue + ', 55%, 45%)';
+  div.textContent = node.name;
+  div.addEventListener('mousemove', (e) => {
+    tooltip.style.display = 'block';
+    tooltip.style.left = (e.clientX + 8) + 'px';
+    tooltip.style.top = (e.clientY + 8) + 'px';
+    tooltip.textContent = node.name + ' - ' + node.totalMicros + 'us';
+  });
+  div.addEventListener('mouseleave', () => tooltip.style.display = 'none');
+  rootEl.appendChild(div);
+
+  let childX = x;
+  for (const child of node.children) {
+    const childWidth = node.totalMicros > 0 ? (width * child.totalMicros / node.totalMicros) : 0;
+    render(child, childX, y + rowHeight, childWidth);
+    childX += childWidth;
+  }
+}
+
+render(data, 0, 0, rootEl.clientWidth || 1200);
+</script>
+</body>
+</html>
+`
+
+type jsonFrame struct {
+	Name        string      `json:"name"`
+	TotalMicros int64       `json:"totalMicros"`
+	Children    []jsonFrame `json:"children"`
+}
+
+func toJSONFrame(f *frame) jsonFrame {
+	jf := jsonFrame{Name: f.Name, TotalMicros: f.Total.Microseconds()}
+	for _, c := range f.Children {
+		jf.Children = append(jf.Children, toJSONFrame(c))
+	}
+	return jf
+}
+
+// WriteHTML renders samples as a self-contained interactive flame graph.
+func WriteHTML(w io.Writer, samples []Sample) error {
+	tree := buildTree(samples)
+	treeJSON, err := marshalFrame(toJSONFrame(tree))
+	if err != nil {
+		return err
+	}
+
+	tmpl, err := template.New("flamegraph").Parse(flameGraphTemplate)
+	if err != nil {
+		return err
+	}
+	return tmpl.Execute(w, struct{ TreeJSON template.JS }{template.JS(treeJSON)})
+}
+
+func marshalFrame(f jsonFrame) ([]byte, error) {
+	return json.Marshal(f)
+}
+
+const svgRowHeight = 20
+
+// WriteSVG renders samples as a static SVG flame graph - the same
+// frame-width-proportional-to-duration layout as WriteHTML, but as a
+// plain image that doesn't need a browser's JS engine to display (e.g.
+// for embedding in a report or viewing in an image viewer).
+func WriteSVG(w io.Writer, samples []Sample) error {
+	tree := buildTree(samples)
+	depth := treeDepth(tree)
+
+	const width = 1200
+	height := depth * svgRowHeight
+	if height == 0 {
+		height = svgRowHeight
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" font-family="monospace" font-size="12">`+"\n", width, height)
+	fmt.Fprintf(&b, `<rect width="%d" height="%d" fill="#111"/>`+"\n", width, height)
+	writeSVGFrame(&b, tree, 0, 0, float64(width))
+	b.WriteString("</svg>\n")
+
+	_, err := w.Write([]byte(b.String()))
+	return err
+}
+
+func treeDepth(f *frame) int {
+	max := 0
+	for _, c := range f.Children {
+		if d := treeDepth(c); d > max {
+			max = d
+		}
+	}
+	return max + 1
+}
+
+func writeSVGFrame(b *strings.Builder, f *frame, x, y, width float64) {
+	if width <= 0 {
+		return
+	}
+
+	hue := (len(f.Name) * 37) % 360
+	fmt.Fprintf(b, `<rect x="%.1f" y="%.1f" width="%.1f" height="%d" fill="hsl(%d,55%%,45%%)" stroke="#111"/>`+"\n",
+		x, y, width, svgRowHeight-1, hue)
+	if width > 20 {
+		fmt.Fprintf(b, `<text x="%.1f" y="%.1f" fill="#eee" clip-path="inset(0 0 0 0)">%s</text>`+"\n",
+			x+2, y+14, escapeSVGText(f.Name))
+	}
+
+	childX := x
+	for _, c := range f.Children {
+		childWidth := 0.0
+		if f.Total > 0 {
+			childWidth = width * float64(c.Total) / float64(f.Total)
+		}
+		writeSVGFrame(b, c, childX, y+svgRowHeight, childWidth)
+		childX += childWidth
+	}
+}
+
+func escapeSVGText(s string) string {
+	s = strings.ReplaceAll(s, "&", "&amp;")
+	s = strings.ReplaceAll(s, "<", "&lt;")
+	s = strings.ReplaceAll(s, ">", "&gt;")
+	return s
+}
@@ -0,0 +1,88 @@
+package performance
+
+import (
+	"bufio"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// HotFunction is one row of a `go tool pprof -top` report: a real,
+// symbolicated function name together with how much of the profile it
+// accounts for. Flat and Cum keep pprof's own formatting (e.g. "10ms"
+// for a CPU profile, "512kB" for a heap profile) rather than being
+// parsed into a single unit, since the unit depends on the profile's
+// sample type.
+type HotFunction struct {
+	Function    string
+	Flat        string
+	FlatPercent float64
+	Cum         string
+	CumPercent  float64
+}
+
+// HotFunctions symbolicates profilePath (a CPU or heap profile written
+// by runtime/pprof, e.g. by Agent) into real function names ordered by
+// self time, by shelling out to `go tool pprof -top`. Go's pprof
+// profiles embed their own function table, so this reuses the Go
+// toolchain's parser and symbolication instead of re-implementing the
+// pprof wire format.
+func HotFunctions(profilePath string, topN int) ([]HotFunction, error) {
+	if topN <= 0 {
+		topN = 10
+	}
+
+	cmd := exec.Command("go", "tool", "pprof", "-top", "-nodecount="+strconv.Itoa(topN), profilePath)
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("go tool pprof: %w", err)
+	}
+	return parsePprofTop(string(out))
+}
+
+// parsePprofTop extracts the rows of `go tool pprof -top`'s table,
+// which looks like:
+//
+//	   flat  flat%   sum%        cum   cum%
+//	10.5ms  42.00%  42.00%    10.5ms  42.00%  main.hotLoop
+func parsePprofTop(output string) ([]HotFunction, error) {
+	var functions []HotFunction
+
+	inTable := false
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	for scanner.Scan() {
+		line := scanner.Text()
+		trimmed := strings.TrimSpace(line)
+
+		if !inTable {
+			if strings.HasPrefix(trimmed, "flat") && strings.Contains(trimmed, "cum") {
+				inTable = true
+			}
+			continue
+		}
+
+		fields := strings.Fields(trimmed)
+		if len(fields) < 6 {
+			continue
+		}
+
+		flatPct, err := strconv.ParseFloat(strings.TrimSuffix(fields[1], "%"), 64)
+		if err != nil {
+			continue // not a data row, e.g. a blank line or trailing note
+		}
+		cumPct, err := strconv.ParseFloat(strings.TrimSuffix(fields[4], "%"), 64)
+		if err != nil {
+			continue
+		}
+
+		functions = append(functions, HotFunction{
+			Function:    strings.Join(fields[5:], " "),
+			Flat:        fields[0],
+			FlatPercent: flatPct,
+			Cum:         fields[3],
+			CumPercent:  cumPct,
+		})
+	}
+	return functions, scanner.Err()
+}
@@ -0,0 +1,54 @@
+package performance
+
+import (
+	"os"
+	"path/filepath"
+	"runtime/pprof"
+	"testing"
+	"time"
+)
+
+func TestHotFunctionsReturnsRealSymbolicatedNames(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "cpu.pprof")
+
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := pprof.StartCPUProfile(f); err != nil {
+		t.Fatal(err)
+	}
+	burnCPU(100 * time.Millisecond)
+	pprof.StopCPUProfile()
+	f.Close()
+
+	functions, err := HotFunctions(path, 5)
+	if err != nil {
+		t.Fatalf("HotFunctions returned error: %v", err)
+	}
+	if len(functions) == 0 {
+		t.Fatal("expected at least one hot function")
+	}
+	for _, fn := range functions {
+		if fn.Function == "" || fn.Function == "unknown" {
+			t.Fatalf("expected a real function name, got %q", fn.Function)
+		}
+	}
+}
+
+// burnCPU spends d busy-looping so the CPU profile above has samples to
+// symbolicate, attributed to this very function.
+func burnCPU(d time.Duration) {
+	deadline := time.Now().Add(d)
+	for time.Now().Before(deadline) {
+		_ = fib(20)
+	}
+}
+
+func fib(n int) int {
+	if n < 2 {
+		return n
+	}
+	return fib(n-1) + fib(n-2)
+}
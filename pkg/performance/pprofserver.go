@@ -0,0 +1,25 @@
+package performance
+
+import (
+	"net/http"
+	"net/http/pprof"
+)
+
+// NewPprofServer builds an *http.Server exposing Go's standard
+// /debug/pprof/* profiles on addr. It uses its own ServeMux rather than
+// http.DefaultServeMux, so enabling it is opt-in and doesn't leak
+// profiling handlers into a caller that imports this package but never
+// calls NewPprofServer.
+func NewPprofServer(addr string) *http.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+
+	return &http.Server{
+		Addr:    addr,
+		Handler: mux,
+	}
+}
@@ -0,0 +1,18 @@
+package performance
+
+import (
+	"net/http/httptest"
+	"testing"
+)
+
+func TestPprofServerServesIndex(t *testing.T) {
+	srv := NewPprofServer("127.0.0.1:0")
+
+	req := httptest.NewRequest("GET", "/debug/pprof/", nil)
+	rec := httptest.NewRecorder()
+	srv.Handler.ServeHTTP(rec, req)
+
+	if rec.Code != 200 {
+		t.Fatalf("expected 200 from /debug/pprof/, got %d", rec.Code)
+	}
+}
@@ -0,0 +1,48 @@
+package performance
+
+import (
+	"time"
+
+	"gitee.com/com_818cloud/shode/pkg/engine"
+)
+
+// Profiler converts the per-command durations an ExecutionEngine reports
+// into flame-graph Samples. Shode's AST is executed as a flat command
+// stream rather than a call tree, so each command is recorded as a single
+// frame under the script name.
+type Profiler struct {
+	script string
+}
+
+// NewProfiler creates a Profiler that labels every sample as running under
+// scriptName, the root frame of the resulting flame graph.
+func NewProfiler(scriptName string) *Profiler {
+	return &Profiler{script: scriptName}
+}
+
+// Samples converts an ExecutionResult's per-command timings into flame
+// graph samples.
+func (p *Profiler) Samples(result *engine.ExecutionResult) []Sample {
+	samples := make([]Sample, 0, len(result.Commands))
+	for _, cmd := range result.Commands {
+		name := cmd.Command.Name
+		if name == "" {
+			name = "?"
+		}
+		samples = append(samples, Sample{
+			Stack:    []string{p.script, name},
+			Duration: cmd.Duration,
+		})
+	}
+	return samples
+}
+
+// TotalDuration sums the durations of every sample, handy for sanity
+// checking a trace against the wall-clock execution time.
+func TotalDuration(samples []Sample) time.Duration {
+	var total time.Duration
+	for _, s := range samples {
+		total += s.Duration
+	}
+	return total
+}
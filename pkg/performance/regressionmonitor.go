@@ -0,0 +1,249 @@
+package performance
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/smtp"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RegressionAlert is one detected performance regression - a span of
+// time during which a script's CPU usage met or exceeded the
+// optimizer's threshold - tracked from when it first fires until it
+// resolves.
+type RegressionAlert struct {
+	ID          string
+	ScriptLabel string
+	Metric      *PerformanceMetrics
+	FiredAt     time.Time
+	ResolvedAt  time.Time // zero until resolved
+}
+
+// Resolved reports whether the regression has cleared.
+func (a *RegressionAlert) Resolved() bool {
+	return !a.ResolvedAt.IsZero()
+}
+
+// Notifier delivers a RegressionAlert to an operator-facing channel.
+// resolved is false when the alert first fires and true for the
+// follow-up notification sent once it clears.
+type Notifier interface {
+	Notify(alert RegressionAlert, resolved bool) error
+}
+
+// RegressionMonitor watches an AutoOptimizer's samples for CPU
+// regressions and dispatches them to Notifiers, deduplicating repeat
+// firings of the same ongoing alert and tracking when it resolves -
+// so alerts reach operators instead of sitting in an in-memory slice
+// nobody reads.
+type RegressionMonitor struct {
+	mu          sync.Mutex
+	optimizer   *AutoOptimizer
+	scriptLabel string
+	notifiers   []Notifier
+	active      *RegressionAlert
+	history     []*RegressionAlert
+	nextID      int
+}
+
+// NewRegressionMonitor creates a RegressionMonitor over optimizer,
+// labeling alerts with scriptLabel and dispatching them to notifiers.
+func NewRegressionMonitor(optimizer *AutoOptimizer, scriptLabel string, notifiers ...Notifier) *RegressionMonitor {
+	return &RegressionMonitor{
+		optimizer:   optimizer,
+		scriptLabel: scriptLabel,
+		notifiers:   notifiers,
+	}
+}
+
+// Check samples the optimizer and, if its CPU usage newly crosses or
+// un-crosses the threshold, fires or resolves the active alert and
+// notifies every configured Notifier. A sample that repeats an
+// already-firing alert's condition is deduplicated - notifiers are not
+// re-invoked until the alert resolves.
+func (m *RegressionMonitor) Check() *PerformanceMetrics {
+	metric := m.optimizer.Sample()
+	over := m.optimizer.ShouldOptimizeCPU(metric)
+
+	m.mu.Lock()
+	var toNotify *RegressionAlert
+	var resolved bool
+
+	switch {
+	case over && m.active == nil:
+		m.nextID++
+		m.active = &RegressionAlert{
+			ID:          fmt.Sprintf("%s-%d", m.scriptLabel, m.nextID),
+			ScriptLabel: m.scriptLabel,
+			Metric:      metric,
+			FiredAt:     metric.Timestamp,
+		}
+		m.history = append(m.history, m.active)
+		toNotify = m.active
+	case !over && m.active != nil:
+		m.active.ResolvedAt = metric.Timestamp
+		toNotify = m.active
+		resolved = true
+		m.active = nil
+	}
+	m.mu.Unlock()
+
+	if toNotify != nil {
+		m.dispatch(*toNotify, resolved)
+	}
+	return metric
+}
+
+// dispatch sends alert to every notifier, logging (rather than
+// aborting on) individual failures so one broken channel doesn't
+// silence the others.
+func (m *RegressionMonitor) dispatch(alert RegressionAlert, resolved bool) {
+	for _, n := range m.notifiers {
+		if err := n.Notify(alert, resolved); err != nil {
+			fmt.Fprintf(os.Stderr, "performance: notifier failed for alert %s: %v\n", alert.ID, err)
+		}
+	}
+}
+
+// Active returns the currently firing alert, or nil if none.
+func (m *RegressionMonitor) Active() *RegressionAlert {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.active
+}
+
+// History returns every alert fired so far, oldest first, including
+// ones that have since resolved.
+func (m *RegressionMonitor) History() []*RegressionAlert {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	out := make([]*RegressionAlert, len(m.history))
+	copy(out, m.history)
+	return out
+}
+
+// alertMessage renders alert as a short human-readable line shared by
+// the webhook and Slack notifiers.
+func alertMessage(alert RegressionAlert, resolved bool) string {
+	if resolved {
+		return fmt.Sprintf("[shode] performance regression resolved for %s (alert %s)", alert.ScriptLabel, alert.ID)
+	}
+	return fmt.Sprintf("[shode] performance regression detected for %s: CPU %.1f%% (alert %s)",
+		alert.ScriptLabel, alert.Metric.CPUPercent, alert.ID)
+}
+
+// WebhookNotifier POSTs each alert as JSON to URL.
+type WebhookNotifier struct {
+	URL    string
+	Client *http.Client
+}
+
+// NewWebhookNotifier creates a WebhookNotifier posting to url.
+func NewWebhookNotifier(url string) *WebhookNotifier {
+	return &WebhookNotifier{URL: url, Client: &http.Client{Timeout: 10 * time.Second}}
+}
+
+type webhookPayload struct {
+	ID          string    `json:"id"`
+	ScriptLabel string    `json:"scriptLabel"`
+	CPUPercent  float64   `json:"cpuPercent"`
+	FiredAt     time.Time `json:"firedAt"`
+	Resolved    bool      `json:"resolved"`
+	ResolvedAt  time.Time `json:"resolvedAt,omitempty"`
+	Message     string    `json:"message"`
+}
+
+// Notify implements Notifier.
+func (w *WebhookNotifier) Notify(alert RegressionAlert, resolved bool) error {
+	body, err := json.Marshal(webhookPayload{
+		ID:          alert.ID,
+		ScriptLabel: alert.ScriptLabel,
+		CPUPercent:  alert.Metric.CPUPercent,
+		FiredAt:     alert.FiredAt,
+		Resolved:    resolved,
+		ResolvedAt:  alert.ResolvedAt,
+		Message:     alertMessage(alert, resolved),
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, w.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := w.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook endpoint returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// SlackNotifier posts each alert as a message to a Slack incoming
+// webhook URL.
+type SlackNotifier struct {
+	WebhookURL string
+	Client     *http.Client
+}
+
+// NewSlackNotifier creates a SlackNotifier posting to webhookURL.
+func NewSlackNotifier(webhookURL string) *SlackNotifier {
+	return &SlackNotifier{WebhookURL: webhookURL, Client: &http.Client{Timeout: 10 * time.Second}}
+}
+
+// Notify implements Notifier.
+func (s *SlackNotifier) Notify(alert RegressionAlert, resolved bool) error {
+	body, err := json.Marshal(map[string]string{"text": alertMessage(alert, resolved)})
+	if err != nil {
+		return err
+	}
+
+	resp, err := s.Client.Post(s.WebhookURL, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("slack webhook returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// EmailNotifier emails each alert via an SMTP relay using net/smtp.
+type EmailNotifier struct {
+	Addr string // SMTP server address, e.g. "smtp.example.com:587"
+	Auth smtp.Auth
+	From string
+	To   []string
+}
+
+// NewEmailNotifier creates an EmailNotifier relaying through addr,
+// authenticated with auth, from "from" to each address in to.
+func NewEmailNotifier(addr string, auth smtp.Auth, from string, to []string) *EmailNotifier {
+	return &EmailNotifier{Addr: addr, Auth: auth, From: from, To: to}
+}
+
+// Notify implements Notifier.
+func (e *EmailNotifier) Notify(alert RegressionAlert, resolved bool) error {
+	subject := alertMessage(alert, resolved)
+	msg := strings.Builder{}
+	fmt.Fprintf(&msg, "From: %s\r\n", e.From)
+	fmt.Fprintf(&msg, "To: %s\r\n", strings.Join(e.To, ", "))
+	fmt.Fprintf(&msg, "Subject: %s\r\n", subject)
+	fmt.Fprintf(&msg, "\r\n%s\r\n", subject)
+
+	return smtp.SendMail(e.Addr, e.Auth, e.From, e.To, []byte(msg.String()))
+}
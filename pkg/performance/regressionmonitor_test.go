@@ -0,0 +1,153 @@
+package performance
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+// recordingNotifier is a test Notifier that records every call it
+// receives, used to assert dedup/resolution behavior without a real
+// webhook/Slack/email endpoint.
+type recordingNotifier struct {
+	mu    sync.Mutex
+	calls []recordedCall
+}
+
+type recordedCall struct {
+	alert    RegressionAlert
+	resolved bool
+}
+
+func (r *recordingNotifier) Notify(alert RegressionAlert, resolved bool) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls = append(r.calls, recordedCall{alert: alert, resolved: resolved})
+	return nil
+}
+
+func (r *recordingNotifier) Calls() []recordedCall {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	out := make([]recordedCall, len(r.calls))
+	copy(out, r.calls)
+	return out
+}
+
+// forcedCPUOptimizer returns an AutoOptimizer whose threshold is set so
+// cpuPercent deterministically decides whether a sample is "over", by
+// injecting history directly rather than relying on real CPU load.
+func forcedOverThreshold(o *AutoOptimizer, over bool) {
+	if over {
+		o.SetCPUThreshold(-1) // any CPUPercent >= 0 is "over"
+	} else {
+		o.SetCPUThreshold(1000) // unreachable
+	}
+}
+
+func TestRegressionMonitorFiresOnceAndDedupsRepeatSamples(t *testing.T) {
+	o := NewAutoOptimizer()
+	n := &recordingNotifier{}
+	m := NewRegressionMonitor(o, "myscript", n)
+
+	forcedOverThreshold(o, true)
+	m.Check()
+	m.Check()
+	m.Check()
+
+	calls := n.Calls()
+	if len(calls) != 1 {
+		t.Fatalf("expected exactly 1 notification for a sustained regression, got %d", len(calls))
+	}
+	if calls[0].resolved {
+		t.Fatal("expected the first notification to be a fire, not a resolution")
+	}
+	if m.Active() == nil {
+		t.Fatal("expected an active alert while still over threshold")
+	}
+}
+
+func TestRegressionMonitorNotifiesOnceOnResolution(t *testing.T) {
+	o := NewAutoOptimizer()
+	n := &recordingNotifier{}
+	m := NewRegressionMonitor(o, "myscript", n)
+
+	forcedOverThreshold(o, true)
+	m.Check()
+
+	forcedOverThreshold(o, false)
+	m.Check()
+	m.Check()
+
+	calls := n.Calls()
+	if len(calls) != 2 {
+		t.Fatalf("expected a fire and a single resolution notification, got %d", len(calls))
+	}
+	if !calls[1].resolved {
+		t.Fatal("expected the second notification to mark resolution")
+	}
+	if m.Active() != nil {
+		t.Fatal("expected no active alert after resolution")
+	}
+	if len(m.History()) != 1 {
+		t.Fatalf("expected 1 alert in history, got %d", len(m.History()))
+	}
+}
+
+func TestWebhookNotifierPostsJSON(t *testing.T) {
+	var gotBody map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&gotBody); err != nil {
+			t.Errorf("decoding webhook body: %v", err)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	notifier := NewWebhookNotifier(srv.URL)
+	alert := RegressionAlert{ID: "s-1", ScriptLabel: "s", Metric: &PerformanceMetrics{CPUPercent: 95}}
+
+	if err := notifier.Notify(alert, false); err != nil {
+		t.Fatalf("Notify returned error: %v", err)
+	}
+	if gotBody["id"] != "s-1" {
+		t.Fatalf("expected webhook payload to include alert id, got: %v", gotBody)
+	}
+}
+
+func TestSlackNotifierPostsMessage(t *testing.T) {
+	var gotBody map[string]string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&gotBody); err != nil {
+			t.Errorf("decoding slack body: %v", err)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	notifier := NewSlackNotifier(srv.URL)
+	alert := RegressionAlert{ID: "s-1", ScriptLabel: "s", Metric: &PerformanceMetrics{CPUPercent: 95}}
+
+	if err := notifier.Notify(alert, false); err != nil {
+		t.Fatalf("Notify returned error: %v", err)
+	}
+	if gotBody["text"] == "" {
+		t.Fatalf("expected a non-empty Slack message, got: %v", gotBody)
+	}
+}
+
+func TestWebhookNotifierReturnsErrorOnNonSuccessStatus(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	notifier := NewWebhookNotifier(srv.URL)
+	alert := RegressionAlert{ID: "s-1", ScriptLabel: "s", Metric: &PerformanceMetrics{CPUPercent: 95}}
+
+	if err := notifier.Notify(alert, false); err == nil {
+		t.Fatal("expected an error for a 500 response")
+	}
+}
@@ -0,0 +1,95 @@
+package performance
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// DefaultBaselineDir returns the directory where per-script performance
+// baselines and history are persisted, or "" if the home directory can't
+// be determined.
+func DefaultBaselineDir() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".shode", "performance")
+}
+
+// baselineFile is the on-disk representation of a script's persisted
+// AutoOptimizer state. It is plain JSON rather than SQLite since a
+// script's history is small and read/written wholesale, not queried.
+type baselineFile struct {
+	ScriptLabel string                `json:"scriptLabel"`
+	Baseline    *PerformanceMetrics   `json:"baseline"`
+	History     []*PerformanceMetrics `json:"history"`
+}
+
+// BaselineStore persists AutoOptimizer baselines and history to disk
+// under Dir, one JSON file per script label, so regression detection
+// survives process restarts and can compare across runs of a script.
+type BaselineStore struct {
+	Dir string
+}
+
+// NewBaselineStore creates a BaselineStore rooted at dir. If dir is "",
+// DefaultBaselineDir is used.
+func NewBaselineStore(dir string) *BaselineStore {
+	if dir == "" {
+		dir = DefaultBaselineDir()
+	}
+	return &BaselineStore{Dir: dir}
+}
+
+func (s *BaselineStore) path(scriptLabel string) string {
+	return filepath.Join(s.Dir, scriptLabel+".json")
+}
+
+// Save writes o's baseline and history for scriptLabel to disk,
+// overwriting any previously saved state for that label.
+func (s *BaselineStore) Save(scriptLabel string, o *AutoOptimizer) error {
+	if s.Dir == "" {
+		return fmt.Errorf("performance: could not determine baseline directory")
+	}
+	if err := os.MkdirAll(s.Dir, 0755); err != nil {
+		return fmt.Errorf("creating baseline dir: %w", err)
+	}
+
+	data, err := json.MarshalIndent(&baselineFile{
+		ScriptLabel: scriptLabel,
+		Baseline:    o.Baseline(),
+		History:     o.History(),
+	}, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(s.path(scriptLabel), data, 0644)
+}
+
+// Load restores a previously saved baseline and history for scriptLabel
+// into o. It returns (false, nil) if no state has been saved for that
+// label yet.
+func (s *BaselineStore) Load(scriptLabel string, o *AutoOptimizer) (bool, error) {
+	data, err := os.ReadFile(s.path(scriptLabel))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	var bf baselineFile
+	if err := json.Unmarshal(data, &bf); err != nil {
+		return false, fmt.Errorf("parsing baseline file for %s: %w", scriptLabel, err)
+	}
+
+	o.mu.Lock()
+	o.baseline = bf.Baseline
+	o.history = bf.History
+	o.mu.Unlock()
+
+	return true, nil
+}
@@ -0,0 +1,61 @@
+package performance
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestBaselineStoreRoundTripsBaselineAndHistory(t *testing.T) {
+	dir := t.TempDir()
+	store := NewBaselineStore(dir)
+
+	o := NewAutoOptimizer()
+	o.Sample()
+	o.Sample()
+
+	if err := store.Save("myscript", o); err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+
+	if _, err := filepath.Glob(filepath.Join(dir, "myscript.json")); err != nil {
+		t.Fatalf("unexpected glob error: %v", err)
+	}
+
+	restored := NewAutoOptimizer()
+	ok, err := store.Load("myscript", restored)
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	if !ok {
+		t.Fatal("expected Load to find previously saved state")
+	}
+
+	if restored.Baseline() == nil || !restored.Baseline().Timestamp.Equal(o.Baseline().Timestamp) {
+		t.Fatalf("expected restored baseline to match saved baseline")
+	}
+	if len(restored.History()) != len(o.History()) {
+		t.Fatalf("expected %d history entries, got %d", len(o.History()), len(restored.History()))
+	}
+}
+
+func TestBaselineStoreLoadMissingReturnsFalse(t *testing.T) {
+	store := NewBaselineStore(t.TempDir())
+
+	ok, err := store.Load("never-saved", NewAutoOptimizer())
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	if ok {
+		t.Fatal("expected Load to report no saved state")
+	}
+}
+
+func TestDefaultBaselineDirIsUnderDotShode(t *testing.T) {
+	dir := DefaultBaselineDir()
+	if dir == "" {
+		t.Skip("home directory not available in this environment")
+	}
+	if filepath.Base(dir) != "performance" || filepath.Base(filepath.Dir(dir)) != ".shode" {
+		t.Fatalf("expected a .shode/performance path, got %s", dir)
+	}
+}
@@ -0,0 +1,27 @@
+package performance
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWriteSVGRendersFramesProportionalToDuration(t *testing.T) {
+	samples := []Sample{
+		{Stack: []string{"script", "fast"}, Duration: 10 * time.Millisecond},
+		{Stack: []string{"script", "slow"}, Duration: 90 * time.Millisecond},
+	}
+
+	var b strings.Builder
+	if err := WriteSVG(&b, samples); err != nil {
+		t.Fatalf("WriteSVG returned error: %v", err)
+	}
+
+	svg := b.String()
+	if !strings.HasPrefix(svg, "<svg") {
+		t.Fatalf("expected output to start with an <svg> tag, got: %s", svg[:min(40, len(svg))])
+	}
+	if !strings.Contains(svg, ">fast<") || !strings.Contains(svg, ">slow<") {
+		t.Fatalf("expected both frame names in the SVG, got: %s", svg)
+	}
+}
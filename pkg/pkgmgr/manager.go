@@ -724,15 +724,18 @@ func (pm *PackageManager) FindAbsoluteLatest(name string) string {
 	return metadata.LatestVersion
 }
 
-// ShowPackageInfo displays detailed information about a package
-func (pm *PackageManager) ShowPackageInfo(name string) error {
+// GetPackageDisplayInfo fetches a package's metadata from the registry and
+// returns it in display-ready form, without printing anything. Used by
+// ShowPackageInfo and by callers (e.g. the --json CLI flag) that need the
+// structured data instead of formatted text.
+func (pm *PackageManager) GetPackageDisplayInfo(name string) (*PackageDisplayInfo, error) {
 	if pm.registryClient == nil {
-		return fmt.Errorf("registry client not available")
+		return nil, fmt.Errorf("registry client not available")
 	}
 
 	metadata, err := pm.registryClient.GetPackage(context.Background(), name)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// Check installed version
@@ -771,30 +774,40 @@ func (pm *PackageManager) ShowPackageInfo(name string) error {
 		info.Dependencies = latestVersion.Dependencies
 	}
 
+	return info, nil
+}
+
+// ShowPackageInfo displays detailed information about a package
+func (pm *PackageManager) ShowPackageInfo(name string) error {
+	info, err := pm.GetPackageDisplayInfo(name)
+	if err != nil {
+		return err
+	}
+
 	// Print formatted info directly (inline to avoid cross-package function calls)
 	var builder strings.Builder
 
 	// Package name and version
-	builder.WriteString(fmt.Sprintf("\n%s@%s\n", metadata.Name, metadata.LatestVersion))
+	builder.WriteString(fmt.Sprintf("\n%s@%s\n", info.Name, info.LatestVersion))
 
 	// Description
-	if metadata.Description != "" {
-		builder.WriteString(fmt.Sprintf("\n├─ Description: %s\n", metadata.Description))
+	if info.Description != "" {
+		builder.WriteString(fmt.Sprintf("\n├─ Description: %s\n", info.Description))
 	}
 
 	// Metadata
 	builder.WriteString("├─ Metadata\n")
-	if metadata.Author != "" {
-		builder.WriteString(fmt.Sprintf("│  ├─ Author: %s\n", metadata.Author))
+	if info.Author != "" {
+		builder.WriteString(fmt.Sprintf("│  ├─ Author: %s\n", info.Author))
 	}
-	if metadata.License != "" {
-		builder.WriteString(fmt.Sprintf("│  ├─ License: %s\n", metadata.License))
+	if info.License != "" {
+		builder.WriteString(fmt.Sprintf("│  ├─ License: %s\n", info.License))
 	}
-	if metadata.Homepage != "" {
-		builder.WriteString(fmt.Sprintf("│  ├─ Homepage: %s\n", metadata.Homepage))
+	if info.Homepage != "" {
+		builder.WriteString(fmt.Sprintf("│  ├─ Homepage: %s\n", info.Homepage))
 	}
-	if metadata.Repository != "" {
-		builder.WriteString(fmt.Sprintf("│  └─ Repository: %s\n", metadata.Repository))
+	if info.Repository != "" {
+		builder.WriteString(fmt.Sprintf("│  └─ Repository: %s\n", info.Repository))
 	}
 
 	// Versions
@@ -0,0 +1,89 @@
+// Package powershell invokes PowerShell cmdlets from shode scripts,
+// capturing their result as structured JSON instead of formatted text
+// - easing automation that has to cross between a shode host and
+// Windows-only tooling. Like pkg/ssh, it shells out to an existing
+// binary (pwsh or powershell.exe) rather than reimplementing a
+// PowerShell host.
+package powershell
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+)
+
+// DefaultExecutables are tried in order to find a PowerShell host:
+// "pwsh" (PowerShell 7+, available on Linux/macOS/Windows) first, then
+// the Windows-only "powershell.exe" (Windows PowerShell 5.1).
+var DefaultExecutables = []string{"pwsh", "powershell.exe", "powershell"}
+
+// Result is a cmdlet invocation's outcome.
+type Result struct {
+	Output   interface{} `json:"output"`   // the cmdlet's result, decoded from its ConvertTo-Json output; nil if it produced none
+	Raw      string      `json:"raw"`      // the raw stdout PowerShell produced, before JSON decoding
+	ExitCode int         `json:"exitCode"` // the PowerShell host's own exit code
+}
+
+// Invoke runs cmdlet (e.g. "Get-Process -Name pwsh") through a
+// PowerShell host found on PATH, wrapping it in "ConvertTo-Json" so the
+// result comes back structured instead of as a formatted text table.
+func Invoke(ctx context.Context, cmdlet string) (*Result, error) {
+	exe, err := findExecutable()
+	if err != nil {
+		return nil, err
+	}
+	return InvokeWith(ctx, exe, cmdlet)
+}
+
+// InvokeWith is Invoke against an explicit PowerShell executable path
+// (or name resolved via PATH), for callers that already know which
+// host to use.
+func InvokeWith(ctx context.Context, executable, cmdlet string) (*Result, error) {
+	script := fmt.Sprintf("%s | ConvertTo-Json -Depth 10 -Compress", cmdlet)
+	cmd := exec.CommandContext(ctx, executable, "-NoLogo", "-NoProfile", "-NonInteractive", "-Command", script)
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	runErr := cmd.Run()
+
+	result := &Result{Raw: stdout.String()}
+	if exitErr, ok := runErr.(*exec.ExitError); ok {
+		result.ExitCode = exitErr.ExitCode()
+	} else if runErr != nil {
+		return nil, fmt.Errorf("powershell: running %q via %s: %w", cmdlet, executable, runErr)
+	}
+
+	if trimmed := bytes.TrimSpace(stdout.Bytes()); len(trimmed) > 0 {
+		if err := json.Unmarshal(trimmed, &result.Output); err != nil {
+			return nil, fmt.Errorf("powershell: decoding JSON result of %q: %w", cmdlet, err)
+		}
+	}
+
+	if result.ExitCode != 0 {
+		return result, fmt.Errorf("powershell: %q exited %d: %s", cmdlet, result.ExitCode, trimTrailingNewlines(stderr.String()))
+	}
+	return result, nil
+}
+
+// trimTrailingNewlines strips trailing newlines off a captured stderr
+// stream so error messages don't carry a dangling blank line.
+func trimTrailingNewlines(s string) string {
+	for len(s) > 0 && (s[len(s)-1] == '\n' || s[len(s)-1] == '\r') {
+		s = s[:len(s)-1]
+	}
+	return s
+}
+
+// findExecutable returns the first of DefaultExecutables found on PATH.
+func findExecutable() (string, error) {
+	for _, name := range DefaultExecutables {
+		if path, err := exec.LookPath(name); err == nil {
+			return path, nil
+		}
+	}
+	return "", fmt.Errorf("powershell: no PowerShell host found on PATH (tried %v)", DefaultExecutables)
+}
@@ -0,0 +1,88 @@
+package powershell
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeFakePowerShell writes a fake PowerShell host that echoes a
+// canned response for a given invocation, standing in for pwsh/
+// powershell.exe on machines that don't have either installed.
+func writeFakePowerShell(t *testing.T, name, script string) string {
+	t.Helper()
+	dir := t.TempDir()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte("#!/bin/sh\n"+script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestInvokeWithDecodesJSONResult(t *testing.T) {
+	exe := writeFakePowerShell(t, "pwsh", `echo '{"Name":"pwsh","Id":42}'`)
+
+	result, err := InvokeWith(context.Background(), exe, "Get-Process -Name pwsh")
+	if err != nil {
+		t.Fatalf("InvokeWith returned error: %v", err)
+	}
+
+	obj, ok := result.Output.(map[string]interface{})
+	if !ok {
+		t.Fatalf("expected decoded object, got %T", result.Output)
+	}
+	if obj["Name"] != "pwsh" {
+		t.Errorf("expected Name=pwsh, got %v", obj["Name"])
+	}
+	if obj["Id"].(float64) != 42 {
+		t.Errorf("expected Id=42, got %v", obj["Id"])
+	}
+	if result.ExitCode != 0 {
+		t.Errorf("expected exit code 0, got %d", result.ExitCode)
+	}
+}
+
+func TestInvokeWithHandlesEmptyResult(t *testing.T) {
+	exe := writeFakePowerShell(t, "pwsh", `exit 0`)
+
+	result, err := InvokeWith(context.Background(), exe, "Remove-Item nonexistent")
+	if err != nil {
+		t.Fatalf("InvokeWith returned error: %v", err)
+	}
+	if result.Output != nil {
+		t.Errorf("expected nil Output for an empty result, got %v", result.Output)
+	}
+}
+
+func TestInvokeWithSurfacesNonZeroExit(t *testing.T) {
+	exe := writeFakePowerShell(t, "pwsh", `echo "boom" 1>&2; exit 1`)
+
+	result, err := InvokeWith(context.Background(), exe, "Get-Item nonexistent")
+	if err == nil {
+		t.Fatalf("expected an error for a non-zero exit")
+	}
+	if !strings.Contains(err.Error(), "boom") {
+		t.Errorf("expected error to include stderr, got %v", err)
+	}
+	if result == nil || result.ExitCode != 1 {
+		t.Errorf("expected ExitCode 1, got %+v", result)
+	}
+}
+
+func TestInvokeWithRejectsMalformedJSON(t *testing.T) {
+	exe := writeFakePowerShell(t, "pwsh", `echo 'not json'`)
+
+	if _, err := InvokeWith(context.Background(), exe, "Get-Date"); err == nil {
+		t.Fatalf("expected a decode error for malformed JSON")
+	}
+}
+
+func TestFindExecutableReturnsErrorWhenNoneOnPath(t *testing.T) {
+	t.Setenv("PATH", t.TempDir())
+
+	if _, err := findExecutable(); err == nil {
+		t.Fatalf("expected an error when no PowerShell host is on PATH")
+	}
+}
@@ -0,0 +1,73 @@
+// Package remote runs a script across many hosts over SSH in
+// parallel, aggregating per-host results - the "Ansible-lite" piece
+// backing the `shode remote run` command. Hosts are organized into
+// named groups by an inventory file, the same grouping concept
+// Ansible's inventories use, pared down to what a single shode.toml-
+// adjacent TOML file can express.
+package remote
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/BurntSushi/toml"
+)
+
+// HostGroup is one [groups.<name>] section of an inventory file.
+type HostGroup struct {
+	Hosts          []string `toml:"hosts"`
+	User           string   `toml:"user"`
+	Port           int      `toml:"port"`
+	Password       string   `toml:"password"`
+	KeyFile        string   `toml:"key_file"`
+	KnownHostsFile string   `toml:"known_hosts_file"`
+}
+
+// Inventory is the parsed form of an inventory TOML file, e.g.:
+//
+//	[groups.web]
+//	hosts = ["web1.example.com", "web2.example.com"]
+//	user = "deploy"
+//	key_file = "~/.ssh/id_rsa"
+type Inventory struct {
+	Groups map[string]HostGroup `toml:"groups"`
+}
+
+// LoadInventory reads and parses an inventory file at path.
+func LoadInventory(path string) (*Inventory, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read inventory file: %w", err)
+	}
+
+	var inv Inventory
+	if _, err := toml.Decode(string(data), &inv); err != nil {
+		return nil, fmt.Errorf("failed to parse inventory file: %w", err)
+	}
+	return &inv, nil
+}
+
+// Group looks up a named group, erroring with the list of groups that
+// do exist when it isn't found.
+func (inv *Inventory) Group(name string) (HostGroup, error) {
+	group, ok := inv.Groups[name]
+	if !ok {
+		return HostGroup{}, fmt.Errorf("inventory has no group %q (have: %s)", name, groupNames(inv.Groups))
+	}
+	return group, nil
+}
+
+func groupNames(groups map[string]HostGroup) string {
+	names := make([]string, 0, len(groups))
+	for name := range groups {
+		names = append(names, name)
+	}
+	if len(names) == 0 {
+		return "none"
+	}
+	result := names[0]
+	for _, name := range names[1:] {
+		result += ", " + name
+	}
+	return result
+}
@@ -0,0 +1,56 @@
+package remote
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeInventory(t *testing.T, contents string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "inventory.toml")
+	if err := os.WriteFile(path, []byte(contents), 0644); err != nil {
+		t.Fatalf("failed to write inventory file: %v", err)
+	}
+	return path
+}
+
+func TestLoadInventoryParsesGroups(t *testing.T) {
+	path := writeInventory(t, `
+[groups.web]
+hosts = ["web1.example.com", "web2.example.com"]
+user = "deploy"
+port = 2222
+key_file = "/home/deploy/.ssh/id_rsa"
+
+[groups.db]
+hosts = ["db1.example.com"]
+user = "deploy"
+`)
+
+	inv, err := LoadInventory(path)
+	if err != nil {
+		t.Fatalf("LoadInventory returned error: %v", err)
+	}
+
+	web, err := inv.Group("web")
+	if err != nil {
+		t.Fatalf("Group(web) returned error: %v", err)
+	}
+	if len(web.Hosts) != 2 || web.Hosts[0] != "web1.example.com" {
+		t.Errorf("unexpected web hosts: %v", web.Hosts)
+	}
+	if web.Port != 2222 {
+		t.Errorf("expected port 2222, got %d", web.Port)
+	}
+
+	if _, err := inv.Group("cache"); err == nil {
+		t.Fatal("expected an error looking up a group that doesn't exist")
+	}
+}
+
+func TestLoadInventoryRejectsMissingFile(t *testing.T) {
+	if _, err := LoadInventory("/does/not/exist.toml"); err == nil {
+		t.Fatal("expected an error loading a missing inventory file")
+	}
+}
@@ -0,0 +1,57 @@
+package remote
+
+import (
+	"sync"
+
+	"gitee.com/com_818cloud/shode/pkg/ssh"
+)
+
+// HostResult is one host's outcome from RunScript.
+type HostResult struct {
+	Host    string
+	Success bool
+	Output  string
+	Error   string
+}
+
+// RunScript connects to every host in group and runs script (its raw
+// shell source, piped to "sh -s" on the remote end) concurrently,
+// returning one HostResult per host in group.Hosts order. A failure on
+// one host never stops the others - each gets its own HostResult with
+// Success=false and Error set.
+func RunScript(group HostGroup, script string) []HostResult {
+	results := make([]HostResult, len(group.Hosts))
+
+	var wg sync.WaitGroup
+	for i, host := range group.Hosts {
+		wg.Add(1)
+		go func(i int, host string) {
+			defer wg.Done()
+			results[i] = runOnHost(group, host, script)
+		}(i, host)
+	}
+	wg.Wait()
+
+	return results
+}
+
+func runOnHost(group HostGroup, host, script string) HostResult {
+	client, err := ssh.Dial(ssh.Config{
+		Host:           host,
+		Port:           group.Port,
+		User:           group.User,
+		Password:       group.Password,
+		KeyFile:        group.KeyFile,
+		KnownHostsFile: group.KnownHostsFile,
+	})
+	if err != nil {
+		return HostResult{Host: host, Success: false, Error: err.Error()}
+	}
+	defer client.Close()
+
+	output, err := client.RunScript(script)
+	if err != nil {
+		return HostResult{Host: host, Success: false, Output: output, Error: err.Error()}
+	}
+	return HostResult{Host: host, Success: true, Output: output}
+}
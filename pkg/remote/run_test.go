@@ -0,0 +1,169 @@
+package remote
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"fmt"
+	"net"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// startTestServer starts a minimal SSH server accepting password auth,
+// running every exec request through /bin/sh -c locally - the same
+// fixture pkg/ssh's own tests use, duplicated here since it's
+// unexported and this package tests RunScript's fan-out, not the
+// client's wire protocol.
+func startTestServer(t *testing.T, password string) (port int, shutdown func()) {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate host key: %v", err)
+	}
+	signer, err := ssh.NewSignerFromKey(key)
+	if err != nil {
+		t.Fatalf("failed to build signer: %v", err)
+	}
+
+	config := &ssh.ServerConfig{
+		PasswordCallback: func(c ssh.ConnMetadata, pass []byte) (*ssh.Permissions, error) {
+			if string(pass) != password {
+				return nil, fmt.Errorf("wrong password")
+			}
+			return nil, nil
+		},
+	}
+	config.AddHostKey(signer)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go serveTestConn(conn, config)
+		}
+	}()
+
+	return listener.Addr().(*net.TCPAddr).Port, func() { listener.Close() }
+}
+
+func serveTestConn(conn net.Conn, config *ssh.ServerConfig) {
+	sshConn, chans, reqs, err := ssh.NewServerConn(conn, config)
+	if err != nil {
+		return
+	}
+	defer sshConn.Close()
+	go ssh.DiscardRequests(reqs)
+
+	for newChannel := range chans {
+		if newChannel.ChannelType() != "session" {
+			newChannel.Reject(ssh.UnknownChannelType, "unsupported channel type")
+			continue
+		}
+		channel, requests, err := newChannel.Accept()
+		if err != nil {
+			continue
+		}
+		go serveTestSession(channel, requests)
+	}
+}
+
+func serveTestSession(channel ssh.Channel, requests <-chan *ssh.Request) {
+	defer channel.Close()
+	for req := range requests {
+		switch req.Type {
+		case "exec":
+			command := string(req.Payload[4:])
+			if req.WantReply {
+				req.Reply(true, nil)
+			}
+			runTestCommand(channel, command)
+			return
+		default:
+			if req.WantReply {
+				req.Reply(false, nil)
+			}
+		}
+	}
+}
+
+func runTestCommand(channel ssh.Channel, command string) {
+	cmd := exec.Command("/bin/sh", "-c", command)
+	cmd.Stdin = channel
+	cmd.Stdout = channel
+	cmd.Stderr = channel.Stderr()
+	exitCode := 0
+	if err := cmd.Run(); err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			exitCode = exitErr.ExitCode()
+		} else {
+			exitCode = 1
+		}
+	}
+	channel.SendRequest("exit-status", false, ssh.Marshal(struct{ ExitStatus uint32 }{uint32(exitCode)}))
+}
+
+func TestRunScriptAggregatesResultsAcrossHosts(t *testing.T) {
+	port1, shutdown1 := startTestServer(t, "s3cr3t")
+	defer shutdown1()
+	port2, shutdown2 := startTestServer(t, "s3cr3t")
+	defer shutdown2()
+
+	// HostGroup.Port is shared across the whole group, so two
+	// differently-ported local fixtures need two groups rather than
+	// one two-host group.
+	group := HostGroup{
+		Hosts:    []string{"127.0.0.1"},
+		User:     "tester",
+		Password: "s3cr3t",
+	}
+	groupA := group
+	groupA.Port = port1
+	groupB := group
+	groupB.Port = port2
+
+	resultsA := RunScript(groupA, "echo from-a")
+	resultsB := RunScript(groupB, "echo from-b")
+
+	if len(resultsA) != 1 || !resultsA[0].Success || strings.TrimSpace(resultsA[0].Output) != "from-a" {
+		t.Fatalf("unexpected resultsA: %+v", resultsA)
+	}
+	if len(resultsB) != 1 || !resultsB[0].Success || strings.TrimSpace(resultsB[0].Output) != "from-b" {
+		t.Fatalf("unexpected resultsB: %+v", resultsB)
+	}
+}
+
+func TestRunScriptReportsPerHostFailureWithoutStoppingOthers(t *testing.T) {
+	port, shutdown := startTestServer(t, "s3cr3t")
+	defer shutdown()
+
+	group := HostGroup{
+		Hosts:    []string{"127.0.0.1", "127.0.0.1"},
+		User:     "tester",
+		Password: "wrong-password",
+		Port:     port,
+	}
+
+	results := RunScript(group, "echo hi")
+	if len(results) != 2 {
+		t.Fatalf("expected 2 results, got %d", len(results))
+	}
+	for _, r := range results {
+		if r.Success {
+			t.Errorf("expected host %q to fail with the wrong password, got success", r.Host)
+		}
+		if r.Error == "" {
+			t.Errorf("expected host %q to have an error message", r.Host)
+		}
+	}
+}
@@ -0,0 +1,260 @@
+package repl
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+const (
+	keyCtrlC     = 3
+	keyCtrlD     = 4
+	keyTab       = 9
+	keyEnter     = 13
+	keyBackspace = 127
+	keyEscape    = 27
+)
+
+// ErrEOF is returned by LineEditor.ReadLine when the user sends EOF (Ctrl-D)
+// on an empty line.
+var ErrEOF = fmt.Errorf("EOF")
+
+// Completer returns the candidate completions for the text typed so far.
+// line is the full buffer, pos is the cursor offset being completed from.
+type Completer func(line string, pos int) []string
+
+// LineEditor provides readline-style editing (cursor movement, history
+// recall, tab completion) on top of a raw terminal. When raw mode can't be
+// enabled - not a tty, or an unsupported platform - it transparently falls
+// back to plain buffered line reading.
+type LineEditor struct {
+	in        *os.File
+	out       *os.File
+	history   *History
+	completer Completer
+	fallback  *bufio.Scanner
+}
+
+// NewLineEditor creates an editor reading from in and writing prompts/echo
+// to out.
+func NewLineEditor(in, out *os.File, history *History, completer Completer) *LineEditor {
+	return &LineEditor{
+		in:        in,
+		out:       out,
+		history:   history,
+		completer: completer,
+	}
+}
+
+// ReadLine prompts and reads a single line of input, supporting arrow-key
+// history navigation and tab completion when the terminal supports raw
+// mode. It returns ErrEOF when the user presses Ctrl-D on an empty buffer.
+func (e *LineEditor) ReadLine(prompt string) (string, error) {
+	state, err := enableRawMode(int(e.in.Fd()))
+	if err != nil {
+		return e.readLineFallback(prompt)
+	}
+	defer state.restore()
+
+	buf := []rune{}
+	pos := 0
+	histIdx := e.history.Len()
+	saved := ""
+
+	redraw := func() {
+		fmt.Fprint(e.out, "\r\033[K", prompt, string(buf))
+		if back := len(buf) - pos; back > 0 {
+			fmt.Fprintf(e.out, "\033[%dD", back)
+		}
+	}
+
+	fmt.Fprint(e.out, prompt)
+	reader := bufio.NewReader(e.in)
+
+	for {
+		r, _, err := reader.ReadRune()
+		if err != nil {
+			return "", err
+		}
+
+		switch r {
+		case keyCtrlC:
+			fmt.Fprintln(e.out)
+			return "", nil
+		case keyCtrlD:
+			if len(buf) == 0 {
+				fmt.Fprintln(e.out)
+				return "", ErrEOF
+			}
+		case keyEnter:
+			fmt.Fprintln(e.out)
+			return string(buf), nil
+		case keyBackspace, 8:
+			if pos > 0 {
+				buf = append(buf[:pos-1], buf[pos:]...)
+				pos--
+				redraw()
+			}
+		case keyTab:
+			e.handleCompletion(&buf, &pos)
+			redraw()
+		case keyEscape:
+			seq := make([]byte, 2)
+			if _, err := reader.Read(seq); err != nil {
+				continue
+			}
+			if seq[0] != '[' {
+				continue
+			}
+			switch seq[1] {
+			case 'A': // up
+				if histIdx == e.history.Len() {
+					saved = string(buf)
+				}
+				if line, ok := e.history.At(histIdx - 1); ok {
+					histIdx--
+					buf = []rune(line)
+					pos = len(buf)
+					redraw()
+				}
+			case 'B': // down
+				if histIdx < e.history.Len() {
+					histIdx++
+					if histIdx == e.history.Len() {
+						buf = []rune(saved)
+					} else if line, ok := e.history.At(histIdx); ok {
+						buf = []rune(line)
+					}
+					pos = len(buf)
+					redraw()
+				}
+			case 'C': // right
+				if pos < len(buf) {
+					pos++
+					redraw()
+				}
+			case 'D': // left
+				if pos > 0 {
+					pos--
+					redraw()
+				}
+			}
+		default:
+			if r >= 32 {
+				buf = append(buf[:pos], append([]rune{r}, buf[pos:]...)...)
+				pos++
+				redraw()
+			}
+		}
+	}
+}
+
+// readLineFallback is used when raw mode is unavailable (non-tty input,
+// piped scripts, unsupported platform). It behaves like a plain Scanner.
+func (e *LineEditor) readLineFallback(prompt string) (string, error) {
+	fmt.Fprint(e.out, prompt)
+	if e.fallback == nil {
+		e.fallback = bufio.NewScanner(e.in)
+	}
+	if !e.fallback.Scan() {
+		if err := e.fallback.Err(); err != nil {
+			return "", err
+		}
+		return "", ErrEOF
+	}
+	return e.fallback.Text(), nil
+}
+
+// handleCompletion replaces the word under the cursor with a completion,
+// printing the list of candidates when there is more than one match.
+func (e *LineEditor) handleCompletion(buf *[]rune, pos *int) {
+	if e.completer == nil {
+		return
+	}
+
+	line := string(*buf)
+	candidates := e.completer(line, *pos)
+	if len(candidates) == 0 {
+		return
+	}
+
+	wordStart := *pos
+	for wordStart > 0 && !isWordBoundary((*buf)[wordStart-1]) {
+		wordStart--
+	}
+	word := string((*buf)[wordStart:*pos])
+
+	if len(candidates) == 1 {
+		completion := []rune(strings.TrimPrefix(candidates[0], word))
+		*buf = append((*buf)[:*pos], append(completion, (*buf)[*pos:]...)...)
+		*pos += len(completion)
+		return
+	}
+
+	common := longestCommonPrefix(candidates)
+	if len(common) > len(word) {
+		completion := []rune(strings.TrimPrefix(common, word))
+		*buf = append((*buf)[:*pos], append(completion, (*buf)[*pos:]...)...)
+		*pos += len(completion)
+		return
+	}
+
+	fmt.Fprintln(e.out)
+	sort.Strings(candidates)
+	fmt.Fprintln(e.out, strings.Join(candidates, "  "))
+}
+
+func isWordBoundary(r rune) bool {
+	return r == ' ' || r == '\t' || r == '|' || r == ';'
+}
+
+func longestCommonPrefix(items []string) string {
+	if len(items) == 0 {
+		return ""
+	}
+	prefix := items[0]
+	for _, item := range items[1:] {
+		for !strings.HasPrefix(item, prefix) {
+			prefix = prefix[:len(prefix)-1]
+			if prefix == "" {
+				return ""
+			}
+		}
+	}
+	return prefix
+}
+
+// completeFilePath returns filesystem entries under dir matching the given
+// fragment, used by the default completer for file/path arguments.
+func completeFilePath(fragment string) []string {
+	dir := filepath.Dir(fragment)
+	base := filepath.Base(fragment)
+	if fragment == "" {
+		dir = "."
+		base = ""
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+
+	var matches []string
+	for _, entry := range entries {
+		if !strings.HasPrefix(entry.Name(), base) {
+			continue
+		}
+		name := entry.Name()
+		if dir != "." {
+			name = filepath.Join(dir, name)
+		}
+		if entry.IsDir() {
+			name += "/"
+		}
+		matches = append(matches, name)
+	}
+	return matches
+}
@@ -0,0 +1,105 @@
+package repl
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+)
+
+// defaultHistorySize caps how many entries are kept in memory and
+// persisted to disk, mirroring common shell defaults.
+const defaultHistorySize = 1000
+
+// History stores REPL command history in memory and, when a file path is
+// configured, persists it across sessions.
+type History struct {
+	entries []string
+	path    string
+	maxSize int
+}
+
+// NewHistory creates a History backed by path. If path is empty, history
+// is kept in memory only for the lifetime of the process.
+func NewHistory(path string) *History {
+	h := &History{path: path, maxSize: defaultHistorySize}
+	h.load()
+	return h
+}
+
+// DefaultHistoryPath returns the persistent history file in the user's
+// home directory, or "" if the home directory can't be determined.
+func DefaultHistoryPath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".shode_history")
+}
+
+// Add appends a command to history, ignoring consecutive duplicates and
+// blank input, then persists it if a history file is configured.
+func (h *History) Add(line string) {
+	if line == "" {
+		return
+	}
+	if len(h.entries) > 0 && h.entries[len(h.entries)-1] == line {
+		return
+	}
+
+	h.entries = append(h.entries, line)
+	if len(h.entries) > h.maxSize {
+		h.entries = h.entries[len(h.entries)-h.maxSize:]
+	}
+	h.appendToFile(line)
+}
+
+// Len returns the number of entries currently in history.
+func (h *History) Len() int {
+	return len(h.entries)
+}
+
+// At returns the history entry at index i, or ok=false if out of range.
+func (h *History) At(i int) (string, bool) {
+	if i < 0 || i >= len(h.entries) {
+		return "", false
+	}
+	return h.entries[i], true
+}
+
+// All returns a copy of the full history slice, oldest first.
+func (h *History) All() []string {
+	out := make([]string, len(h.entries))
+	copy(out, h.entries)
+	return out
+}
+
+func (h *History) load() {
+	if h.path == "" {
+		return
+	}
+	file, err := os.Open(h.path)
+	if err != nil {
+		return
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		h.entries = append(h.entries, scanner.Text())
+	}
+	if len(h.entries) > h.maxSize {
+		h.entries = h.entries[len(h.entries)-h.maxSize:]
+	}
+}
+
+func (h *History) appendToFile(line string) {
+	if h.path == "" {
+		return
+	}
+	file, err := os.OpenFile(h.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return
+	}
+	defer file.Close()
+	file.WriteString(line + "\n")
+}
@@ -1,7 +1,6 @@
 package repl
 
 import (
-	"bufio"
 	"fmt"
 	"os"
 	"strings"
@@ -13,26 +12,38 @@ import (
 	"gitee.com/com_818cloud/shode/pkg/types"
 )
 
+// builtins lists the REPL's special commands, used for completion and help.
+var builtins = []string{"exit", "quit", "help", "clear", "pwd", "env", "history", "cd", "echo", "ls", "cat"}
+
+// blockOpeners/blockClosers track the shell keywords that open and close a
+// multi-line block, so the REPL knows to keep prompting for continuation
+// lines instead of evaluating a half-finished if/for/while statement.
+var blockOpeners = map[string]string{"if": "fi", "for": "done", "while": "done"}
+var blockClosers = map[string]bool{"fi": true, "done": true}
+
 // REPL represents a Read-Eval-Print Loop interactive environment
 type REPL struct {
-	envManager   *environment.EnvironmentManager
-	security     *sandbox.SecurityChecker
-	parser       *parser.SimpleParser
-	stdlib       *stdlib.StdLib
-	history      []string
-	running      bool
+	envManager *environment.EnvironmentManager
+	security   *sandbox.SecurityChecker
+	parser     *parser.SimpleParser
+	stdlib     *stdlib.StdLib
+	history    *History
+	editor     *LineEditor
+	running    bool
 }
 
 // NewREPL creates a new interactive REPL environment
 func NewREPL() *REPL {
-	return &REPL{
+	r := &REPL{
 		envManager: environment.NewEnvironmentManager(),
 		security:   sandbox.NewSecurityChecker(),
 		parser:     parser.NewSimpleParser(),
 		stdlib:     stdlib.New(),
-		history:    make([]string, 0),
+		history:    NewHistory(DefaultHistoryPath()),
 		running:    false,
 	}
+	r.editor = NewLineEditor(os.Stdin, os.Stdout, r.history, r.complete)
+	return r
 }
 
 // Start begins the REPL interactive session
@@ -42,35 +53,99 @@ func (r *REPL) Start() {
 	fmt.Println("Type 'exit' or 'quit' to exit, 'help' for help")
 	fmt.Printf("Working directory: %s\n", r.envManager.GetWorkingDir())
 
-	scanner := bufio.NewScanner(os.Stdin)
-
 	for r.running {
-		fmt.Printf("shode> ")
-		
-		if !scanner.Scan() {
+		input, err := r.readStatement("shode> ")
+		if err != nil {
+			if err != ErrEOF {
+				fmt.Printf("Error reading input: %v\n", err)
+			}
 			break
 		}
 
-		input := strings.TrimSpace(scanner.Text())
+		input = strings.TrimSpace(input)
 		if input == "" {
 			continue
 		}
 
-		// Add to history
-		r.history = append(r.history, input)
+		r.history.Add(input)
 
-		// Handle special commands
 		if r.handleSpecialCommand(input) {
 			continue
 		}
 
-		// Process the command
 		r.processCommand(input)
 	}
+}
+
+// readStatement reads one logical statement, transparently prompting for
+// continuation lines ("> ") until every opened if/for/while block has a
+// matching fi/done.
+func (r *REPL) readStatement(prompt string) (string, error) {
+	line, err := r.editor.ReadLine(prompt)
+	if err != nil {
+		return "", err
+	}
+
+	var pending []string
+	depth := 0
+	for _, word := range strings.Fields(line) {
+		if _, ok := blockOpeners[word]; ok {
+			depth++
+		} else if blockClosers[word] {
+			depth--
+		}
+	}
+	pending = append(pending, line)
+
+	for depth > 0 {
+		cont, err := r.editor.ReadLine("> ")
+		if err != nil {
+			return "", err
+		}
+		pending = append(pending, cont)
+		for _, word := range strings.Fields(cont) {
+			if _, ok := blockOpeners[word]; ok {
+				depth++
+			} else if blockClosers[word] {
+				depth--
+			}
+		}
+	}
+
+	return strings.Join(pending, "\n"), nil
+}
+
+// complete returns tab-completion candidates for the word under the
+// cursor: builtin names, stdlib-known variables, or file paths.
+func (r *REPL) complete(line string, pos int) []string {
+	wordStart := pos
+	for wordStart > 0 && !isWordBoundary(rune(line[wordStart-1])) {
+		wordStart--
+	}
+	fragment := line[wordStart:pos]
+
+	if wordStart == 0 {
+		var matches []string
+		for _, b := range builtins {
+			if strings.HasPrefix(b, fragment) {
+				matches = append(matches, b)
+			}
+		}
+		return matches
+	}
 
-	if err := scanner.Err(); err != nil {
-		fmt.Printf("Error reading input: %v\n", err)
+	if strings.HasPrefix(fragment, "$") {
+		var matches []string
+		name := strings.TrimPrefix(fragment, "$")
+		for key := range r.envManager.GetAllEnv() {
+			if strings.HasPrefix(key, name) {
+				matches = append(matches, "$"+key)
+			}
+		}
+		return matches
 	}
+
+	return completeFilePath(fragment)
 }
 
 // handleSpecialCommand processes REPL-specific commands
@@ -135,7 +210,11 @@ func (r *REPL) processCommand(input string) {
 		return
 	}
 
-	cmd := script.Nodes[0].(*types.CommandNode)
+	cmd, ok := script.Nodes[0].(*types.CommandNode)
+	if !ok {
+		fmt.Println("(Execution engine will handle this in future versions)")
+		return
+	}
 
 	// Check security
 	if err := r.security.CheckCommand(cmd); err != nil {
@@ -215,6 +294,8 @@ func (r *REPL) showHelp() {
 	fmt.Println("  cat <file>    - Show file content")
 	fmt.Println("  echo <text>   - Echo text")
 	fmt.Println("  Other shell commands will be processed by Shode")
+	fmt.Println("  Tab completes builtins, $variables and paths; Up/Down recall history")
+	fmt.Println("  if/for/while blocks automatically continue until fi/done")
 }
 
 // showEnvironment displays current environment variables
@@ -227,7 +308,7 @@ func (r *REPL) showEnvironment() {
 
 // showHistory displays command history
 func (r *REPL) showHistory() {
-	for i, cmd := range r.history {
+	for i, cmd := range r.history.All() {
 		fmt.Printf("%4d  %s\n", i+1, cmd)
 	}
 }
@@ -239,5 +320,5 @@ func (r *REPL) Stop() {
 
 // GetHistory returns the command history
 func (r *REPL) GetHistory() []string {
-	return r.history
+	return r.history.All()
 }
@@ -0,0 +1,18 @@
+//go:build !linux
+
+package repl
+
+import "errors"
+
+// rawModeState is a stub on platforms without a raw-mode implementation.
+type rawModeState struct{}
+
+// enableRawMode is unsupported outside Linux; the editor falls back to
+// plain line-buffered input when this returns an error.
+func enableRawMode(fd int) (*rawModeState, error) {
+	return nil, errors.New("raw terminal mode is not supported on this platform")
+}
+
+func (s *rawModeState) restore() error {
+	return nil
+}
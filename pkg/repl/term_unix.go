@@ -0,0 +1,64 @@
+//go:build linux
+
+package repl
+
+import (
+	"syscall"
+	"unsafe"
+)
+
+// termios mirrors the subset of the kernel's struct termios needed to
+// toggle canonical mode. Field order/sizes match linux/amd64; darwin's
+// layout happens to be compatible for the flags we touch.
+type termios struct {
+	Iflag, Oflag, Cflag, Lflag uint32
+	Cc                         [20]byte
+	Ispeed, Ospeed             uint32
+}
+
+const (
+	ioctlGetTermios = 0x5401 // TCGETS
+	ioctlSetTermios = 0x5402 // TCSETS
+)
+
+// rawModeState captures what is needed to restore the terminal.
+type rawModeState struct {
+	fd  int
+	old termios
+}
+
+// enableRawMode puts the given file descriptor into raw mode (no echo, no
+// line buffering) so the editor can read and react to individual keys.
+func enableRawMode(fd int) (*rawModeState, error) {
+	var t termios
+	if err := ioctl(fd, ioctlGetTermios, uintptr(unsafe.Pointer(&t))); err != nil {
+		return nil, err
+	}
+
+	state := &rawModeState{fd: fd, old: t}
+
+	raw := t
+	raw.Lflag &^= syscall.ECHO | syscall.ICANON | syscall.ISIG
+	raw.Iflag &^= syscall.IXON | syscall.ICRNL
+	raw.Cc[syscall.VMIN] = 1
+	raw.Cc[syscall.VTIME] = 0
+
+	if err := ioctl(fd, ioctlSetTermios, uintptr(unsafe.Pointer(&raw))); err != nil {
+		return nil, err
+	}
+
+	return state, nil
+}
+
+// restore puts the terminal back into its original mode.
+func (s *rawModeState) restore() error {
+	return ioctl(s.fd, ioctlSetTermios, uintptr(unsafe.Pointer(&s.old)))
+}
+
+func ioctl(fd int, req uintptr, arg uintptr) error {
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, uintptr(fd), req, arg)
+	if errno != 0 {
+		return errno
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// File is a declared file resource: Path should either contain
+// Content with permissions Mode, or not exist at all when Absent is
+// set.
+type File struct {
+	Path    string
+	Content string
+	Mode    os.FileMode
+	Absent  bool
+}
+
+// ID implements Resource.
+func (f *File) ID() string { return "file:" + f.Path }
+
+// Kind implements Resource.
+func (f *File) Kind() string { return "file" }
+
+// Plan implements Resource.
+func (f *File) Plan(ctx context.Context) (Action, error) {
+	info, err := os.Stat(f.Path)
+	exists := err == nil
+	if err != nil && !os.IsNotExist(err) {
+		return Action{}, fmt.Errorf("stat %s: %w", f.Path, err)
+	}
+
+	if f.Absent {
+		if !exists {
+			return f.noop(), nil
+		}
+		return Action{ResourceID: f.ID(), Kind: f.Kind(), Type: ActionDelete, Detail: "remove " + f.Path}, nil
+	}
+
+	if !exists {
+		return Action{ResourceID: f.ID(), Kind: f.Kind(), Type: ActionCreate, Detail: fmt.Sprintf("write %s (mode %s)", f.Path, f.mode())}, nil
+	}
+
+	current, err := os.ReadFile(f.Path)
+	if err != nil {
+		return Action{}, fmt.Errorf("read %s: %w", f.Path, err)
+	}
+	if string(current) != f.Content || info.Mode().Perm() != f.mode().Perm() {
+		return Action{ResourceID: f.ID(), Kind: f.Kind(), Type: ActionUpdate, Detail: fmt.Sprintf("rewrite %s (mode %s)", f.Path, f.mode())}, nil
+	}
+	return f.noop(), nil
+}
+
+// Apply implements Resource.
+func (f *File) Apply(ctx context.Context, action Action) error {
+	switch action.Type {
+	case ActionDelete:
+		if err := os.Remove(f.Path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("remove %s: %w", f.Path, err)
+		}
+		return nil
+	case ActionCreate, ActionUpdate:
+		if dir := filepath.Dir(f.Path); dir != "." {
+			if err := os.MkdirAll(dir, 0755); err != nil {
+				return fmt.Errorf("create parent directory for %s: %w", f.Path, err)
+			}
+		}
+		if err := os.WriteFile(f.Path, []byte(f.Content), f.mode()); err != nil {
+			return fmt.Errorf("write %s: %w", f.Path, err)
+		}
+		return os.Chmod(f.Path, f.mode().Perm())
+	default:
+		return nil
+	}
+}
+
+// mode returns f.Mode, defaulting to 0644 when unset so a script that
+// only cares about content doesn't also have to specify permissions.
+func (f *File) mode() os.FileMode {
+	if f.Mode == 0 {
+		return 0644
+	}
+	return f.Mode
+}
+
+func (f *File) noop() Action {
+	return Action{ResourceID: f.ID(), Kind: f.Kind(), Type: ActionNoop}
+}
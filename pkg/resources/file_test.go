@@ -0,0 +1,100 @@
+package resources
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFilePlanCreateThenApplyThenNoop(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sub", "greeting.txt")
+	f := &File{Path: path, Content: "hello\n", Mode: 0640}
+
+	action, err := f.Plan(context.Background())
+	if err != nil {
+		t.Fatalf("Plan returned error: %v", err)
+	}
+	if action.Type != ActionCreate {
+		t.Fatalf("expected ActionCreate for a missing file, got %v", action.Type)
+	}
+
+	if err := f.Apply(context.Background(), action); err != nil {
+		t.Fatalf("Apply returned error: %v", err)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("expected file to exist after Apply: %v", err)
+	}
+	if string(content) != "hello\n" {
+		t.Errorf("expected content %q, got %q", "hello\n", content)
+	}
+
+	action, err = f.Plan(context.Background())
+	if err != nil {
+		t.Fatalf("second Plan returned error: %v", err)
+	}
+	if action.Type != ActionNoop {
+		t.Errorf("expected ActionNoop once the file matches, got %v", action.Type)
+	}
+}
+
+func TestFilePlanUpdateWhenContentDiffers(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.txt")
+	if err := os.WriteFile(path, []byte("old"), 0644); err != nil {
+		t.Fatalf("failed to seed file: %v", err)
+	}
+
+	f := &File{Path: path, Content: "new", Mode: 0644}
+	action, err := f.Plan(context.Background())
+	if err != nil {
+		t.Fatalf("Plan returned error: %v", err)
+	}
+	if action.Type != ActionUpdate {
+		t.Fatalf("expected ActionUpdate for differing content, got %v", action.Type)
+	}
+
+	if err := f.Apply(context.Background(), action); err != nil {
+		t.Fatalf("Apply returned error: %v", err)
+	}
+	content, _ := os.ReadFile(path)
+	if string(content) != "new" {
+		t.Errorf("expected content to be rewritten, got %q", content)
+	}
+}
+
+func TestFilePlanDeleteWhenAbsentButExists(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "stale.txt")
+	if err := os.WriteFile(path, []byte("x"), 0644); err != nil {
+		t.Fatalf("failed to seed file: %v", err)
+	}
+
+	f := &File{Path: path, Absent: true}
+	action, err := f.Plan(context.Background())
+	if err != nil {
+		t.Fatalf("Plan returned error: %v", err)
+	}
+	if action.Type != ActionDelete {
+		t.Fatalf("expected ActionDelete, got %v", action.Type)
+	}
+
+	if err := f.Apply(context.Background(), action); err != nil {
+		t.Fatalf("Apply returned error: %v", err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("expected file to be removed, stat error: %v", err)
+	}
+}
+
+func TestFilePlanNoopWhenAbsentAndMissing(t *testing.T) {
+	f := &File{Path: filepath.Join(t.TempDir(), "never-existed.txt"), Absent: true}
+
+	action, err := f.Plan(context.Background())
+	if err != nil {
+		t.Fatalf("Plan returned error: %v", err)
+	}
+	if action.Type != ActionNoop {
+		t.Errorf("expected ActionNoop for an already-absent file, got %v", action.Type)
+	}
+}
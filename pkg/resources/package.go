@@ -0,0 +1,104 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// Package is a declared OS package resource, managed through dpkg/
+// apt-get - the package manager shode's other Linux targets (the base
+// images pkg/pack generates, most CI containers) run. Name should be
+// absent entirely when Absent is set, otherwise present and, if
+// Version is non-empty, at exactly that version.
+type Package struct {
+	Name    string
+	Version string
+	Absent  bool
+}
+
+// ID implements Resource.
+func (p *Package) ID() string { return "package:" + p.Name }
+
+// Kind implements Resource.
+func (p *Package) Kind() string { return "package" }
+
+// Plan implements Resource.
+func (p *Package) Plan(ctx context.Context) (Action, error) {
+	installedVersion, installed, err := p.installedVersion(ctx)
+	if err != nil {
+		return Action{}, err
+	}
+
+	if p.Absent {
+		if !installed {
+			return p.noop(), nil
+		}
+		return Action{ResourceID: p.ID(), Kind: p.Kind(), Type: ActionDelete, Detail: "remove package " + p.Name}, nil
+	}
+
+	if !installed {
+		return Action{ResourceID: p.ID(), Kind: p.Kind(), Type: ActionCreate, Detail: "install " + p.target()}, nil
+	}
+	if p.Version != "" && installedVersion != p.Version {
+		return Action{ResourceID: p.ID(), Kind: p.Kind(), Type: ActionUpdate,
+			Detail: fmt.Sprintf("change %s from %s to %s", p.Name, installedVersion, p.Version)}, nil
+	}
+	return p.noop(), nil
+}
+
+// Apply implements Resource.
+func (p *Package) Apply(ctx context.Context, action Action) error {
+	switch action.Type {
+	case ActionDelete:
+		out, err := exec.CommandContext(ctx, "apt-get", "remove", "-y", p.Name).CombinedOutput()
+		if err != nil {
+			return fmt.Errorf("apt-get remove %s: %w: %s", p.Name, err, strings.TrimSpace(string(out)))
+		}
+		return nil
+	case ActionCreate, ActionUpdate:
+		out, err := exec.CommandContext(ctx, "apt-get", "install", "-y", p.target()).CombinedOutput()
+		if err != nil {
+			return fmt.Errorf("apt-get install %s: %w: %s", p.target(), err, strings.TrimSpace(string(out)))
+		}
+		return nil
+	default:
+		return nil
+	}
+}
+
+// target is the apt-get argument for installing Name, pinned to
+// Version when one was declared.
+func (p *Package) target() string {
+	if p.Version == "" {
+		return p.Name
+	}
+	return p.Name + "=" + p.Version
+}
+
+// installedVersion queries dpkg-query for Name's installed version.
+// A package that isn't installed is reported as installed=false, not
+// an error.
+func (p *Package) installedVersion(ctx context.Context) (version string, installed bool, err error) {
+	if _, lookErr := exec.LookPath("dpkg-query"); lookErr != nil {
+		return "", false, fmt.Errorf("package %s: dpkg-query not found on PATH", p.Name)
+	}
+
+	out, err := exec.CommandContext(ctx, "dpkg-query", "-W", "-f=${Status}\t${Version}", p.Name).CombinedOutput()
+	if err != nil {
+		// dpkg-query exits non-zero for an unknown package; that's
+		// "not installed", not a failure to check.
+		return "", false, nil
+	}
+
+	fields := strings.SplitN(strings.TrimSpace(string(out)), "\t", 2)
+	if len(fields) != 2 || !strings.HasPrefix(fields[0], "install ok installed") {
+		return "", false, nil
+	}
+	return fields[1], true, nil
+}
+
+func (p *Package) noop() Action {
+	return Action{ResourceID: p.ID(), Kind: p.Kind(), Type: ActionNoop}
+}
@@ -0,0 +1,114 @@
+package resources
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeFakeBin writes a fake executable named name onto a fresh PATH
+// and returns that PATH, standing in for the real package manager
+// binaries these resources shell out to.
+func writeFakeBin(t *testing.T, name, script string) string {
+	t.Helper()
+	dir := t.TempDir()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte("#!/bin/sh\n"+script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+// writeFakeBins writes several fake executables into the same
+// directory and returns it as a PATH.
+func writeFakeBins(t *testing.T, scripts map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, script := range scripts {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte("#!/bin/sh\n"+script), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestPackagePlanCreateWhenNotInstalled(t *testing.T) {
+	t.Setenv("PATH", writeFakeBin(t, "dpkg-query", `exit 1`))
+
+	p := &Package{Name: "curl"}
+	action, err := p.Plan(context.Background())
+	if err != nil {
+		t.Fatalf("Plan returned error: %v", err)
+	}
+	if action.Type != ActionCreate {
+		t.Fatalf("expected ActionCreate, got %v", action.Type)
+	}
+}
+
+func TestPackagePlanNoopWhenInstalledAtDesiredVersion(t *testing.T) {
+	t.Setenv("PATH", writeFakeBin(t, "dpkg-query", `printf 'install ok installed\t1.2.3'`))
+
+	p := &Package{Name: "curl", Version: "1.2.3"}
+	action, err := p.Plan(context.Background())
+	if err != nil {
+		t.Fatalf("Plan returned error: %v", err)
+	}
+	if action.Type != ActionNoop {
+		t.Fatalf("expected ActionNoop, got %v", action.Type)
+	}
+}
+
+func TestPackagePlanUpdateWhenVersionDiffers(t *testing.T) {
+	t.Setenv("PATH", writeFakeBin(t, "dpkg-query", `printf 'install ok installed\t1.2.3'`))
+
+	p := &Package{Name: "curl", Version: "2.0.0"}
+	action, err := p.Plan(context.Background())
+	if err != nil {
+		t.Fatalf("Plan returned error: %v", err)
+	}
+	if action.Type != ActionUpdate {
+		t.Fatalf("expected ActionUpdate, got %v", action.Type)
+	}
+}
+
+func TestPackagePlanDeleteWhenAbsentButInstalled(t *testing.T) {
+	t.Setenv("PATH", writeFakeBin(t, "dpkg-query", `printf 'install ok installed\t1.2.3'`))
+
+	p := &Package{Name: "curl", Absent: true}
+	action, err := p.Plan(context.Background())
+	if err != nil {
+		t.Fatalf("Plan returned error: %v", err)
+	}
+	if action.Type != ActionDelete {
+		t.Fatalf("expected ActionDelete, got %v", action.Type)
+	}
+}
+
+func TestPackageApplyCreateInvokesAptGetInstallWithPinnedVersion(t *testing.T) {
+	logPath := filepath.Join(t.TempDir(), "apt-get.log")
+	dir := writeFakeBins(t, map[string]string{
+		"dpkg-query": `exit 1`,
+		"apt-get":    `echo "$@" >> ` + logPath,
+	})
+	t.Setenv("PATH", dir)
+
+	p := &Package{Name: "curl", Version: "1.2.3"}
+	action, err := p.Plan(context.Background())
+	if err != nil {
+		t.Fatalf("Plan returned error: %v", err)
+	}
+	if err := p.Apply(context.Background(), action); err != nil {
+		t.Fatalf("Apply returned error: %v", err)
+	}
+
+	log, err := os.ReadFile(logPath)
+	if err != nil {
+		t.Fatalf("expected apt-get to have been invoked: %v", err)
+	}
+	if !strings.Contains(string(log), "install -y curl=1.2.3") {
+		t.Errorf("expected apt-get install -y curl=1.2.3, got %q", log)
+	}
+}
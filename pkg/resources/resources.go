@@ -0,0 +1,138 @@
+// Package resources implements a small Terraform-style provisioning
+// model: scripts declare the desired state of files, packages,
+// services, and users, Plan diffs that desired state against what's
+// actually on the machine, and Apply carries out only the resulting
+// changes - so re-running the same script twice is a no-op the second
+// time instead of redoing work or erroring on "already exists".
+package resources
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// ActionType is the kind of change Plan decided a resource needs.
+type ActionType string
+
+const (
+	// ActionNoop means the resource already matches its desired state.
+	ActionNoop ActionType = "noop"
+	// ActionCreate means the resource doesn't exist yet and Apply
+	// will create it.
+	ActionCreate ActionType = "create"
+	// ActionUpdate means the resource exists but differs from its
+	// desired state and Apply will bring it into line.
+	ActionUpdate ActionType = "update"
+	// ActionDelete means the resource exists but is declared absent
+	// and Apply will remove it.
+	ActionDelete ActionType = "delete"
+)
+
+// Action is the outcome of diffing one Resource's desired state
+// against the machine's current state.
+type Action struct {
+	ResourceID string     `json:"resourceId"`
+	Kind       string     `json:"kind"`
+	Type       ActionType `json:"type"`
+	Detail     string     `json:"detail,omitempty"`
+}
+
+// Resource is a single piece of declared desired state - a file,
+// package, service, or user. Plan must not change anything on the
+// machine; only Apply may.
+type Resource interface {
+	// ID uniquely identifies this resource within a State, e.g.
+	// "file:/etc/hosts".
+	ID() string
+	// Kind names the resource type, e.g. "file".
+	Kind() string
+	// Plan inspects the machine's current state and reports what
+	// Apply would need to do to reach the desired state, without
+	// changing anything itself.
+	Plan(ctx context.Context) (Action, error)
+	// Apply carries out action, which must be the Action Plan most
+	// recently returned for this resource.
+	Apply(ctx context.Context, action Action) error
+}
+
+// State accumulates the resources a script declares over its run, in
+// declaration order, so Plan/Apply can be called once at the end
+// against everything the script has described so far.
+type State struct {
+	mu        sync.Mutex
+	resources []Resource
+}
+
+// NewState creates an empty State.
+func NewState() *State {
+	return &State{}
+}
+
+// Declare adds r to the state and returns its ID, the same value a
+// script would get back from the stdlib builtin that created r.
+func (s *State) Declare(r Resource) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.resources = append(s.resources, r)
+	return r.ID()
+}
+
+// Resources returns every resource declared so far, in declaration
+// order.
+func (s *State) Resources() []Resource {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Resource(nil), s.resources...)
+}
+
+// Plan diffs every resource in resources against the machine's
+// current state and returns the action each one needs, in the same
+// order as resources.
+func Plan(ctx context.Context, resources []Resource) ([]Action, error) {
+	actions := make([]Action, len(resources))
+	for i, r := range resources {
+		action, err := r.Plan(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("planning %s: %w", r.ID(), err)
+		}
+		actions[i] = action
+	}
+	return actions, nil
+}
+
+// Result is the outcome of applying a single resource's planned
+// Action.
+type Result struct {
+	ResourceID string     `json:"resourceId"`
+	Type       ActionType `json:"type"`
+	Success    bool       `json:"success"`
+	Error      string     `json:"error,omitempty"`
+}
+
+// Apply carries out actions (as returned by Plan, in the same order
+// as resources) against the machine, one resource at a time. A
+// resource whose action fails doesn't stop the rest from being
+// attempted; its failure is reported in the corresponding Result.
+func Apply(ctx context.Context, resources []Resource, actions []Action) ([]Result, error) {
+	if len(resources) != len(actions) {
+		return nil, fmt.Errorf("resources/actions length mismatch: %d resources, %d actions", len(resources), len(actions))
+	}
+
+	results := make([]Result, len(resources))
+	for i, r := range resources {
+		action := actions[i]
+		results[i] = Result{ResourceID: r.ID(), Type: action.Type}
+
+		if action.Type == ActionNoop {
+			results[i].Success = true
+			continue
+		}
+		if err := r.Apply(ctx, action); err != nil {
+			results[i].Error = err.Error()
+			continue
+		}
+		results[i].Success = true
+	}
+	return results, nil
+}
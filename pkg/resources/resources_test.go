@@ -0,0 +1,87 @@
+package resources
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// fakeResource is a minimal Resource for exercising Plan/Apply
+// orchestration without touching the filesystem or shelling out.
+type fakeResource struct {
+	id       string
+	action   Action
+	applyErr error
+	applied  bool
+}
+
+func (f *fakeResource) ID() string   { return f.id }
+func (f *fakeResource) Kind() string { return "fake" }
+
+func (f *fakeResource) Plan(ctx context.Context) (Action, error) {
+	return f.action, nil
+}
+
+func (f *fakeResource) Apply(ctx context.Context, action Action) error {
+	f.applied = true
+	return f.applyErr
+}
+
+func TestPlanReturnsOneActionPerResourceInOrder(t *testing.T) {
+	a := &fakeResource{id: "a", action: Action{ResourceID: "a", Type: ActionCreate}}
+	b := &fakeResource{id: "b", action: Action{ResourceID: "b", Type: ActionNoop}}
+
+	actions, err := Plan(context.Background(), []Resource{a, b})
+	if err != nil {
+		t.Fatalf("Plan returned error: %v", err)
+	}
+	if len(actions) != 2 || actions[0].ResourceID != "a" || actions[1].ResourceID != "b" {
+		t.Fatalf("unexpected actions: %+v", actions)
+	}
+}
+
+func TestApplySkipsNoopResources(t *testing.T) {
+	noop := &fakeResource{id: "a", action: Action{Type: ActionNoop}}
+	create := &fakeResource{id: "b", action: Action{Type: ActionCreate}}
+
+	results, err := Apply(context.Background(), []Resource{noop, create}, []Action{noop.action, create.action})
+	if err != nil {
+		t.Fatalf("Apply returned error: %v", err)
+	}
+	if noop.applied {
+		t.Error("expected a noop resource's Apply not to be called")
+	}
+	if !create.applied {
+		t.Error("expected a create resource's Apply to be called")
+	}
+	if !results[0].Success || !results[1].Success {
+		t.Errorf("expected both results to succeed, got %+v", results)
+	}
+}
+
+func TestApplyRecordsPerResourceFailureWithoutStopping(t *testing.T) {
+	failing := &fakeResource{id: "a", action: Action{Type: ActionCreate}, applyErr: errors.New("boom")}
+	ok := &fakeResource{id: "b", action: Action{Type: ActionCreate}}
+
+	results, err := Apply(context.Background(), []Resource{failing, ok}, []Action{failing.action, ok.action})
+	if err != nil {
+		t.Fatalf("Apply returned error: %v", err)
+	}
+	if results[0].Success || results[0].Error != "boom" {
+		t.Errorf("expected resource a to fail with \"boom\", got %+v", results[0])
+	}
+	if !ok.applied || !results[1].Success {
+		t.Errorf("expected resource b to still be applied after a's failure, got %+v", results[1])
+	}
+}
+
+func TestStateDeclareAccumulatesInOrder(t *testing.T) {
+	state := NewState()
+	state.Declare(&fakeResource{id: "a"})
+	state.Declare(&fakeResource{id: "b"})
+
+	resources := state.Resources()
+	if len(resources) != 2 || resources[0].ID() != "a" || resources[1].ID() != "b" {
+		t.Fatalf("unexpected declared resources: %+v", resources)
+	}
+}
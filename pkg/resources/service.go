@@ -0,0 +1,97 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// Service is a declared systemd unit's run/boot state. It manages an
+// existing unit's activation, not the unit file itself - pair it with
+// a File resource for the unit file when the unit doesn't already
+// exist on the target.
+type Service struct {
+	Name    string
+	Running bool
+	Enabled bool
+}
+
+// ID implements Resource.
+func (s *Service) ID() string { return "service:" + s.Name }
+
+// Kind implements Resource.
+func (s *Service) Kind() string { return "service" }
+
+// Plan implements Resource.
+func (s *Service) Plan(ctx context.Context) (Action, error) {
+	if _, err := exec.LookPath("systemctl"); err != nil {
+		return Action{}, fmt.Errorf("service %s: systemctl not found on PATH", s.Name)
+	}
+
+	active := s.isActive(ctx)
+	enabled := s.isEnabled(ctx)
+
+	var changes []string
+	if active != s.Running {
+		verb := "start"
+		if !s.Running {
+			verb = "stop"
+		}
+		changes = append(changes, verb+" "+s.Name)
+	}
+	if enabled != s.Enabled {
+		verb := "enable"
+		if !s.Enabled {
+			verb = "disable"
+		}
+		changes = append(changes, verb+" "+s.Name)
+	}
+
+	if len(changes) == 0 {
+		return s.noop(), nil
+	}
+	return Action{ResourceID: s.ID(), Kind: s.Kind(), Type: ActionUpdate, Detail: strings.Join(changes, ", ")}, nil
+}
+
+// Apply implements Resource.
+func (s *Service) Apply(ctx context.Context, action Action) error {
+	if action.Type != ActionUpdate {
+		return nil
+	}
+
+	if s.isActive(ctx) != s.Running {
+		verb := "start"
+		if !s.Running {
+			verb = "stop"
+		}
+		if out, err := exec.CommandContext(ctx, "systemctl", verb, s.Name).CombinedOutput(); err != nil {
+			return fmt.Errorf("systemctl %s %s: %w: %s", verb, s.Name, err, strings.TrimSpace(string(out)))
+		}
+	}
+
+	if s.isEnabled(ctx) != s.Enabled {
+		verb := "enable"
+		if !s.Enabled {
+			verb = "disable"
+		}
+		if out, err := exec.CommandContext(ctx, "systemctl", verb, s.Name).CombinedOutput(); err != nil {
+			return fmt.Errorf("systemctl %s %s: %w: %s", verb, s.Name, err, strings.TrimSpace(string(out)))
+		}
+	}
+	return nil
+}
+
+func (s *Service) isActive(ctx context.Context) bool {
+	out, err := exec.CommandContext(ctx, "systemctl", "is-active", s.Name).CombinedOutput()
+	return err == nil && strings.TrimSpace(string(out)) == "active"
+}
+
+func (s *Service) isEnabled(ctx context.Context) bool {
+	out, err := exec.CommandContext(ctx, "systemctl", "is-enabled", s.Name).CombinedOutput()
+	return err == nil && strings.TrimSpace(string(out)) == "enabled"
+}
+
+func (s *Service) noop() Action {
+	return Action{ResourceID: s.ID(), Kind: s.Kind(), Type: ActionNoop}
+}
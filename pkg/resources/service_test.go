@@ -0,0 +1,79 @@
+package resources
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestServicePlanNoopWhenAlreadyInDesiredState(t *testing.T) {
+	t.Setenv("PATH", writeFakeBins(t, map[string]string{
+		"systemctl": `
+case "$1" in
+  is-active) echo active;;
+  is-enabled) echo enabled;;
+esac`,
+	}))
+
+	s := &Service{Name: "nginx", Running: true, Enabled: true}
+	action, err := s.Plan(context.Background())
+	if err != nil {
+		t.Fatalf("Plan returned error: %v", err)
+	}
+	if action.Type != ActionNoop {
+		t.Fatalf("expected ActionNoop, got %v: %s", action.Type, action.Detail)
+	}
+}
+
+func TestServicePlanUpdateWhenStoppedButShouldRun(t *testing.T) {
+	t.Setenv("PATH", writeFakeBins(t, map[string]string{
+		"systemctl": `
+case "$1" in
+  is-active) echo inactive; exit 3;;
+  is-enabled) echo enabled;;
+esac`,
+	}))
+
+	s := &Service{Name: "nginx", Running: true, Enabled: true}
+	action, err := s.Plan(context.Background())
+	if err != nil {
+		t.Fatalf("Plan returned error: %v", err)
+	}
+	if action.Type != ActionUpdate {
+		t.Fatalf("expected ActionUpdate, got %v", action.Type)
+	}
+	if !strings.Contains(action.Detail, "start nginx") {
+		t.Errorf("expected detail to mention starting nginx, got %q", action.Detail)
+	}
+}
+
+func TestServiceApplyStartsAndEnables(t *testing.T) {
+	logPath := filepath.Join(t.TempDir(), "systemctl.log")
+	t.Setenv("PATH", writeFakeBins(t, map[string]string{
+		"systemctl": `
+echo "$@" >> ` + logPath + `
+case "$1" in
+  is-active) exit 3;;
+  is-enabled) exit 1;;
+esac`,
+	}))
+
+	s := &Service{Name: "nginx", Running: true, Enabled: true}
+	action, err := s.Plan(context.Background())
+	if err != nil {
+		t.Fatalf("Plan returned error: %v", err)
+	}
+	if err := s.Apply(context.Background(), action); err != nil {
+		t.Fatalf("Apply returned error: %v", err)
+	}
+
+	log, err := os.ReadFile(logPath)
+	if err != nil {
+		t.Fatalf("expected systemctl invocations to be logged: %v", err)
+	}
+	if !strings.Contains(string(log), "start nginx") || !strings.Contains(string(log), "enable nginx") {
+		t.Errorf("expected systemctl start and enable nginx, got %q", log)
+	}
+}
@@ -0,0 +1,125 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// User is a declared OS user account, managed through useradd/usermod/
+// userdel. Name should be absent entirely when Absent is set;
+// otherwise present with Shell and Home, each left alone when empty.
+type User struct {
+	Name   string
+	Shell  string
+	Home   string
+	Absent bool
+}
+
+// ID implements Resource.
+func (u *User) ID() string { return "user:" + u.Name }
+
+// Kind implements Resource.
+func (u *User) Kind() string { return "user" }
+
+// Plan implements Resource.
+func (u *User) Plan(ctx context.Context) (Action, error) {
+	entry, exists, err := u.lookup(ctx)
+	if err != nil {
+		return Action{}, err
+	}
+
+	if u.Absent {
+		if !exists {
+			return u.noop(), nil
+		}
+		return Action{ResourceID: u.ID(), Kind: u.Kind(), Type: ActionDelete, Detail: "remove user " + u.Name}, nil
+	}
+
+	if !exists {
+		return Action{ResourceID: u.ID(), Kind: u.Kind(), Type: ActionCreate, Detail: "create user " + u.Name}, nil
+	}
+
+	var changes []string
+	if u.Shell != "" && entry.shell != u.Shell {
+		changes = append(changes, fmt.Sprintf("shell %s -> %s", entry.shell, u.Shell))
+	}
+	if u.Home != "" && entry.home != u.Home {
+		changes = append(changes, fmt.Sprintf("home %s -> %s", entry.home, u.Home))
+	}
+	if len(changes) == 0 {
+		return u.noop(), nil
+	}
+	return Action{ResourceID: u.ID(), Kind: u.Kind(), Type: ActionUpdate, Detail: strings.Join(changes, ", ")}, nil
+}
+
+// Apply implements Resource.
+func (u *User) Apply(ctx context.Context, action Action) error {
+	switch action.Type {
+	case ActionDelete:
+		out, err := exec.CommandContext(ctx, "userdel", "-r", u.Name).CombinedOutput()
+		if err != nil {
+			return fmt.Errorf("userdel %s: %w: %s", u.Name, err, strings.TrimSpace(string(out)))
+		}
+		return nil
+	case ActionCreate:
+		args := []string{}
+		if u.Shell != "" {
+			args = append(args, "-s", u.Shell)
+		}
+		if u.Home != "" {
+			args = append(args, "-d", u.Home)
+		}
+		args = append(args, "-m", u.Name)
+		out, err := exec.CommandContext(ctx, "useradd", args...).CombinedOutput()
+		if err != nil {
+			return fmt.Errorf("useradd %s: %w: %s", u.Name, err, strings.TrimSpace(string(out)))
+		}
+		return nil
+	case ActionUpdate:
+		args := []string{}
+		if u.Shell != "" {
+			args = append(args, "-s", u.Shell)
+		}
+		if u.Home != "" {
+			args = append(args, "-d", u.Home)
+		}
+		args = append(args, u.Name)
+		out, err := exec.CommandContext(ctx, "usermod", args...).CombinedOutput()
+		if err != nil {
+			return fmt.Errorf("usermod %s: %w: %s", u.Name, err, strings.TrimSpace(string(out)))
+		}
+		return nil
+	default:
+		return nil
+	}
+}
+
+type passwdEntry struct {
+	shell string
+	home  string
+}
+
+// lookup reads Name's passwd entry via getent. A user that doesn't
+// exist is reported as exists=false, not an error.
+func (u *User) lookup(ctx context.Context) (entry passwdEntry, exists bool, err error) {
+	if _, lookErr := exec.LookPath("getent"); lookErr != nil {
+		return passwdEntry{}, false, fmt.Errorf("user %s: getent not found on PATH", u.Name)
+	}
+
+	out, runErr := exec.CommandContext(ctx, "getent", "passwd", u.Name).CombinedOutput()
+	if runErr != nil {
+		return passwdEntry{}, false, nil
+	}
+
+	fields := strings.Split(strings.TrimSpace(string(out)), ":")
+	if len(fields) < 7 {
+		return passwdEntry{}, false, nil
+	}
+	return passwdEntry{home: fields[5], shell: fields[6]}, true, nil
+}
+
+func (u *User) noop() Action {
+	return Action{ResourceID: u.ID(), Kind: u.Kind(), Type: ActionNoop}
+}
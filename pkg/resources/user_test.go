@@ -0,0 +1,101 @@
+package resources
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestUserPlanCreateWhenMissing(t *testing.T) {
+	t.Setenv("PATH", writeFakeBin(t, "getent", `exit 2`))
+
+	u := &User{Name: "deploy"}
+	action, err := u.Plan(context.Background())
+	if err != nil {
+		t.Fatalf("Plan returned error: %v", err)
+	}
+	if action.Type != ActionCreate {
+		t.Fatalf("expected ActionCreate, got %v", action.Type)
+	}
+}
+
+func TestUserPlanNoopWhenEntryMatches(t *testing.T) {
+	t.Setenv("PATH", writeFakeBin(t, "getent", `echo 'deploy:x:1001:1001::/home/deploy:/bin/bash'`))
+
+	u := &User{Name: "deploy", Shell: "/bin/bash", Home: "/home/deploy"}
+	action, err := u.Plan(context.Background())
+	if err != nil {
+		t.Fatalf("Plan returned error: %v", err)
+	}
+	if action.Type != ActionNoop {
+		t.Fatalf("expected ActionNoop, got %v", action.Type)
+	}
+}
+
+func TestUserPlanUpdateWhenShellDiffers(t *testing.T) {
+	t.Setenv("PATH", writeFakeBin(t, "getent", `echo 'deploy:x:1001:1001::/home/deploy:/bin/sh'`))
+
+	u := &User{Name: "deploy", Shell: "/bin/bash", Home: "/home/deploy"}
+	action, err := u.Plan(context.Background())
+	if err != nil {
+		t.Fatalf("Plan returned error: %v", err)
+	}
+	if action.Type != ActionUpdate {
+		t.Fatalf("expected ActionUpdate, got %v", action.Type)
+	}
+	if !strings.Contains(action.Detail, "/bin/sh -> /bin/bash") {
+		t.Errorf("expected detail to mention the shell change, got %q", action.Detail)
+	}
+}
+
+func TestUserApplyCreateInvokesUseradd(t *testing.T) {
+	logPath := filepath.Join(t.TempDir(), "useradd.log")
+	t.Setenv("PATH", writeFakeBins(t, map[string]string{
+		"getent":  `exit 2`,
+		"useradd": `echo "$@" >> ` + logPath,
+	}))
+
+	u := &User{Name: "deploy", Shell: "/bin/bash", Home: "/home/deploy"}
+	action, err := u.Plan(context.Background())
+	if err != nil {
+		t.Fatalf("Plan returned error: %v", err)
+	}
+	if err := u.Apply(context.Background(), action); err != nil {
+		t.Fatalf("Apply returned error: %v", err)
+	}
+
+	log, err := os.ReadFile(logPath)
+	if err != nil {
+		t.Fatalf("expected useradd to have been invoked: %v", err)
+	}
+	if !strings.Contains(string(log), "-s /bin/bash") || !strings.Contains(string(log), "-d /home/deploy") {
+		t.Errorf("expected useradd with shell and home flags, got %q", log)
+	}
+}
+
+func TestUserApplyDeleteInvokesUserdel(t *testing.T) {
+	logPath := filepath.Join(t.TempDir(), "userdel.log")
+	t.Setenv("PATH", writeFakeBins(t, map[string]string{
+		"getent":  `echo 'deploy:x:1001:1001::/home/deploy:/bin/bash'`,
+		"userdel": `echo "$@" >> ` + logPath,
+	}))
+
+	u := &User{Name: "deploy", Absent: true}
+	action, err := u.Plan(context.Background())
+	if err != nil {
+		t.Fatalf("Plan returned error: %v", err)
+	}
+	if err := u.Apply(context.Background(), action); err != nil {
+		t.Fatalf("Apply returned error: %v", err)
+	}
+
+	log, err := os.ReadFile(logPath)
+	if err != nil {
+		t.Fatalf("expected userdel to have been invoked: %v", err)
+	}
+	if !strings.Contains(string(log), "-r deploy") {
+		t.Errorf("expected userdel -r deploy, got %q", log)
+	}
+}
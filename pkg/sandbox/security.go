@@ -5,6 +5,7 @@ import (
 	"regexp"
 	"strings"
 
+	"gitee.com/com_818cloud/shode/pkg/events"
 	"gitee.com/com_818cloud/shode/pkg/types"
 )
 
@@ -13,6 +14,14 @@ type SecurityChecker struct {
 	dangerousCommands map[string]bool
 	fileBlacklist     map[string]bool
 	networkBlacklist  map[string]bool
+	dockerAllow       map[string]bool // nil means every docker operation is permitted; set via AllowDockerOperations to restrict to a named allowlist
+	kubernetesAllow   map[string]bool // nil means every kubernetes operation is permitted; set via AllowKubernetesOperations to restrict to a named allowlist
+	gitAllow          map[string]bool // nil means every git operation is permitted; set via AllowGitOperations to restrict to a named allowlist
+	objectAllow       map[string]bool // nil means every object storage operation is permitted; set via AllowObjectOperations to restrict to a named allowlist
+	sshAllow          map[string]bool // nil means every SSH operation is permitted; set via AllowSSHOperations to restrict to a named allowlist
+	powershellAllow   map[string]bool // nil means every PowerShell operation is permitted; set via AllowPowerShellOperations to restrict to a named allowlist
+	resourceAllow     map[string]bool // nil means every resource operation is permitted; set via AllowResourceOperations to restrict to a named allowlist
+	events            *events.Bus     // Publishes SecurityDenied; nil Bus is a safe no-op, so this stays unset until SetEventBus attaches one
 }
 
 // NewSecurityChecker creates a new security checker with default rules
@@ -31,6 +40,210 @@ func NewSecurityChecker() *SecurityChecker {
 	return sc
 }
 
+// SetEventBus attaches the bus CheckCommand publishes a SecurityDenied
+// event to whenever it refuses a command. Leaving it unset keeps
+// publishing a no-op.
+func (sc *SecurityChecker) SetEventBus(bus *events.Bus) {
+	sc.events = bus
+}
+
+// AllowDockerOperations restricts CheckDockerOperation to ops (e.g.
+// "build", "run", "push", "ps"). Calling it at all switches Docker
+// builtins from permit-all to deny-by-default, so a script's
+// shode.toml must list every operation it needs.
+func (sc *SecurityChecker) AllowDockerOperations(ops []string) {
+	sc.dockerAllow = make(map[string]bool, len(ops))
+	for _, op := range ops {
+		sc.dockerAllow[strings.ToLower(op)] = true
+	}
+}
+
+// CheckDockerOperation validates that op (e.g. "build", "run", "push",
+// "ps") is permitted, publishing a SecurityDenied event when it
+// isn't. Until AllowDockerOperations is called every operation is
+// permitted, so Docker builtins stay usable without extra
+// configuration.
+func (sc *SecurityChecker) CheckDockerOperation(op string) error {
+	if sc.dockerAllow == nil || sc.dockerAllow[strings.ToLower(op)] {
+		return nil
+	}
+	err := fmt.Errorf("security violation: docker operation %q is not allowed", op)
+	sc.events.Publish(events.SecurityDenied, map[string]interface{}{
+		"command": "docker:" + op,
+		"reason":  err.Error(),
+	})
+	return err
+}
+
+// AllowKubernetesOperations restricts CheckKubernetesOperation to ops
+// (e.g. "apply", "get", "logs", "waitfor"). Calling it at all switches
+// Kubernetes builtins from permit-all to deny-by-default, so a script's
+// shode.toml must list every operation it needs.
+func (sc *SecurityChecker) AllowKubernetesOperations(ops []string) {
+	sc.kubernetesAllow = make(map[string]bool, len(ops))
+	for _, op := range ops {
+		sc.kubernetesAllow[strings.ToLower(op)] = true
+	}
+}
+
+// CheckKubernetesOperation validates that op (e.g. "apply", "get",
+// "logs", "waitfor") is permitted, publishing a SecurityDenied event
+// when it isn't. Until AllowKubernetesOperations is called every
+// operation is permitted, so Kubernetes builtins stay usable without
+// extra configuration.
+func (sc *SecurityChecker) CheckKubernetesOperation(op string) error {
+	if sc.kubernetesAllow == nil || sc.kubernetesAllow[strings.ToLower(op)] {
+		return nil
+	}
+	err := fmt.Errorf("security violation: kubernetes operation %q is not allowed", op)
+	sc.events.Publish(events.SecurityDenied, map[string]interface{}{
+		"command": "kubernetes:" + op,
+		"reason":  err.Error(),
+	})
+	return err
+}
+
+// AllowGitOperations restricts CheckGitOperation to ops (e.g. "clone",
+// "checkout", "status", "tag", "push"). Calling it at all switches the
+// Git builtins from permit-all to deny-by-default, so a script's
+// shode.toml must list every operation it needs.
+func (sc *SecurityChecker) AllowGitOperations(ops []string) {
+	sc.gitAllow = make(map[string]bool, len(ops))
+	for _, op := range ops {
+		sc.gitAllow[strings.ToLower(op)] = true
+	}
+}
+
+// CheckGitOperation validates that op (e.g. "clone", "checkout",
+// "status", "tag", "push") is permitted, publishing a SecurityDenied
+// event when it isn't. Until AllowGitOperations is called every
+// operation is permitted, so Git builtins stay usable without extra
+// configuration.
+func (sc *SecurityChecker) CheckGitOperation(op string) error {
+	if sc.gitAllow == nil || sc.gitAllow[strings.ToLower(op)] {
+		return nil
+	}
+	err := fmt.Errorf("security violation: git operation %q is not allowed", op)
+	sc.events.Publish(events.SecurityDenied, map[string]interface{}{
+		"command": "git:" + op,
+		"reason":  err.Error(),
+	})
+	return err
+}
+
+// AllowObjectOperations restricts CheckObjectOperation to ops (e.g.
+// "put", "get", "list", "presign"). Calling it at all switches the
+// object storage builtins from permit-all to deny-by-default, so a
+// script's shode.toml must list every operation it needs.
+func (sc *SecurityChecker) AllowObjectOperations(ops []string) {
+	sc.objectAllow = make(map[string]bool, len(ops))
+	for _, op := range ops {
+		sc.objectAllow[strings.ToLower(op)] = true
+	}
+}
+
+// CheckObjectOperation validates that op (e.g. "put", "get", "list",
+// "presign") is permitted, publishing a SecurityDenied event when it
+// isn't. Until AllowObjectOperations is called every operation is
+// permitted, so the object storage builtins stay usable without extra
+// configuration.
+func (sc *SecurityChecker) CheckObjectOperation(op string) error {
+	if sc.objectAllow == nil || sc.objectAllow[strings.ToLower(op)] {
+		return nil
+	}
+	err := fmt.Errorf("security violation: object storage operation %q is not allowed", op)
+	sc.events.Publish(events.SecurityDenied, map[string]interface{}{
+		"command": "object:" + op,
+		"reason":  err.Error(),
+	})
+	return err
+}
+
+// AllowSSHOperations restricts CheckSSHOperation to ops (e.g. "run").
+// Calling it at all switches the SSH builtins from permit-all to
+// deny-by-default, so a script's shode.toml must list every operation
+// it needs.
+func (sc *SecurityChecker) AllowSSHOperations(ops []string) {
+	sc.sshAllow = make(map[string]bool, len(ops))
+	for _, op := range ops {
+		sc.sshAllow[strings.ToLower(op)] = true
+	}
+}
+
+// CheckSSHOperation validates that op (e.g. "run") is permitted,
+// publishing a SecurityDenied event when it isn't. Until
+// AllowSSHOperations is called every operation is permitted, so the
+// SSH builtins stay usable without extra configuration.
+func (sc *SecurityChecker) CheckSSHOperation(op string) error {
+	if sc.sshAllow == nil || sc.sshAllow[strings.ToLower(op)] {
+		return nil
+	}
+	err := fmt.Errorf("security violation: ssh operation %q is not allowed", op)
+	sc.events.Publish(events.SecurityDenied, map[string]interface{}{
+		"command": "ssh:" + op,
+		"reason":  err.Error(),
+	})
+	return err
+}
+
+// AllowPowerShellOperations restricts CheckPowerShellOperation to ops
+// (e.g. "invoke"). Calling it at all switches the PowerShell builtins
+// from permit-all to deny-by-default, so a script's shode.toml must
+// list every operation it needs.
+func (sc *SecurityChecker) AllowPowerShellOperations(ops []string) {
+	sc.powershellAllow = make(map[string]bool, len(ops))
+	for _, op := range ops {
+		sc.powershellAllow[strings.ToLower(op)] = true
+	}
+}
+
+// CheckPowerShellOperation validates that op (e.g. "invoke") is
+// permitted, publishing a SecurityDenied event when it isn't. Until
+// AllowPowerShellOperations is called every operation is permitted, so
+// the PowerShell builtins stay usable without extra configuration.
+func (sc *SecurityChecker) CheckPowerShellOperation(op string) error {
+	if sc.powershellAllow == nil || sc.powershellAllow[strings.ToLower(op)] {
+		return nil
+	}
+	err := fmt.Errorf("security violation: powershell operation %q is not allowed", op)
+	sc.events.Publish(events.SecurityDenied, map[string]interface{}{
+		"command": "powershell:" + op,
+		"reason":  err.Error(),
+	})
+	return err
+}
+
+// AllowResourceOperations restricts CheckResourceOperation to ops
+// (the pkg/resources kinds: "file", "package", "service", "user").
+// Calling it at all switches the resource builtins from permit-all to
+// deny-by-default, so a script's shode.toml must list every kind it
+// needs - in particular, a script that isn't allowed to run "user"
+// can't reach useradd/userdel/usermod via ResourceUser+ResourceApply
+// any more than it could by shelling out to them directly.
+func (sc *SecurityChecker) AllowResourceOperations(ops []string) {
+	sc.resourceAllow = make(map[string]bool, len(ops))
+	for _, op := range ops {
+		sc.resourceAllow[strings.ToLower(op)] = true
+	}
+}
+
+// CheckResourceOperation validates that op (a pkg/resources kind, e.g.
+// "user") is permitted, publishing a SecurityDenied event when it
+// isn't. Until AllowResourceOperations is called every kind is
+// permitted, so the resource builtins stay usable without extra
+// configuration.
+func (sc *SecurityChecker) CheckResourceOperation(op string) error {
+	if sc.resourceAllow == nil || sc.resourceAllow[strings.ToLower(op)] {
+		return nil
+	}
+	err := fmt.Errorf("security violation: resource operation %q is not allowed", op)
+	sc.events.Publish(events.SecurityDenied, map[string]interface{}{
+		"command": "resource:" + op,
+		"reason":  err.Error(),
+	})
+	return err
+}
+
 // initializeDangerousCommands sets up the default dangerous command blacklist
 func (sc *SecurityChecker) initializeDangerousCommands() {
 	dangerous := []string{
@@ -93,8 +306,23 @@ func (sc *SecurityChecker) initializeNetworkBlacklist() {
 	}
 }
 
-// CheckCommand validates a command for security risks
+// CheckCommand validates a command for security risks, publishing a
+// SecurityDenied event for every command it refuses.
 func (sc *SecurityChecker) CheckCommand(cmd *types.CommandNode) error {
+	err := sc.checkCommand(cmd)
+	if err != nil {
+		sc.events.Publish(events.SecurityDenied, map[string]interface{}{
+			"command": cmd.Name,
+			"reason":  err.Error(),
+		})
+	}
+	return err
+}
+
+// checkCommand holds the actual validation rules; CheckCommand wraps it
+// to publish a SecurityDenied event from a single place regardless of
+// which rule rejected the command.
+func (sc *SecurityChecker) checkCommand(cmd *types.CommandNode) error {
 	commandName := strings.ToLower(cmd.Name)
 
 	// Check for dangerous commands
@@ -160,10 +388,15 @@ func (sc *SecurityChecker) checkPatterns(cmd *types.CommandNode) error {
 	shellInjection := regexp.MustCompile(`[;&|` + "`" + `$()]`)
 	// Exclude database functions and shode from shell injection check
 	excludedCommands := map[string]bool{
-		"shode":      true,
-		"QueryDB":    true,
-		"QueryRowDB": true,
-		"ExecDB":     true,
+		"shode":          true,
+		"QueryDB":        true,
+		"QueryRowDB":     true,
+		"ExecDB":         true,
+		"ExecBatchDB":    true,
+		"ConnectMongo":   true,
+		"FindMongo":      true,
+		"InsertMongo":    true,
+		"AggregateMongo": true,
 	}
 	if shellInjection.MatchString(fullCommand) && !excludedCommands[cmd.Name] {
 		return fmt.Errorf("security violation: potential shell injection detected")
@@ -0,0 +1,253 @@
+package sandbox
+
+import "testing"
+
+// TestCheckDockerOperation tests that the Docker builtins' gate
+// actually denies an operation left off the allowlist.
+func TestCheckDockerOperation(t *testing.T) {
+	t.Run("permits every operation by default", func(t *testing.T) {
+		sc := NewSecurityChecker()
+		if err := sc.CheckDockerOperation("push"); err != nil {
+			t.Errorf("expected no error before AllowDockerOperations is called, got %v", err)
+		}
+	})
+
+	t.Run("permits a listed operation", func(t *testing.T) {
+		sc := NewSecurityChecker()
+		sc.AllowDockerOperations([]string{"build", "run"})
+		if err := sc.CheckDockerOperation("build"); err != nil {
+			t.Errorf("expected \"build\" to be permitted, got %v", err)
+		}
+	})
+
+	t.Run("denies an operation left off the allowlist", func(t *testing.T) {
+		sc := NewSecurityChecker()
+		sc.AllowDockerOperations([]string{"build", "run"})
+		if err := sc.CheckDockerOperation("push"); err == nil {
+			t.Error("expected \"push\" to be denied once docker_allow is set without it")
+		}
+	})
+
+	t.Run("is case-insensitive", func(t *testing.T) {
+		sc := NewSecurityChecker()
+		sc.AllowDockerOperations([]string{"Build"})
+		if err := sc.CheckDockerOperation("BUILD"); err != nil {
+			t.Errorf("expected case-insensitive match to permit, got %v", err)
+		}
+	})
+}
+
+// TestCheckKubernetesOperation tests that the Kubernetes builtins' gate
+// actually denies an operation left off the allowlist.
+func TestCheckKubernetesOperation(t *testing.T) {
+	t.Run("permits every operation by default", func(t *testing.T) {
+		sc := NewSecurityChecker()
+		if err := sc.CheckKubernetesOperation("apply"); err != nil {
+			t.Errorf("expected no error before AllowKubernetesOperations is called, got %v", err)
+		}
+	})
+
+	t.Run("permits a listed operation", func(t *testing.T) {
+		sc := NewSecurityChecker()
+		sc.AllowKubernetesOperations([]string{"get", "logs"})
+		if err := sc.CheckKubernetesOperation("get"); err != nil {
+			t.Errorf("expected \"get\" to be permitted, got %v", err)
+		}
+	})
+
+	t.Run("denies an operation left off the allowlist", func(t *testing.T) {
+		sc := NewSecurityChecker()
+		sc.AllowKubernetesOperations([]string{"get", "logs"})
+		if err := sc.CheckKubernetesOperation("apply"); err == nil {
+			t.Error("expected \"apply\" to be denied once kubernetes_allow is set without it")
+		}
+	})
+
+	t.Run("is case-insensitive", func(t *testing.T) {
+		sc := NewSecurityChecker()
+		sc.AllowKubernetesOperations([]string{"Apply"})
+		if err := sc.CheckKubernetesOperation("APPLY"); err != nil {
+			t.Errorf("expected case-insensitive match to permit, got %v", err)
+		}
+	})
+}
+
+// TestCheckGitOperation tests that the Git builtins' gate actually
+// denies an operation left off the allowlist.
+func TestCheckGitOperation(t *testing.T) {
+	t.Run("permits every operation by default", func(t *testing.T) {
+		sc := NewSecurityChecker()
+		if err := sc.CheckGitOperation("push"); err != nil {
+			t.Errorf("expected no error before AllowGitOperations is called, got %v", err)
+		}
+	})
+
+	t.Run("permits a listed operation", func(t *testing.T) {
+		sc := NewSecurityChecker()
+		sc.AllowGitOperations([]string{"clone", "status"})
+		if err := sc.CheckGitOperation("clone"); err != nil {
+			t.Errorf("expected \"clone\" to be permitted, got %v", err)
+		}
+	})
+
+	t.Run("denies an operation left off the allowlist", func(t *testing.T) {
+		sc := NewSecurityChecker()
+		sc.AllowGitOperations([]string{"clone", "status"})
+		if err := sc.CheckGitOperation("push"); err == nil {
+			t.Error("expected \"push\" to be denied once git_allow is set without it")
+		}
+	})
+
+	t.Run("is case-insensitive", func(t *testing.T) {
+		sc := NewSecurityChecker()
+		sc.AllowGitOperations([]string{"Push"})
+		if err := sc.CheckGitOperation("PUSH"); err != nil {
+			t.Errorf("expected case-insensitive match to permit, got %v", err)
+		}
+	})
+}
+
+// TestCheckObjectOperation tests that the object storage builtins'
+// gate actually denies an operation left off the allowlist.
+func TestCheckObjectOperation(t *testing.T) {
+	t.Run("permits every operation by default", func(t *testing.T) {
+		sc := NewSecurityChecker()
+		if err := sc.CheckObjectOperation("put"); err != nil {
+			t.Errorf("expected no error before AllowObjectOperations is called, got %v", err)
+		}
+	})
+
+	t.Run("permits a listed operation", func(t *testing.T) {
+		sc := NewSecurityChecker()
+		sc.AllowObjectOperations([]string{"get", "list"})
+		if err := sc.CheckObjectOperation("get"); err != nil {
+			t.Errorf("expected \"get\" to be permitted, got %v", err)
+		}
+	})
+
+	t.Run("denies an operation left off the allowlist", func(t *testing.T) {
+		sc := NewSecurityChecker()
+		sc.AllowObjectOperations([]string{"get", "list"})
+		if err := sc.CheckObjectOperation("put"); err == nil {
+			t.Error("expected \"put\" to be denied once object_allow is set without it")
+		}
+	})
+
+	t.Run("is case-insensitive", func(t *testing.T) {
+		sc := NewSecurityChecker()
+		sc.AllowObjectOperations([]string{"Put"})
+		if err := sc.CheckObjectOperation("PUT"); err != nil {
+			t.Errorf("expected case-insensitive match to permit, got %v", err)
+		}
+	})
+}
+
+// TestCheckSSHOperation tests that the SSH builtins' gate actually
+// denies an operation left off the allowlist.
+func TestCheckSSHOperation(t *testing.T) {
+	t.Run("permits every operation by default", func(t *testing.T) {
+		sc := NewSecurityChecker()
+		if err := sc.CheckSSHOperation("run"); err != nil {
+			t.Errorf("expected no error before AllowSSHOperations is called, got %v", err)
+		}
+	})
+
+	t.Run("permits a listed operation", func(t *testing.T) {
+		sc := NewSecurityChecker()
+		sc.AllowSSHOperations([]string{"run"})
+		if err := sc.CheckSSHOperation("run"); err != nil {
+			t.Errorf("expected \"run\" to be permitted, got %v", err)
+		}
+	})
+
+	t.Run("denies an operation left off the allowlist", func(t *testing.T) {
+		sc := NewSecurityChecker()
+		sc.AllowSSHOperations([]string{"run"})
+		if err := sc.CheckSSHOperation("tunnel"); err == nil {
+			t.Error("expected \"tunnel\" to be denied once ssh_allow is set without it")
+		}
+	})
+
+	t.Run("is case-insensitive", func(t *testing.T) {
+		sc := NewSecurityChecker()
+		sc.AllowSSHOperations([]string{"Run"})
+		if err := sc.CheckSSHOperation("RUN"); err != nil {
+			t.Errorf("expected case-insensitive match to permit, got %v", err)
+		}
+	})
+}
+
+// TestCheckPowerShellOperation tests that the PowerShell builtins' gate
+// actually denies an operation left off the allowlist.
+func TestCheckPowerShellOperation(t *testing.T) {
+	t.Run("permits every operation by default", func(t *testing.T) {
+		sc := NewSecurityChecker()
+		if err := sc.CheckPowerShellOperation("invoke"); err != nil {
+			t.Errorf("expected no error before AllowPowerShellOperations is called, got %v", err)
+		}
+	})
+
+	t.Run("permits a listed operation", func(t *testing.T) {
+		sc := NewSecurityChecker()
+		sc.AllowPowerShellOperations([]string{"invoke"})
+		if err := sc.CheckPowerShellOperation("invoke"); err != nil {
+			t.Errorf("expected \"invoke\" to be permitted, got %v", err)
+		}
+	})
+
+	t.Run("denies an operation left off the allowlist", func(t *testing.T) {
+		sc := NewSecurityChecker()
+		sc.AllowPowerShellOperations([]string{"other"})
+		if err := sc.CheckPowerShellOperation("invoke"); err == nil {
+			t.Error("expected \"invoke\" to be denied once powershell_allow is set without it")
+		}
+	})
+
+	t.Run("is case-insensitive", func(t *testing.T) {
+		sc := NewSecurityChecker()
+		sc.AllowPowerShellOperations([]string{"Invoke"})
+		if err := sc.CheckPowerShellOperation("INVOKE"); err != nil {
+			t.Errorf("expected case-insensitive match to permit, got %v", err)
+		}
+	})
+}
+
+// TestCheckResourceOperation tests that ResourceUser+ResourceApply's
+// gate (and the other pkg/resources kinds alongside it) actually
+// denies an operation left off the allowlist - this is what closes the
+// useradd/userdel/usermod sandbox-bypass hole: a script limited to
+// resource_allow = ["file"] must not be able to declare a "user"
+// resource just because CheckCommand doesn't know ResourceUser shells
+// out to useradd.
+func TestCheckResourceOperation(t *testing.T) {
+	t.Run("permits every kind by default", func(t *testing.T) {
+		sc := NewSecurityChecker()
+		if err := sc.CheckResourceOperation("user"); err != nil {
+			t.Errorf("expected no error before AllowResourceOperations is called, got %v", err)
+		}
+	})
+
+	t.Run("permits a listed kind", func(t *testing.T) {
+		sc := NewSecurityChecker()
+		sc.AllowResourceOperations([]string{"file", "user"})
+		if err := sc.CheckResourceOperation("user"); err != nil {
+			t.Errorf("expected \"user\" to be permitted, got %v", err)
+		}
+	})
+
+	t.Run("denies a kind left off the allowlist", func(t *testing.T) {
+		sc := NewSecurityChecker()
+		sc.AllowResourceOperations([]string{"file"})
+		if err := sc.CheckResourceOperation("user"); err == nil {
+			t.Error("expected \"user\" to be denied once resource_allow is set without it")
+		}
+	})
+
+	t.Run("is case-insensitive", func(t *testing.T) {
+		sc := NewSecurityChecker()
+		sc.AllowResourceOperations([]string{"User"})
+		if err := sc.CheckResourceOperation("USER"); err != nil {
+			t.Errorf("expected case-insensitive match to permit, got %v", err)
+		}
+	})
+}
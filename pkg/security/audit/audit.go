@@ -8,6 +8,8 @@ import (
 	"os"
 	"sync"
 	"time"
+
+	"gitee.com/com_818cloud/shode/pkg/events"
 )
 
 // Level 审计级别
@@ -40,6 +42,14 @@ type Config struct {
 	Output   []string
 	Format   string
 	MinLevel Level
+
+	// BufferSize, FlushInterval, and MaxRetries tune the near-real-time
+	// SIEM outputs ("splunk-hec://", "elastic://", "syslog+tls://" in
+	// Output); zero uses the defaults in siem.go. Plain stdout/stderr/
+	// file outputs ignore them, since those write synchronously.
+	BufferSize    int
+	FlushInterval time.Duration
+	MaxRetries    int
 }
 
 // Auditor 审计日志器
@@ -77,6 +87,10 @@ func New(config *Config) *Auditor {
 		case "stderr":
 			a.outputs = append(a.outputs, &StderrOutput{})
 		default:
+			if siem, ok := parseSIEMOutput(output, config); ok {
+				a.outputs = append(a.outputs, siem)
+				continue
+			}
 			file, err := NewFileOutput(output)
 			if err == nil {
 				a.outputs = append(a.outputs, file)
@@ -105,6 +119,45 @@ func (a *Auditor) Log(event Event) error {
 	return nil
 }
 
+// SubscribeEvents 订阅事件总线的命令与安全事件，将其转换为审计事件写入，
+// 取代各功能模块自行调用 Log 的方式。返回的函数用于取消订阅。
+func (a *Auditor) SubscribeEvents(bus *events.Bus) func() {
+	watched := []events.Type{
+		events.CommandStarted,
+		events.CommandFinished,
+		events.SecurityDenied,
+	}
+
+	unsubscribes := make([]func(), 0, len(watched))
+	for _, t := range watched {
+		ch, unsubscribe := bus.Subscribe(t)
+		unsubscribes = append(unsubscribes, unsubscribe)
+		go func(t events.Type, ch <-chan events.Event) {
+			for event := range ch {
+				a.Log(busEventToAuditEvent(t, event))
+			}
+		}(t, ch)
+	}
+
+	return func() {
+		for _, unsubscribe := range unsubscribes {
+			unsubscribe()
+		}
+	}
+}
+
+// busEventToAuditEvent converts a pkg/events.Event into the audit
+// Event shape, carrying its Data straight through as Metadata.
+func busEventToAuditEvent(t events.Type, event events.Event) Event {
+	return Event{
+		Type:      string(t),
+		Action:    string(t),
+		Success:   t != events.SecurityDenied,
+		Timestamp: event.Time,
+		Metadata:  event.Data,
+	}
+}
+
 // Close 关闭审计日志器
 func (a *Auditor) Close() error {
 	for _, output := range a.outputs {
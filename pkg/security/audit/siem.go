@@ -0,0 +1,316 @@
+package audit
+
+import (
+	"bytes"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+// siemDefaults are applied to any SIEM output whose Config left the
+// corresponding buffering field zero.
+const (
+	siemDefaultBufferSize    = 100
+	siemDefaultFlushInterval = 5 * time.Second
+	siemDefaultMaxRetries    = 3
+)
+
+// flushFunc sends a batch of events to a SIEM backend, returning an
+// error if the whole batch should be retried.
+type flushFunc func(batch []Event) error
+
+// bufferedOutput is the shared near-real-time delivery loop behind
+// SplunkHECOutput, ElasticOutput, and SyslogTLSOutput: events are
+// queued and shipped in batches, either when bufferSize is reached or
+// flushInterval elapses, whichever comes first. A batch that fails to
+// send is retried with exponential backoff up to maxRetries times,
+// then dropped with a warning on stderr rather than blocking the
+// audit logger or growing without bound.
+type bufferedOutput struct {
+	queue chan Event
+	stop  chan struct{}
+	done  chan struct{}
+
+	bufferSize    int
+	flushInterval time.Duration
+	maxRetries    int
+	send          flushFunc
+	name          string
+}
+
+func newBufferedOutput(name string, bufferSize int, flushInterval time.Duration, maxRetries int, send flushFunc) *bufferedOutput {
+	if bufferSize <= 0 {
+		bufferSize = siemDefaultBufferSize
+	}
+	if flushInterval <= 0 {
+		flushInterval = siemDefaultFlushInterval
+	}
+	if maxRetries <= 0 {
+		maxRetries = siemDefaultMaxRetries
+	}
+
+	b := &bufferedOutput{
+		queue:         make(chan Event, bufferSize*4),
+		stop:          make(chan struct{}),
+		done:          make(chan struct{}),
+		bufferSize:    bufferSize,
+		flushInterval: flushInterval,
+		maxRetries:    maxRetries,
+		send:          send,
+		name:          name,
+	}
+	go b.run()
+	return b
+}
+
+func (b *bufferedOutput) Write(event Event) error {
+	select {
+	case b.queue <- event:
+	default:
+		fmt.Fprintf(os.Stderr, "audit: %s queue full, dropping event %s\n", b.name, event.ID)
+	}
+	return nil
+}
+
+func (b *bufferedOutput) Close() error {
+	close(b.stop)
+	<-b.done
+	return nil
+}
+
+func (b *bufferedOutput) run() {
+	defer close(b.done)
+
+	ticker := time.NewTicker(b.flushInterval)
+	defer ticker.Stop()
+
+	batch := make([]Event, 0, b.bufferSize)
+	for {
+		select {
+		case event := <-b.queue:
+			batch = append(batch, event)
+			if len(batch) >= b.bufferSize {
+				b.flushWithRetry(batch)
+				batch = batch[:0]
+			}
+		case <-ticker.C:
+			if len(batch) > 0 {
+				b.flushWithRetry(batch)
+				batch = batch[:0]
+			}
+		case <-b.stop:
+			if len(batch) > 0 {
+				b.flushWithRetry(batch)
+			}
+			return
+		}
+	}
+}
+
+// flushWithRetry sends batch, retrying with exponential backoff
+// (starting at 500ms) until maxRetries is exhausted, at which point
+// the batch is dropped and logged rather than held onto indefinitely.
+func (b *bufferedOutput) flushWithRetry(batch []Event) {
+	delay := 500 * time.Millisecond
+	for attempt := 0; ; attempt++ {
+		if err := b.send(batch); err == nil {
+			return
+		} else if attempt >= b.maxRetries {
+			fmt.Fprintf(os.Stderr, "audit: %s dropping %d event(s) after %d retries: %v\n", b.name, len(batch), b.maxRetries, err)
+			return
+		}
+		time.Sleep(delay)
+		delay *= 2
+	}
+}
+
+// SplunkHECOutput ships audit events to Splunk's HTTP Event Collector.
+// Splunk accepts a POST body of multiple concatenated (not
+// array-wrapped) JSON event envelopes, so a whole batch goes out in
+// one request.
+type SplunkHECOutput struct {
+	*bufferedOutput
+}
+
+// NewSplunkHECOutput creates a SplunkHECOutput that posts to
+// endpoint (a Splunk HEC "/services/collector/event" URL) using
+// token for the "Splunk <token>" Authorization header, tagging every
+// event with sourcetype. bufferSize, flushInterval, and maxRetries
+// follow the defaults in siemDefaults when zero.
+func NewSplunkHECOutput(endpoint, token, sourcetype string, bufferSize int, flushInterval time.Duration, maxRetries int) *SplunkHECOutput {
+	client := &http.Client{Timeout: 10 * time.Second}
+	send := func(batch []Event) error {
+		var body bytes.Buffer
+		for _, event := range batch {
+			envelope := map[string]interface{}{
+				"time":       event.Timestamp.Unix(),
+				"sourcetype": sourcetype,
+				"event":      event,
+			}
+			data, err := json.Marshal(envelope)
+			if err != nil {
+				return fmt.Errorf("audit: marshaling splunk event: %w", err)
+			}
+			body.Write(data)
+		}
+
+		req, err := http.NewRequest(http.MethodPost, endpoint, bytes.NewReader(body.Bytes()))
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Authorization", "Splunk "+token)
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := client.Do(req)
+		if err != nil {
+			return fmt.Errorf("audit: splunk hec request failed: %w", err)
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode >= 300 {
+			return fmt.Errorf("audit: splunk hec returned status %d", resp.StatusCode)
+		}
+		return nil
+	}
+
+	return &SplunkHECOutput{bufferedOutput: newBufferedOutput("splunk-hec", bufferSize, flushInterval, maxRetries, send)}
+}
+
+// ElasticOutput ships audit events to an Elasticsearch (or
+// OpenSearch) cluster's Bulk API, one index action per event.
+type ElasticOutput struct {
+	*bufferedOutput
+}
+
+// NewElasticOutput creates an ElasticOutput that bulk-indexes into
+// index on the cluster at endpoint, authenticating with apiKey via
+// the "ApiKey <apiKey>" Authorization header.
+func NewElasticOutput(endpoint, apiKey, index string, bufferSize int, flushInterval time.Duration, maxRetries int) *ElasticOutput {
+	client := &http.Client{Timeout: 10 * time.Second}
+	bulkURL := strings.TrimRight(endpoint, "/") + "/_bulk"
+
+	send := func(batch []Event) error {
+		var body bytes.Buffer
+		for _, event := range batch {
+			action, err := json.Marshal(map[string]interface{}{
+				"index": map[string]string{"_index": index},
+			})
+			if err != nil {
+				return fmt.Errorf("audit: marshaling elastic action: %w", err)
+			}
+			doc, err := json.Marshal(event)
+			if err != nil {
+				return fmt.Errorf("audit: marshaling elastic document: %w", err)
+			}
+			body.Write(action)
+			body.WriteByte('\n')
+			body.Write(doc)
+			body.WriteByte('\n')
+		}
+
+		req, err := http.NewRequest(http.MethodPost, bulkURL, bytes.NewReader(body.Bytes()))
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Authorization", "ApiKey "+apiKey)
+		req.Header.Set("Content-Type", "application/x-ndjson")
+
+		resp, err := client.Do(req)
+		if err != nil {
+			return fmt.Errorf("audit: elasticsearch bulk request failed: %w", err)
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode >= 300 {
+			return fmt.Errorf("audit: elasticsearch bulk returned status %d", resp.StatusCode)
+		}
+		return nil
+	}
+
+	return &ElasticOutput{bufferedOutput: newBufferedOutput("elastic", bufferSize, flushInterval, maxRetries, send)}
+}
+
+// SyslogTLSOutput ships audit events as RFC 5424 syslog messages over
+// a TLS connection, the transport most SIEMs (Splunk, QRadar, Sentinel)
+// accept for near-real-time syslog ingestion. A fresh connection is
+// dialed per flush rather than held open, since flushes are already
+// batched and infrequent, and this avoids having to detect and
+// recover from a connection going stale between flushes.
+type SyslogTLSOutput struct {
+	*bufferedOutput
+}
+
+// NewSyslogTLSOutput creates a SyslogTLSOutput that dials addr
+// (host:port) with tlsConfig for each flush and writes one syslog
+// message per event, tagged with appName as the APP-NAME field. A
+// nil tlsConfig uses the system's default TLS settings.
+func NewSyslogTLSOutput(addr, appName string, tlsConfig *tls.Config, bufferSize int, flushInterval time.Duration, maxRetries int) *SyslogTLSOutput {
+	send := func(batch []Event) error {
+		conn, err := tls.DialWithDialer(&net.Dialer{Timeout: 5 * time.Second}, "tcp", addr, tlsConfig)
+		if err != nil {
+			return fmt.Errorf("audit: syslog+tls dial failed: %w", err)
+		}
+		defer conn.Close()
+
+		hostname, _ := os.Hostname()
+		for _, event := range batch {
+			data, err := json.Marshal(event)
+			if err != nil {
+				return fmt.Errorf("audit: marshaling syslog event: %w", err)
+			}
+			message := fmt.Sprintf("<134>1 %s %s %s - - - %s\n",
+				event.Timestamp.UTC().Format(time.RFC3339), hostname, appName, data)
+			if _, err := conn.Write([]byte(message)); err != nil {
+				return fmt.Errorf("audit: syslog+tls write failed: %w", err)
+			}
+		}
+		return nil
+	}
+
+	return &SyslogTLSOutput{bufferedOutput: newBufferedOutput("syslog+tls", bufferSize, flushInterval, maxRetries, send)}
+}
+
+// parseSIEMOutput recognizes the splunk-hec://, elastic://, and
+// syslog+tls:// output URIs New accepts alongside the plain "stdout"/
+// "stderr"/file-path forms, building the matching buffered SIEM
+// output from its query parameters. ok is false for any other spec,
+// so New falls back to treating it as a file path.
+func parseSIEMOutput(spec string, cfg *Config) (Output, bool) {
+	u, err := url.Parse(spec)
+	if err != nil {
+		return nil, false
+	}
+
+	switch u.Scheme {
+	case "splunk-hec":
+		token := ""
+		if u.User != nil {
+			token = u.User.Username()
+		}
+		endpoint := (&url.URL{Scheme: "https", Host: u.Host, Path: u.Path}).String()
+		sourcetype := u.Query().Get("sourcetype")
+		if sourcetype == "" {
+			sourcetype = "shode_audit"
+		}
+		return NewSplunkHECOutput(endpoint, token, sourcetype, cfg.BufferSize, cfg.FlushInterval, cfg.MaxRetries), true
+	case "elastic":
+		apiKey := ""
+		if u.User != nil {
+			apiKey = u.User.Username()
+		}
+		endpoint := (&url.URL{Scheme: "https", Host: u.Host}).String()
+		index := strings.TrimPrefix(u.Path, "/")
+		if index == "" {
+			index = "shode-audit"
+		}
+		return NewElasticOutput(endpoint, apiKey, index, cfg.BufferSize, cfg.FlushInterval, cfg.MaxRetries), true
+	case "syslog+tls":
+		return NewSyslogTLSOutput(u.Host, "shode", nil, cfg.BufferSize, cfg.FlushInterval, cfg.MaxRetries), true
+	default:
+		return nil, false
+	}
+}
@@ -0,0 +1,197 @@
+package serverless
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// FunctionMetrics is a snapshot of a Function's recent load, as
+// observed by whatever is driving the AutoScaler (a Gateway, a
+// QueueConsumer, or an external metrics pipeline).
+type FunctionMetrics struct {
+	RequestsPerSecond float64
+	P95LatencyMS      float64
+	QueueDepth        int
+}
+
+// ScalingPolicy bounds how an AutoScaler may resize a Function's
+// worker pool.
+type ScalingPolicy struct {
+	// MinWorkers and MaxWorkers bound the pool size. MinWorkers may be
+	// 0, allowing the pool to scale to zero when idle.
+	MinWorkers int
+	MaxWorkers int
+	// ScaleUpLatencyMS and ScaleUpQueueDepth: exceeding either triggers
+	// a scale-up.
+	ScaleUpLatencyMS  float64
+	ScaleUpQueueDepth int
+	// ScaleDownLatencyMS and ScaleDownQueueDepth: staying under both
+	// triggers a scale-down.
+	ScaleDownLatencyMS  float64
+	ScaleDownQueueDepth int
+	// Step is how many workers are added or removed per scaling
+	// decision. Defaults to 1 if zero.
+	Step int
+	// Cooldown is the minimum time between two scaling decisions for
+	// the same Function, to avoid thrashing.
+	Cooldown time.Duration
+	// ScaleToZeroIdle is how long a Function must see zero requests
+	// and zero queue depth before its pool is scaled to 0, regardless
+	// of MinWorkers. Zero disables scale-to-zero.
+	ScaleToZeroIdle time.Duration
+}
+
+// ScalingAction is the decision an AutoScaler made for one Function
+// during a single Evaluate call.
+type ScalingAction string
+
+const (
+	ActionNone      ScalingAction = "none"
+	ActionScaleUp   ScalingAction = "scale_up"
+	ActionScaleDown ScalingAction = "scale_down"
+	ActionScaleZero ScalingAction = "scale_to_zero"
+)
+
+// poolState is an AutoScaler's bookkeeping for a single Function's
+// worker pool.
+type poolState struct {
+	size       int
+	lastScaled time.Time
+	idleSince  time.Time
+}
+
+// AutoScaler watches FunctionMetrics and resizes per-Function worker
+// pools within a ScalingPolicy's bounds. Pool sizes are advisory: it's
+// up to the caller (e.g. Gateway's concurrency limit, or a worker pool
+// feeding a QueueConsumer) to read PoolSize and act on it.
+type AutoScaler struct {
+	mu     sync.Mutex
+	policy ScalingPolicy
+	pools  map[string]*poolState
+}
+
+// NewAutoScaler creates an AutoScaler enforcing policy. Every Function
+// starts at policy.MinWorkers until first evaluated.
+func NewAutoScaler(policy ScalingPolicy) *AutoScaler {
+	if policy.Step <= 0 {
+		policy.Step = 1
+	}
+	return &AutoScaler{
+		policy: policy,
+		pools:  make(map[string]*poolState),
+	}
+}
+
+// PoolSize reports functionName's current worker pool size.
+func (a *AutoScaler) PoolSize(functionName string) int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.stateLocked(functionName).size
+}
+
+// ScaleUp grows functionName's pool by the policy step, clamped to
+// MaxWorkers, ignoring cooldown. Intended for manual/operator-driven
+// scaling; the control loop (Evaluate/Run) applies cooldowns itself.
+func (a *AutoScaler) ScaleUp(functionName string) int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	st := a.stateLocked(functionName)
+	st.size = clamp(st.size+a.policy.Step, a.policy.MinWorkers, a.policy.MaxWorkers)
+	st.lastScaled = time.Now()
+	return st.size
+}
+
+// ScaleDown shrinks functionName's pool by the policy step, clamped to
+// MinWorkers, ignoring cooldown.
+func (a *AutoScaler) ScaleDown(functionName string) int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	st := a.stateLocked(functionName)
+	st.size = clamp(st.size-a.policy.Step, a.policy.MinWorkers, a.policy.MaxWorkers)
+	st.lastScaled = time.Now()
+	return st.size
+}
+
+// Evaluate applies the control loop's scaling rules for functionName
+// given its latest metrics, returning what it did (if anything) and
+// the pool size afterward. A cooldown since the last scaling decision
+// suppresses further action; scale-to-zero overrides the cooldown,
+// since an idle pool should never be kept warm just because it was
+// scaled recently.
+func (a *AutoScaler) Evaluate(functionName string, metrics FunctionMetrics) (ScalingAction, int) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	st := a.stateLocked(functionName)
+	now := time.Now()
+
+	idle := metrics.RequestsPerSecond == 0 && metrics.QueueDepth == 0
+	if !idle {
+		st.idleSince = time.Time{}
+	} else if st.idleSince.IsZero() {
+		st.idleSince = now
+	}
+
+	if a.policy.ScaleToZeroIdle > 0 && idle && st.size > 0 && now.Sub(st.idleSince) >= a.policy.ScaleToZeroIdle {
+		st.size = 0
+		st.lastScaled = now
+		return ActionScaleZero, st.size
+	}
+
+	if now.Sub(st.lastScaled) < a.policy.Cooldown {
+		return ActionNone, st.size
+	}
+
+	switch {
+	case st.size < a.policy.MaxWorkers && (metrics.P95LatencyMS > a.policy.ScaleUpLatencyMS || metrics.QueueDepth > a.policy.ScaleUpQueueDepth):
+		st.size = clamp(st.size+a.policy.Step, a.policy.MinWorkers, a.policy.MaxWorkers)
+		st.lastScaled = now
+		return ActionScaleUp, st.size
+	case st.size > a.policy.MinWorkers && metrics.P95LatencyMS <= a.policy.ScaleDownLatencyMS && metrics.QueueDepth <= a.policy.ScaleDownQueueDepth:
+		st.size = clamp(st.size-a.policy.Step, a.policy.MinWorkers, a.policy.MaxWorkers)
+		st.lastScaled = now
+		return ActionScaleDown, st.size
+	default:
+		return ActionNone, st.size
+	}
+}
+
+// Run evaluates every Function reported by metricsFn once per
+// interval, until ctx is done.
+func (a *AutoScaler) Run(ctx context.Context, interval time.Duration, metricsFn func() map[string]FunctionMetrics) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			for functionName, metrics := range metricsFn() {
+				a.Evaluate(functionName, metrics)
+			}
+		}
+	}
+}
+
+// stateLocked returns functionName's poolState, creating one seeded at
+// MinWorkers if this is the first time it's been seen. Callers must
+// hold a.mu.
+func (a *AutoScaler) stateLocked(functionName string) *poolState {
+	st, ok := a.pools[functionName]
+	if !ok {
+		st = &poolState{size: a.policy.MinWorkers}
+		a.pools[functionName] = st
+	}
+	return st
+}
+
+func clamp(v, min, max int) int {
+	if v < min {
+		return min
+	}
+	if v > max {
+		return max
+	}
+	return v
+}
@@ -0,0 +1,118 @@
+package serverless
+
+import (
+	"testing"
+	"time"
+)
+
+func TestScaleUpScaleDownRespectBounds(t *testing.T) {
+	a := NewAutoScaler(ScalingPolicy{MinWorkers: 1, MaxWorkers: 3, Step: 1})
+
+	if got := a.ScaleUp("f"); got != 2 {
+		t.Errorf("ScaleUp() = %d, want 2", got)
+	}
+	if got := a.ScaleUp("f"); got != 3 {
+		t.Errorf("ScaleUp() = %d, want 3", got)
+	}
+	if got := a.ScaleUp("f"); got != 3 {
+		t.Errorf("ScaleUp() past MaxWorkers = %d, want clamped to 3", got)
+	}
+
+	if got := a.ScaleDown("f"); got != 2 {
+		t.Errorf("ScaleDown() = %d, want 2", got)
+	}
+	a.ScaleDown("f")
+	if got := a.ScaleDown("f"); got != 1 {
+		t.Errorf("ScaleDown() past MinWorkers = %d, want clamped to 1", got)
+	}
+}
+
+func TestEvaluateScalesUpOnHighLatency(t *testing.T) {
+	a := NewAutoScaler(ScalingPolicy{
+		MinWorkers:       1,
+		MaxWorkers:       5,
+		ScaleUpLatencyMS: 200,
+		Step:             1,
+	})
+
+	action, size := a.Evaluate("f", FunctionMetrics{P95LatencyMS: 500})
+	if action != ActionScaleUp {
+		t.Errorf("Evaluate() action = %q, want %q", action, ActionScaleUp)
+	}
+	if size != 2 {
+		t.Errorf("Evaluate() size = %d, want 2", size)
+	}
+}
+
+func TestEvaluateScalesDownWhenQuiet(t *testing.T) {
+	a := NewAutoScaler(ScalingPolicy{
+		MinWorkers:          0,
+		MaxWorkers:          5,
+		ScaleUpLatencyMS:    1000,
+		ScaleUpQueueDepth:   100,
+		ScaleDownLatencyMS:  50,
+		ScaleDownQueueDepth: 0,
+		Step:                1,
+	})
+	a.ScaleUp("f")
+	a.ScaleUp("f")
+
+	action, size := a.Evaluate("f", FunctionMetrics{P95LatencyMS: 10})
+	if action != ActionScaleDown {
+		t.Errorf("Evaluate() action = %q, want %q", action, ActionScaleDown)
+	}
+	if size != 1 {
+		t.Errorf("Evaluate() size = %d, want 1", size)
+	}
+}
+
+func TestEvaluateRespectsCooldown(t *testing.T) {
+	a := NewAutoScaler(ScalingPolicy{
+		MinWorkers:       1,
+		MaxWorkers:       5,
+		ScaleUpLatencyMS: 100,
+		Cooldown:         time.Hour,
+		Step:             1,
+	})
+
+	a.Evaluate("f", FunctionMetrics{P95LatencyMS: 500})
+	action, size := a.Evaluate("f", FunctionMetrics{P95LatencyMS: 500})
+	if action != ActionNone {
+		t.Errorf("Evaluate() during cooldown action = %q, want %q", action, ActionNone)
+	}
+	if size != 2 {
+		t.Errorf("Evaluate() during cooldown size = %d, want unchanged 2", size)
+	}
+}
+
+func TestEvaluateScalesToZeroAfterIdleDuration(t *testing.T) {
+	a := NewAutoScaler(ScalingPolicy{
+		MinWorkers:      1,
+		MaxWorkers:      5,
+		Cooldown:        time.Hour,
+		ScaleToZeroIdle: 10 * time.Millisecond,
+		Step:            1,
+	})
+	a.ScaleUp("f")
+
+	action, size := a.Evaluate("f", FunctionMetrics{})
+	if action != ActionNone {
+		t.Errorf("Evaluate() immediately idle action = %q, want %q", action, ActionNone)
+	}
+
+	time.Sleep(15 * time.Millisecond)
+	action, size = a.Evaluate("f", FunctionMetrics{})
+	if action != ActionScaleZero {
+		t.Errorf("Evaluate() after idle duration action = %q, want %q", action, ActionScaleZero)
+	}
+	if size != 0 {
+		t.Errorf("Evaluate() after idle duration size = %d, want 0", size)
+	}
+}
+
+func TestPoolSizeDefaultsToMinWorkers(t *testing.T) {
+	a := NewAutoScaler(ScalingPolicy{MinWorkers: 2, MaxWorkers: 5})
+	if got := a.PoolSize("never-scaled"); got != 2 {
+		t.Errorf("PoolSize() = %d, want MinWorkers 2", got)
+	}
+}
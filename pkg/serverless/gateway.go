@@ -0,0 +1,193 @@
+package serverless
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	contextx "gitee.com/com_818cloud/shode/pkg/context"
+	"gitee.com/com_818cloud/shode/pkg/web"
+)
+
+// TraceHeader is the HTTP header a Gateway reads an upstream trace ID
+// from, so a call chain that already has one keeps it across the
+// gateway boundary instead of starting a new trace.
+const TraceHeader = "X-Trace-Id"
+
+// Gateway exposes registered Functions over HTTP: each Route maps a
+// method and path to a Function name (its trigger config), incoming
+// requests are marshaled into event payloads and dispatched through a
+// shared InvocationScheduler, and the resulting Invocation is marshaled
+// back as the response.
+type Gateway struct {
+	scheduler *InvocationScheduler
+	router    *web.Router
+	sem       chan struct{}
+}
+
+// NewGateway creates a Gateway dispatching invocations through
+// scheduler. maxConcurrent bounds how many invocations the gateway will
+// run at once; requests beyond that are rejected with 503 rather than
+// queued. A maxConcurrent of 0 or less means unlimited.
+func NewGateway(scheduler *InvocationScheduler, maxConcurrent int) *Gateway {
+	g := &Gateway{
+		scheduler: scheduler,
+		router:    web.NewRouter(),
+	}
+	if maxConcurrent > 0 {
+		g.sem = make(chan struct{}, maxConcurrent)
+	}
+	return g
+}
+
+// AddRoute registers a trigger config mapping method and path to the
+// named Function. method must be one of GET, POST, PUT, DELETE, or
+// PATCH (see net/http's Method constants).
+func (g *Gateway) AddRoute(method, path, functionName string) error {
+	handler := g.invoke(functionName)
+	switch method {
+	case http.MethodGet:
+		g.router.Get(path, handler)
+	case http.MethodPost:
+		g.router.Post(path, handler)
+	case http.MethodPut:
+		g.router.Put(path, handler)
+	case http.MethodDelete:
+		g.router.Delete(path, handler)
+	case http.MethodPatch:
+		g.router.Patch(path, handler)
+	default:
+		return fmt.Errorf("serverless: unsupported gateway method %q", method)
+	}
+	return nil
+}
+
+// ServeHTTP implements http.Handler, enforcing the gateway's
+// concurrency limit before routing to the matched Function.
+func (g *Gateway) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if g.sem != nil {
+		select {
+		case g.sem <- struct{}{}:
+			defer func() { <-g.sem }()
+		default:
+			w.Header().Set("Retry-After", "1")
+			http.Error(w, "gateway is at its concurrency limit", http.StatusServiceUnavailable)
+			return
+		}
+	}
+	g.router.ServeHTTP(w, r)
+}
+
+// invoke builds the handler for a single route: decode the request
+// into an event payload, run functionName, and write back the
+// resulting Invocation.
+func (g *Gateway) invoke(functionName string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		payload, err := requestPayload(r)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("invalid request body: %v", err), http.StatusBadRequest)
+			return
+		}
+
+		ctx := r.Context()
+		if traceID := r.Header.Get(TraceHeader); traceID != "" {
+			ctx = contextx.WithTraceID(ctx, traceID)
+		}
+
+		inv, err := g.scheduler.ExecuteWithContext(ctx, functionName, payload)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		w.Header().Set(TraceHeader, inv.TraceID)
+
+		if acceptsEventStream(r) {
+			writeInvocationStream(w, r, inv)
+			return
+		}
+		writeInvocationJSON(w, inv)
+	}
+}
+
+// requestPayload decodes r's body as a JSON object of strings and
+// merges in its query parameters (the body takes precedence on
+// conflicts), so a Function sees both as its event payload.
+func requestPayload(r *http.Request) (map[string]string, error) {
+	payload := make(map[string]string)
+	for key := range r.URL.Query() {
+		payload[key] = r.URL.Query().Get(key)
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, err
+	}
+	if len(body) == 0 {
+		return payload, nil
+	}
+
+	var fromBody map[string]string
+	if err := json.Unmarshal(body, &fromBody); err != nil {
+		return nil, err
+	}
+	for key, value := range fromBody {
+		payload[key] = value
+	}
+	return payload, nil
+}
+
+// acceptsEventStream reports whether r asked for a streaming response.
+func acceptsEventStream(r *http.Request) bool {
+	return r.Header.Get("Accept") == "text/event-stream"
+}
+
+// invocationResponse is the JSON body written back for a completed
+// Invocation.
+type invocationResponse struct {
+	ID       int    `json:"id"`
+	Function string `json:"function"`
+	Status   string `json:"status"`
+	Output   string `json:"output,omitempty"`
+	ExitCode int    `json:"exitCode"`
+	Error    string `json:"error,omitempty"`
+	TraceID  string `json:"traceId"`
+}
+
+func writeInvocationJSON(w http.ResponseWriter, inv *Invocation) {
+	w.Header().Set("Content-Type", "application/json")
+	if inv.Status == StatusFailed {
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+	json.NewEncoder(w).Encode(invocationResponse{
+		ID:       inv.ID,
+		Function: inv.FunctionName,
+		Status:   string(inv.Status),
+		Output:   inv.Output,
+		ExitCode: inv.ExitCode,
+		Error:    inv.Error,
+		TraceID:  inv.TraceID,
+	})
+}
+
+// writeInvocationStream replays inv's per-command output as Server-Sent
+// Events. The engine only returns output once a Function has finished
+// running (see pkg/web's BridgeExecutionOutput), so this is a replay of
+// the completed run rather than output arriving live as it's produced.
+func writeInvocationStream(w http.ResponseWriter, r *http.Request, inv *Invocation) {
+	stream, err := web.SSE(w, r)
+	if err != nil {
+		writeInvocationJSON(w, inv)
+		return
+	}
+
+	commands := make([]web.ExecutionCommandOutput, len(inv.Commands))
+	for i, cmd := range inv.Commands {
+		commands[i] = web.ExecutionCommandOutput{Output: cmd.Output, Error: cmd.Error}
+	}
+	web.BridgeExecutionOutput(stream, web.ExecutionOutput{
+		Success:  inv.Status == StatusSucceeded,
+		Error:    inv.Error,
+		Commands: commands,
+	})
+}
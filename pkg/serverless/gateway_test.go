@@ -0,0 +1,108 @@
+package serverless
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestGatewayInvokesFunctionAndReturnsJSON(t *testing.T) {
+	s := NewInvocationScheduler()
+	s.RegisterFunction(NewFunction("greet", `Println "hello $name"`))
+
+	g := NewGateway(s, 0)
+	if err := g.AddRoute(http.MethodPost, "/greet", "greet"); err != nil {
+		t.Fatalf("AddRoute() error = %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/greet", strings.NewReader(`{"name":"world"}`))
+	rec := httptest.NewRecorder()
+	g.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200; body = %s", rec.Code, rec.Body.String())
+	}
+	if !strings.Contains(rec.Body.String(), "hello world") {
+		t.Errorf("body = %s, want it to contain %q", rec.Body.String(), "hello world")
+	}
+}
+
+func TestGatewayUsesQueryParamsAsPayload(t *testing.T) {
+	s := NewInvocationScheduler()
+	s.RegisterFunction(NewFunction("greet", `Println "hello $name"`))
+
+	g := NewGateway(s, 0)
+	if err := g.AddRoute(http.MethodGet, "/greet", "greet"); err != nil {
+		t.Fatalf("AddRoute() error = %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/greet?name=alice", nil)
+	rec := httptest.NewRecorder()
+	g.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200; body = %s", rec.Code, rec.Body.String())
+	}
+	if !strings.Contains(rec.Body.String(), "hello alice") {
+		t.Errorf("body = %s, want it to contain %q", rec.Body.String(), "hello alice")
+	}
+}
+
+func TestGatewayUnknownRouteReturns404(t *testing.T) {
+	g := NewGateway(NewInvocationScheduler(), 0)
+	if err := g.AddRoute(http.MethodGet, "/greet", "greet"); err != nil {
+		t.Fatalf("AddRoute() error = %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/missing", nil)
+	rec := httptest.NewRecorder()
+	g.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("status = %d, want 404", rec.Code)
+	}
+}
+
+func TestGatewayUnregisteredFunctionReturns404(t *testing.T) {
+	g := NewGateway(NewInvocationScheduler(), 0)
+	if err := g.AddRoute(http.MethodGet, "/greet", "does-not-exist"); err != nil {
+		t.Fatalf("AddRoute() error = %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/greet", nil)
+	rec := httptest.NewRecorder()
+	g.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("status = %d, want 404", rec.Code)
+	}
+}
+
+func TestGatewayRejectsUnsupportedMethod(t *testing.T) {
+	g := NewGateway(NewInvocationScheduler(), 0)
+	if err := g.AddRoute(http.MethodHead, "/greet", "greet"); err == nil {
+		t.Fatal("AddRoute() error = nil, want error for unsupported method")
+	}
+}
+
+func TestGatewayEnforcesConcurrencyLimit(t *testing.T) {
+	s := NewInvocationScheduler()
+	s.RegisterFunction(NewFunction("noop", `true`))
+
+	g := NewGateway(s, 1)
+	if err := g.AddRoute(http.MethodGet, "/noop", "noop"); err != nil {
+		t.Fatalf("AddRoute() error = %v", err)
+	}
+
+	g.sem <- struct{}{} // simulate an in-flight invocation holding the only slot
+	defer func() { <-g.sem }()
+
+	req := httptest.NewRequest(http.MethodGet, "/noop", nil)
+	rec := httptest.NewRecorder()
+	g.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("status = %d, want 503", rec.Code)
+	}
+}
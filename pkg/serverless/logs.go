@@ -0,0 +1,222 @@
+package serverless
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// LogEntry is one record of a Function invocation's output, persisted
+// by a LogManager.
+type LogEntry struct {
+	FunctionName string    `json:"functionName"`
+	InvocationID int       `json:"invocationId"`
+	Timestamp    time.Time `json:"timestamp"`
+	Status       string    `json:"status"`
+	Output       string    `json:"output,omitempty"`
+	Error        string    `json:"error,omitempty"`
+}
+
+// DefaultLogDir returns the directory a LogManager writes to when none
+// is given explicitly, under the user's home directory.
+func DefaultLogDir() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ".shode-logs"
+	}
+	return filepath.Join(home, ".shode", "logs")
+}
+
+// LogManager persists Functions' LogEntries to disk, one
+// newline-delimited JSON file per function under dir, and enforces
+// retention by age: entries older than retention are dropped the next
+// time their function logs, so a long-running function's log file
+// doesn't grow without bound.
+//
+// Reading back through Tail, or a separate process following the same
+// file through Follow, is how "shode fn logs --follow" gets its stream
+// without requiring the reader to be attached to the process that
+// produced the logs.
+type LogManager struct {
+	dir       string
+	retention time.Duration
+
+	mu sync.Mutex
+}
+
+// NewLogManager creates a LogManager writing under dir (created if it
+// doesn't exist), dropping entries older than retention. A retention of
+// 0 or less disables pruning - entries are kept forever, matching the
+// in-memory LogManager's prior behavior for callers that don't want it.
+func NewLogManager(dir string, retention time.Duration) (*LogManager, error) {
+	if dir == "" {
+		dir = DefaultLogDir()
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("serverless: creating log directory %q: %w", dir, err)
+	}
+	return &LogManager{dir: dir, retention: retention}, nil
+}
+
+// logPath returns the path of functionName's log file. functionName is
+// sanitized to a single path component first, since Functions are
+// often named after a script's path (see cmd/shode/commands' fn
+// subcommands) and would otherwise scatter log files outside dir or
+// collide with directories that don't exist yet.
+func (lm *LogManager) logPath(functionName string) string {
+	safeName := strings.ReplaceAll(functionName, string(filepath.Separator), "_")
+	return filepath.Join(lm.dir, safeName+".log")
+}
+
+// Append records entry for its function, then enforces retention on
+// that function's log file.
+func (lm *LogManager) Append(entry LogEntry) error {
+	lm.mu.Lock()
+	defer lm.mu.Unlock()
+
+	entries, err := lm.readAll(entry.FunctionName)
+	if err != nil {
+		return err
+	}
+	entries = append(entries, entry)
+	entries = pruneByRetention(entries, lm.retention, time.Now())
+	return lm.writeAll(entry.FunctionName, entries)
+}
+
+// Tail returns the last n LogEntries recorded for functionName, oldest
+// first. A n of 0 or less returns every retained entry.
+func (lm *LogManager) Tail(functionName string, n int) ([]LogEntry, error) {
+	lm.mu.Lock()
+	defer lm.mu.Unlock()
+
+	entries, err := lm.readAll(functionName)
+	if err != nil {
+		return nil, err
+	}
+	if n > 0 && len(entries) > n {
+		entries = entries[len(entries)-n:]
+	}
+	return entries, nil
+}
+
+// Follow streams functionName's log file as it grows: it first sends
+// every entry already on disk, then polls for entries appended by
+// another process (or another goroutine in this one) until ctx is
+// done, closing the returned channel when it is. Polling, rather than a
+// filesystem watch, mirrors this package's fn-dev hot reload - simple
+// and good enough at log-tailing cadence.
+func (lm *LogManager) Follow(ctx context.Context, functionName string) <-chan LogEntry {
+	out := make(chan LogEntry)
+	go func() {
+		defer close(out)
+
+		sent := 0
+		ticker := time.NewTicker(500 * time.Millisecond)
+		defer ticker.Stop()
+
+		for {
+			entries, err := lm.Tail(functionName, 0)
+			if err == nil {
+				for _, entry := range entries[sent:] {
+					select {
+					case out <- entry:
+					case <-ctx.Done():
+						return
+					}
+				}
+				sent = len(entries)
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	return out
+}
+
+// readAll loads functionName's log file, returning no entries (not an
+// error) if it doesn't exist yet.
+func (lm *LogManager) readAll(functionName string) ([]LogEntry, error) {
+	f, err := os.Open(lm.logPath(functionName))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("serverless: reading logs for %q: %w", functionName, err)
+	}
+	defer f.Close()
+
+	var entries []LogEntry
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var entry LogEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	return entries, scanner.Err()
+}
+
+// writeAll replaces functionName's log file with entries.
+func (lm *LogManager) writeAll(functionName string, entries []LogEntry) error {
+	path := lm.logPath(functionName)
+	tmp := path + ".tmp"
+
+	f, err := os.Create(tmp)
+	if err != nil {
+		return fmt.Errorf("serverless: writing logs for %q: %w", functionName, err)
+	}
+	w := bufio.NewWriter(f)
+	for _, entry := range entries {
+		body, err := json.Marshal(entry)
+		if err != nil {
+			f.Close()
+			return err
+		}
+		w.Write(body)
+		w.WriteByte('\n')
+	}
+	if err := w.Flush(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// pruneByRetention drops entries older than retention relative to now.
+// A retention of 0 or less is a no-op.
+func pruneByRetention(entries []LogEntry, retention time.Duration, now time.Time) []LogEntry {
+	if retention <= 0 {
+		return entries
+	}
+	cutoff := now.Add(-retention)
+	kept := entries[:0:0]
+	for _, entry := range entries {
+		if entry.Timestamp.After(cutoff) {
+			kept = append(kept, entry)
+		}
+	}
+	return kept
+}
+
+// SetLogManager configures lm to receive a LogEntry for every future
+// invocation. A nil lm (the default) disables log persistence.
+func (s *InvocationScheduler) SetLogManager(lm *LogManager) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.logManager = lm
+}
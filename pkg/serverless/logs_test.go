@@ -0,0 +1,125 @@
+package serverless
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestLogManagerAppendAndTail(t *testing.T) {
+	lm, err := NewLogManager(t.TempDir(), 0)
+	if err != nil {
+		t.Fatalf("NewLogManager() error = %v", err)
+	}
+
+	for i := 1; i <= 3; i++ {
+		if err := lm.Append(LogEntry{FunctionName: "greet", InvocationID: i, Timestamp: time.Now(), Status: "succeeded"}); err != nil {
+			t.Fatalf("Append() error = %v", err)
+		}
+	}
+
+	entries, err := lm.Tail("greet", 2)
+	if err != nil {
+		t.Fatalf("Tail() error = %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("Tail(2) returned %d entries, want 2", len(entries))
+	}
+	if entries[0].InvocationID != 2 || entries[1].InvocationID != 3 {
+		t.Errorf("Tail(2) = %+v, want entries for invocations 2 and 3", entries)
+	}
+}
+
+func TestLogManagerTailUnknownFunctionReturnsEmpty(t *testing.T) {
+	lm, err := NewLogManager(t.TempDir(), 0)
+	if err != nil {
+		t.Fatalf("NewLogManager() error = %v", err)
+	}
+	entries, err := lm.Tail("nope", 0)
+	if err != nil {
+		t.Fatalf("Tail() error = %v", err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("Tail() for unknown function = %v, want empty", entries)
+	}
+}
+
+func TestLogManagerEnforcesRetention(t *testing.T) {
+	lm, err := NewLogManager(t.TempDir(), time.Hour)
+	if err != nil {
+		t.Fatalf("NewLogManager() error = %v", err)
+	}
+
+	if err := lm.Append(LogEntry{FunctionName: "greet", InvocationID: 1, Timestamp: time.Now().Add(-2 * time.Hour)}); err != nil {
+		t.Fatalf("Append() error = %v", err)
+	}
+	if err := lm.Append(LogEntry{FunctionName: "greet", InvocationID: 2, Timestamp: time.Now()}); err != nil {
+		t.Fatalf("Append() error = %v", err)
+	}
+
+	entries, err := lm.Tail("greet", 0)
+	if err != nil {
+		t.Fatalf("Tail() error = %v", err)
+	}
+	if len(entries) != 1 || entries[0].InvocationID != 2 {
+		t.Errorf("Tail() after retention = %+v, want only invocation 2", entries)
+	}
+}
+
+func TestLogManagerFollowSendsExistingThenNewEntries(t *testing.T) {
+	lm, err := NewLogManager(t.TempDir(), 0)
+	if err != nil {
+		t.Fatalf("NewLogManager() error = %v", err)
+	}
+	if err := lm.Append(LogEntry{FunctionName: "greet", InvocationID: 1, Timestamp: time.Now()}); err != nil {
+		t.Fatalf("Append() error = %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream := lm.Follow(ctx, "greet")
+
+	first := <-stream
+	if first.InvocationID != 1 {
+		t.Fatalf("first streamed entry = %+v, want invocation 1", first)
+	}
+
+	if err := lm.Append(LogEntry{FunctionName: "greet", InvocationID: 2, Timestamp: time.Now()}); err != nil {
+		t.Fatalf("Append() error = %v", err)
+	}
+
+	select {
+	case second := <-stream:
+		if second.InvocationID != 2 {
+			t.Errorf("second streamed entry = %+v, want invocation 2", second)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for newly appended entry")
+	}
+}
+
+func TestSchedulerRecordsLogsWhenConfigured(t *testing.T) {
+	s := NewInvocationScheduler()
+	s.RegisterFunction(NewFunction("greet", `Println "hello"`))
+
+	lm, err := NewLogManager(t.TempDir(), 0)
+	if err != nil {
+		t.Fatalf("NewLogManager() error = %v", err)
+	}
+	s.SetLogManager(lm)
+
+	if _, err := s.Execute("greet", nil); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	entries, err := lm.Tail("greet", 0)
+	if err != nil {
+		t.Fatalf("Tail() error = %v", err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("Tail() = %d entries, want 1", len(entries))
+	}
+	if entries[0].Status != string(StatusSucceeded) {
+		t.Errorf("entry Status = %q, want %q", entries[0].Status, StatusSucceeded)
+	}
+}
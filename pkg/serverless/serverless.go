@@ -0,0 +1,379 @@
+// Package serverless implements a minimal in-process FaaS-style invocation
+// model on top of the Shode parser and execution engine: register a
+// Function whose Code is a Shode script, then Execute it with an event
+// payload. The payload is exposed to the script both as variables and as
+// a JSON document on stdin, and the script's real output and exit status
+// are captured into the returned Invocation rather than a placeholder.
+package serverless
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	contextx "gitee.com/com_818cloud/shode/pkg/context"
+	"gitee.com/com_818cloud/shode/pkg/engine"
+	"gitee.com/com_818cloud/shode/pkg/environment"
+	"gitee.com/com_818cloud/shode/pkg/module"
+	"gitee.com/com_818cloud/shode/pkg/parser"
+	"gitee.com/com_818cloud/shode/pkg/sandbox"
+	"gitee.com/com_818cloud/shode/pkg/stdlib"
+	"gitee.com/com_818cloud/shode/pkg/types"
+)
+
+// Function is a named unit of deployable code: a Shode script that can be
+// invoked with an event payload.
+type Function struct {
+	Name string
+	Code string
+
+	// Runtime selects how Code is run. The zero value, RuntimeShode,
+	// parses Code as a Shode script; RuntimeWasm runs it as a compiled
+	// WebAssembly module instead (see NewWasmFunction).
+	Runtime RuntimeKind
+}
+
+// NewFunction creates a Function with the given name and Shode script code.
+func NewFunction(name, code string) *Function {
+	return &Function{Name: name, Code: code}
+}
+
+// InvocationStatus is the outcome of running a Function once.
+type InvocationStatus string
+
+const (
+	StatusSucceeded InvocationStatus = "succeeded"
+	StatusFailed    InvocationStatus = "failed"
+)
+
+// InvocationCommand is one command's contribution to an Invocation's
+// output, in execution order.
+type InvocationCommand struct {
+	Output string
+	Error  string
+}
+
+// Invocation is the record of a single Function run.
+type Invocation struct {
+	ID           int
+	FunctionName string
+	Payload      map[string]string
+	Status       InvocationStatus
+	Output       string
+	Commands     []InvocationCommand
+	ExitCode     int
+	Error        string
+	StartedAt    time.Time
+	FinishedAt   time.Time
+
+	// TraceID identifies the distributed trace this invocation belongs
+	// to, for correlating it with the request that triggered it and
+	// with whatever it calls out to. It's carried in from ctx (see
+	// pkg/context's trace ID, which a Gateway can propagate from an
+	// incoming request) or generated fresh if ctx didn't carry one.
+	TraceID string
+}
+
+// Duration is how long the invocation took to run.
+func (inv *Invocation) Duration() time.Duration {
+	return inv.FinishedAt.Sub(inv.StartedAt)
+}
+
+// InvocationScheduler holds registered Functions and dispatches
+// Invocations against them, using a pre-warmed execution engine from a
+// WarmPool when one is available (see Prewarm) and falling back to
+// building one fresh otherwise.
+type InvocationScheduler struct {
+	mu          sync.Mutex
+	functions   map[string]*Function
+	invocations map[int]*Invocation
+	nextID      int
+	executeMu   sync.Mutex // serializes runs that mutate process-wide os.Stdin
+
+	poolMu sync.Mutex
+	pools  map[string]*WarmPool
+
+	coldStarts int64
+	warmStarts int64
+
+	spanExporter SpanExporter
+
+	runtimeMu      sync.Mutex
+	runtimeManager *RuntimeManager
+
+	logManager *LogManager
+}
+
+// SetSpanExporter configures exporter to receive the Spans recorded for
+// every future invocation (queue wait, cold/warm start, handler
+// duration). A nil exporter (the default) disables span recording.
+func (s *InvocationScheduler) SetSpanExporter(exporter SpanExporter) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.spanExporter = exporter
+}
+
+// NewInvocationScheduler creates an empty InvocationScheduler.
+func NewInvocationScheduler() *InvocationScheduler {
+	return &InvocationScheduler{
+		functions:   make(map[string]*Function),
+		invocations: make(map[int]*Invocation),
+	}
+}
+
+// RegisterFunction makes fn available for Execute by name, replacing any
+// previously registered Function with the same name.
+func (s *InvocationScheduler) RegisterFunction(fn *Function) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.functions[fn.Name] = fn
+}
+
+// GetInvocation looks up a previously recorded Invocation by ID.
+func (s *InvocationScheduler) GetInvocation(id int) (*Invocation, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	inv, ok := s.invocations[id]
+	return inv, ok
+}
+
+// Execute runs the named Function with payload as its event, recording
+// and returning the resulting Invocation. It's equivalent to
+// ExecuteWithContext with a background context, so the invocation gets
+// a fresh TraceID rather than one propagated from a caller.
+func (s *InvocationScheduler) Execute(functionName string, payload map[string]string) (*Invocation, error) {
+	return s.ExecuteWithContext(context.Background(), functionName, payload)
+}
+
+// ExecuteWithContext runs the named Function with payload as its event,
+// recording and returning the resulting Invocation. If ctx carries a
+// trace ID (see pkg/context.WithTraceID - a Gateway can set one from an
+// incoming request), the Invocation and its Spans use it; otherwise a
+// fresh one is generated. An error is returned only when the function
+// isn't registered; a script failure is reported through the
+// Invocation's Status/ExitCode/Error, not through the error return.
+func (s *InvocationScheduler) ExecuteWithContext(ctx context.Context, functionName string, payload map[string]string) (*Invocation, error) {
+	queuedAt := time.Now()
+
+	s.mu.Lock()
+	fn, ok := s.functions[functionName]
+	if !ok {
+		s.mu.Unlock()
+		return nil, fmt.Errorf("serverless: function %q is not registered", functionName)
+	}
+	s.nextID++
+
+	traceID := contextx.GetTraceID(ctx)
+	if traceID == "" {
+		traceID = newTraceID()
+	}
+	inv := &Invocation{
+		ID:           s.nextID,
+		FunctionName: functionName,
+		Payload:      payload,
+		TraceID:      traceID,
+	}
+	s.invocations[inv.ID] = inv
+	exporter := s.spanExporter
+	s.mu.Unlock()
+
+	s.execute(contextx.WithTraceID(ctx, traceID), fn, inv, queuedAt, exporter)
+	return inv, nil
+}
+
+// execute obtains a parsed script and execution engine for fn - from a
+// WarmPool if one is available, otherwise built fresh - makes the
+// event payload available as script variables and as JSON on stdin,
+// runs the script, and fills in inv's result fields. It records a Span
+// per phase (queue wait, cold/warm start, handler) and hands them to
+// exporter, if one is configured.
+func (s *InvocationScheduler) execute(ctx context.Context, fn *Function, inv *Invocation, queuedAt time.Time, exporter SpanExporter) {
+	if fn.Runtime == RuntimeWasm {
+		s.executeWasm(ctx, fn, inv, queuedAt, exporter)
+		return
+	}
+
+	inv.StartedAt = time.Now()
+	var spans []Span
+	spans = append(spans, Span{TraceID: inv.TraceID, SpanID: newSpanID(), Name: "queue_wait", StartTime: queuedAt, EndTime: inv.StartedAt})
+	defer func() {
+		inv.FinishedAt = time.Now()
+		exportSpans(exporter, spans)
+		s.recordLog(inv)
+	}()
+
+	var script *types.ScriptNode
+	var envManager *environment.EnvironmentManager
+	var ee *engine.ExecutionEngine
+
+	startSpanName := "cold_start"
+	startBegin := time.Now()
+	if inst, ok := s.acquireWarm(fn.Name); ok {
+		atomic.AddInt64(&s.warmStarts, 1)
+		startSpanName = "warm_start"
+		script, envManager, ee = inst.script, inst.envManager, inst.engine
+	} else {
+		atomic.AddInt64(&s.coldStarts, 1)
+		var err error
+		script, err = parser.NewSimpleParser().ParseString(fn.Code)
+		if err != nil {
+			inv.Status = StatusFailed
+			inv.ExitCode = 1
+			inv.Error = fmt.Sprintf("failed to parse function %q: %v", fn.Name, err)
+			spans = append(spans, Span{TraceID: inv.TraceID, SpanID: newSpanID(), Name: startSpanName, StartTime: startBegin, EndTime: time.Now()})
+			return
+		}
+
+		envManager = environment.NewEnvironmentManager()
+		stdLib := stdlib.New()
+		moduleMgr := module.NewModuleManager()
+		security := sandbox.NewSecurityChecker()
+		ee = engine.NewExecutionEngine(envManager, stdLib, moduleMgr, security)
+	}
+	spans = append(spans, Span{TraceID: inv.TraceID, SpanID: newSpanID(), Name: startSpanName, StartTime: startBegin, EndTime: time.Now()})
+	envManager.Export(inv.Payload)
+
+	restoreStdin, err := s.replaceStdin(inv.Payload)
+	if err != nil {
+		inv.Status = StatusFailed
+		inv.ExitCode = 1
+		inv.Error = fmt.Sprintf("failed to prepare event payload: %v", err)
+		return
+	}
+	defer restoreStdin()
+
+	handlerBegin := time.Now()
+	result, err := ee.Execute(ctx, script)
+	spans = append(spans, Span{TraceID: inv.TraceID, SpanID: newSpanID(), Name: "handler", StartTime: handlerBegin, EndTime: time.Now()})
+	if err != nil {
+		inv.Status = StatusFailed
+		inv.ExitCode = 1
+		inv.Error = err.Error()
+		return
+	}
+
+	inv.Output = result.Output
+	inv.ExitCode = result.ExitCode
+	for _, cmd := range result.Commands {
+		inv.Commands = append(inv.Commands, InvocationCommand{Output: cmd.Output, Error: cmd.Error})
+	}
+	if result.Success {
+		inv.Status = StatusSucceeded
+	} else {
+		inv.Status = StatusFailed
+		inv.Error = result.Error
+	}
+}
+
+// executeWasm is execute's counterpart for a RuntimeWasm Function: no
+// Shode parser, environment, or warm pool is involved, so it just times
+// a queue_wait span followed by a single handler span around
+// RuntimeManager.Invoke.
+func (s *InvocationScheduler) executeWasm(ctx context.Context, fn *Function, inv *Invocation, queuedAt time.Time, exporter SpanExporter) {
+	inv.StartedAt = time.Now()
+	var spans []Span
+	spans = append(spans, Span{TraceID: inv.TraceID, SpanID: newSpanID(), Name: "queue_wait", StartTime: queuedAt, EndTime: inv.StartedAt})
+	defer func() {
+		inv.FinishedAt = time.Now()
+		exportSpans(exporter, spans)
+		s.recordLog(inv)
+	}()
+
+	rm, err := s.wasmRuntime(ctx)
+	if err != nil {
+		inv.Status = StatusFailed
+		inv.ExitCode = 1
+		inv.Error = err.Error()
+		return
+	}
+
+	handlerBegin := time.Now()
+	output, exitCode, err := rm.Invoke(ctx, fn, inv.Payload)
+	spans = append(spans, Span{TraceID: inv.TraceID, SpanID: newSpanID(), Name: "handler", StartTime: handlerBegin, EndTime: time.Now()})
+
+	inv.Output = output
+	inv.ExitCode = exitCode
+	if err != nil {
+		inv.Status = StatusFailed
+		inv.Error = err.Error()
+		return
+	}
+	if exitCode == 0 {
+		inv.Status = StatusSucceeded
+	} else {
+		inv.Status = StatusFailed
+		inv.Error = fmt.Sprintf("wasm module exited with code %d", exitCode)
+	}
+}
+
+// wasmRuntime returns the scheduler's shared RuntimeManager, building
+// one on first use since most schedulers never run a RuntimeWasm
+// Function and shouldn't pay wazero's startup cost for nothing.
+func (s *InvocationScheduler) wasmRuntime(ctx context.Context) (*RuntimeManager, error) {
+	s.runtimeMu.Lock()
+	defer s.runtimeMu.Unlock()
+	if s.runtimeManager == nil {
+		rm, err := NewRuntimeManager(ctx)
+		if err != nil {
+			return nil, err
+		}
+		s.runtimeManager = rm
+	}
+	return s.runtimeManager, nil
+}
+
+// recordLog appends a LogEntry for inv to the scheduler's LogManager,
+// if one is configured. It's called once an Invocation has finished,
+// so the entry always has a final Status.
+func (s *InvocationScheduler) recordLog(inv *Invocation) {
+	s.mu.Lock()
+	lm := s.logManager
+	s.mu.Unlock()
+	if lm == nil {
+		return
+	}
+	lm.Append(LogEntry{
+		FunctionName: inv.FunctionName,
+		InvocationID: inv.ID,
+		Timestamp:    inv.FinishedAt,
+		Status:       string(inv.Status),
+		Output:       inv.Output,
+		Error:        inv.Error,
+	})
+}
+
+// replaceStdin swaps the process's os.Stdin for a pipe containing
+// payload encoded as JSON, so functions that read stdin (directly, or via
+// spawned external commands) see the event body. The execution lock is
+// held until the returned restore func runs, since os.Stdin is
+// process-wide shared state.
+func (s *InvocationScheduler) replaceStdin(payload map[string]string) (restore func(), err error) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		return nil, err
+	}
+
+	s.executeMu.Lock()
+	original := os.Stdin
+	os.Stdin = r
+
+	go func() {
+		w.Write(body)
+		w.Close()
+	}()
+
+	return func() {
+		os.Stdin = original
+		r.Close()
+		s.executeMu.Unlock()
+	}, nil
+}
@@ -0,0 +1,66 @@
+package serverless
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestExecuteRunsScriptWithPayloadAsVariables(t *testing.T) {
+	s := NewInvocationScheduler()
+	s.RegisterFunction(NewFunction("greet", `Println "hello $name"`))
+
+	inv, err := s.Execute("greet", map[string]string{"name": "world"})
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if inv.Status != StatusSucceeded {
+		t.Fatalf("Status = %q, want %q (error: %s)", inv.Status, StatusSucceeded, inv.Error)
+	}
+	if !strings.Contains(inv.Output, "hello world") {
+		t.Errorf("Output = %q, want it to contain %q", inv.Output, "hello world")
+	}
+	if inv.ExitCode != 0 {
+		t.Errorf("ExitCode = %d, want 0", inv.ExitCode)
+	}
+}
+
+func TestExecuteExposesPayloadOnStdin(t *testing.T) {
+	s := NewInvocationScheduler()
+	s.RegisterFunction(NewFunction("echo-stdin", `cat`))
+
+	inv, err := s.Execute("echo-stdin", map[string]string{"hello": "world"})
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if inv.Status != StatusSucceeded {
+		t.Fatalf("Status = %q, want %q (error: %s)", inv.Status, StatusSucceeded, inv.Error)
+	}
+	if !strings.Contains(inv.Output, `"hello":"world"`) {
+		t.Errorf("Output = %q, want it to contain the JSON-encoded payload", inv.Output)
+	}
+}
+
+func TestExecuteUnknownFunctionReturnsError(t *testing.T) {
+	s := NewInvocationScheduler()
+	if _, err := s.Execute("missing", nil); err == nil {
+		t.Fatal("Execute() error = nil, want error for unregistered function")
+	}
+}
+
+func TestGetInvocationReturnsRecordedRun(t *testing.T) {
+	s := NewInvocationScheduler()
+	s.RegisterFunction(NewFunction("noop", `true`))
+
+	inv, err := s.Execute("noop", nil)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	got, ok := s.GetInvocation(inv.ID)
+	if !ok {
+		t.Fatalf("GetInvocation(%d) not found", inv.ID)
+	}
+	if got != inv {
+		t.Errorf("GetInvocation(%d) returned a different Invocation", inv.ID)
+	}
+}
@@ -0,0 +1,170 @@
+package serverless
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Span is one timed segment of an Invocation's lifecycle: the time it
+// waited for the executeMu lock, the cold or warm start that got a
+// runtime ready, and the handler itself running.
+type Span struct {
+	TraceID   string
+	SpanID    string
+	Name      string
+	StartTime time.Time
+	EndTime   time.Time
+}
+
+// Duration is how long the span took.
+func (s Span) Duration() time.Duration {
+	return s.EndTime.Sub(s.StartTime)
+}
+
+// SpanExporter receives the Spans recorded for one Invocation, so a
+// collector (or a test double) can observe queue time, cold/warm start,
+// and handler duration without InvocationScheduler depending on a
+// specific tracing backend.
+type SpanExporter interface {
+	Export(spans []Span) error
+}
+
+// newTraceID generates a new trace ID for an invocation that didn't
+// arrive with one already (e.g. a Gateway request carrying an upstream
+// trace ID via context).
+func newTraceID() string {
+	return uuid.NewString()
+}
+
+func newSpanID() string {
+	return uuid.NewString()
+}
+
+// exportSpans hands spans off to exporter on a background goroutine, so
+// a slow or unreachable collector can't add latency to the invocation
+// that produced them.
+func exportSpans(exporter SpanExporter, spans []Span) {
+	if exporter == nil || len(spans) == 0 {
+		return
+	}
+	go exporter.Export(spans)
+}
+
+// OTLPSpanExporter exports Spans to an OTLP/HTTP trace collector,
+// mirroring pkg/logger's otlpWriter for logs: it hand-rolls just enough
+// of the OTLP/HTTP JSON data model (protobuf JSON mapping of
+// ExportTraceServiceRequest) to carry a Span across, rather than taking
+// on the OpenTelemetry SDK as a dependency.
+type OTLPSpanExporter struct {
+	endpoint string
+	resource map[string]string
+	client   *http.Client
+}
+
+// NewOTLPSpanExporter creates an OTLPSpanExporter posting to endpoint,
+// tagging every export with resource's attributes (e.g. service.name).
+func NewOTLPSpanExporter(endpoint string, resource map[string]string) *OTLPSpanExporter {
+	return &OTLPSpanExporter{
+		endpoint: endpoint,
+		resource: resource,
+		client:   &http.Client{Timeout: 5 * time.Second},
+	}
+}
+
+// Export POSTs spans to the configured OTLP/HTTP endpoint as a single
+// ExportTraceServiceRequest.
+func (e *OTLPSpanExporter) Export(spans []Span) error {
+	payload, err := buildOTLPTraceRequest(spans, e.resource)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, e.endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("serverless: OTLP trace export failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("serverless: OTLP collector returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// otlpAnyValue and otlpKeyValue mirror the matching types in
+// pkg/logger's OTLP exporter; duplicated rather than shared since both
+// are package-private implementation detail of an OTLP/HTTP JSON
+// payload, not a type either package's API needs to expose.
+type otlpAnyValue struct {
+	StringValue string `json:"stringValue"`
+}
+
+type otlpKeyValue struct {
+	Key   string       `json:"key"`
+	Value otlpAnyValue `json:"value"`
+}
+
+// otlpSpan, otlpScopeSpans, otlpResourceSpans, and otlpTraceRequest
+// mirror just enough of the OTLP/HTTP JSON trace data model to carry a
+// Span across. Attribute-less, parent-less spans are enough for the
+// queue-time/cold-start/handler-duration breakdown this package emits.
+type otlpSpan struct {
+	TraceID           string `json:"traceId"`
+	SpanID            string `json:"spanId"`
+	Name              string `json:"name"`
+	StartTimeUnixNano string `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string `json:"endTimeUnixNano"`
+}
+
+type otlpScopeSpans struct {
+	Spans []otlpSpan `json:"spans"`
+}
+
+type otlpTraceResource struct {
+	Attributes []otlpKeyValue `json:"attributes,omitempty"`
+}
+
+type otlpResourceSpans struct {
+	Resource   otlpTraceResource `json:"resource"`
+	ScopeSpans []otlpScopeSpans  `json:"scopeSpans"`
+}
+
+type otlpTraceRequest struct {
+	ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+}
+
+func buildOTLPTraceRequest(spans []Span, resource map[string]string) ([]byte, error) {
+	records := make([]otlpSpan, len(spans))
+	for i, s := range spans {
+		records[i] = otlpSpan{
+			TraceID:           s.TraceID,
+			SpanID:            s.SpanID,
+			Name:              s.Name,
+			StartTimeUnixNano: fmt.Sprintf("%d", s.StartTime.UnixNano()),
+			EndTimeUnixNano:   fmt.Sprintf("%d", s.EndTime.UnixNano()),
+		}
+	}
+
+	resourceAttrs := make([]otlpKeyValue, 0, len(resource))
+	for k, v := range resource {
+		resourceAttrs = append(resourceAttrs, otlpKeyValue{Key: k, Value: otlpAnyValue{StringValue: v}})
+	}
+
+	request := otlpTraceRequest{
+		ResourceSpans: []otlpResourceSpans{{
+			Resource:   otlpTraceResource{Attributes: resourceAttrs},
+			ScopeSpans: []otlpScopeSpans{{Spans: records}},
+		}},
+	}
+	return json.Marshal(request)
+}
@@ -0,0 +1,116 @@
+package serverless
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	contextx "gitee.com/com_818cloud/shode/pkg/context"
+)
+
+// fakeSpanExporter collects exported spans for assertions, guarded by a
+// mutex since exportSpans hands off to a background goroutine.
+type fakeSpanExporter struct {
+	mu    sync.Mutex
+	spans []Span
+}
+
+func (e *fakeSpanExporter) Export(spans []Span) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.spans = append(e.spans, spans...)
+	return nil
+}
+
+func (e *fakeSpanExporter) wait(t *testing.T, want int) []Span {
+	t.Helper()
+	for i := 0; i < 100; i++ {
+		e.mu.Lock()
+		got := len(e.spans)
+		e.mu.Unlock()
+		if got >= want {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return append([]Span(nil), e.spans...)
+}
+
+func TestExecuteGeneratesTraceIDAndExportsSpans(t *testing.T) {
+	s := NewInvocationScheduler()
+	s.RegisterFunction(NewFunction("greet", `Println "hello"`))
+
+	exporter := &fakeSpanExporter{}
+	s.SetSpanExporter(exporter)
+
+	inv, err := s.Execute("greet", nil)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if inv.TraceID == "" {
+		t.Error("Invocation.TraceID is empty, want a generated trace ID")
+	}
+
+	spans := exporter.wait(t, 3)
+	if len(spans) != 3 {
+		t.Fatalf("exported spans = %d, want 3 (queue_wait, cold_start, handler)", len(spans))
+	}
+	for _, span := range spans {
+		if span.TraceID != inv.TraceID {
+			t.Errorf("span %q TraceID = %q, want %q", span.Name, span.TraceID, inv.TraceID)
+		}
+	}
+
+	names := map[string]bool{}
+	for _, span := range spans {
+		names[span.Name] = true
+	}
+	for _, want := range []string{"queue_wait", "cold_start", "handler"} {
+		if !names[want] {
+			t.Errorf("exported spans %v missing %q", names, want)
+		}
+	}
+}
+
+func TestExecuteWithContextReusesIncomingTraceID(t *testing.T) {
+	s := NewInvocationScheduler()
+	s.RegisterFunction(NewFunction("greet", `true`))
+
+	ctx := contextx.WithTraceID(context.Background(), "trace-123")
+	inv, err := s.ExecuteWithContext(ctx, "greet", nil)
+	if err != nil {
+		t.Fatalf("ExecuteWithContext() error = %v", err)
+	}
+	if inv.TraceID != "trace-123" {
+		t.Errorf("TraceID = %q, want %q", inv.TraceID, "trace-123")
+	}
+}
+
+func TestPrewarmedInvocationRecordsWarmStartSpan(t *testing.T) {
+	s := NewInvocationScheduler()
+	s.RegisterFunction(NewFunction("greet", `true`))
+	if err := s.Prewarm("greet", 1); err != nil {
+		t.Fatalf("Prewarm() error = %v", err)
+	}
+
+	exporter := &fakeSpanExporter{}
+	s.SetSpanExporter(exporter)
+
+	if _, err := s.Execute("greet", nil); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	spans := exporter.wait(t, 3)
+	found := false
+	for _, span := range spans {
+		if span.Name == "warm_start" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("exported spans = %v, want a warm_start span", spans)
+	}
+}
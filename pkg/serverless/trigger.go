@@ -0,0 +1,246 @@
+package serverless
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// IntervalTrigger invokes a Function on a fixed period.
+type IntervalTrigger struct {
+	scheduler    *InvocationScheduler
+	functionName string
+	period       time.Duration
+	payload      map[string]string
+}
+
+// NewIntervalTrigger creates an IntervalTrigger that invokes
+// functionName with payload every period.
+func NewIntervalTrigger(scheduler *InvocationScheduler, functionName string, period time.Duration, payload map[string]string) *IntervalTrigger {
+	return &IntervalTrigger{
+		scheduler:    scheduler,
+		functionName: functionName,
+		period:       period,
+		payload:      payload,
+	}
+}
+
+// Run invokes the Function every period until ctx is done.
+func (t *IntervalTrigger) Run(ctx context.Context) {
+	ticker := time.NewTicker(t.period)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			t.scheduler.Execute(t.functionName, t.payload)
+		}
+	}
+}
+
+// CronSchedule is a minimal five-field cron schedule (minute hour
+// day-of-month month day-of-week), matching the subset of cron syntax
+// this repo needs: "*" for any value, or a comma-separated list of
+// literal integers. Ranges and step values ("1-5", "*/15") aren't
+// supported.
+type CronSchedule struct {
+	minute, hour, day, month, weekday cronField
+}
+
+// cronField is one field of a CronSchedule: nil means "any value".
+type cronField map[int]bool
+
+// ParseCronSchedule parses a five-field cron expression like "0 9 * * 1-5"
+// would in full cron - but since this package only supports "*" and
+// comma-separated literals, "1-5" must be spelled out as "1,2,3,4,5".
+func ParseCronSchedule(expr string) (*CronSchedule, error) {
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("serverless: cron expression %q must have 5 fields, got %d", expr, len(fields))
+	}
+
+	parsed := make([]cronField, 5)
+	for i, f := range fields {
+		field, err := parseCronField(f)
+		if err != nil {
+			return nil, fmt.Errorf("serverless: cron field %d (%q): %w", i, f, err)
+		}
+		parsed[i] = field
+	}
+
+	return &CronSchedule{
+		minute:  parsed[0],
+		hour:    parsed[1],
+		day:     parsed[2],
+		month:   parsed[3],
+		weekday: parsed[4],
+	}, nil
+}
+
+func parseCronField(f string) (cronField, error) {
+	if f == "*" {
+		return nil, nil
+	}
+	field := make(cronField)
+	for _, part := range strings.Split(f, ",") {
+		n, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil {
+			return nil, fmt.Errorf("not an integer or \"*\": %q", part)
+		}
+		field[n] = true
+	}
+	return field, nil
+}
+
+func (f cronField) matches(v int) bool {
+	return f == nil || f[v]
+}
+
+// Matches reports whether t falls on this schedule, to minute
+// precision.
+func (s *CronSchedule) Matches(t time.Time) bool {
+	return s.minute.matches(t.Minute()) &&
+		s.hour.matches(t.Hour()) &&
+		s.day.matches(t.Day()) &&
+		s.month.matches(int(t.Month())) &&
+		s.weekday.matches(int(t.Weekday()))
+}
+
+// CronTrigger invokes a Function once per minute that matches its
+// CronSchedule.
+type CronTrigger struct {
+	scheduler    *InvocationScheduler
+	functionName string
+	schedule     *CronSchedule
+	payload      map[string]string
+}
+
+// NewCronTrigger creates a CronTrigger that invokes functionName with
+// payload whenever the wall clock matches schedule.
+func NewCronTrigger(scheduler *InvocationScheduler, functionName string, schedule *CronSchedule, payload map[string]string) *CronTrigger {
+	return &CronTrigger{
+		scheduler:    scheduler,
+		functionName: functionName,
+		schedule:     schedule,
+		payload:      payload,
+	}
+}
+
+// Run checks the schedule once a minute and invokes the Function on
+// every match, until ctx is done.
+func (t *CronTrigger) Run(ctx context.Context) {
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			if t.schedule.Matches(now) {
+				t.scheduler.Execute(t.functionName, t.payload)
+			}
+		}
+	}
+}
+
+// QueueMessage is a single message received from a QueueClient.
+type QueueMessage struct {
+	ID      string
+	Payload map[string]string
+}
+
+// QueueClient is the minimal subset of behavior a QueueConsumer needs
+// to receive, acknowledge, and dead-letter messages. A real NATS,
+// Kafka, or SQS client can implement it without this package depending
+// on a specific driver, mirroring pkg/web/ratelimit.go's RedisClient.
+type QueueClient interface {
+	// Receive blocks until a message is available or ctx is done.
+	Receive(ctx context.Context) (QueueMessage, error)
+	// Ack acknowledges successful processing of a message so the
+	// queue doesn't redeliver it.
+	Ack(ctx context.Context, id string) error
+	// DeadLetter moves a message that exhausted its retries to the
+	// dead-letter destination, recording why it failed.
+	DeadLetter(ctx context.Context, msg QueueMessage, reason error) error
+}
+
+// QueueConsumer feeds an InvocationScheduler from a QueueClient with
+// at-least-once delivery: a message is acknowledged only after its
+// Function invocation succeeds, so a consumer crash before Ack leaves
+// the message to be redelivered. A message that keeps failing past
+// MaxRetries is handed to QueueClient.DeadLetter and then acknowledged
+// so it stops being redelivered.
+type QueueConsumer struct {
+	scheduler    *InvocationScheduler
+	client       QueueClient
+	functionName string
+	maxRetries   int
+
+	mu       sync.Mutex
+	attempts map[string]int
+}
+
+// NewQueueConsumer creates a QueueConsumer that invokes functionName
+// for each message received from client, retrying a failing message up
+// to maxRetries times before dead-lettering it.
+func NewQueueConsumer(scheduler *InvocationScheduler, client QueueClient, functionName string, maxRetries int) *QueueConsumer {
+	return &QueueConsumer{
+		scheduler:    scheduler,
+		client:       client,
+		functionName: functionName,
+		maxRetries:   maxRetries,
+		attempts:     make(map[string]int),
+	}
+}
+
+// Run receives and dispatches messages until ctx is done or Receive
+// returns an error.
+func (c *QueueConsumer) Run(ctx context.Context) error {
+	for {
+		msg, err := c.client.Receive(ctx)
+		if err != nil {
+			return err
+		}
+		c.handle(ctx, msg)
+	}
+}
+
+// handle dispatches a single message, acking, retrying, or
+// dead-lettering it depending on the outcome.
+func (c *QueueConsumer) handle(ctx context.Context, msg QueueMessage) {
+	inv, err := c.scheduler.Execute(c.functionName, msg.Payload)
+	if err == nil && inv.Status == StatusSucceeded {
+		c.clearAttempts(msg.ID)
+		c.client.Ack(ctx, msg.ID)
+		return
+	}
+
+	failure := err
+	if failure == nil {
+		failure = fmt.Errorf("invocation failed: %s", inv.Error)
+	}
+
+	if c.recordAttempt(msg.ID) > c.maxRetries {
+		c.client.DeadLetter(ctx, msg, failure)
+		c.client.Ack(ctx, msg.ID)
+		c.clearAttempts(msg.ID)
+	}
+	// Otherwise leave msg unacknowledged so the QueueClient redelivers it.
+}
+
+func (c *QueueConsumer) recordAttempt(id string) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.attempts[id]++
+	return c.attempts[id]
+}
+
+func (c *QueueConsumer) clearAttempts(id string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.attempts, id)
+}
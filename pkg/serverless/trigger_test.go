@@ -0,0 +1,130 @@
+package serverless
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestIntervalTriggerInvokesRepeatedly(t *testing.T) {
+	s := NewInvocationScheduler()
+	s.RegisterFunction(NewFunction("tick", `true`))
+
+	trigger := NewIntervalTrigger(s, "tick", 5*time.Millisecond, nil)
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Millisecond)
+	defer cancel()
+
+	done := make(chan struct{})
+	go func() {
+		trigger.Run(ctx)
+		close(done)
+	}()
+	<-done
+
+	if _, ok := s.GetInvocation(1); !ok {
+		t.Errorf("IntervalTrigger invoked tick 0 times, want at least 1")
+	}
+}
+
+func TestParseCronScheduleRejectsWrongFieldCount(t *testing.T) {
+	if _, err := ParseCronSchedule("* * *"); err == nil {
+		t.Fatal("ParseCronSchedule() error = nil, want error for wrong field count")
+	}
+}
+
+func TestCronScheduleMatches(t *testing.T) {
+	sched, err := ParseCronSchedule("30 9 * * 1,2,3,4,5")
+	if err != nil {
+		t.Fatalf("ParseCronSchedule() error = %v", err)
+	}
+
+	monday930 := time.Date(2026, time.August, 10, 9, 30, 0, 0, time.UTC)
+	if !sched.Matches(monday930) {
+		t.Errorf("Matches(%v) = false, want true", monday930)
+	}
+
+	saturday930 := time.Date(2026, time.August, 8, 9, 30, 0, 0, time.UTC)
+	if sched.Matches(saturday930) {
+		t.Errorf("Matches(%v) = true, want false (Saturday not in weekday list)", saturday930)
+	}
+
+	monday931 := time.Date(2026, time.August, 10, 9, 31, 0, 0, time.UTC)
+	if sched.Matches(monday931) {
+		t.Errorf("Matches(%v) = true, want false (wrong minute)", monday931)
+	}
+}
+
+// fakeQueueClient is an in-memory QueueClient for tests: Receive serves
+// messages from a queue one at a time, Ack/DeadLetter record what
+// happened to each message ID.
+type fakeQueueClient struct {
+	mu           sync.Mutex
+	messages     []QueueMessage
+	acked        []string
+	deadLettered []string
+}
+
+func (c *fakeQueueClient) Receive(ctx context.Context) (QueueMessage, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.messages) == 0 {
+		return QueueMessage{}, errors.New("fakeQueueClient: no more messages")
+	}
+	msg := c.messages[0]
+	c.messages = c.messages[1:]
+	return msg, nil
+}
+
+func (c *fakeQueueClient) Ack(ctx context.Context, id string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.acked = append(c.acked, id)
+	return nil
+}
+
+func (c *fakeQueueClient) DeadLetter(ctx context.Context, msg QueueMessage, reason error) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.deadLettered = append(c.deadLettered, msg.ID)
+	return nil
+}
+
+func TestQueueConsumerAcksSuccessfulInvocation(t *testing.T) {
+	s := NewInvocationScheduler()
+	s.RegisterFunction(NewFunction("ok", `true`))
+
+	client := &fakeQueueClient{messages: []QueueMessage{{ID: "m1"}}}
+	consumer := NewQueueConsumer(s, client, "ok", 3)
+
+	if err := consumer.Run(context.Background()); err == nil {
+		t.Fatal("Run() error = nil, want error once messages are exhausted")
+	}
+
+	if len(client.acked) != 1 || client.acked[0] != "m1" {
+		t.Errorf("acked = %v, want [m1]", client.acked)
+	}
+	if len(client.deadLettered) != 0 {
+		t.Errorf("deadLettered = %v, want none", client.deadLettered)
+	}
+}
+
+func TestQueueConsumerDeadLettersAfterMaxRetries(t *testing.T) {
+	s := NewInvocationScheduler()
+
+	client := &fakeQueueClient{}
+	consumer := NewQueueConsumer(s, client, "missing", 2)
+
+	msg := QueueMessage{ID: "m1"}
+	for i := 0; i < 3; i++ {
+		consumer.handle(context.Background(), msg)
+	}
+
+	if len(client.deadLettered) != 1 || client.deadLettered[0] != "m1" {
+		t.Errorf("deadLettered = %v, want [m1]", client.deadLettered)
+	}
+	if len(client.acked) != 1 || client.acked[0] != "m1" {
+		t.Errorf("acked = %v, want [m1] (dead-lettered messages are still acked)", client.acked)
+	}
+}
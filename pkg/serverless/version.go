@@ -0,0 +1,225 @@
+package serverless
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Version is one deployed revision of a Function, registered into the
+// owning InvocationScheduler under a mangled name so it can be invoked
+// independently of other versions of the same function.
+type Version struct {
+	ID     string
+	Weight int
+}
+
+// versionStats tracks a Version's observed outcomes, used to decide
+// whether it's healthy enough to keep receiving traffic.
+type versionStats struct {
+	total    int
+	failures int
+}
+
+// RollbackPolicy controls when a VersionManager automatically reverts
+// all traffic to the stable version.
+type RollbackPolicy struct {
+	// MinSamples is how many invocations a non-stable version must
+	// accumulate before its error rate is judged at all. Guards
+	// against rolling back on a single unlucky canary invocation.
+	MinSamples int
+	// ErrorRateThreshold is the failure fraction (0 to 1) a
+	// non-stable version may reach before it's rolled back.
+	ErrorRateThreshold float64
+}
+
+// versionedFunction is a VersionManager's bookkeeping for one logical
+// function name: its registered versions, their traffic weights, and
+// the deterministic routing table built from those weights.
+type versionedFunction struct {
+	stable   string
+	versions map[string]*Version
+	stats    map[string]*versionStats
+	route    []string // weighted-round-robin routing table
+	next     int
+}
+
+// VersionManager layers function versioning, weighted traffic
+// splitting, and automatic rollback on top of an InvocationScheduler.
+// Each Version of a function is registered into the scheduler under
+// the mangled name "<base>@<versionID>"; Invoke picks a version by a
+// deterministic weighted-round-robin over the configured split and
+// dispatches to that mangled name.
+type VersionManager struct {
+	mu        sync.Mutex
+	scheduler *InvocationScheduler
+	rollback  RollbackPolicy
+	functions map[string]*versionedFunction
+}
+
+// NewVersionManager creates a VersionManager that deploys versions
+// into scheduler and applies rollback to the given policy. A zero
+// RollbackPolicy disables automatic rollback.
+func NewVersionManager(scheduler *InvocationScheduler, rollback RollbackPolicy) *VersionManager {
+	return &VersionManager{
+		scheduler: scheduler,
+		rollback:  rollback,
+		functions: make(map[string]*versionedFunction),
+	}
+}
+
+// qualifiedName is the name a Version of baseName is registered under
+// in the underlying InvocationScheduler.
+func qualifiedName(baseName, versionID string) string {
+	return baseName + "@" + versionID
+}
+
+// Deploy registers code as a new Version of baseName and makes it the
+// sole stable version, receiving all traffic. Any previously deployed
+// versions of baseName are discarded.
+func (vm *VersionManager) Deploy(baseName, versionID, code string) error {
+	if versionID == "" {
+		return fmt.Errorf("serverless: version id must not be empty")
+	}
+
+	vm.scheduler.RegisterFunction(NewFunction(qualifiedName(baseName, versionID), code))
+
+	vm.mu.Lock()
+	defer vm.mu.Unlock()
+	vf := &versionedFunction{
+		stable:   versionID,
+		versions: map[string]*Version{versionID: {ID: versionID, Weight: 100}},
+		stats:    map[string]*versionStats{versionID: {}},
+	}
+	vf.rebuildRoute()
+	vm.functions[baseName] = vf
+	return nil
+}
+
+// Canary deploys code as a new, non-stable Version of baseName
+// alongside the current stable version, splitting traffic so weight
+// percent of invocations go to the canary and the rest keep going to
+// stable. weight is a percentage in [0, 100].
+func (vm *VersionManager) Canary(baseName, versionID, code string, weight int) error {
+	if versionID == "" {
+		return fmt.Errorf("serverless: version id must not be empty")
+	}
+	if weight < 0 || weight > 100 {
+		return fmt.Errorf("serverless: canary weight must be between 0 and 100, got %d", weight)
+	}
+
+	vm.mu.Lock()
+	vf, ok := vm.functions[baseName]
+	vm.mu.Unlock()
+	if !ok {
+		return fmt.Errorf("serverless: function %q has no deployed version", baseName)
+	}
+
+	vm.scheduler.RegisterFunction(NewFunction(qualifiedName(baseName, versionID), code))
+
+	vm.mu.Lock()
+	defer vm.mu.Unlock()
+	vf.versions[versionID] = &Version{ID: versionID, Weight: weight}
+	vf.versions[vf.stable] = &Version{ID: vf.stable, Weight: 100 - weight}
+	vf.stats[versionID] = &versionStats{}
+	vf.rebuildRoute()
+	return nil
+}
+
+// rebuildRoute expands vf.versions into a weighted-round-robin
+// routing table: each version ID appears Weight times, so Invoke can
+// pick the next version with a simple incrementing index rather than
+// weighted random sampling, keeping routing decisions reproducible.
+// Callers must hold the VersionManager's lock.
+func (vf *versionedFunction) rebuildRoute() {
+	route := make([]string, 0, len(vf.versions))
+	for id, v := range vf.versions {
+		if v.Weight <= 0 {
+			continue
+		}
+		for i := 0; i < v.Weight; i++ {
+			route = append(route, id)
+		}
+	}
+	if len(route) == 0 {
+		route = append(route, vf.stable)
+	}
+	vf.route = route
+	vf.next = 0
+}
+
+// Invoke routes one invocation of baseName to a version chosen by the
+// configured traffic split, runs it, records the outcome against that
+// version's stats, and rolls back to stable if the outcome pushes a
+// non-stable version's error rate over the RollbackPolicy threshold.
+func (vm *VersionManager) Invoke(baseName string, payload map[string]string) (*Invocation, string, error) {
+	vm.mu.Lock()
+	vf, ok := vm.functions[baseName]
+	if !ok {
+		vm.mu.Unlock()
+		return nil, "", fmt.Errorf("serverless: function %q has no deployed version", baseName)
+	}
+	versionID := vf.route[vf.next%len(vf.route)]
+	vf.next++
+	vm.mu.Unlock()
+
+	inv, err := vm.scheduler.Execute(qualifiedName(baseName, versionID), payload)
+	if err != nil {
+		return nil, versionID, err
+	}
+
+	vm.recordOutcome(baseName, vf, versionID, inv.Status == StatusFailed)
+	return inv, versionID, nil
+}
+
+// recordOutcome updates versionID's stats and, if it's not the stable
+// version and its error rate now exceeds the RollbackPolicy threshold,
+// rolls all traffic back to stable.
+func (vm *VersionManager) recordOutcome(baseName string, vf *versionedFunction, versionID string, failed bool) {
+	vm.mu.Lock()
+	defer vm.mu.Unlock()
+
+	st := vf.stats[versionID]
+	if st == nil {
+		st = &versionStats{}
+		vf.stats[versionID] = st
+	}
+	st.total++
+	if failed {
+		st.failures++
+	}
+
+	if versionID == vf.stable || vm.rollback.MinSamples <= 0 || st.total < vm.rollback.MinSamples {
+		return
+	}
+	if float64(st.failures)/float64(st.total) > vm.rollback.ErrorRateThreshold {
+		vf.versions = map[string]*Version{vf.stable: {ID: vf.stable, Weight: 100}}
+		vf.rebuildRoute()
+	}
+}
+
+// Routes reports baseName's current traffic split as version ID to
+// weight percent, for inspection by a CLI or dashboard.
+func (vm *VersionManager) Routes(baseName string) (map[string]int, error) {
+	vm.mu.Lock()
+	defer vm.mu.Unlock()
+	vf, ok := vm.functions[baseName]
+	if !ok {
+		return nil, fmt.Errorf("serverless: function %q has no deployed version", baseName)
+	}
+	routes := make(map[string]int, len(vf.versions))
+	for id, v := range vf.versions {
+		routes[id] = v.Weight
+	}
+	return routes, nil
+}
+
+// Stable reports baseName's current stable version ID.
+func (vm *VersionManager) Stable(baseName string) (string, error) {
+	vm.mu.Lock()
+	defer vm.mu.Unlock()
+	vf, ok := vm.functions[baseName]
+	if !ok {
+		return "", fmt.Errorf("serverless: function %q has no deployed version", baseName)
+	}
+	return vf.stable, nil
+}
@@ -0,0 +1,113 @@
+package serverless
+
+import "testing"
+
+func TestInvokeRoutesAllTrafficToStableAfterDeploy(t *testing.T) {
+	s := NewInvocationScheduler()
+	vm := NewVersionManager(s, RollbackPolicy{})
+
+	if err := vm.Deploy("greet", "v1", `Println "hello"`); err != nil {
+		t.Fatalf("Deploy() error = %v", err)
+	}
+
+	for i := 0; i < 5; i++ {
+		_, versionID, err := vm.Invoke("greet", nil)
+		if err != nil {
+			t.Fatalf("Invoke() error = %v", err)
+		}
+		if versionID != "v1" {
+			t.Errorf("Invoke() versionID = %q, want %q", versionID, "v1")
+		}
+	}
+}
+
+func TestCanarySplitsTrafficByWeight(t *testing.T) {
+	s := NewInvocationScheduler()
+	vm := NewVersionManager(s, RollbackPolicy{})
+
+	if err := vm.Deploy("greet", "v1", `Println "hello"`); err != nil {
+		t.Fatalf("Deploy() error = %v", err)
+	}
+	if err := vm.Canary("greet", "v2", `Println "hello canary"`, 10); err != nil {
+		t.Fatalf("Canary() error = %v", err)
+	}
+
+	counts := map[string]int{}
+	for i := 0; i < 100; i++ {
+		_, versionID, err := vm.Invoke("greet", nil)
+		if err != nil {
+			t.Fatalf("Invoke() error = %v", err)
+		}
+		counts[versionID]++
+	}
+
+	if counts["v2"] != 10 {
+		t.Errorf("canary share = %d, want 10 out of 100", counts["v2"])
+	}
+	if counts["v1"] != 90 {
+		t.Errorf("stable share = %d, want 90 out of 100", counts["v1"])
+	}
+}
+
+func TestCanaryRejectsWeightOutOfRange(t *testing.T) {
+	s := NewInvocationScheduler()
+	vm := NewVersionManager(s, RollbackPolicy{})
+	if err := vm.Deploy("greet", "v1", `Println "hello"`); err != nil {
+		t.Fatalf("Deploy() error = %v", err)
+	}
+	if err := vm.Canary("greet", "v2", `Println "hello"`, 101); err == nil {
+		t.Fatal("Canary() error = nil, want error for weight > 100")
+	}
+}
+
+func TestAutomaticRollbackOnErrorRateSpike(t *testing.T) {
+	s := NewInvocationScheduler()
+	vm := NewVersionManager(s, RollbackPolicy{MinSamples: 3, ErrorRateThreshold: 0.5})
+
+	if err := vm.Deploy("greet", "v1", `Println "hello"`); err != nil {
+		t.Fatalf("Deploy() error = %v", err)
+	}
+	if err := vm.Canary("greet", "bad", `Println "hello"`, 100); err != nil {
+		t.Fatalf("Canary() error = %v", err)
+	}
+
+	vm.mu.Lock()
+	vf := vm.functions["greet"]
+	vm.mu.Unlock()
+
+	// Drive recordOutcome directly with synthetic failures rather than
+	// through Invoke: the Shode execution engine currently has no way
+	// to make a script's top-level result come back unsuccessful, so
+	// this exercises the rollback bookkeeping the way a real error-rate
+	// spike would, without depending on that engine behavior.
+	for i := 0; i < 3; i++ {
+		vm.recordOutcome("greet", vf, "bad", true)
+	}
+
+	routes, err := vm.Routes("greet")
+	if err != nil {
+		t.Fatalf("Routes() error = %v", err)
+	}
+	if len(routes) != 1 {
+		t.Fatalf("Routes() after rollback = %v, want only the stable version", routes)
+	}
+	if _, ok := routes["v1"]; !ok {
+		t.Errorf("Routes() after rollback = %v, want stable version %q present", routes, "v1")
+	}
+
+	_, versionID, err := vm.Invoke("greet", nil)
+	if err != nil {
+		t.Fatalf("Invoke() after rollback error = %v", err)
+	}
+	if versionID != "v1" {
+		t.Errorf("Invoke() after rollback versionID = %q, want %q", versionID, "v1")
+	}
+}
+
+func TestInvokeUnknownFunctionReturnsError(t *testing.T) {
+	s := NewInvocationScheduler()
+	vm := NewVersionManager(s, RollbackPolicy{})
+	if _, _, err := vm.Invoke("missing", nil); err == nil {
+		t.Fatal("Invoke() error = nil, want error for undeployed function")
+	}
+}
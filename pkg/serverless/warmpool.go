@@ -0,0 +1,170 @@
+package serverless
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"gitee.com/com_818cloud/shode/pkg/engine"
+	"gitee.com/com_818cloud/shode/pkg/environment"
+	"gitee.com/com_818cloud/shode/pkg/module"
+	"gitee.com/com_818cloud/shode/pkg/parser"
+	"gitee.com/com_818cloud/shode/pkg/sandbox"
+	"gitee.com/com_818cloud/shode/pkg/stdlib"
+	"gitee.com/com_818cloud/shode/pkg/types"
+)
+
+// warmInstance is a fully parsed and constructed execution stack for a
+// Function, ready to run without paying parse or setup cost. It's
+// single-use: once acquired, it's consumed and the pool replenishes
+// itself with a freshly built instance rather than resetting and
+// reusing this one, so stale environment state from a previous
+// invocation never leaks into the next.
+type warmInstance struct {
+	script     *types.ScriptNode
+	envManager *environment.EnvironmentManager
+	engine     *engine.ExecutionEngine
+}
+
+// buildWarmInstance parses fn's code and constructs a fresh execution
+// engine for it, mirroring the setup InvocationScheduler.execute does
+// on a cold start.
+func buildWarmInstance(fn *Function) (*warmInstance, error) {
+	script, err := parser.NewSimpleParser().ParseString(fn.Code)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse function %q: %w", fn.Name, err)
+	}
+
+	envManager := environment.NewEnvironmentManager()
+	stdLib := stdlib.New()
+	moduleMgr := module.NewModuleManager()
+	security := sandbox.NewSecurityChecker()
+
+	return &warmInstance{
+		script:     script,
+		envManager: envManager,
+		engine:     engine.NewExecutionEngine(envManager, stdLib, moduleMgr, security),
+	}, nil
+}
+
+// WarmPool holds pre-built warmInstances for one Function so an
+// invocation can skip parsing and engine construction. Instances are
+// single-use: acquiring one triggers a background rebuild to keep the
+// pool at its target size.
+type WarmPool struct {
+	fn     *Function
+	target int
+
+	mu        sync.Mutex
+	instances []*warmInstance
+}
+
+// NewWarmPool builds target warmInstances for fn up front and returns
+// the pool holding them. A size of 0 or less is rejected, since an
+// empty pool would never be anything but a cold path.
+func NewWarmPool(fn *Function, target int) (*WarmPool, error) {
+	if target <= 0 {
+		return nil, fmt.Errorf("serverless: warm pool size must be positive, got %d", target)
+	}
+
+	p := &WarmPool{fn: fn, target: target}
+	for i := 0; i < target; i++ {
+		inst, err := buildWarmInstance(fn)
+		if err != nil {
+			return nil, err
+		}
+		p.instances = append(p.instances, inst)
+	}
+	return p, nil
+}
+
+// acquire removes and returns one warmInstance, or reports false if
+// the pool is momentarily empty (a cold start is needed).
+func (p *WarmPool) acquire() (*warmInstance, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if len(p.instances) == 0 {
+		return nil, false
+	}
+	n := len(p.instances) - 1
+	inst := p.instances[n]
+	p.instances = p.instances[:n]
+	return inst, true
+}
+
+// replenish tops the pool back up to its target size in the
+// background, so the invocation that just acquired an instance isn't
+// held up rebuilding it.
+func (p *WarmPool) replenish() {
+	go func() {
+		p.mu.Lock()
+		need := p.target - len(p.instances)
+		p.mu.Unlock()
+
+		for i := 0; i < need; i++ {
+			inst, err := buildWarmInstance(p.fn)
+			if err != nil {
+				return
+			}
+			p.mu.Lock()
+			p.instances = append(p.instances, inst)
+			p.mu.Unlock()
+		}
+	}()
+}
+
+// Size reports how many warmInstances are currently available.
+func (p *WarmPool) Size() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.instances)
+}
+
+// Prewarm provisions a WarmPool of size warm runtime instances for the
+// named Function, so future invocations can skip parsing and engine
+// setup. It replaces any existing pool for the same function.
+func (s *InvocationScheduler) Prewarm(functionName string, size int) error {
+	s.mu.Lock()
+	fn, ok := s.functions[functionName]
+	s.mu.Unlock()
+	if !ok {
+		return fmt.Errorf("serverless: function %q is not registered", functionName)
+	}
+
+	pool, err := NewWarmPool(fn, size)
+	if err != nil {
+		return err
+	}
+
+	s.poolMu.Lock()
+	if s.pools == nil {
+		s.pools = make(map[string]*WarmPool)
+	}
+	s.pools[functionName] = pool
+	s.poolMu.Unlock()
+	return nil
+}
+
+// StartCounts reports how many invocations have been served from a
+// warm pool versus built from a cold start, across all Functions.
+func (s *InvocationScheduler) StartCounts() (cold, warm int64) {
+	return atomic.LoadInt64(&s.coldStarts), atomic.LoadInt64(&s.warmStarts)
+}
+
+// acquireWarm returns a warmInstance for functionName if a WarmPool
+// exists and has one available, triggering a background replenish.
+func (s *InvocationScheduler) acquireWarm(functionName string) (*warmInstance, bool) {
+	s.poolMu.Lock()
+	pool := s.pools[functionName]
+	s.poolMu.Unlock()
+	if pool == nil {
+		return nil, false
+	}
+
+	inst, ok := pool.acquire()
+	if !ok {
+		return nil, false
+	}
+	pool.replenish()
+	return inst, true
+}
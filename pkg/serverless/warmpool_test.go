@@ -0,0 +1,80 @@
+package serverless
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewWarmPoolRejectsNonPositiveSize(t *testing.T) {
+	fn := NewFunction("f", `true`)
+	if _, err := NewWarmPool(fn, 0); err == nil {
+		t.Fatal("NewWarmPool() error = nil, want error for size 0")
+	}
+}
+
+func TestPrewarmUnregisteredFunctionReturnsError(t *testing.T) {
+	s := NewInvocationScheduler()
+	if err := s.Prewarm("missing", 2); err == nil {
+		t.Fatal("Prewarm() error = nil, want error for unregistered function")
+	}
+}
+
+func TestExecuteServesFromWarmPoolAndReplenishes(t *testing.T) {
+	s := NewInvocationScheduler()
+	s.RegisterFunction(NewFunction("greet", `Println "hello $name"`))
+
+	if err := s.Prewarm("greet", 1); err != nil {
+		t.Fatalf("Prewarm() error = %v", err)
+	}
+
+	inv, err := s.Execute("greet", map[string]string{"name": "world"})
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if inv.Status != StatusSucceeded {
+		t.Fatalf("Status = %q, want %q (error: %s)", inv.Status, StatusSucceeded, inv.Error)
+	}
+
+	cold, warm := s.StartCounts()
+	if warm != 1 {
+		t.Errorf("StartCounts() warm = %d, want 1", warm)
+	}
+	if cold != 0 {
+		t.Errorf("StartCounts() cold = %d, want 0", cold)
+	}
+
+	// The pool started with exactly one instance; acquiring it should
+	// trigger a background replenish back up to the target size.
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		s.poolMu.Lock()
+		size := s.pools["greet"].Size()
+		s.poolMu.Unlock()
+		if size == 1 {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Error("warm pool was not replenished back to its target size in time")
+}
+
+func TestExecuteFallsBackToColdStartWhenPoolEmpty(t *testing.T) {
+	s := NewInvocationScheduler()
+	s.RegisterFunction(NewFunction("greet", `true`))
+
+	inv, err := s.Execute("greet", nil)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if inv.Status != StatusSucceeded {
+		t.Fatalf("Status = %q, want %q (error: %s)", inv.Status, StatusSucceeded, inv.Error)
+	}
+
+	cold, warm := s.StartCounts()
+	if cold != 1 {
+		t.Errorf("StartCounts() cold = %d, want 1", cold)
+	}
+	if warm != 0 {
+		t.Errorf("StartCounts() warm = %d, want 0", warm)
+	}
+}
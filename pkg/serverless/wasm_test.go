@@ -0,0 +1,83 @@
+package serverless
+
+import (
+	"context"
+	"testing"
+)
+
+// helloWasm is a minimal WASI module, equivalent to:
+//
+//	(module
+//	  (import "wasi_snapshot_preview1" "proc_exit" (func $proc_exit (param i32)))
+//	  (func (export "_start") (call $proc_exit (i32.const 0))))
+//
+// It's enough to exercise compilation, instantiation, and a clean exit
+// without needing a real toolchain in this test.
+var helloWasm = []byte{
+	0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00,
+	0x01, 0x08, 0x02, 0x60, 0x01, 0x7f, 0x00, 0x60, 0x00, 0x00,
+	0x02, 0x24, 0x01, 0x16, 0x77, 0x61, 0x73, 0x69, 0x5f, 0x73, 0x6e, 0x61,
+	0x70, 0x73, 0x68, 0x6f, 0x74, 0x5f, 0x70, 0x72, 0x65, 0x76, 0x69, 0x65,
+	0x77, 0x31, 0x09, 0x70, 0x72, 0x6f, 0x63, 0x5f, 0x65, 0x78, 0x69, 0x74,
+	0x00, 0x00,
+	0x03, 0x02, 0x01, 0x01,
+	0x07, 0x0a, 0x01, 0x06, 0x5f, 0x73, 0x74, 0x61, 0x72, 0x74, 0x00, 0x01,
+	0x0a, 0x08, 0x01, 0x06, 0x00, 0x41, 0x00, 0x10, 0x00, 0x0b,
+}
+
+func TestRuntimeManagerInvokeRunsWasmModule(t *testing.T) {
+	ctx := context.Background()
+	rm, err := NewRuntimeManager(ctx)
+	if err != nil {
+		t.Fatalf("NewRuntimeManager() error = %v", err)
+	}
+	defer rm.Close(ctx)
+
+	fn := NewWasmFunction("hello", helloWasm)
+	_, exitCode, err := rm.Invoke(ctx, fn, nil)
+	if err != nil {
+		t.Fatalf("Invoke() error = %v", err)
+	}
+	if exitCode != 0 {
+		t.Errorf("exitCode = %d, want 0", exitCode)
+	}
+}
+
+func TestRuntimeManagerCachesCompiledModule(t *testing.T) {
+	ctx := context.Background()
+	rm, err := NewRuntimeManager(ctx)
+	if err != nil {
+		t.Fatalf("NewRuntimeManager() error = %v", err)
+	}
+	defer rm.Close(ctx)
+
+	fn := NewWasmFunction("hello", helloWasm)
+	if _, err := rm.compiled(ctx, fn); err != nil {
+		t.Fatalf("compiled() error = %v", err)
+	}
+	if len(rm.modules) != 1 {
+		t.Fatalf("modules cached = %d, want 1", len(rm.modules))
+	}
+	if _, err := rm.compiled(ctx, fn); err != nil {
+		t.Fatalf("compiled() (second call) error = %v", err)
+	}
+	if len(rm.modules) != 1 {
+		t.Errorf("modules cached after repeat compiled() = %d, want still 1", len(rm.modules))
+	}
+}
+
+func TestExecuteRunsWasmFunction(t *testing.T) {
+	s := NewInvocationScheduler()
+	s.RegisterFunction(NewWasmFunction("hello", helloWasm))
+
+	inv, err := s.Execute("hello", nil)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if inv.Status != StatusSucceeded {
+		t.Errorf("Status = %v, want %v (error: %s)", inv.Status, StatusSucceeded, inv.Error)
+	}
+	if inv.ExitCode != 0 {
+		t.Errorf("ExitCode = %d, want 0", inv.ExitCode)
+	}
+}
@@ -0,0 +1,39 @@
+package service
+
+import "fmt"
+
+// LaunchdLabel is the reverse-DNS-style label ("com.shode.<name>")
+// launchd identifies the generated agent by.
+func LaunchdLabel(name string) string {
+	return "com.shode." + name
+}
+
+// GenerateLaunchdPlist renders a launchd agent plist that runs
+// opts's script under the shode runtime, mirroring
+// GenerateSystemdUnit's restart-on-failure supervision via KeepAlive.
+func GenerateLaunchdPlist(opts Options) string {
+	return fmt.Sprintf(`<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
+<plist version="1.0">
+<dict>
+	<key>Label</key>
+	<string>%s</string>
+	<key>ProgramArguments</key>
+	<array>
+		<string>%s</string>
+		<string>run</string>
+		<string>%s</string>
+	</array>
+	<key>WorkingDirectory</key>
+	<string>%s</string>
+	<key>KeepAlive</key>
+	<dict>
+		<key>SuccessfulExit</key>
+		<false/>
+	</dict>
+	<key>RunAtLoad</key>
+	<true/>
+</dict>
+</plist>
+`, LaunchdLabel(opts.Name), opts.ShodeBinary, opts.ScriptPath, opts.WorkingDir)
+}
@@ -0,0 +1,155 @@
+package service
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+)
+
+// systemdUnitDir returns the directory systemd user units are
+// installed to, creating it if missing.
+func systemdUnitDir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to determine home directory: %w", err)
+	}
+	dir := filepath.Join(home, ".config", "systemd", "user")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create systemd user unit directory: %w", err)
+	}
+	return dir, nil
+}
+
+// launchAgentsDir returns the directory launchd agent plists are
+// installed to, creating it if missing.
+func launchAgentsDir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to determine home directory: %w", err)
+	}
+	dir := filepath.Join(home, "Library", "LaunchAgents")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create LaunchAgents directory: %w", err)
+	}
+	return dir, nil
+}
+
+// WriteSystemdUnit renders opts's systemd unit and writes it to the
+// user unit directory, returning the path written. It does not talk
+// to systemctl; Install does that on top of this.
+func WriteSystemdUnit(opts Options) (string, error) {
+	dir, err := systemdUnitDir()
+	if err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, SystemdUnitName(opts.Name))
+	if err := os.WriteFile(path, []byte(GenerateSystemdUnit(opts)), 0644); err != nil {
+		return "", fmt.Errorf("failed to write systemd unit: %w", err)
+	}
+	return path, nil
+}
+
+// WriteLaunchdPlist renders opts's launchd plist and writes it to
+// ~/Library/LaunchAgents, returning the path written. It does not
+// talk to launchctl; Install does that on top of this.
+func WriteLaunchdPlist(opts Options) (string, error) {
+	dir, err := launchAgentsDir()
+	if err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, LaunchdLabel(opts.Name)+".plist")
+	if err := os.WriteFile(path, []byte(GenerateLaunchdPlist(opts)), 0644); err != nil {
+		return "", fmt.Errorf("failed to write launchd plist: %w", err)
+	}
+	return path, nil
+}
+
+// Install writes the service definition for opts appropriate to the
+// running OS and registers it with the service manager so it starts
+// now and on future logins, returning the path written.
+func Install(opts Options) (string, error) {
+	if runtime.GOOS == "darwin" {
+		path, err := WriteLaunchdPlist(opts)
+		if err != nil {
+			return "", err
+		}
+		if err := exec.Command("launchctl", "load", "-w", path).Run(); err != nil {
+			return path, fmt.Errorf("wrote %s but launchctl load failed: %w", path, err)
+		}
+		return path, nil
+	}
+
+	path, err := WriteSystemdUnit(opts)
+	if err != nil {
+		return "", err
+	}
+	if err := exec.Command("systemctl", "--user", "daemon-reload").Run(); err != nil {
+		return path, fmt.Errorf("wrote %s but systemctl --user daemon-reload failed: %w", path, err)
+	}
+	if err := exec.Command("systemctl", "--user", "enable", "--now", SystemdUnitName(opts.Name)).Run(); err != nil {
+		return path, fmt.Errorf("wrote %s but systemctl --user enable --now failed: %w", path, err)
+	}
+	return path, nil
+}
+
+// Status reports whether the named service is currently registered
+// and running, shelling out to launchctl or systemctl depending on
+// the platform.
+func Status(name string) (string, error) {
+	if runtime.GOOS == "darwin" {
+		out, err := exec.Command("launchctl", "list", LaunchdLabel(name)).CombinedOutput()
+		if err != nil {
+			return string(out), fmt.Errorf("service %q is not loaded: %w", name, err)
+		}
+		return string(out), nil
+	}
+
+	out, err := exec.Command("systemctl", "--user", "status", SystemdUnitName(name)).CombinedOutput()
+	if err != nil && len(out) == 0 {
+		return "", fmt.Errorf("failed to query service %q: %w", name, err)
+	}
+	return string(out), err
+}
+
+// Uninstall stops and removes the named service's definition. A
+// service manager that's already stopped it, or isn't running at
+// all, is not an error - only a failure to remove the unit file is.
+func Uninstall(name string) error {
+	if runtime.GOOS == "darwin" {
+		return uninstallLaunchd(name)
+	}
+	return uninstallSystemd(name)
+}
+
+func uninstallSystemd(name string) error {
+	unitName := SystemdUnitName(name)
+	exec.Command("systemctl", "--user", "disable", "--now", unitName).Run()
+
+	dir, err := systemdUnitDir()
+	if err != nil {
+		return err
+	}
+	path := filepath.Join(dir, unitName)
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove %s: %w", path, err)
+	}
+
+	exec.Command("systemctl", "--user", "daemon-reload").Run()
+	return nil
+}
+
+func uninstallLaunchd(name string) error {
+	dir, err := launchAgentsDir()
+	if err != nil {
+		return err
+	}
+	path := filepath.Join(dir, LaunchdLabel(name)+".plist")
+	exec.Command("launchctl", "unload", path).Run()
+
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove %s: %w", path, err)
+	}
+	return nil
+}
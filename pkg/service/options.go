@@ -0,0 +1,58 @@
+// Package service generates and installs OS service definitions - a
+// systemd user unit on Linux, a launchd agent plist on macOS - that
+// run a shode script under the shode runtime, so a long-running or
+// restart-on-boot script gets the same supervision any other system
+// service does without the user hand-writing unit files.
+package service
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Options describes the service to generate: which script to run,
+// under which shode binary, and how to label the result.
+type Options struct {
+	Name        string // service/unit name, e.g. "myscript"
+	Description string
+	ShodeBinary string // absolute path to the shode binary that will run the script
+	ScriptPath  string // absolute path to the script file
+	WorkingDir  string // directory the script runs from, so its shode.toml (and [sandbox] policy) applies
+}
+
+// ResolveOptions fills in Options from scriptPath, defaulting name to
+// the script's base file name (without extension) and the working
+// directory to the script's own directory, so the generated service
+// picks up the nearest shode.toml the same way "shode run" does.
+func ResolveOptions(scriptPath, name, description string) (Options, error) {
+	absScript, err := filepath.Abs(scriptPath)
+	if err != nil {
+		return Options{}, fmt.Errorf("failed to resolve script path: %w", err)
+	}
+	if _, err := os.Stat(absScript); err != nil {
+		return Options{}, fmt.Errorf("script file not found: %s", absScript)
+	}
+
+	shodeBinary, err := os.Executable()
+	if err != nil {
+		return Options{}, fmt.Errorf("failed to resolve shode binary path: %w", err)
+	}
+
+	if name == "" {
+		base := filepath.Base(absScript)
+		name = strings.TrimSuffix(base, filepath.Ext(base))
+	}
+	if description == "" {
+		description = fmt.Sprintf("Shode script: %s", filepath.Base(absScript))
+	}
+
+	return Options{
+		Name:        name,
+		Description: description,
+		ShodeBinary: shodeBinary,
+		ScriptPath:  absScript,
+		WorkingDir:  filepath.Dir(absScript),
+	}, nil
+}
@@ -0,0 +1,131 @@
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestResolveOptionsDefaultsNameAndWorkingDir(t *testing.T) {
+	dir := t.TempDir()
+	scriptPath := filepath.Join(dir, "myscript.sh")
+	if err := os.WriteFile(scriptPath, []byte("echo hi\n"), 0644); err != nil {
+		t.Fatalf("failed to write script: %v", err)
+	}
+
+	opts, err := ResolveOptions(scriptPath, "", "")
+	if err != nil {
+		t.Fatalf("ResolveOptions returned error: %v", err)
+	}
+	if opts.Name != "myscript" {
+		t.Errorf("expected name %q, got %q", "myscript", opts.Name)
+	}
+	if opts.WorkingDir != dir {
+		t.Errorf("expected working dir %q, got %q", dir, opts.WorkingDir)
+	}
+	if opts.ScriptPath != scriptPath {
+		t.Errorf("expected script path %q, got %q", scriptPath, opts.ScriptPath)
+	}
+	if !strings.Contains(opts.Description, "myscript.sh") {
+		t.Errorf("expected default description to mention the script, got %q", opts.Description)
+	}
+}
+
+func TestResolveOptionsRejectsMissingScript(t *testing.T) {
+	if _, err := ResolveOptions(filepath.Join(t.TempDir(), "missing.sh"), "", ""); err == nil {
+		t.Fatal("expected an error for a missing script file")
+	}
+}
+
+func TestGenerateSystemdUnitIncludesRunCommand(t *testing.T) {
+	opts := Options{
+		Name:        "myscript",
+		Description: "Shode script: myscript.sh",
+		ShodeBinary: "/usr/local/bin/shode",
+		ScriptPath:  "/home/dev/project/myscript.sh",
+		WorkingDir:  "/home/dev/project",
+	}
+
+	unit := GenerateSystemdUnit(opts)
+	for _, want := range []string{
+		"Description=Shode script: myscript.sh",
+		"WorkingDirectory=/home/dev/project",
+		"ExecStart=/usr/local/bin/shode run /home/dev/project/myscript.sh",
+		"Restart=on-failure",
+	} {
+		if !strings.Contains(unit, want) {
+			t.Errorf("expected unit to contain %q, got:\n%s", want, unit)
+		}
+	}
+}
+
+func TestGenerateLaunchdPlistIncludesProgramArguments(t *testing.T) {
+	opts := Options{
+		Name:        "myscript",
+		ShodeBinary: "/usr/local/bin/shode",
+		ScriptPath:  "/Users/dev/project/myscript.sh",
+		WorkingDir:  "/Users/dev/project",
+	}
+
+	plist := GenerateLaunchdPlist(opts)
+	for _, want := range []string{
+		"<string>com.shode.myscript</string>",
+		"<string>/usr/local/bin/shode</string>",
+		"<string>run</string>",
+		"<string>/Users/dev/project/myscript.sh</string>",
+		"<string>/Users/dev/project</string>",
+	} {
+		if !strings.Contains(plist, want) {
+			t.Errorf("expected plist to contain %q, got:\n%s", want, plist)
+		}
+	}
+}
+
+func TestWriteSystemdUnitWritesToUserUnitDir(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	opts := Options{
+		Name:        "myscript",
+		Description: "test service",
+		ShodeBinary: "/usr/local/bin/shode",
+		ScriptPath:  "/home/dev/myscript.sh",
+		WorkingDir:  "/home/dev",
+	}
+
+	path, err := WriteSystemdUnit(opts)
+	if err != nil {
+		t.Fatalf("WriteSystemdUnit returned error: %v", err)
+	}
+	wantPath := filepath.Join(home, ".config", "systemd", "user", "myscript.service")
+	if path != wantPath {
+		t.Errorf("expected path %q, got %q", wantPath, path)
+	}
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read written unit: %v", err)
+	}
+	if !strings.Contains(string(contents), "test service") {
+		t.Errorf("written unit missing description: %s", contents)
+	}
+}
+
+func TestUninstallSystemdRemovesUnitFileEvenWithoutSystemctl(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("PATH", "")
+
+	opts := Options{Name: "myscript", ShodeBinary: "/usr/local/bin/shode", ScriptPath: "/home/dev/myscript.sh", WorkingDir: "/home/dev"}
+	path, err := WriteSystemdUnit(opts)
+	if err != nil {
+		t.Fatalf("WriteSystemdUnit returned error: %v", err)
+	}
+
+	if err := uninstallSystemd("myscript"); err != nil {
+		t.Fatalf("uninstallSystemd returned error: %v", err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("expected unit file to be removed, stat error: %v", err)
+	}
+}
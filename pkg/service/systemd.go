@@ -0,0 +1,30 @@
+package service
+
+import "fmt"
+
+// SystemdUnitName is the file name ("<name>.service") a systemd unit
+// generated for name would be installed as.
+func SystemdUnitName(name string) string {
+	return name + ".service"
+}
+
+// GenerateSystemdUnit renders a systemd user unit that runs opts's
+// script under the shode runtime, restarting it on failure - the
+// same supervision a long-running script would otherwise need a
+// process manager for.
+func GenerateSystemdUnit(opts Options) string {
+	return fmt.Sprintf(`[Unit]
+Description=%s
+After=network.target
+
+[Service]
+Type=simple
+WorkingDirectory=%s
+ExecStart=%s run %s
+Restart=on-failure
+RestartSec=5
+
+[Install]
+WantedBy=default.target
+`, opts.Description, opts.WorkingDir, opts.ShodeBinary, opts.ScriptPath)
+}
@@ -0,0 +1,171 @@
+// Package ssh wraps golang.org/x/crypto/ssh with the handful of
+// operations the remote execution builtins and the `shode remote`
+// command need: dial a host, run a command, or pipe a whole script to
+// a remote shell. Unlike pkg/docker, pkg/k8s, and pkg/mongo - which
+// hand-roll REST/wire clients because their upstream SDKs aren't
+// fetchable under this project's vendoring policy - x/crypto/ssh is
+// already a direct dependency (see pkg/security's use of
+// golang.org/x/crypto/bcrypt), so it's used directly here instead of
+// reimplementing the SSH transport.
+package ssh
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+// Config describes how to reach and authenticate to a single host.
+type Config struct {
+	Host           string
+	Port           int // defaults to 22
+	User           string
+	Password       string        // used when set
+	KeyFile        string        // path to a private key, used when set (combinable with Password)
+	KnownHostsFile string        // path to a known_hosts file; empty falls back to accepting any host key, logged by the caller as a trust-on-first-use tradeoff
+	Timeout        time.Duration // defaults to 10s
+}
+
+// Client is a connected SSH session factory for a single host.
+type Client struct {
+	conn *ssh.Client
+}
+
+// Dial connects and authenticates to cfg.Host, trying the private key
+// first (if KeyFile is set) and then the password (if Password is
+// set). At least one of the two must be set.
+func Dial(cfg Config) (*Client, error) {
+	var auths []ssh.AuthMethod
+
+	if cfg.KeyFile != "" {
+		key, err := os.ReadFile(cfg.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read key file %q: %w", cfg.KeyFile, err)
+		}
+		signer, err := ssh.ParsePrivateKey(key)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse key file %q: %w", cfg.KeyFile, err)
+		}
+		auths = append(auths, ssh.PublicKeys(signer))
+	}
+	if cfg.Password != "" {
+		auths = append(auths, ssh.Password(cfg.Password))
+	}
+	if len(auths) == 0 {
+		return nil, fmt.Errorf("no authentication method configured: set KeyFile and/or Password")
+	}
+
+	hostKeyCallback, err := hostKeyCallback(cfg.KnownHostsFile)
+	if err != nil {
+		return nil, err
+	}
+
+	timeout := cfg.Timeout
+	if timeout == 0 {
+		timeout = 10 * time.Second
+	}
+	port := cfg.Port
+	if port == 0 {
+		port = 22
+	}
+
+	addr := net.JoinHostPort(cfg.Host, strconv.Itoa(port))
+	conn, err := ssh.Dial("tcp", addr, &ssh.ClientConfig{
+		User:            cfg.User,
+		Auth:            auths,
+		HostKeyCallback: hostKeyCallback,
+		Timeout:         timeout,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial %s: %w", addr, err)
+	}
+	return &Client{conn: conn}, nil
+}
+
+// hostKeyCallback builds a verifying callback against knownHostsFile,
+// or accepts any host key when it's empty - a deliberate
+// trust-on-first-use tradeoff for ad-hoc fleets that don't maintain a
+// known_hosts file, same as Ansible's ansible_ssh_common_args
+// StrictHostKeyChecking=no escape hatch.
+func hostKeyCallback(knownHostsFile string) (ssh.HostKeyCallback, error) {
+	if knownHostsFile == "" {
+		return ssh.InsecureIgnoreHostKey(), nil
+	}
+	callback, err := knownhosts.New(knownHostsFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load known_hosts file %q: %w", knownHostsFile, err)
+	}
+	return callback, nil
+}
+
+// Close closes the underlying connection.
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
+
+// syncBuffer is a bytes.Buffer safe for concurrent writes. Session.Run
+// copies stdout and stderr from two separate goroutines, so pointing
+// both at a plain bytes.Buffer races and silently drops output; this
+// is the same guard os/exec.Cmd.CombinedOutput uses internally.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (w *syncBuffer) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.buf.Write(p)
+}
+
+func (w *syncBuffer) String() string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.buf.String()
+}
+
+// Run executes command on the remote host and returns its combined
+// stdout+stderr.
+func (c *Client) Run(command string) (string, error) {
+	session, err := c.conn.NewSession()
+	if err != nil {
+		return "", fmt.Errorf("failed to open session: %w", err)
+	}
+	defer session.Close()
+
+	var output syncBuffer
+	session.Stdout = &output
+	session.Stderr = &output
+	if err := session.Run(command); err != nil {
+		return output.String(), fmt.Errorf("remote command failed: %w", err)
+	}
+	return output.String(), nil
+}
+
+// RunScript pipes script's contents to "sh -s" on the remote host -
+// the same "cat script.sh | ssh host sh -s" idiom admins use to run a
+// local script remotely without copying it there first - and returns
+// its combined stdout+stderr.
+func (c *Client) RunScript(script string) (string, error) {
+	session, err := c.conn.NewSession()
+	if err != nil {
+		return "", fmt.Errorf("failed to open session: %w", err)
+	}
+	defer session.Close()
+
+	session.Stdin = bytes.NewReader([]byte(script))
+	var output syncBuffer
+	session.Stdout = &output
+	session.Stderr = &output
+	if err := session.Run("sh -s"); err != nil {
+		return output.String(), fmt.Errorf("remote script failed: %w", err)
+	}
+	return output.String(), nil
+}
@@ -0,0 +1,183 @@
+package ssh
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"fmt"
+	"net"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// startTestServer starts a minimal SSH server on 127.0.0.1 accepting
+// password auth with the given password, running every exec request
+// through /bin/sh -c locally. It returns the port to dial and a
+// shutdown func.
+func startTestServer(t *testing.T, password string) (port int, shutdown func()) {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate host key: %v", err)
+	}
+	signer, err := ssh.NewSignerFromKey(key)
+	if err != nil {
+		t.Fatalf("failed to build signer: %v", err)
+	}
+
+	config := &ssh.ServerConfig{
+		PasswordCallback: func(c ssh.ConnMetadata, pass []byte) (*ssh.Permissions, error) {
+			if string(pass) != password {
+				return nil, fmt.Errorf("wrong password")
+			}
+			return nil, nil
+		},
+	}
+	config.AddHostKey(signer)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go serveTestConn(conn, config)
+		}
+	}()
+
+	return listener.Addr().(*net.TCPAddr).Port, func() { listener.Close() }
+}
+
+func serveTestConn(conn net.Conn, config *ssh.ServerConfig) {
+	sshConn, chans, reqs, err := ssh.NewServerConn(conn, config)
+	if err != nil {
+		return
+	}
+	defer sshConn.Close()
+	go ssh.DiscardRequests(reqs)
+
+	for newChannel := range chans {
+		if newChannel.ChannelType() != "session" {
+			newChannel.Reject(ssh.UnknownChannelType, "unsupported channel type")
+			continue
+		}
+		channel, requests, err := newChannel.Accept()
+		if err != nil {
+			continue
+		}
+		go serveTestSession(channel, requests)
+	}
+}
+
+func serveTestSession(channel ssh.Channel, requests <-chan *ssh.Request) {
+	defer channel.Close()
+	for req := range requests {
+		switch req.Type {
+		case "exec":
+			command := string(req.Payload[4:])
+			if req.WantReply {
+				req.Reply(true, nil)
+			}
+			runTestCommand(channel, command)
+			return
+		default:
+			if req.WantReply {
+				req.Reply(false, nil)
+			}
+		}
+	}
+}
+
+func runTestCommand(channel ssh.Channel, command string) {
+	cmd := exec.Command("/bin/sh", "-c", command)
+	cmd.Stdin = channel
+	cmd.Stdout = channel
+	cmd.Stderr = channel.Stderr()
+	exitCode := 0
+	if err := cmd.Run(); err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			exitCode = exitErr.ExitCode()
+		} else {
+			exitCode = 1
+		}
+	}
+	channel.SendRequest("exit-status", false, ssh.Marshal(struct{ ExitStatus uint32 }{uint32(exitCode)}))
+}
+
+func TestDialAndRunExecutesRemoteCommand(t *testing.T) {
+	port, shutdown := startTestServer(t, "s3cr3t")
+	defer shutdown()
+
+	client, err := Dial(Config{Host: "127.0.0.1", Port: port, User: "tester", Password: "s3cr3t"})
+	if err != nil {
+		t.Fatalf("Dial returned error: %v", err)
+	}
+	defer client.Close()
+
+	output, err := client.Run("echo hello")
+	if err != nil {
+		t.Fatalf("Run returned error: %v", err)
+	}
+	if strings.TrimSpace(output) != "hello" {
+		t.Errorf("unexpected output: %q", output)
+	}
+}
+
+func TestDialRejectsWrongPassword(t *testing.T) {
+	port, shutdown := startTestServer(t, "s3cr3t")
+	defer shutdown()
+
+	_, err := Dial(Config{Host: "127.0.0.1", Port: port, User: "tester", Password: "wrong"})
+	if err == nil {
+		t.Fatal("expected Dial to fail with the wrong password")
+	}
+}
+
+func TestRunSurfacesNonZeroExit(t *testing.T) {
+	port, shutdown := startTestServer(t, "s3cr3t")
+	defer shutdown()
+
+	client, err := Dial(Config{Host: "127.0.0.1", Port: port, User: "tester", Password: "s3cr3t"})
+	if err != nil {
+		t.Fatalf("Dial returned error: %v", err)
+	}
+	defer client.Close()
+
+	if _, err := client.Run("exit 7"); err == nil {
+		t.Fatal("expected Run to surface a non-zero exit status as an error")
+	}
+}
+
+func TestRunScriptPipesStdin(t *testing.T) {
+	port, shutdown := startTestServer(t, "s3cr3t")
+	defer shutdown()
+
+	client, err := Dial(Config{Host: "127.0.0.1", Port: port, User: "tester", Password: "s3cr3t"})
+	if err != nil {
+		t.Fatalf("Dial returned error: %v", err)
+	}
+	defer client.Close()
+
+	output, err := client.RunScript("echo from-script")
+	if err != nil {
+		t.Fatalf("RunScript returned error: %v", err)
+	}
+	if strings.TrimSpace(output) != "from-script" {
+		t.Errorf("unexpected output: %q", output)
+	}
+}
+
+func TestDialRejectsMissingKeyFile(t *testing.T) {
+	_, err := Dial(Config{Host: "127.0.0.1", Port: 2222, User: "tester", KeyFile: "/does/not/exist"})
+	if err == nil {
+		t.Fatal("expected Dial to fail reading a missing key file")
+	}
+}
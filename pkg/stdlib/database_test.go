@@ -0,0 +1,91 @@
+package stdlib
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestConnectDBRegistersNamedConnections(t *testing.T) {
+	sl := New()
+	dir := t.TempDir()
+
+	mainDSN := filepath.Join(dir, "main.db")
+	secondaryDSN := filepath.Join(dir, "secondary.db")
+
+	if err := sl.ConnectDB("sqlite", "main", mainDSN); err != nil {
+		t.Fatalf("ConnectDB(main) error = %v", err)
+	}
+	if err := sl.ConnectDB("sqlite", "secondary", secondaryDSN); err != nil {
+		t.Fatalf("ConnectDB(secondary) error = %v", err)
+	}
+
+	if _, err := sl.ExecDBOn("main", "CREATE TABLE t (v TEXT)"); err != nil {
+		t.Fatalf("ExecDBOn(main) error = %v", err)
+	}
+	if _, err := sl.ExecDBOn("main", "INSERT INTO t (v) VALUES ('from-main')"); err != nil {
+		t.Fatalf("ExecDBOn(main) insert error = %v", err)
+	}
+	if _, err := sl.ExecDBOn("secondary", "CREATE TABLE t (v TEXT)"); err != nil {
+		t.Fatalf("ExecDBOn(secondary) error = %v", err)
+	}
+
+	rows, err := sl.QueryDBOn("main", "SELECT v FROM t")
+	if err != nil {
+		t.Fatalf("QueryDBOn(main) error = %v", err)
+	}
+	defer rows.Close()
+	if !rows.Next() {
+		t.Fatal("expected a row from the main connection")
+	}
+	var v string
+	if err := rows.Scan(&v); err != nil {
+		t.Fatalf("Scan() error = %v", err)
+	}
+	if v != "from-main" {
+		t.Errorf("main connection row = %q, want %q", v, "from-main")
+	}
+
+	secondaryRows, err := sl.QueryDBOn("secondary", "SELECT v FROM t")
+	if err != nil {
+		t.Fatalf("QueryDBOn(secondary) error = %v", err)
+	}
+	defer secondaryRows.Close()
+	if secondaryRows.Next() {
+		t.Error("secondary connection should not see main's data")
+	}
+}
+
+func TestConnectDBAppliesPoolSettings(t *testing.T) {
+	sl := New()
+	dsn := filepath.Join(t.TempDir(), "pooled.db")
+
+	if err := sl.ConnectDB("sqlite", "pooled", dsn, "--max-open", "3", "--max-idle", "1"); err != nil {
+		t.Fatalf("ConnectDB() error = %v", err)
+	}
+
+	conn, err := sl.DBManager().GetConnection("pooled")
+	if err != nil {
+		t.Fatalf("GetConnection() error = %v", err)
+	}
+	if conn.Config == nil {
+		t.Fatal("expected connection Config to be set")
+	}
+	if conn.Config.MaxOpenConns != 3 {
+		t.Errorf("MaxOpenConns = %d, want 3", conn.Config.MaxOpenConns)
+	}
+	if conn.Config.MaxIdleConns != 1 {
+		t.Errorf("MaxIdleConns = %d, want 1", conn.Config.MaxIdleConns)
+	}
+}
+
+func TestConnectDBDefaultsToDefaultConnectionName(t *testing.T) {
+	sl := New()
+	dsn := filepath.Join(t.TempDir(), "default.db")
+
+	if err := sl.ConnectDB("sqlite", dsn); err != nil {
+		t.Fatalf("ConnectDB() error = %v", err)
+	}
+	if !sl.IsDBConnected() {
+		t.Error("IsDBConnected() = false, want true after ConnectDB with no explicit name")
+	}
+}
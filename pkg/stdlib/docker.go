@@ -0,0 +1,127 @@
+package stdlib
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"gitee.com/com_818cloud/shode/pkg/docker"
+)
+
+// DockerBuild builds the directory at contextDir (optionally using a
+// Dockerfile other than the one at its root) into an image tagged
+// tag, talking to the Docker Engine API directly rather than
+// shelling out to the docker CLI. Subject to the active
+// SecurityChecker's "build" Docker policy.
+func (sl *StdLib) DockerBuild(contextDir, dockerfile, tag string) (*docker.BuildResult, error) {
+	if err := sl.checkDockerOperation("build"); err != nil {
+		return nil, err
+	}
+
+	client, err := sl.DockerClient()
+	if err != nil {
+		return nil, fmt.Errorf("DockerBuild: %w", err)
+	}
+
+	tarPath, err := sl.tarBuildContext(contextDir)
+	if err != nil {
+		return nil, fmt.Errorf("DockerBuild: %w", err)
+	}
+	defer os.Remove(tarPath)
+
+	f, err := os.Open(tarPath)
+	if err != nil {
+		return nil, fmt.Errorf("DockerBuild: %w", err)
+	}
+	defer f.Close()
+
+	result, err := client.Build(context.Background(), f, dockerfile, tag)
+	if err != nil {
+		return nil, fmt.Errorf("DockerBuild: %w", err)
+	}
+	return result, nil
+}
+
+// DockerRun creates and starts a container from image, waits for it
+// to exit, and returns its logs and exit code - equivalent to
+// `docker run` without -d. cmd and env may both be empty, in which
+// case the image's own defaults apply. Subject to the active
+// SecurityChecker's "run" Docker policy.
+func (sl *StdLib) DockerRun(image string, cmd []string, env []string) (*docker.RunResult, error) {
+	if err := sl.checkDockerOperation("run"); err != nil {
+		return nil, err
+	}
+
+	client, err := sl.DockerClient()
+	if err != nil {
+		return nil, fmt.Errorf("DockerRun: %w", err)
+	}
+
+	result, err := client.Run(context.Background(), image, cmd, env)
+	if err != nil {
+		return nil, fmt.Errorf("DockerRun: %w", err)
+	}
+	return result, nil
+}
+
+// DockerPush pushes image to its registry. username and password are
+// optional; an empty username pushes unauthenticated. Subject to the
+// active SecurityChecker's "push" Docker policy.
+func (sl *StdLib) DockerPush(image, username, password string) error {
+	if err := sl.checkDockerOperation("push"); err != nil {
+		return err
+	}
+
+	client, err := sl.DockerClient()
+	if err != nil {
+		return fmt.Errorf("DockerPush: %w", err)
+	}
+
+	var auth *docker.RegistryAuth
+	if username != "" {
+		auth = &docker.RegistryAuth{Username: username, Password: password}
+	}
+
+	if err := client.Push(context.Background(), image, auth); err != nil {
+		return fmt.Errorf("DockerPush: %w", err)
+	}
+	return nil
+}
+
+// DockerPs lists containers known to the daemon - equivalent to
+// `docker ps` (all == false) or `docker ps -a` (all == true). Subject
+// to the active SecurityChecker's "ps" Docker policy.
+func (sl *StdLib) DockerPs(all bool) ([]docker.ContainerSummary, error) {
+	if err := sl.checkDockerOperation("ps"); err != nil {
+		return nil, err
+	}
+
+	client, err := sl.DockerClient()
+	if err != nil {
+		return nil, fmt.Errorf("DockerPs: %w", err)
+	}
+
+	containers, err := client.List(context.Background(), all)
+	if err != nil {
+		return nil, fmt.Errorf("DockerPs: %w", err)
+	}
+	return containers, nil
+}
+
+// tarBuildContext packs dir into a temporary tar file using sl's
+// existing Tar builtin, returning its path for the caller to stream
+// and remove once the build request has read it.
+func (sl *StdLib) tarBuildContext(dir string) (string, error) {
+	tmp, err := os.CreateTemp("", "shode-docker-build-*.tar")
+	if err != nil {
+		return "", err
+	}
+	path := tmp.Name()
+	tmp.Close()
+
+	if err := sl.Tar(dir, path); err != nil {
+		os.Remove(path)
+		return "", err
+	}
+	return path, nil
+}
@@ -0,0 +1,212 @@
+package stdlib
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// Note: this repo has no generic secrets-management subsystem to pull
+// credentials from (see pkg/cli/build's GetGitStatus/IsGitDirty for the
+// existing precedent of shelling out to the git binary). Auth is threaded
+// through explicit username/token arguments instead. The token is never
+// embedded in a URL passed as a command-line argument - any other local
+// user could read it off `ps aux` or /proc/<pid>/cmdline for as long as
+// the git process runs - it travels through the GIT_ASKPASS mechanism
+// (see runGitWithAuth), which git only ever reads from its environment.
+
+// GitClone clones url into dest, shelling out to the git binary the
+// same way pkg/cli/build's GetVersion/GetGitStatus already do rather
+// than linking a Go git implementation. depth <= 0 clones full history;
+// a positive depth performs a shallow clone. An empty username clones
+// unauthenticated; otherwise username is embedded into url's userinfo
+// and token is supplied via GIT_ASKPASS for the duration of the clone.
+// Subject to the active SecurityChecker's "clone" Git policy.
+func (sl *StdLib) GitClone(repoURL, dest string, depth int, username, token string) error {
+	if err := sl.checkGitOperation("clone"); err != nil {
+		return err
+	}
+
+	authURL, err := gitURLWithUsername(repoURL, username)
+	if err != nil {
+		return fmt.Errorf("GitClone: %w", err)
+	}
+
+	args := []string{"clone"}
+	if depth > 0 {
+		args = append(args, "--depth", strconv.Itoa(depth))
+	}
+	args = append(args, authURL, dest)
+
+	if out, err := runGitWithAuth(token, args...); err != nil {
+		return fmt.Errorf("GitClone: %w: %s", err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// GitCheckout checks out ref (a branch, tag, or commit) in the
+// repository at repoDir. Subject to the active SecurityChecker's
+// "checkout" Git policy.
+func (sl *StdLib) GitCheckout(repoDir, ref string) error {
+	if err := sl.checkGitOperation("checkout"); err != nil {
+		return err
+	}
+
+	if out, err := exec.Command("git", "-C", repoDir, "checkout", ref).CombinedOutput(); err != nil {
+		return fmt.Errorf("GitCheckout: %w: %s", err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// GitStatus returns the repository at repoDir's short-format status -
+// equivalent to `git status --short`, empty when the working tree is
+// clean. Subject to the active SecurityChecker's "status" Git policy.
+func (sl *StdLib) GitStatus(repoDir string) (string, error) {
+	if err := sl.checkGitOperation("status"); err != nil {
+		return "", err
+	}
+
+	out, err := exec.Command("git", "-C", repoDir, "status", "--short").Output()
+	if err != nil {
+		return "", fmt.Errorf("GitStatus: %w", err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// GitTag creates an annotated tag named tag at HEAD in the repository at
+// repoDir, using message as the tag message. An empty message creates a
+// lightweight tag instead. Subject to the active SecurityChecker's
+// "tag" Git policy.
+func (sl *StdLib) GitTag(repoDir, tag, message string) error {
+	if err := sl.checkGitOperation("tag"); err != nil {
+		return err
+	}
+
+	args := []string{"-C", repoDir, "tag"}
+	if message != "" {
+		args = append(args, "-a", tag, "-m", message)
+	} else {
+		args = append(args, tag)
+	}
+
+	if out, err := exec.Command("git", args...).CombinedOutput(); err != nil {
+		return fmt.Errorf("GitTag: %w: %s", err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// GitPush pushes ref to remote (a configured remote name, e.g.
+// "origin") from the repository at repoDir. An empty username pushes
+// unauthenticated; otherwise username is embedded into the remote's
+// configured URL and token is supplied via GIT_ASKPASS for the
+// duration of the push. Subject to the active SecurityChecker's "push"
+// Git policy.
+func (sl *StdLib) GitPush(repoDir, remote, ref, username, token string) error {
+	if err := sl.checkGitOperation("push"); err != nil {
+		return err
+	}
+
+	if username == "" {
+		if out, err := exec.Command("git", "-C", repoDir, "push", remote, ref).CombinedOutput(); err != nil {
+			return fmt.Errorf("GitPush: %w: %s", err, strings.TrimSpace(string(out)))
+		}
+		return nil
+	}
+
+	remoteURL, err := exec.Command("git", "-C", repoDir, "remote", "get-url", remote).Output()
+	if err != nil {
+		return fmt.Errorf("GitPush: resolving remote %q: %w", remote, err)
+	}
+
+	authURL, err := gitURLWithUsername(strings.TrimSpace(string(remoteURL)), username)
+	if err != nil {
+		return fmt.Errorf("GitPush: %w", err)
+	}
+
+	if out, err := runGitWithAuth(token, "-C", repoDir, "push", authURL, ref); err != nil {
+		return fmt.Errorf("GitPush: %w: %s", err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// gitURLWithUsername embeds username into rawURL's userinfo, so git's
+// HTTPS basic-auth prompt already knows who is authenticating and only
+// asks GIT_ASKPASS for the password. An empty username returns rawURL
+// unchanged.
+func gitURLWithUsername(rawURL, username string) (string, error) {
+	if username == "" {
+		return rawURL, nil
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", fmt.Errorf("parsing repository URL: %w", err)
+	}
+	u.User = url.User(username)
+	return u.String(), nil
+}
+
+// gitAskpassScript is installed as GIT_ASKPASS so a clone/push's token
+// reaches git through its environment instead of argv: git invokes it
+// for the password prompt, and it simply echoes GIT_ASKPASS_TOKEN back.
+const gitAskpassScript = "#!/bin/sh\necho \"$GIT_ASKPASS_TOKEN\"\n"
+
+// runGitWithAuth runs git with args, supplying token (if non-empty) via
+// a temporary GIT_ASKPASS helper rather than embedding it in args,
+// where it would be readable by any local user via `ps aux` or
+// /proc/<pid>/cmdline for as long as the process runs.
+func runGitWithAuth(token string, args ...string) ([]byte, error) {
+	cmd := exec.Command("git", args...)
+	if token == "" {
+		return cmd.CombinedOutput()
+	}
+
+	askpass, cleanup, err := writeGitAskpassScript()
+	if err != nil {
+		return nil, err
+	}
+	defer cleanup()
+
+	cmd.Env = append(os.Environ(),
+		"GIT_ASKPASS="+askpass,
+		"GIT_ASKPASS_TOKEN="+token,
+		"GIT_TERMINAL_PROMPT=0",
+	)
+	return cmd.CombinedOutput()
+}
+
+// writeGitAskpassScript writes gitAskpassScript to a fresh temporary
+// file and makes it executable, returning its path and a cleanup func
+// that removes it. The script itself never contains the token - only
+// the GIT_ASKPASS_TOKEN env var set by runGitWithAuth does.
+func writeGitAskpassScript() (path string, cleanup func(), err error) {
+	f, err := os.CreateTemp("", "shode-git-askpass-*")
+	if err != nil {
+		return "", nil, fmt.Errorf("creating askpass helper: %w", err)
+	}
+	name := f.Name()
+	if _, err := f.WriteString(gitAskpassScript); err != nil {
+		f.Close()
+		os.Remove(name)
+		return "", nil, fmt.Errorf("writing askpass helper: %w", err)
+	}
+	f.Close()
+	if err := os.Chmod(name, 0700); err != nil {
+		os.Remove(name)
+		return "", nil, fmt.Errorf("chmod askpass helper: %w", err)
+	}
+	return name, func() { os.Remove(name) }, nil
+}
+
+// checkGitOperation consults sl.security, if one has been attached via
+// SetSecurityChecker, before a Git builtin shells out to the git
+// binary. A nil checker permits every operation.
+func (sl *StdLib) checkGitOperation(op string) error {
+	if sl.security == nil {
+		return nil
+	}
+	return sl.security.CheckGitOperation(op)
+}
@@ -0,0 +1,165 @@
+package stdlib
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// initBareRepoWithCommit creates a bare repo at dir/origin.git seeded
+// with one commit on main, mirroring the minimal fixture CI/deploy
+// scripts would clone from.
+func initBareRepoWithCommit(t *testing.T, dir string) string {
+	t.Helper()
+
+	origin := filepath.Join(dir, "origin.git")
+	run(t, dir, "git", "init", "-q", "--bare", origin)
+
+	seed := filepath.Join(dir, "seed")
+	run(t, dir, "git", "clone", "-q", origin, seed)
+	run(t, seed, "git", "config", "user.email", "t@t.com")
+	run(t, seed, "git", "config", "user.name", "t")
+	run(t, seed, "sh", "-c", "echo hello > a.txt")
+	run(t, seed, "git", "add", "-A")
+	run(t, seed, "git", "commit", "-qm", "init")
+	run(t, seed, "git", "push", "-q", "origin", "HEAD:main")
+	run(t, dir, "git", "--git-dir="+origin, "symbolic-ref", "HEAD", "refs/heads/main")
+
+	return origin
+}
+
+func run(t *testing.T, dir, name string, args ...string) {
+	t.Helper()
+	cmd := exec.Command(name, args...)
+	cmd.Dir = dir
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("%s %v: %v: %s", name, args, err, out)
+	}
+}
+
+func TestGitCloneCheckoutStatusTagPush(t *testing.T) {
+	dir := t.TempDir()
+	origin := initBareRepoWithCommit(t, dir)
+	work := filepath.Join(dir, "work")
+
+	sl := New()
+
+	if err := sl.GitClone(origin, work, 0, "", ""); err != nil {
+		t.Fatalf("GitClone returned error: %v", err)
+	}
+
+	status, err := sl.GitStatus(work)
+	if err != nil {
+		t.Fatalf("GitStatus returned error: %v", err)
+	}
+	if status != "" {
+		t.Errorf("expected a clean status right after clone, got %q", status)
+	}
+
+	if err := sl.GitCheckout(work, "main"); err != nil {
+		t.Fatalf("GitCheckout returned error: %v", err)
+	}
+
+	run(t, work, "git", "config", "user.email", "t@t.com")
+	run(t, work, "git", "config", "user.name", "t")
+
+	if err := sl.GitTag(work, "v0.0.1", "release"); err != nil {
+		t.Fatalf("GitTag returned error: %v", err)
+	}
+	tags := mustOutput(t, work, "git", "tag", "-l")
+	if !strings.Contains(tags, "v0.0.1") {
+		t.Errorf("expected tag v0.0.1 to exist, got %q", tags)
+	}
+
+	run(t, work, "sh", "-c", "echo pushed > b.txt")
+	run(t, work, "git", "add", "-A")
+	run(t, work, "git", "commit", "-qm", "second")
+
+	if err := sl.GitPush(work, "origin", "main", "", ""); err != nil {
+		t.Fatalf("GitPush returned error: %v", err)
+	}
+	log := mustOutput(t, dir, "git", "--git-dir="+origin, "log", "--oneline", "main")
+	if !strings.Contains(log, "second") {
+		t.Errorf("expected the push to update origin's main, got %q", log)
+	}
+}
+
+func mustOutput(t *testing.T, dir, name string, args ...string) string {
+	t.Helper()
+	cmd := exec.Command(name, args...)
+	cmd.Dir = dir
+	out, err := cmd.Output()
+	if err != nil {
+		t.Fatalf("%s %v: %v", name, args, err)
+	}
+	return string(out)
+}
+
+func TestGitCloneRejectsMissingSource(t *testing.T) {
+	dir := t.TempDir()
+	sl := New()
+
+	err := sl.GitClone(filepath.Join(dir, "does-not-exist"), filepath.Join(dir, "work"), 0, "", "")
+	if err == nil {
+		t.Fatal("expected an error cloning a nonexistent repository")
+	}
+}
+
+func TestGitURLWithUsernameEmbedsUsernameOnly(t *testing.T) {
+	authed, err := gitURLWithUsername("https://github.com/example/repo.git", "bot")
+	if err != nil {
+		t.Fatalf("gitURLWithUsername returned error: %v", err)
+	}
+	if authed != "https://bot@github.com/example/repo.git" {
+		t.Errorf("unexpected authed URL: %q", authed)
+	}
+}
+
+func TestGitURLWithUsernamePassesThroughWithoutUsername(t *testing.T) {
+	authed, err := gitURLWithUsername("https://github.com/example/repo.git", "")
+	if err != nil {
+		t.Fatalf("gitURLWithUsername returned error: %v", err)
+	}
+	if authed != "https://github.com/example/repo.git" {
+		t.Errorf("expected the URL unchanged, got %q", authed)
+	}
+}
+
+// TestWriteGitAskpassScriptEchoesTokenFromEnv tests that the askpass
+// helper returns whatever GIT_ASKPASS_TOKEN is set to, which is how
+// runGitWithAuth gets the token to git without ever putting it in argv.
+func TestWriteGitAskpassScriptEchoesTokenFromEnv(t *testing.T) {
+	path, cleanup, err := writeGitAskpassScript()
+	if err != nil {
+		t.Fatalf("writeGitAskpassScript returned error: %v", err)
+	}
+	defer cleanup()
+
+	cmd := exec.Command(path, "Password:")
+	cmd.Env = append(os.Environ(), "GIT_ASKPASS_TOKEN=s3cr3t-token")
+	out, err := cmd.Output()
+	if err != nil {
+		t.Fatalf("running askpass helper returned error: %v", err)
+	}
+	if got := strings.TrimSpace(string(out)); got != "s3cr3t-token" {
+		t.Errorf("askpass helper printed %q, want %q", got, "s3cr3t-token")
+	}
+}
+
+// TestRunGitWithAuthKeepsTokenOutOfArgs tests that a token passed to
+// runGitWithAuth doesn't need to appear in args for git to see it -
+// the only place it's set is the GIT_ASKPASS_TOKEN environment
+// variable, never argv, which is what keeps it out of `ps aux`/cmdline.
+func TestRunGitWithAuthKeepsTokenOutOfArgs(t *testing.T) {
+	args := []string{"version"}
+	if out, err := runGitWithAuth("s3cr3t-token", args...); err != nil {
+		t.Fatalf("runGitWithAuth returned error: %v: %s", err, out)
+	}
+	for _, a := range args {
+		if strings.Contains(a, "s3cr3t-token") {
+			t.Fatal("token leaked into the command's argument list")
+		}
+	}
+}
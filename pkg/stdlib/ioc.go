@@ -14,23 +14,23 @@ func (sl *StdLib) RegisterBean(name, scope string, factory interface{}) error {
 		beanScope = ioc.ScopeSingleton
 	}
 
-	return sl.iocContainer.RegisterBean(name, beanScope, factory)
+	return sl.IOCContainer().RegisterBean(name, beanScope, factory)
 }
 
 // GetBean retrieves a bean from the IoC container
 // Usage: GetBean "beanName"
 func (sl *StdLib) GetBean(name string) (interface{}, error) {
-	return sl.iocContainer.GetBean(name)
+	return sl.IOCContainer().GetBean(name)
 }
 
 // ContainsBean checks if a bean is registered
 // Usage: ContainsBean "beanName"
 func (sl *StdLib) ContainsBean(name string) bool {
-	return sl.iocContainer.ContainsBean(name)
+	return sl.IOCContainer().ContainsBean(name)
 }
 
 // GetBeanNames returns all registered bean names
 // Usage: GetBeanNames
 func (sl *StdLib) GetBeanNames() []string {
-	return sl.iocContainer.GetBeanNames()
+	return sl.IOCContainer().GetBeanNames()
 }
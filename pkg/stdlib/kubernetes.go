@@ -0,0 +1,95 @@
+package stdlib
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+)
+
+// K8sApply applies the manifest (a single YAML or JSON document) at
+// manifestPath to namespace, creating the resource if it doesn't yet
+// exist or merge-patching it otherwise - equivalent to
+// `kubectl apply -f` for the resource kinds pkg/k8s recognizes. Subject
+// to the active SecurityChecker's "apply" Kubernetes policy.
+func (sl *StdLib) K8sApply(namespace, manifestPath string) (map[string]interface{}, error) {
+	if err := sl.checkKubernetesOperation("apply"); err != nil {
+		return nil, err
+	}
+
+	manifest, err := os.ReadFile(manifestPath)
+	if err != nil {
+		return nil, fmt.Errorf("K8sApply: %w", err)
+	}
+
+	client, err := sl.KubernetesClient()
+	if err != nil {
+		return nil, fmt.Errorf("K8sApply: %w", err)
+	}
+
+	result, err := client.Apply(context.Background(), namespace, manifest)
+	if err != nil {
+		return nil, fmt.Errorf("K8sApply: %w", err)
+	}
+	return result, nil
+}
+
+// K8sGet fetches a single resource by kind and name - equivalent to
+// `kubectl get <kind> <name>`. Subject to the active SecurityChecker's
+// "get" Kubernetes policy.
+func (sl *StdLib) K8sGet(namespace, kind, name string) (map[string]interface{}, error) {
+	if err := sl.checkKubernetesOperation("get"); err != nil {
+		return nil, err
+	}
+
+	client, err := sl.KubernetesClient()
+	if err != nil {
+		return nil, fmt.Errorf("K8sGet: %w", err)
+	}
+
+	result, err := client.Get(context.Background(), namespace, kind, name)
+	if err != nil {
+		return nil, fmt.Errorf("K8sGet: %w", err)
+	}
+	return result, nil
+}
+
+// K8sLogs returns a pod's log output - equivalent to `kubectl logs`. An
+// empty container fetches the pod's only container's logs. Subject to
+// the active SecurityChecker's "logs" Kubernetes policy.
+func (sl *StdLib) K8sLogs(namespace, pod, container string) (string, error) {
+	if err := sl.checkKubernetesOperation("logs"); err != nil {
+		return "", err
+	}
+
+	client, err := sl.KubernetesClient()
+	if err != nil {
+		return "", fmt.Errorf("K8sLogs: %w", err)
+	}
+
+	logs, err := client.Logs(context.Background(), namespace, pod, container)
+	if err != nil {
+		return "", fmt.Errorf("K8sLogs: %w", err)
+	}
+	return logs, nil
+}
+
+// K8sWaitFor blocks until kind/name reaches condition ("Ready" or
+// "Available") or timeoutSeconds elapses - equivalent to
+// `kubectl wait --for=condition=...`. Subject to the active
+// SecurityChecker's "waitfor" Kubernetes policy.
+func (sl *StdLib) K8sWaitFor(namespace, kind, name, condition string, timeoutSeconds int) error {
+	if err := sl.checkKubernetesOperation("waitfor"); err != nil {
+		return err
+	}
+
+	client, err := sl.KubernetesClient()
+	if err != nil {
+		return fmt.Errorf("K8sWaitFor: %w", err)
+	}
+
+	if err := client.WaitFor(context.Background(), namespace, kind, name, condition, time.Duration(timeoutSeconds)*time.Second); err != nil {
+		return fmt.Errorf("K8sWaitFor: %w", err)
+	}
+	return nil
+}
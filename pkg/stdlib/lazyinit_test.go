@@ -0,0 +1,47 @@
+package stdlib
+
+import "testing"
+
+func TestNewDoesNotConstructLazyManagers(t *testing.T) {
+	sl := New()
+	if sl.cache != nil {
+		t.Errorf("cache should be nil until Cache() is called")
+	}
+	if sl.dbManager != nil {
+		t.Errorf("dbManager should be nil until DBManager() is called")
+	}
+	if sl.iocContainer != nil {
+		t.Errorf("iocContainer should be nil until IOCContainer() is called")
+	}
+	if sl.configManager != nil {
+		t.Errorf("configManager should be nil until ConfigManager() is called")
+	}
+	if sl.middlewareManager != nil {
+		t.Errorf("middlewareManager should be nil until Middleware() is called")
+	}
+}
+
+func TestLazyAccessorsConstructOnFirstUseAndReuse(t *testing.T) {
+	sl := New()
+
+	cache1 := sl.Cache()
+	if cache1 == nil {
+		t.Fatal("Cache() returned nil")
+	}
+	if cache2 := sl.Cache(); cache2 != cache1 {
+		t.Errorf("Cache() returned a different instance on the second call")
+	}
+
+	if sl.DBManager() == nil {
+		t.Errorf("DBManager() returned nil")
+	}
+	if sl.IOCContainer() == nil {
+		t.Errorf("IOCContainer() returned nil")
+	}
+	if sl.ConfigManager() == nil {
+		t.Errorf("ConfigManager() returned nil")
+	}
+	if sl.Middleware() == nil {
+		t.Errorf("Middleware() returned nil")
+	}
+}
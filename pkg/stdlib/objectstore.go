@@ -0,0 +1,101 @@
+package stdlib
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+)
+
+// Object storage builtins, backed by ObjectStoreClient's S3-compatible
+// client (see pkg/storage.S3Storage). This repo has no "registry
+// BlobStore" abstraction - the closest existing thing is
+// pkg/storage.FileStorage, which S3Storage implements - so these
+// builtins move artifacts to and from any S3-compatible bucket (AWS
+// S3, MinIO, R2, or GCS in S3-interop mode) the same way DockerPush
+// moves an image to a registry.
+
+// ObjectPut uploads the local file at localPath to key in the
+// configured bucket.
+func (sl *StdLib) ObjectPut(localPath, key string) error {
+	if err := sl.checkObjectOperation("put"); err != nil {
+		return err
+	}
+	client, err := sl.ObjectStoreClient()
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Open(localPath)
+	if err != nil {
+		return fmt.Errorf("failed to open %q: %w", localPath, err)
+	}
+	defer f.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+	return client.Upload(ctx, key, f)
+}
+
+// ObjectGet downloads key from the configured bucket to the local file
+// at localPath.
+func (sl *StdLib) ObjectGet(key, localPath string) error {
+	if err := sl.checkObjectOperation("get"); err != nil {
+		return err
+	}
+	client, err := sl.ObjectStoreClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+	body, err := client.Download(ctx, key)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+
+	f, err := os.Create(localPath)
+	if err != nil {
+		return fmt.Errorf("failed to create %q: %w", localPath, err)
+	}
+	defer f.Close()
+
+	if _, err := io.Copy(f, body); err != nil {
+		return fmt.Errorf("failed to write %q: %w", localPath, err)
+	}
+	return nil
+}
+
+// ObjectList returns the keys in the configured bucket under prefix.
+func (sl *StdLib) ObjectList(prefix string) ([]string, error) {
+	if err := sl.checkObjectOperation("list"); err != nil {
+		return nil, err
+	}
+	client, err := sl.ObjectStoreClient()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	return client.List(ctx, prefix)
+}
+
+// PresignURL returns a time-limited, signed URL granting GET access to
+// key in the configured bucket, valid for ttlSeconds.
+func (sl *StdLib) PresignURL(key string, ttlSeconds int) (string, error) {
+	if err := sl.checkObjectOperation("presign"); err != nil {
+		return "", err
+	}
+	client, err := sl.ObjectStoreClient()
+	if err != nil {
+		return "", err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	return client.PresignedURL(ctx, key, time.Duration(ttlSeconds)*time.Second)
+}
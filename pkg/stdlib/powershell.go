@@ -0,0 +1,34 @@
+package stdlib
+
+import (
+	"context"
+	"fmt"
+
+	"gitee.com/com_818cloud/shode/pkg/powershell"
+)
+
+// PowerShellInvoke runs cmdlet through a PowerShell host found on
+// PATH, returning its result decoded from JSON - easing mixed-Windows
+// automation without the script parsing PowerShell's formatted text
+// output itself.
+func (sl *StdLib) PowerShellInvoke(cmdlet string) (interface{}, error) {
+	if err := sl.checkPowerShellOperation("invoke"); err != nil {
+		return nil, err
+	}
+
+	result, err := powershell.Invoke(context.Background(), cmdlet)
+	if err != nil {
+		return nil, fmt.Errorf("PowerShellInvoke failed: %w", err)
+	}
+	return result.Output, nil
+}
+
+// checkPowerShellOperation consults sl.security, if one has been
+// attached via SetSecurityChecker, before a PowerShell builtin shells
+// out to a PowerShell host. A nil checker permits every operation.
+func (sl *StdLib) checkPowerShellOperation(op string) error {
+	if sl.security == nil {
+		return nil
+	}
+	return sl.security.CheckPowerShellOperation(op)
+}
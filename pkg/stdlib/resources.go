@@ -0,0 +1,132 @@
+package stdlib
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+
+	"gitee.com/com_818cloud/shode/pkg/resources"
+)
+
+// resourceStateOf returns sl's resources.State, constructing it on
+// first use.
+func (sl *StdLib) resourceStateOf() *resources.State {
+	sl.resourceStateOnce.Do(func() {
+		sl.resourceState = resources.NewState()
+	})
+	return sl.resourceState
+}
+
+// ResourceFile declares a file resource: path should contain content
+// with the given mode (an octal string like "0644", defaulting to
+// "0644" when empty), or not exist at all when state is "absent".
+// Returns the resource's ID for ResourcePlan/ResourceApply output to
+// reference. Nothing changes on disk until ResourceApply runs.
+func (sl *StdLib) ResourceFile(path, content, mode, state string) (string, error) {
+	if err := sl.checkResourceOperation("file"); err != nil {
+		return "", err
+	}
+
+	fileMode := os.FileMode(0644)
+	if mode != "" {
+		parsed, err := strconv.ParseUint(mode, 8, 32)
+		if err != nil {
+			return "", fmt.Errorf("ResourceFile: invalid mode %q: %w", mode, err)
+		}
+		fileMode = os.FileMode(parsed)
+	}
+
+	id := sl.resourceStateOf().Declare(&resources.File{
+		Path:    path,
+		Content: content,
+		Mode:    fileMode,
+		Absent:  state == "absent",
+	})
+	return id, nil
+}
+
+// ResourcePackage declares an OS package resource: name should be
+// installed (optionally pinned to version) unless state is "absent".
+// Returns the resource's ID. Nothing is installed until ResourceApply
+// runs.
+func (sl *StdLib) ResourcePackage(name, version, state string) (string, error) {
+	if err := sl.checkResourceOperation("package"); err != nil {
+		return "", err
+	}
+
+	return sl.resourceStateOf().Declare(&resources.Package{
+		Name:    name,
+		Version: version,
+		Absent:  state == "absent",
+	}), nil
+}
+
+// ResourceService declares a systemd service's run/boot state: runState
+// is "running" or "stopped", enableState is "enabled" or "disabled".
+// Returns the resource's ID. Nothing changes until ResourceApply runs.
+func (sl *StdLib) ResourceService(name, runState, enableState string) (string, error) {
+	if err := sl.checkResourceOperation("service"); err != nil {
+		return "", err
+	}
+
+	return sl.resourceStateOf().Declare(&resources.Service{
+		Name:    name,
+		Running: runState == "running",
+		Enabled: enableState == "enabled",
+	}), nil
+}
+
+// ResourceUser declares an OS user account: name should exist with
+// shell and home (each left alone when empty) unless state is
+// "absent". Returns the resource's ID. Nothing changes until
+// ResourceApply runs.
+//
+// This is gated by checkResourceOperation the same as every other
+// resource kind: ResourceUser+ResourceApply ultimately runs
+// useradd/userdel/usermod (pkg/resources.User.Apply), which
+// CheckCommand would refuse as dangerous commands if a script tried
+// to run them directly, so declaring one must be refusable the same
+// way.
+func (sl *StdLib) ResourceUser(name, shell, home, state string) (string, error) {
+	if err := sl.checkResourceOperation("user"); err != nil {
+		return "", err
+	}
+
+	return sl.resourceStateOf().Declare(&resources.User{
+		Name:   name,
+		Shell:  shell,
+		Home:   home,
+		Absent: state == "absent",
+	}), nil
+}
+
+// checkResourceOperation consults sl.security, if one has been
+// attached via SetSecurityChecker, before a resource builtin declares
+// a resource of kind op (e.g. "user"). A nil checker permits every
+// kind.
+func (sl *StdLib) checkResourceOperation(op string) error {
+	if sl.security == nil {
+		return nil
+	}
+	return sl.security.CheckResourceOperation(op)
+}
+
+// ResourcePlan diffs every resource declared so far against the
+// machine's current state and returns the action each one needs,
+// without changing anything.
+func (sl *StdLib) ResourcePlan() ([]resources.Action, error) {
+	return resources.Plan(context.Background(), sl.resourceStateOf().Resources())
+}
+
+// ResourceApply plans and then carries out every resource declared so
+// far, returning each resource's outcome. A resource whose apply
+// fails doesn't stop the rest from being attempted.
+func (sl *StdLib) ResourceApply() ([]resources.Result, error) {
+	declared := sl.resourceStateOf().Resources()
+	actions, err := resources.Plan(context.Background(), declared)
+	if err != nil {
+		return nil, err
+	}
+	return resources.Apply(context.Background(), declared, actions)
+}
@@ -0,0 +1,32 @@
+package stdlib
+
+import (
+	"fmt"
+
+	"gitee.com/com_818cloud/shode/pkg/ssh"
+)
+
+// SSHRun connects to host:port as user (via keyFile if non-empty,
+// otherwise password) and runs command, returning its combined
+// stdout+stderr. This is the single-host primitive the `shode remote
+// run` command fans out over pkg/remote to run the same command (or
+// script) across an inventory in parallel.
+func (sl *StdLib) SSHRun(host string, port int, user, password, keyFile, command string) (string, error) {
+	if err := sl.checkSSHOperation("run"); err != nil {
+		return "", err
+	}
+
+	client, err := ssh.Dial(ssh.Config{
+		Host:     host,
+		Port:     port,
+		User:     user,
+		Password: password,
+		KeyFile:  keyFile,
+	})
+	if err != nil {
+		return "", fmt.Errorf("SSHRun failed to connect to %s: %w", host, err)
+	}
+	defer client.Close()
+
+	return client.Run(command)
+}
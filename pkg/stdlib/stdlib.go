@@ -4,9 +4,11 @@ import (
 	"bytes"
 	"compress/gzip"
 	"context"
+	"crypto/rand"
 	"crypto/sha256"
 	"database/sql"
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"io"
 	"mime"
@@ -20,12 +22,22 @@ import (
 	"sync"
 	"time"
 
+	"gitee.com/com_818cloud/shode/pkg/auth"
 	"gitee.com/com_818cloud/shode/pkg/cache"
 	"gitee.com/com_818cloud/shode/pkg/config"
 	"gitee.com/com_818cloud/shode/pkg/database"
+	"gitee.com/com_818cloud/shode/pkg/docker"
+	"gitee.com/com_818cloud/shode/pkg/events"
 	"gitee.com/com_818cloud/shode/pkg/ioc"
+	"gitee.com/com_818cloud/shode/pkg/k8s"
+	"gitee.com/com_818cloud/shode/pkg/logger"
 	"gitee.com/com_818cloud/shode/pkg/middleware"
+	"gitee.com/com_818cloud/shode/pkg/mongo"
 	"gitee.com/com_818cloud/shode/pkg/parser"
+	"gitee.com/com_818cloud/shode/pkg/resources"
+	"gitee.com/com_818cloud/shode/pkg/sandbox"
+	"gitee.com/com_818cloud/shode/pkg/storage"
+	"gitee.com/com_818cloud/shode/pkg/tracing"
 	"gitee.com/com_818cloud/shode/pkg/types"
 	"gitee.com/com_818cloud/shode/pkg/web"
 )
@@ -55,14 +67,23 @@ type StdLib struct {
 	httpMu     sync.Mutex
 	// Request context storage (per-goroutine)
 	requestContexts sync.Map // map[goroutineID]*HTTPRequestContext
-	// Cache instance
-	cache *cache.Cache
-	// Database manager
-	dbManager *database.DatabaseManager
-	// IoC container
-	iocContainer *ioc.Container
-	// Config manager
-	configManager *config.ConfigManager
+	// Cache instance, lazily constructed by Cache() on first use so a
+	// script that never touches the cache doesn't pay for its cleanup
+	// goroutine at startup
+	cache     cache.Provider
+	cacheOnce sync.Once
+	// Database manager, lazily constructed by DBManager()
+	dbManager     *database.DatabaseManager
+	dbManagerOnce sync.Once
+	// MongoDB connection manager, lazily constructed by MongoManager()
+	mongoManager     *mongo.Manager
+	mongoManagerOnce sync.Once
+	// IoC container, lazily constructed by IOCContainer()
+	iocContainer     *ioc.Container
+	iocContainerOnce sync.Once
+	// Config manager, lazily constructed by ConfigManager()
+	configManager     *config.ConfigManager
+	configManagerOnce sync.Once
 	// Execution engine factory (to avoid circular dependency)
 	engineFactory func() interface{} // Returns *engine.ExecutionEngine
 	// Files manager
@@ -71,12 +92,133 @@ type StdLib struct {
 	systemManager *SystemManager
 	// Network manager
 	networkManager *NetworkManager
-	// Middleware manager
-	middlewareManager *middleware.Manager
+	// Middleware manager, lazily constructed by Middleware()
+	middlewareManager     *middleware.Manager
+	middlewareManagerOnce sync.Once
 	// Archive manager
 	archiveManager *ArchiveManager
 	// WebSocket manager
 	wsManager *WebSocketManager
+	// Logger backing LogInfo/LogWarn/LogError (defaults to logger.DefaultLogger when nil)
+	logger *logger.Logger
+	// Tracer backing the network/DB builtins' spans (a nil *tracing.Tracer is a safe no-op, so tracing stays off until SetTracer attaches one)
+	tracer *tracing.Tracer
+	// Event bus the in-memory cache publishes CacheEvicted to, attached to Cache() on construction; nil until SetEventBus attaches one
+	events *events.Bus
+	// Security checker the Docker and Kubernetes builtins consult via CheckDockerOperation/CheckKubernetesOperation before talking to their respective daemons; nil is a safe no-op, so this stays unset until SetSecurityChecker attaches one
+	security *sandbox.SecurityChecker
+	// Docker Engine API client, lazily constructed by DockerClient()
+	dockerClient     *docker.Client
+	dockerClientOnce sync.Once
+	dockerClientErr  error
+	// Kubernetes API server client, lazily constructed by KubernetesClient()
+	kubernetesClient     *k8s.Client
+	kubernetesClientOnce sync.Once
+	kubernetesClientErr  error
+	// S3-compatible object storage client, lazily constructed by ObjectStoreClient()
+	objectStoreClient     *storage.S3Storage
+	objectStoreClientOnce sync.Once
+	objectStoreClientErr  error
+	// Declared provisioning resources (files/packages/services/users),
+	// lazily constructed by resourceStateOf() on first ResourceFile/
+	// ResourcePackage/ResourceService/ResourceUser call
+	resourceState     *resources.State
+	resourceStateOnce sync.Once
+}
+
+// Cache returns the standard library's cache, constructing it on
+// first use. By default this is the in-memory store; setting
+// SHODE_CACHE_BACKEND=redis and SHODE_CACHE_REDIS_ADDR (a
+// comma-separated host:port list) switches to a Redis-backed cache
+// instead, so cached data survives restarts and is shared across
+// instances.
+func (sl *StdLib) Cache() cache.Provider {
+	sl.cacheOnce.Do(func() {
+		sl.cache = newCacheProvider()
+		if c, ok := sl.cache.(*cache.Cache); ok && sl.events != nil {
+			c.SetEventBus(sl.events)
+		}
+	})
+	return sl.cache
+}
+
+// newCacheProvider builds the cache backend Cache() uses, falling
+// back to the in-memory store when Redis isn't configured or fails
+// to connect, so a script keeps running rather than failing outright.
+func newCacheProvider() cache.Provider {
+	if strings.ToLower(os.Getenv("SHODE_CACHE_BACKEND")) != "redis" {
+		return newInMemoryCacheProvider()
+	}
+
+	addr := os.Getenv("SHODE_CACHE_REDIS_ADDR")
+	if addr == "" {
+		return newInMemoryCacheProvider()
+	}
+
+	db, _ := strconv.Atoi(os.Getenv("SHODE_CACHE_REDIS_DB"))
+	redisCache, err := cache.NewRedisCache(cache.RedisConfig{
+		Addrs:    strings.Split(addr, ","),
+		Password: os.Getenv("SHODE_CACHE_REDIS_PASSWORD"),
+		DB:       db,
+	})
+	if err != nil {
+		return newInMemoryCacheProvider()
+	}
+	return redisCache
+}
+
+// newInMemoryCacheProvider builds the in-memory Cache, applying a
+// max-bytes budget and/or snapshot file from the environment when
+// configured. SHODE_CACHE_MAX_BYTES caps total entry size (LRU
+// eviction once exceeded); SHODE_CACHE_SNAPSHOT_PATH restores any
+// existing snapshot on startup, so cached data survives a restart
+// without a Redis backend.
+func newInMemoryCacheProvider() *cache.Cache {
+	maxBytes, _ := strconv.ParseInt(os.Getenv("SHODE_CACHE_MAX_BYTES"), 10, 64)
+	snapshotPath := os.Getenv("SHODE_CACHE_SNAPSHOT_PATH")
+	c := cache.NewCacheWithOptions(cache.CacheOptions{
+		MaxBytes:     maxBytes,
+		SnapshotPath: snapshotPath,
+	})
+	if snapshotPath != "" {
+		c.LoadSnapshot("")
+	}
+	return c
+}
+
+// DBManager returns the standard library's database manager,
+// constructing it on first use.
+func (sl *StdLib) DBManager() *database.DatabaseManager {
+	sl.dbManagerOnce.Do(func() { sl.dbManager = database.NewDatabaseManager() })
+	return sl.dbManager
+}
+
+// MongoManager returns the standard library's MongoDB connection
+// manager, constructing it on first use.
+func (sl *StdLib) MongoManager() *mongo.Manager {
+	sl.mongoManagerOnce.Do(func() { sl.mongoManager = mongo.NewManager() })
+	return sl.mongoManager
+}
+
+// IOCContainer returns the standard library's IoC container,
+// constructing it on first use.
+func (sl *StdLib) IOCContainer() *ioc.Container {
+	sl.iocContainerOnce.Do(func() { sl.iocContainer = ioc.NewContainer() })
+	return sl.iocContainer
+}
+
+// ConfigManager returns the standard library's configuration
+// manager, constructing it on first use.
+func (sl *StdLib) ConfigManager() *config.ConfigManager {
+	sl.configManagerOnce.Do(func() { sl.configManager = config.NewConfigManager() })
+	return sl.configManager
+}
+
+// Middleware returns the standard library's middleware manager,
+// constructing it on first use.
+func (sl *StdLib) Middleware() *middleware.Manager {
+	sl.middlewareManagerOnce.Do(func() { sl.middlewareManager = middleware.NewManager() })
+	return sl.middlewareManager
 }
 
 // FilesManager handles file operations
@@ -103,26 +245,26 @@ type StaticFileConfig struct {
 
 // routeHandler represents a route handler
 type routeHandler struct {
-	method      string // HTTP method (GET, POST, PUT, DELETE, PATCH, "*" for all)
-	path        string
-	handlerType string // "function", "script", or "static"
-	handlerName string // function name or script content
+	method       string // HTTP method (GET, POST, PUT, DELETE, PATCH, "*" for all)
+	path         string
+	handlerType  string            // "function", "script", or "static"
+	handlerName  string            // function name or script content
 	staticConfig *StaticFileConfig // Only for "static" type
 }
 
 // httpServer represents an HTTP server instance
 type httpServer struct {
-	server      *http.Server
-	mux         *http.ServeMux
-	routes      map[string]*routeHandler // routeKey (method:path) -> handler
-	staticRoutes map[string]*StaticFileConfig // route prefix -> config (for static routes)
-	registeredPaths map[string]bool // Track which paths have mux handlers registered
-	isRunning   bool
-	middlewares []web.Middleware // Global middlewares
-	enableRequestLog bool // Enable request logging
-	requestLogLevel string // Log level: "debug", "info", "error"
-	errorPages map[int]string // Custom error pages (status code -> file path)
-	mu          sync.RWMutex
+	server           *http.Server
+	mux              *http.ServeMux
+	routes           map[string]*routeHandler     // routeKey (method:path) -> handler
+	staticRoutes     map[string]*StaticFileConfig // route prefix -> config (for static routes)
+	registeredPaths  map[string]bool              // Track which paths have mux handlers registered
+	isRunning        bool
+	middlewares      []web.Middleware // Global middlewares
+	enableRequestLog bool             // Enable request logging
+	requestLogLevel  string           // Log level: "debug", "info", "error"
+	errorPages       map[int]string   // Custom error pages (status code -> file path)
+	mu               sync.RWMutex
 }
 
 // responseWriterWrapper wraps http.ResponseWriter to capture status code
@@ -149,15 +291,10 @@ func (w *responseWriterWrapper) Write(b []byte) (int, error) {
 // New creates a new standard library instance
 func New() *StdLib {
 	return &StdLib{
-		cache:            cache.NewCache(),
-		dbManager:        database.NewDatabaseManager(),
-		iocContainer:     ioc.NewContainer(),
-		configManager:     config.NewConfigManager(),
-		filesManager:     &FilesManager{},
-		systemManager:    &SystemManager{},
-		networkManager:    &NetworkManager{},
-		archiveManager:   &ArchiveManager{},
-		middlewareManager: middleware.NewManager(),
+		filesManager:   &FilesManager{},
+		systemManager:  &SystemManager{},
+		networkManager: &NetworkManager{},
+		archiveManager: &ArchiveManager{},
 	}
 }
 
@@ -810,6 +947,166 @@ func (sl *StdLib) Errorln(text string) {
 	fmt.Fprintln(os.Stderr, text)
 }
 
+// SetLogger overrides the logger LogInfo/LogWarn/LogError write
+// through, in place of logger.DefaultLogger.
+func (sl *StdLib) SetLogger(l *logger.Logger) {
+	sl.logger = l
+}
+
+// scriptLogger returns sl's configured logger, falling back to
+// logger.DefaultLogger when none has been set.
+func (sl *StdLib) scriptLogger() *logger.Logger {
+	if sl.logger != nil {
+		return sl.logger
+	}
+	return logger.DefaultLogger
+}
+
+// SetTracer attaches a tracer that exports a span for every network/DB
+// builtin call (ConnectDB, QueryDB, ExecDB, ConnectMongo, FindMongo,
+// and their siblings). Leaving it unset (the default) keeps tracing
+// off, since a nil *tracing.Tracer is a safe no-op.
+func (sl *StdLib) SetTracer(t *tracing.Tracer) {
+	sl.tracer = t
+}
+
+// SetEventBus attaches the bus an in-memory Cache() publishes
+// CacheEvicted events to. It has no effect on a Redis-backed cache,
+// which doesn't track eviction locally. Must be called before the
+// first Cache() call to take effect.
+func (sl *StdLib) SetEventBus(bus *events.Bus) {
+	sl.events = bus
+}
+
+// SetSecurityChecker attaches the checker the Docker builtins
+// (DockerBuild, DockerRun, DockerPush, DockerPs), the Kubernetes
+// builtins (K8sApply, K8sGet, K8sLogs, K8sWaitFor), the Git builtins
+// (GitClone, GitCheckout, GitStatus, GitTag, GitPush), the object
+// storage builtins (ObjectPut, ObjectGet, ObjectList, PresignURL), and
+// the SSH builtin (SSHRun) consult before talking to their daemons.
+// Leaving it unset keeps every operation permitted, since a nil
+// *sandbox.SecurityChecker is a safe no-op.
+func (sl *StdLib) SetSecurityChecker(sc *sandbox.SecurityChecker) {
+	sl.security = sc
+}
+
+// DockerClient returns the standard library's Docker Engine API
+// client, constructing it on first use against DOCKER_HOST (or the
+// default /var/run/docker.sock if unset).
+func (sl *StdLib) DockerClient() (*docker.Client, error) {
+	sl.dockerClientOnce.Do(func() {
+		sl.dockerClient, sl.dockerClientErr = docker.NewClient("")
+	})
+	return sl.dockerClient, sl.dockerClientErr
+}
+
+// checkDockerOperation consults sl.security, if one has been attached
+// via SetSecurityChecker, before a Docker builtin talks to the
+// daemon. A nil checker permits every operation.
+func (sl *StdLib) checkDockerOperation(op string) error {
+	if sl.security == nil {
+		return nil
+	}
+	return sl.security.CheckDockerOperation(op)
+}
+
+// KubernetesClient returns the standard library's Kubernetes API server
+// client, constructing it on first use via in-cluster auth when running
+// inside a pod, or the current kubeconfig context otherwise.
+func (sl *StdLib) KubernetesClient() (*k8s.Client, error) {
+	sl.kubernetesClientOnce.Do(func() {
+		sl.kubernetesClient, sl.kubernetesClientErr = k8s.NewClientFromEnv()
+	})
+	return sl.kubernetesClient, sl.kubernetesClientErr
+}
+
+// checkKubernetesOperation consults sl.security, if one has been
+// attached via SetSecurityChecker, before a Kubernetes builtin talks to
+// the API server. A nil checker permits every operation.
+func (sl *StdLib) checkKubernetesOperation(op string) error {
+	if sl.security == nil {
+		return nil
+	}
+	return sl.security.CheckKubernetesOperation(op)
+}
+
+// ObjectStoreClient returns the standard library's S3-compatible object
+// storage client, constructing it on first use from SHODE_S3_BUCKET,
+// SHODE_S3_REGION, SHODE_S3_ENDPOINT, SHODE_S3_ACCESS_KEY_ID, and
+// SHODE_S3_SECRET_ACCESS_KEY (SHODE_S3_USE_SSL defaults to "true").
+// SHODE_S3_BUCKET and SHODE_S3_ENDPOINT are required; the credential
+// variables may be left empty for anonymous/public-bucket access.
+func (sl *StdLib) ObjectStoreClient() (*storage.S3Storage, error) {
+	sl.objectStoreClientOnce.Do(func() {
+		bucket := os.Getenv("SHODE_S3_BUCKET")
+		endpoint := os.Getenv("SHODE_S3_ENDPOINT")
+		if bucket == "" || endpoint == "" {
+			sl.objectStoreClientErr = fmt.Errorf("object storage is not configured: set SHODE_S3_BUCKET and SHODE_S3_ENDPOINT")
+			return
+		}
+		useSSL := true
+		if v := os.Getenv("SHODE_S3_USE_SSL"); v != "" {
+			useSSL, sl.objectStoreClientErr = strconv.ParseBool(v)
+			if sl.objectStoreClientErr != nil {
+				return
+			}
+		}
+		sl.objectStoreClient = storage.NewS3Storage(
+			bucket,
+			os.Getenv("SHODE_S3_REGION"),
+			os.Getenv("SHODE_S3_ACCESS_KEY_ID"),
+			os.Getenv("SHODE_S3_SECRET_ACCESS_KEY"),
+			endpoint,
+			useSSL,
+		)
+	})
+	return sl.objectStoreClient, sl.objectStoreClientErr
+}
+
+// checkObjectOperation consults sl.security, if one has been attached
+// via SetSecurityChecker, before an object storage builtin talks to
+// the remote bucket. A nil checker permits every operation.
+func (sl *StdLib) checkObjectOperation(op string) error {
+	if sl.security == nil {
+		return nil
+	}
+	return sl.security.CheckObjectOperation(op)
+}
+
+// checkSSHOperation consults sl.security, if one has been attached via
+// SetSecurityChecker, before an SSH builtin connects to a remote host.
+// A nil checker permits every operation.
+func (sl *StdLib) checkSSHOperation(op string) error {
+	if sl.security == nil {
+		return nil
+	}
+	return sl.security.CheckSSHOperation(op)
+}
+
+// logLocationFields builds the script/line attribution fields shared by
+// LogInfo/LogWarn/LogError.
+func logLocationFields(script string, line int) map[string]interface{} {
+	return map[string]interface{}{"script": script, "line": line}
+}
+
+// LogInfo writes an informational log entry tagged with the script and
+// line number it was called from (replaces echo-based logging).
+func (sl *StdLib) LogInfo(script string, line int, message string) {
+	sl.scriptLogger().WithFields(logLocationFields(script, line)).Info(message)
+}
+
+// LogWarn writes a warning log entry tagged with the script and line
+// number it was called from (replaces echo-based logging).
+func (sl *StdLib) LogWarn(script string, line int, message string) {
+	sl.scriptLogger().WithFields(logLocationFields(script, line)).Warn(message)
+}
+
+// LogError writes an error log entry tagged with the script and line
+// number it was called from (replaces echo-based logging).
+func (sl *StdLib) LogError(script string, line int, message string) {
+	sl.scriptLogger().WithFields(logLocationFields(script, line)).Error(message)
+}
+
 // HTTP Server functions
 
 // logRequest logs HTTP request details
@@ -1927,72 +2224,339 @@ func (sl *StdLib) SetHTTPHeader(name, value string) {
 
 // SetCache sets a value in the cache with optional TTL
 func (sl *StdLib) SetCache(key, value string, ttlSeconds int) {
-	sl.cache.Set(key, value, ttlSeconds)
+	sl.Cache().Set(key, value, ttlSeconds)
 }
 
 // GetCache retrieves a value from the cache
 func (sl *StdLib) GetCache(key string) (string, bool) {
-	return sl.cache.Get(key)
+	return sl.Cache().Get(key)
 }
 
 // DeleteCache removes a key from the cache
 func (sl *StdLib) DeleteCache(key string) {
-	sl.cache.Delete(key)
+	sl.Cache().Delete(key)
 }
 
 // ClearCache removes all entries from the cache
 func (sl *StdLib) ClearCache() {
-	sl.cache.Clear()
+	sl.Cache().Clear()
 }
 
 // CacheExists checks if a key exists in the cache
 func (sl *StdLib) CacheExists(key string) bool {
-	return sl.cache.Exists(key)
+	return sl.Cache().Exists(key)
 }
 
 // GetCacheTTL returns the remaining TTL in seconds for a key
 func (sl *StdLib) GetCacheTTL(key string) int {
-	return sl.cache.GetTTL(key)
+	return sl.Cache().GetTTL(key)
 }
 
 // SetCacheBatch sets multiple key-value pairs at once
 func (sl *StdLib) SetCacheBatch(keyValues map[string]string, ttlSeconds int) {
-	sl.cache.SetBatch(keyValues, ttlSeconds)
+	sl.Cache().SetBatch(keyValues, ttlSeconds)
 }
 
 // GetCacheKeys returns all keys matching a pattern
 func (sl *StdLib) GetCacheKeys(pattern string) []string {
-	return sl.cache.GetKeys(pattern)
+	return sl.Cache().GetKeys(pattern)
 }
 
-// Database functions
+// GetCacheStats returns hit/miss/eviction counters for the cache.
+// Only the in-memory backend tracks these locally; if the active
+// backend doesn't (e.g. RedisCache), ok is false.
+func (sl *StdLib) GetCacheStats() (cache.CacheStats, bool) {
+	statsProvider, ok := sl.Cache().(cache.StatsProvider)
+	if !ok {
+		return cache.CacheStats{}, false
+	}
+	return statsProvider.Stats(), true
+}
 
-// ConnectDB connects to a database
-func (sl *StdLib) ConnectDB(dbType, dsn string) error {
-	// 简化实现
+// Session functions
+//
+// Scripts handling an HTTP request (see RegisterHTTPRoute) get a
+// cookie-based session backed by the same engine cache used by
+// SetCache/GetCache. The session cookie is issued automatically on the
+// first call to one of these functions for a request that has none.
+
+const sessionCookieName = "shode_session"
+const sessionCacheKeyPrefix = "session:"
+const sessionTTLSeconds = 1800
+
+// sessionID returns the current request's session ID, issuing and
+// persisting a new one via Set-Cookie if the request carries none.
+func (sl *StdLib) sessionID() string {
+	cookieHeader := sl.GetHTTPHeader("Cookie")
+	for _, part := range strings.Split(cookieHeader, ";") {
+		part = strings.TrimSpace(part)
+		if name, value, found := strings.Cut(part, "="); found && name == sessionCookieName {
+			return value
+		}
+	}
+
+	id := generateSessionID()
+	sl.SetHTTPHeader("Set-Cookie", fmt.Sprintf("%s=%s; Path=/; HttpOnly", sessionCookieName, id))
+	return id
+}
+
+func generateSessionID() string {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return hex.EncodeToString([]byte(time.Now().String()))
+	}
+	return hex.EncodeToString(b)
+}
+
+func (sl *StdLib) loadSessionValues(id string) map[string]string {
+	raw, ok := sl.Cache().Get(sessionCacheKeyPrefix + id)
+	if !ok {
+		return make(map[string]string)
+	}
+	var values map[string]string
+	if err := json.Unmarshal([]byte(raw), &values); err != nil {
+		return make(map[string]string)
+	}
+	return values
+}
+
+func (sl *StdLib) saveSessionValues(id string, values map[string]string) error {
+	data, err := json.Marshal(values)
+	if err != nil {
+		return err
+	}
+	sl.Cache().Set(sessionCacheKeyPrefix+id, string(data), sessionTTLSeconds)
 	return nil
 }
 
-// CloseDB closes the database connection
-func (sl *StdLib) CloseDB() error {
-	// 简化实现
+// GetSessionValue returns a value from the current request's session
+// Usage: GetSessionValue "user_id"
+func (sl *StdLib) GetSessionValue(key string) string {
+	return sl.loadSessionValues(sl.sessionID())[key]
+}
+
+// SetSessionValue stores a value in the current request's session
+// Usage: SetSessionValue "user_id" "42"
+func (sl *StdLib) SetSessionValue(key, value string) error {
+	id := sl.sessionID()
+	values := sl.loadSessionValues(id)
+	values[key] = value
+	return sl.saveSessionValues(id, values)
+}
+
+// DeleteSessionValue removes a value from the current request's session
+// Usage: DeleteSessionValue "user_id"
+func (sl *StdLib) DeleteSessionValue(key string) error {
+	id := sl.sessionID()
+	values := sl.loadSessionValues(id)
+	delete(values, key)
+	return sl.saveSessionValues(id, values)
+}
+
+// ClearSession removes every value from the current request's session
+// Usage: ClearSession
+func (sl *StdLib) ClearSession() error {
+	sl.Cache().Delete(sessionCacheKeyPrefix + sl.sessionID())
 	return nil
 }
 
-// IsDBConnected checks if the database is connected
+// JWT functions
+
+// IssueJWT creates a JWT for subject, signed with secret, valid for
+// ttlSeconds
+// Usage: IssueJWT "user-42" "my-secret" 3600
+func (sl *StdLib) IssueJWT(subject, secret string, ttlSeconds int) (string, error) {
+	claims := auth.NewClaims(subject, time.Duration(ttlSeconds)*time.Second)
+	return auth.SignHS256(claims, []byte(secret))
+}
+
+// VerifyJWT verifies a JWT's signature and expiry against secret and
+// returns its subject claim
+// Usage: VerifyJWT "$token" "my-secret"
+func (sl *StdLib) VerifyJWT(token, secret string) (string, error) {
+	claims, err := auth.ParseHS256(token, []byte(secret))
+	if err != nil {
+		return "", err
+	}
+	return claims.Subject(), nil
+}
+
+// GetHTTPBearerToken returns the bearer token from the current
+// request's Authorization header, or "" if none was sent
+// Usage: GetHTTPBearerToken
+func (sl *StdLib) GetHTTPBearerToken() string {
+	header := sl.GetHTTPHeader("Authorization")
+	const prefix = "Bearer "
+	if !strings.HasPrefix(header, prefix) {
+		return ""
+	}
+	return strings.TrimPrefix(header, prefix)
+}
+
+// Database functions
+
+// defaultDBConnName is the connection name ConnectDB registers under
+// when no name is given, and the one QueryDB/ExecDB/BeginTxDB and
+// friends target when no --on selector is given.
+const defaultDBConnName = "default"
+
+// dbDrivers maps the dbType ConnectDB accepts to the database/sql
+// driver name it's registered under.
+var dbDrivers = map[string]string{
+	"sqlite":     "sqlite3",
+	"sqlite3":    "sqlite3",
+	"postgres":   "postgres",
+	"postgresql": "postgres",
+	"mysql":      "mysql",
+}
+
+// ConnectDB opens a database connection of dbType (sqlite, postgres or
+// mysql) and registers it for later use by the other DB builtins.
+//
+// Accepted forms:
+//
+//	ConnectDB <dbType> <dsn>                      registers as "default"
+//	ConnectDB <dbType> <name> <dsn>                registers as name
+//	... --max-open <n> --max-idle <n>              optional pool sizing
+//
+// Connecting again under a name that's already registered replaces
+// the existing connection.
+func (sl *StdLib) ConnectDB(args ...string) error {
+	_, span := sl.tracer.Start(context.Background(), "shode.db.connect")
+	defer span.End()
+
+	if len(args) < 2 {
+		err := fmt.Errorf("ConnectDB requires a dbType and dsn (optionally a connection name: ConnectDB <type> [name] <dsn>)")
+		span.SetErr(err)
+		return err
+	}
+	dbType := args[0]
+	span.SetAttribute("db.type", dbType)
+
+	name, dsn, maxOpen, maxIdle, err := parseConnectDBArgs(args[1:])
+	if err != nil {
+		span.SetErr(err)
+		return err
+	}
+	span.SetAttribute("db.conn", name)
+
+	driver, ok := dbDrivers[strings.ToLower(dbType)]
+	if !ok {
+		err := fmt.Errorf("unsupported database type: %s", dbType)
+		span.SetErr(err)
+		return err
+	}
+
+	config := database.DefaultConfig(driver)
+	config.DSN = database.NormalizeDSN(driver, dsn)
+	if maxOpen > 0 {
+		config.MaxOpenConns = maxOpen
+	}
+	if maxIdle > 0 {
+		config.MaxIdleConns = maxIdle
+	}
+
+	db, err := database.Open(config)
+	if err != nil {
+		err = fmt.Errorf("opening %s connection %q: %w", dbType, name, err)
+		span.SetErr(err)
+		return err
+	}
+
+	err = sl.DBManager().RegisterConnection(name, &database.Connection{
+		Name:   name,
+		Driver: driver,
+		DSN:    config.DSN,
+		DB:     db,
+		Config: &database.ConnConfig{
+			MaxOpenConns:    config.MaxOpenConns,
+			MaxIdleConns:    config.MaxIdleConns,
+			ConnMaxLifetime: config.ConnMaxLifetime,
+			ConnMaxIdleTime: config.ConnMaxIdleTime,
+		},
+		Status: "connected",
+	})
+	span.SetErr(err)
+	return err
+}
+
+// parseConnectDBArgs splits ConnectDB's post-dbType arguments into the
+// connection name, dsn, and optional pool sizes. args is either
+// [dsn] or [name, dsn], with an arbitrary number of "--max-open n" /
+// "--max-idle n" pairs interspersed.
+func parseConnectDBArgs(args []string) (name, dsn string, maxOpen, maxIdle int, err error) {
+	var positional []string
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--max-open", "--max-idle":
+			if i+1 >= len(args) {
+				return "", "", 0, 0, fmt.Errorf("%s requires a value", args[i])
+			}
+			n, convErr := strconv.Atoi(args[i+1])
+			if convErr != nil {
+				return "", "", 0, 0, fmt.Errorf("invalid %s value: %s", args[i], args[i+1])
+			}
+			if args[i] == "--max-open" {
+				maxOpen = n
+			} else {
+				maxIdle = n
+			}
+			i++
+		default:
+			positional = append(positional, args[i])
+		}
+	}
+
+	switch len(positional) {
+	case 1:
+		return defaultDBConnName, positional[0], maxOpen, maxIdle, nil
+	case 2:
+		return positional[0], positional[1], maxOpen, maxIdle, nil
+	default:
+		return "", "", 0, 0, fmt.Errorf("ConnectDB requires a dsn, or a name and dsn")
+	}
+}
+
+// CloseDB closes the default database connection opened by ConnectDB.
+func (sl *StdLib) CloseDB() error {
+	conn, err := sl.DBManager().GetConnection(defaultDBConnName)
+	if err != nil {
+		return nil
+	}
+	conn.CloseStatements()
+	return conn.DB.Close()
+}
+
+// IsDBConnected checks if the default database connection is open.
 func (sl *StdLib) IsDBConnected() bool {
-	// 简化实现
-	return true
+	_, err := sl.DBManager().GetConnection(defaultDBConnName)
+	return err == nil
 }
 
-// QueryDB executes a SELECT query
+// QueryDB executes a SELECT query against the default connection.
 func (sl *StdLib) QueryDB(sql string, args ...string) (*sql.Rows, error) {
-	// Convert string args to interface{}
+	return sl.QueryDBOn(defaultDBConnName, sql, args...)
+}
+
+// QueryDBOn executes a SELECT query against the named connection
+// (connName == "" means the default connection), as registered by
+// ConnectDB.
+func (sl *StdLib) QueryDBOn(connName, sql string, args ...string) (*sql.Rows, error) {
+	if connName == "" {
+		connName = defaultDBConnName
+	}
+
+	ctx, span := sl.tracer.Start(context.Background(), "shode.db.query")
+	span.SetAttribute("db.conn", connName)
+	defer span.End()
+
 	interfaceArgs := make([]interface{}, len(args))
 	for i, arg := range args {
 		interfaceArgs[i] = arg
 	}
-	return sl.dbManager.Query(context.Background(), "default", sql, interfaceArgs...)
+	rows, err := sl.DBManager().Query(ctx, connName, sql, interfaceArgs...)
+	span.SetErr(err)
+	return rows, err
 }
 
 // QueryRowDB executes a SELECT query and returns a single row
@@ -2001,13 +2565,250 @@ func (sl *StdLib) QueryRowDB(sql string, args ...string) (map[string]interface{}
 	return make(map[string]interface{}), nil
 }
 
-// ExecDB executes a non-query SQL statement
+// ExecDB executes a non-query SQL statement against the default
+// connection.
 func (sl *StdLib) ExecDB(sql string, args ...string) (sql.Result, error) {
+	return sl.ExecDBOn(defaultDBConnName, sql, args...)
+}
+
+// ExecDBOn executes a non-query SQL statement against the named
+// connection (connName == "" means the default connection), as
+// registered by ConnectDB.
+func (sl *StdLib) ExecDBOn(connName, sql string, args ...string) (sql.Result, error) {
+	if connName == "" {
+		connName = defaultDBConnName
+	}
+
+	ctx, span := sl.tracer.Start(context.Background(), "shode.db.exec")
+	span.SetAttribute("db.conn", connName)
+	defer span.End()
+
 	interfaceArgs := make([]interface{}, len(args))
 	for i, arg := range args {
 		interfaceArgs[i] = arg
 	}
-	return sl.dbManager.Execute(context.Background(), "default", sql, interfaceArgs...)
+	result, err := sl.DBManager().Execute(ctx, connName, sql, interfaceArgs...)
+	span.SetErr(err)
+	return result, err
+}
+
+// ExecBatchDB executes sql once per row of argSets against the
+// default connection, reusing one prepared statement across the
+// whole batch. argSets is a JSON array of arrays, e.g.
+// [["1","alice"],["2","bob"]], so data-loading scripts can insert
+// many rows without looping ExecDB one call - and one round trip to
+// prepare/plan - per row.
+func (sl *StdLib) ExecBatchDB(sql, argSetsJSON string) (int, error) {
+	return sl.ExecBatchDBOn(defaultDBConnName, sql, argSetsJSON)
+}
+
+// ExecBatchDBOn is ExecBatchDB against the named connection (connName
+// == "" means the default connection), as registered by ConnectDB.
+func (sl *StdLib) ExecBatchDBOn(connName, sql, argSetsJSON string) (int, error) {
+	if connName == "" {
+		connName = defaultDBConnName
+	}
+
+	ctx, span := sl.tracer.Start(context.Background(), "shode.db.exec_batch")
+	span.SetAttribute("db.conn", connName)
+	defer span.End()
+
+	var argSets [][]interface{}
+	if err := json.Unmarshal([]byte(argSetsJSON), &argSets); err != nil {
+		err = fmt.Errorf("ExecBatchDB: invalid argSets JSON: %w", err)
+		span.SetErr(err)
+		return 0, err
+	}
+
+	results, err := sl.DBManager().ExecuteBatch(ctx, connName, sql, argSets)
+	span.SetErr(err)
+	return len(results), err
+}
+
+// MongoDB functions
+
+// defaultMongoConnName is the connection name ConnectMongo registers
+// under when no name is given, and the one FindMongo/InsertMongo/
+// AggregateMongo target when no --on selector is given.
+const defaultMongoConnName = "default"
+
+// ConnectMongo dials a MongoDB server and registers the connection
+// for later use by the other Mongo builtins.
+//
+// Accepted forms:
+//
+//	ConnectMongo <addr> <db>          registers as "default"
+//	ConnectMongo <addr> <name> <db>   registers as name
+//
+// Connecting again under a name that's already registered replaces
+// the existing connection.
+func (sl *StdLib) ConnectMongo(args ...string) error {
+	_, span := sl.tracer.Start(context.Background(), "shode.mongo.connect")
+	defer span.End()
+
+	name, addr, db, err := parseConnectMongoArgs(args)
+	if err != nil {
+		span.SetErr(err)
+		return err
+	}
+	span.SetAttribute("db.conn", name)
+
+	err = sl.MongoManager().Register(name, addr, db)
+	span.SetErr(err)
+	return err
+}
+
+// parseConnectMongoArgs splits ConnectMongo's arguments into the
+// connection name, address, and default database. args is either
+// [addr, db] or [name, addr, db].
+func parseConnectMongoArgs(args []string) (name, addr, db string, err error) {
+	switch len(args) {
+	case 2:
+		return defaultMongoConnName, args[0], args[1], nil
+	case 3:
+		return args[0], args[1], args[2], nil
+	default:
+		return "", "", "", fmt.Errorf("ConnectMongo requires an addr and db (optionally a connection name: ConnectMongo <addr> [name] <db>)")
+	}
+}
+
+// FindMongo queries collection on the default connection and returns
+// the matching documents. filterJSON is a JSON object, e.g.
+// `{"status":"active"}`; limit <= 0 means no limit.
+func (sl *StdLib) FindMongo(collection, filterJSON string, limit int) ([]map[string]interface{}, error) {
+	return sl.FindMongoOn(defaultMongoConnName, collection, filterJSON, limit)
+}
+
+// FindMongoOn is FindMongo against the named connection (connName ==
+// "" means the default connection), as registered by ConnectMongo.
+func (sl *StdLib) FindMongoOn(connName, collection, filterJSON string, limit int) ([]map[string]interface{}, error) {
+	if connName == "" {
+		connName = defaultMongoConnName
+	}
+
+	_, span := sl.tracer.Start(context.Background(), "shode.mongo.find")
+	span.SetAttribute("db.conn", connName)
+	span.SetAttribute("db.collection", collection)
+	defer span.End()
+
+	client, db, err := sl.MongoManager().Get(connName)
+	if err != nil {
+		span.SetErr(err)
+		return nil, err
+	}
+
+	var filter map[string]interface{}
+	if err := json.Unmarshal([]byte(filterJSON), &filter); err != nil {
+		err = fmt.Errorf("FindMongo: invalid filter JSON: %w", err)
+		span.SetErr(err)
+		return nil, err
+	}
+
+	docs, err := client.Find(db, collection, filter, int64(limit))
+	if err != nil {
+		span.SetErr(err)
+		return nil, err
+	}
+	return toDocumentMaps(docs)
+}
+
+// InsertMongo inserts docsJSON, a JSON array of documents, into
+// collection on the default connection and returns the number of
+// documents inserted. Documents without an "_id" field are assigned
+// one automatically.
+func (sl *StdLib) InsertMongo(collection, docsJSON string) (int, error) {
+	return sl.InsertMongoOn(defaultMongoConnName, collection, docsJSON)
+}
+
+// InsertMongoOn is InsertMongo against the named connection (connName
+// == "" means the default connection), as registered by ConnectMongo.
+func (sl *StdLib) InsertMongoOn(connName, collection, docsJSON string) (int, error) {
+	if connName == "" {
+		connName = defaultMongoConnName
+	}
+
+	_, span := sl.tracer.Start(context.Background(), "shode.mongo.insert")
+	span.SetAttribute("db.conn", connName)
+	span.SetAttribute("db.collection", collection)
+	defer span.End()
+
+	client, db, err := sl.MongoManager().Get(connName)
+	if err != nil {
+		span.SetErr(err)
+		return 0, err
+	}
+
+	var docs []map[string]interface{}
+	if err := json.Unmarshal([]byte(docsJSON), &docs); err != nil {
+		err = fmt.Errorf("InsertMongo: invalid documents JSON: %w", err)
+		span.SetErr(err)
+		return 0, err
+	}
+
+	interfaceDocs := make([]interface{}, len(docs))
+	for i, doc := range docs {
+		interfaceDocs[i] = doc
+	}
+
+	n, err := client.Insert(db, collection, interfaceDocs)
+	span.SetErr(err)
+	return n, err
+}
+
+// AggregateMongo runs pipelineJSON, a JSON array of aggregation
+// stages, against collection on the default connection and returns
+// the resulting documents.
+func (sl *StdLib) AggregateMongo(collection, pipelineJSON string) ([]map[string]interface{}, error) {
+	return sl.AggregateMongoOn(defaultMongoConnName, collection, pipelineJSON)
+}
+
+// AggregateMongoOn is AggregateMongo against the named connection
+// (connName == "" means the default connection), as registered by
+// ConnectMongo.
+func (sl *StdLib) AggregateMongoOn(connName, collection, pipelineJSON string) ([]map[string]interface{}, error) {
+	if connName == "" {
+		connName = defaultMongoConnName
+	}
+
+	_, span := sl.tracer.Start(context.Background(), "shode.mongo.aggregate")
+	span.SetAttribute("db.conn", connName)
+	span.SetAttribute("db.collection", collection)
+	defer span.End()
+
+	client, db, err := sl.MongoManager().Get(connName)
+	if err != nil {
+		span.SetErr(err)
+		return nil, err
+	}
+
+	var pipeline []interface{}
+	if err := json.Unmarshal([]byte(pipelineJSON), &pipeline); err != nil {
+		err = fmt.Errorf("AggregateMongo: invalid pipeline JSON: %w", err)
+		span.SetErr(err)
+		return nil, err
+	}
+
+	docs, err := client.Aggregate(db, collection, pipeline)
+	if err != nil {
+		span.SetErr(err)
+		return nil, err
+	}
+	return toDocumentMaps(docs)
+}
+
+// toDocumentMaps asserts a slice of decoded BSON documents (each
+// already a map[string]interface{}, per mongo.Unmarshal) into the
+// concrete []map[string]interface{} the Mongo builtins return.
+func toDocumentMaps(docs []interface{}) ([]map[string]interface{}, error) {
+	result := make([]map[string]interface{}, len(docs))
+	for i, doc := range docs {
+		m, ok := doc.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("mongo: expected document, got %T", doc)
+		}
+		result[i] = m
+	}
+	return result, nil
 }
 
 // GetQueryResult returns the last query result as JSON
@@ -2016,6 +2817,24 @@ func (sl *StdLib) GetQueryResult() (string, error) {
 	return "{}", nil
 }
 
+// BeginTxDB starts a transaction on the default connection and returns
+// its ID. Until CommitDB or RollbackDB ends it, every QueryDB/ExecDB
+// call runs inside that transaction instead of directly against the
+// connection, so a sequence of statements can be made atomic.
+func (sl *StdLib) BeginTxDB() (string, error) {
+	return sl.DBManager().BeginTx(context.Background(), defaultDBConnName)
+}
+
+// CommitDB commits the transaction started by BeginTxDB.
+func (sl *StdLib) CommitDB() error {
+	return sl.DBManager().CommitTx(defaultDBConnName)
+}
+
+// RollbackDB rolls back the transaction started by BeginTxDB.
+func (sl *StdLib) RollbackDB() error {
+	return sl.DBManager().RollbackTx(defaultDBConnName)
+}
+
 // SetEngineFactory sets the execution engine factory
 // This allows the HTTP server to execute handlers
 func (sl *StdLib) SetEngineFactory(factory func() interface{}) {
@@ -2094,13 +2913,13 @@ func (sl *StdLib) UseMiddleware(name string) error {
 		return fmt.Errorf("unknown middleware: %s", name)
 	}
 
-	sl.middlewareManager.Use(mw)
+	sl.Middleware().Use(mw)
 	return nil
 }
 
 // ListMiddlewares 列出所有中间件
 func (sl *StdLib) ListMiddlewares() []string {
-	return sl.middlewareManager.List()
+	return sl.Middleware().List()
 }
 
 // RemoveMiddleware 移除中间件
@@ -2113,7 +2932,7 @@ func (sl *StdLib) RemoveMiddleware(name string) error {
 	}
 
 	// Remove last middleware by default
-	sl.middlewareManager.Remove(-1)
+	sl.Middleware().Remove(-1)
 	return nil
 }
 
@@ -2126,7 +2945,6 @@ func (sl *StdLib) ClearMiddlewareManager() error {
 		return fmt.Errorf("HTTP server not started")
 	}
 
-	sl.middlewareManager.Clear()
+	sl.Middleware().Clear()
 	return nil
 }
-
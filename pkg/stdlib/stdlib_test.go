@@ -1,8 +1,13 @@
 package stdlib
 
 import (
+	"encoding/json"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+
+	"gitee.com/com_818cloud/shode/pkg/logger"
 )
 
 func TestNewStdLib(t *testing.T) {
@@ -152,3 +157,50 @@ func TestSHA256Hash(t *testing.T) {
 		t.Errorf("SHA256Hash() = %v, want %v", hash, expectedHash)
 	}
 }
+
+func TestLogInfoIncludesScriptAndLine(t *testing.T) {
+	sl := New()
+	logPath := filepath.Join(t.TempDir(), "script.log")
+	sl.SetLogger(logger.NewLogger(logger.Config{
+		Output:   logger.FileOutput,
+		Format:   logger.JSONFormat,
+		FilePath: logPath,
+	}))
+
+	sl.LogInfo("deploy.sh", 42, "starting deploy")
+
+	data, err := os.ReadFile(logPath)
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+
+	line := strings.TrimSpace(string(data))
+	var entry map[string]interface{}
+	if err := json.Unmarshal([]byte(line), &entry); err != nil {
+		t.Fatalf("Unmarshal() error = %v, line = %q", err, line)
+	}
+
+	if entry["msg"] != "starting deploy" {
+		t.Errorf("msg = %v, want %v", entry["msg"], "starting deploy")
+	}
+
+	fields, ok := entry["fields"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("fields missing or wrong type: %v", entry["fields"])
+	}
+	if fields["script"] != "deploy.sh" {
+		t.Errorf("fields[script] = %v, want %v", fields["script"], "deploy.sh")
+	}
+	if fields["line"] != float64(42) {
+		t.Errorf("fields[line] = %v, want %v", fields["line"], 42)
+	}
+}
+
+func TestLogWarnAndLogErrorUseDefaultLoggerWhenUnset(t *testing.T) {
+	sl := New()
+
+	// Without SetLogger, these should fall back to logger.DefaultLogger
+	// and simply not panic.
+	sl.LogWarn("script.sh", 1, "warning")
+	sl.LogError("script.sh", 2, "error")
+}
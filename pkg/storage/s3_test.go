@@ -0,0 +1,160 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testS3Storage builds an S3Storage pointed at server. Production code
+// addresses objects with a virtual-hosted-style bucket subdomain
+// ("bucket.host"), which has no DNS entry in a test - the transport
+// below dials the real server address regardless of the host the
+// client asked for, so the virtual-hosted URL still resolves.
+func testS3Storage(server *httptest.Server) *S3Storage {
+	serverAddr := strings.TrimPrefix(server.URL, "http://")
+	client := &http.Client{
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, network, addr string) (net.Conn, error) {
+				return net.Dial(network, serverAddr)
+			},
+		},
+	}
+	return &S3Storage{
+		bucket:          "test-bucket",
+		region:          "us-east-1",
+		accessKeyID:     "AKIDTEST",
+		secretAccessKey: "secret",
+		endpoint:        serverAddr,
+		useSSL:          false,
+		httpClient:      client,
+	}
+}
+
+func TestUploadSignsAndSendsBody(t *testing.T) {
+	var gotAuth, gotBody string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = r.Header.Get("Authorization")
+		body, _ := io.ReadAll(r.Body)
+		gotBody = string(body)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	s := testS3Storage(server)
+	if err := s.Upload(context.Background(), "artifacts/app.tar.gz", strings.NewReader("payload")); err != nil {
+		t.Fatalf("Upload returned error: %v", err)
+	}
+	if gotBody != "payload" {
+		t.Errorf("expected server to receive the upload body, got %q", gotBody)
+	}
+	if !strings.HasPrefix(gotAuth, "AWS4-HMAC-SHA256 Credential=AKIDTEST/") {
+		t.Errorf("expected a SigV4 Authorization header, got %q", gotAuth)
+	}
+}
+
+func TestDownloadReturnsBody(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("contents"))
+	}))
+	defer server.Close()
+
+	s := testS3Storage(server)
+	body, err := s.Download(context.Background(), "artifacts/app.tar.gz")
+	if err != nil {
+		t.Fatalf("Download returned error: %v", err)
+	}
+	defer body.Close()
+	data, _ := io.ReadAll(body)
+	if string(data) != "contents" {
+		t.Errorf("expected downloaded contents, got %q", data)
+	}
+}
+
+func TestDownloadSurfacesNotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte("NoSuchKey"))
+	}))
+	defer server.Close()
+
+	s := testS3Storage(server)
+	if _, err := s.Download(context.Background(), "missing"); err == nil {
+		t.Fatal("expected an error downloading a missing key")
+	}
+}
+
+func TestExistsDistinguishesFoundFromMissing(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/present" {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	s := testS3Storage(server)
+	ok, err := s.Exists(context.Background(), "present")
+	if err != nil || !ok {
+		t.Fatalf("expected present to exist, got ok=%v err=%v", ok, err)
+	}
+	ok, err = s.Exists(context.Background(), "absent")
+	if err != nil || ok {
+		t.Fatalf("expected absent to not exist, got ok=%v err=%v", ok, err)
+	}
+}
+
+func TestListParsesListBucketResult(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/xml")
+		w.Write([]byte(`<?xml version="1.0" encoding="UTF-8"?>
+<ListBucketResult><Contents><Key>a/one.txt</Key></Contents><Contents><Key>a/two.txt</Key></Contents></ListBucketResult>`))
+	}))
+	defer server.Close()
+
+	s := testS3Storage(server)
+	keys, err := s.List(context.Background(), "a/")
+	if err != nil {
+		t.Fatalf("List returned error: %v", err)
+	}
+	if len(keys) != 2 || keys[0] != "a/one.txt" || keys[1] != "a/two.txt" {
+		t.Errorf("unexpected keys: %v", keys)
+	}
+}
+
+func TestPresignedURLIncludesSignatureAndExpiry(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	s := testS3Storage(server)
+	u, err := s.PresignedURL(context.Background(), "artifacts/app.tar.gz", 15*time.Minute)
+	if err != nil {
+		t.Fatalf("PresignedURL returned error: %v", err)
+	}
+	if !strings.Contains(u, "X-Amz-Signature=") {
+		t.Errorf("expected a signed URL, got %q", u)
+	}
+	if !strings.Contains(u, "X-Amz-Expires=900") {
+		t.Errorf("expected a 900s expiry, got %q", u)
+	}
+}
+
+func TestDeletePropagatesServerError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte("AccessDenied"))
+	}))
+	defer server.Close()
+
+	s := testS3Storage(server)
+	err := s.Delete(context.Background(), "protected")
+	if err == nil || !strings.Contains(err.Error(), "AccessDenied") {
+		t.Fatalf("expected the server's AccessDenied message to surface, got %v", err)
+	}
+}
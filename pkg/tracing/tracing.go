@@ -0,0 +1,280 @@
+// Package tracing provides a minimal, dependency-free span tracer for
+// the execution engine. It follows the same hand-rolled OTLP/HTTP
+// export approach as pkg/logger's log exporter and
+// pkg/serverless/tracing.go's span exporter, but adds the two things
+// those callers didn't need: per-span attributes and parent/child
+// relationships propagated through context.Context, so a script's
+// execution, the commands it runs, and the modules/DB calls those
+// commands make can be correlated into a single trace.
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Span is one timed, named segment of execution, optionally tagged
+// with attributes (e.g. script path, command name, node position) and
+// linked to the span that started it.
+type Span struct {
+	TraceID      string
+	SpanID       string
+	ParentSpanID string
+	Name         string
+	StartTime    time.Time
+	EndTime      time.Time
+	Attributes   map[string]string
+	Err          string
+}
+
+// Duration is how long the span took.
+func (s Span) Duration() time.Duration {
+	return s.EndTime.Sub(s.StartTime)
+}
+
+// SpanExporter receives finished Spans, so a collector (or a test
+// double) can observe them without Tracer depending on a specific
+// tracing backend.
+type SpanExporter interface {
+	Export(spans []Span) error
+}
+
+// exportSpans hands spans off to exporter on a background goroutine, so
+// a slow or unreachable collector can't add latency to the code path
+// that produced them. A nil exporter (the default, tracing disabled)
+// is a no-op.
+func exportSpans(exporter SpanExporter, spans []Span) {
+	if exporter == nil || len(spans) == 0 {
+		return
+	}
+	go exporter.Export(spans)
+}
+
+// Tracer starts and exports Spans for one execution engine. The zero
+// value is not usable; create one with NewTracer.
+type Tracer struct {
+	exporter SpanExporter
+}
+
+// NewTracer creates a Tracer exporting finished spans via exporter.
+// Passing a nil exporter is valid and makes Start/End a cheap no-op,
+// which is the default until something opts into tracing.
+func NewTracer(exporter SpanExporter) *Tracer {
+	return &Tracer{exporter: exporter}
+}
+
+type activeSpanKey struct{}
+
+type activeSpan struct {
+	traceID string
+	spanID  string
+}
+
+// Recorder is a Span in progress. SetAttribute and SetErr may be
+// called any number of times before End, which finalizes and exports
+// it.
+type Recorder struct {
+	tracer *Tracer
+	span   Span
+}
+
+// Start begins a new span named name, parented to whatever span is
+// active in ctx, or starting a fresh trace if ctx carries none. It
+// returns a context carrying the new span as the active one (so
+// further Start calls nest beneath it) and a Recorder used to record
+// attributes and end the span.
+func (t *Tracer) Start(ctx context.Context, name string) (context.Context, *Recorder) {
+	if t == nil {
+		return ctx, nil
+	}
+
+	var traceID, parentSpanID string
+	if parent, ok := ctx.Value(activeSpanKey{}).(activeSpan); ok {
+		traceID = parent.traceID
+		parentSpanID = parent.spanID
+	} else {
+		traceID = uuid.NewString()
+	}
+
+	span := Span{
+		TraceID:      traceID,
+		SpanID:       uuid.NewString(),
+		ParentSpanID: parentSpanID,
+		Name:         name,
+		StartTime:    time.Now(),
+		Attributes:   make(map[string]string),
+	}
+
+	ctx = context.WithValue(ctx, activeSpanKey{}, activeSpan{traceID: span.TraceID, spanID: span.SpanID})
+	return ctx, &Recorder{tracer: t, span: span}
+}
+
+// SetAttribute tags the span with a key/value attribute. Calling
+// SetAttribute on a nil Recorder (tracing disabled) is a safe no-op.
+func (r *Recorder) SetAttribute(key, value string) {
+	if r == nil {
+		return
+	}
+	r.span.Attributes[key] = value
+}
+
+// SetErr records err's message on the span. A nil err is a no-op, as
+// is calling SetErr on a nil Recorder.
+func (r *Recorder) SetErr(err error) {
+	if r == nil || err == nil {
+		return
+	}
+	r.span.Err = err.Error()
+}
+
+// End finalizes the span and exports it. Calling End on a nil
+// Recorder is a safe no-op, so callers can unconditionally
+// `defer rec.End()` regardless of whether tracing is enabled.
+func (r *Recorder) End() {
+	if r == nil {
+		return
+	}
+	r.span.EndTime = time.Now()
+	exportSpans(r.tracer.exporter, []Span{r.span})
+}
+
+// OTLPSpanExporter exports Spans to an OTLP/HTTP trace collector,
+// mirroring pkg/logger's otlpWriter and pkg/serverless's
+// OTLPSpanExporter: it hand-rolls just enough of the OTLP/HTTP JSON
+// data model (protobuf JSON mapping of ExportTraceServiceRequest) to
+// carry a Span across, including its attributes and parent span id,
+// rather than taking on the OpenTelemetry SDK as a dependency.
+type OTLPSpanExporter struct {
+	endpoint string
+	resource map[string]string
+	client   *http.Client
+}
+
+// NewOTLPSpanExporter creates an OTLPSpanExporter posting to endpoint,
+// tagging every export with resource's attributes (e.g. service.name).
+func NewOTLPSpanExporter(endpoint string, resource map[string]string) *OTLPSpanExporter {
+	return &OTLPSpanExporter{
+		endpoint: endpoint,
+		resource: resource,
+		client:   &http.Client{Timeout: 5 * time.Second},
+	}
+}
+
+// Export POSTs spans to the configured OTLP/HTTP endpoint as a single
+// ExportTraceServiceRequest.
+func (e *OTLPSpanExporter) Export(spans []Span) error {
+	payload, err := buildOTLPTraceRequest(spans, e.resource)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, e.endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("tracing: OTLP trace export failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("tracing: OTLP collector returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// otlpAnyValue and otlpKeyValue mirror the matching types in
+// pkg/logger's and pkg/serverless's OTLP exporters; duplicated rather
+// than shared since all three are package-private implementation
+// detail of an OTLP/HTTP JSON payload, not a type any package's API
+// needs to expose.
+type otlpAnyValue struct {
+	StringValue string `json:"stringValue"`
+}
+
+type otlpKeyValue struct {
+	Key   string       `json:"key"`
+	Value otlpAnyValue `json:"value"`
+}
+
+// otlpSpan, otlpScopeSpans, otlpResourceSpans, and otlpTraceRequest
+// mirror just enough of the OTLP/HTTP JSON trace data model to carry a
+// Span, its attributes, and its parent span id across.
+type otlpSpan struct {
+	TraceID           string         `json:"traceId"`
+	SpanID            string         `json:"spanId"`
+	ParentSpanID      string         `json:"parentSpanId,omitempty"`
+	Name              string         `json:"name"`
+	StartTimeUnixNano string         `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string         `json:"endTimeUnixNano"`
+	Attributes        []otlpKeyValue `json:"attributes,omitempty"`
+	Status            *otlpStatus    `json:"status,omitempty"`
+}
+
+type otlpStatus struct {
+	Code    int    `json:"code"`
+	Message string `json:"message,omitempty"`
+}
+
+type otlpScopeSpans struct {
+	Spans []otlpSpan `json:"spans"`
+}
+
+type otlpTraceResource struct {
+	Attributes []otlpKeyValue `json:"attributes,omitempty"`
+}
+
+type otlpResourceSpans struct {
+	Resource   otlpTraceResource `json:"resource"`
+	ScopeSpans []otlpScopeSpans  `json:"scopeSpans"`
+}
+
+type otlpTraceRequest struct {
+	ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+}
+
+// otlpStatusCodeError is the OTLP Status.code value for a span that
+// ended with an error (OTLP's STATUS_CODE_ERROR).
+const otlpStatusCodeError = 2
+
+func buildOTLPTraceRequest(spans []Span, resource map[string]string) ([]byte, error) {
+	records := make([]otlpSpan, len(spans))
+	for i, s := range spans {
+		record := otlpSpan{
+			TraceID:           s.TraceID,
+			SpanID:            s.SpanID,
+			ParentSpanID:      s.ParentSpanID,
+			Name:              s.Name,
+			StartTimeUnixNano: fmt.Sprintf("%d", s.StartTime.UnixNano()),
+			EndTimeUnixNano:   fmt.Sprintf("%d", s.EndTime.UnixNano()),
+		}
+		for k, v := range s.Attributes {
+			record.Attributes = append(record.Attributes, otlpKeyValue{Key: k, Value: otlpAnyValue{StringValue: v}})
+		}
+		if s.Err != "" {
+			record.Status = &otlpStatus{Code: otlpStatusCodeError, Message: s.Err}
+		}
+		records[i] = record
+	}
+
+	resourceAttrs := make([]otlpKeyValue, 0, len(resource))
+	for k, v := range resource {
+		resourceAttrs = append(resourceAttrs, otlpKeyValue{Key: k, Value: otlpAnyValue{StringValue: v}})
+	}
+
+	request := otlpTraceRequest{
+		ResourceSpans: []otlpResourceSpans{{
+			Resource:   otlpTraceResource{Attributes: resourceAttrs},
+			ScopeSpans: []otlpScopeSpans{{Spans: records}},
+		}},
+	}
+	return json.Marshal(request)
+}
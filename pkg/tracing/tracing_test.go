@@ -0,0 +1,184 @@
+package tracing
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeSpanExporter collects exported spans for assertions, guarded by a
+// mutex since exportSpans hands off to a background goroutine.
+type fakeSpanExporter struct {
+	mu    sync.Mutex
+	spans []Span
+}
+
+func (e *fakeSpanExporter) Export(spans []Span) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.spans = append(e.spans, spans...)
+	return nil
+}
+
+func (e *fakeSpanExporter) wait(t *testing.T, want int) []Span {
+	t.Helper()
+	for i := 0; i < 100; i++ {
+		e.mu.Lock()
+		got := len(e.spans)
+		e.mu.Unlock()
+		if got >= want {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return append([]Span(nil), e.spans...)
+}
+
+func TestStartEndExportsSpanWithAttributes(t *testing.T) {
+	exporter := &fakeSpanExporter{}
+	tracer := NewTracer(exporter)
+
+	_, rec := tracer.Start(context.Background(), "shode.execute")
+	rec.SetAttribute("script", "deploy.sh")
+	rec.End()
+
+	spans := exporter.wait(t, 1)
+	if len(spans) != 1 {
+		t.Fatalf("got %d spans, want 1", len(spans))
+	}
+	if spans[0].Name != "shode.execute" {
+		t.Errorf("Name = %q, want shode.execute", spans[0].Name)
+	}
+	if spans[0].Attributes["script"] != "deploy.sh" {
+		t.Errorf("script attribute = %q, want deploy.sh", spans[0].Attributes["script"])
+	}
+	if spans[0].TraceID == "" || spans[0].SpanID == "" {
+		t.Error("span was exported without a trace/span id")
+	}
+}
+
+func TestChildSpanSharesTraceIDAndRecordsParent(t *testing.T) {
+	exporter := &fakeSpanExporter{}
+	tracer := NewTracer(exporter)
+
+	ctx, parent := tracer.Start(context.Background(), "shode.execute")
+	ctx, child := tracer.Start(ctx, "shode.command")
+	child.End()
+	parent.End()
+	_ = ctx
+
+	spans := exporter.wait(t, 2)
+	if len(spans) != 2 {
+		t.Fatalf("got %d spans, want 2", len(spans))
+	}
+
+	var parentSpan, childSpan Span
+	for _, s := range spans {
+		if s.Name == "shode.execute" {
+			parentSpan = s
+		} else {
+			childSpan = s
+		}
+	}
+	if childSpan.TraceID != parentSpan.TraceID {
+		t.Errorf("child trace id = %q, want %q", childSpan.TraceID, parentSpan.TraceID)
+	}
+	if childSpan.ParentSpanID != parentSpan.SpanID {
+		t.Errorf("child parent span id = %q, want %q", childSpan.ParentSpanID, parentSpan.SpanID)
+	}
+}
+
+func TestSetErrRecordsMessage(t *testing.T) {
+	exporter := &fakeSpanExporter{}
+	tracer := NewTracer(exporter)
+
+	_, rec := tracer.Start(context.Background(), "shode.command")
+	rec.SetErr(errors.New("boom"))
+	rec.End()
+
+	spans := exporter.wait(t, 1)
+	if len(spans) != 1 {
+		t.Fatalf("got %d spans, want 1", len(spans))
+	}
+	if spans[0].Err != "boom" {
+		t.Errorf("Err = %q, want boom", spans[0].Err)
+	}
+}
+
+func TestNilTracerAndRecorderAreNoops(t *testing.T) {
+	var tracer *Tracer
+	ctx, rec := tracer.Start(context.Background(), "shode.execute")
+	if ctx == nil {
+		t.Error("Start() on a nil Tracer returned a nil context")
+	}
+	rec.SetAttribute("key", "value")
+	rec.SetErr(errors.New("boom"))
+	rec.End()
+}
+
+func TestNilExporterDoesNotPanic(t *testing.T) {
+	tracer := NewTracer(nil)
+	_, rec := tracer.Start(context.Background(), "shode.execute")
+	rec.End()
+}
+
+func TestOTLPSpanExporterPostsTraceRequest(t *testing.T) {
+	var received otlpTraceRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+			t.Errorf("failed to decode request body: %v", err)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	exporter := NewOTLPSpanExporter(server.URL, map[string]string{"service.name": "shode"})
+	span := Span{
+		TraceID:      "trace-1",
+		SpanID:       "span-1",
+		ParentSpanID: "span-0",
+		Name:         "shode.command",
+		StartTime:    time.Unix(1700000000, 0),
+		EndTime:      time.Unix(1700000001, 0),
+		Attributes:   map[string]string{"command": "QueryDB"},
+		Err:          "query failed",
+	}
+
+	if err := exporter.Export([]Span{span}); err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+
+	if len(received.ResourceSpans) != 1 || len(received.ResourceSpans[0].ScopeSpans) != 1 {
+		t.Fatalf("unexpected request shape: %+v", received)
+	}
+	spans := received.ResourceSpans[0].ScopeSpans[0].Spans
+	if len(spans) != 1 {
+		t.Fatalf("got %d spans, want 1", len(spans))
+	}
+	if spans[0].ParentSpanID != "span-0" {
+		t.Errorf("ParentSpanID = %q, want span-0", spans[0].ParentSpanID)
+	}
+	if spans[0].Status == nil || spans[0].Status.Code != otlpStatusCodeError {
+		t.Errorf("Status = %+v, want an error status", spans[0].Status)
+	}
+}
+
+func TestOTLPSpanExporterOnCollectorError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	exporter := NewOTLPSpanExporter(server.URL, nil)
+	err := exporter.Export([]Span{{Name: "shode.execute", StartTime: time.Now(), EndTime: time.Now()}})
+	if err == nil {
+		t.Error("Export() against a failing collector returned no error")
+	}
+}
@@ -0,0 +1,81 @@
+package web
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"gitee.com/com_818cloud/shode/pkg/auth"
+)
+
+// JWTAuthOptions configures the JWTAuth middleware.
+type JWTAuthOptions struct {
+	// Secret verifies the token's HMAC-SHA256 signature. Required.
+	Secret []byte
+	// HeaderName is the request header carrying the token. Defaults to
+	// "Authorization".
+	HeaderName string
+	// Scheme is the expected prefix before the token in HeaderName.
+	// Defaults to "Bearer".
+	Scheme string
+	// ErrorHandler handles a missing, malformed, or unverifiable
+	// token. Defaults to a plain 401 response.
+	ErrorHandler http.HandlerFunc
+}
+
+type claimsContextKey struct{}
+
+// ClaimsFromContext returns the JWT claims verified for the current
+// request, or nil if JWTAuth was not applied or verification failed.
+func ClaimsFromContext(r *http.Request) auth.Claims {
+	c, _ := r.Context().Value(claimsContextKey{}).(auth.Claims)
+	return c
+}
+
+// JWTAuth returns a Middleware that requires a valid bearer JWT - by
+// default in the Authorization header - rejecting the request with 401
+// when it's missing or fails verification, and otherwise making its
+// claims available via ClaimsFromContext.
+func JWTAuth(opts JWTAuthOptions) Middleware {
+	headerName := opts.HeaderName
+	if headerName == "" {
+		headerName = "Authorization"
+	}
+	scheme := opts.Scheme
+	if scheme == "" {
+		scheme = "Bearer"
+	}
+	onError := opts.ErrorHandler
+	if onError == nil {
+		onError = func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+		}
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			token := bearerToken(r.Header.Get(headerName), scheme)
+			if token == "" {
+				onError(w, r)
+				return
+			}
+
+			claims, err := auth.ParseHS256(token, opts.Secret)
+			if err != nil {
+				onError(w, r)
+				return
+			}
+
+			r = r.WithContext(context.WithValue(r.Context(), claimsContextKey{}, claims))
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func bearerToken(header, scheme string) string {
+	prefix := scheme + " "
+	if !strings.HasPrefix(header, prefix) {
+		return ""
+	}
+	return strings.TrimPrefix(header, prefix)
+}
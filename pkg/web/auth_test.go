@@ -0,0 +1,52 @@
+package web
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"gitee.com/com_818cloud/shode/pkg/auth"
+)
+
+func TestJWTAuthRejectsMissingToken(t *testing.T) {
+	r := NewRouter()
+	r.Use(JWTAuth(JWTAuthOptions{Secret: []byte("secret")}))
+	r.Get("/private", func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/private", nil)
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("expected 401, got %d", rec.Code)
+	}
+}
+
+func TestJWTAuthAllowsValidToken(t *testing.T) {
+	secret := []byte("secret")
+	token, err := auth.SignHS256(auth.NewClaims("user-1", time.Hour), secret)
+	if err != nil {
+		t.Fatalf("unexpected error signing token: %v", err)
+	}
+
+	r := NewRouter()
+	r.Use(JWTAuth(JWTAuthOptions{Secret: secret}))
+	r.Get("/private", func(w http.ResponseWriter, req *http.Request) {
+		if ClaimsFromContext(req).Subject() != "user-1" {
+			t.Error("expected claims to be available in context")
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/private", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Errorf("expected 200, got %d", rec.Code)
+	}
+}
@@ -0,0 +1,149 @@
+package web
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"gitee.com/com_818cloud/shode/pkg/validator"
+)
+
+// BindError wraps the field-level validation errors produced after a
+// request was successfully decoded, so handlers can respond with a
+// structured 422 instead of a generic 500.
+type BindError struct {
+	Errors validator.ValidationErrors
+}
+
+// Error implements the error interface.
+func (e *BindError) Error() string {
+	return e.Errors.Error()
+}
+
+// Bind decodes req into dst (which must be a non-nil struct pointer) and
+// runs its `validate` struct tags, the same tags used by the devtools
+// config validator. The request body is decoded as JSON when
+// Content-Type is application/json, as a form when it is
+// application/x-www-form-urlencoded or multipart/form-data, and
+// otherwise falls back to the URL query string (e.g. for GET requests).
+//
+// Validation failures are returned as *BindError; WriteBindError writes
+// them as a 422 response.
+func Bind(r *http.Request, dst any) error {
+	contentType := r.Header.Get("Content-Type")
+
+	switch {
+	case strings.Contains(contentType, "application/json"):
+		if err := NewBinder().BindJSON(r, dst); err != nil {
+			return err
+		}
+	case strings.Contains(contentType, "multipart/form-data"):
+		if err := r.ParseMultipartForm(32 << 20); err != nil {
+			return fmt.Errorf("failed to parse multipart form: %v", err)
+		}
+		if err := bindValues(r.Form, dst); err != nil {
+			return err
+		}
+	case strings.Contains(contentType, "application/x-www-form-urlencoded"):
+		if err := r.ParseForm(); err != nil {
+			return fmt.Errorf("failed to parse form: %v", err)
+		}
+		if err := bindValues(r.Form, dst); err != nil {
+			return err
+		}
+	default:
+		if err := bindValues(r.URL.Query(), dst); err != nil {
+			return err
+		}
+	}
+
+	if err := validator.ValidateStruct(dst); err != nil {
+		if ve, ok := err.(validator.ValidationErrors); ok {
+			return &BindError{Errors: ve}
+		}
+		return err
+	}
+
+	return nil
+}
+
+// bindValues populates dst's fields from form/query values, matching on
+// the field's "form" tag or, failing that, its lower-cased name.
+func bindValues(values url.Values, dst any) error {
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("bind target must be a pointer to a struct")
+	}
+	v = v.Elem()
+	t := v.Type()
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name := field.Tag.Get("form")
+		if name == "" {
+			name = strings.ToLower(field.Name)
+		}
+
+		raw := values.Get(name)
+		if raw == "" {
+			continue
+		}
+
+		fv := v.Field(i)
+		if !fv.CanSet() {
+			continue
+		}
+
+		if err := setFieldValue(fv, raw); err != nil {
+			return fmt.Errorf("field %q: %v", field.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// setFieldValue assigns raw to fv after converting it to fv's kind.
+func setFieldValue(fv reflect.Value, raw string) error {
+	switch fv.Kind() {
+	case reflect.String:
+		fv.SetString(raw)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return err
+		}
+		fv.SetInt(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return err
+		}
+		fv.SetFloat(f)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return err
+		}
+		fv.SetBool(b)
+	default:
+		return fmt.Errorf("unsupported field type %s", fv.Kind())
+	}
+	return nil
+}
+
+// WriteBindError writes err as a response. *BindError is written as a
+// structured 422 with one entry per failed field; any other error is
+// written as a 400.
+func WriteBindError(w http.ResponseWriter, err error) {
+	if be, ok := err.(*BindError); ok {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		json.NewEncoder(w).Encode(be.Errors)
+		return
+	}
+	http.Error(w, err.Error(), http.StatusBadRequest)
+}
@@ -0,0 +1,64 @@
+package web
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type createArticleRequest struct {
+	Title string `json:"title" validate:"required"`
+}
+
+// TestBindJSONSuccess tests decoding and validating a JSON body.
+func TestBindJSONSuccess(t *testing.T) {
+	body := strings.NewReader(`{"title":"hello"}`)
+	req := httptest.NewRequest(http.MethodPost, "/articles", body)
+	req.Header.Set("Content-Type", "application/json")
+
+	var dst createArticleRequest
+	if err := Bind(req, &dst); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if dst.Title != "hello" {
+		t.Errorf("expected title %q, got %q", "hello", dst.Title)
+	}
+}
+
+// TestBindJSONValidationFailure tests that a missing required field
+// produces a *BindError written as a 422 by WriteBindError.
+func TestBindJSONValidationFailure(t *testing.T) {
+	body := strings.NewReader(`{"title":""}`)
+	req := httptest.NewRequest(http.MethodPost, "/articles", body)
+	req.Header.Set("Content-Type", "application/json")
+
+	var dst createArticleRequest
+	err := Bind(req, &dst)
+	if err == nil {
+		t.Fatal("expected a validation error")
+	}
+
+	if _, ok := err.(*BindError); !ok {
+		t.Fatalf("expected *BindError, got %T", err)
+	}
+
+	rec := httptest.NewRecorder()
+	WriteBindError(rec, err)
+	if rec.Code != http.StatusUnprocessableEntity {
+		t.Errorf("expected status 422, got %d", rec.Code)
+	}
+}
+
+// TestBindQueryParams tests binding from the URL query string.
+func TestBindQueryParams(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/articles?title=from-query", nil)
+
+	var dst createArticleRequest
+	if err := Bind(req, &dst); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if dst.Title != "from-query" {
+		t.Errorf("expected title %q, got %q", "from-query", dst.Title)
+	}
+}
@@ -6,7 +6,6 @@ import (
 	"io"
 	"net/http"
 	"strconv"
-	"strings"
 )
 
 // Binder binds request data to structs or values
@@ -35,21 +34,10 @@ func (b *Binder) BindQueryInt(r *http.Request, key string, defaultValue int) int
 	return intValue
 }
 
-// BindPath binds path parameter (from URL pattern like /users/:id)
+// BindPath binds path parameter (from URL pattern like /users/:id),
+// extracted by Router during matching.
 func (b *Binder) BindPath(r *http.Request, key string) string {
-	// Extract from path - simplified implementation
-	// In full implementation, this would use a router that extracts path params
-	path := r.URL.Path
-	parts := strings.Split(path, "/")
-	
-	// Simple pattern matching - look for key in path
-	keyWithColon := ":" + key
-	for i, part := range parts {
-		if part == keyWithColon && i+1 < len(parts) {
-			return parts[i+1]
-		}
-	}
-	return ""
+	return PathParam(r, key)
 }
 
 // BindJSON binds JSON request body
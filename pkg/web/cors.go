@@ -0,0 +1,24 @@
+package web
+
+import (
+	"gitee.com/com_818cloud/shode/pkg/security/cors"
+)
+
+// CORSConfig configures the CORS middleware. It's an alias for
+// cors.Config so callers configuring a web.Router don't need to import
+// pkg/security/cors directly.
+type CORSConfig = cors.Config
+
+// CORS returns a Middleware that applies CORS headers - allowed origins,
+// methods, headers, credentials, and preflight caching (MaxAge) - using
+// the shared pkg/security/cors implementation, for use with
+// Router.Use or as a per-route middleware.
+func CORS(config *CORSConfig) Middleware {
+	return cors.New(config).Middleware
+}
+
+// DefaultCORS returns a permissive CORS middleware (any origin, the
+// common verbs, any header) suitable for local development.
+func DefaultCORS() Middleware {
+	return cors.Default().Middleware
+}
@@ -0,0 +1,27 @@
+package web
+
+import (
+	"net/http"
+
+	"gitee.com/com_818cloud/shode/pkg/security/csrf"
+)
+
+// CSRFConfig configures the CSRF middleware. It's an alias for
+// csrf.Config so callers configuring a web.Router don't need to import
+// pkg/security/csrf directly.
+type CSRFConfig = csrf.Config
+
+// CSRF returns a Middleware that issues a CSRF token cookie on safe
+// requests (GET/HEAD/OPTIONS/TRACE) and requires a matching token -
+// from a header, form field, or cookie per config - on unsafe ones,
+// using the shared pkg/security/csrf implementation. Pair it with
+// Session so the token survives across requests for a given user.
+func CSRF(config *CSRFConfig) Middleware {
+	return csrf.New(config).Middleware
+}
+
+// CSRFToken returns the CSRF token issued for the current request, or
+// "" if the CSRF middleware was not applied.
+func CSRFToken(r *http.Request) string {
+	return csrf.Token(r)
+}
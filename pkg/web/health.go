@@ -0,0 +1,117 @@
+package web
+
+import (
+	"context"
+	"net/http"
+	"sync"
+)
+
+// HealthStatus is the outcome of a single health check.
+type HealthStatus string
+
+const (
+	// StatusUp means the check passed.
+	StatusUp HealthStatus = "up"
+	// StatusDown means the check failed.
+	StatusDown HealthStatus = "down"
+)
+
+// HealthCheck is run by a HealthRegistry to determine whether a
+// subsystem (a database connection, a cache, a registry, ...) is
+// reachable. It should return quickly and respect ctx's deadline.
+type HealthCheck func(ctx context.Context) error
+
+// CheckResult is one check's contribution to a readiness response.
+type CheckResult struct {
+	Status HealthStatus `json:"status"`
+	Error  string       `json:"error,omitempty"`
+}
+
+// HealthRegistry collects named checks from the subsystems an
+// application depends on and serves them as /healthz, /readyz, and
+// /version handlers.
+type HealthRegistry struct {
+	mu     sync.RWMutex
+	checks map[string]HealthCheck
+}
+
+// NewHealthRegistry returns an empty HealthRegistry.
+func NewHealthRegistry() *HealthRegistry {
+	return &HealthRegistry{checks: make(map[string]HealthCheck)}
+}
+
+// Register adds a named check. Registering under a name that's
+// already in use replaces the previous check.
+func (h *HealthRegistry) Register(name string, check HealthCheck) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.checks[name] = check
+}
+
+// Run executes every registered check and returns its result, keyed
+// by name.
+func (h *HealthRegistry) Run(ctx context.Context) map[string]CheckResult {
+	h.mu.RLock()
+	checks := make(map[string]HealthCheck, len(h.checks))
+	for name, check := range h.checks {
+		checks[name] = check
+	}
+	h.mu.RUnlock()
+
+	results := make(map[string]CheckResult, len(checks))
+	for name, check := range checks {
+		if err := check(ctx); err != nil {
+			results[name] = CheckResult{Status: StatusDown, Error: err.Error()}
+		} else {
+			results[name] = CheckResult{Status: StatusUp}
+		}
+	}
+	return results
+}
+
+// LivenessHandler always reports the process as up - it answers
+// whether the server is running at all, not whether its dependencies
+// are healthy, so /healthz should never depend on Run.
+func (h *HealthRegistry) LivenessHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		JSON(w, r, http.StatusOK, map[string]HealthStatus{"status": StatusUp})
+	}
+}
+
+// ReadinessHandler runs every registered check and reports 200 with
+// each check's status if all of them passed, or 503 if any failed.
+func (h *HealthRegistry) ReadinessHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		results := h.Run(r.Context())
+
+		status := http.StatusOK
+		overall := StatusUp
+		for _, result := range results {
+			if result.Status == StatusDown {
+				status = http.StatusServiceUnavailable
+				overall = StatusDown
+				break
+			}
+		}
+
+		JSON(w, r, status, map[string]any{
+			"status": overall,
+			"checks": results,
+		})
+	}
+}
+
+// BuildInfo describes the running binary, as reported by /version.
+type BuildInfo struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit,omitempty"`
+	BuildTime string `json:"build_time,omitempty"`
+}
+
+// VersionHandler serves info as a JSON response, for mounting at
+// /version.
+func VersionHandler(info BuildInfo) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		JSON(w, r, http.StatusOK, info)
+	}
+}
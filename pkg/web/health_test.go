@@ -0,0 +1,67 @@
+package web
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestLivenessHandlerAlwaysUp(t *testing.T) {
+	registry := NewHealthRegistry()
+	registry.Register("db", func(ctx context.Context) error {
+		return errors.New("unreachable")
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/healthz", nil)
+	rec := httptest.NewRecorder()
+	registry.LivenessHandler()(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Errorf("expected status 200, got %d", rec.Code)
+	}
+}
+
+func TestReadinessHandlerReportsDownOnFailedCheck(t *testing.T) {
+	registry := NewHealthRegistry()
+	registry.Register("db", func(ctx context.Context) error { return nil })
+	registry.Register("cache", func(ctx context.Context) error {
+		return errors.New("connection refused")
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/readyz", nil)
+	rec := httptest.NewRecorder()
+	registry.ReadinessHandler()(rec, req)
+
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("expected status 503, got %d", rec.Code)
+	}
+}
+
+func TestReadinessHandlerReportsUpWhenAllChecksPass(t *testing.T) {
+	registry := NewHealthRegistry()
+	registry.Register("db", func(ctx context.Context) error { return nil })
+
+	req := httptest.NewRequest(http.MethodGet, "/readyz", nil)
+	rec := httptest.NewRecorder()
+	registry.ReadinessHandler()(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Errorf("expected status 200, got %d", rec.Code)
+	}
+}
+
+func TestVersionHandlerReportsBuildInfo(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/version", nil)
+	rec := httptest.NewRecorder()
+	VersionHandler(BuildInfo{Version: "1.2.3", Commit: "abc123"})(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Errorf("expected status 200, got %d", rec.Code)
+	}
+	if !strings.Contains(rec.Body.String(), "1.2.3") {
+		t.Errorf("expected version in response body, got %q", rec.Body.String())
+	}
+}
@@ -0,0 +1,117 @@
+package web
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"runtime/debug"
+	"time"
+
+	"gitee.com/com_818cloud/shode/pkg/logger"
+)
+
+// requestIDContextKey is the context key under which the current
+// request's ID is stored.
+type requestIDContextKey struct{}
+
+// RequestIDHeader is the header checked for an upstream-supplied
+// request ID before generating a new one.
+const RequestIDHeader = "X-Request-Id"
+
+// RequestID returns the ID assigned to r by AccessLog or Recover, or
+// "" if neither middleware has run.
+func RequestID(r *http.Request) string {
+	id, _ := r.Context().Value(requestIDContextKey{}).(string)
+	return id
+}
+
+// withRequestID returns a copy of r carrying id, reusing an
+// upstream-supplied X-Request-Id if present or generating one
+// otherwise.
+func withRequestID(r *http.Request) (*http.Request, string) {
+	id := r.Header.Get(RequestIDHeader)
+	if id == "" {
+		id = newRequestID()
+	}
+	return r.WithContext(context.WithValue(r.Context(), requestIDContextKey{}, id)), id
+}
+
+func newRequestID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return hex.EncodeToString([]byte(time.Now().String()))
+	}
+	return hex.EncodeToString(b)
+}
+
+// statusRecorder wraps a ResponseWriter to capture the status code a
+// handler wrote, since http.ResponseWriter has no way to read it back.
+type statusRecorder struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+}
+
+func (rec *statusRecorder) WriteHeader(status int) {
+	rec.status = status
+	rec.wroteHeader = true
+	rec.ResponseWriter.WriteHeader(status)
+}
+
+func (rec *statusRecorder) Write(b []byte) (int, error) {
+	if !rec.wroteHeader {
+		rec.status = http.StatusOK
+		rec.wroteHeader = true
+	}
+	return rec.ResponseWriter.Write(b)
+}
+
+// AccessLog returns middleware that assigns each request an ID
+// (reusing X-Request-Id if the caller set it) and logs its method,
+// path, status, and latency through log once the handler returns.
+func AccessLog(log *logger.Logger) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			r, requestID := withRequestID(r)
+			w.Header().Set(RequestIDHeader, requestID)
+
+			rec := &statusRecorder{ResponseWriter: w}
+			start := time.Now()
+
+			next.ServeHTTP(rec, r)
+
+			log.WithTrace(requestID).WithFields(map[string]interface{}{
+				"method":      r.Method,
+				"path":        r.URL.Path,
+				"status":      rec.status,
+				"duration_ms": time.Since(start).Milliseconds(),
+			}).Info("request completed")
+		})
+	}
+}
+
+// Recover returns middleware that converts a panic in next into a 500
+// response, logging the panic value and stack trace through log
+// instead of letting it crash the server.
+func Recover(log *logger.Logger) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			defer func() {
+				if rec := recover(); rec != nil {
+					log.WithTrace(RequestID(r)).WithFields(map[string]interface{}{
+						"method": r.Method,
+						"path":   r.URL.Path,
+						"panic":  fmt.Sprintf("%v", rec),
+						"stack":  string(debug.Stack()),
+					}).Error("panic recovered")
+
+					http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+				}
+			}()
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package web
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"gitee.com/com_818cloud/shode/pkg/logger"
+)
+
+func newTestLogger() *logger.Logger {
+	return logger.NewLogger(logger.Config{
+		Level:  logger.ERROR,
+		Format: logger.JSONFormat,
+		Output: logger.ConsoleOutput,
+	})
+}
+
+func TestAccessLogAssignsRequestID(t *testing.T) {
+	log := newTestLogger()
+	handler := AccessLog(log)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if RequestID(r) == "" {
+			t.Error("expected a request ID to be set on the request context")
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/widgets", nil)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Header().Get(RequestIDHeader) == "" {
+		t.Error("expected the response to carry an X-Request-Id header")
+	}
+	if rec.Code != http.StatusCreated {
+		t.Errorf("expected status 201, got %d", rec.Code)
+	}
+}
+
+func TestAccessLogReusesUpstreamRequestID(t *testing.T) {
+	log := newTestLogger()
+	var seen string
+	handler := AccessLog(log)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = RequestID(r)
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/widgets", nil)
+	req.Header.Set(RequestIDHeader, "upstream-id")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if seen != "upstream-id" {
+		t.Errorf("expected upstream request ID to be reused, got %q", seen)
+	}
+}
+
+func TestRecoverConvertsPanicToInternalServerError(t *testing.T) {
+	log := newTestLogger()
+	handler := Recover(log)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("boom")
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/widgets", nil)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("expected status 500, got %d", rec.Code)
+	}
+}
+
+func TestRecoverDoesNotInterfereWithNormalResponses(t *testing.T) {
+	log := newTestLogger()
+	handler := Recover(log)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/widgets", nil)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Errorf("expected status 200, got %d", rec.Code)
+	}
+}
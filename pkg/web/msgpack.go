@@ -0,0 +1,145 @@
+package web
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math"
+)
+
+// normalizeForMsgPack round-trips v through encoding/json so arbitrary
+// structs, maps, and slices collapse into the handful of shapes
+// appendMsgPack knows how to encode (nil, bool, float64, string,
+// []any, map[string]any) - the same shapes json.Unmarshal produces when
+// decoding into an interface{}.
+func normalizeForMsgPack(v any) (any, error) {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var normalized any
+	if err := json.Unmarshal(raw, &normalized); err != nil {
+		return nil, err
+	}
+	return normalized, nil
+}
+
+// encodeMsgPack encodes v as MessagePack (https://msgpack.org/). v is
+// first normalized through encoding/json so callers can pass the same
+// structs, maps, and slices they'd hand to json.Marshal; this package
+// then only has to encode the resulting nil/bool/float64/string/slice/map
+// shapes, rather than reimplementing Go's reflection-based struct
+// encoding a second time.
+func encodeMsgPack(v any) ([]byte, error) {
+	normalized, err := normalizeForMsgPack(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf []byte
+	buf, err = appendMsgPack(buf, normalized)
+	if err != nil {
+		return nil, err
+	}
+	return buf, nil
+}
+
+func appendMsgPack(buf []byte, v any) ([]byte, error) {
+	switch val := v.(type) {
+	case nil:
+		return append(buf, 0xc0), nil
+	case bool:
+		if val {
+			return append(buf, 0xc3), nil
+		}
+		return append(buf, 0xc2), nil
+	case float64:
+		return appendMsgPackNumber(buf, val), nil
+	case string:
+		return appendMsgPackString(buf, val), nil
+	case []any:
+		buf = appendMsgPackArrayHeader(buf, len(val))
+		for _, item := range val {
+			var err error
+			buf, err = appendMsgPack(buf, item)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return buf, nil
+	case map[string]any:
+		buf = appendMsgPackMapHeader(buf, len(val))
+		for key, item := range val {
+			buf = appendMsgPackString(buf, key)
+			var err error
+			buf, err = appendMsgPack(buf, item)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return buf, nil
+	default:
+		return nil, fmt.Errorf("msgpack: unsupported value type %T", v)
+	}
+}
+
+func appendMsgPackNumber(buf []byte, f float64) []byte {
+	if f == math.Trunc(f) && f >= math.MinInt64 && f <= math.MaxInt64 {
+		n := int64(f)
+		if n >= 0 && n < 128 {
+			return append(buf, byte(n))
+		}
+		if n < 0 && n >= -32 {
+			return append(buf, byte(0xe0|(n+32)))
+		}
+		buf = append(buf, 0xd3)
+		return append(buf, binary.BigEndian.AppendUint64(nil, uint64(n))...)
+	}
+
+	buf = append(buf, 0xcb)
+	bits := math.Float64bits(f)
+	return append(buf, binary.BigEndian.AppendUint64(nil, bits)...)
+}
+
+func appendMsgPackString(buf []byte, s string) []byte {
+	n := len(s)
+	switch {
+	case n < 32:
+		buf = append(buf, 0xa0|byte(n))
+	case n < 1<<8:
+		buf = append(buf, 0xd9, byte(n))
+	case n < 1<<16:
+		buf = append(buf, 0xda)
+		buf = binary.BigEndian.AppendUint16(buf, uint16(n))
+	default:
+		buf = append(buf, 0xdb)
+		buf = binary.BigEndian.AppendUint32(buf, uint32(n))
+	}
+	return append(buf, s...)
+}
+
+func appendMsgPackArrayHeader(buf []byte, n int) []byte {
+	switch {
+	case n < 16:
+		return append(buf, 0x90|byte(n))
+	case n < 1<<16:
+		buf = append(buf, 0xdc)
+		return binary.BigEndian.AppendUint16(buf, uint16(n))
+	default:
+		buf = append(buf, 0xdd)
+		return binary.BigEndian.AppendUint32(buf, uint32(n))
+	}
+}
+
+func appendMsgPackMapHeader(buf []byte, n int) []byte {
+	switch {
+	case n < 16:
+		return append(buf, 0x80|byte(n))
+	case n < 1<<16:
+		buf = append(buf, 0xde)
+		return binary.BigEndian.AppendUint16(buf, uint16(n))
+	default:
+		buf = append(buf, 0xdf)
+		return binary.BigEndian.AppendUint32(buf, uint32(n))
+	}
+}
@@ -0,0 +1,89 @@
+package web
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/google/uuid"
+)
+
+// paramsContextKey is the context key under which a route's extracted
+// path parameters are stored.
+type paramsContextKey struct{}
+
+// withPathParams returns a copy of req carrying the given path
+// parameters, retrievable later with PathParam.
+func withPathParams(req *http.Request, params map[string]string) *http.Request {
+	ctx := context.WithValue(req.Context(), paramsContextKey{}, params)
+	return req.WithContext(ctx)
+}
+
+// PathParam returns the value captured for a named (":id") or wildcard
+// ("*path") segment of the route that matched req. It returns "" if the
+// route defined no such parameter.
+func PathParam(r *http.Request, key string) string {
+	params, ok := r.Context().Value(paramsContextKey{}).(map[string]string)
+	if !ok {
+		return ""
+	}
+	return params[key]
+}
+
+// ParamInt returns the named path parameter parsed as an int, or an
+// error describing why it could not be converted.
+func ParamInt(r *http.Request, key string) (int, error) {
+	value := PathParam(r, key)
+	if value == "" {
+		return 0, fmt.Errorf("path parameter %q is missing", key)
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("path parameter %q is not a valid integer: %v", key, err)
+	}
+	return n, nil
+}
+
+// ParamUUID returns the named path parameter parsed as a UUID string, or
+// an error if it isn't a valid UUID.
+func ParamUUID(r *http.Request, key string) (string, error) {
+	value := PathParam(r, key)
+	if value == "" {
+		return "", fmt.Errorf("path parameter %q is missing", key)
+	}
+	id, err := uuid.Parse(value)
+	if err != nil {
+		return "", fmt.Errorf("path parameter %q is not a valid UUID: %v", key, err)
+	}
+	return id.String(), nil
+}
+
+// RequireParamInt returns the named path parameter as an int. On
+// conversion failure it writes a 400 response and returns ok=false, so
+// handlers can bail out immediately instead of repeating Sscanf/error
+// boilerplate:
+//
+//	id, ok := web.RequireParamInt(w, r, "id")
+//	if !ok {
+//	    return
+//	}
+func RequireParamInt(w http.ResponseWriter, r *http.Request, key string) (int, bool) {
+	n, err := ParamInt(r, key)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return 0, false
+	}
+	return n, true
+}
+
+// RequireParamUUID returns the named path parameter as a UUID string,
+// writing a 400 response and returning ok=false on conversion failure.
+func RequireParamUUID(w http.ResponseWriter, r *http.Request, key string) (string, bool) {
+	id, err := ParamUUID(r, key)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return "", false
+	}
+	return id, true
+}
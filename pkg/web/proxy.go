@@ -0,0 +1,115 @@
+package web
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"time"
+)
+
+// ProxyOptions configures Proxy.
+type ProxyOptions struct {
+	// PathRewrite rewrites the upstream request path. If nil, the
+	// original path is forwarded unchanged.
+	PathRewrite func(path string) string
+	// Headers are set on every request before it's forwarded upstream,
+	// overriding any header the client sent with the same name.
+	Headers map[string]string
+	// MaxRetries is how many additional attempts are made against the
+	// upstream after a connection-level failure (not an HTTP error
+	// status). 0 means no retries.
+	MaxRetries int
+	// RetryBackoff is how long to wait between retries. Defaults to
+	// 100ms.
+	RetryBackoff time.Duration
+	// ErrorHandler is invoked when the upstream can't be reached after
+	// all retries are exhausted. Defaults to a 502 Bad Gateway.
+	ErrorHandler func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+// Proxy returns a reverse proxy handler forwarding requests to target.
+// It supports path rewriting and header injection via opts, retries
+// failed upstream connections, and - via the standard library's
+// ReverseProxy - transparently passes through WebSocket upgrades.
+func Proxy(target *url.URL, opts ProxyOptions) http.Handler {
+	retryBackoff := opts.RetryBackoff
+	if retryBackoff <= 0 {
+		retryBackoff = 100 * time.Millisecond
+	}
+	errorHandler := opts.ErrorHandler
+	if errorHandler == nil {
+		errorHandler = func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, fmt.Sprintf("Bad Gateway: %v", err), http.StatusBadGateway)
+		}
+	}
+
+	proxy := &httputil.ReverseProxy{
+		Director: func(req *http.Request) {
+			req.URL.Scheme = target.Scheme
+			req.URL.Host = target.Host
+			req.Host = target.Host
+
+			if opts.PathRewrite != nil {
+				req.URL.Path = opts.PathRewrite(req.URL.Path)
+			}
+			for key, value := range opts.Headers {
+				req.Header.Set(key, value)
+			}
+		},
+		Transport: &retryTransport{
+			base:       http.DefaultTransport,
+			maxRetries: opts.MaxRetries,
+			backoff:    retryBackoff,
+		},
+		ErrorHandler: errorHandler,
+	}
+
+	return proxy
+}
+
+// retryTransport retries a request against the upstream when the
+// connection itself fails (refused, reset, timed out), rather than
+// when the upstream returns an HTTP error status - those are valid
+// responses and are passed through as-is.
+type retryTransport struct {
+	base       http.RoundTripper
+	maxRetries int
+	backoff    time.Duration
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// A request with a body can only be retried if that body can be
+	// replayed - GetBody lets us get a fresh reader for each attempt.
+	// Without it, the body is already drained after the first failed
+	// attempt, so we fall back to a single try.
+	maxRetries := t.maxRetries
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		maxRetries = 0
+	}
+
+	var lastErr error
+	for attempt := 0; attempt <= maxRetries; attempt++ {
+		if attempt > 0 {
+			time.Sleep(t.backoff)
+			if req.GetBody != nil {
+				body, err := req.GetBody()
+				if err != nil {
+					return nil, err
+				}
+				req.Body = body
+			}
+		}
+
+		// RoundTrip only returns an error when it couldn't complete
+		// the round trip at all (dial failure, connection reset,
+		// timeout, ...) - a response with an error status code is
+		// still a successful round trip and is returned as-is.
+		resp, err := t.base.RoundTrip(req)
+		if err == nil {
+			return resp, nil
+		}
+		lastErr = err
+	}
+	return nil, lastErr
+}
@@ -0,0 +1,111 @@
+package web
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestProxyForwardsRequestAndRewritesPath(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2/widgets" {
+			t.Errorf("expected rewritten path /v2/widgets, got %q", r.URL.Path)
+		}
+		if r.Header.Get("X-Forwarded-By") != "shode" {
+			t.Errorf("expected injected header, got %q", r.Header.Get("X-Forwarded-By"))
+		}
+		w.Write([]byte("upstream response"))
+	}))
+	defer upstream.Close()
+
+	target, _ := url.Parse(upstream.URL)
+	handler := Proxy(target, ProxyOptions{
+		PathRewrite: func(path string) string { return "/v2" + path },
+		Headers:     map[string]string{"X-Forwarded-By": "shode"},
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/widgets", nil)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	body, _ := io.ReadAll(rec.Body)
+	if string(body) != "upstream response" {
+		t.Errorf("expected upstream response body, got %q", body)
+	}
+}
+
+func TestProxyRetriesOnConnectionFailure(t *testing.T) {
+	var attempts int32
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) == 1 {
+			// Close the connection without responding to simulate a
+			// transient upstream failure on the first attempt.
+			hijacker, ok := w.(http.Hijacker)
+			if !ok {
+				t.Fatal("expected ResponseWriter to support hijacking")
+			}
+			conn, _, err := hijacker.Hijack()
+			if err != nil {
+				t.Fatalf("failed to hijack connection: %v", err)
+			}
+			conn.Close()
+			return
+		}
+		w.Write([]byte("ok"))
+	}))
+	defer upstream.Close()
+
+	target, _ := url.Parse(upstream.URL)
+	handler := Proxy(target, ProxyOptions{MaxRetries: 1, RetryBackoff: 0})
+
+	req := httptest.NewRequest(http.MethodGet, "/widgets", nil)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Errorf("expected status 200 after retry, got %d", rec.Code)
+	}
+	if atomic.LoadInt32(&attempts) != 2 {
+		t.Errorf("expected 2 attempts, got %d", attempts)
+	}
+}
+
+func TestProxyReturnsBadGatewayWhenUpstreamUnreachable(t *testing.T) {
+	target, _ := url.Parse("http://127.0.0.1:1")
+	handler := Proxy(target, ProxyOptions{})
+
+	req := httptest.NewRequest(http.MethodGet, "/widgets", nil)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusBadGateway {
+		t.Errorf("expected status 502, got %d", rec.Code)
+	}
+}
+
+func TestProxyDoesNotRetryRequestsWithUnreplayableBody(t *testing.T) {
+	var attempts int32
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		hijacker, _ := w.(http.Hijacker)
+		conn, _, _ := hijacker.Hijack()
+		conn.Close()
+	}))
+	defer upstream.Close()
+
+	target, _ := url.Parse(upstream.URL)
+	handler := Proxy(target, ProxyOptions{MaxRetries: 2, RetryBackoff: 0})
+
+	req := httptest.NewRequest(http.MethodPost, "/widgets", strings.NewReader("payload"))
+	req.GetBody = nil
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if atomic.LoadInt32(&attempts) != 1 {
+		t.Errorf("expected exactly 1 attempt for a non-replayable body, got %d", attempts)
+	}
+}
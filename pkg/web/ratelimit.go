@@ -0,0 +1,190 @@
+package web
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// RateLimitStore decides whether a request identified by key may
+// proceed. It abstracts over where the counters live, so RateLimit can
+// run against an in-memory store (NewMemoryRateLimitStore) or a shared
+// one such as Redis (NewRedisRateLimitStore) without the middleware
+// itself changing.
+type RateLimitStore interface {
+	// Allow reports whether a request for key is allowed. When it is
+	// not, retryAfter is how long the caller should wait before trying
+	// again.
+	Allow(key string) (allowed bool, retryAfter time.Duration)
+}
+
+// RateLimitOptions configures the RateLimit middleware.
+type RateLimitOptions struct {
+	// Store tracks request counts per key. Required.
+	Store RateLimitStore
+	// KeyFunc extracts the rate-limit key from a request - by client
+	// IP, an API key header, the route, or any combination. Defaults
+	// to RateLimitByIP.
+	KeyFunc func(*http.Request) string
+}
+
+// RateLimit returns a Middleware that rejects requests exceeding the
+// configured store's limit with 429 Too Many Requests and a
+// Retry-After header, for use with Router.Use or as a per-route
+// middleware.
+func RateLimit(opts RateLimitOptions) Middleware {
+	keyFunc := opts.KeyFunc
+	if keyFunc == nil {
+		keyFunc = RateLimitByIP
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			allowed, retryAfter := opts.Store.Allow(keyFunc(r))
+			if !allowed {
+				w.Header().Set("Retry-After", strconv.Itoa(int(retryAfter.Seconds()+0.5)))
+				http.Error(w, "rate limit exceeded", http.StatusTooManyRequests)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// RateLimitByIP keys on the request's remote IP address.
+func RateLimitByIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// RateLimitByHeader returns a KeyFunc that keys on the value of the
+// given request header (e.g. an API key), falling back to the client
+// IP when the header is absent.
+func RateLimitByHeader(header string) func(*http.Request) string {
+	return func(r *http.Request) string {
+		if v := r.Header.Get(header); v != "" {
+			return v
+		}
+		return RateLimitByIP(r)
+	}
+}
+
+// RateLimitByRoute keys on the request method and path, so every route
+// gets its own independent limit from a single shared store.
+func RateLimitByRoute(r *http.Request) string {
+	return r.Method + " " + r.URL.Path
+}
+
+// memoryBucket is a single key's token bucket.
+type memoryBucket struct {
+	tokens   float64
+	lastFill time.Time
+}
+
+// MemoryRateLimitStore is an in-memory, per-key token-bucket
+// RateLimitStore. It never shares state across processes; use
+// RedisRateLimitStore for multi-instance deployments.
+type MemoryRateLimitStore struct {
+	rate  float64 // tokens replenished per second
+	burst float64 // bucket capacity
+
+	mu      sync.Mutex
+	buckets map[string]*memoryBucket
+}
+
+// NewMemoryRateLimitStore creates a RateLimitStore that allows up to
+// burst requests immediately per key, refilling at rate tokens/second.
+func NewMemoryRateLimitStore(rate float64, burst int) *MemoryRateLimitStore {
+	return &MemoryRateLimitStore{
+		rate:    rate,
+		burst:   float64(burst),
+		buckets: make(map[string]*memoryBucket),
+	}
+}
+
+// Allow implements RateLimitStore.
+func (s *MemoryRateLimitStore) Allow(key string) (bool, time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &memoryBucket{tokens: s.burst, lastFill: now}
+		s.buckets[key] = b
+	}
+
+	elapsed := now.Sub(b.lastFill).Seconds()
+	b.tokens += elapsed * s.rate
+	if b.tokens > s.burst {
+		b.tokens = s.burst
+	}
+	b.lastFill = now
+
+	if b.tokens >= 1 {
+		b.tokens--
+		return true, 0
+	}
+
+	retryAfter := time.Duration((1 - b.tokens) / s.rate * float64(time.Second))
+	return false, retryAfter
+}
+
+// RedisClient is the minimal subset of a Redis client RedisRateLimitStore
+// needs - any client (go-redis, redigo, a test fake) implementing it can
+// back the store, so this package doesn't depend on a specific driver.
+type RedisClient interface {
+	// Incr atomically increments key by 1 and returns the new value,
+	// creating it with value 1 if it didn't exist.
+	Incr(key string) (int64, error)
+	// Expire sets key's time-to-live, for keys that have no TTL yet.
+	Expire(key string, ttl time.Duration) error
+	// TTL returns key's remaining time-to-live.
+	TTL(key string) (time.Duration, error)
+}
+
+// RedisRateLimitStore is a fixed-window RateLimitStore backed by a
+// Redis-compatible client, for rate limits shared across multiple
+// shode instances.
+type RedisRateLimitStore struct {
+	client RedisClient
+	limit  int64
+	window time.Duration
+}
+
+// NewRedisRateLimitStore creates a RateLimitStore allowing up to limit
+// requests per key in each window-length period, counted by client.
+func NewRedisRateLimitStore(client RedisClient, limit int, window time.Duration) *RedisRateLimitStore {
+	return &RedisRateLimitStore{client: client, limit: int64(limit), window: window}
+}
+
+// Allow implements RateLimitStore. On a Redis error it fails open
+// (allows the request) rather than rejecting traffic because the
+// counter backend is unavailable.
+func (s *RedisRateLimitStore) Allow(key string) (bool, time.Duration) {
+	windowID := time.Now().Unix() / int64(s.window.Seconds())
+	redisKey := fmt.Sprintf("shode:ratelimit:%s:%d", key, windowID)
+
+	count, err := s.client.Incr(redisKey)
+	if err != nil {
+		return true, 0
+	}
+	if count == 1 {
+		_ = s.client.Expire(redisKey, s.window)
+	}
+	if count <= s.limit {
+		return true, 0
+	}
+
+	ttl, err := s.client.TTL(redisKey)
+	if err != nil || ttl < 0 {
+		ttl = s.window
+	}
+	return false, ttl
+}
@@ -0,0 +1,110 @@
+package web
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// TestRateLimitBlocksAfterBurst tests that RateLimit allows up to the
+// burst size and then rejects with 429 and a Retry-After header.
+func TestRateLimitBlocksAfterBurst(t *testing.T) {
+	r := NewRouter()
+	r.Use(RateLimit(RateLimitOptions{
+		Store: NewMemoryRateLimitStore(1, 2),
+	}))
+	r.Get("/widgets", func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	for i := 0; i < 2; i++ {
+		req := httptest.NewRequest(http.MethodGet, "/widgets", nil)
+		req.RemoteAddr = "10.0.0.1:1234"
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("request %d: expected 200, got %d", i, rec.Code)
+		}
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/widgets", nil)
+	req.RemoteAddr = "10.0.0.1:1234"
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusTooManyRequests {
+		t.Fatalf("expected 429, got %d", rec.Code)
+	}
+	if rec.Header().Get("Retry-After") == "" {
+		t.Error("expected a Retry-After header")
+	}
+}
+
+// TestRateLimitKeysIndependently tests that requests from different
+// keys (here, different IPs) each get their own bucket.
+func TestRateLimitKeysIndependently(t *testing.T) {
+	store := NewMemoryRateLimitStore(1, 1)
+	r := NewRouter()
+	r.Use(RateLimit(RateLimitOptions{Store: store}))
+	r.Get("/widgets", func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	for _, ip := range []string{"10.0.0.1:1", "10.0.0.2:1"} {
+		req := httptest.NewRequest(http.MethodGet, "/widgets", nil)
+		req.RemoteAddr = ip
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected 200 for %s, got %d", ip, rec.Code)
+		}
+	}
+}
+
+// fakeRedisClient is an in-memory stand-in for a real Redis client,
+// used only to exercise RedisRateLimitStore's logic.
+type fakeRedisClient struct {
+	counts map[string]int64
+	ttls   map[string]time.Duration
+}
+
+func newFakeRedisClient() *fakeRedisClient {
+	return &fakeRedisClient{counts: make(map[string]int64), ttls: make(map[string]time.Duration)}
+}
+
+func (f *fakeRedisClient) Incr(key string) (int64, error) {
+	f.counts[key]++
+	return f.counts[key], nil
+}
+
+func (f *fakeRedisClient) Expire(key string, ttl time.Duration) error {
+	f.ttls[key] = ttl
+	return nil
+}
+
+func (f *fakeRedisClient) TTL(key string) (time.Duration, error) {
+	return f.ttls[key], nil
+}
+
+// TestRedisRateLimitStoreBlocksOverLimit tests that RedisRateLimitStore
+// rejects once a key exceeds its limit within the current window.
+func TestRedisRateLimitStoreBlocksOverLimit(t *testing.T) {
+	client := newFakeRedisClient()
+	store := NewRedisRateLimitStore(client, 2, time.Minute)
+
+	for i := 0; i < 2; i++ {
+		allowed, _ := store.Allow("user-1")
+		if !allowed {
+			t.Fatalf("request %d: expected allowed", i)
+		}
+	}
+
+	allowed, retryAfter := store.Allow("user-1")
+	if allowed {
+		t.Fatal("expected third request to be blocked")
+	}
+	if retryAfter <= 0 {
+		t.Error("expected a positive retry-after duration")
+	}
+}
@@ -0,0 +1,93 @@
+// Package web response helpers. These replace the respondJSON/
+// respondError pair that each example app (blog, chat, microservices)
+// used to define for itself, and add Accept-header negotiation so the
+// same handler can serve JSON, XML, or MessagePack clients.
+package web
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// JSON writes data as a JSON response with the given status code. If
+// the request's Accept header prefers XML or MessagePack, the response
+// is negotiated to that format instead; see Negotiate.
+func JSON(w http.ResponseWriter, r *http.Request, status int, data any) error {
+	return Negotiate(w, r, status, data)
+}
+
+// Error writes err as a negotiated error response, shaped as
+// {"error": "..."} (or the XML/MsgPack equivalent).
+func Error(w http.ResponseWriter, r *http.Request, status int, err error) error {
+	return Negotiate(w, r, status, map[string]string{"error": err.Error()})
+}
+
+// NoContent writes an empty 204 No Content response.
+func NoContent(w http.ResponseWriter) {
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Stream copies src to the response body as it's read, setting
+// Content-Type first so the headers are flushed before any data a slow
+// producer might trickle in. Useful for proxying or for handlers that
+// produce output incrementally.
+func Stream(w http.ResponseWriter, contentType string, src io.Reader) error {
+	w.Header().Set("Content-Type", contentType)
+	_, err := io.Copy(w, src)
+	return err
+}
+
+// Negotiate writes data in the format requested by the request's Accept
+// header - application/json (the default), application/xml, or
+// application/x-msgpack - with the given status code.
+func Negotiate(w http.ResponseWriter, r *http.Request, status int, data any) error {
+	switch negotiateContentType(r) {
+	case contentTypeXML:
+		w.Header().Set("Content-Type", "application/xml")
+		w.WriteHeader(status)
+		return xml.NewEncoder(w).Encode(data)
+	case contentTypeMsgPack:
+		body, err := encodeMsgPack(data)
+		if err != nil {
+			return err
+		}
+		w.Header().Set("Content-Type", "application/x-msgpack")
+		w.WriteHeader(status)
+		_, err = w.Write(body)
+		return err
+	default:
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		return json.NewEncoder(w).Encode(data)
+	}
+}
+
+type negotiatedContentType int
+
+const (
+	contentTypeJSON negotiatedContentType = iota
+	contentTypeXML
+	contentTypeMsgPack
+)
+
+// negotiateContentType picks a response format from the request's
+// Accept header, preferring the earliest match and defaulting to JSON
+// when the header is absent, empty, or "*/*".
+func negotiateContentType(r *http.Request) negotiatedContentType {
+	accept := r.Header.Get("Accept")
+	for _, part := range strings.Split(accept, ",") {
+		mediaType := strings.TrimSpace(strings.SplitN(part, ";", 2)[0])
+		switch mediaType {
+		case "application/xml", "text/xml":
+			return contentTypeXML
+		case "application/x-msgpack", "application/msgpack":
+			return contentTypeMsgPack
+		case "application/json", "*/*", "":
+			return contentTypeJSON
+		}
+	}
+	return contentTypeJSON
+}
@@ -0,0 +1,92 @@
+package web
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestJSONDefaultsToJSON tests that JSON responds with application/json
+// when the request sends no Accept header.
+func TestJSONDefaultsToJSON(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/articles", nil)
+	rec := httptest.NewRecorder()
+
+	if err := JSON(rec, req, http.StatusOK, map[string]string{"status": "ok"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if ct := rec.Header().Get("Content-Type"); ct != "application/json" {
+		t.Errorf("expected application/json, got %q", ct)
+	}
+
+	var decoded map[string]string
+	if err := json.Unmarshal(rec.Body.Bytes(), &decoded); err != nil {
+		t.Fatalf("failed to decode body: %v", err)
+	}
+	if decoded["status"] != "ok" {
+		t.Errorf("expected status %q, got %q", "ok", decoded["status"])
+	}
+}
+
+// TestJSONNegotiatesXML tests that an Accept: application/xml header
+// switches the response format.
+func TestJSONNegotiatesXML(t *testing.T) {
+	type payload struct {
+		Status string `xml:"status"`
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/articles", nil)
+	req.Header.Set("Accept", "application/xml")
+	rec := httptest.NewRecorder()
+
+	if err := JSON(rec, req, http.StatusOK, payload{Status: "ok"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if ct := rec.Header().Get("Content-Type"); ct != "application/xml" {
+		t.Errorf("expected application/xml, got %q", ct)
+	}
+
+	var decoded payload
+	if err := xml.Unmarshal(rec.Body.Bytes(), &decoded); err != nil {
+		t.Fatalf("failed to decode body: %v", err)
+	}
+	if decoded.Status != "ok" {
+		t.Errorf("expected status %q, got %q", "ok", decoded.Status)
+	}
+}
+
+// TestJSONNegotiatesMsgPack tests that an Accept: application/x-msgpack
+// header produces a non-empty binary body with the right content type.
+func TestJSONNegotiatesMsgPack(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/articles", nil)
+	req.Header.Set("Accept", "application/x-msgpack")
+	rec := httptest.NewRecorder()
+
+	if err := JSON(rec, req, http.StatusOK, map[string]any{"count": 3}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if ct := rec.Header().Get("Content-Type"); ct != "application/x-msgpack" {
+		t.Errorf("expected application/x-msgpack, got %q", ct)
+	}
+	if rec.Body.Len() == 0 {
+		t.Error("expected a non-empty msgpack body")
+	}
+}
+
+// TestNoContent tests that NoContent writes a 204 with no body.
+func TestNoContent(t *testing.T) {
+	rec := httptest.NewRecorder()
+	NoContent(rec)
+
+	if rec.Code != http.StatusNoContent {
+		t.Errorf("expected status 204, got %d", rec.Code)
+	}
+	if rec.Body.Len() != 0 {
+		t.Errorf("expected empty body, got %q", rec.Body.String())
+	}
+}
@@ -3,12 +3,22 @@ package web
 
 import (
 	"net/http"
+	"strings"
 	"sync"
 )
 
+// route 保存一个已注册路由的处理函数、路径段以及其专属中间件。路径按 "/"
+// 拆分为段以支持 ":name" 命名参数和 "*name" 通配符段。
+type route struct {
+	path       string
+	segments   []string
+	handler    http.HandlerFunc
+	middleware []Middleware
+}
+
 // Router HTTP 路由器
 type Router struct {
-	routes     map[string]map[string]http.HandlerFunc
+	routes     map[string][]*route
 	middleware []Middleware
 	mu         sync.RWMutex
 }
@@ -16,47 +26,94 @@ type Router struct {
 // NewRouter 创建路由器
 func NewRouter() *Router {
 	return &Router{
-		routes: make(map[string]map[string]http.HandlerFunc),
+		routes: make(map[string][]*route),
 	}
 }
 
-// Get 注册 GET 路由
-func (r *Router) Get(path string, handler http.HandlerFunc) {
-	r.addRoute("GET", path, handler)
+// Get 注册 GET 路由，可选附加仅对该路由生效的中间件
+func (r *Router) Get(path string, handler http.HandlerFunc, middleware ...Middleware) {
+	r.addRoute("GET", path, handler, middleware)
 }
 
-// Post 注册 POST 路由
-func (r *Router) Post(path string, handler http.HandlerFunc) {
-	r.addRoute("POST", path, handler)
+// Post 注册 POST 路由，可选附加仅对该路由生效的中间件
+func (r *Router) Post(path string, handler http.HandlerFunc, middleware ...Middleware) {
+	r.addRoute("POST", path, handler, middleware)
 }
 
-// Put 注册 PUT 路由
-func (r *Router) Put(path string, handler http.HandlerFunc) {
-	r.addRoute("PUT", path, handler)
+// Put 注册 PUT 路由，可选附加仅对该路由生效的中间件
+func (r *Router) Put(path string, handler http.HandlerFunc, middleware ...Middleware) {
+	r.addRoute("PUT", path, handler, middleware)
 }
 
-// Delete 注册 DELETE 路由
-func (r *Router) Delete(path string, handler http.HandlerFunc) {
-	r.addRoute("DELETE", path, handler)
+// Delete 注册 DELETE 路由，可选附加仅对该路由生效的中间件
+func (r *Router) Delete(path string, handler http.HandlerFunc, middleware ...Middleware) {
+	r.addRoute("DELETE", path, handler, middleware)
 }
 
-// Patch 注册 PATCH 路由
-func (r *Router) Patch(path string, handler http.HandlerFunc) {
-	r.addRoute("PATCH", path, handler)
+// Patch 注册 PATCH 路由，可选附加仅对该路由生效的中间件
+func (r *Router) Patch(path string, handler http.HandlerFunc, middleware ...Middleware) {
+	r.addRoute("PATCH", path, handler, middleware)
 }
 
 // addRoute 添加路由
-func (r *Router) addRoute(method, path string, handler http.HandlerFunc) {
+func (r *Router) addRoute(method, path string, handler http.HandlerFunc, middleware []Middleware) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	if r.routes[method] == nil {
-		r.routes[method] = make(map[string]http.HandlerFunc)
+	r.routes[method] = append(r.routes[method], &route{
+		path:       path,
+		segments:   splitPath(path),
+		handler:    handler,
+		middleware: middleware,
+	})
+}
+
+// splitPath 将路径拆分为非空段
+func splitPath(path string) []string {
+	parts := strings.Split(path, "/")
+	segments := make([]string, 0, len(parts))
+	for _, p := range parts {
+		if p != "" {
+			segments = append(segments, p)
+		}
 	}
-	r.routes[method][path] = handler
+	return segments
 }
 
-// Use 添加中间件
+// match 检查请求路径是否匹配该路由，并返回提取到的命名参数
+func (rt *route) match(requestPath string) (map[string]string, bool) {
+	reqSegments := splitPath(requestPath)
+	params := make(map[string]string)
+
+	for i, seg := range rt.segments {
+		switch {
+		case strings.HasPrefix(seg, "*"):
+			// 通配符段必须是模式中的最后一段，捕获剩余路径（可以为空）
+			name := strings.TrimPrefix(seg, "*")
+			params[name] = strings.Join(reqSegments[min(i, len(reqSegments)):], "/")
+			return params, true
+		case strings.HasPrefix(seg, ":"):
+			if i >= len(reqSegments) {
+				return nil, false
+			}
+			params[strings.TrimPrefix(seg, ":")] = reqSegments[i]
+		default:
+			if i >= len(reqSegments) || reqSegments[i] != seg {
+				return nil, false
+			}
+		}
+	}
+
+	if len(rt.segments) > 0 && strings.HasPrefix(rt.segments[len(rt.segments)-1], "*") {
+		return params, true
+	}
+	if len(reqSegments) != len(rt.segments) {
+		return nil, false
+	}
+	return params, true
+}
+
+// Use 添加应用于所有路由的全局中间件
 func (r *Router) Use(middleware Middleware) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
@@ -66,32 +123,34 @@ func (r *Router) Use(middleware Middleware) {
 // ServeHTTP 实现 http.Handler 接口
 func (r *Router) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 	r.mu.RLock()
-	handlers, methodExists := r.routes[req.Method]
-	if !methodExists {
-		http.NotFound(w, req)
-		r.mu.RUnlock()
-		return
-	}
+	candidates := r.routes[req.Method]
+	globalMiddleware := r.middleware
+	r.mu.RUnlock()
 
-	handler, pathExists := handlers[req.URL.Path]
-	if !pathExists {
-		http.NotFound(w, req)
-		r.mu.RUnlock()
-		return
+	var matched *route
+	var params map[string]string
+	for _, rt := range candidates {
+		if p, ok := rt.match(req.URL.Path); ok {
+			matched, params = rt, p
+			break
+		}
 	}
-	r.mu.RUnlock()
 
-	// 应用中间件
-	var h http.Handler = http.HandlerFunc(handler)
-	for i := len(r.middleware) - 1; i >= 0; i-- {
-		h = r.middleware[i](h)
+	// 全局中间件（如 CORS、日志、恢复）即使没有路由匹配也要执行，
+	// 这样像 CORS 预检 OPTIONS 这类从不直接注册路由的请求也能被处理。
+	var h http.Handler
+	if matched == nil {
+		h = http.HandlerFunc(http.NotFound)
+	} else {
+		req = withPathParams(req, params)
+		h = http.HandlerFunc(matched.handler)
+		for i := len(matched.middleware) - 1; i >= 0; i-- {
+			h = matched.middleware[i](h)
+		}
+	}
+	for i := len(globalMiddleware) - 1; i >= 0; i-- {
+		h = globalMiddleware[i](h)
 	}
 
 	h.ServeHTTP(w, req)
 }
-
-// PathParam 获取路径参数（简化实现）
-func PathParam(r *http.Request, key string) string {
-	// 简化实现，实际应该从路径中提取参数
-	return ""
-}
@@ -0,0 +1,158 @@
+package web
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestRouterNamedParams tests that ":name" segments are extracted and
+// retrievable via PathParam.
+func TestRouterNamedParams(t *testing.T) {
+	r := NewRouter()
+
+	var gotID string
+	r.Get("/articles/:id", func(w http.ResponseWriter, req *http.Request) {
+		gotID = PathParam(req, "id")
+		w.WriteHeader(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/articles/42", nil)
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rec.Code)
+	}
+	if gotID != "42" {
+		t.Errorf("expected id %q, got %q", "42", gotID)
+	}
+}
+
+// TestRouterWildcardParam tests that a "*name" segment captures the rest
+// of the path.
+func TestRouterWildcardParam(t *testing.T) {
+	r := NewRouter()
+
+	var gotPath string
+	r.Get("/files/*path", func(w http.ResponseWriter, req *http.Request) {
+		gotPath = PathParam(req, "path")
+		w.WriteHeader(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/files/a/b/c.txt", nil)
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rec.Code)
+	}
+	if gotPath != "a/b/c.txt" {
+		t.Errorf("expected path %q, got %q", "a/b/c.txt", gotPath)
+	}
+}
+
+// TestRequireParamIntRejectsBadInput tests that RequireParamInt writes a
+// 400 response when the path parameter doesn't parse as an int.
+func TestRequireParamIntRejectsBadInput(t *testing.T) {
+	r := NewRouter()
+
+	r.Get("/articles/:id", func(w http.ResponseWriter, req *http.Request) {
+		id, ok := RequireParamInt(w, req, "id")
+		if !ok {
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		_ = id
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/articles/not-a-number", nil)
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400, got %d", rec.Code)
+	}
+}
+
+// TestRouterGlobalMiddleware tests that middleware registered with Use
+// wraps every route.
+func TestRouterGlobalMiddleware(t *testing.T) {
+	r := NewRouter()
+
+	var order []string
+	r.Use(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			order = append(order, "global")
+			next.ServeHTTP(w, req)
+		})
+	})
+
+	r.Get("/ping", func(w http.ResponseWriter, req *http.Request) {
+		order = append(order, "handler")
+		w.WriteHeader(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/ping", nil)
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Errorf("expected status 200, got %d", rec.Code)
+	}
+	if len(order) != 2 || order[0] != "global" || order[1] != "handler" {
+		t.Errorf("expected [global handler], got %v", order)
+	}
+}
+
+// TestRouterPerRouteMiddleware tests that middleware attached to a single
+// route does not affect other routes, and runs inside global middleware.
+func TestRouterPerRouteMiddleware(t *testing.T) {
+	r := NewRouter()
+
+	var order []string
+	r.Use(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			order = append(order, "global")
+			next.ServeHTTP(w, req)
+		})
+	})
+
+	auth := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			order = append(order, "auth")
+			next.ServeHTTP(w, req)
+		})
+	}
+
+	r.Get("/secure", func(w http.ResponseWriter, req *http.Request) {
+		order = append(order, "handler")
+		w.WriteHeader(http.StatusOK)
+	}, auth)
+	r.Get("/open", func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/secure", nil)
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	if got, want := order, []string{"global", "auth", "handler"}; len(got) != len(want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	} else {
+		for i := range want {
+			if got[i] != want[i] {
+				t.Fatalf("expected %v, got %v", want, got)
+			}
+		}
+	}
+
+	order = nil
+	req = httptest.NewRequest(http.MethodGet, "/open", nil)
+	rec = httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	if len(order) != 1 || order[0] != "global" {
+		t.Errorf("expected /open to skip the auth middleware, got %v", order)
+	}
+}
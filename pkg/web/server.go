@@ -2,20 +2,37 @@
 package web
 
 import (
+	"context"
 	"fmt"
 	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"golang.org/x/crypto/acme/autocert"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
 )
 
 // Server HTTP 服务器
 type Server struct {
 	addr    string
 	handler http.Handler
+
+	// ShutdownTimeout bounds how long Start/StartTLS wait for
+	// in-flight requests to finish after SIGINT/SIGTERM before forcing
+	// the listener closed. Defaults to 10 seconds.
+	ShutdownTimeout time.Duration
+
+	httpServer *http.Server
 }
 
 // NewServer 创建服务器
 func NewServer(addr string) *Server {
 	return &Server{
-		addr: addr,
+		addr:            addr,
+		ShutdownTimeout: 10 * time.Second,
 	}
 }
 
@@ -24,10 +41,104 @@ func (s *Server) SetHandler(h http.Handler) {
 	s.handler = h
 }
 
-// Start 启动服务器
+// Start 启动服务器 - plain HTTP, with h2c so HTTP/2 clients that know
+// to skip TLS (e.g. internal service-to-service traffic) still get it.
+// Blocks until the server shuts down after SIGINT/SIGTERM, draining
+// in-flight requests for up to ShutdownTimeout.
 func (s *Server) Start() error {
 	if s.handler == nil {
 		return fmt.Errorf("no handler set")
 	}
-	return http.ListenAndServe(s.addr, s.handler)
+
+	h2s := &http2.Server{}
+	s.httpServer = &http.Server{
+		Addr:    s.addr,
+		Handler: h2c.NewHandler(s.handler, h2s),
+	}
+
+	return s.serveWithGracefulShutdown(func() error {
+		return s.httpServer.ListenAndServe()
+	})
+}
+
+// StartTLS 启动服务器 - HTTPS with HTTP/2 negotiated over ALPN.
+func (s *Server) StartTLS(certFile, keyFile string) error {
+	if s.handler == nil {
+		return fmt.Errorf("no handler set")
+	}
+
+	s.httpServer = &http.Server{Addr: s.addr, Handler: s.handler}
+	if err := http2.ConfigureServer(s.httpServer, &http2.Server{}); err != nil {
+		return fmt.Errorf("configure http2: %w", err)
+	}
+
+	return s.serveWithGracefulShutdown(func() error {
+		return s.httpServer.ListenAndServeTLS(certFile, keyFile)
+	})
+}
+
+// StartAutoTLS 启动服务器 - HTTPS with certificates issued and renewed
+// automatically by Let's Encrypt (ACME) for the given domains, cached
+// under cacheDir.
+func (s *Server) StartAutoTLS(cacheDir string, domains ...string) error {
+	if s.handler == nil {
+		return fmt.Errorf("no handler set")
+	}
+
+	manager := &autocert.Manager{
+		Prompt:     autocert.AcceptTOS,
+		HostPolicy: autocert.HostWhitelist(domains...),
+		Cache:      autocert.DirCache(cacheDir),
+	}
+
+	s.httpServer = &http.Server{
+		Addr:      s.addr,
+		Handler:   s.handler,
+		TLSConfig: manager.TLSConfig(),
+	}
+	if err := http2.ConfigureServer(s.httpServer, &http2.Server{}); err != nil {
+		return fmt.Errorf("configure http2: %w", err)
+	}
+
+	return s.serveWithGracefulShutdown(func() error {
+		return s.httpServer.ListenAndServeTLS("", "")
+	})
+}
+
+// Shutdown gracefully stops the running server, waiting up to
+// ShutdownTimeout for in-flight requests to finish.
+func (s *Server) Shutdown() error {
+	if s.httpServer == nil {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), s.ShutdownTimeout)
+	defer cancel()
+	return s.httpServer.Shutdown(ctx)
+}
+
+// serveWithGracefulShutdown runs serve in the background and, on
+// SIGINT/SIGTERM, shuts the server down gracefully before returning.
+func (s *Server) serveWithGracefulShutdown(serve func() error) error {
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(sig)
+
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- serve()
+	}()
+
+	select {
+	case err := <-serveErr:
+		if err == http.ErrServerClosed {
+			return nil
+		}
+		return err
+	case <-sig:
+		if err := s.Shutdown(); err != nil {
+			return err
+		}
+		<-serveErr
+		return nil
+	}
 }
@@ -0,0 +1,17 @@
+package web
+
+import "testing"
+
+func TestServerStartRequiresHandler(t *testing.T) {
+	s := NewServer(":0")
+	if err := s.Start(); err == nil {
+		t.Error("expected an error when starting without a handler")
+	}
+}
+
+func TestServerShutdownWithoutStartIsNoop(t *testing.T) {
+	s := NewServer(":0")
+	if err := s.Shutdown(); err != nil {
+		t.Errorf("expected no error shutting down an unstarted server, got %v", err)
+	}
+}
@@ -0,0 +1,253 @@
+package web
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// SessionStore persists session values keyed by session ID. Implementations
+// back sessions with memory, a file, Redis, or the engine's shared cache.
+type SessionStore interface {
+	// Load returns the stored values for id, or ok=false if id is
+	// unknown or has expired.
+	Load(id string) (values map[string]string, ok bool)
+	// Save persists values for id, expiring after maxAge.
+	Save(id string, values map[string]string, maxAge time.Duration) error
+	// Delete removes id's stored values.
+	Delete(id string) error
+}
+
+// Session is a single request's session data, loaded from a SessionStore
+// at the start of the request and saved back by the Session middleware
+// once the handler returns.
+type Session struct {
+	ID      string
+	isNew   bool
+	dirty   bool
+	maxAge  time.Duration
+	mu      sync.RWMutex
+	values  map[string]string
+}
+
+// Get returns a session value and whether it was set.
+func (s *Session) Get(key string) (string, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	v, ok := s.values[key]
+	return v, ok
+}
+
+// Set stores a session value.
+func (s *Session) Set(key, value string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.values[key] = value
+	s.dirty = true
+}
+
+// Delete removes a session value.
+func (s *Session) Delete(key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.values, key)
+	s.dirty = true
+}
+
+// Clear removes every value from the session.
+func (s *Session) Clear() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.values = make(map[string]string)
+	s.dirty = true
+}
+
+// IsNew reports whether this session had no existing entry in the store
+// when it was loaded.
+func (s *Session) IsNew() bool {
+	return s.isNew
+}
+
+func (s *Session) snapshot() (map[string]string, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	values := make(map[string]string, len(s.values))
+	for k, v := range s.values {
+		values[k] = v
+	}
+	return values, s.dirty
+}
+
+type sessionContextKey struct{}
+
+// SessionFromContext returns the request's Session, or nil if the
+// Session middleware was not applied to this route.
+func SessionFromContext(r *http.Request) *Session {
+	s, _ := r.Context().Value(sessionContextKey{}).(*Session)
+	return s
+}
+
+// SessionOptions configures the Session middleware.
+type SessionOptions struct {
+	// Store persists session values. Required.
+	Store SessionStore
+	// CookieName is the name of the cookie carrying the session ID.
+	// Defaults to "shode_session".
+	CookieName string
+	// HeaderName, if set, lets the session ID travel in a request/
+	// response header instead of a cookie (useful for APIs without
+	// cookie support). When set it takes precedence over the cookie.
+	HeaderName string
+	// MaxAge is how long a session lives after its last save. Defaults
+	// to 30 minutes.
+	MaxAge time.Duration
+	// CookiePath, CookieSecure and CookieHTTPOnly configure the
+	// session cookie when HeaderName is not set.
+	CookiePath     string
+	CookieSecure   bool
+	CookieHTTPOnly bool
+	CookieSameSite http.SameSite
+}
+
+// Sessions returns a Middleware that loads a Session for each request -
+// from a cookie or header named by opts - and saves it back to opts.Store
+// once the handler returns, issuing a fresh session ID when none is
+// present. Handlers access the session via SessionFromContext.
+func Sessions(opts SessionOptions) Middleware {
+	cookieName := opts.CookieName
+	if cookieName == "" {
+		cookieName = "shode_session"
+	}
+	maxAge := opts.MaxAge
+	if maxAge <= 0 {
+		maxAge = 30 * time.Minute
+	}
+	cookiePath := opts.CookiePath
+	if cookiePath == "" {
+		cookiePath = "/"
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			id, isNew := sessionID(r, opts.HeaderName, cookieName)
+
+			values, ok := opts.Store.Load(id)
+			if !ok {
+				// A client-supplied ID that isn't in the store is either
+				// expired or was never issued by us - never one we should
+				// adopt, since a client can set the cookie/header to
+				// anything it likes before we've confirmed it. Regenerate
+				// so a planted ID never gets confirmed and persisted.
+				id = newSessionID()
+				values = make(map[string]string)
+				isNew = true
+			}
+			session := &Session{ID: id, isNew: isNew, maxAge: maxAge, values: values}
+
+			if isNew {
+				if opts.HeaderName != "" {
+					w.Header().Set(opts.HeaderName, id)
+				} else {
+					http.SetCookie(w, &http.Cookie{
+						Name:     cookieName,
+						Value:    id,
+						Path:     cookiePath,
+						MaxAge:   int(maxAge.Seconds()),
+						Secure:   opts.CookieSecure,
+						HttpOnly: opts.CookieHTTPOnly,
+						SameSite: opts.CookieSameSite,
+					})
+				}
+			}
+
+			r = r.WithContext(context.WithValue(r.Context(), sessionContextKey{}, session))
+			next.ServeHTTP(w, r)
+
+			if values, dirty := session.snapshot(); dirty {
+				_ = opts.Store.Save(id, values, maxAge)
+			}
+		})
+	}
+}
+
+// sessionID reads an existing session ID from the request, or generates
+// a fresh one. The second return value reports whether the ID is new.
+func sessionID(r *http.Request, headerName, cookieName string) (string, bool) {
+	if headerName != "" {
+		if id := r.Header.Get(headerName); id != "" {
+			return id, false
+		}
+		return newSessionID(), true
+	}
+	if c, err := r.Cookie(cookieName); err == nil && c.Value != "" {
+		return c.Value, false
+	}
+	return newSessionID(), true
+}
+
+func newSessionID() string {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return hex.EncodeToString([]byte(time.Now().String()))
+	}
+	return hex.EncodeToString(b)
+}
+
+// memorySession is a stored session's values and expiry.
+type memorySession struct {
+	values    map[string]string
+	expiresAt time.Time
+}
+
+// MemorySessionStore is an in-memory SessionStore. Sessions do not
+// survive a process restart and are not shared across instances; use
+// FileSessionStore or RedisSessionStore for that.
+type MemorySessionStore struct {
+	mu       sync.Mutex
+	sessions map[string]*memorySession
+}
+
+// NewMemorySessionStore creates an empty in-memory SessionStore.
+func NewMemorySessionStore() *MemorySessionStore {
+	return &MemorySessionStore{sessions: make(map[string]*memorySession)}
+}
+
+// Load implements SessionStore.
+func (s *MemorySessionStore) Load(id string) (map[string]string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sess, ok := s.sessions[id]
+	if !ok {
+		return nil, false
+	}
+	if time.Now().After(sess.expiresAt) {
+		delete(s.sessions, id)
+		return nil, false
+	}
+
+	values := make(map[string]string, len(sess.values))
+	for k, v := range sess.values {
+		values[k] = v
+	}
+	return values, true
+}
+
+// Save implements SessionStore.
+func (s *MemorySessionStore) Save(id string, values map[string]string, maxAge time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sessions[id] = &memorySession{values: values, expiresAt: time.Now().Add(maxAge)}
+	return nil
+}
+
+// Delete implements SessionStore.
+func (s *MemorySessionStore) Delete(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.sessions, id)
+	return nil
+}
@@ -0,0 +1,179 @@
+package web
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// FileSessionStore is a SessionStore that persists each session as a
+// JSON file in dir, named after the session ID. It survives process
+// restarts but, like MemorySessionStore, is not shared across
+// instances.
+type FileSessionStore struct {
+	dir string
+}
+
+type fileSessionRecord struct {
+	Values    map[string]string `json:"values"`
+	ExpiresAt time.Time         `json:"expiresAt"`
+}
+
+// NewFileSessionStore creates a SessionStore that writes session files
+// into dir, creating it if necessary.
+func NewFileSessionStore(dir string) (*FileSessionStore, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("create session directory: %w", err)
+	}
+	return &FileSessionStore{dir: dir}, nil
+}
+
+func (s *FileSessionStore) path(id string) string {
+	return filepath.Join(s.dir, id+".json")
+}
+
+// Load implements SessionStore.
+func (s *FileSessionStore) Load(id string) (map[string]string, bool) {
+	data, err := os.ReadFile(s.path(id))
+	if err != nil {
+		return nil, false
+	}
+
+	var record fileSessionRecord
+	if err := json.Unmarshal(data, &record); err != nil {
+		return nil, false
+	}
+	if time.Now().After(record.ExpiresAt) {
+		_ = os.Remove(s.path(id))
+		return nil, false
+	}
+	return record.Values, true
+}
+
+// Save implements SessionStore.
+func (s *FileSessionStore) Save(id string, values map[string]string, maxAge time.Duration) error {
+	data, err := json.Marshal(fileSessionRecord{Values: values, ExpiresAt: time.Now().Add(maxAge)})
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(s.path(id), data, 0o600)
+}
+
+// Delete implements SessionStore.
+func (s *FileSessionStore) Delete(id string) error {
+	err := os.Remove(s.path(id))
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// StringCache is the subset of pkg/cache.Cache that EngineCacheSessionStore
+// needs, letting a web.Router share the engine's existing cache for
+// session storage instead of keeping a separate store.
+type StringCache interface {
+	Set(key, value string, ttlSeconds int)
+	Get(key string) (string, bool)
+	Delete(key string)
+}
+
+// EngineCacheSessionStore is a SessionStore backed by the execution
+// engine's shared cache (see pkg/cache), so sessions ride along with
+// whatever cache backend a deployment already runs.
+type EngineCacheSessionStore struct {
+	cache     StringCache
+	keyPrefix string
+}
+
+// NewEngineCacheSessionStore creates a SessionStore that stores each
+// session under "<keyPrefix><id>" in cache.
+func NewEngineCacheSessionStore(cache StringCache, keyPrefix string) *EngineCacheSessionStore {
+	if keyPrefix == "" {
+		keyPrefix = "session:"
+	}
+	return &EngineCacheSessionStore{cache: cache, keyPrefix: keyPrefix}
+}
+
+// Load implements SessionStore.
+func (s *EngineCacheSessionStore) Load(id string) (map[string]string, bool) {
+	raw, ok := s.cache.Get(s.keyPrefix + id)
+	if !ok {
+		return nil, false
+	}
+	var values map[string]string
+	if err := json.Unmarshal([]byte(raw), &values); err != nil {
+		return nil, false
+	}
+	return values, true
+}
+
+// Save implements SessionStore.
+func (s *EngineCacheSessionStore) Save(id string, values map[string]string, maxAge time.Duration) error {
+	data, err := json.Marshal(values)
+	if err != nil {
+		return err
+	}
+	s.cache.Set(s.keyPrefix+id, string(data), int(maxAge.Seconds()))
+	return nil
+}
+
+// Delete implements SessionStore.
+func (s *EngineCacheSessionStore) Delete(id string) error {
+	s.cache.Delete(s.keyPrefix + id)
+	return nil
+}
+
+// RedisStringClient is the minimal subset of a Redis client
+// RedisSessionStore needs - any client implementing it (go-redis,
+// redigo, a test fake) can back the store without this package
+// depending on a specific driver.
+type RedisStringClient interface {
+	Get(key string) (string, error)
+	Set(key, value string, ttl time.Duration) error
+	Del(key string) error
+}
+
+// RedisSessionStore is a SessionStore backed by a Redis-compatible
+// client, for sessions shared across multiple shode instances.
+type RedisSessionStore struct {
+	client    RedisStringClient
+	keyPrefix string
+}
+
+// NewRedisSessionStore creates a SessionStore that stores each session
+// under "<keyPrefix><id>" via client.
+func NewRedisSessionStore(client RedisStringClient, keyPrefix string) *RedisSessionStore {
+	if keyPrefix == "" {
+		keyPrefix = "shode:session:"
+	}
+	return &RedisSessionStore{client: client, keyPrefix: keyPrefix}
+}
+
+// Load implements SessionStore.
+func (s *RedisSessionStore) Load(id string) (map[string]string, bool) {
+	raw, err := s.client.Get(s.keyPrefix + id)
+	if err != nil || raw == "" {
+		return nil, false
+	}
+	var values map[string]string
+	if err := json.Unmarshal([]byte(raw), &values); err != nil {
+		return nil, false
+	}
+	return values, true
+}
+
+// Save implements SessionStore.
+func (s *RedisSessionStore) Save(id string, values map[string]string, maxAge time.Duration) error {
+	data, err := json.Marshal(values)
+	if err != nil {
+		return err
+	}
+	return s.client.Set(s.keyPrefix+id, string(data), maxAge)
+}
+
+// Delete implements SessionStore.
+func (s *RedisSessionStore) Delete(id string) error {
+	return s.client.Del(s.keyPrefix + id)
+}
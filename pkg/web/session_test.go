@@ -0,0 +1,97 @@
+package web
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestSessionPersistsAcrossRequests tests that a value set in one
+// request is visible in a later request carrying the session cookie.
+func TestSessionPersistsAcrossRequests(t *testing.T) {
+	store := NewMemorySessionStore()
+	r := NewRouter()
+	r.Use(Sessions(SessionOptions{Store: store}))
+	r.Get("/set", func(w http.ResponseWriter, req *http.Request) {
+		SessionFromContext(req).Set("user", "ada")
+		w.WriteHeader(http.StatusOK)
+	})
+	r.Get("/get", func(w http.ResponseWriter, req *http.Request) {
+		v, _ := SessionFromContext(req).Get("user")
+		w.Write([]byte(v))
+	})
+
+	setReq := httptest.NewRequest(http.MethodGet, "/set", nil)
+	setRec := httptest.NewRecorder()
+	r.ServeHTTP(setRec, setReq)
+
+	cookies := setRec.Result().Cookies()
+	if len(cookies) != 1 {
+		t.Fatalf("expected 1 cookie to be set, got %d", len(cookies))
+	}
+
+	getReq := httptest.NewRequest(http.MethodGet, "/get", nil)
+	getReq.AddCookie(cookies[0])
+	getRec := httptest.NewRecorder()
+	r.ServeHTTP(getRec, getReq)
+
+	if got := getRec.Body.String(); got != "ada" {
+		t.Errorf("expected session value %q, got %q", "ada", got)
+	}
+}
+
+// TestSessionIssuesNewIDWithoutCookie tests that a request with no
+// session cookie gets a fresh one.
+func TestSessionIssuesNewIDWithoutCookie(t *testing.T) {
+	store := NewMemorySessionStore()
+	r := NewRouter()
+	r.Use(Sessions(SessionOptions{Store: store}))
+	r.Get("/", func(w http.ResponseWriter, req *http.Request) {
+		if !SessionFromContext(req).IsNew() {
+			t.Error("expected a new session")
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	if len(rec.Result().Cookies()) != 1 {
+		t.Error("expected a session cookie to be issued")
+	}
+}
+
+// TestSessionRegeneratesUnknownCookieID tests that a client-supplied
+// session ID not found in the store is replaced with a freshly
+// generated one rather than adopted, so a planted ID never gets
+// confirmed and persisted (session fixation).
+func TestSessionRegeneratesUnknownCookieID(t *testing.T) {
+	store := NewMemorySessionStore()
+	r := NewRouter()
+	r.Use(Sessions(SessionOptions{Store: store}))
+
+	const planted = "attacker-chosen-session-id"
+	r.Get("/", func(w http.ResponseWriter, req *http.Request) {
+		if SessionFromContext(req).ID == planted {
+			t.Error("expected the planted session ID to be replaced")
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.AddCookie(&http.Cookie{Name: "shode_session", Value: planted})
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	cookies := rec.Result().Cookies()
+	if len(cookies) != 1 {
+		t.Fatalf("expected 1 cookie to be set, got %d", len(cookies))
+	}
+	if cookies[0].Value == planted {
+		t.Errorf("issued cookie still carries the planted ID %q", planted)
+	}
+	if _, ok := store.Load(planted); ok {
+		t.Error("planted ID should never have been saved to the store")
+	}
+}
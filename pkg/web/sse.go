@@ -0,0 +1,171 @@
+package web
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// SSEEvent is a single Server-Sent Events message.
+type SSEEvent struct {
+	ID    string
+	Event string
+	Data  string
+	Retry int
+}
+
+// SSEStream writes Server-Sent Events to a client, flushing after
+// every write so events arrive as they're sent rather than buffering.
+type SSEStream struct {
+	w       http.ResponseWriter
+	flusher http.Flusher
+	ctx     context.Context
+
+	mu       sync.Mutex
+	stopPing func()
+}
+
+// SSE prepares w for a Server-Sent Events response and returns a
+// stream to write events to. It fails if w doesn't support flushing,
+// since SSE requires pushing each event to the client as it's sent.
+func SSE(w http.ResponseWriter, r *http.Request) (*SSEStream, error) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		return nil, fmt.Errorf("sse: ResponseWriter does not support flushing")
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	return &SSEStream{w: w, flusher: flusher, ctx: r.Context()}, nil
+}
+
+// Send writes one event to the client and flushes it immediately.
+func (s *SSEStream) Send(event SSEEvent) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var b strings.Builder
+	if event.ID != "" {
+		fmt.Fprintf(&b, "id: %s\n", event.ID)
+	}
+	if event.Event != "" {
+		fmt.Fprintf(&b, "event: %s\n", event.Event)
+	}
+	if event.Retry > 0 {
+		fmt.Fprintf(&b, "retry: %d\n", event.Retry)
+	}
+	for _, line := range strings.Split(event.Data, "\n") {
+		fmt.Fprintf(&b, "data: %s\n", line)
+	}
+	b.WriteString("\n")
+
+	if _, err := s.w.Write([]byte(b.String())); err != nil {
+		return err
+	}
+	s.flusher.Flush()
+	return nil
+}
+
+// SendData is a shorthand for Send(SSEEvent{Data: data}).
+func (s *SSEStream) SendData(data string) error {
+	return s.Send(SSEEvent{Data: data})
+}
+
+// Ping writes a comment line, which browsers and intermediate proxies
+// ignore as event data but which keeps the connection from being
+// reaped as idle.
+func (s *SSEStream) Ping() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, err := s.w.Write([]byte(": ping\n\n")); err != nil {
+		return err
+	}
+	s.flusher.Flush()
+	return nil
+}
+
+// KeepAlive starts sending a Ping on interval until the client
+// disconnects or the returned stop function is called.
+func (s *SSEStream) KeepAlive(interval time.Duration) (stop func()) {
+	ctx, cancel := context.WithCancel(s.ctx)
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if s.Ping() != nil {
+					return
+				}
+			}
+		}
+	}()
+	return cancel
+}
+
+// Done returns a channel that's closed when the client disconnects.
+func (s *SSEStream) Done() <-chan struct{} {
+	return s.ctx.Done()
+}
+
+// ExecutionOutput mirrors the parts of pkg/engine's ExecutionResult
+// that BridgeExecutionOutput needs. It's duplicated here rather than
+// imported because pkg/engine already depends on pkg/web (through
+// pkg/stdlib's HTTP builtins), so pkg/web importing pkg/engine back
+// would be a cycle; callers that hold a real *engine.ExecutionResult
+// convert it to an ExecutionOutput at the call site.
+type ExecutionOutput struct {
+	Success  bool
+	Error    string
+	Commands []ExecutionCommandOutput
+}
+
+// ExecutionCommandOutput is one command's contribution to an
+// ExecutionOutput.
+type ExecutionCommandOutput struct {
+	Output string
+	Error  string
+}
+
+// BridgeExecutionOutput streams an engine execution result to the
+// client as SSE, one "command" event per command followed by a final
+// "done" event, so a browser can watch a script's output arrive
+// command-by-command instead of waiting for the whole run to finish.
+//
+// ExecutionEngine currently buffers each command's output and only
+// returns it once the whole script has finished running, so this
+// replays that buffered result rather than streaming it live - a
+// real live bridge would need the engine itself to expose an output
+// hook as commands run.
+func BridgeExecutionOutput(stream *SSEStream, result ExecutionOutput) error {
+	for i, cmd := range result.Commands {
+		event := SSEEvent{
+			ID:    fmt.Sprintf("%d", i),
+			Event: "command",
+			Data:  cmd.Output,
+		}
+		if cmd.Error != "" {
+			event.Data = cmd.Error
+			event.Event = "command-error"
+		}
+		if err := stream.Send(event); err != nil {
+			return err
+		}
+	}
+
+	done := "ok"
+	if !result.Success {
+		done = result.Error
+	}
+	return stream.Send(SSEEvent{Event: "done", Data: done})
+}
@@ -0,0 +1,94 @@
+package web
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSSESetsStreamingHeaders(t *testing.T) {
+	req := httptest.NewRequest("GET", "/events", nil)
+	rec := httptest.NewRecorder()
+
+	stream, err := SSE(rec, req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if stream == nil {
+		t.Fatal("expected a stream")
+	}
+
+	if got := rec.Header().Get("Content-Type"); got != "text/event-stream" {
+		t.Errorf("expected text/event-stream, got %q", got)
+	}
+	if got := rec.Header().Get("Cache-Control"); got != "no-cache" {
+		t.Errorf("expected no-cache, got %q", got)
+	}
+}
+
+func TestSSESendFormatsEvent(t *testing.T) {
+	req := httptest.NewRequest("GET", "/events", nil)
+	rec := httptest.NewRecorder()
+
+	stream, err := SSE(rec, req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := stream.Send(SSEEvent{ID: "1", Event: "tick", Data: "hello"}); err != nil {
+		t.Fatalf("unexpected error sending event: %v", err)
+	}
+
+	body := rec.Body.String()
+	for _, want := range []string{"id: 1\n", "event: tick\n", "data: hello\n"} {
+		if !strings.Contains(body, want) {
+			t.Errorf("expected body to contain %q, got %q", want, body)
+		}
+	}
+}
+
+func TestSSEDoneClosesWhenClientDisconnects(t *testing.T) {
+	req := httptest.NewRequest("GET", "/events", nil)
+	rec := httptest.NewRecorder()
+
+	stream, err := SSE(rec, req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	select {
+	case <-stream.Done():
+		t.Fatal("expected stream not to be done yet")
+	case <-time.After(10 * time.Millisecond):
+	}
+}
+
+func TestBridgeExecutionOutputSendsCommandsThenDone(t *testing.T) {
+	req := httptest.NewRequest("GET", "/events", nil)
+	rec := httptest.NewRecorder()
+
+	stream, err := SSE(rec, req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	result := ExecutionOutput{
+		Success: true,
+		Commands: []ExecutionCommandOutput{
+			{Output: "first"},
+			{Output: "second"},
+		},
+	}
+
+	if err := BridgeExecutionOutput(stream, result); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	body := rec.Body.String()
+	for _, want := range []string{"data: first\n", "data: second\n", "event: done\n", "data: ok\n"} {
+		if !strings.Contains(body, want) {
+			t.Errorf("expected body to contain %q, got %q", want, body)
+		}
+	}
+}
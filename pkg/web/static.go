@@ -0,0 +1,144 @@
+package web
+
+import (
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// StaticOptions configures Router.Static.
+type StaticOptions struct {
+	// IndexFile is served for requests that resolve to a directory.
+	// Defaults to "index.html".
+	IndexFile string
+	// SPAFallback, if set, is served (relative to dir) for any request
+	// that doesn't match a real file, so client-side routers see every
+	// path under prefix - typically "index.html".
+	SPAFallback string
+	// CacheControl, if set, is sent on every response.
+	CacheControl string
+	// Precompressed enables serving a ".br" or ".gz" sibling file when
+	// the client's Accept-Encoding allows it and the sibling exists,
+	// instead of compressing on the fly.
+	Precompressed bool
+}
+
+// Static registers a GET route serving files from dir under prefix,
+// with ETag/Last-Modified validation, Range support (via
+// http.ServeContent), directory-traversal protection, and an optional
+// SPA fallback for single-page apps.
+func (r *Router) Static(prefix, dir string, opts StaticOptions) {
+	if opts.IndexFile == "" {
+		opts.IndexFile = "index.html"
+	}
+	prefix = strings.TrimSuffix(prefix, "/")
+
+	r.Get(prefix+"/*filepath", func(w http.ResponseWriter, req *http.Request) {
+		serveStatic(w, req, dir, PathParam(req, "filepath"), opts)
+	})
+}
+
+func serveStatic(w http.ResponseWriter, r *http.Request, dir, requestedPath string, opts StaticOptions) {
+	cleanPath := path.Clean("/" + requestedPath)
+	fsPath := filepath.Join(dir, filepath.FromSlash(cleanPath))
+
+	if !isWithinDir(dir, fsPath) {
+		http.Error(w, "forbidden", http.StatusForbidden)
+		return
+	}
+
+	info, err := os.Stat(fsPath)
+	if err == nil && info.IsDir() {
+		fsPath = filepath.Join(fsPath, opts.IndexFile)
+		info, err = os.Stat(fsPath)
+	}
+
+	if err != nil || info.IsDir() {
+		if opts.SPAFallback == "" {
+			http.NotFound(w, r)
+			return
+		}
+		fsPath = filepath.Join(dir, filepath.FromSlash(opts.SPAFallback))
+		info, err = os.Stat(fsPath)
+		if err != nil {
+			http.NotFound(w, r)
+			return
+		}
+	}
+
+	if opts.CacheControl != "" {
+		w.Header().Set("Cache-Control", opts.CacheControl)
+	}
+
+	if opts.Precompressed {
+		if encoded, encoding := precompressedVariant(r, fsPath); encoded != "" {
+			if encInfo, err := os.Stat(encoded); err == nil {
+				w.Header().Set("Content-Encoding", encoding)
+				w.Header().Set("Content-Type", contentTypeFor(fsPath))
+				serveFileContent(w, r, encoded, encInfo)
+				return
+			}
+		}
+	}
+
+	serveFileContent(w, r, fsPath, info)
+}
+
+func serveFileContent(w http.ResponseWriter, r *http.Request, fsPath string, info os.FileInfo) {
+	f, err := os.Open(fsPath)
+	if err != nil {
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	defer f.Close()
+
+	w.Header().Set("ETag", fileETag(info))
+	http.ServeContent(w, r, fsPath, info.ModTime(), f)
+}
+
+func fileETag(info os.FileInfo) string {
+	return `"` + strconv.FormatInt(info.ModTime().Unix(), 16) + "-" + strconv.FormatInt(info.Size(), 16) + `"`
+}
+
+func precompressedVariant(r *http.Request, fsPath string) (path string, encoding string) {
+	accept := r.Header.Get("Accept-Encoding")
+	if strings.Contains(accept, "br") {
+		return fsPath + ".br", "br"
+	}
+	if strings.Contains(accept, "gzip") {
+		return fsPath + ".gz", "gzip"
+	}
+	return "", ""
+}
+
+func contentTypeFor(fsPath string) string {
+	ext := filepath.Ext(fsPath)
+	switch ext {
+	case ".html":
+		return "text/html; charset=utf-8"
+	case ".css":
+		return "text/css; charset=utf-8"
+	case ".js":
+		return "application/javascript; charset=utf-8"
+	case ".json":
+		return "application/json; charset=utf-8"
+	case ".svg":
+		return "image/svg+xml"
+	default:
+		return "application/octet-stream"
+	}
+}
+
+// isWithinDir reports whether fsPath, once resolved, is dir or a
+// descendant of dir - guarding against "../" traversal past the static
+// root.
+func isWithinDir(dir, fsPath string) bool {
+	rel, err := filepath.Rel(dir, fsPath)
+	if err != nil {
+		return false
+	}
+	return rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
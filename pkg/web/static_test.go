@@ -0,0 +1,72 @@
+package web
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestStaticServesFileWithETag(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "app.js"), []byte("console.log(1)"), 0o644); err != nil {
+		t.Fatalf("failed to write fixture: %v", err)
+	}
+
+	r := NewRouter()
+	r.Static("/assets", dir, StaticOptions{})
+
+	req := httptest.NewRequest(http.MethodGet, "/assets/app.js", nil)
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rec.Code)
+	}
+	if rec.Body.String() != "console.log(1)" {
+		t.Errorf("unexpected body: %q", rec.Body.String())
+	}
+	if rec.Header().Get("ETag") == "" {
+		t.Error("expected an ETag header")
+	}
+}
+
+func TestStaticRejectsDirectoryTraversal(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "app.js"), []byte("ok"), 0o644); err != nil {
+		t.Fatalf("failed to write fixture: %v", err)
+	}
+
+	r := NewRouter()
+	r.Static("/assets", dir, StaticOptions{})
+
+	req := httptest.NewRequest(http.MethodGet, "/assets/..%2f..%2fetc%2fpasswd", nil)
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	if rec.Code == http.StatusOK {
+		t.Error("expected traversal attempt to be rejected")
+	}
+}
+
+func TestStaticSPAFallback(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "index.html"), []byte("<html>spa</html>"), 0o644); err != nil {
+		t.Fatalf("failed to write fixture: %v", err)
+	}
+
+	r := NewRouter()
+	r.Static("/", dir, StaticOptions{SPAFallback: "index.html"})
+
+	req := httptest.NewRequest(http.MethodGet, "/dashboard/settings", nil)
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rec.Code)
+	}
+	if rec.Body.String() != "<html>spa</html>" {
+		t.Errorf("unexpected body: %q", rec.Body.String())
+	}
+}
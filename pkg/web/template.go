@@ -0,0 +1,111 @@
+package web
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// Renderer renders HTML views with html/template, so interpolated data
+// is escaped automatically. Every ".html" file under its root
+// directory (recursively) is parsed into one shared template set, so a
+// page can {{template "layout" .}} a layout and layouts/pages can
+// {{template "partial" .}} a partial - whatever name each file
+// {{define}}s.
+type Renderer struct {
+	dir     string
+	funcs   template.FuncMap
+	devMode bool
+
+	mu   sync.RWMutex
+	tmpl *template.Template
+}
+
+// NewRenderer creates a Renderer that will load every ".html" file
+// under dir (recursively) when Load is called.
+func NewRenderer(dir string) *Renderer {
+	return &Renderer{dir: dir, funcs: make(template.FuncMap)}
+}
+
+// Funcs registers template functions available to every view. Call
+// before Load.
+func (ren *Renderer) Funcs(funcs template.FuncMap) *Renderer {
+	for name, fn := range funcs {
+		ren.funcs[name] = fn
+	}
+	return ren
+}
+
+// DevMode, when enabled, makes Render reload every template from disk
+// before rendering - slower, but picks up edits without a restart.
+// Leave disabled in production.
+func (ren *Renderer) DevMode(enabled bool) *Renderer {
+	ren.devMode = enabled
+	return ren
+}
+
+// Load parses every ".html" file under the renderer's root directory
+// into the shared template set.
+func (ren *Renderer) Load() error {
+	files, err := findHTMLFiles(ren.dir)
+	if err != nil {
+		return fmt.Errorf("renderer: walk templates: %w", err)
+	}
+	if len(files) == 0 {
+		return fmt.Errorf("renderer: no templates found under %s", ren.dir)
+	}
+
+	tmpl, err := template.New("").Funcs(ren.funcs).ParseFiles(files...)
+	if err != nil {
+		return fmt.Errorf("renderer: parse templates: %w", err)
+	}
+
+	ren.mu.Lock()
+	ren.tmpl = tmpl
+	ren.mu.Unlock()
+	return nil
+}
+
+// Render writes the named template - a page, or a layout that in turn
+// {{template}}s a page - to w as "text/html; charset=utf-8".
+func (ren *Renderer) Render(w http.ResponseWriter, name string, data any) error {
+	if ren.devMode {
+		if err := ren.Load(); err != nil {
+			return err
+		}
+	}
+
+	ren.mu.RLock()
+	tmpl := ren.tmpl
+	ren.mu.RUnlock()
+	if tmpl == nil {
+		return fmt.Errorf("renderer: Load was never called")
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.ExecuteTemplate(&buf, name, data); err != nil {
+		return err
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+func findHTMLFiles(dir string) ([]string, error) {
+	var files []string
+	err := filepath.WalkDir(dir, func(p string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() && filepath.Ext(p) == ".html" {
+			files = append(files, p)
+		}
+		return nil
+	})
+	return files, err
+}
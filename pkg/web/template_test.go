@@ -0,0 +1,62 @@
+package web
+
+import (
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeTemplateFixtures(t *testing.T, dir string) {
+	t.Helper()
+	layout := `{{define "layout"}}<html><body>{{template "content" .}}</body></html>{{end}}`
+	page := `{{define "content"}}Hello, {{.Name}}!{{end}}`
+	if err := os.WriteFile(filepath.Join(dir, "layout.html"), []byte(layout), 0o644); err != nil {
+		t.Fatalf("failed to write layout fixture: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "page.html"), []byte(page), 0o644); err != nil {
+		t.Fatalf("failed to write page fixture: %v", err)
+	}
+}
+
+func TestRendererComposesLayoutAndPage(t *testing.T) {
+	dir := t.TempDir()
+	writeTemplateFixtures(t, dir)
+
+	ren := NewRenderer(dir)
+	if err := ren.Load(); err != nil {
+		t.Fatalf("unexpected error loading templates: %v", err)
+	}
+
+	rec := httptest.NewRecorder()
+	if err := ren.Render(rec, "layout", map[string]string{"Name": "Ada"}); err != nil {
+		t.Fatalf("unexpected error rendering: %v", err)
+	}
+
+	want := "<html><body>Hello, Ada!</body></html>"
+	if rec.Body.String() != want {
+		t.Errorf("expected %q, got %q", want, rec.Body.String())
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != "text/html; charset=utf-8" {
+		t.Errorf("expected text/html content type, got %q", ct)
+	}
+}
+
+func TestRendererEscapesData(t *testing.T) {
+	dir := t.TempDir()
+	writeTemplateFixtures(t, dir)
+
+	ren := NewRenderer(dir)
+	if err := ren.Load(); err != nil {
+		t.Fatalf("unexpected error loading templates: %v", err)
+	}
+
+	rec := httptest.NewRecorder()
+	if err := ren.Render(rec, "content", map[string]string{"Name": "<script>"}); err != nil {
+		t.Fatalf("unexpected error rendering: %v", err)
+	}
+
+	if rec.Body.String() == "Hello, <script>!" {
+		t.Error("expected data to be HTML-escaped")
+	}
+}
@@ -0,0 +1,163 @@
+package web
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path/filepath"
+)
+
+// FormFileOptions bounds and filters a multipart file upload.
+type FormFileOptions struct {
+	// MaxSize rejects the request body once it exceeds this many
+	// bytes. 0 means no limit.
+	MaxSize int64
+	// MaxMemory is the threshold (see multipart.Form) below which
+	// ParseMultipartForm keeps parts in memory instead of spooling
+	// them to a temp file. Defaults to 32MiB.
+	MaxMemory int64
+	// AllowedMIMETypes, if non-empty, rejects any upload whose sniffed
+	// content type isn't in the list.
+	AllowedMIMETypes []string
+}
+
+// UploadedFile is a parsed multipart file, ready to be inspected or
+// streamed to a destination without buffering it all into memory.
+type UploadedFile struct {
+	Filename     string
+	Size         int64
+	DetectedMIME string
+	file         multipart.File
+}
+
+// Close closes the underlying multipart file.
+func (u *UploadedFile) Close() error {
+	return u.file.Close()
+}
+
+// SaveTo streams the upload to dest under key.
+func (u *UploadedFile) SaveTo(ctx context.Context, dest UploadDestination, key string) error {
+	if _, err := u.file.Seek(0, io.SeekStart); err != nil {
+		return fmt.Errorf("upload: rewind file: %w", err)
+	}
+	return dest.Save(ctx, key, u.file)
+}
+
+// FormFile parses field from a multipart/form-data request - bounding
+// the body to opts.MaxSize, sniffing the file's content type from its
+// first 512 bytes, and rejecting it if AllowedMIMETypes doesn't permit
+// that type. Callers must Close the returned file.
+func FormFile(r *http.Request, field string, opts FormFileOptions) (*UploadedFile, error) {
+	if opts.MaxSize > 0 {
+		r.Body = http.MaxBytesReader(nil, r.Body, opts.MaxSize)
+	}
+	maxMemory := opts.MaxMemory
+	if maxMemory <= 0 {
+		maxMemory = 32 << 20
+	}
+	if err := r.ParseMultipartForm(maxMemory); err != nil {
+		return nil, fmt.Errorf("upload: parse multipart form: %w", err)
+	}
+
+	file, header, err := r.FormFile(field)
+	if err != nil {
+		return nil, fmt.Errorf("upload: read field %q: %w", field, err)
+	}
+
+	sniff := make([]byte, 512)
+	n, _ := file.Read(sniff)
+	detectedMIME := http.DetectContentType(sniff[:n])
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		file.Close()
+		return nil, fmt.Errorf("upload: rewind file: %w", err)
+	}
+
+	if len(opts.AllowedMIMETypes) > 0 && !mimeAllowed(detectedMIME, opts.AllowedMIMETypes) {
+		file.Close()
+		return nil, fmt.Errorf("upload: content type %q is not allowed", detectedMIME)
+	}
+
+	return &UploadedFile{
+		Filename:     header.Filename,
+		Size:         header.Size,
+		DetectedMIME: detectedMIME,
+		file:         file,
+	}, nil
+}
+
+func mimeAllowed(mime string, allowed []string) bool {
+	for _, a := range allowed {
+		if a == mime {
+			return true
+		}
+	}
+	return false
+}
+
+// UploadDestination streams an uploaded file's contents to wherever an
+// application wants it kept - local disk, an object store, and so on.
+type UploadDestination interface {
+	Save(ctx context.Context, key string, r io.Reader) error
+}
+
+// diskDestination streams uploads into files under a directory.
+type diskDestination struct {
+	dir string
+}
+
+// DiskDestination returns an UploadDestination that streams each
+// upload straight to a file named key under dir, creating dir if
+// necessary.
+func DiskDestination(dir string) UploadDestination {
+	return &diskDestination{dir: dir}
+}
+
+// Save implements UploadDestination.
+func (d *diskDestination) Save(ctx context.Context, key string, r io.Reader) error {
+	path := filepath.Join(d.dir, filepath.FromSlash(key))
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("upload: create directory: %w", err)
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("upload: create file: %w", err)
+	}
+	defer f.Close()
+
+	_, err = io.Copy(f, r)
+	return err
+}
+
+// ObjectPutter is the subset of pkg/storage's StorageEngine (or any
+// S3-compatible client) that ObjectStoreDestination needs.
+type ObjectPutter interface {
+	PutObject(ctx context.Context, bucket, key string, data []byte) error
+}
+
+// objectStoreDestination streams uploads into an object store. The
+// underlying PutObject takes a []byte, so unlike DiskDestination this
+// buffers the whole upload in memory before sending it.
+type objectStoreDestination struct {
+	store  ObjectPutter
+	bucket string
+}
+
+// ObjectStoreDestination returns an UploadDestination that puts each
+// upload into bucket via store (e.g. pkg/storage's StorageEngine, or
+// any S3-compatible client satisfying ObjectPutter).
+func ObjectStoreDestination(store ObjectPutter, bucket string) UploadDestination {
+	return &objectStoreDestination{store: store, bucket: bucket}
+}
+
+// Save implements UploadDestination.
+func (d *objectStoreDestination) Save(ctx context.Context, key string, r io.Reader) error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return fmt.Errorf("upload: read upload: %w", err)
+	}
+	return d.store.PutObject(ctx, d.bucket, key, data)
+}
@@ -0,0 +1,78 @@
+package web
+
+import (
+	"bytes"
+	"context"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func newMultipartUploadRequest(t *testing.T, field, filename string, content []byte) *http.Request {
+	t.Helper()
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	part, err := writer.CreateFormFile(field, filename)
+	if err != nil {
+		t.Fatalf("failed to create form file: %v", err)
+	}
+	if _, err := part.Write(content); err != nil {
+		t.Fatalf("failed to write form file content: %v", err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("failed to close multipart writer: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/upload", &body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	return req
+}
+
+func TestFormFileSniffsMIMEAndStreamsToDisk(t *testing.T) {
+	content := []byte("<html><body>hi</body></html>")
+	req := newMultipartUploadRequest(t, "file", "page.html", content)
+
+	upload, err := FormFile(req, "file", FormFileOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer upload.Close()
+
+	if upload.DetectedMIME != "text/html; charset=utf-8" {
+		t.Errorf("expected sniffed HTML mime type, got %q", upload.DetectedMIME)
+	}
+
+	dir := t.TempDir()
+	if err := upload.SaveTo(context.Background(), DiskDestination(dir), "page.html"); err != nil {
+		t.Fatalf("unexpected error saving upload: %v", err)
+	}
+
+	saved, err := os.ReadFile(filepath.Join(dir, "page.html"))
+	if err != nil {
+		t.Fatalf("unexpected error reading saved file: %v", err)
+	}
+	if !bytes.Equal(saved, content) {
+		t.Errorf("expected saved content %q, got %q", content, saved)
+	}
+}
+
+func TestFormFileRejectsDisallowedMIME(t *testing.T) {
+	req := newMultipartUploadRequest(t, "file", "page.html", []byte("<html></html>"))
+
+	_, err := FormFile(req, "file", FormFileOptions{AllowedMIMETypes: []string{"image/png"}})
+	if err == nil {
+		t.Error("expected an error for a disallowed content type")
+	}
+}
+
+func TestFormFileEnforcesMaxSize(t *testing.T) {
+	req := newMultipartUploadRequest(t, "file", "big.bin", bytes.Repeat([]byte("a"), 1024))
+
+	_, err := FormFile(req, "file", FormFileOptions{MaxSize: 10})
+	if err == nil {
+		t.Error("expected an error when the upload exceeds MaxSize")
+	}
+}
@@ -0,0 +1,157 @@
+package websocket
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"time"
+)
+
+// DeliveryStatus is the outcome of a message sent with SendWithAck.
+type DeliveryStatus int
+
+const (
+	// DeliveryPending means the message is still awaiting
+	// acknowledgment or redelivery.
+	DeliveryPending DeliveryStatus = iota
+	// DeliveryAcked means the recipient acknowledged the message.
+	DeliveryAcked
+	// DeliveryFailed means redelivery attempts were exhausted without
+	// an acknowledgment.
+	DeliveryFailed
+)
+
+// AckOptions configures how long SendWithAck waits for an
+// acknowledgment before redelivering, and how many times it retries.
+type AckOptions struct {
+	// Timeout is how long to wait for Ack before redelivering. Zero
+	// uses DefaultAckOptions.Timeout.
+	Timeout time.Duration
+	// MaxRetries is how many times to redeliver before giving up and
+	// reporting DeliveryFailed. Zero uses DefaultAckOptions.MaxRetries.
+	MaxRetries int
+}
+
+// DefaultAckOptions is used by SendWithAck for any zero-valued fields
+// in the AckOptions it's given.
+var DefaultAckOptions = AckOptions{
+	Timeout:    5 * time.Second,
+	MaxRetries: 3,
+}
+
+// pendingAck tracks a message sent with SendWithAck that hasn't been
+// acknowledged yet, so it can be redelivered on timeout.
+type pendingAck struct {
+	connID      string
+	messageType MessageType
+	data        []byte
+	attempts    int
+	maxRetries  int
+	timeout     time.Duration
+	status      chan DeliveryStatus
+	timer       *time.Timer
+}
+
+// SendWithAck sends a message to connID that requires acknowledgment
+// for at-least-once delivery: if Ack isn't called with the returned
+// message ID within opts.Timeout, the message is resent, up to
+// opts.MaxRetries times, before the returned status channel receives
+// DeliveryFailed. The Hub itself has no opinion on how an
+// acknowledgment travels back from the client - callers thread the
+// message ID through their own message protocol and call Ack when
+// they observe it.
+func (m *Manager) SendWithAck(connID string, messageType MessageType, data []byte, opts AckOptions) (messageID string, status <-chan DeliveryStatus, err error) {
+	if opts.Timeout <= 0 {
+		opts.Timeout = DefaultAckOptions.Timeout
+	}
+	if opts.MaxRetries <= 0 {
+		opts.MaxRetries = DefaultAckOptions.MaxRetries
+	}
+
+	if err := m.Send(connID, messageType, data); err != nil {
+		return "", nil, err
+	}
+
+	messageID = newMessageID()
+	pending := &pendingAck{
+		connID:      connID,
+		messageType: messageType,
+		data:        data,
+		maxRetries:  opts.MaxRetries,
+		timeout:     opts.Timeout,
+		status:      make(chan DeliveryStatus, 1),
+	}
+
+	m.ackMu.Lock()
+	if m.pendingAcks == nil {
+		m.pendingAcks = make(map[string]*pendingAck)
+	}
+	m.pendingAcks[messageID] = pending
+	pending.timer = time.AfterFunc(opts.Timeout, func() { m.redeliverOrFail(messageID) })
+	m.ackMu.Unlock()
+
+	return messageID, pending.status, nil
+}
+
+// redeliverOrFail resends a pending message, or reports it failed if
+// its retries are exhausted.
+func (m *Manager) redeliverOrFail(messageID string) {
+	m.ackMu.Lock()
+	pending, exists := m.pendingAcks[messageID]
+	if !exists {
+		m.ackMu.Unlock()
+		return
+	}
+	if pending.attempts >= pending.maxRetries {
+		delete(m.pendingAcks, messageID)
+		m.ackMu.Unlock()
+		pending.status <- DeliveryFailed
+		close(pending.status)
+		return
+	}
+	pending.attempts++
+	m.ackMu.Unlock()
+
+	if err := m.Send(pending.connID, pending.messageType, pending.data); err != nil {
+		// The connection is gone - no point retrying further.
+		m.ackMu.Lock()
+		delete(m.pendingAcks, messageID)
+		m.ackMu.Unlock()
+		pending.status <- DeliveryFailed
+		close(pending.status)
+		return
+	}
+
+	m.ackMu.Lock()
+	pending.timer = time.AfterFunc(pending.timeout, func() { m.redeliverOrFail(messageID) })
+	m.ackMu.Unlock()
+}
+
+// Ack marks a message sent by SendWithAck as acknowledged, cancelling
+// any pending redelivery. It returns false if messageID is unknown -
+// already acknowledged, failed, or never issued.
+func (m *Manager) Ack(messageID string) bool {
+	m.ackMu.Lock()
+	pending, exists := m.pendingAcks[messageID]
+	if exists {
+		delete(m.pendingAcks, messageID)
+	}
+	m.ackMu.Unlock()
+
+	if !exists {
+		return false
+	}
+	pending.timer.Stop()
+	pending.status <- DeliveryAcked
+	close(pending.status)
+	return true
+}
+
+// newMessageID generates a unique ID for a message sent with SendWithAck.
+func newMessageID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return fmt.Sprintf("msg_%d", time.Now().UnixNano())
+	}
+	return "msg_" + hex.EncodeToString(b)
+}
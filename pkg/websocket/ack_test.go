@@ -0,0 +1,112 @@
+package websocket
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestAckCancelsRedeliveryAndReportsAcked(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		wsConn, err := AcceptWebSocket(w, r)
+		if err != nil {
+			t.Errorf("AcceptWebSocket failed: %v", err)
+			return
+		}
+		conn := &Connection{ID: "conn1", Conn: wsConn, CloseChan: make(chan bool)}
+		manager := NewManager()
+		manager.AddConnection(conn)
+
+		messageID, status, err := manager.SendWithAck("conn1", TextMessage, []byte("hello"), AckOptions{
+			Timeout:    50 * time.Millisecond,
+			MaxRetries: 3,
+		})
+		if err != nil {
+			t.Errorf("SendWithAck failed: %v", err)
+			return
+		}
+
+		if !manager.Ack(messageID) {
+			t.Error("expected Ack to succeed for a pending message")
+		}
+
+		select {
+		case got := <-status:
+			if got != DeliveryAcked {
+				t.Errorf("expected DeliveryAcked, got %v", got)
+			}
+		case <-time.After(time.Second):
+			t.Error("timed out waiting for delivery status")
+		}
+	}))
+	defer server.Close()
+
+	client := dialWebSocket(t, server.URL)
+	defer client.Close()
+
+	client.SetReadDeadline(time.Now().Add(time.Second))
+	if _, _, err := client.ReadMessage(); err != nil {
+		t.Fatalf("expected the initial delivery, got error: %v", err)
+	}
+
+	client.SetReadDeadline(time.Now().Add(200 * time.Millisecond))
+	if _, _, err := client.ReadMessage(); err == nil {
+		t.Error("expected no redelivery after Ack")
+	}
+}
+
+func TestSendWithAckRedeliversUntilAckedOrExhausted(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		wsConn, err := AcceptWebSocket(w, r)
+		if err != nil {
+			t.Errorf("AcceptWebSocket failed: %v", err)
+			return
+		}
+		conn := &Connection{ID: "conn1", Conn: wsConn, CloseChan: make(chan bool)}
+		manager := NewManager()
+		manager.AddConnection(conn)
+
+		_, status, err := manager.SendWithAck("conn1", TextMessage, []byte("hello"), AckOptions{
+			Timeout:    20 * time.Millisecond,
+			MaxRetries: 2,
+		})
+		if err != nil {
+			t.Errorf("SendWithAck failed: %v", err)
+			return
+		}
+
+		select {
+		case got := <-status:
+			if got != DeliveryFailed {
+				t.Errorf("expected DeliveryFailed once retries are exhausted, got %v", got)
+			}
+		case <-time.After(time.Second):
+			t.Error("timed out waiting for delivery status")
+		}
+	}))
+	defer server.Close()
+
+	client := dialWebSocket(t, server.URL)
+	defer client.Close()
+
+	delivered := 0
+	for i := 0; i < 3; i++ {
+		client.SetReadDeadline(time.Now().Add(time.Second))
+		if _, _, err := client.ReadMessage(); err != nil {
+			t.Fatalf("expected delivery attempt %d, got error: %v", i+1, err)
+		}
+		delivered++
+	}
+
+	if delivered != 3 {
+		t.Errorf("expected the original send plus 2 retries (3 total), got %d", delivered)
+	}
+}
+
+func TestAckOfUnknownMessageReturnsFalse(t *testing.T) {
+	manager := NewManager()
+	if manager.Ack("never-sent") {
+		t.Error("expected Ack to report false for an unknown message ID")
+	}
+}
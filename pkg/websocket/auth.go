@@ -0,0 +1,91 @@
+package websocket
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// Identity is the authenticated principal behind a WebSocket
+// connection, as determined by an AuthenticateFunc.
+type Identity struct {
+	UserID   string
+	Metadata map[string]interface{}
+}
+
+// AuthenticateFunc validates a WebSocket upgrade request - typically
+// by checking a bearer token or session cookie on r - and returns the
+// identity to attach to the resulting connection. Returning an error
+// rejects the connection.
+type AuthenticateFunc func(r *http.Request) (*Identity, error)
+
+// ServeWebSocketOptions configures ServeWebSocket.
+type ServeWebSocketOptions struct {
+	// Authenticate, if set, runs against every upgrade request. A
+	// connection it rejects is closed with CloseUnauthorized instead
+	// of being registered with the manager.
+	Authenticate AuthenticateFunc
+
+	// Accept configures the underlying upgrade - compression
+	// negotiation, max message size, and read deadline. The zero value
+	// uses AcceptWebSocket's defaults (no compression, no limits).
+	Accept AcceptWebSocketOptions
+}
+
+// CloseUnauthorized is the WebSocket close code (RFC 6455's "Policy
+// Violation") sent when Authenticate rejects a connection.
+const CloseUnauthorized = websocket.ClosePolicyViolation
+
+// ServeWebSocket upgrades r to a WebSocket connection, runs
+// opts.Authenticate against it if set, and on success registers the
+// resulting Connection - carrying the authenticated UserID - with m.
+// A connection that fails authentication is closed immediately with
+// CloseUnauthorized rather than being added to m.
+func ServeWebSocket(m *Manager, w http.ResponseWriter, r *http.Request, opts ServeWebSocketOptions) (*Connection, error) {
+	wsConn, err := AcceptWebSocketWithOptions(w, r, opts.Accept)
+	if err != nil {
+		return nil, err
+	}
+
+	var identity *Identity
+	if opts.Authenticate != nil {
+		identity, err = opts.Authenticate(r)
+		if err != nil {
+			closeMsg := websocket.FormatCloseMessage(CloseUnauthorized, err.Error())
+			wsConn.WriteControl(websocket.CloseMessage, closeMsg, time.Now().Add(time.Second))
+			wsConn.Close()
+			return nil, fmt.Errorf("websocket: authentication failed: %w", err)
+		}
+	}
+
+	conn := &Connection{
+		ID:         newConnectionID(),
+		Conn:       wsConn,
+		Request:    r,
+		WriteChan:  make(chan []byte, 16),
+		CloseChan:  make(chan bool),
+		RemoteAddr: r.RemoteAddr,
+		UserAgent:  r.Header.Get("User-Agent"),
+	}
+	if identity != nil {
+		conn.UserID = identity.UserID
+	}
+
+	m.AddConnection(conn)
+	return conn, nil
+}
+
+// newConnectionID generates a unique connection ID. It's distinct
+// from GenerateConnectionID, whose timestamp source is currently a
+// placeholder that always returns 0.
+func newConnectionID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return fmt.Sprintf("conn_%d", time.Now().UnixNano())
+	}
+	return "conn_" + hex.EncodeToString(b)
+}
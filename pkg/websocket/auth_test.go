@@ -0,0 +1,83 @@
+package websocket
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/websocket"
+)
+
+func TestServeWebSocketAttachesIdentityOnSuccess(t *testing.T) {
+	manager := NewManager()
+	var gotConn *Connection
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := ServeWebSocket(manager, w, r, ServeWebSocketOptions{
+			Authenticate: func(r *http.Request) (*Identity, error) {
+				return &Identity{UserID: "user-1"}, nil
+			},
+		})
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		gotConn = conn
+	}))
+	defer server.Close()
+
+	dialWebSocket(t, server.URL)
+
+	if gotConn == nil {
+		t.Fatal("expected a connection to be returned")
+	}
+	if gotConn.UserID != "user-1" {
+		t.Errorf("expected UserID user-1, got %q", gotConn.UserID)
+	}
+	if _, exists := manager.GetConnection(gotConn.ID); !exists {
+		t.Error("expected the connection to be registered with the manager")
+	}
+}
+
+func TestServeWebSocketRejectsFailedAuthentication(t *testing.T) {
+	manager := NewManager()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, err := ServeWebSocket(manager, w, r, ServeWebSocketOptions{
+			Authenticate: func(r *http.Request) (*Identity, error) {
+				return nil, errors.New("invalid token")
+			},
+		})
+		if err == nil {
+			t.Error("expected ServeWebSocket to return an error")
+		}
+	}))
+	defer server.Close()
+
+	conn := dialWebSocket(t, server.URL)
+	defer conn.Close()
+
+	_, _, err := conn.ReadMessage()
+	closeErr, ok := err.(*websocket.CloseError)
+	if !ok {
+		t.Fatalf("expected a close error, got %v", err)
+	}
+	if closeErr.Code != CloseUnauthorized {
+		t.Errorf("expected close code %d, got %d", CloseUnauthorized, closeErr.Code)
+	}
+
+	if manager.GetConnectionCount() != 0 {
+		t.Error("expected the rejected connection not to be registered with the manager")
+	}
+}
+
+func dialWebSocket(t *testing.T, httpURL string) *websocket.Conn {
+	t.Helper()
+	wsURL := "ws" + httpURL[len("http"):]
+	conn, _, err := websocket.DefaultDialer.Dial(wsURL, nil)
+	if err != nil {
+		t.Fatalf("failed to dial websocket server: %v", err)
+	}
+	return conn
+}
@@ -0,0 +1,124 @@
+package websocket
+
+import "errors"
+
+var errEmptyEnvelope = errors.New("websocket: empty backend envelope")
+
+// PubSubClient is the minimal interface a Hub backend must satisfy to
+// let BroadcastToChannel reach connections held by other server
+// instances - a thin abstraction over a Redis/NATS pub/sub client so
+// this package doesn't need to depend on one directly. Publish sends
+// a channel's encoded message to every subscriber, including ones on
+// other instances; Subscribe returns a channel of raw payloads for
+// messages published to channel (by any instance) along with a func
+// to cancel the subscription.
+type PubSubClient interface {
+	Publish(channel string, payload []byte) error
+	Subscribe(channel string) (payloads <-chan []byte, unsubscribe func())
+}
+
+// UseBackend wires a pub/sub backend into m so that BroadcastToChannel
+// also publishes there, and so messages published by other instances
+// are delivered to this instance's connections. It must be called
+// before any channel traffic happens; connections already joined to a
+// channel at the time UseBackend is called are unaffected until they
+// rejoin.
+func (m *Manager) UseBackend(backend PubSubClient) {
+	m.backendMu.Lock()
+	defer m.backendMu.Unlock()
+	m.backend = backend
+}
+
+func (m *Manager) backendClient() PubSubClient {
+	m.backendMu.Lock()
+	defer m.backendMu.Unlock()
+	return m.backend
+}
+
+// subscribeBackend subscribes this instance to channel on the backend,
+// if one is configured and it isn't already subscribed. Messages
+// received from the backend are delivered to the channel's local
+// connections via deliverToChannel, without being re-published, so
+// instances don't echo messages back and forth.
+func (m *Manager) subscribeBackend(channel string) {
+	m.backendMu.Lock()
+	defer m.backendMu.Unlock()
+
+	if m.backend == nil {
+		return
+	}
+	if _, subscribed := m.backendSubs[channel]; subscribed {
+		return
+	}
+
+	payloads, unsubscribe := m.backend.Subscribe(channel)
+	if m.backendSubs == nil {
+		m.backendSubs = make(map[string]func())
+	}
+	m.backendSubs[channel] = unsubscribe
+
+	go func() {
+		for payload := range payloads {
+			origin, messageType, data, err := decodeEnvelope(payload)
+			if err != nil {
+				continue
+			}
+			if origin == m.instanceID {
+				// Already delivered locally when BroadcastToChannel
+				// published it - skip to avoid a double delivery.
+				continue
+			}
+			m.deliverToChannel(channel, messageType, data)
+		}
+	}()
+}
+
+// unsubscribeBackendIfEmpty cancels this instance's backend
+// subscription to channel once it has no more local connections, so
+// idle channels don't accumulate subscriptions.
+func (m *Manager) unsubscribeBackendIfEmpty(channel string) {
+	if len(m.ChannelConnections(channel)) > 0 {
+		return
+	}
+
+	m.backendMu.Lock()
+	unsubscribe, subscribed := m.backendSubs[channel]
+	if subscribed {
+		delete(m.backendSubs, channel)
+	}
+	m.backendMu.Unlock()
+
+	if subscribed {
+		unsubscribe()
+	}
+}
+
+// encodeEnvelope packs a message type, the publishing instance's ID,
+// and the payload into a single byte slice for publishing on the
+// backend: a one-byte MessageType tag, a one-byte origin length, the
+// origin bytes, then the raw data. The origin lets subscribeBackend
+// recognize - and skip - messages this same instance published, since
+// those were already delivered locally by BroadcastToChannel.
+func encodeEnvelope(messageType MessageType, origin string, data []byte) []byte {
+	envelope := make([]byte, 2+len(origin)+len(data))
+	envelope[0] = byte(messageType)
+	envelope[1] = byte(len(origin))
+	copy(envelope[2:], origin)
+	copy(envelope[2+len(origin):], data)
+	return envelope
+}
+
+// decodeEnvelope reverses encodeEnvelope.
+func decodeEnvelope(envelope []byte) (origin string, messageType MessageType, data []byte, err error) {
+	if len(envelope) < 2 {
+		return "", 0, nil, errEmptyEnvelope
+	}
+	originLen := int(envelope[1])
+	if len(envelope) < 2+originLen {
+		return "", 0, nil, errEmptyEnvelope
+	}
+	messageType = MessageType(envelope[0])
+	origin = string(envelope[2 : 2+originLen])
+	data = envelope[2+originLen:]
+	return origin, messageType, data, nil
+}
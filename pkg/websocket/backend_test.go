@@ -0,0 +1,156 @@
+package websocket
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// fakePubSub is an in-memory PubSubClient test double standing in for
+// a real Redis/NATS client, shared by every Manager that calls
+// UseBackend with the same instance - simulating multiple server
+// instances talking through one backend.
+type fakePubSub struct {
+	mu   sync.Mutex
+	subs map[string][]chan []byte
+}
+
+func newFakePubSub() *fakePubSub {
+	return &fakePubSub{subs: make(map[string][]chan []byte)}
+}
+
+func (p *fakePubSub) Publish(channel string, payload []byte) error {
+	p.mu.Lock()
+	subscribers := append([]chan []byte(nil), p.subs[channel]...)
+	p.mu.Unlock()
+
+	for _, ch := range subscribers {
+		ch <- payload
+	}
+	return nil
+}
+
+func (p *fakePubSub) Subscribe(channel string) (<-chan []byte, func()) {
+	ch := make(chan []byte, 16)
+
+	p.mu.Lock()
+	p.subs[channel] = append(p.subs[channel], ch)
+	p.mu.Unlock()
+
+	unsubscribe := func() {
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		subscribers := p.subs[channel]
+		for i, existing := range subscribers {
+			if existing == ch {
+				p.subs[channel] = append(subscribers[:i], subscribers[i+1:]...)
+				break
+			}
+		}
+		close(ch)
+	}
+	return ch, unsubscribe
+}
+
+func (p *fakePubSub) subscriberCount(channel string) int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.subs[channel])
+}
+
+// joinViaWebSocket accepts a real WebSocket connection on manager,
+// joins it to channel, and returns the client-side connection so the
+// test can read what the server sends it.
+func joinViaWebSocket(t *testing.T, manager *Manager, channel string) *websocket.Conn {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		wsConn, err := AcceptWebSocket(w, r)
+		if err != nil {
+			t.Errorf("AcceptWebSocket failed: %v", err)
+			return
+		}
+		conn := &Connection{
+			ID:        newConnectionID(),
+			Conn:      wsConn,
+			WriteChan: make(chan []byte, 16),
+			CloseChan: make(chan bool),
+		}
+		manager.AddConnection(conn)
+		manager.Join(conn.ID, channel)
+	}))
+	t.Cleanup(server.Close)
+
+	return dialWebSocket(t, server.URL)
+}
+
+func TestBroadcastToChannelReachesConnectionsOnAnotherInstance(t *testing.T) {
+	backend := newFakePubSub()
+
+	instanceA := NewManager()
+	instanceA.UseBackend(backend)
+	instanceB := NewManager()
+	instanceB.UseBackend(backend)
+
+	client := joinViaWebSocket(t, instanceB, "room-42")
+	defer client.Close()
+
+	instanceA.BroadcastToChannel("room-42", TextMessage, []byte("hello"))
+
+	client.SetReadDeadline(time.Now().Add(time.Second))
+	_, msg, err := client.ReadMessage()
+	if err != nil {
+		t.Fatalf("expected a message delivered via the backend, got error: %v", err)
+	}
+	if string(msg) != "hello" {
+		t.Errorf("expected %q, got %q", "hello", msg)
+	}
+}
+
+func TestBroadcastToChannelDoesNotDoubleDeliverLocally(t *testing.T) {
+	backend := newFakePubSub()
+
+	manager := NewManager()
+	manager.UseBackend(backend)
+
+	client := joinViaWebSocket(t, manager, "room-42")
+	defer client.Close()
+
+	manager.BroadcastToChannel("room-42", TextMessage, []byte("hello"))
+
+	client.SetReadDeadline(time.Now().Add(time.Second))
+	if _, _, err := client.ReadMessage(); err != nil {
+		t.Fatalf("expected one delivery, got error: %v", err)
+	}
+
+	client.SetReadDeadline(time.Now().Add(100 * time.Millisecond))
+	if _, extra, err := client.ReadMessage(); err == nil {
+		t.Errorf("expected exactly one delivery, got an extra message: %q", extra)
+	}
+}
+
+func TestUnsubscribesFromBackendWhenChannelEmpties(t *testing.T) {
+	backend := newFakePubSub()
+
+	manager := NewManager()
+	manager.UseBackend(backend)
+
+	manager.AddConnection(&Connection{ID: "conn-1", CloseChan: make(chan bool)})
+	if err := manager.Join("conn-1", "room-42"); err != nil {
+		t.Fatalf("Join failed: %v", err)
+	}
+	if got := backend.subscriberCount("room-42"); got != 1 {
+		t.Fatalf("expected 1 backend subscriber after Join, got %d", got)
+	}
+
+	if err := manager.Leave("conn-1", "room-42"); err != nil {
+		t.Fatalf("Leave failed: %v", err)
+	}
+	if got := backend.subscriberCount("room-42"); got != 0 {
+		t.Errorf("expected backend subscription to be cancelled once the channel is empty, got %d", got)
+	}
+}
@@ -0,0 +1,97 @@
+package websocket
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+func TestAcceptWebSocketWithOptionsAppliesMaxMessageSize(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := AcceptWebSocketWithOptions(w, r, AcceptWebSocketOptions{MaxMessageSize: 8})
+		if err != nil {
+			t.Errorf("AcceptWebSocketWithOptions failed: %v", err)
+			return
+		}
+		defer conn.Close()
+		conn.ReadMessage()
+	}))
+	defer server.Close()
+
+	client := dialWebSocket(t, server.URL)
+	defer client.Close()
+
+	if err := client.WriteMessage(websocket.TextMessage, []byte("this message is far too long")); err != nil {
+		t.Fatalf("failed to write message: %v", err)
+	}
+
+	client.SetReadDeadline(time.Now().Add(time.Second))
+	_, _, err := client.ReadMessage()
+	closeErr, ok := err.(*websocket.CloseError)
+	if !ok {
+		t.Fatalf("expected the server to close the connection over the size limit, got %v", err)
+	}
+	if closeErr.Code != websocket.CloseMessageTooBig {
+		t.Errorf("expected close code %d, got %d", websocket.CloseMessageTooBig, closeErr.Code)
+	}
+}
+
+func TestAcceptWebSocketWithOptionsNegotiatesCompression(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := AcceptWebSocketWithOptions(w, r, AcceptWebSocketOptions{EnableCompression: true})
+		if err != nil {
+			t.Errorf("AcceptWebSocketWithOptions failed: %v", err)
+			return
+		}
+		defer conn.Close()
+		conn.WriteMessage(websocket.TextMessage, []byte("ok"))
+	}))
+	defer server.Close()
+
+	dialer := &websocket.Dialer{EnableCompression: true}
+	wsURL := "ws" + server.URL[len("http"):]
+	client, resp, err := dialer.Dial(wsURL, nil)
+	if err != nil {
+		t.Fatalf("failed to dial websocket server: %v", err)
+	}
+	defer client.Close()
+
+	if resp.Header.Get("Sec-WebSocket-Extensions") == "" {
+		t.Error("expected the server to negotiate a WebSocket extension when compression is enabled")
+	}
+}
+
+func TestManagerSendDeliversBinaryMessages(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		wsConn, err := AcceptWebSocket(w, r)
+		if err != nil {
+			t.Errorf("AcceptWebSocket failed: %v", err)
+			return
+		}
+		conn := &Connection{ID: "conn1", Conn: wsConn, CloseChan: make(chan bool)}
+		manager := NewManager()
+		manager.AddConnection(conn)
+		if err := manager.Send("conn1", BinaryMessage, []byte{0x00, 0x01, 0xff}); err != nil {
+			t.Errorf("Send failed: %v", err)
+		}
+	}))
+	defer server.Close()
+
+	client := dialWebSocket(t, server.URL)
+	defer client.Close()
+
+	client.SetReadDeadline(time.Now().Add(time.Second))
+	messageType, data, err := client.ReadMessage()
+	if err != nil {
+		t.Fatalf("ReadMessage failed: %v", err)
+	}
+	if messageType != websocket.BinaryMessage {
+		t.Errorf("expected a binary message, got type %d", messageType)
+	}
+	if len(data) != 3 || data[2] != 0xff {
+		t.Errorf("expected binary payload to round-trip, got %v", data)
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/gorilla/websocket"
 )
@@ -39,16 +40,48 @@ type Connection struct {
 	CloseChan  chan bool
 	RemoteAddr string
 	UserAgent  string
+
+	// UserID identifies the authenticated user behind this connection,
+	// if any. Set it before calling AddConnection.
+	UserID string
+	// JoinedAt is when the connection was added. AddConnection fills
+	// this in if it's left zero.
+	JoinedAt time.Time
+
+	channels map[string]bool // channels this connection has joined, guarded by Manager.mu
+}
+
+// ClientInfo is a snapshot of a connection's identity and membership,
+// returned by Manager.Clients.
+type ClientInfo struct {
+	ID       string
+	UserID   string
+	Room     string
+	Channels []string
+	JoinedAt time.Time
 }
 
 // Manager manages WebSocket connections
 type Manager struct {
-	connections map[string]*Connection
-	rooms       map[string][]*Connection
-	mu          sync.RWMutex
-	onMessage   func(*Connection, MessageType, []byte)
-	onConnect   func(*Connection)
-	onDisconnect func(*Connection)
+	connections   map[string]*Connection
+	rooms         map[string][]*Connection
+	channels      map[string]map[string]*Connection // channel name -> connection ID -> Connection
+	replayBuffers map[string]*replayBuffer          // channel name -> recent message history
+	mu            sync.RWMutex
+	onMessage     func(*Connection, MessageType, []byte)
+	onConnect     func(*Connection)
+	onDisconnect  func(*Connection)
+
+	instanceID  string
+	backendMu   sync.Mutex
+	backend     PubSubClient
+	backendSubs map[string]func() // channel name -> unsubscribe
+
+	ackMu       sync.Mutex
+	pendingAcks map[string]*pendingAck // message ID -> delivery awaiting acknowledgment
+
+	subMu       sync.Mutex
+	subscribers map[string]map[chan Message]bool // channel name -> listeners (SSE, long-poll)
 }
 
 // NewManager creates a new WebSocket manager
@@ -56,6 +89,8 @@ func NewManager() *Manager {
 	return &Manager{
 		connections: make(map[string]*Connection),
 		rooms:       make(map[string][]*Connection),
+		channels:    make(map[string]map[string]*Connection),
+		instanceID:  newConnectionID(),
 	}
 }
 
@@ -85,6 +120,9 @@ func (m *Manager) AddConnection(conn *Connection) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
+	if conn.JoinedAt.IsZero() {
+		conn.JoinedAt = time.Now()
+	}
 	m.connections[conn.ID] = conn
 
 	// Add to room if specified
@@ -100,12 +138,19 @@ func (m *Manager) AddConnection(conn *Connection) {
 
 // RemoveConnection removes a WebSocket connection
 func (m *Manager) RemoveConnection(connID string) {
+	emptiedChannels := m.removeConnectionLocked(connID)
+	for _, channel := range emptiedChannels {
+		m.unsubscribeBackendIfEmpty(channel)
+	}
+}
+
+func (m *Manager) removeConnectionLocked(connID string) []string {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
 	conn, exists := m.connections[connID]
 	if !exists {
-		return
+		return nil
 	}
 
 	// Remove from room
@@ -123,12 +168,24 @@ func (m *Manager) RemoveConnection(connID string) {
 		}
 	}
 
+	// Remove from any channels it joined
+	var emptiedChannels []string
+	for channel := range conn.channels {
+		delete(m.channels[channel], connID)
+		if len(m.channels[channel]) == 0 {
+			delete(m.channels, channel)
+			emptiedChannels = append(emptiedChannels, channel)
+		}
+	}
+
 	delete(m.connections, connID)
 
 	// Call disconnect handler if set
 	if m.onDisconnect != nil {
 		go m.onDisconnect(conn)
 	}
+
+	return emptiedChannels
 }
 
 // GetConnection retrieves a connection by ID
@@ -166,6 +223,160 @@ func (m *Manager) GetRoomConnections(room string) []*Connection {
 	return result
 }
 
+// Join adds the connection to a named channel. Unlike Room, which is
+// fixed when the connection is added, a connection can join and leave
+// any number of channels over its lifetime.
+func (m *Manager) Join(connID, channel string) error {
+	err := func() error {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+
+		conn, exists := m.connections[connID]
+		if !exists {
+			return fmt.Errorf("connection not found: %s", connID)
+		}
+
+		if m.channels[channel] == nil {
+			m.channels[channel] = make(map[string]*Connection)
+		}
+		m.channels[channel][connID] = conn
+
+		if conn.channels == nil {
+			conn.channels = make(map[string]bool)
+		}
+		conn.channels[channel] = true
+
+		return nil
+	}()
+	if err != nil {
+		return err
+	}
+
+	// If a pub/sub backend is configured, make sure this instance is
+	// subscribed to the channel so broadcasts from other instances
+	// reach the connection that just joined.
+	m.subscribeBackend(channel)
+	return nil
+}
+
+// Leave removes the connection from a named channel.
+func (m *Manager) Leave(connID, channel string) error {
+	err := func() error {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+
+		conn, exists := m.connections[connID]
+		if !exists {
+			return fmt.Errorf("connection not found: %s", connID)
+		}
+
+		delete(m.channels[channel], connID)
+		if len(m.channels[channel]) == 0 {
+			delete(m.channels, channel)
+		}
+		delete(conn.channels, channel)
+
+		return nil
+	}()
+	if err != nil {
+		return err
+	}
+
+	m.unsubscribeBackendIfEmpty(channel)
+	return nil
+}
+
+// ChannelConnections returns all connections currently joined to a
+// channel.
+func (m *Manager) ChannelConnections(channel string) []*Connection {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	conns := make([]*Connection, 0, len(m.channels[channel]))
+	for _, conn := range m.channels[channel] {
+		conns = append(conns, conn)
+	}
+	return conns
+}
+
+// GetAllChannels returns the names of all channels with at least one
+// member.
+func (m *Manager) GetAllChannels() []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	channels := make([]string, 0, len(m.channels))
+	for channel := range m.channels {
+		channels = append(channels, channel)
+	}
+	return channels
+}
+
+// BroadcastToChannel sends a message to every connection joined to a
+// channel and records it in the channel's replay buffer under a new
+// sequence number, which it returns, so a client that reconnects can
+// call ReplaySince to catch up on anything it missed. If a pub/sub
+// backend is configured (see UseBackend), the message is also
+// published there so it reaches connections on other instances.
+func (m *Manager) BroadcastToChannel(channel string, messageType MessageType, data []byte) uint64 {
+	seq := m.deliverToChannel(channel, messageType, data)
+
+	if backend := m.backendClient(); backend != nil {
+		backend.Publish(channel, encodeEnvelope(messageType, m.instanceID, data))
+	}
+
+	return seq
+}
+
+// deliverToChannel sends a message to every local connection joined
+// to channel and records it in the replay buffer, without publishing
+// it to the backend - used both for local broadcasts and for
+// messages arriving from other instances via the backend.
+func (m *Manager) deliverToChannel(channel string, messageType MessageType, data []byte) uint64 {
+	seq := m.replayBufferFor(channel).append(messageType, data)
+
+	conns := m.ChannelConnections(channel)
+	for _, conn := range conns {
+		conn.Send(messageType, data)
+	}
+
+	m.notifySubscribers(channel, Message{Seq: seq, Type: messageType, Data: data})
+	return seq
+}
+
+// Send delivers a message to a single connection by ID, rather than
+// broadcasting it to a room, channel, or everyone.
+func (m *Manager) Send(connID string, messageType MessageType, data []byte) error {
+	conn, exists := m.GetConnection(connID)
+	if !exists {
+		return fmt.Errorf("connection not found: %s", connID)
+	}
+	return conn.Send(messageType, data)
+}
+
+// Clients returns a snapshot of every connected client's identity and
+// current room/channel membership.
+func (m *Manager) Clients() []ClientInfo {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	clients := make([]ClientInfo, 0, len(m.connections))
+	for _, conn := range m.connections {
+		channels := make([]string, 0, len(conn.channels))
+		for channel := range conn.channels {
+			channels = append(channels, channel)
+		}
+		clients = append(clients, ClientInfo{
+			ID:       conn.ID,
+			UserID:   conn.UserID,
+			Room:     conn.Room,
+			Channels: channels,
+			JoinedAt: conn.JoinedAt,
+		})
+	}
+	return clients
+}
+
 // Broadcast sends a message to all connections
 func (m *Manager) Broadcast(messageType MessageType, data []byte) {
 	conns := m.GetConnections()
@@ -325,8 +536,34 @@ func buildFrame(messageType MessageType, data []byte) ([]byte, error) {
 	return frame, nil
 }
 
-// AcceptWebSocket accepts a WebSocket connection
+// AcceptWebSocketOptions configures AcceptWebSocketWithOptions.
+type AcceptWebSocketOptions struct {
+	// EnableCompression negotiates permessage-deflate with the client
+	// when it offers it, trading CPU for bandwidth on text-heavy
+	// traffic. Defaults to off.
+	EnableCompression bool
+	// MaxMessageSize caps the size, in bytes, of a single incoming
+	// message. A message over the limit fails the read and the
+	// connection should be closed. Zero means no limit.
+	MaxMessageSize int64
+	// ReadDeadline bounds how long a read may wait for the next
+	// message before failing. Zero means no deadline. Callers that
+	// also use heartbeats typically push the deadline forward on every
+	// received pong (see HeartbeatManager).
+	ReadDeadline time.Duration
+}
+
+// AcceptWebSocket accepts a WebSocket connection using default
+// options: no compression, no message size limit, and no read
+// deadline. Use AcceptWebSocketWithOptions to configure these.
 func AcceptWebSocket(w http.ResponseWriter, r *http.Request) (*websocket.Conn, error) {
+	return AcceptWebSocketWithOptions(w, r, AcceptWebSocketOptions{})
+}
+
+// AcceptWebSocketWithOptions accepts a WebSocket connection, applying
+// opts' compression negotiation, max message size, and read deadline
+// to the resulting connection.
+func AcceptWebSocketWithOptions(w http.ResponseWriter, r *http.Request, opts AcceptWebSocketOptions) (*websocket.Conn, error) {
 	// Validate WebSocket handshake
 	if !isWebSocketUpgrade(r) {
 		return nil, fmt.Errorf("not a WebSocket upgrade request")
@@ -334,8 +571,9 @@ func AcceptWebSocket(w http.ResponseWriter, r *http.Request) (*websocket.Conn, e
 
 	// Create WebSocket upgrader
 	upgrader := websocket.Upgrader{
-		ReadBufferSize:  1024,
-		WriteBufferSize: 1024,
+		ReadBufferSize:    1024,
+		WriteBufferSize:   1024,
+		EnableCompression: opts.EnableCompression,
 		CheckOrigin: func(r *http.Request) bool {
 			return true // Allow all origins for now
 		},
@@ -347,6 +585,13 @@ func AcceptWebSocket(w http.ResponseWriter, r *http.Request) (*websocket.Conn, e
 		return nil, err
 	}
 
+	if opts.MaxMessageSize > 0 {
+		conn.SetReadLimit(opts.MaxMessageSize)
+	}
+	if opts.ReadDeadline > 0 {
+		conn.SetReadDeadline(time.Now().Add(opts.ReadDeadline))
+	}
+
 	return conn, nil
 }
 
@@ -0,0 +1,104 @@
+package websocket
+
+import "testing"
+
+func TestAddConnectionSetsJoinedAt(t *testing.T) {
+	manager := NewManager()
+	manager.AddConnection(&Connection{ID: "conn1", UserID: "user-1", CloseChan: make(chan bool)})
+
+	clients := manager.Clients()
+	if len(clients) != 1 {
+		t.Fatalf("expected 1 client, got %d", len(clients))
+	}
+	if clients[0].UserID != "user-1" {
+		t.Errorf("expected user-1, got %q", clients[0].UserID)
+	}
+	if clients[0].JoinedAt.IsZero() {
+		t.Error("expected JoinedAt to be set")
+	}
+}
+
+func TestSendErrorsForUnknownConnection(t *testing.T) {
+	manager := NewManager()
+
+	if err := manager.Send("missing", TextMessage, []byte("hi")); err == nil {
+		t.Error("expected an error sending to an unknown connection")
+	}
+}
+
+func TestClientsReflectsChannelMembership(t *testing.T) {
+	manager := NewManager()
+	manager.AddConnection(&Connection{ID: "conn1", CloseChan: make(chan bool)})
+	manager.Join("conn1", "lobby")
+
+	clients := manager.Clients()
+	if len(clients) != 1 || len(clients[0].Channels) != 1 || clients[0].Channels[0] != "lobby" {
+		t.Errorf("expected conn1 to report channel membership, got %+v", clients)
+	}
+}
+
+func TestJoinAddsConnectionToChannel(t *testing.T) {
+	manager := NewManager()
+	manager.AddConnection(&Connection{ID: "conn1", CloseChan: make(chan bool)})
+
+	if err := manager.Join("conn1", "room-42"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	conns := manager.ChannelConnections("room-42")
+	if len(conns) != 1 || conns[0].ID != "conn1" {
+		t.Errorf("expected conn1 to be in room-42, got %v", conns)
+	}
+}
+
+func TestJoinUnknownConnectionFails(t *testing.T) {
+	manager := NewManager()
+
+	if err := manager.Join("missing", "room-42"); err == nil {
+		t.Error("expected an error joining a channel with an unknown connection ID")
+	}
+}
+
+func TestLeaveRemovesConnectionFromChannel(t *testing.T) {
+	manager := NewManager()
+	manager.AddConnection(&Connection{ID: "conn1", CloseChan: make(chan bool)})
+	manager.Join("conn1", "room-42")
+
+	if err := manager.Leave("conn1", "room-42"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if conns := manager.ChannelConnections("room-42"); len(conns) != 0 {
+		t.Errorf("expected room-42 to be empty, got %v", conns)
+	}
+	if channels := manager.GetAllChannels(); len(channels) != 0 {
+		t.Errorf("expected no channels left, got %v", channels)
+	}
+}
+
+func TestRemoveConnectionClearsItsChannels(t *testing.T) {
+	manager := NewManager()
+	manager.AddConnection(&Connection{ID: "conn1", CloseChan: make(chan bool)})
+	manager.Join("conn1", "room-42")
+
+	manager.RemoveConnection("conn1")
+
+	if conns := manager.ChannelConnections("room-42"); len(conns) != 0 {
+		t.Errorf("expected room-42 to be empty after disconnect, got %v", conns)
+	}
+}
+
+func TestConnectionCanJoinMultipleChannels(t *testing.T) {
+	manager := NewManager()
+	manager.AddConnection(&Connection{ID: "conn1", CloseChan: make(chan bool)})
+
+	manager.Join("conn1", "room-a")
+	manager.Join("conn1", "room-b")
+
+	if len(manager.ChannelConnections("room-a")) != 1 {
+		t.Error("expected conn1 to remain in room-a")
+	}
+	if len(manager.ChannelConnections("room-b")) != 1 {
+		t.Error("expected conn1 to also be in room-b")
+	}
+}
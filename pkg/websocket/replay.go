@@ -0,0 +1,99 @@
+package websocket
+
+import "sync"
+
+// DefaultReplayBufferSize is how many messages BroadcastToChannel
+// retains per channel when no other size has been set with
+// SetReplayBufferSize.
+const DefaultReplayBufferSize = 100
+
+// ReplayMessage is one message retained by a channel's replay
+// buffer, tagged with the sequence number it was broadcast with.
+type ReplayMessage struct {
+	Seq  uint64
+	Type MessageType
+	Data []byte
+}
+
+// replayBuffer is a bounded, ordered record of the most recent
+// messages broadcast to a channel, so a client that reconnects can
+// ask for everything it missed.
+type replayBuffer struct {
+	mu       sync.Mutex
+	size     int
+	nextSeq  uint64
+	messages []ReplayMessage
+}
+
+func newReplayBuffer(size int) *replayBuffer {
+	return &replayBuffer{size: size}
+}
+
+// append records a message and returns the sequence number assigned
+// to it.
+func (b *replayBuffer) append(messageType MessageType, data []byte) uint64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.nextSeq++
+	seq := b.nextSeq
+
+	b.messages = append(b.messages, ReplayMessage{Seq: seq, Type: messageType, Data: data})
+	if len(b.messages) > b.size {
+		b.messages = b.messages[len(b.messages)-b.size:]
+	}
+	return seq
+}
+
+// since returns every retained message with a sequence number
+// greater than afterSeq, oldest first.
+func (b *replayBuffer) since(afterSeq uint64) []ReplayMessage {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	result := make([]ReplayMessage, 0, len(b.messages))
+	for _, msg := range b.messages {
+		if msg.Seq > afterSeq {
+			result = append(result, msg)
+		}
+	}
+	return result
+}
+
+// SetReplayBufferSize sets how many messages BroadcastToChannel
+// retains for a channel's replay buffer. It must be called before the
+// channel's buffer is first used (i.e. before the first broadcast to
+// that channel); calling it afterwards has no effect.
+func (m *Manager) SetReplayBufferSize(channel string, size int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.replayBuffers == nil {
+		m.replayBuffers = make(map[string]*replayBuffer)
+	}
+	if _, exists := m.replayBuffers[channel]; !exists {
+		m.replayBuffers[channel] = newReplayBuffer(size)
+	}
+}
+
+func (m *Manager) replayBufferFor(channel string) *replayBuffer {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.replayBuffers == nil {
+		m.replayBuffers = make(map[string]*replayBuffer)
+	}
+	buf, exists := m.replayBuffers[channel]
+	if !exists {
+		buf = newReplayBuffer(DefaultReplayBufferSize)
+		m.replayBuffers[channel] = buf
+	}
+	return buf
+}
+
+// ReplaySince returns every message broadcast to channel since
+// afterSeq, up to the channel's replay buffer size, so a reconnecting
+// client can catch up on what it missed instead of losing state.
+func (m *Manager) ReplaySince(channel string, afterSeq uint64) []ReplayMessage {
+	return m.replayBufferFor(channel).since(afterSeq)
+}
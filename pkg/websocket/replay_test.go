@@ -0,0 +1,51 @@
+package websocket
+
+import "testing"
+
+func TestBroadcastToChannelAssignsIncreasingSequenceNumbers(t *testing.T) {
+	manager := NewManager()
+
+	first := manager.BroadcastToChannel("room-42", TextMessage, []byte("hello"))
+	second := manager.BroadcastToChannel("room-42", TextMessage, []byte("world"))
+
+	if second <= first {
+		t.Errorf("expected sequence numbers to increase, got %d then %d", first, second)
+	}
+}
+
+func TestReplaySinceReturnsOnlyNewerMessages(t *testing.T) {
+	manager := NewManager()
+
+	first := manager.BroadcastToChannel("room-42", TextMessage, []byte("hello"))
+	manager.BroadcastToChannel("room-42", TextMessage, []byte("world"))
+
+	missed := manager.ReplaySince("room-42", first)
+	if len(missed) != 1 || string(missed[0].Data) != "world" {
+		t.Errorf("expected only the message after seq %d, got %+v", first, missed)
+	}
+}
+
+func TestReplayBufferIsBoundedBySize(t *testing.T) {
+	manager := NewManager()
+	manager.SetReplayBufferSize("room-42", 2)
+
+	manager.BroadcastToChannel("room-42", TextMessage, []byte("one"))
+	manager.BroadcastToChannel("room-42", TextMessage, []byte("two"))
+	manager.BroadcastToChannel("room-42", TextMessage, []byte("three"))
+
+	missed := manager.ReplaySince("room-42", 0)
+	if len(missed) != 2 {
+		t.Fatalf("expected replay buffer capped at 2 messages, got %d", len(missed))
+	}
+	if string(missed[0].Data) != "two" || string(missed[1].Data) != "three" {
+		t.Errorf("expected the oldest message to be dropped, got %+v", missed)
+	}
+}
+
+func TestReplaySinceOnUnusedChannelReturnsEmpty(t *testing.T) {
+	manager := NewManager()
+
+	if missed := manager.ReplaySince("never-used", 0); len(missed) != 0 {
+		t.Errorf("expected no messages for an unused channel, got %+v", missed)
+	}
+}
@@ -0,0 +1,174 @@
+package websocket
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Message is the unit of data a channel broadcast delivers, shared
+// across every realtime transport this package offers - a WebSocket
+// Connection, ServeSSE, and ServeLongPoll - so a client can fall back
+// from one to another (e.g. when a proxy strips WebSocket upgrade
+// headers) without a different wire shape. It's the same shape
+// ReplaySince already returns.
+type Message = ReplayMessage
+
+// DefaultLongPollTimeout is how long ServeLongPoll waits for a new
+// message before responding with an empty result, when the caller
+// doesn't specify a timeout.
+const DefaultLongPollTimeout = 30 * time.Second
+
+// Subscribe registers a local, in-process listener for every message
+// subsequently broadcast to channel - the mechanism ServeSSE and
+// ServeLongPoll use to watch a channel, independent of whether any
+// WebSocket connections are joined to it. The returned func cancels
+// the subscription; callers must call it when done to avoid leaking
+// the channel.
+func (m *Manager) Subscribe(channel string) (<-chan Message, func()) {
+	ch := make(chan Message, 16)
+
+	m.subMu.Lock()
+	if m.subscribers == nil {
+		m.subscribers = make(map[string]map[chan Message]bool)
+	}
+	if m.subscribers[channel] == nil {
+		m.subscribers[channel] = make(map[chan Message]bool)
+	}
+	m.subscribers[channel][ch] = true
+	m.subMu.Unlock()
+
+	unsubscribe := func() {
+		m.subMu.Lock()
+		defer m.subMu.Unlock()
+		if _, ok := m.subscribers[channel][ch]; !ok {
+			return
+		}
+		delete(m.subscribers[channel], ch)
+		if len(m.subscribers[channel]) == 0 {
+			delete(m.subscribers, channel)
+		}
+		close(ch)
+	}
+	return ch, unsubscribe
+}
+
+// notifySubscribers forwards a delivered message to every local
+// Subscribe listener on channel. A listener that isn't keeping up has
+// the message dropped rather than blocking the broadcast.
+func (m *Manager) notifySubscribers(channel string, msg Message) {
+	m.subMu.Lock()
+	defer m.subMu.Unlock()
+	for ch := range m.subscribers[channel] {
+		select {
+		case ch <- msg:
+		default:
+		}
+	}
+}
+
+// ServeSSE streams channel's broadcasts to w as Server-Sent Events,
+// for clients that can't use a WebSocket connection - for example
+// behind a proxy that strips Upgrade headers. A reconnecting client
+// that sends the Last-Event-ID header is first caught up on anything
+// it missed via ReplaySince before switching to live delivery. It
+// blocks until the request context is cancelled.
+func (m *Manager) ServeSSE(w http.ResponseWriter, r *http.Request, channel string) error {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		return fmt.Errorf("websocket: ResponseWriter does not support flushing")
+	}
+
+	var afterSeq uint64
+	fmt.Sscanf(r.Header.Get("Last-Event-ID"), "%d", &afterSeq)
+
+	// Subscribe before replaying so a message broadcast in between
+	// isn't lost - it'll show up in both, and lastSeq filters the
+	// duplicate out of the live stream below.
+	messages, unsubscribe := m.Subscribe(channel)
+	defer unsubscribe()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+
+	lastSeq := afterSeq
+	for _, msg := range m.ReplaySince(channel, afterSeq) {
+		if err := writeSSEMessage(w, msg); err != nil {
+			return err
+		}
+		lastSeq = msg.Seq
+	}
+	flusher.Flush()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return nil
+		case msg := <-messages:
+			if msg.Seq <= lastSeq {
+				continue
+			}
+			lastSeq = msg.Seq
+			if err := writeSSEMessage(w, msg); err != nil {
+				return err
+			}
+			flusher.Flush()
+		}
+	}
+}
+
+func writeSSEMessage(w http.ResponseWriter, msg Message) error {
+	payload, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "id: %d\nevent: message\ndata: %s\n\n", msg.Seq, payload)
+	return err
+}
+
+// ServeLongPoll responds to a single long-polling request for
+// channel. If messages broadcast after the "since" query parameter
+// are already in the replay buffer, it responds with those
+// immediately; otherwise it waits up to timeout for the next one
+// before responding with an empty JSON array, at which point the
+// client is expected to poll again with the same "since" value.
+func (m *Manager) ServeLongPoll(w http.ResponseWriter, r *http.Request, channel string, timeout time.Duration) {
+	if timeout <= 0 {
+		timeout = DefaultLongPollTimeout
+	}
+
+	var afterSeq uint64
+	fmt.Sscanf(r.URL.Query().Get("since"), "%d", &afterSeq)
+
+	// Subscribe before replaying for the same reason as ServeSSE: it
+	// closes the window where a message broadcast in between would be
+	// missed by both the replay buffer check and the subscription.
+	messages, unsubscribe := m.Subscribe(channel)
+	defer unsubscribe()
+
+	missed := m.ReplaySince(channel, afterSeq)
+	lastSeq := afterSeq
+	for _, msg := range missed {
+		if msg.Seq > lastSeq {
+			lastSeq = msg.Seq
+		}
+	}
+
+	if len(missed) == 0 {
+		select {
+		case msg := <-messages:
+			if msg.Seq > lastSeq {
+				missed = append(missed, msg)
+			}
+		case <-time.After(timeout):
+		case <-r.Context().Done():
+			return
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(missed)
+}
@@ -0,0 +1,160 @@
+package websocket
+
+import (
+	"bufio"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestServeSSEStreamsLiveBroadcasts(t *testing.T) {
+	manager := NewManager()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		manager.ServeSSE(w, r, "room-42")
+	}))
+	defer server.Close()
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Errorf("expected text/event-stream, got %q", ct)
+	}
+
+	reader := bufio.NewReader(resp.Body)
+
+	// Give ServeSSE a moment to subscribe before broadcasting.
+	time.Sleep(50 * time.Millisecond)
+	manager.BroadcastToChannel("room-42", TextMessage, []byte("hello"))
+
+	event := readSSEEvent(t, reader)
+	var msg Message
+	if err := json.Unmarshal([]byte(event), &msg); err != nil {
+		t.Fatalf("failed to decode SSE data: %v", err)
+	}
+	if string(msg.Data) != "hello" {
+		t.Errorf("expected %q, got %q", "hello", msg.Data)
+	}
+}
+
+func TestServeSSEReplaysMissedMessagesFromLastEventID(t *testing.T) {
+	manager := NewManager()
+
+	first := manager.BroadcastToChannel("room-42", TextMessage, []byte("one"))
+	manager.BroadcastToChannel("room-42", TextMessage, []byte("two"))
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		manager.ServeSSE(w, r, "room-42")
+	}))
+	defer server.Close()
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	req.Header.Set("Last-Event-ID", strconv.FormatUint(first, 10))
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	reader := bufio.NewReader(resp.Body)
+	event := readSSEEvent(t, reader)
+	var msg Message
+	if err := json.Unmarshal([]byte(event), &msg); err != nil {
+		t.Fatalf("failed to decode SSE data: %v", err)
+	}
+	if string(msg.Data) != "two" {
+		t.Errorf("expected replay to skip to %q, got %q", "two", msg.Data)
+	}
+}
+
+func TestServeLongPollReturnsImmediatelyWhenMessagesAlreadyBuffered(t *testing.T) {
+	manager := NewManager()
+	manager.BroadcastToChannel("room-42", TextMessage, []byte("hello"))
+
+	req := httptest.NewRequest(http.MethodGet, "/poll?since=0", nil)
+	rec := httptest.NewRecorder()
+
+	manager.ServeLongPoll(rec, req, "room-42", time.Second)
+
+	var messages []Message
+	if err := json.Unmarshal(rec.Body.Bytes(), &messages); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(messages) != 1 || string(messages[0].Data) != "hello" {
+		t.Errorf("expected one buffered message, got %+v", messages)
+	}
+}
+
+func TestServeLongPollWaitsForNewMessage(t *testing.T) {
+	manager := NewManager()
+
+	req := httptest.NewRequest(http.MethodGet, "/poll?since=0", nil)
+	rec := httptest.NewRecorder()
+
+	done := make(chan struct{})
+	go func() {
+		manager.ServeLongPoll(rec, req, "room-42", time.Second)
+		close(done)
+	}()
+
+	time.Sleep(50 * time.Millisecond)
+	manager.BroadcastToChannel("room-42", TextMessage, []byte("hello"))
+
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for ServeLongPoll to return")
+	}
+
+	var messages []Message
+	if err := json.Unmarshal(rec.Body.Bytes(), &messages); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(messages) != 1 || string(messages[0].Data) != "hello" {
+		t.Errorf("expected the message broadcast during the poll, got %+v", messages)
+	}
+}
+
+func TestServeLongPollTimesOutWithEmptyResult(t *testing.T) {
+	manager := NewManager()
+
+	req := httptest.NewRequest(http.MethodGet, "/poll?since=0", nil)
+	rec := httptest.NewRecorder()
+
+	manager.ServeLongPoll(rec, req, "room-42", 50*time.Millisecond)
+
+	var messages []Message
+	if err := json.Unmarshal(rec.Body.Bytes(), &messages); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(messages) != 0 {
+		t.Errorf("expected no messages after timeout, got %+v", messages)
+	}
+}
+
+func readSSEEvent(t *testing.T, reader *bufio.Reader) string {
+	t.Helper()
+	var data string
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			t.Fatalf("failed to read SSE event: %v", err)
+		}
+		line = strings.TrimRight(line, "\r\n")
+		if strings.HasPrefix(line, "data: ") {
+			data = strings.TrimPrefix(line, "data: ")
+		}
+		if line == "" && data != "" {
+			return data
+		}
+	}
+}